@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+var (
+	actorsAppID      string
+	actorsType       string
+	actorsID         string
+	actorsKey        string
+	actorsStateStore string
+	actorsQuery      string
+	actorsQueryFile  string
+	actorsSocket     string
+)
+
+var ActorsCmd = &cobra.Command{
+	Use:   "actors",
+	Short: "Inspect the actors hosted by the running Dapr apps. Supported platforms: Self-hosted",
+}
+
+var ActorsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the actor types registered with the placement service and their active actors per app. Supported platforms: Self-hosted",
+	Example: `
+# List the actor types of all running apps
+dapr actors list
+
+# List the apps hosting an actor type
+dapr actors list --actor-type MyActor
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		actors, err := standalone.NewClient().ListActors(actorsType)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error listing the actors: %s", err)
+			exit(1)
+		}
+		if len(actors) == 0 && print.GetRenderer().Interactive() {
+			print.InfoStatusEvent(os.Stdout, "No actor types are registered by the running apps")
+			return
+		}
+		if err = print.WriteTable(os.Stdout, actors, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+}
+
+var ActorsStateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Read the state of actors. Supported platforms: Self-hosted",
+}
+
+var ActorsStateGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get a key of the state of an actor. Supported platforms: Self-hosted",
+	Example: `
+# Get the key balance of the actor MyActor/1
+dapr actors state get --actor-type MyActor --actor-id 1 --key balance
+
+# Get the key balance of the actor MyActor/1 hosted by the app myapp
+dapr actors state get --app-id myapp --actor-type MyActor --actor-id 1 --key balance
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkUnixDomainSocket(actorsSocket)
+		value, err := standalone.NewClient().GetActorState(actorsAppID, actorsType, actorsID, actorsKey, actorsSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error getting the actor state: %s", err)
+			exit(1)
+		}
+		fmt.Println(value)
+	},
+}
+
+var ActorsStateQueryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Query the state of the actors of a type in the actor state store. Supported platforms: Self-hosted",
+	Long: `Query the state of the actors of a type in the actor state store.
+The actor state store must support the state query API, see https://docs.dapr.io/developing-applications/building-blocks/state-management/state-store-query-api/.
+`,
+	Example: `
+# List the state of all actors of type MyActor
+dapr actors state query --actor-type MyActor
+
+# List the state of the actor MyActor/1 in the state store actorstore
+dapr actors state query --actor-type MyActor --actor-id 1 --state-store actorstore
+
+# List the state of the actors of type MyActor that match a query
+dapr actors state query --actor-type MyActor --query '{"filter":{"EQ":{"status":"active"}}}'
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if actorsQuery != "" && actorsQueryFile != "" {
+			print.FailureStatusEvent(os.Stderr, "Only one of --query and --query-file allowed in the same command")
+			exit(1)
+		}
+		query := []byte(actorsQuery)
+		if actorsQueryFile != "" {
+			var err error
+			query, err = os.ReadFile(actorsQueryFile)
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, "Error reading the query from '%s'. Error: %s", actorsQueryFile, err)
+				exit(1)
+			}
+		}
+		checkUnixDomainSocket(actorsSocket)
+
+		state, err := standalone.NewClient().QueryActorState(actorsAppID, actorsType, actorsID, actorsStateStore, query, actorsSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error querying the actor state: %s", err)
+			exit(1)
+		}
+		if len(state) == 0 && print.GetRenderer().Interactive() {
+			print.InfoStatusEvent(os.Stdout, "No state found for actor type %s", actorsType)
+			return
+		}
+		if err = print.WriteTable(os.Stdout, state, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	ActorsListCmd.Flags().StringVarP(&actorsType, "actor-type", "t", "", "Only list the apps hosting this actor type")
+	ActorsListCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	ActorsCmd.AddCommand(ActorsListCmd)
+
+	for _, c := range []*cobra.Command{ActorsStateGetCmd, ActorsStateQueryCmd} {
+		c.Flags().StringVarP(&actorsAppID, "app-id", "a", "", "The ID of the app hosting the actor type. Required if more than one running app hosts it")
+		c.Flags().StringVarP(&actorsType, "actor-type", "t", "", "The actor type")
+		c.Flags().StringVarP(&actorsSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Dapr API servers will use Unix Domain Sockets")
+		c.Flags().BoolP("help", "h", false, "Print this help message")
+		c.MarkFlagRequired("actor-type")
+		ActorsStateCmd.AddCommand(c)
+	}
+	ActorsStateGetCmd.Flags().StringVarP(&actorsID, "actor-id", "", "", "The actor ID")
+	ActorsStateGetCmd.Flags().StringVarP(&actorsKey, "key", "", "", "The key of the actor state")
+	ActorsStateGetCmd.MarkFlagRequired("actor-id")
+	ActorsStateGetCmd.MarkFlagRequired("key")
+	ActorsStateQueryCmd.Flags().StringVarP(&actorsID, "actor-id", "", "", "Only list the state of this actor ID")
+	ActorsStateQueryCmd.Flags().StringVarP(&actorsStateStore, "state-store", "s", "statestore", "The name of the actor state store component")
+	ActorsStateQueryCmd.Flags().StringVarP(&actorsQuery, "query", "q", "", "The JSON serialized state query. Defaults to a query of all keys")
+	ActorsStateQueryCmd.Flags().StringVarP(&actorsQueryFile, "query-file", "f", "", "A file containing the JSON serialized state query")
+	ActorsCmd.AddCommand(ActorsStateCmd)
+
+	RootCmd.AddCommand(ActorsCmd)
+}
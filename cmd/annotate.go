@@ -26,6 +26,7 @@ import (
 
 	"github.com/dapr/cli/pkg/kubernetes"
 	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/utils"
 )
 
 var (
@@ -70,11 +71,14 @@ var (
 	annotateVolumeMountsReadWrite        string
 	annotateDisableBuiltinK8sSecretStore bool
 	annotatePlacementHostAddress         string
+	annotateFilename                     string
+	annotateInPlace                      bool
+	annotateDryRun                       bool
 )
 
 var AnnotateCmd = &cobra.Command{
-	Use:   "annotate [flags] CONFIG-FILE",
-	Short: "Add dapr annotations to a Kubernetes configuration. Supported platforms: Kubernetes",
+	Use:   "annotate [flags] CONFIG-FILE | KIND/NAME",
+	Short: "Add dapr annotations to a Kubernetes configuration or to a workload in the cluster. Supported platforms: Kubernetes",
 	Example: `
 # Annotate the first deployment found in the input
 kubectl get deploy -l app=node -o yaml | dapr annotate -k - | kubectl apply -f -
@@ -88,6 +92,18 @@ dapr annotate -k -r nodeapp -n namespace mydeploy.yaml | kubectl apply -f -
 # Annotate deployment from url by name
 dapr annotate -k -r nodeapp --log-level debug https://raw.githubusercontent.com/dapr/quickstarts/master/tutorials/hello-kubernetes/deploy/node.yaml | kubectl apply -f -
 
+# Annotate the first deployment in a file and save it in the file
+dapr annotate -k -f deploy.yaml --app-id myapp --app-port 8080 --in-place
+
+# Print the annotated manifest of a deployment in the cluster
+dapr annotate -k deployment/myapp -n namespace --app-id myapp --app-port 8080
+
+# Annotate a deployment in the cluster, which rolls out its pods with the dapr sidecar
+dapr annotate -k deployment/myapp -n namespace --app-id myapp --app-port 8080 --in-place
+
+# Print the patch that would annotate a deployment in the cluster without patching it
+dapr annotate -k deployment/myapp -n namespace --app-id myapp --app-port 8080 --in-place --dry-run
+
 --------------------------------------------------------------------------------
 WARNING: If an app id is not provided, we will generate one using the format '<namespace>-<kind>-<name>'.
 --------------------------------------------------------------------------------
@@ -95,18 +111,31 @@ WARNING: If an app id is not provided, we will generate one using the format '<n
 	Run: func(cmd *cobra.Command, args []string) {
 		if !kubernetesMode {
 			print.FailureStatusEvent(os.Stderr, "annotate command is only supported for Kubernetes, please provide the -k flag")
-			os.Exit(1)
+			exit(1)
+		}
+
+		source := annotateFilename
+		if len(args) > 0 {
+			if source != "" {
+				print.FailureStatusEvent(os.Stderr, "please specify either a Kubernetes resource file with --filename or an argument, not both")
+				exit(1)
+			}
+			source = args[0]
+		}
+		if source == "" {
+			print.FailureStatusEvent(os.Stderr, "please specify a Kubernetes resource file or a workload in the cluster as KIND/NAME")
+			exit(1)
 		}
 
-		if len(args) < 1 {
-			print.FailureStatusEvent(os.Stderr, "please specify a Kubernetes resource file")
-			os.Exit(1)
+		if isLiveResource(source) {
+			annotateLive(source)
+			return
 		}
 
-		input, err := readInput(args[0])
+		input, err := readInput(source)
 		if err != nil {
 			print.FailureStatusEvent(os.Stderr, err.Error())
-			os.Exit(1)
+			exit(1)
 		}
 
 		var config kubernetes.K8sAnnotatorConfig
@@ -123,18 +152,74 @@ WARNING: If an app id is not provided, we will generate one using the format '<n
 				// is invalid as we cannot search for a resource
 				// if the identifier isn't provided.
 				print.FailureStatusEvent(os.Stderr, "--resource is required when --namespace is provided.")
-				os.Exit(1)
+				exit(1)
 			}
 		}
 		annotator := kubernetes.NewK8sAnnotator(config)
 		opts := getOptionsFromFlags()
-		if err := annotator.Annotate(input, os.Stdout, opts); err != nil {
+		if !annotateInPlace {
+			if err := annotator.Annotate(input, os.Stdout, opts); err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			return
+		}
+
+		if info, statErr := os.Stat(source); statErr != nil || info.IsDir() {
+			print.FailureStatusEvent(os.Stderr, "--in-place requires a single Kubernetes resource file or a workload in the cluster")
+			exit(1)
+		}
+		var annotated bytes.Buffer
+		if err := annotator.Annotate(input, &annotated, opts); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		if annotateDryRun {
+			print.DryRunStatusEvent(os.Stdout, "Would write the annotated resource to %s:", source)
+			os.Stdout.Write(annotated.Bytes())
+			return
+		}
+		// #nosec G306
+		if err := os.WriteFile(source, annotated.Bytes(), 0o644); err != nil {
 			print.FailureStatusEvent(os.Stderr, err.Error())
-			os.Exit(1)
+			exit(1)
 		}
+		print.SuccessStatusEvent(os.Stdout, "Annotated %s", source)
 	},
 }
 
+// isLiveResource returns true if source is a workload in the cluster given as KIND/NAME, rather than stdin, a URL or a path.
+func isLiveResource(source string) bool {
+	if source == "-" || isURL(source) {
+		return false
+	}
+	if _, err := os.Stat(source); err == nil {
+		return false
+	}
+	_, _, ok := kubernetes.ParseLiveResource(source)
+	return ok
+}
+
+// annotateLive annotates a workload in the cluster, printing its annotated manifest unless --in-place is set.
+func annotateLive(resource string) {
+	if annotateTargetResource != "" {
+		print.FailureStatusEvent(os.Stderr, "--resource cannot be used with a workload in the cluster")
+		exit(1)
+	}
+	client, err := kubernetes.Client()
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+	if err = kubernetes.AnnotateLive(client, annotateTargetNamespace, resource, getOptionsFromFlags(), annotateInPlace, annotateDryRun, os.Stdout); err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+	if annotateInPlace && !annotateDryRun {
+		print.SuccessStatusEvent(os.Stdout, "Annotated %s. Its pods are restarted with the Dapr sidecar", resource)
+	}
+}
+
 func readInput(arg string) ([]io.Reader, error) {
 	var inputs []io.Reader
 	var err error
@@ -158,7 +243,7 @@ func readInput(arg string) ([]io.Reader, error) {
 }
 
 func readInputsFromURL(url string) ([]io.Reader, error) {
-	resp, err := http.Get(url) // #nosec
+	resp, err := utils.NewHTTPClient(0).Get(url) // #nosec
 	if err != nil {
 		return nil, err
 	}
@@ -355,13 +440,16 @@ func getOptionsFromFlags() kubernetes.AnnotateOptions {
 func init() {
 	AnnotateCmd.Flags().BoolVarP(&kubernetesMode, "kubernetes", "k", false, "Apply annotations to Kubernetes resources")
 	AnnotateCmd.Flags().StringVarP(&annotateTargetResource, "resource", "r", "", "The resource to target to annotate")
-	AnnotateCmd.Flags().StringVarP(&annotateTargetNamespace, "namespace", "n", "", "The namespace the resource target is in (can only be set if --resource is also set)")
+	AnnotateCmd.Flags().StringVarP(&annotateTargetNamespace, "namespace", "n", "", "The namespace the resource target is in (can only be set if --resource is also set), or of the workload in the cluster")
+	AnnotateCmd.Flags().StringVarP(&annotateFilename, "filename", "f", "", "The Kubernetes resource file or directory to annotate, instead of the argument")
+	AnnotateCmd.Flags().BoolVar(&annotateInPlace, "in-place", false, "Save the annotated resource file, or patch the workload in the cluster, instead of printing the annotated YAML")
+	AnnotateCmd.Flags().BoolVar(&annotateDryRun, "dry-run", false, "With --in-place, print the annotated file, or the patch of the workload in the cluster, without saving or applying it")
 	AnnotateCmd.Flags().StringVarP(&annotateAppID, "app-id", "a", "", "The app id to annotate")
 	AnnotateCmd.Flags().IntVarP(&annotateAppPort, "app-port", "p", -1, "The port to expose the app on")
 	AnnotateCmd.Flags().StringVarP(&annotateConfig, "config", "c", "", "The config file to annotate")
 	AnnotateCmd.Flags().StringVar(&annotateAppProtocol, "app-protocol", "", "The protocol to use for the app")
 	AnnotateCmd.Flags().BoolVar(&annotateEnableProfile, "enable-profile", false, "Enable profiling")
-	AnnotateCmd.Flags().StringVar(&annotateLogLevel, "log-level", "", "The log level to use")
+	AnnotateCmd.Flags().StringVar(&annotateLogLevel, "log-level", "", "The log level of the sidecar, instead of the CLI log level")
 	AnnotateCmd.Flags().StringVar(&annotateAPITokenSecret, "api-token-secret", "", "The secret to use for the API token")
 	AnnotateCmd.Flags().StringVar(&annotateAppTokenSecret, "app-token-secret", "", "The secret to use for the app token")
 	AnnotateCmd.Flags().BoolVar(&annotateLogAsJSON, "log-as-json", false, "Log as JSON")
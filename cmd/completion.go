@@ -14,11 +14,24 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
+	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/dapr/cli/pkg/completion"
+	"github.com/dapr/cli/pkg/kubernetes"
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
 )
 
+// appIDCompletionFlags are the flags that take the ID of a running app.
+var appIDCompletionFlags = []string{"app-id", "publish-app-id"}
+
 var completionExample = `
 	# Installing bash completion on macOS using homebrew
 	## If running Bash 3.2 included with macOS
@@ -57,11 +70,20 @@ var completionExample = `
 	# Set the dapr completion code for zsh[1] to autoload on startup
   	dapr completion zsh > "${fpath[1]}/_dapr"
 
+	# Installing fish completion
+	dapr completion fish > ~/.config/fish/completions/dapr.fish
+
 	# Installing powershell completion on Windows
 	## Create $PROFILE if it not exists
 	if (!(Test-Path -Path $PROFILE )){ New-Item -Type File -Path $PROFILE -Force }
 	## Add the completion to your profile
 	dapr completion powershell >> $PROFILE
+
+	# Installing the completion of the current shell, detected from $SHELL, in its profile
+	dapr completion install
+
+	# Installing zsh completion in ~/.zshrc
+	dapr completion install zsh
 `
 
 func newCompletionCmd() *cobra.Command {
@@ -77,7 +99,9 @@ func newCompletionCmd() *cobra.Command {
 	cmd.AddCommand(
 		newCompletionBashCmd(),
 		newCompletionZshCmd(),
+		newCompletionFishCmd(),
 		newCompletionPowerShellCmd(),
+		newCompletionInstallCmd(),
 	)
 
 	cmd.Flags().BoolP("help", "h", false, "Print this help message")
@@ -117,7 +141,60 @@ func newCompletionPowerShellCmd() *cobra.Command {
 		Use:   "powershell",
 		Short: "Generates powershell completion scripts",
 		Run: func(cmd *cobra.Command, args []string) {
-			RootCmd.GenPowerShellCompletion(os.Stdout)
+			RootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		},
+	}
+	cmd.Flags().BoolP("help", "h", false, "Print this help message")
+
+	return cmd
+}
+
+func newCompletionFishCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fish",
+		Short: "Generates fish completion scripts",
+		Run: func(cmd *cobra.Command, args []string) {
+			RootCmd.GenFishCompletion(os.Stdout, true)
+		},
+	}
+	cmd.Flags().BoolP("help", "h", false, "Print this help message")
+
+	return cmd
+}
+
+func newCompletionInstallCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "install [bash|zsh|fish|powershell]",
+		Short:     "Installs the completion script of a shell and loads it from the shell profile",
+		Long:      "Installs the completion script of a shell, detected from the SHELL environment variable if not given, and loads it from the shell profile.\nBash, zsh and PowerShell scripts are written to the ~/.dapr directory and sourced from ~/.bashrc (~/.bash_profile on macOS), ~/.zshrc or $PROFILE. The fish script is written to ~/.config/fish/completions.",
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: completion.Shells,
+		Run: func(cmd *cobra.Command, args []string) {
+			var shell string
+			var err error
+			if len(args) > 0 {
+				shell = args[0]
+			} else if shell, err = completion.DetectShell(); err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+
+			script, err := completionScript(shell)
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			inst, err := completion.Install(shell, script)
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, "Failed to install the %s completion: %s", shell, err)
+				exit(1)
+			}
+			print.SuccessStatusEvent(os.Stdout, "Installed the %s completion script to %s", shell, inst.Script)
+			if inst.ProfileUpdated {
+				print.InfoStatusEvent(os.Stdout, "Added the completion to %s. Start a new shell to use it", inst.Profile)
+			} else if inst.Profile != "" {
+				print.InfoStatusEvent(os.Stdout, "The completion is already loaded by %s", inst.Profile)
+			}
 		},
 	}
 	cmd.Flags().BoolP("help", "h", false, "Print this help message")
@@ -125,6 +202,99 @@ func newCompletionPowerShellCmd() *cobra.Command {
 	return cmd
 }
 
+// completionScript returns the completion script of shell.
+func completionScript(shell string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch shell {
+	case completion.Bash:
+		err = RootCmd.GenBashCompletion(&buf)
+	case completion.Zsh:
+		err = RootCmd.GenZshCompletion(&buf)
+	case completion.Fish:
+		err = RootCmd.GenFishCompletion(&buf, true)
+	case completion.PowerShell:
+		err = RootCmd.GenPowerShellCompletionWithDesc(&buf)
+	default:
+		return nil, fmt.Errorf("unsupported shell %s. Supported shells are: %s", shell, strings.Join(completion.Shells, ", "))
+	}
+	return buf.Bytes(), err
+}
+
 func init() {
 	RootCmd.AddCommand(newCompletionCmd())
 }
+
+// registerCompletions registers the completion of flag values and arguments that depend on live data
+// for cmd and all its subcommands.
+func registerCompletions(cmd *cobra.Command) {
+	// The app ID of these commands names a new app.
+	if cmd != RunCmd && cmd != AnnotateCmd {
+		for _, name := range appIDCompletionFlags {
+			if cmd.Flags().Lookup(name) != nil {
+				cmd.RegisterFlagCompletionFunc(name, completeAppIDs)
+			}
+		}
+	}
+	if cmd.Flags().Lookup("namespace") != nil {
+		cmd.RegisterFlagCompletionFunc("namespace", completeNamespaces)
+	}
+	for _, c := range cmd.Commands() {
+		registerCompletions(c)
+	}
+}
+
+// completeAppIDs completes the IDs of the apps started with `dapr run`,
+// or of the Dapr apps in the cluster if the --kubernetes flag is set.
+func completeAppIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	appIDs := []string{}
+	if kubernetesFlag := cmd.Flags().Lookup("kubernetes"); kubernetesFlag != nil && kubernetesFlag.Value.String() == "true" {
+		namespace := meta_v1.NamespaceAll
+		if namespaceFlag := cmd.Flags().Lookup("namespace"); namespaceFlag != nil && namespaceFlag.Changed {
+			namespace = namespaceFlag.Value.String()
+		}
+		apps, err := kubernetes.List(namespace, "")
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		for _, app := range apps {
+			appIDs = append(appIDs, app.AppID)
+		}
+	} else {
+		apps, err := standalone.List()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		for _, app := range apps {
+			appIDs = append(appIDs, app.AppID)
+		}
+	}
+	return filterCompletions(appIDs, args, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeNamespaces completes the namespaces of the cluster.
+func completeNamespaces(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	namespaces, err := kubernetes.Namespaces()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return filterCompletions(namespaces, nil, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// filterCompletions returns the sorted, unique values that start with toComplete and are not in args.
+func filterCompletions(values []string, args []string, toComplete string) []string {
+	seen := map[string]bool{}
+	for _, arg := range args {
+		seen[arg] = true
+	}
+	completions := []string{}
+	for _, value := range values {
+		if value == "" || seen[value] || !strings.HasPrefix(value, toComplete) {
+			continue
+		}
+		seen[value] = true
+		completions = append(completions, value)
+	}
+	sort.Strings(completions)
+	return completions
+}
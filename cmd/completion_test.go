@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/cli/pkg/completion"
+)
+
+func TestFilterCompletions(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []string
+		args       []string
+		toComplete string
+		expected   []string
+	}{
+		{name: "all values sorted", values: []string{"orders", "checkout"}, expected: []string{"checkout", "orders"}},
+		{name: "prefix", values: []string{"orders", "checkout", "order-processor"}, toComplete: "order", expected: []string{"order-processor", "orders"}},
+		{name: "duplicates and empty values", values: []string{"orders", "", "orders"}, expected: []string{"orders"}},
+		{name: "values given as args", values: []string{"orders", "checkout"}, args: []string{"orders"}, expected: []string{"checkout"}},
+		{name: "no match", values: []string{"orders"}, toComplete: "x", expected: []string{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, filterCompletions(tc.values, tc.args, tc.toComplete))
+		})
+	}
+}
+
+func TestCompletionScript(t *testing.T) {
+	for _, shell := range completion.Shells {
+		t.Run(shell, func(t *testing.T) {
+			script, err := completionScript(shell)
+			require.NoError(t, err)
+			assert.NotEmpty(t, script)
+		})
+	}
+
+	_, err := completionScript("tcsh")
+	assert.ErrorContains(t, err, "unsupported shell tcsh")
+}
+
+func TestRegisterCompletions(t *testing.T) {
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "missing"))
+	root := &cobra.Command{Use: "dapr"}
+	sub := &cobra.Command{Use: "logs", Run: func(cmd *cobra.Command, args []string) {}}
+	sub.Flags().String("namespace", "", "")
+	sub.Flags().String("other", "", "")
+	root.AddCommand(sub)
+	registerCompletions(root)
+
+	complete := func(args ...string) string {
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetArgs(append([]string{cobra.ShellCompRequestCmd}, args...))
+		require.NoError(t, root.Execute())
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		return lines[len(lines)-1]
+	}
+	assert.Equal(t, ":1", complete("logs", "--namespace", ""), "namespaces are completed from the cluster, which is unreachable")
+	assert.Equal(t, ":0", complete("logs", "--other", ""), "other flags have no completion")
+}
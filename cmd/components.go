@@ -14,12 +14,19 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	path_filepath "path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/dapr/cli/pkg/components"
 	"github.com/dapr/cli/pkg/kubernetes"
 	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
 
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -27,6 +34,21 @@ import (
 var (
 	componentsName         string
 	componentsOutputFormat string
+	newComponentsPath      string
+	newComponentStdout     bool
+	newComponentForce      bool
+	validateComponentsPath string
+	validateOutputFormat   string
+	renderComponentsPath   string
+	renderSecretStore      string
+	renderSecretsFile      string
+	renderNestedSeparator  string
+	testAppID              string
+	testSocket             string
+	testResourcesPath      string
+	testTopic              string
+	testSecretKey          string
+	testTimeout            int
 )
 
 var ComponentsCmd = &cobra.Command{
@@ -43,7 +65,7 @@ var ComponentsCmd = &cobra.Command{
 			err := kubernetes.PrintComponents(componentsName, resourceNamespace, componentsOutputFormat)
 			if err != nil {
 				print.FailureStatusEvent(os.Stderr, err.Error())
-				os.Exit(1)
+				exit(1)
 			}
 		}
 	},
@@ -65,7 +87,255 @@ dapr components -k --all-namespaces
 `,
 }
 
+var ComponentsNewCmd = &cobra.Command{
+	Use:   "new <type> <name>",
+	Short: "Generate a component file to edit. Run without arguments to list the supported component types",
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 && len(args) != 2 {
+			return errors.New("requires a component type and a name, or no arguments to list the supported component types")
+		}
+		return nil
+	},
+	Example: `
+# List the component types that can be generated
+dapr components new
+
+# Generate a Redis state store named statestore in the default components directory
+dapr components new state.redis statestore
+
+# Generate a Kafka pub/sub named pubsub in a custom components directory
+dapr components new pubsub.kafka pubsub --components-path ./components
+
+# Print a cron binding instead of writing it to a file
+dapr components new bindings.cron scheduler --stdout
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			type componentTypeOutput struct {
+				Type        string `csv:"TYPE"`
+				Description string `csv:"DESCRIPTION"`
+			}
+			rows := []componentTypeOutput{}
+			for _, c := range components.Types() {
+				rows = append(rows, componentTypeOutput{Type: c.Type, Description: c.Description})
+			}
+			err := print.WriteTable(os.Stdout, rows, false)
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			return
+		}
+
+		componentType, name := args[0], args[1]
+		b, err := components.Scaffold(componentType, name)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+
+		if newComponentStdout {
+			fmt.Print(string(b))
+			return
+		}
+
+		filePath := path_filepath.Join(newComponentsPath, name+".yaml")
+		if _, err = os.Stat(filePath); err == nil && !newComponentForce {
+			print.FailureStatusEvent(os.Stderr, "%s already exists. Use --force to overwrite it", filePath)
+			exit(1)
+		}
+		err = os.MkdirAll(newComponentsPath, 0o755)
+		if err == nil {
+			// #nosec G306
+			err = os.WriteFile(filePath, b, 0o644)
+		}
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error writing component file: %s", err)
+			exit(1)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Component %s of type %s written to %s. Edit its metadata before running your app.", name, componentType, filePath)
+	},
+}
+
+var ComponentsValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate component files against the known component types and their metadata fields",
+	Example: `
+# Validate the components in the default components directory
+dapr components validate
+
+# Validate a single component file
+dapr components validate -f ./components/statestore.yaml
+
+# Validate a components directory and print the issues as JSON
+dapr components validate -f ./components -o json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if validateOutputFormat != "" && validateOutputFormat != "json" {
+			print.FailureStatusEvent(os.Stderr, "Invalid output format %q. Supported format: json", validateOutputFormat)
+			exit(1)
+		}
+
+		issues, err := components.Validate(validateComponentsPath)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error validating components: %s", err)
+			exit(1)
+		}
+
+		errorCount := 0
+		for _, issue := range issues {
+			if issue.Severity == components.SeverityError {
+				errorCount++
+			}
+		}
+
+		if validateOutputFormat == "json" {
+			b, err := json.MarshalIndent(issues, "", "  ")
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			fmt.Println(string(b))
+		} else {
+			for _, issue := range issues {
+				location := issue.File
+				if issue.Component != "" {
+					location += " (" + issue.Component + ")"
+				}
+				if issue.Severity == components.SeverityError {
+					print.FailureStatusEvent(os.Stderr, "%s: %s", location, issue.Message)
+				} else {
+					print.WarningStatusEvent(os.Stdout, "%s: %s", location, issue.Message)
+				}
+			}
+			if len(issues) == 0 {
+				print.SuccessStatusEvent(os.Stdout, "No issues found in %s", validateComponentsPath)
+			} else {
+				print.InfoStatusEvent(os.Stdout, "Found %d error(s) and %d warning(s)", errorCount, len(issues)-errorCount)
+			}
+		}
+
+		if errorCount > 0 {
+			exit(1)
+		}
+	},
+}
+
+var ComponentsRenderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Print component files with the secrets they reference resolved from a local secret store",
+	Example: `
+# Render the components in the default components directory with secrets from environment variables
+dapr components render
+
+# Render a component file with secrets from a JSON secrets file, as used by the local file secret store
+dapr components render -f ./components/statestore.yaml --secret-store file --secrets-file ./secrets.json
+
+# Render a components directory with secrets from the keyring of the operating system
+dapr components render -f ./components --secret-store keyring
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		store, err := components.NewSecretStore(renderSecretStore, renderSecretsFile, renderNestedSeparator)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		b, err := components.Render(renderComponentsPath, store)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error rendering components: %s", err)
+			exit(1)
+		}
+		print.WarningStatusEvent(os.Stderr, "The rendered components contain secrets in plain text. Do not save or share them.")
+		os.Stdout.Write(b)
+	},
+}
+
+var ComponentsTestCmd = &cobra.Command{
+	Use:   "test <name>",
+	Short: "Test the connectivity of a component with a round trip through a sidecar. Supported platforms: Self-hosted",
+	Long: `Test the connectivity of a component with a round trip through a sidecar, and print the latency of each step.
+
+State stores are tested with a set, get and delete of a temporary key, pub/sub components with a publish to a test
+topic, and secret stores with a read of a secret or of all secrets. The delivery of published events is not checked.
+
+By default, a temporary sidecar is started with the resources of --resources-path, so components that fail to
+initialize, for example because of bad credentials, are reported with the errors of the sidecar. Use --app-id to
+test the component through the sidecar of a running app instead.
+`,
+	Example: `
+# Test the state store statestore of the default components directory
+dapr components test statestore
+
+# Test the pub/sub component pubsub of a resources directory, publishing to the topic orders-test
+dapr components test pubsub --resources-path ./resources --topic orders-test
+
+# Test reading the secret db-password of the secret store secretstore through the sidecar of a running app
+dapr components test secretstore --app-id orders --secret-key db-password
+`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if testAppID != "" && cmd.Flags().Changed("resources-path") {
+			print.FailureStatusEvent(os.Stderr, "The --app-id and --resources-path flags cannot be used together")
+			exit(1)
+		}
+		checkUnixDomainSocket(testSocket)
+		if testAppID == "" {
+			print.InfoStatusEvent(os.Stdout, "Starting a temporary sidecar with the resources of %s", testResourcesPath)
+		}
+		results, err := standalone.NewClient().TestComponent(args[0], standalone.ComponentTestOptions{
+			AppID:         testAppID,
+			Socket:        testSocket,
+			ResourcesPath: testResourcesPath,
+			Topic:         testTopic,
+			SecretKey:     testSecretKey,
+			Timeout:       time.Duration(testTimeout) * time.Second,
+		})
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error testing component %s: %s", args[0], err)
+			exit(1)
+		}
+		if err = print.WriteTable(os.Stdout, results, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		for _, result := range results {
+			if result.Error != "" {
+				print.FailureStatusEvent(os.Stderr, "Component %s failed the test", args[0])
+				exit(1)
+			}
+		}
+		print.SuccessStatusEvent(os.Stdout, "Component %s passed the test", args[0])
+	},
+}
+
 func init() {
+	ComponentsTestCmd.Flags().StringVarP(&testAppID, "app-id", "a", "", "The ID of a running app whose sidecar tests the component. Defaults to a temporary sidecar")
+	ComponentsTestCmd.Flags().StringVarP(&testSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Dapr API servers will use Unix Domain Sockets")
+	ComponentsTestCmd.Flags().StringVarP(&testResourcesPath, "resources-path", "", standalone.DefaultComponentsDirPath(), "The resources directory of the temporary sidecar")
+	ComponentsTestCmd.Flags().StringVarP(&testTopic, "topic", "", standalone.DefaultComponentTestTopic, "The topic to publish to when testing a pub/sub component")
+	ComponentsTestCmd.Flags().StringVarP(&testSecretKey, "secret-key", "", "", "The secret to read when testing a secret store. Defaults to reading all secrets")
+	ComponentsTestCmd.Flags().IntVarP(&testTimeout, "timeout", "", int(standalone.DefaultComponentTestTimeout.Seconds()), "The number of seconds to wait for the temporary sidecar to load the components")
+	ComponentsTestCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	ComponentsCmd.AddCommand(ComponentsTestCmd)
+
+	ComponentsValidateCmd.Flags().StringVarP(&validateComponentsPath, "file", "f", standalone.DefaultComponentsDirPath(), "The component file or components directory to validate")
+	ComponentsValidateCmd.Flags().StringVarP(&validateOutputFormat, "output", "o", "", "The output format of the issues (options: json)")
+	ComponentsValidateCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	ComponentsCmd.AddCommand(ComponentsValidateCmd)
+
+	ComponentsRenderCmd.Flags().StringVarP(&renderComponentsPath, "file", "f", standalone.DefaultComponentsDirPath(), "The component file or components directory to render")
+	ComponentsRenderCmd.Flags().StringVarP(&renderSecretStore, "secret-store", "", components.EnvSecretStore, "The local secret store to resolve secrets from. Valid values are: env, file, keyring")
+	ComponentsRenderCmd.Flags().StringVarP(&renderSecretsFile, "secrets-file", "", "", "The JSON file to resolve secrets from with the file secret store")
+	ComponentsRenderCmd.Flags().StringVarP(&renderNestedSeparator, "nested-separator", "", components.DefaultNestedSeparator, "The separator of nested keys in the secrets file")
+	ComponentsRenderCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	ComponentsCmd.AddCommand(ComponentsRenderCmd)
+
+	ComponentsNewCmd.Flags().StringVarP(&newComponentsPath, "components-path", "d", standalone.DefaultComponentsDirPath(), "The path for components directory to write the component file to")
+	ComponentsNewCmd.Flags().BoolVar(&newComponentStdout, "stdout", false, "Print the component instead of writing it to a file")
+	ComponentsNewCmd.Flags().BoolVar(&newComponentForce, "force", false, "Overwrite an existing component file")
+	ComponentsNewCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	ComponentsCmd.AddCommand(ComponentsNewCmd)
+
 	ComponentsCmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "If true, list all Dapr components in all namespaces")
 	ComponentsCmd.Flags().StringVarP(&componentsName, "name", "n", "", "The components name to be printed (optional)")
 	ComponentsCmd.Flags().StringVarP(&resourceNamespace, "namespace", "", "", "List all namespace components in a Kubernetes cluster")
@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+var (
+	configurationAppID     string
+	configurationStore     string
+	configurationKeys      []string
+	configurationSubscribe bool
+	configurationSocket    string
+)
+
+var ConfigurationCmd = &cobra.Command{
+	Use:   "configuration",
+	Short: "Read the items of a configuration store through a running sidecar. Supported platforms: Self-hosted",
+}
+
+var ConfigurationGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get the items of a configuration store, or watch their changes with --subscribe. Supported platforms: Self-hosted",
+	Example: `
+# Get the items of keys in a configuration store
+dapr configuration get --store configstore --key orderLimit,discount
+
+# Get the items through the sidecar of an app, if more than one app is running
+dapr configuration get --app-id myapp --store configstore --key orderLimit
+
+# Print the items, then every change of them until interrupted
+dapr configuration get --store configstore --key orderLimit --subscribe
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkUnixDomainSocket(configurationSocket)
+
+		client := standalone.NewClient()
+		items, err := client.GetConfiguration(configurationAppID, configurationStore, configurationKeys, nil, configurationSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		printConfigurationItems(items)
+		if !configurationSubscribe {
+			return
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+		print.InfoStatusEvent(os.Stdout, "Watching the configuration of store %s. Press Ctrl+C to stop", configurationStore)
+		err = client.SubscribeConfiguration(ctx, configurationAppID, configurationStore, configurationKeys, nil, configurationSocket, printConfigurationItems)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+}
+
+func printConfigurationItems(items []standalone.ConfigurationItemOutput) {
+	if err := print.WriteTable(os.Stdout, items, false); err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+}
+
+func init() {
+	ConfigurationGetCmd.Flags().StringVarP(&configurationAppID, "app-id", "a", "", "The ID of the app whose sidecar is used. Required if more than one app is running")
+	ConfigurationGetCmd.Flags().StringVarP(&configurationStore, "store", "s", "", "The name of the configuration store component")
+	ConfigurationGetCmd.Flags().StringSliceVarP(&configurationKeys, "key", "", []string{}, "The keys of the items to get, as a comma-separated list. Gets all items if the store supports it when not set")
+	ConfigurationGetCmd.Flags().BoolVarP(&configurationSubscribe, "subscribe", "", false, "Print every change of the items until interrupted")
+	ConfigurationGetCmd.Flags().StringVarP(&configurationSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Dapr API servers will use Unix Domain Sockets")
+	ConfigurationGetCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	ConfigurationGetCmd.MarkFlagRequired("store")
+	ConfigurationCmd.AddCommand(ConfigurationGetCmd)
+
+	RootCmd.AddCommand(ConfigurationCmd)
+}
@@ -14,6 +14,7 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -27,6 +28,9 @@ import (
 var (
 	configurationName         string
 	configurationOutputFormat string
+	configurationFile         string
+	configurationDryRun       bool
+	configurationGetFormat    string
 )
 
 var ConfigurationsCmd = &cobra.Command{
@@ -43,7 +47,7 @@ var ConfigurationsCmd = &cobra.Command{
 			err := kubernetes.PrintConfigurations(configurationName, resourceNamespace, configurationOutputFormat)
 			if err != nil {
 				print.FailureStatusEvent(os.Stderr, err.Error())
-				os.Exit(1)
+				exit(1)
 			}
 		}
 	},
@@ -65,7 +69,105 @@ dapr configurations -k --all-namespaces
 `,
 }
 
+var ConfigurationsApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create or update Dapr configurations from a file, validated against the Configuration CRD. Supported platforms: Kubernetes",
+	Example: `
+# Apply the configurations of a file in Kubernetes mode
+dapr configurations apply -k -f ./tracing.yaml
+
+# Apply the configurations of a file to a specific namespace
+dapr configurations apply -k -f ./tracing.yaml --namespace apps
+
+# Validate the configurations of a file and print what would change, without applying them
+dapr configurations apply -k -f ./tracing.yaml --dry-run
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		results, err := kubernetes.ApplyConfigurations(configurationFile, resourceNamespace, configurationDryRun)
+		var validationErr *kubernetes.ConfigurationValidationError
+		if errors.As(err, &validationErr) {
+			for _, problem := range validationErr.Problems {
+				print.FailureStatusEvent(os.Stderr, problem)
+			}
+			exit(1)
+		}
+		suffix := ""
+		if configurationDryRun {
+			suffix = " (dry run)"
+		}
+		for _, r := range results {
+			print.SuccessStatusEvent(os.Stdout, "Configuration %s/%s %s%s", r.Namespace, r.Name, r.Result, suffix)
+		}
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+}
+
+var ConfigurationsDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a Dapr configuration. Supported platforms: Kubernetes",
+	Args:  cobra.ExactArgs(1),
+	Example: `
+# Delete the configuration tracing in the default namespace in Kubernetes mode
+dapr configurations delete -k tracing
+
+# Delete the configuration tracing in a specific namespace
+dapr configurations delete -k tracing --namespace apps
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		namespace := configurationNamespace()
+		if err := kubernetes.DeleteConfiguration(args[0], namespace); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Configuration %s/%s deleted", namespace, args[0])
+	},
+}
+
+var ConfigurationsGetCmd = &cobra.Command{
+	Use:   "get <name>",
+	Short: "Print the manifest of a Dapr configuration, ready to be edited and applied. Supported platforms: Kubernetes",
+	Args:  cobra.ExactArgs(1),
+	Example: `
+# Print the configuration tracing in the default namespace in Kubernetes mode
+dapr configurations get -k tracing
+
+# Print the configuration tracing in a specific namespace as JSON
+dapr configurations get -k tracing --namespace apps -o json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := kubernetes.WriteConfiguration(os.Stdout, args[0], configurationNamespace(), configurationGetFormat); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+}
+
+// configurationNamespace returns the namespace of a single configuration: --namespace, or the default namespace.
+func configurationNamespace() string {
+	if resourceNamespace == "" {
+		return meta_v1.NamespaceDefault
+	}
+	return resourceNamespace
+}
+
 func init() {
+	ConfigurationsApplyCmd.Flags().StringVarP(&configurationFile, "file", "f", "", "The file with the configurations to apply, or - to read them from stdin")
+	ConfigurationsApplyCmd.Flags().BoolVar(&configurationDryRun, "dry-run", false, "Validate the configurations and print whether they would be created or updated, without applying them")
+	ConfigurationsApplyCmd.MarkFlagRequired("file")
+	ConfigurationsApplyCmd.Flags().StringVarP(&resourceNamespace, "namespace", "", "", "The namespace to apply the configurations to. Defaults to the namespace in the file, or default")
+	ConfigurationsDeleteCmd.Flags().StringVarP(&resourceNamespace, "namespace", "", "", "The namespace of the configuration (default \"default\")")
+	ConfigurationsGetCmd.Flags().StringVarP(&resourceNamespace, "namespace", "", "", "The namespace of the configuration (default \"default\")")
+	ConfigurationsGetCmd.Flags().StringVarP(&configurationGetFormat, "output", "o", "yaml", "Output format (options: json or yaml)")
+	for _, c := range []*cobra.Command{ConfigurationsApplyCmd, ConfigurationsDeleteCmd, ConfigurationsGetCmd} {
+		c.Flags().BoolVarP(&kubernetesMode, "kubernetes", "k", false, "Manage the Dapr configurations of a Kubernetes cluster")
+		c.Flags().BoolP("help", "h", false, "Print this help message")
+		c.MarkFlagRequired("kubernetes")
+		ConfigurationsCmd.AddCommand(c)
+	}
+
 	ConfigurationsCmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "If true, list all Dapr configurations in all namespaces")
 	ConfigurationsCmd.Flags().StringVarP(&configurationName, "name", "n", "", "The configuration name to be printed (optional)")
 	ConfigurationsCmd.Flags().StringVarP(&resourceNamespace, "namespace", "", "", "List Define namespace configurations in a Kubernetes cluster")
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	path_filepath "path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/kubernetes"
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+const (
+	convertToCompose    = "compose"
+	convertToKubernetes = "k8s"
+)
+
+var (
+	convertRunFile        string
+	convertTo             string
+	convertOut            string
+	convertRuntimeVersion string
+	convertImageRegistry  string
+	convertNamespace      string
+)
+
+var ConvertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert a run file to a Docker Compose file or Kubernetes manifests",
+	Long: `Convert a run file of dapr run -f to a Docker Compose file or to Kubernetes Deployments.
+
+With --to compose, each app is built from the Dockerfile of its directory and gets a sidecar service that shares its
+network, with the resources and configuration of the app mounted, and a placement service is added.
+
+With --to k8s, each app gets a Deployment with the dapr.io annotations that inject its sidecar. The image of each app
+is <image-registry>/<app-id>:latest. Components and configurations must be applied to the cluster separately.
+
+The commands of the apps in the run file are not converted, as the image of each app is expected to start it.
+`,
+	Example: `
+# Generate a Docker Compose file next to the run file
+dapr convert -f dapr.yaml --to compose -o docker-compose.yaml
+
+# Generate Kubernetes Deployments with the images of a registry, and apply them
+dapr convert -f dapr.yaml --to k8s --image-registry myregistry.io/orders -n orders | kubectl apply -f -
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		apps, err := standalone.ParseRunFile(convertRunFile)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+
+		var out bytes.Buffer
+		switch convertTo {
+		case convertToCompose:
+			err = convertCompose(apps, &out)
+		case convertToKubernetes:
+			err = kubernetes.GenerateDeployments(deploymentApps(apps), convertNamespace, &out)
+		default:
+			err = fmt.Errorf("invalid value %q for --to, valid values are: %s, %s", convertTo, convertToCompose, convertToKubernetes)
+		}
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+
+		if convertOut == "" {
+			os.Stdout.Write(out.Bytes())
+			return
+		}
+		if err = os.WriteFile(convertOut, out.Bytes(), 0o644); err != nil { //nolint:gosec
+			print.FailureStatusEvent(os.Stderr, "Error writing %s: %s", convertOut, err)
+			exit(1)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Converted %d apps of %s to %s", len(apps), convertRunFile, convertOut)
+	},
+}
+
+// convertCompose writes the Compose file of apps. Its paths are relative to the directory of the output file,
+// or of the run file if the Compose file is written to stdout.
+func convertCompose(apps []standalone.RunConfig, out *bytes.Buffer) error {
+	baseDir := path_filepath.Dir(convertRunFile)
+	if convertOut != "" {
+		baseDir = path_filepath.Dir(convertOut)
+	}
+	baseDir, err := path_filepath.Abs(baseDir)
+	if err != nil {
+		return err
+	}
+
+	runtimeVersion := convertRuntimeVersion
+	if runtimeVersion == "" {
+		runtimeVersion = strings.TrimSpace(standalone.GetRuntimeVersion())
+		if runtimeVersion == "n/a" || runtimeVersion == "" {
+			runtimeVersion = "latest"
+		}
+	}
+	return standalone.GenerateCompose(apps, runtimeVersion, baseDir, out)
+}
+
+// deploymentApps returns the Deployments of the apps of a run file, with the annotations of their sidecar flags.
+func deploymentApps(apps []standalone.RunConfig) []kubernetes.DeploymentApp {
+	deployments := make([]kubernetes.DeploymentApp, 0, len(apps))
+	for _, app := range apps {
+		image := app.AppID + ":latest"
+		if convertImageRegistry != "" {
+			image = strings.TrimSuffix(convertImageRegistry, "/") + "/" + image
+		}
+
+		opts := []kubernetes.AnnoteOption{}
+		if app.AppPort > 0 {
+			opts = append(opts, kubernetes.WithAppPort(app.AppPort))
+		}
+		if app.Protocol != "" {
+			opts = append(opts, kubernetes.WithAppProtocol(app.Protocol))
+		}
+		if app.LogLevel != "" {
+			opts = append(opts, kubernetes.WithLogLevel(app.LogLevel))
+		}
+		if app.MetricsPort > 0 {
+			opts = append(opts, kubernetes.WithMetricsEnabled(), kubernetes.WithMetricsPort(app.MetricsPort))
+		}
+		if app.MaxConcurrency > 0 {
+			opts = append(opts, kubernetes.WithAppMaxConcurrency(app.MaxConcurrency))
+		}
+		if app.MaxRequestBodySize > 0 {
+			opts = append(opts, kubernetes.WithMaxRequestBodySize(app.MaxRequestBodySize))
+		}
+		if app.HTTPReadBufferSize > 0 {
+			opts = append(opts, kubernetes.WithReadBufferSize(app.HTTPReadBufferSize))
+		}
+		if app.EnableProfiling {
+			opts = append(opts, kubernetes.WithProfileEnabled())
+		}
+		if app.AppSSL {
+			opts = append(opts, kubernetes.WithAppSSL())
+		}
+		if app.EnableAPILogging {
+			opts = append(opts, kubernetes.WithEnableAPILogging())
+		}
+
+		deployments = append(deployments, kubernetes.DeploymentApp{
+			AppID:       app.AppID,
+			Image:       image,
+			AppPort:     app.AppPort,
+			Env:         app.Env,
+			Annotations: kubernetes.NewAnnotateOptions(opts...),
+		})
+	}
+	return deployments
+}
+
+func init() {
+	ConvertCmd.Flags().StringVarP(&convertRunFile, "run-file", "f", "", "The run file to convert")
+	ConvertCmd.Flags().StringVar(&convertTo, "to", "", "The format to convert to. Valid values are: compose, k8s")
+	ConvertCmd.Flags().StringVarP(&convertOut, "out", "o", "", "The file to write to. Defaults to stdout")
+	ConvertCmd.Flags().StringVar(&convertRuntimeVersion, "runtime-version", "", "The version of the Dapr images of a Compose file. Defaults to the installed runtime version")
+	ConvertCmd.Flags().StringVar(&convertImageRegistry, "image-registry", "", "The registry of the images of the apps in Kubernetes Deployments")
+	ConvertCmd.Flags().StringVarP(&convertNamespace, "namespace", "n", "", "The namespace of the Kubernetes Deployments. Defaults to the namespace of kubectl apply")
+	ConvertCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	ConvertCmd.MarkFlagRequired("run-file")
+	ConvertCmd.MarkFlagRequired("to")
+	RootCmd.AddCommand(ConvertCmd)
+}
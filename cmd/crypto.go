@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+var (
+	cryptoAppID      string
+	cryptoComponent  string
+	cryptoData       string
+	cryptoDataFile   string
+	cryptoOutputFile string
+	cryptoKeyName    string
+	cryptoSocket     string
+	cryptoEncryptOpt standalone.EncryptOptions
+)
+
+var CryptoCmd = &cobra.Command{
+	Use:   "crypto",
+	Short: "Encrypt and decrypt data with a cryptography component through a running sidecar. Supported platforms: Self-hosted",
+}
+
+var CryptoEncryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Encrypt data with a key of a cryptography component. Supported platforms: Self-hosted",
+	Example: `
+# Encrypt a file with an RSA key of a cryptography component
+dapr crypto encrypt --component localstorage --key-name mykey --algorithm RSA-OAEP-256 --data-file secret.txt --output-file secret.enc
+
+# Encrypt stdin with an AES key and print the encrypted data
+echo "secret" | dapr crypto encrypt --component localstorage --key-name symmetric --algorithm AES --data-file - > secret.enc
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		data := cryptoInput()
+		checkUnixDomainSocket(cryptoSocket)
+		cryptoEncryptOpt.KeyName = cryptoKeyName
+		encrypted, err := standalone.NewClient().Encrypt(cryptoAppID, cryptoComponent, data, cryptoEncryptOpt, cryptoSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error encrypting the data: %s", err)
+			exit(1)
+		}
+		writeCryptoOutput(encrypted, "Encrypted")
+	},
+}
+
+var CryptoDecryptCmd = &cobra.Command{
+	Use:   "decrypt",
+	Short: "Decrypt data encrypted with a key of a cryptography component. Supported platforms: Self-hosted",
+	Example: `
+# Decrypt a file and print the decrypted data
+dapr crypto decrypt --component localstorage --data-file secret.enc
+
+# Decrypt a file encrypted without the name of its key
+dapr crypto decrypt --component localstorage --key-name mykey --data-file secret.enc --output-file secret.txt
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		data := cryptoInput()
+		checkUnixDomainSocket(cryptoSocket)
+		decrypted, err := standalone.NewClient().Decrypt(cryptoAppID, cryptoComponent, data, cryptoKeyName, cryptoSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error decrypting the data: %s", err)
+			exit(1)
+		}
+		writeCryptoOutput(decrypted, "Decrypted")
+	},
+}
+
+// cryptoInput returns the data given by --data or --data-file.
+func cryptoInput() []byte {
+	if cryptoData != "" && cryptoDataFile != "" {
+		print.FailureStatusEvent(os.Stderr, "Only one of --data and --data-file allowed in the same command")
+		exit(1)
+	}
+	if cryptoDataFile == "" {
+		return []byte(cryptoData)
+	}
+	data, err := readInputFile(cryptoDataFile)
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, "Error reading the data from '%s'. Error: %s", cryptoDataFile, err)
+		exit(1)
+	}
+	return data
+}
+
+// writeCryptoOutput writes data to the file given by --output-file, or else to stdout as is.
+func writeCryptoOutput(data []byte, operation string) {
+	if cryptoOutputFile == "" {
+		os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(cryptoOutputFile, data, 0o600); err != nil {
+		print.FailureStatusEvent(os.Stderr, "Error writing to %s: %s", cryptoOutputFile, err)
+		exit(1)
+	}
+	print.SuccessStatusEvent(os.Stdout, "%s %d bytes to %s", operation, len(data), cryptoOutputFile)
+}
+
+func init() {
+	for _, c := range []*cobra.Command{CryptoEncryptCmd, CryptoDecryptCmd} {
+		c.Flags().StringVarP(&cryptoAppID, "app-id", "a", "", "The ID of the app whose sidecar is used. Required if more than one app is running")
+		c.Flags().StringVarP(&cryptoComponent, "component", "c", "", "The name of the cryptography component")
+		c.Flags().StringVarP(&cryptoData, "data", "d", "", "The data to process")
+		c.Flags().StringVarP(&cryptoDataFile, "data-file", "f", "", "A file containing the data to process, or - to read it from stdin")
+		c.Flags().StringVarP(&cryptoOutputFile, "output-file", "", "", "Write the result to this file instead of stdout")
+		c.Flags().StringVarP(&cryptoSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Dapr API servers will use Unix Domain Sockets")
+		c.Flags().BoolP("help", "h", false, "Print this help message")
+		c.MarkFlagRequired("component")
+		CryptoCmd.AddCommand(c)
+	}
+	CryptoEncryptCmd.Flags().StringVarP(&cryptoKeyName, "key-name", "k", "", "The name of the key to encrypt with")
+	CryptoEncryptCmd.Flags().StringVar(&cryptoEncryptOpt.KeyWrapAlgorithm, "algorithm", "", "The algorithm that wraps the data encryption key, for example: RSA-OAEP-256, AES or A256KW")
+	CryptoEncryptCmd.Flags().StringVar(&cryptoEncryptOpt.DataEncryptionCipher, "cipher", "", "The cipher of the data: aes-gcm or chacha20-poly1305. Chosen by the sidecar if not given")
+	CryptoEncryptCmd.Flags().StringVar(&cryptoEncryptOpt.DecryptionKeyName, "decryption-key-name", "", "The name of the key to decrypt with, if it differs from --key-name")
+	CryptoEncryptCmd.Flags().BoolVar(&cryptoEncryptOpt.OmitDecryptionKeyName, "omit-key-name", false, "Leave the name of the key out of the encrypted data, so it must be given to decrypt")
+	CryptoEncryptCmd.MarkFlagRequired("key-name")
+	CryptoEncryptCmd.MarkFlagRequired("algorithm")
+	CryptoDecryptCmd.Flags().StringVarP(&cryptoKeyName, "key-name", "k", "", "The name of the key to decrypt with. Required if it was omitted from the encrypted data")
+
+	RootCmd.AddCommand(CryptoCmd)
+}
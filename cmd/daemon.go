@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/daemon"
+	"github.com/dapr/cli/pkg/print"
+)
+
+// daemonShutdownTimeout is the time to wait for the requests in progress, such as followed logs, when the daemon stops.
+const daemonShutdownTimeout = 5 * time.Second
+
+var daemonSocket string
+
+var DaemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Serve a local HTTP API to list, run and stop apps and read their logs. Supported platforms: Self-hosted",
+	Long: `Serve a local HTTP API on a unix socket, so that IDE extensions and other tools can manage self-hosted apps
+without running the CLI and parsing its output. Responses are JSON, except for logs, which are plain text:
+
+  GET    /v1/apps                 list the running apps, like dapr list -o json
+  POST   /v1/apps                 run an app, with a body like {"appId": "orders", "appPort": 3000, "command": ["node", "app.js"]}
+  DELETE /v1/apps/{appId}         stop an app, waiting for ?timeout=10s before killing it
+  GET    /v1/apps/{appId}/logs    get the logs of an app, with ?tail=<lines> and ?follow=true
+
+Apps run by the daemon keep running when it stops.`,
+	Example: `
+# Serve the API on ~/.dapr/daemon.sock
+dapr daemon
+
+# List the running apps through the API
+curl --unix-socket ~/.dapr/daemon.sock http://localhost/v1/apps
+
+# Run an app through the API
+curl --unix-socket ~/.dapr/daemon.sock -X POST http://localhost/v1/apps -d '{"appId": "orders", "command": ["python", "app.py"], "dir": "/src/orders"}'
+`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		socketPath := daemonSocket
+		if socketPath == "" {
+			var err error
+			socketPath, err = daemon.DefaultSocketPath()
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+		}
+
+		executable, err := os.Executable()
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Cannot find the path of the CLI: %s", err)
+			exit(1)
+		}
+
+		listener, err := daemon.Listen(socketPath)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+
+		server := &http.Server{Handler: daemon.NewServer(executable), ReadHeaderTimeout: 10 * time.Second}
+		serveErr := make(chan error, 1)
+		go func() {
+			serveErr <- server.Serve(listener)
+		}()
+		print.InfoStatusEvent(os.Stdout, "Serving the Dapr CLI API on %s", socketPath)
+
+		sigCh := make(chan os.Signal, 1)
+		setupShutdownNotify(sigCh)
+		select {
+		case err = <-serveErr:
+			print.FailureStatusEvent(os.Stderr, "Error serving the Dapr CLI API: %s", err)
+			exit(1)
+		case <-sigCh:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), daemonShutdownTimeout)
+		defer cancel()
+		err = server.Shutdown(ctx)
+		if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			print.WarningStatusEvent(os.Stdout, "Error stopping the Dapr CLI API: %s", err)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Stopped the Dapr CLI API")
+	},
+}
+
+func init() {
+	DaemonCmd.Flags().StringVar(&daemonSocket, "socket", "", "The path of the unix socket to serve the API on. Defaults to daemon.sock in the Dapr directory of the user")
+	DaemonCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	RootCmd.AddCommand(DaemonCmd)
+}
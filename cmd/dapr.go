@@ -16,14 +16,17 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"runtime"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/dapr/cli/pkg/api"
+	"github.com/dapr/cli/pkg/kubernetes"
 	"github.com/dapr/cli/pkg/print"
 	"github.com/dapr/cli/pkg/standalone"
+	"github.com/dapr/cli/pkg/version"
 )
 
 var RootCmd = &cobra.Command{
@@ -39,16 +42,37 @@ var RootCmd = &cobra.Command{
 									   
 ===============================
 Distributed Application Runtime`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		appID := ""
+		if flag := cmd.Flags().Lookup("app-id"); flag != nil {
+			appID = flag.Value.String()
+		}
+		print.SetCommandContext(cmd.CommandPath(), appID)
+		startInvocation(cmd)
+	},
 }
 
 type daprVersion struct {
-	CliVersion     string `json:"Cli version"`
-	RuntimeVersion string `json:"Runtime version"`
+	CliVersion           string `json:"Cli version"`
+	RuntimeVersion       string `json:"Runtime version"`
+	DashboardVersion     string `json:"Dashboard version,omitempty"`
+	GitCommit            string `json:"Git commit,omitempty"`
+	LatestCliVersion     string `json:"Latest Cli version,omitempty"`
+	LatestRuntimeVersion string `json:"Latest Runtime version,omitempty"`
+
+	BuildInfo *standalone.BuildInfo `json:"Build info,omitempty"`
 }
 
 var (
-	daprVer   daprVersion
-	logAsJSON bool
+	daprVer         daprVersion
+	logAsJSON       bool
+	cliOutputFormat string
+	noColor         bool
+	cliLogLevel     string
+	verbose         bool
+	jsonSchema      int
+	dockerHost      string
+	installPath     string
 )
 
 // Execute adds all child commands to the root command.
@@ -63,12 +87,22 @@ func Execute(version, apiVersion string) {
 
 	cobra.OnInitialize(initConfig)
 
+	for _, cmd := range RootCmd.Commands() {
+		addVerboseFlag(cmd)
+	}
+	registerCompletions(RootCmd)
+
 	setVersion()
 
+	if code, ok := runPlugin(os.Args[1:]); ok {
+		exit(code)
+	}
 	if err := RootCmd.Execute(); err != nil {
+		endInvocation("failure", err.Error())
 		fmt.Println(err)
-		os.Exit(-1)
+		exit(-1)
 	}
+	endInvocation("success", "")
 }
 
 func setVersion() {
@@ -77,15 +111,98 @@ func setVersion() {
 }
 
 func initConfig() {
+	if err := print.SetOutputFormat(cliOutputFormat); err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
 	if logAsJSON {
 		print.EnableJSONFormat()
 	}
+	if err := print.SetJSONSchemaVersion(jsonSchema); err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+
+	if noColor {
+		print.DisableColor()
+	}
+
+	if verbose {
+		print.SetLogLevel(print.DebugLevel)
+	} else {
+		level, err := print.ParseLogLevel(cliLogLevel)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		print.SetLogLevel(level)
+	}
+
+	if dockerHost != "" {
+		if err := standalone.SetDockerHost(dockerHost); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	}
+
+	if installPath == "" {
+		installPath = os.Getenv("DAPR_INSTALL_PATH")
+	}
+	if installPath != "" {
+		if err := standalone.SetInstallPath(installPath); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	}
+
+	version.SetCacheFile(standalone.DefaultVersionCacheFilePath())
 
 	viper.SetEnvPrefix("dapr")
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	viper.AutomaticEnv()
+
+	if viper.IsSet("cert-expiry-warn-days") {
+		kubernetes.SetCertExpiryWarningDays(viper.GetInt("cert-expiry-warn-days"))
+	}
+}
+
+// addVerboseFlag adds the --verbose flag to cmd and all its subcommands.
+// The flag is not persistent because -v is already used as a shorthand by some commands,
+// such as `dapr invoke`, so it is only offered as a shorthand where it is available.
+func addVerboseFlag(cmd *cobra.Command) {
+	if cmd.Flags().Lookup("verbose") == nil {
+		if cmd.Flags().ShorthandLookup("v") == nil {
+			cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Print debug output. Shortcut for --log-level debug")
+		} else {
+			cmd.Flags().BoolVar(&verbose, "verbose", false, "Print debug output. Shortcut for --log-level debug")
+		}
+	}
+	for _, c := range cmd.Commands() {
+		addVerboseFlag(c)
+	}
+}
+
+// checkUnixDomainSocket exits on Windows if a unix domain socket is given, and warns that they are a preview feature otherwise.
+func checkUnixDomainSocket(socket string) {
+	// TODO(@daixiang0): add Windows support.
+	if socket == "" {
+		return
+	}
+	if runtime.GOOS == "windows" {
+		print.FailureStatusEvent(os.Stderr, "The unix-domain-socket option is not supported on Windows")
+		exit(1)
+	}
+	print.WarningStatusEvent(os.Stdout, "Unix domain sockets are currently a preview feature")
 }
 
 func init() {
-	RootCmd.PersistentFlags().BoolVarP(&logAsJSON, "log-as-json", "", false, "Log output in JSON format")
+	RootCmd.PersistentFlags().BoolVarP(&logAsJSON, "log-as-json", "", false, "Log output in JSON format. Shortcut for --output-format json")
+	RootCmd.PersistentFlags().StringVarP(&cliOutputFormat, "output-format", "", print.TextFormat, "The format of status messages and tables. Valid values are: text, json, yaml, or github-actions")
+	RootCmd.PersistentFlags().IntVar(&jsonSchema, "json-schema-version", print.JSONSchemaV1, "The schema of the status messages printed with --output-format json or yaml. Version 2 adds the command, app ID, error code and duration. Valid values are: 1 or 2")
+	RootCmd.PersistentFlags().StringVarP(&cliLogLevel, "log-level", "", "info", "The CLI log level. Valid values are: debug, info, warn, or error. For dapr run and dapr annotate, --log-level sets the log level of the sidecar instead, and --verbose prints debug output of the CLI")
+	RootCmd.PersistentFlags().StringVar(&dockerHost, "docker-host", "", "The Docker daemon of the self-hosted containers, such as tcp://host:2376 or ssh://user@host. Defaults to DOCKER_HOST")
+	RootCmd.PersistentFlags().StringVar(&installPath, "install-path", "", "The directory of the self-hosted installation, holding its binaries, components and configuration. Defaults to DAPR_INSTALL_PATH, or .dapr in the home directory")
+	RootCmd.PersistentFlags().BoolVarP(&noColor, "no-color", "", false, "Disable colors and emoji in output. Also honors the NO_COLOR environment variable")
+	RootCmd.PersistentFlags().Int("cert-expiry-warn-days", kubernetes.DefaultCertExpiryWarningDays, "Warn in Kubernetes commands if the root certificate expires within this number of days. 0 turns the warning off. Also honors the DAPR_CERT_EXPIRY_WARN_DAYS environment variable")
+	viper.BindPFlag("cert-expiry-warn-days", RootCmd.PersistentFlags().Lookup("cert-expiry-warn-days"))
 }
@@ -14,12 +14,17 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
+	"strconv"
+	"time"
 
 	"github.com/pkg/browser"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
 
 	"github.com/dapr/cli/pkg/kubernetes"
 	"github.com/dapr/cli/pkg/print"
@@ -45,6 +50,15 @@ const (
 
 	// remotePort is the port dapr dashboard pod is listening on.
 	remotePort = 8080
+
+	// dashboardStartTimeout is the time to wait for the self-hosted dashboard to listen before opening the browser.
+	dashboardStartTimeout = 10 * time.Second
+
+	// dashboardReconnectTimeout is the time to wait for a restarted dashboard pod to be running again.
+	dashboardReconnectTimeout = 2 * time.Minute
+
+	// dashboardReconnectInterval is the time between attempts to reconnect to the dashboard pod.
+	dashboardReconnectInterval = 2 * time.Second
 )
 
 var (
@@ -52,6 +66,9 @@ var (
 	dashboardHost       string
 	dashboardLocalPort  int
 	dashboardVersionCmd bool
+	dashboardOpen       bool
+
+	errDashboardInterrupted = errors.New("interrupted")
 )
 
 var DashboardCmd = &cobra.Command{
@@ -72,28 +89,40 @@ dapr dashboard -k -p 9999 -a 0.0.0.0
 
 # Port forward to dashboard in Kubernetes using a port
 dapr dashboard -k -p 9999
+
+# Start dashboard locally without opening it in the browser
+dapr dashboard --open=false
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		if dashboardVersionCmd {
 			fmt.Println(standalone.GetDashboardVersion())
-			os.Exit(0)
+			exit(0)
 		}
 
 		if !utils.IsAddressLegal(dashboardHost) {
 			print.FailureStatusEvent(os.Stdout, "Invalid address: %s", dashboardHost)
-			os.Exit(1)
+			exit(1)
 		}
 
 		if dashboardLocalPort <= 0 {
 			print.FailureStatusEvent(os.Stderr, "Invalid port: %v", dashboardLocalPort)
-			os.Exit(1)
+			exit(1)
+		}
+
+		localPort, err := selectDashboardPort(dashboardHost, dashboardLocalPort, cmd.Flags().Changed("port"))
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		if localPort != dashboardLocalPort {
+			print.InfoStatusEvent(os.Stdout, "Port %d is in use, using port %d instead", dashboardLocalPort, localPort)
 		}
 
 		if kubernetesMode {
 			config, client, err := kubernetes.GetKubeConfigClient()
 			if err != nil {
 				print.FailureStatusEvent(os.Stderr, "Failed to initialize kubernetes client: %s", err.Error())
-				os.Exit(1)
+				exit(1)
 			}
 
 			// search for dashboard service namespace in order:
@@ -126,7 +155,7 @@ dapr dashboard -k -p 9999
 				} else {
 					print.FailureStatusEvent(os.Stderr, "Failed to find Dapr dashboard in cluster. Check status of dapr dashboard in the cluster.")
 				}
-				os.Exit(1)
+				exit(1)
 			}
 
 			// manage termination of port forwarding connection on interrupt.
@@ -134,50 +163,44 @@ dapr dashboard -k -p 9999
 			signal.Notify(signals, os.Interrupt)
 			defer signal.Stop(signals)
 
-			portForward, err := kubernetes.NewPortForward(
-				config,
-				foundNamespace,
-				dashboardSvc,
-				dashboardHost,
-				dashboardLocalPort,
-				remotePort,
-				false,
-			)
+			portForward, err := forwardDashboard(config, foundNamespace, localPort)
 			if err != nil {
-				print.FailureStatusEvent(os.Stderr, "%s\n", err)
-				os.Exit(1)
-			}
-
-			// initialize port forwarding.
-			if err = portForward.Init(); err != nil {
 				print.FailureStatusEvent(os.Stderr, "Error in port forwarding: %s\nCheck for `dapr dashboard` running in other terminal sessions, or use the `--port` flag to use a different port.\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 
-			// block until interrupt signal is received.
-			go func() {
-				<-signals
-				portForward.Stop()
-			}()
-
 			// url for dashboard after port forwarding.
-			var webURL string = fmt.Sprintf("http://%s:%d", dashboardHost, dashboardLocalPort) //nolint:nosprintfhostport
+			var webURL string = fmt.Sprintf("http://%s:%d", dashboardHost, localPort) //nolint:nosprintfhostport
 
 			print.InfoStatusEvent(os.Stdout, fmt.Sprintf("Dapr dashboard found in namespace:\t%s", foundNamespace))
-			print.InfoStatusEvent(os.Stdout, fmt.Sprintf("Dapr dashboard available at:\t%s\n", webURL))
+			openDashboard(webURL)
 
-			err = browser.OpenURL(webURL)
-			if err != nil {
-				print.FailureStatusEvent(os.Stderr, "Failed to start Dapr dashboard in browser automatically")
-				print.FailureStatusEvent(os.Stderr, fmt.Sprintf("Visit %s in your browser to view the dashboard", webURL))
+			forward := func() (dashboardForward, error) {
+				return forwardDashboard(config, foundNamespace, localPort)
+			}
+			if err = keepDashboardForwarded(portForward, forward, signals, webURL); err != nil {
+				print.FailureStatusEvent(os.Stderr, "Failed to reconnect to the Dapr dashboard: %s", err)
+				exit(1)
 			}
-
-			<-portForward.GetStop()
 		} else {
 			// Standalone mode.
-			err := standalone.NewDashboardCmd(dashboardLocalPort).Run()
+			dashboardCmd := standalone.NewDashboardCmd(localPort)
+			err := dashboardCmd.Start()
 			if err != nil {
 				print.FailureStatusEvent(os.Stderr, "Dapr dashboard not found. Is Dapr installed?")
+				exit(1)
+			}
+
+			go func() {
+				if utils.IsDaprListeningOnPort(localPort, dashboardStartTimeout) == nil {
+					openDashboard(fmt.Sprintf("http://localhost:%d", localPort))
+				}
+			}()
+
+			err = dashboardCmd.Wait()
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, "Dapr dashboard exited with error: %s", err)
+				exit(1)
 			}
 		}
 	},
@@ -192,8 +215,117 @@ func init() {
 	DashboardCmd.Flags().BoolVarP(&kubernetesMode, "kubernetes", "k", false, "Opens Dapr dashboard in local browser via local proxy to Kubernetes cluster")
 	DashboardCmd.Flags().BoolVarP(&dashboardVersionCmd, "version", "v", false, "Print the version for Dapr dashboard")
 	DashboardCmd.Flags().StringVarP(&dashboardHost, "address", "a", defaultHost, "Address to listen on. Only accepts IP address or localhost as a value")
-	DashboardCmd.Flags().IntVarP(&dashboardLocalPort, "port", "p", defaultLocalPort, "The local port on which to serve Dapr dashboard")
+	DashboardCmd.Flags().IntVarP(&dashboardLocalPort, "port", "p", defaultLocalPort, "The local port on which to serve Dapr dashboard. If not given and the default port is in use, a free port is used")
+	DashboardCmd.Flags().BoolVar(&dashboardOpen, "open", true, "Open Dapr dashboard in the browser")
 	DashboardCmd.Flags().StringVarP(&dashboardNamespace, "namespace", "n", daprSystemNamespace, "The namespace where Dapr dashboard is running")
 	DashboardCmd.Flags().BoolP("help", "h", false, "Print this help message")
 	RootCmd.AddCommand(DashboardCmd)
 }
+
+// selectDashboardPort returns port if it is free on host. Otherwise, unless the port was explicitly requested, it returns a random free port.
+func selectDashboardPort(host string, port int, explicit bool) (int, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err == nil {
+		listener.Close()
+		return port, nil
+	}
+	if explicit {
+		return 0, fmt.Errorf("port %d is already in use. Use the --port flag to use a different port", port)
+	}
+
+	listener, err = net.Listen("tcp", net.JoinHostPort(host, "0"))
+	if err != nil {
+		return 0, fmt.Errorf("could not find a free port: %w", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// dashboardForward is a port forwarding to the dashboard pod, such as a *kubernetes.PortForward.
+type dashboardForward interface {
+	Stop()
+	Done() <-chan struct{}
+}
+
+// dashboardForwarder starts a port forwarding to the dashboard pod.
+type dashboardForwarder func() (dashboardForward, error)
+
+// forwardDashboard forwards localPort to the dashboard pod in namespace.
+func forwardDashboard(config *rest.Config, namespace string, localPort int) (*kubernetes.PortForward, error) {
+	portForward, err := kubernetes.NewPortForward(
+		config,
+		namespace,
+		dashboardSvc,
+		dashboardHost,
+		localPort,
+		remotePort,
+		false,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err = portForward.Init(); err != nil {
+		return nil, err
+	}
+	return portForward, nil
+}
+
+// keepDashboardForwarded reconnects to the dashboard with forward whenever the connection to the pod of portForward
+// is lost, for example because it restarted, until an interrupt is received on signals. It returns an error if it
+// gives up reconnecting.
+func keepDashboardForwarded(portForward dashboardForward, forward dashboardForwarder, signals <-chan os.Signal, webURL string) error {
+	for {
+		select {
+		case <-signals:
+			portForward.Stop()
+			return nil
+		case <-portForward.Done():
+		}
+
+		print.WarningStatusEvent(os.Stdout, "Lost connection to the Dapr dashboard pod. Reconnecting...")
+		var err error
+		portForward, err = reconnectDashboard(forward, signals, dashboardReconnectTimeout, dashboardReconnectInterval)
+		if errors.Is(err, errDashboardInterrupted) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		print.SuccessStatusEvent(os.Stdout, "Reconnected to the Dapr dashboard at %s", webURL)
+	}
+}
+
+// reconnectDashboard retries forward every interval until it succeeds or timeout has passed.
+// It returns errDashboardInterrupted if an interrupt is received on signals.
+func reconnectDashboard(forward dashboardForwarder, signals <-chan os.Signal, timeout, interval time.Duration) (dashboardForward, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		portForward, err := forward()
+		if err == nil {
+			return portForward, nil
+		}
+		print.DebugStatusEvent(os.Stderr, "Could not reconnect to the Dapr dashboard: %s", err)
+		if time.Now().After(deadline) {
+			return nil, err
+		}
+
+		select {
+		case <-signals:
+			return nil, errDashboardInterrupted
+		case <-time.After(interval):
+		}
+	}
+}
+
+// openDashboard prints the URL of the dashboard and opens it in the browser unless --open=false is given.
+func openDashboard(webURL string) {
+	print.InfoStatusEvent(os.Stdout, fmt.Sprintf("Dapr dashboard available at:\t%s\n", webURL))
+	if !dashboardOpen {
+		return
+	}
+	err := browser.OpenURL(webURL)
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, "Failed to start Dapr dashboard in browser automatically")
+		print.FailureStatusEvent(os.Stderr, fmt.Sprintf("Visit %s in your browser to view the dashboard", webURL))
+	}
+}
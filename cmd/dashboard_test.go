@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"net"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeForward is a port forwarding that is dropped by closing done.
+type fakeForward struct {
+	done    chan struct{}
+	stopped bool
+}
+
+func newFakeForward() *fakeForward {
+	return &fakeForward{done: make(chan struct{})}
+}
+
+func (f *fakeForward) Stop() {
+	f.stopped = true
+}
+
+func (f *fakeForward) Done() <-chan struct{} {
+	return f.done
+}
+
+// fakeForwarder returns a forwarder that fails failures times before it returns each of forwards in turn.
+func fakeForwarder(failures int, forwards ...*fakeForward) (dashboardForwarder, *int) {
+	calls := 0
+	return func() (dashboardForward, error) {
+		calls++
+		if failures > 0 {
+			failures--
+			return nil, errors.New("no running pods found for dapr-dashboard")
+		}
+		f := forwards[0]
+		forwards = forwards[1:]
+		return f, nil
+	}, &calls
+}
+
+func TestReconnectDashboard(t *testing.T) {
+	t.Run("reconnects after failed attempts", func(t *testing.T) {
+		reconnected := newFakeForward()
+		forward, calls := fakeForwarder(2, reconnected)
+		portForward, err := reconnectDashboard(forward, nil, time.Minute, time.Millisecond)
+		require.NoError(t, err)
+		assert.Same(t, reconnected, portForward)
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("gives up after the timeout", func(t *testing.T) {
+		forward, calls := fakeForwarder(1000)
+		_, err := reconnectDashboard(forward, nil, 5*time.Millisecond, time.Millisecond)
+		assert.ErrorContains(t, err, "no running pods found")
+		assert.Greater(t, *calls, 1)
+	})
+
+	t.Run("interrupted", func(t *testing.T) {
+		signals := make(chan os.Signal, 1)
+		signals <- os.Interrupt
+		forward, _ := fakeForwarder(1000)
+		_, err := reconnectDashboard(forward, signals, time.Minute, time.Minute)
+		assert.ErrorIs(t, err, errDashboardInterrupted)
+	})
+}
+
+func TestKeepDashboardForwarded(t *testing.T) {
+	t.Run("reconnects after the connection is dropped", func(t *testing.T) {
+		first, second := newFakeForward(), newFakeForward()
+		close(first.done)
+		signals := make(chan os.Signal, 1)
+		forward := func() (dashboardForward, error) {
+			// Interrupt once reconnected, which the forwarding loop receives when it waits again.
+			signals <- os.Interrupt
+			return second, nil
+		}
+
+		require.NoError(t, keepDashboardForwarded(first, forward, signals, "http://localhost:8080"))
+		assert.True(t, second.stopped, "the reconnected forwarding is stopped on interrupt")
+		assert.False(t, first.stopped)
+	})
+
+	t.Run("interrupt stops the forwarding", func(t *testing.T) {
+		portForward := newFakeForward()
+		signals := make(chan os.Signal, 1)
+		signals <- os.Interrupt
+		forward, calls := fakeForwarder(0)
+		require.NoError(t, keepDashboardForwarded(portForward, forward, signals, "http://localhost:8080"))
+		assert.True(t, portForward.stopped)
+		assert.Equal(t, 0, *calls)
+	})
+}
+
+func TestSelectDashboardPort(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	used := listener.Addr().(*net.TCPAddr).Port
+
+	_, err = selectDashboardPort("127.0.0.1", used, true)
+	assert.ErrorContains(t, err, "port "+strconv.Itoa(used)+" is already in use")
+
+	port, err := selectDashboardPort("127.0.0.1", used, false)
+	require.NoError(t, err)
+	assert.NotEqual(t, used, port, "a free port is used instead of the default port")
+
+	port, err = selectDashboardPort("127.0.0.1", port, true)
+	require.NoError(t, err)
+	assert.NotZero(t, port)
+}
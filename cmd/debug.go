@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/pkg/browser"
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/api"
+	"github.com/dapr/cli/pkg/kubernetes"
+	"github.com/dapr/cli/pkg/print"
+)
+
+var (
+	debugAppID     string
+	debugPodName   string
+	debugNamespace string
+	debugOpen      bool
+	debugTail      int64
+)
+
+var DebugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Forward the ports of the sidecar of an app and follow its logs. Supported platforms: Kubernetes",
+	Long: `Forward the HTTP, gRPC and metrics ports of the sidecar of an app to the local machine, open its metadata endpoint
+in the browser and follow the sidecar logs until interrupted with Ctrl+C.
+Each port is forwarded to the same local port if it is free, otherwise to a random free port.
+`,
+	Example: `
+# Debug the sidecar of the app orders
+dapr debug -k --app-id orders
+
+# Debug the sidecar of a pod of the app orders in a namespace, without opening the browser
+dapr debug -k --app-id orders --pod-name orders-5d7f8b-x2l9q --namespace shop --open=false
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !kubernetesMode {
+			print.FailureStatusEvent(os.Stderr, "The debug command is only supported in Kubernetes mode. Use -k")
+			exit(1)
+		}
+		session, err := kubernetes.StartDebugSession(debugAppID, debugPodName, debugNamespace)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error debugging app %s: %s", debugAppID, err)
+			exit(1)
+		}
+		defer session.Stop()
+
+		print.SuccessStatusEvent(os.Stdout, "Forwarding the ports of the sidecar of pod %s", session.Pod)
+		if err = print.WriteTable(os.Stdout, session.Ports, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		metadataURL := fmt.Sprintf("http://localhost:%d/v%s/metadata", session.Port("http"), api.RuntimeAPIVersion)
+		print.InfoStatusEvent(os.Stdout, "Metadata endpoint: %s", metadataURL)
+		if debugOpen {
+			if err = browser.OpenURL(metadataURL); err != nil {
+				print.WarningStatusEvent(os.Stdout, "Failed to open the metadata endpoint in the browser: %s", err)
+			}
+		}
+		print.InfoStatusEvent(os.Stdout, "Following the sidecar logs. Press Ctrl+C to stop")
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+		if err = session.FollowLogs(ctx, os.Stdout, debugTail); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			session.Stop()
+			exit(1)
+		}
+	},
+	PostRun: func(cmd *cobra.Command, args []string) {
+		kubernetes.CheckForCertExpiry()
+	},
+}
+
+func init() {
+	DebugCmd.Flags().BoolVarP(&kubernetesMode, "kubernetes", "k", false, "Debug the sidecar of an app in a Kubernetes cluster")
+	DebugCmd.Flags().StringVarP(&debugAppID, "app-id", "a", "", "The ID of the app to debug")
+	DebugCmd.Flags().StringVarP(&debugPodName, "pod-name", "p", "", "The name of the pod to debug, in case the app has multiple pods. Defaults to the first running pod")
+	DebugCmd.Flags().StringVarP(&debugNamespace, "namespace", "n", "default", "The Kubernetes namespace in which the app is deployed")
+	DebugCmd.Flags().BoolVar(&debugOpen, "open", true, "Open the metadata endpoint of the sidecar in the browser")
+	DebugCmd.Flags().Int64Var(&debugTail, "tail", 20, "The number of most recent log lines to print before following the logs. Prints all lines if negative")
+	DebugCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	DebugCmd.MarkFlagRequired("app-id")
+	RootCmd.AddCommand(DebugCmd)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/dapr/cli/pkg/kubernetes"
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+// doctorCheck is a single diagnostic run by `dapr doctor`.
+type doctorCheck struct {
+	name        string
+	check       func() (string, error)
+	remediation string
+	// optional checks are reported as warnings, since they only matter for some setups.
+	optional bool
+}
+
+var DoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the environment for common problems. Supported platforms: Kubernetes and self-hosted",
+	Example: `
+# Check the environment for common problems
+dapr doctor
+
+# Check the environment when Dapr was initialized in a specific Docker network
+dapr doctor --network dapr-network
+
+# Check the environment when Dapr was initialized with Podman
+dapr doctor --container-runtime podman
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlag("network", cmd.Flags().Lookup("network"))
+		viper.BindPFlag("container-runtime", cmd.Flags().Lookup("container-runtime"))
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		dockerNetwork := viper.GetString("network")
+		containerRuntime := viper.GetString("container-runtime")
+		failed := 0
+		for _, c := range doctorChecks(containerRuntime, dockerNetwork) {
+			msg, err := c.check()
+			switch {
+			case err == nil:
+				print.SuccessStatusEvent(os.Stdout, "%s: %s", c.name, msg)
+				continue
+			case c.optional:
+				print.WarningStatusEvent(os.Stdout, "%s: %s", c.name, err)
+			default:
+				failed++
+				print.FailureStatusEvent(os.Stdout, "%s: %s", c.name, err)
+			}
+			print.InfoStatusEvent(os.Stdout, "  %s", c.remediation)
+		}
+
+		if failed > 0 {
+			print.FailureStatusEvent(os.Stderr, "%d checks failed", failed)
+			exit(1)
+		}
+	},
+}
+
+func doctorChecks(containerRuntime, dockerNetwork string) []doctorCheck {
+	return []doctorCheck{
+		{
+			name:        "Container runtime",
+			check:       standalone.CheckContainerRuntime,
+			remediation: "Install Docker or Podman and make sure it is running. Alternatively, use `dapr init --slim` to run Dapr without containers.",
+			optional:    true,
+		},
+		{
+			name:        "Dapr HTTP port",
+			check:       func() (string, error) { return standalone.CheckPortAvailable(standalone.DefaultDaprHTTPPort) },
+			remediation: fmt.Sprintf("Stop the process using port %d, or pass another port to `dapr run` with --dapr-http-port.", standalone.DefaultDaprHTTPPort),
+			optional:    true,
+		},
+		{
+			name:        "Dapr gRPC port",
+			check:       func() (string, error) { return standalone.CheckPortAvailable(standalone.DefaultDaprGRPCPort) },
+			remediation: fmt.Sprintf("Stop the process using port %d, or pass another port to `dapr run` with --dapr-grpc-port.", standalone.DefaultDaprGRPCPort),
+			optional:    true,
+		},
+		{
+			name:        "Placement port",
+			check:       func() (string, error) { return standalone.CheckPlacementPort(containerRuntime, dockerNetwork) },
+			remediation: fmt.Sprintf("Stop the process using port %d, since the placement service started by `dapr init` needs it.", standalone.PlacementPort()),
+		},
+		{
+			name:        "Dapr runtime",
+			check:       func() (string, error) { return standalone.CheckBinary("daprd") },
+			remediation: "Run `dapr init` to install the Dapr runtime.",
+		},
+		{
+			name:        "Placement binary",
+			check:       func() (string, error) { return standalone.CheckBinary("placement") },
+			remediation: "Run `dapr uninstall` and `dapr init` to reinstall the placement binary.",
+			optional:    true,
+		},
+		{
+			name:        "Dapr dashboard",
+			check:       func() (string, error) { return standalone.CheckBinary("dashboard") },
+			remediation: "Run `dapr uninstall` and `dapr init` to reinstall the dashboard.",
+			optional:    true,
+		},
+		{
+			name: "Placement container",
+			check: func() (string, error) {
+				return standalone.CheckContainer(containerRuntime, standalone.DaprPlacementContainerName, dockerNetwork)
+			},
+			remediation: "Start it with `docker start dapr_placement` (or `podman start dapr_placement`), or run `dapr init` if it does not exist. Not needed for `dapr init --slim` installations.",
+			optional:    true,
+		},
+		{
+			name: "Redis container",
+			check: func() (string, error) {
+				return standalone.CheckContainer(containerRuntime, standalone.DaprRedisContainerName, dockerNetwork)
+			},
+			remediation: "Start it with `docker start dapr_redis` (or `podman start dapr_redis`), or run `dapr init` if it does not exist. Not needed for `dapr init --slim` installations.",
+			optional:    true,
+		},
+		{
+			name: "Zipkin container",
+			check: func() (string, error) {
+				return standalone.CheckContainer(containerRuntime, standalone.DaprZipkinContainerName, dockerNetwork)
+			},
+			remediation: "Start it with `docker start dapr_zipkin` (or `podman start dapr_zipkin`), or run `dapr init` if it does not exist. Not needed for `dapr init --slim` installations.",
+			optional:    true,
+		},
+		{
+			name:        "Kubernetes cluster",
+			check:       kubernetes.CheckClusterReachable,
+			remediation: "Check your kubeconfig and current context with `kubectl config current-context`. Only needed to use Dapr on Kubernetes.",
+			optional:    true,
+		},
+	}
+}
+
+func init() {
+	DoctorCmd.Flags().String("network", "", "The Docker network in which Dapr was initialized")
+	DoctorCmd.Flags().String("container-runtime", standalone.DockerContainerRuntime, "The container runtime Dapr was initialized with. Valid values are: docker, podman")
+	DoctorCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	RootCmd.AddCommand(DoctorCmd)
+}
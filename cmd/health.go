@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+var (
+	healthAppID   string
+	healthWait    bool
+	healthTimeout time.Duration
+	healthSocket  string
+)
+
+var HealthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Check the health of the sidecar of an app, exiting with 0 if it is healthy and 1 otherwise. Supported platforms: Self-hosted",
+	Long: `Check the health of the sidecar of an app, exiting with 0 if it is healthy and 1 otherwise.
+The sidecar is healthy once it is ready and its components are initialized, as reported by its healthz and
+outbound healthz endpoints. Use --wait to block until the sidecar is healthy, as a readiness gate in scripts.
+`,
+	Example: `
+# Check the health of the sidecar of an app
+dapr health --app-id myapp
+
+# Wait up to 60 seconds for the sidecar of an app to be healthy, for example in a Makefile
+dapr health --app-id myapp --wait --timeout 60s
+
+# Print the health of the sidecar as JSON
+dapr health --app-id myapp --output-format json
+`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkUnixDomainSocket(healthSocket)
+		timeout := time.Duration(0)
+		if healthWait {
+			timeout = healthTimeout
+			print.InfoStatusEvent(os.Stdout, "Waiting up to %s for the sidecar to be healthy", timeout)
+		}
+
+		health, err := standalone.NewClient().Health(context.Background(), healthAppID, healthSocket, timeout)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		if !print.GetRenderer().Interactive() {
+			if err = print.WriteTable(os.Stdout, []standalone.HealthOutput{health}, false); err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			if !health.Healthy {
+				exit(1)
+			}
+			return
+		}
+		if !health.Healthy {
+			print.FailureStatusEvent(os.Stderr, "The sidecar of app %s is not healthy. Sidecar: %s, outbound: %s", health.AppID, health.Sidecar, health.Outbound)
+			exit(1)
+		}
+		print.SuccessStatusEvent(os.Stdout, "The sidecar of app %s is healthy", health.AppID)
+	},
+}
+
+func init() {
+	HealthCmd.Flags().StringVarP(&healthAppID, "app-id", "a", "", "The ID of the app whose sidecar is checked. Required if more than one app is running")
+	HealthCmd.Flags().BoolVar(&healthWait, "wait", false, "Wait for the sidecar to start and be healthy instead of checking it once")
+	HealthCmd.Flags().DurationVar(&healthTimeout, "timeout", standalone.DefaultHealthTimeout, "The time to wait for the sidecar to be healthy with --wait")
+	HealthCmd.Flags().StringVarP(&healthSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Dapr API servers will use Unix Domain Sockets")
+	HealthCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	RootCmd.AddCommand(HealthCmd)
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+	"github.com/dapr/cli/pkg/telemetry"
+)
+
+var (
+	historyLimit int
+	historyClear bool
+)
+
+// invocation is the command being run. It is recorded in the history, and sent as a usage event if telemetry
+// is enabled, when the command ends.
+var invocation struct {
+	command string
+	start   time.Time
+	// failure is the message of the last failure event, recorded as the error if the command exits with an error.
+	failure string
+	once    sync.Once
+}
+
+var HistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Print the recent invocations of the CLI and their outcomes",
+	Long: `Print the recent invocations of the CLI and their outcomes, to troubleshoot what was run before a problem.
+The last 200 invocations are kept in the history file of the Dapr directory. Only the commands and the names of
+their flags are recorded, as flag values and arguments can hold credentials.`,
+	Example: `
+# Print the last 20 invocations
+dapr history
+
+# Print the last 5 invocations as JSON
+dapr history -n 5 --output-format json
+
+# Clear the history
+dapr history --clear
+`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path := standalone.DefaultHistoryFilePath()
+		if historyClear {
+			if err := telemetry.ClearHistory(path); err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			print.SuccessStatusEvent(os.Stdout, "History cleared")
+			return
+		}
+
+		entries, err := telemetry.ReadHistory(path, historyLimit)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		if len(entries) == 0 && print.GetRenderer().Interactive() {
+			print.InfoStatusEvent(os.Stdout, "No invocations recorded yet")
+			return
+		}
+		if err = print.WriteTable(os.Stdout, entries, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+}
+
+// startInvocation starts recording cmd. The outcome is recorded when the command exits, see exit.
+func startInvocation(cmd *cobra.Command) {
+	if cmd == HistoryCmd {
+		return
+	}
+	invocation.command = cmd.CommandPath()
+	invocation.start = time.Now()
+	print.SetFailureHook(func(msg string) {
+		invocation.failure = msg
+	})
+}
+
+// exit records the outcome of the command being run from the exit code, with the last failure event as the error,
+// and exits with code. Commands call it instead of os.Exit so that failures are recorded.
+func exit(code int) {
+	if code == 0 {
+		endInvocation("success", "")
+	} else {
+		endInvocation("failure", invocation.failure)
+	}
+	os.Exit(code)
+}
+
+// endInvocation records the outcome of the command being run, once.
+func endInvocation(result, errMsg string) {
+	if invocation.command == "" {
+		return
+	}
+	invocation.once.Do(func() {
+		duration := time.Since(invocation.start)
+		err := telemetry.AppendHistory(standalone.DefaultHistoryFilePath(), telemetry.HistoryEntry{
+			Time:     invocation.start.Format(time.RFC3339),
+			Command:  telemetry.CommandLine(invocation.command, os.Args[1:]),
+			Result:   result,
+			Duration: duration.Round(time.Millisecond).String(),
+			Error:    errMsg,
+		})
+		if err != nil {
+			print.DebugStatusEvent(os.Stderr, "Could not record the command in the history: %s", err)
+		}
+
+		settings, err := telemetry.LoadSettings(standalone.DefaultTelemetryFilePath())
+		if err != nil || !settings.Enabled {
+			return
+		}
+		event := telemetry.NewEvent(settings, invocation.command, telemetry.FlagNames(os.Args[1:]), result, duration, daprVer.CliVersion)
+		if err = telemetry.Send(context.Background(), settings, event); err != nil {
+			print.DebugStatusEvent(os.Stderr, "Could not send the usage event: %s", err)
+		}
+	})
+}
+
+func init() {
+	HistoryCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "The number of invocations to print, 0 for all")
+	HistoryCmd.Flags().BoolVar(&historyClear, "clear", false, "Remove all recorded invocations")
+	HistoryCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	RootCmd.AddCommand(HistoryCmd)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+	"github.com/dapr/cli/utils"
+)
+
+var (
+	pullImagesConfig       standalone.PullImagesConfig
+	imagesContainerRuntime string
+	imagesOutputFormat     string
+)
+
+var ImagesCmd = &cobra.Command{
+	Use:   "images",
+	Short: "Pull and list the container images of a Dapr installation, for example ahead of dapr init on a flaky or metered network. Supported platforms: Self-hosted",
+}
+
+var ImagesPullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Pull the images that dapr init needs, and optionally retag them for a registry mirror",
+	Example: `
+# Pull the images of the latest runtime
+dapr images pull
+
+# Pull the images of a runtime version, with the Kafka image
+dapr images pull --runtime-version 1.8.0 --components redis,zipkin,kafka
+
+# Pull the images and retag them for a mirror, then install from it
+dapr images pull --runtime-version 1.8.0 --registry my.registry
+dapr init --runtime-version 1.8.0 --image-registry my.registry
+
+# Pull the images and push them to a mirror
+dapr images pull --registry my.registry --push
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if pullImagesConfig.Push && pullImagesConfig.Registry == "" {
+			print.FailureStatusEvent(os.Stderr, "--push requires --registry")
+			exit(1)
+		}
+		pullImagesConfig.ContainerRuntime = imagesContainerRuntime
+		if err := standalone.PullImages(pullImagesConfig); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Images pulled")
+	},
+}
+
+var ImagesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the Dapr images stored by the container runtime",
+	Example: `
+# List the Dapr images
+dapr images list
+
+# List the Dapr images stored by Podman, as JSON
+dapr images list --container-runtime podman -o json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if imagesOutputFormat != "table" && imagesOutputFormat != "json" && imagesOutputFormat != "yaml" {
+			print.FailureStatusEvent(os.Stderr, "An invalid output format was specified.")
+			exit(1)
+		}
+		images, err := standalone.ListImages(imagesContainerRuntime)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		if imagesOutputFormat == "table" {
+			if len(images) == 0 {
+				print.InfoStatusEvent(os.Stdout, "No Dapr images found. Pull them with dapr images pull")
+				return
+			}
+			err = print.WriteTable(os.Stdout, images, false)
+		} else {
+			err = utils.PrintDetail(os.Stdout, imagesOutputFormat, images)
+		}
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	ImagesPullCmd.Flags().StringVar(&pullImagesConfig.RuntimeVersion, "runtime-version", "latest", "The version of the Dapr runtime to pull the image of, for example: 1.0.0")
+	ImagesPullCmd.Flags().StringVar(&pullImagesConfig.DashboardVersion, "dashboard-version", "latest", "The version of the Dapr dashboard to pull the image of, for example: 1.0.0")
+	ImagesPullCmd.Flags().StringSliceVar(&pullImagesConfig.Components, "components", standalone.DefaultInitComponents, "The init components to pull the images of. Valid values are: redis, zipkin, kafka, postgres")
+	ImagesPullCmd.Flags().StringVar(&pullImagesConfig.Registry, "registry", "", "The registry to retag the images for, as given to dapr init --image-registry")
+	ImagesPullCmd.Flags().BoolVar(&pullImagesConfig.Push, "push", false, "Push the retagged images to the registry given by --registry")
+	ImagesListCmd.Flags().StringVarP(&imagesOutputFormat, "output", "o", "table", "Output format (options: table or json or yaml)")
+
+	for _, c := range []*cobra.Command{ImagesPullCmd, ImagesListCmd} {
+		c.Flags().StringVar(&imagesContainerRuntime, "container-runtime", standalone.DockerContainerRuntime, "The container runtime to pull or list the images with. Valid values are: docker, podman")
+		c.Flags().BoolP("help", "h", false, "Print this help message")
+		ImagesCmd.AddCommand(c)
+	}
+	RootCmd.AddCommand(ImagesCmd)
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/dapr/cli/pkg/kubernetes"
 	"github.com/dapr/cli/pkg/print"
 	"github.com/dapr/cli/pkg/standalone"
+	"github.com/dapr/cli/pkg/version"
+	"github.com/dapr/cli/utils"
 )
 
 var (
@@ -39,7 +41,22 @@ var (
 	enableMTLS        bool
 	enableHA          bool
 	values            []string
+	valueFiles        []string
 	fromDir           string
+	downloadOnly      bool
+	bundleOutputDir   string
+	skipVerify        bool
+	interactive       bool
+	initComponents    []string
+	caCertFile        string
+	gitHubMirror      string
+	initDryRun        bool
+	sideBySide        bool
+	initResourcesPath string
+	initConfigFile    string
+	initHelmRepo      string
+	initChart         string
+	installServices   bool
 )
 
 var InitCmd = &cobra.Command{
@@ -48,6 +65,8 @@ var InitCmd = &cobra.Command{
 	PreRun: func(cmd *cobra.Command, args []string) {
 		viper.BindPFlag("network", cmd.Flags().Lookup("network"))
 		viper.BindPFlag("image-registry", cmd.Flags().Lookup("image-registry"))
+		viper.BindPFlag("container-runtime", cmd.Flags().Lookup("container-runtime"))
+		applyNetworkFlags()
 	},
 	Example: `
 # Initialize Dapr in self-hosted mode
@@ -63,6 +82,18 @@ dapr init -k
 # Initialize Dapr in Kubernetes and wait for the installation to complete (default timeout is 300s/5m)
 dapr init -k --wait --timeout 600
 
+# Initialize Dapr in Kubernetes with custom Helm values, for example replica counts, resource limits or tolerations
+dapr init -k --values ./dapr-values.yaml --set dapr_operator.replicaCount=2
+
+# Initialize Dapr in Kubernetes from an internal mirror of the Helm repository or from an OCI registry,
+# using the credentials of helm repo add or helm registry login
+dapr init -k --helm-repo https://charts.example.com/dapr
+dapr init -k --chart oci://registry.example.com/charts/dapr
+
+# Print what would be installed in self-hosted mode or in Kubernetes, without installing Dapr
+dapr init --dry-run
+dapr init -k --dry-run --output-format json
+
 # Initialize particular Dapr runtime in self-hosted mode
 dapr init --runtime-version 0.10.0
 
@@ -72,15 +103,101 @@ dapr init -k --runtime-version 0.10.0
 # Initialize Dapr in slim self-hosted mode
 dapr init -s
 
+# Initialize Dapr in slim self-hosted mode and run the placement service as a service of the operating system
+dapr init -s --install-services
+
+# Install another Dapr runtime next to the installed one, and switch to it with dapr use
+dapr init --runtime-version 1.9.0 --side-by-side
+
+# Initialize Dapr in self-hosted mode into another directory than ~/.dapr
+dapr init --install-path /opt/dapr
+
+# Initialize Dapr in self-hosted mode with the components and configuration in the repository of a project
+dapr init --resources-path ./dapr/resources --config-file ./dapr/config.yaml
+
+# Initialize Dapr in self-hosted mode using Podman instead of Docker
+dapr init --container-runtime podman
+
+# Initialize Dapr in self-hosted mode with Redis and its components, but without Zipkin
+dapr init --components redis
+
+# Initialize Dapr in self-hosted mode with Kafka for pub/sub, PostgreSQL for state and Zipkin for tracing
+dapr init --components kafka,postgres,zipkin
+
+# Initialize Dapr in self-hosted mode by answering prompts, and print the equivalent command
+dapr init --interactive
+
+# Initialize Dapr behind a proxy that intercepts TLS, downloading the binaries from a mirror of GitHub
+HTTPS_PROXY=http://proxy.example.com:3128 dapr init --cacert corp-ca.pem --github-mirror https://mirror.example.com/github --image-registry registry.example.com
+
 # Initialize Dapr from a directory (installer-bundle installation) (Preview feature)
 dapr init --from-dir <path-to-directory>
 
+# Download an installer-bundle to a directory, to be installed with --from-dir on a machine without internet access (Preview feature)
+dapr init --download-only --output <path-to-directory>
+
 # See more at: https://docs.dapr.io/getting-started/
 `,
 	Run: func(cmd *cobra.Command, args []string) {
-		print.PendingStatusEvent(os.Stdout, "Making the jump to hyperspace...")
 		imageRegistryFlag := strings.TrimSpace(viper.GetString("image-registry"))
 
+		if downloadOnly {
+			if kubernetesMode || len(strings.TrimSpace(fromDir)) != 0 || initDryRun {
+				print.FailureStatusEvent(os.Stderr, "--download-only cannot be used together with --kubernetes, --from-dir or --dry-run")
+				exit(1)
+			}
+			print.WarningStatusEvent(os.Stdout, "Downloading an installer-bundle using --download-only flag is currently a preview feature and is subject to change.")
+			warnForSkipVerify()
+			err := standalone.DownloadBundle(runtimeVersion, dashboardVersion, RootCmd.Version, imageRegistryFlag, bundleOutputDir, skipVerify)
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			print.SuccessStatusEvent(os.Stdout, "Success! The installer-bundle has been saved to %s. To install it, run `dapr init --from-dir %s`.", bundleOutputDir, bundleOutputDir)
+			return
+		}
+
+		if sideBySide {
+			if kubernetesMode || len(strings.TrimSpace(fromDir)) != 0 || interactive || initDryRun {
+				print.FailureStatusEvent(os.Stderr, "--side-by-side cannot be used together with --kubernetes, --from-dir, --interactive or --dry-run")
+				exit(1)
+			}
+			warnForSkipVerify()
+			err := standalone.InstallRuntimeVersion(runtimeVersion, skipVerify)
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			return
+		}
+
+		if kubernetesMode && (initResourcesPath != "" || initConfigFile != "") {
+			print.FailureStatusEvent(os.Stderr, "--resources-path and --config-file are only supported in self-hosted mode")
+			exit(1)
+		}
+		if !kubernetesMode && (initHelmRepo != "" || initChart != "") {
+			print.FailureStatusEvent(os.Stderr, "--helm-repo and --chart are only supported in Kubernetes mode")
+			exit(1)
+		}
+		if initHelmRepo != "" && initChart != "" {
+			print.FailureStatusEvent(os.Stderr, "Only one of --helm-repo and --chart can be given")
+			exit(1)
+		}
+
+		if interactive {
+			if kubernetesMode || len(strings.TrimSpace(fromDir)) != 0 {
+				print.FailureStatusEvent(os.Stderr, "--interactive cannot be used together with --kubernetes or --from-dir")
+				exit(1)
+			}
+			promptInitOptions()
+		}
+		if installServices && (kubernetesMode || !slimMode) {
+			print.FailureStatusEvent(os.Stderr, "--install-services is only supported together with --slim")
+			exit(1)
+		}
+
+		print.PendingStatusEvent(os.Stdout, "Making the jump to hyperspace...")
+
 		if kubernetesMode {
 			print.InfoStatusEvent(os.Stdout, "Note: To install Dapr using Helm, see here: https://docs.dapr.io/getting-started/install-dapr-kubernetes/#install-with-helm-advanced\n")
 			imageRegistryURI := ""
@@ -94,7 +211,7 @@ dapr init --from-dir <path-to-directory>
 			}
 			if err != nil {
 				print.FailureStatusEvent(os.Stderr, err.Error())
-				os.Exit(1)
+				exit(1)
 			}
 			config := kubernetes.InitConfiguration{
 				Namespace:        initNamespace,
@@ -102,14 +219,21 @@ dapr init --from-dir <path-to-directory>
 				EnableMTLS:       enableMTLS,
 				EnableHA:         enableHA,
 				Args:             values,
+				ValueFiles:       valueFiles,
 				Wait:             wait,
 				Timeout:          timeout,
 				ImageRegistryURI: imageRegistryURI,
+				ChartSource:      kubernetes.ChartSource{RepoURL: initHelmRepo, Chart: initChart},
+				DryRun:           initDryRun,
 			}
 			err = kubernetes.Init(config)
 			if err != nil {
 				print.FailureStatusEvent(os.Stderr, err.Error())
-				os.Exit(1)
+				exit(1)
+			}
+			if initDryRun {
+				print.InfoStatusEvent(os.Stdout, "Dry run, Dapr was not installed.")
+				return
 			}
 			print.SuccessStatusEvent(os.Stdout, fmt.Sprintf("Success! Dapr has been installed to namespace %s. To verify, run `dapr status -k' in your terminal. To get started, go here: https://aka.ms/dapr-getting-started", config.Namespace))
 		} else {
@@ -122,7 +246,7 @@ dapr init --from-dir <path-to-directory>
 			// If both --image-registry and --from-dir flags are given, error out saying only one can be given.
 			if len(strings.TrimSpace(imageRegistryURI)) != 0 && len(strings.TrimSpace(fromDir)) != 0 {
 				print.FailureStatusEvent(os.Stderr, "both --image-registry and --from-dir flags cannot be given at the same time")
-				os.Exit(1)
+				exit(1)
 			}
 			if len(strings.TrimSpace(fromDir)) != 0 {
 				print.WarningStatusEvent(os.Stdout, "Local bundle installation using --from-dir flag is currently a preview feature and is subject to change. It is only available from CLI version 1.7 onwards.")
@@ -130,20 +254,102 @@ dapr init --from-dir <path-to-directory>
 			if len(imageRegistryURI) != 0 {
 				warnForPrivateRegFeat()
 			}
-			err := standalone.Init(runtimeVersion, dashboardVersion, dockerNetwork, slimMode, imageRegistryURI, fromDir)
+			warnForSkipVerify()
+			err := standalone.Init(standalone.InitConfig{
+				RuntimeVersion:     runtimeVersion,
+				DashboardVersion:   dashboardVersion,
+				DockerNetwork:      dockerNetwork,
+				SlimMode:           slimMode,
+				ImageRegistryURL:   imageRegistryURI,
+				FromDir:            fromDir,
+				ContainerRuntime:   viper.GetString("container-runtime"),
+				InsecureSkipVerify: skipVerify,
+				Components:         initComponents,
+				ResourcesPath:      initResourcesPath,
+				ConfigFile:         initConfigFile,
+				DryRun:             initDryRun,
+			})
 			if err != nil {
 				print.FailureStatusEvent(os.Stderr, err.Error())
-				os.Exit(1)
+				exit(1)
+			}
+			if initDryRun {
+				if installServices {
+					print.DryRunStatusEvent(os.Stdout, "Would install and start the placement and scheduler binaries as services of the operating system")
+				}
+				print.InfoStatusEvent(os.Stdout, "Dry run, Dapr was not installed.")
+				return
+			}
+			if installServices {
+				if err = standalone.InstallServices(); err != nil {
+					print.FailureStatusEvent(os.Stderr, "Dapr was installed, but its services were not: %s", err)
+					exit(1)
+				}
 			}
 			print.SuccessStatusEvent(os.Stdout, "Success! Dapr is up and running. To get started, go here: https://aka.ms/dapr-getting-started")
 		}
 	},
 }
 
+// promptInitOptions asks for the options of a self-hosted installation, starting from the values of the flags,
+// and prints the equivalent command to install Dapr without prompting.
+func promptInitOptions() {
+	opts, err := standalone.PromptInitOptions(os.Stdin, os.Stdout, standalone.InitOptions{
+		RuntimeVersion:     runtimeVersion,
+		DashboardVersion:   dashboardVersion,
+		SlimMode:           slimMode,
+		ContainerRuntime:   viper.GetString("container-runtime"),
+		Components:         initComponents,
+		DockerNetwork:      viper.GetString("network"),
+		ImageRegistryURL:   strings.TrimSpace(viper.GetString("image-registry")),
+		InsecureSkipVerify: skipVerify,
+		ResourcesPath:      initResourcesPath,
+		ConfigFile:         initConfigFile,
+		InstallServices:    installServices,
+		CACertFile:         caCertFile,
+		GitHubMirror:       gitHubMirror,
+	})
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+	runtimeVersion = opts.RuntimeVersion
+	dashboardVersion = opts.DashboardVersion
+	slimMode = opts.SlimMode
+	initComponents = opts.Components
+	if opts.ContainerRuntime != "" {
+		viper.Set("container-runtime", opts.ContainerRuntime)
+	}
+	print.InfoStatusEvent(os.Stdout, "To install Dapr with the same options without prompts, run: %s", opts.Command())
+}
+
 func warnForPrivateRegFeat() {
 	print.WarningStatusEvent(os.Stdout, "Flag --image-registry is a preview feature and is subject to change.")
 }
 
+// warnForSkipVerify warns that the checksums of the downloaded binaries are not verified when --insecure-skip-verify is set.
+func warnForSkipVerify() {
+	if skipVerify {
+		print.WarningStatusEvent(os.Stdout, "Flag --insecure-skip-verify is set. The checksums of the downloaded binaries will not be verified.")
+	}
+}
+
+// addNetworkFlags adds the flags for downloads in locked-down networks to cmd. Proxies are given with the
+// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+func addNetworkFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&caCertFile, "cacert", "", "", "A PEM bundle of CA certificates to trust in addition to the system ones for downloads, for example of a proxy that intercepts TLS")
+	cmd.Flags().StringVarP(&gitHubMirror, "github-mirror", "", "", "The URL of a mirror of https://github.com to download the release archives from, as <mirror>/dapr/<repo>/releases/download/v<version>/<archive>")
+}
+
+// applyNetworkFlags applies the flags added by addNetworkFlags to all downloads and version lookups.
+func applyNetworkFlags() {
+	if err := utils.SetCACertFile(caCertFile); err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+	version.SetGitHubMirror(gitHubMirror)
+}
+
 func init() {
 	defaultRuntimeVersion := "latest"
 	viper.BindEnv("runtime_version_override", "DAPR_RUNTIME_VERSION")
@@ -158,18 +364,33 @@ func init() {
 		defaultDashboardVersion = dashboardVersionEnv
 	}
 	InitCmd.Flags().BoolVarP(&kubernetesMode, "kubernetes", "k", false, "Deploy Dapr to a Kubernetes cluster")
-	InitCmd.Flags().BoolVarP(&wait, "wait", "", false, "Wait for Kubernetes initialization to complete")
+	InitCmd.Flags().BoolVarP(&wait, "wait", "", false, "Wait for Kubernetes initialization to complete, printing the rollout of each control plane component")
 	InitCmd.Flags().UintVarP(&timeout, "timeout", "", 300, "The wait timeout for the Kubernetes installation")
 	InitCmd.Flags().BoolVarP(&slimMode, "slim", "s", false, "Exclude placement service, Redis and Zipkin containers from self-hosted installation")
+	InitCmd.Flags().BoolVar(&installServices, "install-services", false, "Run the placement and scheduler binaries of a slim installation as services of the operating system, so they start again after a reboot: systemd user units, launchd agents or scheduled tasks on Windows")
 	InitCmd.Flags().StringVarP(&runtimeVersion, "runtime-version", "", defaultRuntimeVersion, "The version of the Dapr runtime to install, for example: 1.0.0")
 	InitCmd.Flags().StringVarP(&dashboardVersion, "dashboard-version", "", defaultDashboardVersion, "The version of the Dapr dashboard to install, for example: 1.0.0")
 	InitCmd.Flags().StringVarP(&initNamespace, "namespace", "n", "dapr-system", "The Kubernetes namespace to install Dapr in")
 	InitCmd.Flags().BoolVarP(&enableMTLS, "enable-mtls", "", true, "Enable mTLS in your cluster")
 	InitCmd.Flags().BoolVarP(&enableHA, "enable-ha", "", false, "Enable high availability (HA) mode")
 	InitCmd.Flags().String("network", "", "The Docker network on which to deploy the Dapr runtime")
+	InitCmd.Flags().String("container-runtime", standalone.DockerContainerRuntime, "The container runtime to run the self-hosted containers with. Valid values are: docker, podman")
+	InitCmd.Flags().StringSliceVarP(&initComponents, "components", "", standalone.DefaultInitComponents, "The containers and default components to set up in self-hosted mode. Valid values are: redis, zipkin, kafka, postgres")
+	InitCmd.Flags().BoolVarP(&interactive, "interactive", "", false, "Prompt for the options of the self-hosted installation and print the equivalent command")
 	InitCmd.Flags().StringVarP(&fromDir, "from-dir", "", "", "Use Dapr artifacts from local directory for self-hosted installation")
+	InitCmd.Flags().BoolVarP(&downloadOnly, "download-only", "", false, "Download Dapr artifacts to the directory given by --output, to be installed later with --from-dir")
+	InitCmd.Flags().StringVarP(&bundleOutputDir, "output", "o", "", "The directory to download Dapr artifacts to when using --download-only")
+	InitCmd.Flags().BoolVar(&sideBySide, "side-by-side", false, "Install the runtime given by --runtime-version next to the installed one in self-hosted mode, instead of failing because Dapr is already installed")
+	InitCmd.Flags().StringVar(&initResourcesPath, "resources-path", "", "The directory to write the default components to in self-hosted mode, instead of the components directory of the installation. It becomes the default of dapr run")
+	InitCmd.Flags().StringVar(&initConfigFile, "config-file", "", "The path to write the default Dapr configuration to in self-hosted mode, instead of the config.yaml of the installation. It becomes the default of dapr run")
+	InitCmd.Flags().BoolVar(&initDryRun, "dry-run", false, "Print the downloads, files, containers and Helm release of the installation without installing Dapr")
 	InitCmd.Flags().BoolP("help", "h", false, "Print this help message")
 	InitCmd.Flags().StringArrayVar(&values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	InitCmd.Flags().StringArrayVarP(&valueFiles, "values", "f", []string{}, "Helm values file to install Dapr to a Kubernetes cluster with (can specify multiple)")
+	InitCmd.Flags().StringVar(&initHelmRepo, "helm-repo", "", "The URL of the Helm repository to install the Dapr chart from in Kubernetes, such as an internal mirror. Credentials are read from the Helm repositories config")
+	InitCmd.Flags().StringVar(&initChart, "chart", "", "The Dapr chart to install in Kubernetes, as an OCI reference such as oci://registry/dapr/dapr, or as repo/chart of a repository added with helm repo add")
 	InitCmd.Flags().String("image-registry", "", "Custom/Private docker image repository URL")
+	addNetworkFlags(InitCmd)
+	InitCmd.Flags().BoolVarP(&skipVerify, "insecure-skip-verify", "", false, "Do not verify the checksums of the downloaded binaries in self-hosted mode")
 	RootCmd.AddCommand(InitCmd)
 }
@@ -14,31 +14,54 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/dapr/cli/pkg/kubernetes"
 	"github.com/dapr/cli/pkg/print"
 	"github.com/dapr/cli/pkg/standalone"
 )
 
-const defaultHTTPVerb = http.MethodPost
+const (
+	defaultHTTPVerb = http.MethodPost
+	// newTrace is the value of --trace without a traceparent, which starts a new trace.
+	newTrace = "new"
+)
 
 var (
-	invokeAppID     string
-	invokeAppMethod string
-	invokeData      string
-	invokeVerb      string
-	invokeDataFile  string
-	invokeSocket    string
+	invokeAppID       string
+	invokeAppMethod   string
+	invokeData        string
+	invokeVerb        string
+	invokeDataFile    string
+	invokeSocket      string
+	invokeProtocol    string
+	invokeContentType string
+	invokeForm        []string
+	invokeHeaders     []string
+	invokeQuery       []string
+	invokeTrace       string
+	invokeTraceURL    string
+	invokeTimeout     time.Duration
+	invokeRetries     int
+	invokeBackoff     time.Duration
+	invokeOutputFile  string
+	invokeLoad        standalone.LoadConfig
+	invokePodName     string
+	invokeNamespace   string
 )
 
 var InvokeCmd = &cobra.Command{
 	Use:   "invoke",
-	Short: "Invoke a method on a given Dapr application. Supported platforms: Self-hosted",
+	Short: "Invoke a method on a given Dapr application. Supported platforms: Kubernetes and self-hosted",
 	Example: `
 # Invoke a sample method on target app with POST Verb
 dapr invoke --app-id target --method sample --data '{"key":"value"}
@@ -48,48 +71,234 @@ dapr invoke --app-id target --method sample --verb GET
 
 # Invoke a sample method on target app with GET Verb using Unix domain socket
 dapr invoke --unix-domain-socket --app-id target --method sample --verb GET
+
+# Invoke a sample method on target app using the gRPC API of the Dapr sidecar
+dapr invoke --app-id target --method sample --protocol grpc --data '{"key":"value"}'
+
+# Invoke a sample method on target app with a binary payload, read from a file or from stdin with -
+dapr invoke --app-id target --method upload --data-file image.png --content-type image/png
+cat image.png | dapr invoke --app-id target --method upload --data-file - --content-type image/png
+
+# Invoke a sample method on target app with a multipart/form-data payload of a field and a file
+dapr invoke --app-id target --method upload --form description=avatar --form image=@image.png
+
+# Invoke a sample method on a gRPC app with a proto encoded payload
+dapr invoke --app-id target --method sample --protocol grpc --data-file request.bin --content-type application/x-protobuf
+
+# Invoke a sample method on target app with custom headers and query parameters
+dapr invoke --app-id target --method sample --verb GET --header "Authorization: Bearer <token>" --header "Accept: application/json" --query id=42
+
+# Invoke a sample method on target app and save the binary response to a file
+dapr invoke --app-id target --method download --verb GET --output-file resp.bin
+
+# Invoke a sample method on target app and print the response status and the request and response headers
+dapr invoke --app-id target --method sample --header "traceparent: 00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01" --verbose
+
+# Invoke a sample method on target app in a new trace and print a link to the trace in Zipkin
+dapr invoke --app-id target --method sample --trace
+
+# Invoke a sample method on target app in an existing trace and print a link to the trace in Jaeger
+dapr invoke --app-id target --method sample --trace=00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01 --trace-url "http://localhost:16686/trace/{traceID}"
+
+# Invoke a sample method on target app, giving up on each attempt after 5 seconds and retrying up to 3 times
+dapr invoke --app-id target --method sample --timeout 5s --retries 3 --retry-backoff 500ms
+
+# Invoke a sample method on target app 1000 times, 10 at a time, and print the latency percentiles
+dapr invoke --app-id target --method sample --repeat 1000 --concurrency 10
+
+# Invoke a sample method on target app in a Kubernetes cluster through a port-forward to the sidecar of one of its pods
+dapr invoke -k --app-id target --method sample --namespace shop --data '{"key":"value"}'
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		bytePayload := []byte{}
 		var err error
 		if invokeDataFile != "" && invokeData != "" {
 			print.FailureStatusEvent(os.Stderr, "Only one of --data and --data-file allowed in the same invoke command")
-			os.Exit(1)
+			exit(1)
 		}
 
+		if len(invokeForm) > 0 && (invokeDataFile != "" || invokeData != "" || invokeContentType != "") {
+			print.FailureStatusEvent(os.Stderr, "--form cannot be used with --data, --data-file or --content-type")
+			exit(1)
+		}
+
+		contentType := invokeContentType
 		if invokeDataFile != "" {
-			bytePayload, err = os.ReadFile(invokeDataFile)
+			bytePayload, err = readInputFile(invokeDataFile)
 			if err != nil {
 				print.FailureStatusEvent(os.Stderr, "Error reading payload from '%s'. Error: %s", invokeDataFile, err)
-				os.Exit(1)
+				exit(1)
 			}
 		} else if invokeData != "" {
 			bytePayload = []byte(invokeData)
+		} else if len(invokeForm) > 0 {
+			bytePayload, contentType, err = standalone.MultipartPayload(invokeForm)
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+		}
+		headers, err := standalone.ParseHeaders(invokeHeaders)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
 		}
-		client := standalone.NewClient()
+		query, err := standalone.ParseQuery(invokeQuery)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		if invokeTrace != "" {
+			addTraceparent(headers, invokeTrace, invokeTraceURL)
+		}
+		client, stop := sidecarClient(invokeAppID, invokePodName, invokeNamespace, invokeSocket)
+		defer stop()
 
 		// TODO(@daixiang0): add Windows support.
 		if invokeSocket != "" {
 			if runtime.GOOS == "windows" {
 				print.FailureStatusEvent(os.Stderr, "The unix-domain-socket option is not supported on Windows")
-				os.Exit(1)
+				exit(1)
 			} else {
 				print.WarningStatusEvent(os.Stdout, "Unix domain sockets are currently a preview feature")
 			}
 		}
 
-		response, err := client.Invoke(invokeAppID, invokeAppMethod, bytePayload, invokeVerb, invokeSocket)
+		if contentType == "" {
+			contentType = standalone.DefaultInvokeContentType
+		}
+
+		var invoke func(ctx context.Context) (string, error)
+		switch strings.ToLower(invokeProtocol) {
+		case "http":
+			invoke = func(ctx context.Context) (string, error) {
+				return client.Invoke(ctx, invokeAppID, invokeAppMethod, bytePayload, invokeVerb, contentType, headers, query, invokeSocket)
+			}
+		case "grpc":
+			invoke = func(ctx context.Context) (string, error) {
+				return client.InvokeGRPC(ctx, invokeAppID, invokeAppMethod, bytePayload, invokeVerb, contentType, headers, query, invokeSocket)
+			}
+		default:
+			print.FailureStatusEvent(os.Stderr, "Invalid protocol %q. Valid values are: http or grpc", invokeProtocol)
+			exit(1)
+		}
+
+		policy := retryPolicy(invokeTimeout, invokeRetries, invokeBackoff)
+		if loadMode(cmd, invokeLoad) {
+			if invokeOutputFile != "" {
+				print.FailureStatusEvent(os.Stderr, "The --output-file flag cannot be used together with --repeat")
+				exit(1)
+			}
+			runLoad(invokeLoad, policy, func(ctx context.Context) error {
+				_, invokeErr := invoke(ctx)
+				return invokeErr
+			})
+			return
+		}
+
+		var response string
+		err = policy.Do(context.Background(), func(ctx context.Context) error {
+			var invokeErr error
+			response, invokeErr = invoke(ctx)
+			return invokeErr
+		})
 		if err != nil {
-			err = fmt.Errorf("error invoking app %s: %w", invokeAppID, err)
-			print.FailureStatusEvent(os.Stderr, err.Error())
-			os.Exit(1)
+			print.FailureStatusEvent(os.Stderr, "error invoking app %s: %s", invokeAppID, err)
+			exit(1)
 		}
 
+		if invokeOutputFile != "" {
+			if err = os.WriteFile(invokeOutputFile, []byte(response), 0o644); err != nil {
+				print.FailureStatusEvent(os.Stderr, "Error saving the response to %s: %s", invokeOutputFile, err)
+				exit(1)
+			}
+			print.SuccessStatusEvent(os.Stdout, "App invoked successfully, %d bytes of response saved to %s", len(response), invokeOutputFile)
+			return
+		}
 		if response != "" {
-			fmt.Println(response)
+			fmt.Println(string(standalone.IndentJSON([]byte(response))))
 		}
 		print.SuccessStatusEvent(os.Stdout, "App invoked successfully")
 	},
+	PostRun: func(cmd *cobra.Command, args []string) {
+		if kubernetesMode {
+			kubernetes.CheckForCertExpiry()
+		}
+	},
+}
+
+// sidecarClient returns the client of the sidecars run by the CLI or, in Kubernetes mode, a client of the sidecar of
+// appID in the cluster, whose ports are forwarded to the local machine until the returned function is called.
+// The sidecar of podName is used if given, otherwise the sidecar of the first running pod of the app.
+// In self-hosted mode, the app must run in namespace if it is given, and only the sidecars of namespace are called.
+func sidecarClient(appID, podName, namespace, socket string) (standalone.Client, func()) {
+	if !kubernetesMode {
+		if namespace != "" {
+			checkNamespace(appID, namespace)
+			return standalone.NewNamespaceClient(namespace), func() {}
+		}
+		return standalone.NewClient(), func() {}
+	}
+	if namespace == "" {
+		namespace = meta_v1.NamespaceDefault
+	}
+	if socket != "" {
+		print.FailureStatusEvent(os.Stderr, "The --unix-domain-socket flag cannot be used in Kubernetes mode")
+		exit(1)
+	}
+	session, err := kubernetes.StartDebugSession(appID, podName, namespace)
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, "Error connecting to the sidecar of app %s: %s", appID, err)
+		exit(1)
+	}
+	print.DebugStatusEvent(os.Stderr, "Forwarding the ports of the sidecar of pod %s", session.Pod)
+	return standalone.NewSidecarClient(appID, session.Port("http"), session.Port("grpc")), session.Stop
+}
+
+// checkNamespace exits with an error if the app started with dapr run is not running in namespace.
+func checkNamespace(appID, namespace string) {
+	apps, err := standalone.List()
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+	for _, a := range standalone.FilterNamespace(apps, namespace) {
+		if a.AppID == appID {
+			return
+		}
+	}
+	print.FailureStatusEvent(os.Stderr, "App %s is not running in namespace %s", appID, namespace)
+	exit(1)
+}
+
+// addTraceparent sets the traceparent header given by --trace, or of a new trace if it is "new",
+// and prints the trace ID and a link to the trace.
+func addTraceparent(headers http.Header, trace, traceURL string) {
+	traceparent := trace
+	if trace == newTrace {
+		var err error
+		traceparent, err = standalone.NewTraceparent()
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	}
+	traceID, err := standalone.ParseTraceparent(traceparent)
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+	headers.Set(standalone.TraceparentHeader, traceparent)
+	print.InfoStatusEvent(os.Stdout, "Trace ID: %s. View the trace at %s", traceID, standalone.TraceURL(traceURL, traceID))
+}
+
+// retryPolicy returns the policy given by the --timeout, --retries and --retry-backoff flags of a command.
+func retryPolicy(timeout time.Duration, retries int, backoff time.Duration) standalone.RetryPolicy {
+	if retries < 0 || timeout < 0 || backoff < 0 {
+		print.FailureStatusEvent(os.Stderr, "The --timeout, --retries and --retry-backoff flags must not be negative")
+		exit(1)
+	}
+	return standalone.RetryPolicy{Timeout: timeout, Retries: retries, Backoff: backoff}
 }
 
 func init() {
@@ -97,7 +306,23 @@ func init() {
 	InvokeCmd.Flags().StringVarP(&invokeAppMethod, "method", "m", "", "The method to invoke")
 	InvokeCmd.Flags().StringVarP(&invokeData, "data", "d", "", "The JSON serialized data string (optional)")
 	InvokeCmd.Flags().StringVarP(&invokeVerb, "verb", "v", defaultHTTPVerb, "The HTTP verb to use")
-	InvokeCmd.Flags().StringVarP(&invokeDataFile, "data-file", "f", "", "A file containing the JSON serialized data (optional)")
+	InvokeCmd.Flags().StringVarP(&invokeDataFile, "data-file", "f", "", "A file containing the JSON serialized or binary data, such as a proto encoded message, or - to read it from stdin (optional)")
+	InvokeCmd.Flags().StringVarP(&invokeProtocol, "protocol", "", "http", "The Dapr API used to invoke the app. Valid values are: http or grpc")
+	InvokeCmd.Flags().StringVarP(&invokeContentType, "content-type", "", "", "The content type of the data, for example: application/x-protobuf. Defaults to application/json")
+	InvokeCmd.Flags().StringArrayVarP(&invokeForm, "form", "F", []string{}, "A multipart/form-data field to send as name=value, or as name=@file to send the content of a file (can specify multiple)")
+	InvokeCmd.Flags().StringArrayVarP(&invokeHeaders, "header", "H", []string{}, "A header to send to the app as name:value, for example: \"Authorization: Bearer <token>\" (can specify multiple)")
+	InvokeCmd.Flags().StringArrayVarP(&invokeQuery, "query", "", []string{}, "A query parameter to send to the app as name=value, for example: id=42 (can specify multiple)")
+	InvokeCmd.Flags().StringVarP(&invokeTrace, "trace", "", "", "Send the request in a new trace, or in the trace of a traceparent given as --trace=<traceparent>, and print the trace ID")
+	InvokeCmd.Flags().Lookup("trace").NoOptDefVal = newTrace
+	InvokeCmd.Flags().StringVarP(&invokeTraceURL, "trace-url", "", standalone.DefaultTraceURL, "The link printed for the trace given by --trace. {traceID} is replaced by the trace ID")
+	InvokeCmd.Flags().DurationVar(&invokeTimeout, "timeout", 0, "The time to wait for a response of each attempt, for example: 5s. No limit if not set")
+	InvokeCmd.Flags().IntVar(&invokeRetries, "retries", 0, "The number of times to retry the invocation if it fails with a connection error, a timeout or a server error")
+	InvokeCmd.Flags().DurationVar(&invokeBackoff, "retry-backoff", standalone.DefaultRetryBackoff, "The time to wait before the first retry. It doubles for every further retry")
+	InvokeCmd.Flags().StringVar(&invokeOutputFile, "output-file", "", "Save the response to this file as is instead of printing it, for example for binary responses")
+	addLoadFlags(InvokeCmd, &invokeLoad)
+	InvokeCmd.Flags().BoolVarP(&kubernetesMode, "kubernetes", "k", false, "Invoke an app in a Kubernetes cluster through a port-forward to its sidecar")
+	InvokeCmd.Flags().StringVarP(&invokePodName, "pod-name", "", "", "The name of the pod whose sidecar is used in Kubernetes, in case the app has multiple pods. Defaults to the first running pod")
+	InvokeCmd.Flags().StringVarP(&invokeNamespace, "namespace", "n", "", "The namespace of the app. Defaults to default in Kubernetes mode")
 	InvokeCmd.Flags().BoolP("help", "h", false, "Print this help message")
 	InvokeCmd.Flags().StringVarP(&invokeSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Dapr API servers will use Unix Domain Sockets")
 	InvokeCmd.MarkFlagRequired("app-id")
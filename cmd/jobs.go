@@ -0,0 +1,171 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+var (
+	jobsAppID   string
+	jobsSocket  string
+	jobName     string
+	jobSchedule string
+	jobDueTime  string
+	jobRepeats  int
+	jobTTL      string
+	jobData     string
+	jobDataFile string
+)
+
+var JobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "Schedule and manage the jobs of the scheduler for a running app. Supported platforms: Self-hosted",
+	Long: `Schedule and manage the jobs of the scheduler for a running app.
+A job is called back on the app that scheduled it when it is due. The jobs API requires Dapr 1.14 or later.
+`,
+}
+
+var JobsScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Schedule a job, replacing a job with the same name. Supported platforms: Self-hosted",
+	Long: `Schedule a job, replacing a job with the same name.
+The schedule is a cron expression with seconds, such as "0 30 * * * *", or a period such as "@every 1h30m" or "@daily".
+The due time is an RFC3339 time or a duration from now, such as 10s. A job without a schedule runs once at its due time.
+`,
+	Example: `
+# Schedule the job backup of the app orders every hour, with data
+dapr jobs schedule --app-id orders --name backup --schedule "@every 1h" --data '{"db":"orders"}'
+
+# Schedule a job that runs once in 30 seconds
+dapr jobs schedule --app-id orders --name reminder --due-time 30s
+
+# Schedule a job that runs 3 times every minute, starting at a time, with the data of a file, or of stdin with -
+dapr jobs schedule --app-id orders --name retry --schedule "@every 1m" --repeats 3 --due-time 2024-10-01T10:00:00Z --data-file data.json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := workflowData(jobData, jobDataFile, "--data", "--data-file")
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		job := standalone.Job{
+			Schedule: jobSchedule,
+			DueTime:  jobDueTime,
+			Repeats:  jobRepeats,
+			TTL:      jobTTL,
+		}
+		if len(data) > 0 {
+			job.Data = standalone.ParseStateValue(data)
+		}
+		checkUnixDomainSocket(jobsSocket)
+		if err = standalone.NewClient().ScheduleJob(jobsAppID, jobName, job, jobsSocket); err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error scheduling the job: %s", err)
+			exit(1)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Scheduled job %s", jobName)
+	},
+}
+
+var JobsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the jobs of an app scheduled with the CLI. Supported platforms: Self-hosted",
+	Long: `List the jobs of an app scheduled with dapr jobs schedule.
+The jobs API cannot list jobs, so the CLI keeps the names of the jobs it scheduled in ~/.dapr/jobs.json.
+Jobs scheduled by the app itself are not listed. Jobs that no longer exist, such as jobs that have expired, are forgotten.
+`,
+	Example: `
+# List the jobs of the app orders
+dapr jobs list --app-id orders
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkUnixDomainSocket(jobsSocket)
+		jobs, err := standalone.NewClient().ListJobs(jobsAppID, jobsSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error listing the jobs: %s", err)
+			exit(1)
+		}
+		if len(jobs) == 0 && print.GetRenderer().Interactive() {
+			print.InfoStatusEvent(os.Stdout, "No jobs found")
+			return
+		}
+		if err = print.WriteTable(os.Stdout, jobs, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+}
+
+var JobsGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get a job. Supported platforms: Self-hosted",
+	Example: `
+# Get the job backup of the app orders
+dapr jobs get --app-id orders --name backup
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkUnixDomainSocket(jobsSocket)
+		job, err := standalone.NewClient().GetJob(jobsAppID, jobName, jobsSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error getting the job: %s", err)
+			exit(1)
+		}
+		if err = print.WriteTable(os.Stdout, []standalone.JobOutput{job}, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+}
+
+var JobsDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a job. Supported platforms: Self-hosted",
+	Example: `
+# Delete the job backup of the app orders
+dapr jobs delete --app-id orders --name backup
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkUnixDomainSocket(jobsSocket)
+		if err := standalone.NewClient().DeleteJob(jobsAppID, jobName, jobsSocket); err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error deleting the job: %s", err)
+			exit(1)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Deleted job %s", jobName)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{JobsScheduleCmd, JobsListCmd, JobsGetCmd, JobsDeleteCmd} {
+		c.Flags().StringVarP(&jobsAppID, "app-id", "a", "", "The ID of the app that receives the jobs. Required if more than one app is running")
+		c.Flags().StringVarP(&jobsSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Dapr API servers will use Unix Domain Sockets")
+		c.Flags().BoolP("help", "h", false, "Print this help message")
+		if c != JobsListCmd {
+			c.Flags().StringVar(&jobName, "name", "", "The name of the job")
+			c.MarkFlagRequired("name")
+		}
+		JobsCmd.AddCommand(c)
+	}
+	JobsScheduleCmd.Flags().StringVarP(&jobSchedule, "schedule", "s", "", "The schedule of the job, as a cron expression with seconds or a period such as @every 1h")
+	JobsScheduleCmd.Flags().StringVar(&jobDueTime, "due-time", "", "The time the job runs first, as an RFC3339 time or a duration from now such as 10s")
+	JobsScheduleCmd.Flags().IntVar(&jobRepeats, "repeats", 0, "The number of times the job runs. Defaults to no limit")
+	JobsScheduleCmd.Flags().StringVar(&jobTTL, "ttl", "", "The time after which the job expires, as an RFC3339 time or a duration from now")
+	JobsScheduleCmd.Flags().StringVarP(&jobData, "data", "d", "", "The data of the job. Data that is not JSON is sent as a string")
+	JobsScheduleCmd.Flags().StringVarP(&jobDataFile, "data-file", "", "", "A file containing the data of the job, or - to read it from stdin")
+	RootCmd.AddCommand(JobsCmd)
+}
@@ -17,7 +17,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/gocarina/gocsv"
 	"github.com/spf13/cobra"
 
 	"github.com/dapr/cli/pkg/kubernetes"
@@ -28,29 +27,41 @@ import (
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-var outputFormat string
+var (
+	outputFormat  string
+	labelSelector string
+	listSort      string
+	listFilter    map[string]string
+	listTemplate  string
+	listIssues    bool
+)
 
 func outputList(list interface{}, length int) {
+	if listTemplate != "" {
+		if err := print.WriteTemplate(os.Stdout, list, listTemplate); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		return
+	}
 	if outputFormat == "json" || outputFormat == "yaml" {
 		err := utils.PrintDetail(os.Stdout, outputFormat, list)
 		if err != nil {
 			print.FailureStatusEvent(os.Stdout, err.Error())
-			os.Exit(1)
+			exit(1)
 		}
 	} else {
-		table, err := gocsv.MarshalString(list)
-		if err != nil {
-			print.FailureStatusEvent(os.Stdout, err.Error())
-			os.Exit(1)
-		}
-
 		// Standalone mode displays a separate message when no instances are found.
 		if !kubernetesMode && length == 0 {
 			fmt.Println("No Dapr instances found.")
 			return
 		}
 
-		utils.PrintTable(table)
+		err := print.WriteTable(os.Stdout, list, outputFormat == "wide")
+		if err != nil {
+			print.FailureStatusEvent(os.Stdout, err.Error())
+			exit(1)
+		}
 	}
 }
 
@@ -69,35 +80,114 @@ dapr list -k --namespace default
 
 # List Dapr instances in all namespaces in  Kubernetes mode
 dapr list -k --all-namespaces
+
+# List Dapr instances of pods with the label app=checkout in all namespaces in Kubernetes mode
+dapr list -k -A -l app=checkout
+
+# List Dapr instances in Kubernetes mode with the name of their pod
+dapr list -k -A -o wide
+
+# List Dapr instances in self-hosted mode with additional columns
+dapr list -o wide
+
+# List Dapr instances in self-hosted mode started with dapr run --namespace shop
+dapr list --namespace shop
+
+# List Dapr instances in self-hosted mode as JSON for consumption by scripts
+dapr list -o json
+
+# List Dapr instances in self-hosted mode with the highest CPU usage first
+dapr list --sort cpu
+
+# List the Dapr instance of the app orders if it is running
+dapr list --filter app-id=orders,status=running
+
+# Print the app ID and HTTP port of each Dapr instance, for shell scripts
+dapr list --template '{{.AppID}} {{.HTTPPort}}'
+
+# Print the pod of each Dapr instance in the namespace default in Kubernetes mode
+dapr list -k --namespace default --template '{{.Pod}}'
+
+# List the pods whose sidecar was not injected or is crash-looping, with the error of the injector
+dapr list -k -A --issues
 `,
 	PreRun: func(cmd *cobra.Command, args []string) {
-		if outputFormat != "" && outputFormat != "json" && outputFormat != "yaml" && outputFormat != "table" {
+		if outputFormat != "" && outputFormat != "json" && outputFormat != "yaml" && outputFormat != "table" && outputFormat != "wide" {
 			print.FailureStatusEvent(os.Stdout, "An invalid output format was specified.")
-			os.Exit(1)
+			exit(1)
+		}
+		if listTemplate != "" && outputFormat != "" && outputFormat != "table" {
+			print.FailureStatusEvent(os.Stderr, "--template cannot be used together with --output %s", outputFormat)
+			exit(1)
+		}
+		if listSort != "" {
+			// The key is checked before the resource usage is sampled, which takes a moment.
+			if err := standalone.SortList(nil, listSort); err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
 		}
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		if kubernetesMode {
-			print.WarningStatusEvent(os.Stdout, "In future releases, this command will only query the \"default\" namespace by default. Please use the --namespace flag for a specific namespace, or the --all-namespaces (-A) flag for all namespaces.")
+			if listSort != "" {
+				print.FailureStatusEvent(os.Stderr, "The --sort flag is only supported in self-hosted mode")
+				exit(1)
+			}
+			if listTemplate == "" {
+				// The output of templates is read by scripts.
+				print.WarningStatusEvent(os.Stdout, "In future releases, this command will only query the \"default\" namespace by default. Please use the --namespace flag for a specific namespace, or the --all-namespaces (-A) flag for all namespaces.")
+			}
 			if allNamespaces {
 				resourceNamespace = meta_v1.NamespaceAll
 			} else if resourceNamespace == "" {
 				resourceNamespace = meta_v1.NamespaceAll
 			}
 
-			list, err := kubernetes.List(resourceNamespace)
+			if listIssues {
+				listInjectionIssues()
+				return
+			}
+
+			list, err := kubernetes.List(resourceNamespace, labelSelector)
 			if err != nil {
 				print.FailureStatusEvent(os.Stderr, err.Error())
-				os.Exit(1)
+				exit(1)
 			}
+			filtered, err := print.FilterRows(list, listFilter)
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			list = filtered.([]kubernetes.ListOutput)
 
 			outputList(list, len(list))
 		} else {
+			if labelSelector != "" || listIssues {
+				print.FailureStatusEvent(os.Stderr, "The --selector and --issues flags are only supported with --kubernetes")
+				exit(1)
+			}
 			list, err := standalone.List()
 			if err != nil {
 				print.FailureStatusEvent(os.Stderr, err.Error())
-				os.Exit(1)
+				exit(1)
+			}
+			if !allNamespaces {
+				list = standalone.FilterNamespace(list, resourceNamespace)
 			}
+			standalone.SampleResourceUsage(list, standalone.UsageSampleInterval)
+			if listSort != "" {
+				if err = standalone.SortList(list, listSort); err != nil {
+					print.FailureStatusEvent(os.Stderr, err.Error())
+					exit(1)
+				}
+			}
+			filtered, err := print.FilterRows(list, listFilter)
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			list = filtered.([]standalone.ListOutput)
 
 			outputList(list, len(list))
 		}
@@ -109,11 +199,37 @@ dapr list -k --all-namespaces
 	},
 }
 
+// listInjectionIssues prints the sidecar injection issues of the pods in resourceNamespace.
+func listInjectionIssues() {
+	issues, err := kubernetes.InjectionIssues(resourceNamespace, labelSelector)
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+	filtered, err := print.FilterRows(issues, listFilter)
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+	issues = filtered.([]kubernetes.InjectionIssue)
+
+	if len(issues) == 0 && listTemplate == "" && (outputFormat == "" || outputFormat == "table" || outputFormat == "wide") {
+		print.SuccessStatusEvent(os.Stdout, "No sidecar injection issues found")
+		return
+	}
+	outputList(issues, len(issues))
+}
+
 func init() {
 	ListCmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "If true, list all Dapr pods in all namespaces")
 	ListCmd.Flags().BoolVarP(&kubernetesMode, "kubernetes", "k", false, "List all Dapr pods in a Kubernetes cluster")
-	ListCmd.Flags().StringVarP(&resourceNamespace, "namespace", "", "", "List define namespace pod in a Kubernetes cluster")
-	ListCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "The output format of the list. Valid values are: json, yaml, or table (default)")
+	ListCmd.Flags().StringVarP(&resourceNamespace, "namespace", "", "", "List the Dapr instances of a namespace. All namespaces if not set")
+	ListCmd.Flags().StringVarP(&labelSelector, "selector", "l", "", "Only list Dapr pods matching the label selector in a Kubernetes cluster, for example: app=foo or 'tier in (web,api)'")
+	ListCmd.Flags().StringVarP(&outputFormat, "output", "o", "", "The output format of the list. Valid values are: json, yaml, wide, or table (default)")
+	ListCmd.Flags().StringVar(&listSort, "sort", "", "Sort the Dapr instances in self-hosted mode by: cpu, mem (highest usage of the sidecar and app first) or age (oldest first)")
+	ListCmd.Flags().StringToStringVar(&listFilter, "filter", nil, "Only list the Dapr instances whose fields have the given values, for example: app-id=orders,status=running. Keys are the fields of the JSON output in kebab case")
+	ListCmd.Flags().StringVar(&listTemplate, "template", "", "Print each Dapr instance with a Go template instead of a table, for example: '{{.AppID}} {{.HTTPPort}}'")
+	ListCmd.Flags().BoolVar(&listIssues, "issues", false, "List the pods annotated for Dapr whose sidecar was not injected or is crash-looping, and the workloads whose pods the sidecar injector rejected, with the error from their events")
 	ListCmd.Flags().BoolP("help", "h", false, "Print this help message")
 	RootCmd.AddCommand(ListCmd)
 }
@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+// addLoadFlags adds the --repeat, --concurrency and --interval flags of a command that can send its request
+// repeatedly as a quick load test.
+func addLoadFlags(cmd *cobra.Command, config *standalone.LoadConfig) {
+	cmd.Flags().IntVar(&config.Repeat, "repeat", 1, "Send the request this many times and print the latency percentiles and the errors at the end")
+	cmd.Flags().IntVar(&config.Concurrency, "concurrency", 1, "The number of requests in flight at the same time with --repeat")
+	cmd.Flags().DurationVar(&config.Interval, "interval", 0, "The time between the starts of two requests with --repeat, for example: 100ms. As fast as possible if not set")
+}
+
+// loadMode returns true if the load flags of cmd ask for more than a single request.
+func loadMode(cmd *cobra.Command, config standalone.LoadConfig) bool {
+	return config.Repeat != 1 || cmd.Flags().Changed("concurrency") || cmd.Flags().Changed("interval")
+}
+
+// runLoad sends request as configured by the load flags, until all requests are sent or the command is interrupted,
+// and prints the summary of the requests. It exits with 1 if any request failed.
+func runLoad(config standalone.LoadConfig, policy standalone.RetryPolicy, request func(ctx context.Context) error) {
+	if err := config.Validate(); err != nil {
+		print.FailureStatusEvent(os.Stderr, "Invalid --repeat, --concurrency or --interval: %s", err)
+		exit(1)
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	print.InfoStatusEvent(os.Stdout, "Sending %d requests with a concurrency of %d", config.Repeat, config.Concurrency)
+	result := standalone.RunLoad(ctx, config, func(ctx context.Context) error {
+		return policy.Do(ctx, request)
+	})
+	if err := print.WriteTable(os.Stdout, []standalone.LoadSummary{result.Summary()}, false); err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+
+	errs := make([]string, 0, len(result.Errors))
+	for msg := range result.Errors {
+		errs = append(errs, msg)
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		if result.Errors[errs[i]] != result.Errors[errs[j]] {
+			return result.Errors[errs[i]] > result.Errors[errs[j]]
+		}
+		return errs[i] < errs[j]
+	})
+	for _, msg := range errs {
+		print.WarningStatusEvent(os.Stderr, "%d x %s", result.Errors[msg], msg)
+	}
+	if ctx.Err() != nil {
+		print.WarningStatusEvent(os.Stderr, "Interrupted after %d of %d requests", result.Requests, config.Repeat)
+	}
+	if failed := result.ErrorCount(); failed > 0 {
+		print.FailureStatusEvent(os.Stderr, "%d of %d requests failed", failed, result.Requests)
+		exit(1)
+	}
+	print.SuccessStatusEvent(os.Stdout, "%d requests succeeded", result.Requests)
+}
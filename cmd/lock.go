@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+var (
+	lockAppID      string
+	lockStore      string
+	lockResourceID string
+	lockOwner      string
+	lockExpiry     int
+	lockSocket     string
+)
+
+var LockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Acquire and release distributed locks of a lock store through a running sidecar. Supported platforms: Self-hosted",
+}
+
+var LockTryAcquireCmd = &cobra.Command{
+	Use:   "try-acquire",
+	Short: "Try to acquire a lock, exiting with 1 if another owner holds it. Supported platforms: Self-hosted",
+	Example: `
+# Acquire the lock of a resource for 60 seconds
+dapr lock try-acquire --store lockstore --resource-id order1 --owner worker1 --expiry 60
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkUnixDomainSocket(lockSocket)
+		output, err := standalone.NewClient().TryLock(lockAppID, lockStore, lockResourceID, lockOwner, lockExpiry, lockSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error acquiring the lock: %s", err)
+			exit(1)
+		}
+		printLockOutput(output, func() {
+			print.SuccessStatusEvent(os.Stdout, "Lock of %s acquired by %s for %d seconds", output.ResourceID, output.Owner, lockExpiry)
+		}, func() {
+			print.FailureStatusEvent(os.Stderr, "Lock of %s is held by another owner", output.ResourceID)
+		})
+	},
+}
+
+var LockReleaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Release a lock held by an owner, exiting with 1 if it is not released. Supported platforms: Self-hosted",
+	Example: `
+# Release the lock of a resource
+dapr lock release --store lockstore --resource-id order1 --owner worker1
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkUnixDomainSocket(lockSocket)
+		output, err := standalone.NewClient().Unlock(lockAppID, lockStore, lockResourceID, lockOwner, lockSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error releasing the lock: %s", err)
+			exit(1)
+		}
+		printLockOutput(output, func() {
+			print.SuccessStatusEvent(os.Stdout, "Lock of %s released by %s", output.ResourceID, output.Owner)
+		}, func() {
+			print.FailureStatusEvent(os.Stderr, "Lock of %s was not released: %s", output.ResourceID, output.Status)
+		})
+	},
+}
+
+// printLockOutput prints output as a table in structured output, or else calls onSuccess or onFailure.
+// It exits with 1 if the lock was not acquired or released.
+func printLockOutput(output standalone.LockOutput, onSuccess, onFailure func()) {
+	switch {
+	case !print.GetRenderer().Interactive():
+		if err := print.WriteTable(os.Stdout, []standalone.LockOutput{output}, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	case output.Success:
+		onSuccess()
+	default:
+		onFailure()
+	}
+	if !output.Success {
+		exit(1)
+	}
+}
+
+func init() {
+	for _, c := range []*cobra.Command{LockTryAcquireCmd, LockReleaseCmd} {
+		c.Flags().StringVarP(&lockAppID, "app-id", "a", "", "The ID of the app whose sidecar is used. Required if more than one app is running")
+		c.Flags().StringVarP(&lockStore, "store", "s", "", "The name of the lock store component")
+		c.Flags().StringVarP(&lockResourceID, "resource-id", "r", "", "The ID of the resource to lock")
+		c.Flags().StringVarP(&lockOwner, "owner", "o", "", "The owner of the lock, which must be the same to release it")
+		c.Flags().StringVarP(&lockSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Dapr API servers will use Unix Domain Sockets")
+		c.Flags().BoolP("help", "h", false, "Print this help message")
+		c.MarkFlagRequired("store")
+		c.MarkFlagRequired("resource-id")
+		c.MarkFlagRequired("owner")
+		LockCmd.AddCommand(c)
+	}
+	LockTryAcquireCmd.Flags().IntVarP(&lockExpiry, "expiry", "e", 60, "The number of seconds after which the lock is released if it is not released before")
+
+	RootCmd.AddCommand(LockCmd)
+}
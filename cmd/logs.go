@@ -14,48 +14,124 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"os/signal"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/dapr/cli/pkg/kubernetes"
+	"github.com/dapr/cli/pkg/logfilter"
 	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
 )
 
 var (
-	logsAppID string
-	podName   string
-	namespace string
-	k8s       bool
+	logsAppID     string
+	podName       string
+	namespace     string
+	k8s           bool
+	follow        bool
+	tailLines     int
+	logsSince     time.Duration
+	logsContainer string
+	logsPrevious  bool
+	logsGrep      string
+	logsLevel     string
 )
 
 var LogsCmd = &cobra.Command{
 	Use:   "logs",
-	Short: "Get Dapr sidecar logs for an application. Supported platforms: Kubernetes",
+	Short: "Get Dapr sidecar logs for an application. Supported platforms: Kubernetes and self-hosted",
 	Example: `
 # Get logs of sample app from target pod in custom namespace
 dapr logs -k --app-id sample --pod-name target --namespace custom
+
+# Follow the sidecar logs of all pods of an app in Kubernetes, starting 10 minutes ago
+dapr logs -k --app-id sample --since 10m -f
+
+# Get the last 100 lines of the app container of the previous instance of the pods of an app in Kubernetes
+dapr logs -k --app-id sample --container app --previous --tail 100
+
+# Get the sidecar and app logs of an app started with dapr run
+dapr logs --app-id sample
+
+# Follow the last 20 lines of the logs of an app started with dapr run
+dapr logs --app-id sample --tail 20 -f
+
+# Follow the sidecar warnings and errors of an app in Kubernetes, highlighting the lines of a component
+dapr logs -k --app-id sample -f --level warn --grep statestore
 `,
 	Run: func(cmd *cobra.Command, args []string) {
-		err := kubernetes.Logs(logsAppID, podName, namespace)
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+		out, err := logfilter.NewWriter(os.Stdout, logfilter.Config{Level: logsLevel, Pattern: logsGrep})
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		if !k8s {
+			if logsSince != 0 || logsPrevious || cmd.Flags().Changed("container") {
+				print.FailureStatusEvent(os.Stderr, "The --since, --container and --previous flags are only supported in Kubernetes mode")
+				exit(1)
+			}
+			// The namespace of an app started with dapr run is empty unless given with --namespace.
+			appNamespace := ""
+			if cmd.Flags().Changed("namespace") {
+				appNamespace = namespace
+			}
+			err = standalone.Logs(ctx, out, appNamespace, logsAppID, tailLines, follow)
+			out.Flush()
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			return
+		}
+
+		err = kubernetes.Logs(ctx, out, kubernetes.LogsOptions{
+			AppID:     logsAppID,
+			PodName:   podName,
+			Namespace: namespace,
+			Container: logsContainer,
+			Follow:    follow,
+			Since:     logsSince,
+			Tail:      int64(tailLines),
+			Previous:  logsPrevious,
+		})
+		out.Flush()
 		if err != nil {
 			print.FailureStatusEvent(os.Stderr, err.Error())
-			os.Exit(1)
+			exit(1)
+		}
+		if follow {
+			return
 		}
 		print.SuccessStatusEvent(os.Stdout, "Fetched logs")
 	},
 	PostRun: func(cmd *cobra.Command, args []string) {
-		kubernetes.CheckForCertExpiry()
+		if k8s {
+			kubernetes.CheckForCertExpiry()
+		}
 	},
 }
 
 func init() {
-	LogsCmd.Flags().BoolVarP(&k8s, "kubernetes", "k", true, "Get logs from a Kubernetes cluster")
+	LogsCmd.Flags().BoolVarP(&k8s, "kubernetes", "k", false, "Get logs from a Kubernetes cluster")
 	LogsCmd.Flags().StringVarP(&logsAppID, "app-id", "a", "", "The application id for which logs are needed")
-	LogsCmd.Flags().StringVarP(&podName, "pod-name", "p", "", "The name of the pod in Kubernetes, in case your application has multiple pods (optional)")
-	LogsCmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "The Kubernetes namespace in which your application is deployed")
+	LogsCmd.Flags().StringVarP(&podName, "pod-name", "p", "", "The name of the pod in Kubernetes. Defaults to all pods of the app, with their lines prefixed with the pod name")
+	LogsCmd.Flags().StringVarP(&namespace, "namespace", "n", "default", "The Kubernetes namespace in which your application is deployed, or the namespace of an app started with dapr run --namespace")
+	LogsCmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep printing new log lines")
+	LogsCmd.Flags().IntVar(&tailLines, "tail", -1, "The number of most recent log lines to print, of each pod in Kubernetes. Prints all lines if negative")
+	LogsCmd.Flags().DurationVar(&logsSince, "since", 0, "Only print the log lines newer than a duration, such as 10m, in Kubernetes")
+	LogsCmd.Flags().StringVar(&logsContainer, "container", kubernetes.LogsContainerDaprd, "The container to print the logs of in Kubernetes. Valid values are: daprd, app")
+	LogsCmd.Flags().BoolVar(&logsPrevious, "previous", false, "Print the logs of the previous instance of the container in Kubernetes, such as one that crashed")
+	LogsCmd.Flags().StringVar(&logsGrep, "grep", "", "Highlight the matches of a regular expression in the log lines")
+	LogsCmd.Flags().StringVar(&logsLevel, "level", "", fmt.Sprintf("Only print the sidecar lines of this log level or more severe. Lines without a level, such as those of apps, are always printed. Valid values are: %s", strings.Join(logfilter.Levels, ", ")))
 	LogsCmd.Flags().BoolP("help", "h", false, "Print this help message")
 	LogsCmd.MarkFlagRequired("app-id")
-	LogsCmd.MarkFlagRequired("kubernetes")
 	RootCmd.AddCommand(LogsCmd)
 }
@@ -14,10 +14,10 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/spf13/cobra"
 
@@ -25,7 +25,20 @@ import (
 	"github.com/dapr/cli/pkg/print"
 )
 
-var exportPath string
+const (
+	exportFormatFiles            = "files"
+	exportFormatKubernetesSecret = "kubernetes-secret"
+)
+
+var (
+	exportPath         string
+	exportFormat       string
+	exportSecretName   string
+	exportNamespace    string
+	exportApply        bool
+	expiryWarnDays     int
+	expiryOutputFormat string
+)
 
 var MTLSCmd = &cobra.Command{
 	Use:   "mtls",
@@ -38,7 +51,7 @@ dapr mtls -k
 		enabled, err := kubernetes.IsMTLSEnabled()
 		if err != nil {
 			print.FailureStatusEvent(os.Stderr, fmt.Sprintf("error checking mTLS: %s", err))
-			os.Exit(1)
+			exit(1)
 		}
 
 		status := "disabled"
@@ -54,42 +67,114 @@ dapr mtls -k
 
 var ExportCMD = &cobra.Command{
 	Use:   "export",
-	Short: "Export the root CA, issuer cert and key from Kubernetes to local files",
+	Short: "Export the root CA, issuer cert and key from Kubernetes to local files, or the root CA to a Secret",
+	Long: `Export the root CA, issuer cert and key from Kubernetes to local files.
+
+With --format kubernetes-secret, only the root CA is exported, as the ca.crt key of a Secret manifest printed to stdout,
+so that the trust anchors can be propagated to workloads outside the Dapr system namespace. Use --apply to create or
+update the Secret in the cluster directly. The issuer cert and key are never exported to a Secret.
+`,
 	Example: `
 # Export certs to local folder 
 dapr mtls export -o ./certs
+
+# Print a Secret manifest with the root CA for the namespace payments, and apply it with kubectl
+dapr mtls export --format kubernetes-secret --namespace payments | kubectl apply -f -
+
+# Create or update the Secret trust-anchors with the root CA in the namespace payments
+dapr mtls export --format kubernetes-secret --namespace payments --secret-name trust-anchors --apply
 `,
 	Run: func(cmd *cobra.Command, args []string) {
+		switch exportFormat {
+		case exportFormatFiles:
+			if exportApply || cmd.Flags().Changed("secret-name") || cmd.Flags().Changed("namespace") {
+				print.FailureStatusEvent(os.Stderr, "The --secret-name, --namespace and --apply flags require --format kubernetes-secret")
+				exit(1)
+			}
+		case exportFormatKubernetesSecret:
+			exportTrustAnchorsSecret()
+			return
+		default:
+			print.FailureStatusEvent(os.Stderr, "Invalid format %q. Valid values are: %s, %s", exportFormat, exportFormatFiles, exportFormatKubernetesSecret)
+			exit(1)
+		}
+
 		err := kubernetes.ExportTrustChain(exportPath)
 		if err != nil {
 			print.FailureStatusEvent(os.Stderr, fmt.Sprintf("error exporting trust chain certs: %s", err))
-			os.Exit(1)
+			exit(1)
 		}
 
 		dir, _ := filepath.Abs(exportPath)
 		print.SuccessStatusEvent(os.Stdout, fmt.Sprintf("Trust certs successfully exported to %s", dir))
 	},
 	PostRun: func(cmd *cobra.Command, args []string) {
+		if exportFormat == exportFormatKubernetesSecret && !exportApply {
+			// The warning would be applied as part of the manifest printed to stdout.
+			return
+		}
 		kubernetes.CheckForCertExpiry()
 	},
 }
 
+// exportTrustAnchorsSecret prints the Secret with the root CA of the cluster, or applies it with --apply.
+func exportTrustAnchorsSecret() {
+	secret, err := kubernetes.ExportTrustAnchorsSecret(exportSecretName, exportNamespace, exportApply)
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, "error exporting trust anchors: %s", err)
+		exit(1)
+	}
+	if exportApply {
+		print.SuccessStatusEvent(os.Stdout, "Trust anchors successfully exported to Secret %s in namespace %s", secret.Name, secret.Namespace)
+		return
+	}
+	b, err := kubernetes.MarshalSecret(secret)
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+	os.Stdout.Write(b)
+}
+
 var ExpiryCMD = &cobra.Command{
 	Use:   "expiry",
 	Short: "Checks the expiry of the root certificate",
 	Example: `
 # Check expiry of Kubernetes certs
 dapr mtls expiry
+
+# Warn if the root certificate expires within the next 60 days
+dapr mtls expiry --warn-days 60
+
+# Print the expiry status as JSON, for example to feed it into monitoring
+dapr mtls expiry -o json
 `,
 	Run: func(cmd *cobra.Command, args []string) {
-		expiry, err := kubernetes.Expiry()
+		if expiryOutputFormat != "" && expiryOutputFormat != "json" {
+			print.FailureStatusEvent(os.Stderr, "Invalid output format %q. Supported format: json", expiryOutputFormat)
+			exit(1)
+		}
+
+		status, err := kubernetes.GetCertExpiryStatus(expiryWarnDays)
 		if err != nil {
 			print.FailureStatusEvent(os.Stderr, fmt.Sprintf("error getting root cert expiry: %s", err))
+			exit(1)
+		}
+
+		if expiryOutputFormat == "json" {
+			b, err := json.MarshalIndent(status, "", "  ")
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			fmt.Println(string(b))
 			return
 		}
 
-		duration := int(expiry.Sub(time.Now().UTC()).Hours())
-		fmt.Printf("Root certificate expires in %v hours. Expiry date: %s", duration, expiry.String())
+		fmt.Printf("Root certificate expires in %v hours. Expiry date: %s\n", status.HoursRemaining, status.Expiry.String())
+		if status.ExpiringSoon || status.Expired {
+			print.WarningStatusEvent(os.Stdout, status.Message())
+		}
 	},
 }
 
@@ -97,7 +182,14 @@ func init() {
 	MTLSCmd.Flags().BoolVarP(&kubernetesMode, "kubernetes", "k", false, "Check if mTLS is enabled in a Kubernetes cluster")
 	MTLSCmd.Flags().BoolP("help", "h", false, "Print this help message")
 	ExportCMD.Flags().StringVarP(&exportPath, "out", "o", ".", "The output directory path to save the certs")
+	ExportCMD.Flags().StringVar(&exportFormat, "format", exportFormatFiles, "The format to export to. Valid values are: files, kubernetes-secret")
+	ExportCMD.Flags().StringVar(&exportSecretName, "secret-name", kubernetes.DefaultTrustAnchorsSecretName, "The name of the Secret with --format kubernetes-secret")
+	ExportCMD.Flags().StringVarP(&exportNamespace, "namespace", "n", "default", "The namespace of the Secret with --format kubernetes-secret")
+	ExportCMD.Flags().BoolVar(&exportApply, "apply", false, "Create or update the Secret in the cluster instead of printing it, with --format kubernetes-secret")
 	ExportCMD.Flags().BoolP("help", "h", false, "Print this help message")
+	ExpiryCMD.Flags().IntVar(&expiryWarnDays, "warn-days", kubernetes.DefaultCertExpiryWarningDays, "Warn if the root certificate expires within this number of days")
+	ExpiryCMD.Flags().StringVarP(&expiryOutputFormat, "output", "o", "", "The output format of the expiry status (options: json)")
+	ExpiryCMD.Flags().BoolP("help", "h", false, "Print this help message")
 	MTLSCmd.MarkFlagRequired("kubernetes")
 	MTLSCmd.AddCommand(ExportCMD)
 	MTLSCmd.AddCommand(ExpiryCMD)
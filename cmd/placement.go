@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/dapr/cli/pkg/kubernetes"
+	"github.com/dapr/cli/pkg/placement"
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+var (
+	placementHostAddress string
+	placementTimeout     time.Duration
+)
+
+var PlacementCmd = &cobra.Command{
+	Use:   "placement",
+	Short: "Inspect the placement service of Dapr actors. Supported platforms: Kubernetes and self-hosted",
+}
+
+var PlacementDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Print the actor placement table. Supported platforms: Kubernetes and self-hosted",
+	Long: `Print the actor placement table of the placement service: the hosts of every actor type
+and the share of the virtual nodes of its consistent hash ring that each host owns.
+Actors are distributed to the hosts of their type in proportion to that share.
+`,
+	Example: `
+# Print the placement table of the placement service started by dapr init
+dapr placement dump
+
+# Print the placement table of the placement service in Kubernetes as JSON
+dapr placement dump -k --output-format json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), placementTimeout)
+		defer cancel()
+
+		var (
+			rows    []placement.TableOutput
+			version string
+			err     error
+		)
+		if kubernetesMode {
+			rows, version, err = kubernetes.DumpPlacement(ctx)
+		} else {
+			address := placementHostAddress
+			if address == "" {
+				address = fmt.Sprintf("%s:%d", standalone.ServiceHost(), standalone.PlacementPort())
+			}
+			rows, version, err = placement.Dump(ctx, address, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+		}
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error reading the placement table: %s", err)
+			exit(1)
+		}
+
+		if print.GetRenderer().Interactive() {
+			print.InfoStatusEvent(os.Stdout, "Placement table version %s", version)
+			if len(rows) == 0 {
+				print.InfoStatusEvent(os.Stdout, "No actor types are hosted")
+				return
+			}
+		}
+		if err = print.WriteTable(os.Stdout, rows, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	PlacementDumpCmd.Flags().BoolVarP(&kubernetesMode, "kubernetes", "k", false, "Read the placement table of the placement service in a Kubernetes cluster through a port-forward")
+	PlacementDumpCmd.Flags().StringVarP(&placementHostAddress, "placement-host-address", "", "", "The address of the placement service in self-hosted mode. Defaults to the placement service started by dapr init")
+	PlacementDumpCmd.Flags().DurationVarP(&placementTimeout, "timeout", "", 30*time.Second, "The time to wait for the placement table")
+	PlacementDumpCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	PlacementCmd.AddCommand(PlacementDumpCmd)
+
+	RootCmd.AddCommand(PlacementCmd)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/plugin"
+	"github.com/dapr/cli/pkg/print"
+)
+
+var PluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Manage CLI plugins, executables named dapr-<name> in the PATH that are run as dapr <name>",
+	Long: `Manage CLI plugins.
+A plugin is an executable in the PATH whose name starts with dapr-, such as dapr-deploy. It is run with the
+remaining arguments when its name is given as a command that is not built into the CLI, for example dapr deploy orders.
+Dashes in the name of a plugin are given as separate words, so dapr-deploy-app is run by dapr deploy app.
+`,
+}
+
+var PluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the plugins found in the PATH",
+	Example: `
+# List the plugins
+dapr plugin list
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		plugins := plugin.List(os.Getenv("PATH"))
+		if len(plugins) == 0 && print.GetRenderer().Interactive() {
+			print.InfoStatusEvent(os.Stdout, "No plugins found in the PATH")
+			return
+		}
+		if err := print.WriteTable(os.Stdout, plugins, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		for _, p := range plugins {
+			if p.Shadowed {
+				print.WarningStatusEvent(os.Stderr, "%s is not run as another plugin named %s comes first in the PATH", p.Path, p.Name)
+			} else if isBuiltinCommand(p.Name) {
+				print.WarningStatusEvent(os.Stderr, "%s is not run as %s is a built-in command", p.Path, strings.ReplaceAll(p.Name, "-", " "))
+			}
+		}
+	},
+}
+
+// runPlugin runs the plugin that handles args, the arguments of the CLI, if they do not name a built-in command.
+// It returns false if there is no such plugin.
+func runPlugin(args []string) (int, bool) {
+	if len(args) == 0 || isBuiltinCommand(args[0]) {
+		return 0, false
+	}
+	path, pluginArgs, ok := plugin.Find(args)
+	if !ok {
+		return 0, false
+	}
+	code, err := plugin.Run(path, pluginArgs)
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, "Error running plugin %s: %s", path, err)
+	}
+	return code, true
+}
+
+// isBuiltinCommand returns true if name is the name or an alias of a command of the CLI.
+func isBuiltinCommand(name string) bool {
+	// The name of a plugin with dashes is run by the words between them, so its first word can clash too.
+	first, _, _ := strings.Cut(name, "-")
+	for _, n := range []string{name, first} {
+		for _, c := range RootCmd.Commands() {
+			if c.Name() == n || c.HasAlias(n) {
+				return true
+			}
+		}
+		if n == "help" || n == cobra.ShellCompRequestCmd || n == cobra.ShellCompNoDescRequestCmd {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	PluginListCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	PluginCmd.AddCommand(PluginListCmd)
+	RootCmd.AddCommand(PluginCmd)
+}
@@ -14,13 +14,18 @@ limitations under the License.
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
 	"os"
 	"runtime"
+	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/dapr/cli/pkg/kubernetes"
 	"github.com/dapr/cli/pkg/print"
 	"github.com/dapr/cli/pkg/standalone"
 )
@@ -32,12 +37,28 @@ var (
 	publishPayload     string
 	publishPayloadFile string
 	publishSocket      string
-	publishMetadata    string
+	publishMetadata    []string
+	publishEventID     string
+	publishSource      string
+	publishEventType   string
+	publishRaw         bool
+	publishBulk        bool
+	publishTrace       string
+	publishTraceURL    string
+	publishTimeout     time.Duration
+	publishRetries     int
+	publishBackoff     time.Duration
+	publishLoad        standalone.LoadConfig
+	publishPodName     string
+	publishNamespace   string
 )
 
+// maxBulkEventSize is the maximum size of a single event in a bulk publish data file.
+const maxBulkEventSize = 4 << 20
+
 var PublishCmd = &cobra.Command{
 	Use:   "publish",
-	Short: "Publish a pub-sub event. Supported platforms: Self-hosted",
+	Short: "Publish a pub-sub event. Supported platforms: Kubernetes and self-hosted",
 	Example: `
 # Publish to sample topic in target pubsub via a publishing app
 dapr publish --publish-app-id myapp --pubsub target --topic sample --data '{"key":"value"}'
@@ -45,55 +66,197 @@ dapr publish --publish-app-id myapp --pubsub target --topic sample --data '{"key
 # Publish to sample topic in target pubsub via a publishing app using Unix domain socket
 dapr publish --enable-domain-socket --publish-app-id myapp --pubsub target --topic sample --data '{"key":"value"}'
 
-# Publish to sample topic in target pubsub via a publishing app without cloud event
-dapr publish --publish-app-id myapp --pubsub target --topic sample --data '{"key":"value"}' --metadata '{"rawPayload":"true","ttlInSeconds":"10"}'
+# Publish to sample topic in target pubsub via a publishing app without cloud event, expiring after 10 seconds
+dapr publish --publish-app-id myapp --pubsub target --topic sample --data '{"key":"value"}' --raw --metadata ttlInSeconds=10
+
+# Publish to sample topic in target pubsub in a CloudEvents envelope with the attributes of another producer
+dapr publish --publish-app-id myapp --pubsub target --topic sample --data '{"key":"value"}' --id order-1 --source checkout --type com.example.order.created
+
+# Publish all events in a file, one event per line, to sample topic in a single call using the bulk publish API
+dapr publish --publish-app-id myapp --pubsub target --topic sample --bulk --data-file events.jsonl
+
+# Publish to sample topic in target pubsub in a new trace and print a link to the trace in Zipkin
+dapr publish --publish-app-id myapp --pubsub target --topic sample --data '{"key":"value"}' --trace
+
+# Publish to sample topic in target pubsub, giving up on each attempt after 5 seconds and retrying up to 3 times
+dapr publish --publish-app-id myapp --pubsub target --topic sample --data '{"key":"value"}' --timeout 5s --retries 3
+
+# Publish to sample topic in target pubsub 500 times, one event every 100 milliseconds, and print the latency percentiles
+dapr publish --publish-app-id myapp --pubsub target --topic sample --data '{"key":"value"}' --repeat 500 --interval 100ms
+
+# Publish to sample topic in target pubsub via the sidecar of a publishing app in a Kubernetes cluster
+dapr publish -k --publish-app-id myapp --pubsub target --topic sample --namespace shop --data '{"key":"value"}'
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		bytePayload := []byte{}
 		var err error
 		if publishPayloadFile != "" && publishPayload != "" {
 			print.FailureStatusEvent(os.Stderr, "Only one of --data and --data-file allowed in the same publish command")
-			os.Exit(1)
+			exit(1)
+		}
+
+		if publishBulk && publishPayloadFile == "" {
+			print.FailureStatusEvent(os.Stderr, "The --bulk flag requires a file with one event per line passed with --data-file")
+			exit(1)
 		}
 
 		if publishPayloadFile != "" {
 			bytePayload, err = os.ReadFile(publishPayloadFile)
 			if err != nil {
 				print.FailureStatusEvent(os.Stderr, "Error reading payload from '%s'. Error: %s", publishPayloadFile, err)
-				os.Exit(1)
+				exit(1)
 			}
 		} else if publishPayload != "" {
 			bytePayload = []byte(publishPayload)
 		}
 
-		client := standalone.NewClient()
+		client, stop := sidecarClient(publishAppID, publishPodName, publishNamespace, publishSocket)
+		defer stop()
 		// TODO(@daixiang0): add Windows support.
 		if publishSocket != "" {
 			if runtime.GOOS == "windows" {
 				print.FailureStatusEvent(os.Stderr, "The unix-domain-socket option is not supported on Windows")
-				os.Exit(1)
+				exit(1)
 			} else {
 				print.WarningStatusEvent(os.Stdout, "Unix domain sockets are currently a preview feature")
 			}
 		}
 
-		metadata := make(map[string]interface{})
-		if publishMetadata != "" {
-			err = json.Unmarshal([]byte(publishMetadata), &metadata)
+		metadata, err := standalone.ParsePublishMetadata(publishMetadata)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+
+		envelope := standalone.CloudEventOptions{ID: publishEventID, Source: publishSource, Type: publishEventType}
+		wrap := envelope != standalone.CloudEventOptions{}
+		if wrap && publishRaw {
+			print.FailureStatusEvent(os.Stderr, "The --raw flag cannot be used together with --id, --source or --type")
+			exit(1)
+		}
+		if publishEventID != "" && publishBulk {
+			print.FailureStatusEvent(os.Stderr, "The --id flag cannot be used together with --bulk, every event gets a random ID")
+			exit(1)
+		}
+		if publishRaw {
+			metadata["rawPayload"] = "true"
+		}
+		if envelope.Source == "" {
+			// The sidecar uses the app ID as the source of the events it wraps itself.
+			envelope.Source = publishAppID
+		}
+
+		headers := http.Header{}
+		if publishTrace != "" {
+			addTraceparent(headers, publishTrace, publishTraceURL)
+		}
+
+		policy := retryPolicy(publishTimeout, publishRetries, publishBackoff)
+		load := loadMode(cmd, publishLoad)
+		if load && (publishBulk || publishEventID != "") {
+			print.FailureStatusEvent(os.Stderr, "The --bulk and --id flags cannot be used together with --repeat")
+			exit(1)
+		}
+		if publishBulk {
+			var bulkEnvelope *standalone.CloudEventOptions
+			if wrap {
+				bulkEnvelope = &envelope
+			}
+			bulkPublish(client, policy, bytePayload, headers, metadata, bulkEnvelope)
+			return
+		}
+		if load {
+			runLoad(publishLoad, policy, func(ctx context.Context) error {
+				event := bytePayload
+				if wrap {
+					// Every event gets an envelope of its own, with a random ID.
+					var wrapErr error
+					if event, wrapErr = standalone.WrapCloudEvent(bytePayload, envelope); wrapErr != nil {
+						return wrapErr
+					}
+				}
+				return client.Publish(ctx, publishAppID, pubsubName, publishTopic, event, headers, publishSocket, metadata)
+			})
+			return
+		}
+		if wrap {
+			bytePayload, err = standalone.WrapCloudEvent(bytePayload, envelope)
 			if err != nil {
-				print.FailureStatusEvent(os.Stderr, "Error parsing metadata as JSON. Error: %s", err)
-				os.Exit(1)
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
 			}
 		}
 
-		err = client.Publish(publishAppID, pubsubName, publishTopic, bytePayload, publishSocket, metadata)
+		err = policy.Do(context.Background(), func(ctx context.Context) error {
+			return client.Publish(ctx, publishAppID, pubsubName, publishTopic, bytePayload, headers, publishSocket, metadata)
+		})
 		if err != nil {
-			print.FailureStatusEvent(os.Stderr, fmt.Sprintf("Error publishing topic %s: %s", publishTopic, err))
-			os.Exit(1)
+			print.FailureStatusEvent(os.Stderr, "Error publishing topic %s: %s", publishTopic, err)
+			exit(1)
 		}
 
 		print.SuccessStatusEvent(os.Stdout, "Event published successfully")
 	},
+	PostRun: func(cmd *cobra.Command, args []string) {
+		if kubernetesMode {
+			kubernetes.CheckForCertExpiry()
+		}
+	},
+}
+
+// bulkPublish publishes every line of payload as a separate event and reports how many of them failed.
+// Every event is wrapped in a CloudEvents envelope with the attributes of envelope if it is not nil.
+func bulkPublish(client standalone.Client, policy standalone.RetryPolicy, payload []byte, headers http.Header, metadata map[string]interface{}, envelope *standalone.CloudEventOptions) {
+	events := [][]byte{}
+	// The entry ID of each event is its index in events, lineNumbers maps it back to the file.
+	lineNumbers := []int{}
+	scanner := bufio.NewScanner(bytes.NewReader(payload))
+	scanner.Buffer(make([]byte, 64*1024), maxBulkEventSize)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		event := append([]byte{}, line...)
+		if envelope != nil {
+			var err error
+			if event, err = standalone.WrapCloudEvent(event, *envelope); err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+		}
+		events = append(events, event)
+		lineNumbers = append(lineNumbers, lineNumber)
+	}
+	if err := scanner.Err(); err != nil {
+		print.FailureStatusEvent(os.Stderr, "Error reading events from '%s'. Error: %s", publishPayloadFile, err)
+		exit(1)
+	}
+
+	var result standalone.BulkPublishResult
+	err := policy.Do(context.Background(), func(ctx context.Context) error {
+		var publishErr error
+		result, publishErr = client.BulkPublish(ctx, publishAppID, pubsubName, publishTopic, events, headers, publishSocket, metadata)
+		return publishErr
+	})
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, "Error bulk publishing to topic %s: %s", publishTopic, err)
+		exit(1)
+	}
+
+	for _, entry := range result.FailedEntries {
+		if i, convErr := strconv.Atoi(entry.EntryID); convErr == nil && i >= 0 && i < len(lineNumbers) {
+			print.WarningStatusEvent(os.Stdout, "Event on line %d failed: %s", lineNumbers[i], entry.Error)
+		} else {
+			print.WarningStatusEvent(os.Stdout, "Event %s failed: %s", entry.EntryID, entry.Error)
+		}
+	}
+	failed := len(result.FailedEntries)
+	if failed > 0 {
+		print.FailureStatusEvent(os.Stderr, "Published %d of %d events, %d failed", result.Total-failed, result.Total, failed)
+		exit(1)
+	}
+	print.SuccessStatusEvent(os.Stdout, "Published %d of %d events successfully", result.Total, result.Total)
 }
 
 func init() {
@@ -103,7 +266,22 @@ func init() {
 	PublishCmd.Flags().StringVarP(&publishPayload, "data", "d", "", "The JSON serialized data string (optional)")
 	PublishCmd.Flags().StringVarP(&publishPayloadFile, "data-file", "f", "", "A file containing the JSON serialized data (optional)")
 	PublishCmd.Flags().StringVarP(&publishSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Dapr API servers will use Unix Domain Sockets")
-	PublishCmd.Flags().StringVarP(&publishMetadata, "metadata", "m", "", "The JSON serialized publish metadata (optional)")
+	PublishCmd.Flags().StringArrayVarP(&publishMetadata, "metadata", "m", []string{}, "The publish metadata, such as ttlInSeconds or partitionKey, as key=value pairs or a JSON object. Can be repeated")
+	PublishCmd.Flags().StringVar(&publishEventID, "id", "", "The ID of the CloudEvents envelope of the event. Defaults to a random UUID")
+	PublishCmd.Flags().StringVar(&publishSource, "source", "", "The source of the CloudEvents envelope of the event. Defaults to the ID of the publishing app")
+	PublishCmd.Flags().StringVar(&publishEventType, "type", "", "The type of the CloudEvents envelope of the event. Defaults to "+standalone.DefaultCloudEventType)
+	PublishCmd.Flags().BoolVar(&publishRaw, "raw", false, "Publish the data as is, without wrapping it in a CloudEvents envelope. Shortcut for --metadata rawPayload=true")
+	PublishCmd.Flags().BoolVar(&publishBulk, "bulk", false, "Publish every line of the file given by --data-file as a separate event in a single call using the bulk publish API")
+	PublishCmd.Flags().StringVarP(&publishTrace, "trace", "", "", "Publish the event in a new trace, or in the trace of a traceparent given as --trace=<traceparent>, and print the trace ID")
+	PublishCmd.Flags().Lookup("trace").NoOptDefVal = newTrace
+	PublishCmd.Flags().StringVarP(&publishTraceURL, "trace-url", "", standalone.DefaultTraceURL, "The link printed for the trace given by --trace. {traceID} is replaced by the trace ID")
+	PublishCmd.Flags().DurationVar(&publishTimeout, "timeout", 0, "The time to wait for a response of each attempt, for example: 5s. No limit if not set")
+	PublishCmd.Flags().IntVar(&publishRetries, "retries", 0, "The number of times to retry publishing if it fails with a connection error, a timeout or a server error")
+	PublishCmd.Flags().DurationVar(&publishBackoff, "retry-backoff", standalone.DefaultRetryBackoff, "The time to wait before the first retry. It doubles for every further retry")
+	addLoadFlags(PublishCmd, &publishLoad)
+	PublishCmd.Flags().BoolVarP(&kubernetesMode, "kubernetes", "k", false, "Publish through the sidecar of the publishing app in a Kubernetes cluster, reached through a port-forward")
+	PublishCmd.Flags().StringVarP(&publishPodName, "pod-name", "", "", "The name of the pod whose sidecar is used in Kubernetes, in case the app has multiple pods. Defaults to the first running pod")
+	PublishCmd.Flags().StringVarP(&publishNamespace, "namespace", "n", "", "The namespace of the publishing app. Defaults to default in Kubernetes mode")
 	PublishCmd.Flags().BoolP("help", "h", false, "Print this help message")
 	PublishCmd.MarkFlagRequired("publish-app-id")
 	PublishCmd.MarkFlagRequired("topic")
@@ -33,6 +33,7 @@ var (
 	issuerPublicCertificateFile string
 	validUntil                  uint
 	restartDaprServices         bool
+	restartSidecars             bool
 )
 
 func RenewCertificateCmd() *cobra.Command {
@@ -50,6 +51,9 @@ dapr mtls renew-certificate -k --private-key myprivatekey.key --valid-until <no
 # Rotates certificate of your kubernetes cluster with provided ca.cert, issuer.crt and issuer.key file path
 dapr mtls renew-certificate -k --ca-root-certificate <root.pem> --issuer-private-key <issuer.key> --issuer-public-certificate <issuer.pem> --restart
 
+# Generates new root and issuer certificates, then restarts the control plane and all apps with Dapr sidecars
+dapr mtls renew-certificate -k --valid-until <no of days> --restart-sidecars
+
 # See more at: https://docs.dapr.io/getting-started/
 `,
 
@@ -61,6 +65,8 @@ dapr mtls renew-certificate -k --ca-root-certificate <root.pem> --issuer-private
 			issuerCertFlag := cmd.Flags().Lookup("issuer-public-certificate").Changed
 
 			if kubernetesMode {
+				// The root private key is reused with --private-key, so the sidecars keep trusting the new root certificate.
+				warnCertificateRotationDowntime(!pkFlag || rootcertFlag || issuerKeyFlag || issuerCertFlag)
 				print.PendingStatusEvent(os.Stdout, "Starting certificate rotation")
 				if rootcertFlag || issuerKeyFlag || issuerCertFlag {
 					flagArgsEmpty := checkReqFlagArgsEmpty(caRootCertificateFile, issuerPrivateKeyFile, issuerPublicCertificateFile)
@@ -114,11 +120,17 @@ dapr mtls renew-certificate -k --ca-root-certificate <root.pem> --issuer-private
 			print.SuccessStatusEvent(os.Stdout,
 				fmt.Sprintf("Certificate rotation is successful! Your new certicate is valid through %s", expiry.Format(time.RFC1123)))
 
-			if restartDaprServices {
-				restartControlPlaneService()
-				if err != nil {
-					print.FailureStatusEvent(os.Stdout, err.Error())
-					os.Exit(1)
+			// The control plane is restarted first, so that restarted sidecars get certificates of the new issuer.
+			if restartDaprServices || restartSidecars {
+				if err = restartControlPlaneService(); err != nil {
+					print.FailureStatusEvent(os.Stderr, err.Error())
+					exit(1)
+				}
+			}
+			if restartSidecars {
+				if err = restartSidecarWorkloads(); err != nil {
+					print.FailureStatusEvent(os.Stderr, err.Error())
+					exit(1)
 				}
 			}
 		},
@@ -131,6 +143,7 @@ dapr mtls renew-certificate -k --ca-root-certificate <root.pem> --issuer-private
 	command.Flags().StringVarP(&issuerPublicCertificateFile, "issuer-public-certificate", "", "", "The issuer certificate")
 	command.Flags().UintVarP(&validUntil, "valid-until", "", 365, "Max days before certificate expires")
 	command.Flags().BoolVarP(&restartDaprServices, "restart", "", false, "Restart Dapr control plane services")
+	command.Flags().BoolVar(&restartSidecars, "restart-sidecars", false, "Restart Dapr control plane services, then all deployments, statefulsets and daemonsets with Dapr sidecars so they trust the new root certificate")
 	command.Flags().UintVarP(&timeout, "timeout", "", 300, "The timeout for the certificate renewal")
 	command.MarkFlagRequired("kubernetes")
 	return command
@@ -148,7 +161,46 @@ func checkReqFlagArgsEmpty(params ...string) bool {
 func logErrorAndExit(err error) {
 	err = fmt.Errorf("certificate rotation failed: %w", err)
 	print.FailureStatusEvent(os.Stderr, err.Error())
-	os.Exit(1)
+	exit(1)
+}
+
+// warnCertificateRotationDowntime warns about the downtime of the rotation before it starts. newRoot is false if the
+// root private key is reused, in which case the sidecars keep trusting the new root certificate.
+func warnCertificateRotationDowntime(newRoot bool) {
+	if newRoot {
+		print.WarningStatusEvent(os.Stdout, "Unless the root certificate is unchanged, sidecars only trust the new root certificate once they are restarted. "+
+			"Calls between apps fail from the restart of the control plane until both of their sidecars are restarted, so plan a downtime window. "+
+			"Reuse the root private key with --private-key to avoid it")
+	}
+	switch {
+	case !restartDaprServices && !restartSidecars:
+		print.WarningStatusEvent(os.Stdout, "The control plane keeps using the old certificates until it is restarted. Use --restart, or --restart-sidecars to also restart the apps")
+	case newRoot && !restartSidecars:
+		print.WarningStatusEvent(os.Stdout, "The apps with Dapr sidecars are not restarted. Use --restart-sidecars, or restart them with kubectl rollout restart after the control plane")
+	case restartSidecars:
+		print.WarningStatusEvent(os.Stdout, "All deployments, statefulsets and daemonsets with Dapr sidecars are restarted after the control plane")
+	}
+}
+
+// restartSidecarWorkloads starts a rolling restart of all workloads with Dapr sidecars.
+func restartSidecarWorkloads() error {
+	client, err := kubernetes.Client()
+	if err != nil {
+		return err
+	}
+	workloads, err := kubernetes.ListSidecarWorkloads(client)
+	if err != nil {
+		return fmt.Errorf("error listing the workloads with Dapr sidecars: %w", err)
+	}
+	if len(workloads) == 0 {
+		print.InfoStatusEvent(os.Stdout, "No workloads with Dapr sidecars found")
+		return nil
+	}
+	if err = kubernetes.RestartSidecarWorkloads(client, workloads); err != nil {
+		return err
+	}
+	print.SuccessStatusEvent(os.Stdout, "Started the rolling restart of %d workloads with Dapr sidecars. Check their progress with kubectl rollout status", len(workloads))
+	return nil
 }
 
 func restartControlPlaneService() error {
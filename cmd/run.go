@@ -15,11 +15,15 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -50,6 +54,29 @@ var (
 	readBufferSize     int
 	unixDomainSocket   string
 	enableAPILogging   bool
+	runFilePath        string
+	watch              bool
+	watchSidecar       bool
+	waitFor            []string
+	waitForTimeout     int
+	runProfile         string
+	strictPorts        bool
+	envFiles           []string
+	envVars            []string
+	printEnv           bool
+	logFilter          []string
+	logFile            string
+	debugApp           bool
+	restartPolicy      string
+	runKubernetes      bool
+	runImage           string
+	runNamespace       string
+	sidecarInContainer bool
+	sidecarImage       string
+	runContainerRT     string
+	daprMaxMemory      string
+	daprCPULimit       float64
+	detectCommand      bool
 )
 
 const (
@@ -58,7 +85,7 @@ const (
 
 var RunCmd = &cobra.Command{
 	Use:   "run",
-	Short: "Run Dapr and (optionally) your application side by side. Supported platforms: Self-hosted",
+	Short: "Run Dapr and (optionally) your application side by side. Supported platforms: Kubernetes and self-hosted",
 	Example: `
 # Run a .NET application
 dapr run --app-id myapp --app-port 5000 -- dotnet run
@@ -75,23 +102,161 @@ dapr run --app-id myapp -- python myapp.py
 # Run sidecar only
 dapr run --app-id myapp
 
+# Run a NodeJs application in the namespace shop, isolated from the apps of other namespaces
+dapr run --app-id myapp --app-port 3000 --namespace shop -- node myapp.js
+
+# Run the app in the current directory with the command and port detected from its package.json, *.csproj, requirements.txt or go.mod
+dapr run --app-id myapp --detect
+
 # Run a gRPC application written in Go (listening on port 3000)
 dapr run --app-id myapp --app-port 3000 --app-protocol grpc -- go run main.go
+
+# Run multiple applications and their sidecars from a run file
+dapr run -f dapr.yaml
+
+# Run a NodeJs application and restart it when files in the current directory change
+dapr run --app-id myapp --watch -- node myapp.js
+
+# Run a Python application once PostgreSQL accepts connections and the orders app is running
+dapr run --app-id myapp --wait-for tcp://localhost:5432 --wait-for app://orders -- python myapp.py
+
+# Run a NodeJs application with the flags of the debug profile in ~/.dapr/config
+dapr run --app-id myapp --profile debug -- node myapp.js
+
+# Run a NodeJs application on HTTP port 3500, and fail instead of using a free port if it is in use
+dapr run --app-id myapp --dapr-http-port 3500 --strict-ports -- node myapp.js
+
+# Run a Python application with the variables of .env and .env.local, overriding one of them, and print the merged environment
+dapr run --app-id myapp --env-file .env --env-file .env.local --env LOG_LEVEL=debug --print-env -- python myapp.py
+
+# Run the apps of a run file without printing the logs of their sidecars, and write all logs to run.log
+dapr run -f dapr.yaml --log-filter daprd --log-file run.log
+
+# Run only the sidecar of a NodeJs application listening to port 3000, and print how to start the app in a debugger
+dapr run --app-id myapp --app-port 3000 --debug -- node myapp.js
+
+# Run a Python application and restart it up to 5 times when it exits with an error, keeping its sidecar running
+dapr run --app-id myapp --restart on-failure:5 -- python myapp.py
+
+# Run a NodeJs application with its sidecar in a Docker container of the daprio/dapr image, as in production
+dapr run --app-id myapp --app-port 3000 --sidecar-in-container -- node myapp.js
+
+# Run an image with a Dapr sidecar in a temporary pod of the current Kubernetes namespace, deleted on Ctrl-C
+dapr run -k --app-id myapp --app-port 3000 --image myregistry/myapp:dev
   `,
 	Args: cobra.MinimumNArgs(0),
 	PreRun: func(cmd *cobra.Command, args []string) {
 		viper.BindPFlag("placement-host-address", cmd.Flags().Lookup("placement-host-address"))
 	},
 	Run: func(cmd *cobra.Command, args []string) {
+		var profileEnv map[string]string
+		if runProfile != "" {
+			if runFilePath != "" {
+				print.FailureStatusEvent(os.Stderr, "The --profile flag cannot be used together with --run-file")
+				exit(1)
+			}
+			var err error
+			profileEnv, err = applyRunProfile(cmd, runProfile)
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+		}
+
+		if runKubernetes {
+			if runFilePath != "" || watch || debugApp || restartPolicy != standalone.RestartNever {
+				print.FailureStatusEvent(os.Stderr, "The --run-file, --watch, --debug and --restart flags are not supported in Kubernetes mode")
+				exit(1)
+			}
+			runOnKubernetes(cmd, args, profileEnv)
+			return
+		}
+		if runImage != "" {
+			print.FailureStatusEvent(os.Stderr, "The --image flag is only supported in Kubernetes mode")
+			exit(1)
+		}
+		if sidecarInContainer && (runFilePath != "" || watchSidecar || unixDomainSocket != "") {
+			print.FailureStatusEvent(os.Stderr, "The --sidecar-in-container flag cannot be used together with --run-file, --watch-sidecar or --unix-domain-socket")
+			exit(1)
+		}
+		if !sidecarInContainer && sidecarImage != "" {
+			print.FailureStatusEvent(os.Stderr, "The --sidecar-image flag requires --sidecar-in-container")
+			exit(1)
+		}
+
+		if debugApp {
+			if err := checkDebugFlags(runFilePath, watch, restartPolicy); err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+		}
+
+		if runFilePath != "" {
+			if len(args) > 0 {
+				print.FailureStatusEvent(os.Stderr, "An application command cannot be used together with --run-file. Set the command of each app in the run file instead.")
+				exit(1)
+			}
+			if watch || restartPolicy != standalone.RestartNever {
+				print.FailureStatusEvent(os.Stderr, "The --watch and --restart flags cannot be used together with --run-file")
+				exit(1)
+			}
+			if len(envFiles) > 0 || len(envVars) > 0 {
+				print.FailureStatusEvent(os.Stderr, "The --env-file and --env flags cannot be used together with --run-file. Set the env of each app in the run file instead.")
+				exit(1)
+			}
+			runMultiApp(runFilePath)
+			return
+		}
+
+		policy, err := standalone.ParseRestartPolicy(restartPolicy)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		var launcher *standalone.Launcher
 		if len(args) == 0 {
-			fmt.Println(print.WhiteBold("WARNING: no application command found."))
+			launcher = detectLauncher()
+		}
+		if launcher != nil && detectCommand {
+			args = launcher.Command
+			print.InfoStatusEvent(os.Stdout, "Detected a %s app from %s. Running it with: %s", launcher.Language, launcher.Manifest, strings.Join(args, " "))
+			switch {
+			case appPort > 0:
+			case launcher.AppPort > 0:
+				appPort = launcher.AppPort
+				print.InfoStatusEvent(os.Stdout, "Using app port %d, the conventional port of %s apps. Use --app-port if your app listens on another port", appPort, launcher.Language)
+			default:
+				print.InfoStatusEvent(os.Stdout, "The port of %s apps is not known. Use --app-port if your app listens on a port", launcher.Language)
+			}
+		} else if detectCommand && len(args) == 0 {
+			print.FailureStatusEvent(os.Stderr, "No app to run was detected in the current directory. Give the application command after --")
+			exit(1)
+		}
+
+		appArgs := debugAppArgs(args, debugApp)
+
+		if len(args) == 0 {
+			if watch {
+				print.FailureStatusEvent(os.Stderr, "The --watch flag requires an application command")
+				exit(1)
+			}
+			if policy.OnFailure {
+				print.FailureStatusEvent(os.Stderr, "The --restart flag requires an application command")
+				exit(1)
+			}
+			if !debugApp {
+				fmt.Println(print.WhiteBold("WARNING: no application command found."))
+				if launcher != nil {
+					print.InfoStatusEvent(os.Stdout, "Detected a %s app from %s. To run it, use --detect or: %s", launcher.Language, launcher.Manifest, proposedRunCommand(launcher))
+				}
+			}
 		}
 
 		if unixDomainSocket != "" {
 			// TODO(@daixiang0): add Windows support.
 			if runtime.GOOS == "windows" {
 				print.FailureStatusEvent(os.Stderr, "The unix-domain-socket option is not supported on Windows")
-				os.Exit(1)
+				exit(1)
 			} else {
 				// use unix domain socket means no port any more.
 				print.WarningStatusEvent(os.Stdout, "Unix domain sockets are currently a preview feature")
@@ -100,13 +265,19 @@ dapr run --app-id myapp --app-port 3000 --app-protocol grpc -- go run main.go
 			}
 		}
 
-		output, err := standalone.Run(&standalone.RunConfig{
+		env, err := standalone.MergeEnv(profileEnv, envFiles, envVars)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+
+		runConfig := &standalone.RunConfig{
 			AppID:              appID,
 			AppPort:            appPort,
 			HTTPPort:           port,
 			GRPCPort:           grpcPort,
 			ConfigFile:         configFile,
-			Arguments:          args,
+			Arguments:          appArgs,
 			EnableProfiling:    enableProfiling,
 			ProfilePort:        profilePort,
 			LogLevel:           logLevel,
@@ -121,11 +292,39 @@ dapr run --app-id myapp --app-port 3000 --app-protocol grpc -- go run main.go
 			UnixDomainSocket:   unixDomainSocket,
 			EnableAPILogging:   enableAPILogging,
 			InternalGRPCPort:   internalGRPCPort,
-		})
+			WaitFor:            waitFor,
+			WaitForTimeout:     waitForTimeout,
+			Env:                env,
+			StrictPorts:        strictPorts,
+			SidecarInContainer: sidecarInContainer,
+			SidecarImage:       sidecarImage,
+			ContainerRuntime:   runContainerRT,
+			DaprMaxMemory:      daprMaxMemory,
+			DaprCPULimit:       daprCPULimit,
+			Namespace:          runNamespace,
+		}
+		output, err := standalone.Run(runConfig)
 		if err != nil {
 			print.FailureStatusEvent(os.Stderr, err.Error())
-			os.Exit(1)
+			exit(1)
+		}
+		printPortAssignments(output)
+		if debugApp {
+			printDebugInstructions(os.Stdout, runConfig, args)
+		} else if printEnv {
+			print.InfoStatusEvent(os.Stdout, "Environment of the app. The variables from --env-file, --env and the run profile are also set for the sidecar:")
+			for _, v := range runConfig.Environment() {
+				fmt.Println(v)
+			}
+		}
+
+		appLog, err := standalone.OpenAppLog(runNamespace, output.AppID)
+		if err != nil {
+			print.WarningStatusEvent(os.Stdout, "Could not store the logs of your app: %s", err)
 		}
+		logMux := newLogMultiplexer(false)
+		daprOut := processOutput(logMux, appLog, output.AppID, standalone.DaprLogSource)
+		appOut := processOutput(logMux, appLog, output.AppID, standalone.AppLogSource)
 
 		sigCh := make(chan os.Signal, 1)
 		setupShutdownNotify(sigCh)
@@ -133,6 +332,36 @@ dapr run --app-id myapp --app-port 3000 --app-protocol grpc -- go run main.go
 		daprRunning := make(chan bool, 1)
 		appRunning := make(chan bool, 1)
 
+		restarter := newAppRestarter(output, daprOut, appOut)
+		restarter.restartSidecar = watchSidecar
+		restarter.restartPolicy = policy
+		restarter.onDaprExit = func(daprdErr error) {
+			if daprdErr != nil {
+				output.DaprErr = daprdErr
+				print.FailureStatusEvent(os.Stderr, "The daprd process exited with error code: %s", daprdErr.Error())
+			} else {
+				print.SuccessStatusEvent(os.Stdout, "Exited Dapr successfully")
+			}
+			sigCh <- os.Interrupt
+		}
+		restarter.onAppExit = func(appErr error) {
+			if appErr != nil {
+				output.AppErr = appErr
+				print.FailureStatusEvent(os.Stderr, "The App process exited with error code: %s", appErr.Error())
+			} else {
+				print.SuccessStatusEvent(os.Stdout, "Exited App successfully")
+			}
+			if restarter.restartOnFailure(appErr) {
+				return
+			}
+			if watch {
+				// Keep running so that the app is restarted once it is fixed.
+				print.InfoStatusEvent(os.Stdout, "Waiting for changes to restart your app")
+				return
+			}
+			sigCh <- os.Interrupt
+		}
+
 		go func() {
 			var startInfo string
 			if unixDomainSocket != "" {
@@ -150,25 +379,25 @@ dapr run --app-id myapp --app-port 3000 --app-protocol grpc -- go run main.go
 			}
 			print.InfoStatusEvent(os.Stdout, startInfo)
 
-			output.DaprCMD.Stdout = os.Stdout
-			output.DaprCMD.Stderr = os.Stderr
+			print.DebugStatusEvent(os.Stderr, "Dapr command: %s", output.DaprCMD.String())
+
+			output.DaprCMD.Stdout = daprOut
+			output.DaprCMD.Stderr = daprOut
 
 			err = output.DaprCMD.Start()
 			if err != nil {
 				print.FailureStatusEvent(os.Stderr, err.Error())
-				os.Exit(1)
+				exit(1)
 			}
+			limitSidecar(output)
 
+			daprCMD := output.DaprCMD
+			onDaprExit := restarter.exitHandler(daprCMD, restarter.onDaprExit)
 			go func() {
-				daprdErr := output.DaprCMD.Wait()
-
-				if daprdErr != nil {
-					output.DaprErr = daprdErr
-					print.FailureStatusEvent(os.Stderr, "The daprd process exited with error code: %s", daprdErr.Error())
-				} else {
-					print.SuccessStatusEvent(os.Stdout, "Exited Dapr successfully")
-				}
-				sigCh <- os.Interrupt
+				err := daprCMD.Wait()
+				// Wait returns once the output of daprd is copied, so its last line can be flushed.
+				daprOut.Flush()
+				onDaprExit(err)
 			}()
 
 			if appPort <= 0 {
@@ -227,66 +456,37 @@ dapr run --app-id myapp --app-port 3000 --app-protocol grpc -- go run main.go
 				return
 			}
 
-			stdErrPipe, pipeErr := output.AppCMD.StderrPipe()
-			if pipeErr != nil {
-				print.FailureStatusEvent(os.Stderr, fmt.Sprintf("Error creating stderr for App: %s", err.Error()))
-				appRunning <- false
-				return
-			}
-
-			stdOutPipe, pipeErr := output.AppCMD.StdoutPipe()
-			if pipeErr != nil {
-				print.FailureStatusEvent(os.Stderr, fmt.Sprintf("Error creating stdout for App: %s", err.Error()))
-				appRunning <- false
-				return
-			}
-
-			errScanner := bufio.NewScanner(stdErrPipe)
-			outScanner := bufio.NewScanner(stdOutPipe)
-			go func() {
-				for errScanner.Scan() {
-					fmt.Println(print.Blue(fmt.Sprintf("== APP == %s", errScanner.Text())))
+			if len(waitFor) > 0 {
+				err = standalone.WaitFor(waitFor, time.Duration(waitForTimeout)*time.Second)
+				if err != nil {
+					print.FailureStatusEvent(os.Stderr, err.Error())
+					appRunning <- false
+					return
 				}
-			}()
+			}
 
-			go func() {
-				for outScanner.Scan() {
-					fmt.Println(print.Blue(fmt.Sprintf("== APP == %s", outScanner.Text())))
-				}
-			}()
+			print.DebugStatusEvent(os.Stderr, "App command: %s", output.AppCMD.String())
 
-			err = output.AppCMD.Start()
+			err = startProcess(output.AppCMD, appOut, nil, restarter.exitHandler(output.AppCMD, restarter.onAppExit))
 			if err != nil {
 				print.FailureStatusEvent(os.Stderr, err.Error())
 				appRunning <- false
 				return
 			}
 
-			go func() {
-				appErr := output.AppCMD.Wait()
-
-				if appErr != nil {
-					output.AppErr = appErr
-					print.FailureStatusEvent(os.Stderr, "The App process exited with error code: %s", appErr.Error())
-				} else {
-					print.SuccessStatusEvent(os.Stdout, "Exited App successfully")
-				}
-				sigCh <- os.Interrupt
-			}()
-
 			appRunning <- true
 		}()
 
 		appRunStatus := <-appRunning
 		if !appRunStatus {
 			// Start App failed, try to stop Dapr and exit.
-			err = output.DaprCMD.Process.Kill()
+			err = stopSidecar(runConfig, output)
 			if err != nil {
 				print.FailureStatusEvent(os.Stderr, fmt.Sprintf("Start App failed, try to stop Dapr Error: %s", err))
 			} else {
 				print.SuccessStatusEvent(os.Stdout, "Start App failed, try to stop Dapr successfully")
 			}
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Metadata API is only available if app has started listening to port, so wait for app to start before calling metadata API.
@@ -294,9 +494,12 @@ dapr run --app-id myapp --app-port 3000 --app-protocol grpc -- go run main.go
 		if err != nil {
 			print.WarningStatusEvent(os.Stdout, "Could not update sidecar metadata for cliPID: %s", err.Error())
 		}
+		putNamespace(output, runNamespace, unixDomainSocket)
 
 		if output.AppCMD != nil {
+			putAppPID(output, unixDomainSocket)
 			appCommand := strings.Join(args, " ")
+			restarter.appCommand = appCommand
 			print.InfoStatusEvent(os.Stdout, fmt.Sprintf("Updating metadata for app command: %s", appCommand))
 			err = metadata.Put(output.DaprHTTPPort, "appCommand", appCommand, appID, unixDomainSocket)
 			if err != nil {
@@ -308,8 +511,18 @@ dapr run --app-id myapp --app-port 3000 --app-protocol grpc -- go run main.go
 			print.SuccessStatusEvent(os.Stdout, "You're up and running! Dapr logs will appear here.\n")
 		}
 
+		if watch {
+			err = restarter.watch(".")
+			if err != nil {
+				print.WarningStatusEvent(os.Stdout, "Could not watch for changes: %s", err)
+			} else {
+				print.InfoStatusEvent(os.Stdout, "Watching for changes in the current directory")
+			}
+		}
+
 		<-sigCh
 		print.InfoStatusEvent(os.Stdout, "\nterminated signal received: shutting down")
+		restarter.close()
 
 		exitWithError := false
 
@@ -317,7 +530,7 @@ dapr run --app-id myapp --app-port 3000 --app-protocol grpc -- go run main.go
 			exitWithError = true
 			print.FailureStatusEvent(os.Stderr, fmt.Sprintf("Error exiting Dapr: %s", output.DaprErr))
 		} else if output.DaprCMD.ProcessState == nil || !output.DaprCMD.ProcessState.Exited() {
-			err = output.DaprCMD.Process.Kill()
+			err = stopSidecar(runConfig, output)
 			if err != nil {
 				exitWithError = true
 				print.FailureStatusEvent(os.Stderr, fmt.Sprintf("Error exiting Dapr: %s", err))
@@ -345,12 +558,394 @@ dapr run --app-id myapp --app-port 3000 --app-protocol grpc -- go run main.go
 			}
 		}
 
+		appLog.Close()
+		logMux.Close()
+
 		if exitWithError {
-			os.Exit(1)
+			exit(1)
 		}
 	},
 }
 
+// stopSidecar stops the sidecar started by dapr run: its container with --sidecar-in-container, which also ends
+// the container runtime command, or else the daprd process.
+func stopSidecar(config *standalone.RunConfig, output *standalone.RunOutput) error {
+	if config.SidecarInContainer {
+		return standalone.StopSidecarContainer(config.ContainerRuntime, output.AppID)
+	}
+	return output.DaprCMD.Process.Kill()
+}
+
+// runMultiApp starts every app in the run file together with its sidecar.
+// The logs of all processes are printed with a prefix naming the app they belong to,
+// and all processes are stopped when the CLI is terminated.
+func runMultiApp(runFilePath string) {
+	apps, err := standalone.ParseRunFile(runFilePath)
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	setupShutdownNotify(sigCh)
+
+	logMux := newLogMultiplexer(true)
+	var running sync.WaitGroup
+	outputs := []*standalone.RunOutput{}
+	appLogs := []*standalone.AppLog{}
+	for i := range apps {
+		app := apps[i]
+		appLog, logErr := standalone.OpenAppLog(app.Namespace, app.AppID)
+		if logErr != nil {
+			print.WarningStatusEvent(os.Stdout, "Could not store the logs of app %s: %s", app.AppID, logErr)
+		}
+		appLogs = append(appLogs, appLog)
+
+		output, startErr := startApp(&app, appLog, logMux, &running)
+		if output != nil {
+			outputs = append(outputs, output)
+		}
+		if startErr != nil {
+			print.FailureStatusEvent(os.Stderr, "Error starting app %s: %s", app.AppID, startErr)
+			stopApps(outputs, apps)
+			exit(1)
+		}
+	}
+
+	go func() {
+		// Stop waiting once every process has exited on its own.
+		running.Wait()
+		sigCh <- os.Interrupt
+	}()
+
+	print.SuccessStatusEvent(os.Stdout, "You're up and running! Logs of all %d apps and their sidecars will appear here.\n", len(outputs))
+
+	<-sigCh
+	print.InfoStatusEvent(os.Stdout, "\nterminated signal received: shutting down")
+
+	success := stopApps(outputs, apps)
+	for _, appLog := range appLogs {
+		appLog.Close()
+	}
+	logMux.Close()
+	if !success {
+		exit(1)
+	}
+}
+
+// detectLauncher returns the launcher of the app in the current directory, or nil if none is detected.
+func detectLauncher() *standalone.Launcher {
+	launcher, err := standalone.DetectLauncher(".")
+	if err != nil {
+		print.WarningStatusEvent(os.Stdout, "Could not detect the app in the current directory: %s", err)
+		return nil
+	}
+	return launcher
+}
+
+// proposedRunCommand returns the dapr run command that runs the app of launcher with the flags given.
+func proposedRunCommand(launcher *standalone.Launcher) string {
+	parts := []string{"dapr", "run"}
+	if appID != "" {
+		parts = append(parts, "--app-id", appID)
+	}
+	port := appPort
+	if port <= 0 {
+		port = launcher.AppPort
+	}
+	if port > 0 {
+		parts = append(parts, "--app-port", strconv.Itoa(port))
+	}
+	parts = append(parts, "--")
+	return strings.Join(append(parts, launcher.Command...), " ")
+}
+
+// printPortAssignments warns about the requested ports that were in use and, if there were any,
+// prints the ports that are used instead.
+func printPortAssignments(output *standalone.RunOutput) {
+	reassigned := false
+	assignments := make([]string, 0, len(output.Ports))
+	for _, p := range output.Ports {
+		if p.Reassigned() {
+			reassigned = true
+			print.WarningStatusEvent(os.Stdout, "Port %d given by --%s is in use, using port %d instead. Use --strict-ports to fail instead", p.Requested, p.Name, p.Assigned)
+			if p.Name == "app-port" {
+				print.WarningStatusEvent(os.Stdout, "Make sure your app listens on the port set in the APP_PORT environment variable")
+			}
+		}
+		assignments = append(assignments, fmt.Sprintf("%s %d", p.Name, p.Assigned))
+	}
+	if reassigned {
+		print.InfoStatusEvent(os.Stdout, "Ports of app %s: %s", output.AppID, strings.Join(assignments, ", "))
+	}
+}
+
+// checkDebugFlags returns an error if --debug is combined with a flag that needs the CLI to start the app, as the
+// app is started by the user in a debugger.
+func checkDebugFlags(runFile string, watch bool, restartPolicy string) error {
+	switch {
+	case runFile != "":
+		return errors.New("the --debug flag cannot be used together with --run-file")
+	case watch:
+		return errors.New("the --watch flag cannot be used together with --debug")
+	case restartPolicy != standalone.RestartNever:
+		return errors.New("the --restart flag cannot be used together with --debug")
+	}
+	return nil
+}
+
+// debugAppArgs returns the command of the app that the CLI starts. In debug mode, the app is started by the user in
+// a debugger, so its command is only printed.
+func debugAppArgs(args []string, debug bool) []string {
+	if debug {
+		return nil
+	}
+	return args
+}
+
+// printDebugInstructions prints how to start the app in a debugger with `dapr run --debug`: the environment variables
+// it must be started with, and its command if one was given.
+func printDebugInstructions(w io.Writer, config *standalone.RunConfig, args []string) {
+	print.InfoStatusEvent(w, "Debug mode: only the sidecar is started. Start your app in your debugger or IDE with these environment variables:")
+	for _, v := range config.Environment() {
+		fmt.Fprintln(w, v)
+	}
+	if len(args) > 0 {
+		print.InfoStatusEvent(w, "App command: %s", strings.Join(args, " "))
+	}
+	if config.AppPort > 0 {
+		print.InfoStatusEvent(w, "The sidecar waits for your app to listen on port %d. Press Ctrl+C to stop the sidecar", config.AppPort)
+	} else {
+		print.InfoStatusEvent(w, "Press Ctrl+C to stop the sidecar")
+	}
+}
+
+// startApp starts the sidecar and, if a command is given, the app of a single run file entry.
+// The output of both is printed through logMux and also stored in appLog.
+func startApp(app *standalone.RunConfig, appLog *standalone.AppLog, logMux *standalone.LogMultiplexer, running *sync.WaitGroup) (*standalone.RunOutput, error) {
+	output, err := standalone.Run(app)
+	if err != nil {
+		return nil, err
+	}
+	printPortAssignments(output)
+
+	print.InfoStatusEvent(os.Stdout, "Starting Dapr with id %s. HTTP Port: %v. gRPC Port: %v", output.AppID, output.DaprHTTPPort, output.DaprGRPCPort)
+	print.DebugStatusEvent(os.Stderr, "Dapr command: %s", output.DaprCMD.String())
+
+	daprOut := processOutput(logMux, appLog, output.AppID, standalone.DaprLogSource)
+	err = startProcess(output.DaprCMD, daprOut, running, func(exitErr error) {
+		output.DaprErr = exitErr
+		if exitErr != nil {
+			print.FailureStatusEvent(os.Stderr, "The daprd process of app %s exited with error code: %s", output.AppID, exitErr.Error())
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	limitSidecar(output)
+
+	if app.AppPort <= 0 {
+		// If app does not listen to port, we can check for Dapr's sidecar health before starting the app.
+		if app.UnixDomainSocket != "" {
+			err = utils.IsDaprListeningOnSocket(utils.GetSocket(app.UnixDomainSocket, output.AppID, "http"), time.Duration(runtimeWaitTimeoutInSeconds)*time.Second)
+		} else {
+			err = utils.IsDaprListeningOnPort(output.DaprHTTPPort, time.Duration(runtimeWaitTimeoutInSeconds)*time.Second)
+		}
+		if err != nil {
+			print.WarningStatusEvent(os.Stdout, "Dapr sidecar of app %s might not be responding: %s", output.AppID, err.Error())
+		}
+	}
+
+	if output.AppCMD != nil {
+		if len(app.WaitFor) > 0 {
+			err = standalone.WaitFor(app.WaitFor, time.Duration(app.WaitForTimeout)*time.Second)
+			if err != nil {
+				return output, err
+			}
+		}
+
+		print.DebugStatusEvent(os.Stderr, "App command: %s", output.AppCMD.String())
+
+		appOut := processOutput(logMux, appLog, output.AppID, standalone.AppLogSource)
+		err = startProcess(output.AppCMD, appOut, running, func(exitErr error) {
+			output.AppErr = exitErr
+			if exitErr != nil {
+				print.FailureStatusEvent(os.Stderr, "The App process %s exited with error code: %s", output.AppID, exitErr.Error())
+			} else {
+				print.SuccessStatusEvent(os.Stdout, "Exited App %s successfully", output.AppID)
+			}
+		})
+		if err != nil {
+			return output, err
+		}
+	}
+
+	err = metadata.Put(output.DaprHTTPPort, "cliPID", strconv.Itoa(os.Getpid()), output.AppID, app.UnixDomainSocket)
+	if err != nil {
+		print.WarningStatusEvent(os.Stdout, "Could not update sidecar metadata for cliPID: %s", err.Error())
+	}
+	putNamespace(output, app.Namespace, app.UnixDomainSocket)
+	if output.AppCMD != nil {
+		putAppPID(output, app.UnixDomainSocket)
+		err = metadata.Put(output.DaprHTTPPort, "appCommand", strings.Join(app.Arguments, " "), output.AppID, app.UnixDomainSocket)
+		if err != nil {
+			print.WarningStatusEvent(os.Stdout, "Could not update sidecar metadata for appCommand: %s", err.Error())
+		}
+	}
+	return output, nil
+}
+
+// limitSidecar applies the resource limits of the started sidecar of output. The sidecar keeps running without
+// them if they cannot be applied.
+func limitSidecar(output *standalone.RunOutput) {
+	if err := output.LimitSidecar(); err != nil {
+		print.WarningStatusEvent(os.Stdout, "Could not limit the resources of the sidecar of %s: %s", output.AppID, err)
+	}
+}
+
+// startProcess starts cmd with its output written line by line to out, and calls onExit once the process
+// has exited. If running is not nil, it tracks the process until it has exited.
+func startProcess(cmd *exec.Cmd, out io.Writer, running *sync.WaitGroup, onExit func(error)) error {
+	stdOutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stdErrPipe, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	go writeLines(stdOutPipe, out)
+	go writeLines(stdErrPipe, out)
+
+	err = cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	if running != nil {
+		running.Add(1)
+	}
+	go func() {
+		if running != nil {
+			defer running.Done()
+		}
+		onExit(cmd.Wait())
+	}()
+	return nil
+}
+
+// stopApps stops all processes that are still running and returns false if any of them failed.
+func stopApps(outputs []*standalone.RunOutput, apps []standalone.RunConfig) bool {
+	success := true
+	for i, output := range outputs {
+		appSuccess := output.DaprErr == nil && output.AppErr == nil
+		for _, cmd := range []*exec.Cmd{output.AppCMD, output.DaprCMD} {
+			if cmd == nil || cmd.Process == nil || (cmd.ProcessState != nil && cmd.ProcessState.Exited()) {
+				continue
+			}
+			if err := cmd.Process.Kill(); err != nil {
+				appSuccess = false
+				print.FailureStatusEvent(os.Stderr, "Error exiting app %s: %s", output.AppID, err)
+			}
+		}
+
+		if apps[i].UnixDomainSocket != "" {
+			for _, s := range []string{"http", "grpc"} {
+				os.Remove(utils.GetSocket(apps[i].UnixDomainSocket, output.AppID, s))
+			}
+		}
+
+		if appSuccess {
+			print.SuccessStatusEvent(os.Stdout, "Exited Dapr and app %s successfully", output.AppID)
+		}
+		success = success && appSuccess
+	}
+	return success
+}
+
+// writeLines writes every line read from r to w, ending the last line even if r does not.
+func writeLines(r io.Reader, w io.Writer) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fmt.Fprintln(w, scanner.Text())
+	}
+}
+
+// newLogMultiplexer returns the multiplexer that prints the output of the apps and sidecars started by
+// `dapr run`, as set by --log-filter and --log-file.
+func newLogMultiplexer(multiApp bool) *standalone.LogMultiplexer {
+	logMux, err := standalone.NewLogMultiplexer(standalone.LogMultiplexerConfig{
+		Out:      os.Stdout,
+		LogFile:  logFile,
+		Filter:   logFilter,
+		MultiApp: multiApp,
+	})
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+	return logMux
+}
+
+// processOutput returns the writer of the output of the given source of an app, which prints it through logMux
+// and stores it in appLog.
+func processOutput(logMux *standalone.LogMultiplexer, appLog *standalone.AppLog, appID, source string) standalone.LineWriter {
+	prefix := standalone.AppLogPrefix
+	if source == standalone.DaprLogSource {
+		prefix = standalone.DaprLogPrefix
+	}
+	return multiLineWriter{logMux.Writer(appID, source), appLog.Writer(prefix)}
+}
+
+// multiLineWriter writes and flushes the output of a process to all of its writers.
+type multiLineWriter []standalone.LineWriter
+
+func (w multiLineWriter) Write(p []byte) (int, error) {
+	for _, lw := range w {
+		if _, err := lw.Write(p); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func (w multiLineWriter) Flush() {
+	for _, lw := range w {
+		lw.Flush()
+	}
+}
+
+// applyRunProfile sets the flags of cmd that are not given on the command line to the values of the named
+// run profile in the CLI configuration file. It returns the environment variables of the profile.
+func applyRunProfile(cmd *cobra.Command, name string) (map[string]string, error) {
+	path := standalone.DefaultCLIConfigFilePath()
+	profile, err := standalone.LoadRunProfile(path, name)
+	if err != nil {
+		return nil, err
+	}
+	flagValues, err := profile.FlagValues()
+	if err != nil {
+		return nil, err
+	}
+	for flagName, values := range flagValues {
+		flag := cmd.Flags().Lookup(flagName)
+		if flag == nil || flagName == "profile" || flagName == "run-file" {
+			return nil, fmt.Errorf("invalid flag %q in run profile %s", flagName, name)
+		}
+		if flag.Changed {
+			continue
+		}
+		for _, value := range values {
+			if err = cmd.Flags().Set(flagName, value); err != nil {
+				return nil, fmt.Errorf("invalid value %q for flag %s in run profile %s: %w", value, flagName, name, err)
+			}
+		}
+	}
+	print.DebugStatusEvent(os.Stderr, "Using run profile %s from %s", name, path)
+	return profile.Env, nil
+}
+
 func init() {
 	RunCmd.Flags().IntVarP(&appPort, "app-port", "p", -1, "The port your application is listening on")
 	RunCmd.Flags().StringVarP(&appID, "app-id", "a", "", "The id for your application, used for service discovery")
@@ -360,11 +955,11 @@ func init() {
 	RunCmd.Flags().IntVarP(&internalGRPCPort, "dapr-internal-grpc-port", "I", -1, "The gRPC port for the Dapr internal API to listen on")
 	RunCmd.Flags().BoolVar(&enableProfiling, "enable-profiling", false, "Enable pprof profiling via an HTTP endpoint")
 	RunCmd.Flags().IntVarP(&profilePort, "profile-port", "", -1, "The port for the profile server to listen on")
-	RunCmd.Flags().StringVarP(&logLevel, "log-level", "", "info", "The log verbosity. Valid values are: debug, info, warn, error, fatal, or panic")
+	RunCmd.Flags().StringVarP(&logLevel, "log-level", "", "info", "The log verbosity of the sidecar, instead of the CLI log level. Valid values are: debug, info, warn, error, fatal, or panic")
 	RunCmd.Flags().IntVarP(&maxConcurrency, "app-max-concurrency", "", -1, "The concurrency level of the application, otherwise is unlimited")
 	RunCmd.Flags().StringVarP(&protocol, "app-protocol", "P", "http", "The protocol (gRPC or HTTP) Dapr uses to talk to the application")
 	RunCmd.Flags().StringVarP(&componentsPath, "components-path", "d", standalone.DefaultComponentsDirPath(), "The path for components directory")
-	RunCmd.Flags().String("placement-host-address", "localhost", "The address of the placement service. Format is either <hostname> for default port or <hostname>:<port> for custom port")
+	RunCmd.Flags().String("placement-host-address", "", "The address of the placement service. Format is either <hostname> for default port or <hostname>:<port> for custom port. Defaults to localhost, or to the host of a remote Docker daemon given by --docker-host or DOCKER_HOST")
 	RunCmd.Flags().BoolVar(&appSSL, "app-ssl", false, "Enable https when Dapr invokes the application")
 	RunCmd.Flags().IntVarP(&metricsPort, "metrics-port", "M", -1, "The port of metrics on dapr")
 	RunCmd.Flags().BoolP("help", "h", false, "Print this help message")
@@ -372,6 +967,29 @@ func init() {
 	RunCmd.Flags().IntVarP(&readBufferSize, "dapr-http-read-buffer-size", "", -1, "HTTP header read buffer in KB")
 	RunCmd.Flags().StringVarP(&unixDomainSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Dapr API servers will use Unix Domain Sockets")
 	RunCmd.Flags().BoolVar(&enableAPILogging, "enable-api-logging", false, "Log API calls at INFO verbosity. Valid values are: true or false")
+	RunCmd.Flags().StringVarP(&runFilePath, "run-file", "f", "", "Path to a run file used to start multiple apps and their sidecars. All other flags are ignored")
+	RunCmd.Flags().BoolVar(&watch, "watch", false, "Restart the application when files in the current directory change")
+	RunCmd.Flags().BoolVar(&watchSidecar, "watch-sidecar", false, "Also restart the Dapr sidecar when --watch detects changes")
+	RunCmd.Flags().StringArrayVar(&waitFor, "wait-for", []string{}, "A dependency that must be reachable before the app is started: tcp://host:port, http(s)://host:port/path or app://<app-id> (can specify multiple)")
+	RunCmd.Flags().StringVar(&runProfile, "profile", "", "The name of a run profile in ~/.dapr/config whose flags and environment variables are used. Flags given on the command line take precedence")
+	RunCmd.Flags().BoolVar(&strictPorts, "strict-ports", false, "Fail if a port given by a flag is in use, instead of using a free port")
+	RunCmd.Flags().IntVar(&waitForTimeout, "wait-for-timeout", int(standalone.DefaultWaitForTimeout.Seconds()), "The number of seconds to wait for the dependencies given by --wait-for")
+	RunCmd.Flags().StringArrayVar(&envFiles, "env-file", []string{}, "A .env file with environment variables of the app and the sidecar. Variables of later files override earlier ones (can specify multiple)")
+	RunCmd.Flags().StringArrayVar(&envVars, "env", []string{}, "An environment variable of the app and the sidecar, as KEY=VALUE. Overrides the variables of --env-file (can specify multiple)")
+	RunCmd.Flags().BoolVar(&printEnv, "print-env", false, "Print the environment variables set for the app and the sidecar")
+	RunCmd.Flags().StringSliceVar(&logFilter, "log-filter", []string{}, "Hide the output of a source: app, daprd, an app ID, <app-id>/app or <app-id>/daprd. The output is still written to --log-file (can specify multiple)")
+	RunCmd.Flags().BoolVar(&debugApp, "debug", false, "Start only the sidecar and print the ports and environment variables the app needs, so that the app can be started in a debugger")
+	RunCmd.Flags().StringVar(&logFile, "log-file", "", "Write the combined output of the apps and sidecars, with the prefix of each line, to a file")
+	RunCmd.Flags().BoolVarP(&runKubernetes, "kubernetes", "k", false, "Run the app in a temporary pod with a Dapr sidecar in a Kubernetes cluster, streaming its logs until it exits or Ctrl-C is pressed")
+	RunCmd.Flags().StringVar(&runImage, "image", "", "The container image of the app to run in Kubernetes. The application command, if given, replaces the arguments of its entrypoint")
+	RunCmd.Flags().StringVarP(&runNamespace, "namespace", "n", "", "The namespace of the app, which isolates it and its components from the apps of other namespaces. In Kubernetes mode, the namespace to run the app in, which defaults to the namespace of the current context")
+	RunCmd.Flags().BoolVar(&sidecarInContainer, "sidecar-in-container", false, "Run the sidecar in a container of the Dapr image instead of as a process, with the components and configuration mounted into it")
+	RunCmd.Flags().StringVar(&sidecarImage, "sidecar-image", "", "The image of the sidecar container with --sidecar-in-container. Defaults to the daprio/dapr image of the installed runtime version")
+	RunCmd.Flags().StringVar(&runContainerRT, "container-runtime", standalone.DockerContainerRuntime, "The container runtime that runs the sidecar with --sidecar-in-container. Valid values are: docker, podman")
+	RunCmd.Flags().StringVar(&daprMaxMemory, "dapr-max-memory", "", "The maximum memory of the sidecar, such as 512Mi or 1G. Enforced with a cgroup on Linux, a job object on Windows, or the container with --sidecar-in-container")
+	RunCmd.Flags().Float64Var(&daprCPULimit, "dapr-cpu-limit", 0, "The maximum number of CPUs the sidecar may use, such as 0.5. Enforced with a cgroup on Linux, a job object on Windows, or the container with --sidecar-in-container")
+	RunCmd.Flags().BoolVar(&detectCommand, "detect", false, "If no application command is given, run the app in the current directory with the command and port detected from its package.json, *.csproj, requirements.txt or go.mod")
+	RunCmd.Flags().StringVar(&restartPolicy, "restart", standalone.RestartNever, "Restart the application, keeping its sidecar running, when it exits with an error. Valid values are: no, on-failure or on-failure:<max restarts>")
 
 	RootCmd.AddCommand(RunCmd)
 }
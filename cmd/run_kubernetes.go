@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/kubernetes"
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+// runOnKubernetes runs the image given by --image in a temporary pod with a Dapr sidecar for `dapr run -k`.
+// The application command, if given, replaces the arguments of the entrypoint of the image.
+func runOnKubernetes(cmd *cobra.Command, args []string, profileEnv map[string]string) {
+	if appID == "" || runImage == "" {
+		print.FailureStatusEvent(os.Stderr, "The --app-id and --image flags are required in Kubernetes mode")
+		exit(1)
+	}
+
+	env, err := standalone.MergeEnv(profileEnv, envFiles, envVars)
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+	envList := make([]string, 0, len(env))
+	for k, v := range env {
+		envList = append(envList, k+"="+v)
+	}
+	sort.Strings(envList)
+
+	annotations := []kubernetes.AnnoteOption{}
+	if cmd.Flags().Changed("app-protocol") {
+		annotations = append(annotations, kubernetes.WithAppProtocol(protocol))
+	}
+	if cmd.Flags().Changed("log-level") {
+		annotations = append(annotations, kubernetes.WithLogLevel(logLevel))
+	}
+	if maxConcurrency > 0 {
+		annotations = append(annotations, kubernetes.WithAppMaxConcurrency(maxConcurrency))
+	}
+	if enableAPILogging {
+		annotations = append(annotations, kubernetes.WithEnableAPILogging())
+	}
+
+	namespace := runNamespace
+	if namespace == "" {
+		namespace, err = kubernetes.CurrentNamespace()
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error getting the current namespace: %s", err)
+			exit(1)
+		}
+	}
+	client, err := kubernetes.Client()
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	err = kubernetes.Run(ctx, client, kubernetes.RunConfig{
+		AppID:       appID,
+		AppPort:     appPort,
+		Image:       runImage,
+		Namespace:   namespace,
+		Arguments:   args,
+		Env:         envList,
+		Annotations: annotations,
+	}, os.Stdout)
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+func TestCheckDebugFlags(t *testing.T) {
+	tests := []struct {
+		name          string
+		runFile       string
+		watch         bool
+		restartPolicy string
+		err           string
+	}{
+		{name: "debug only", restartPolicy: standalone.RestartNever},
+		{name: "run file", runFile: "dapr.yaml", restartPolicy: standalone.RestartNever, err: "--run-file"},
+		{name: "watch", watch: true, restartPolicy: standalone.RestartNever, err: "--watch"},
+		{name: "restart", restartPolicy: standalone.RestartOnFailure, err: "--restart"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkDebugFlags(tc.runFile, tc.watch, tc.restartPolicy)
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestDebugAppArgs(t *testing.T) {
+	args := []string{"node", "app.js"}
+	assert.Nil(t, debugAppArgs(args, true), "the app is not started in debug mode")
+	assert.Equal(t, args, debugAppArgs(args, false))
+}
+
+func TestPrintDebugInstructions(t *testing.T) {
+	config := &standalone.RunConfig{}
+	config.AppID = "myapp"
+	config.AppPort = 3000
+	config.HTTPPort = 3500
+
+	var out bytes.Buffer
+	printDebugInstructions(&out, config, []string{"node", "app.js"})
+	assert.Contains(t, out.String(), "APP_ID=myapp\n")
+	assert.Contains(t, out.String(), "APP_PORT=3000\n")
+	assert.Contains(t, out.String(), "DAPR_HTTP_PORT=3500\n")
+	assert.Contains(t, out.String(), "App command: node app.js")
+	assert.Contains(t, out.String(), "listen on port 3000")
+
+	out.Reset()
+	printDebugInstructions(&out, &standalone.RunConfig{}, nil)
+	assert.NotContains(t, out.String(), "App command")
+	assert.Contains(t, out.String(), "Press Ctrl+C to stop the sidecar")
+}
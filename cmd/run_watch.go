@@ -0,0 +1,301 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dapr/cli/pkg/metadata"
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+	"github.com/dapr/cli/utils"
+)
+
+const (
+	watchDebounceInterval = 500 * time.Millisecond
+	processStopTimeout    = 10 * time.Second
+)
+
+// appRestarter restarts the app, and optionally its sidecar, started by `dapr run --watch`, and the app after it
+// failed with `dapr run --restart on-failure`. Processes that are stopped for a restart do not trigger their exit handlers.
+type appRestarter struct {
+	mu             sync.Mutex
+	closed         bool
+	output         *standalone.RunOutput
+	daprOut        standalone.LineWriter
+	appOut         io.Writer
+	restartSidecar bool
+	appCommand     string
+	onDaprExit     func(error)
+	onAppExit      func(error)
+	stopWatch      func() error
+	restartPolicy  standalone.RestartPolicy
+	restarts       int
+
+	stoppingMu sync.Mutex
+	stopping   map[*exec.Cmd]chan struct{}
+}
+
+func newAppRestarter(output *standalone.RunOutput, daprOut standalone.LineWriter, appOut io.Writer) *appRestarter {
+	return &appRestarter{
+		output:   output,
+		daprOut:  daprOut,
+		appOut:   appOut,
+		stopping: map[*exec.Cmd]chan struct{}{},
+	}
+}
+
+// exitHandler wraps onExit so that it is not called when cmd exits because of a restart.
+func (r *appRestarter) exitHandler(cmd *exec.Cmd, onExit func(error)) func(error) {
+	return func(err error) {
+		r.stoppingMu.Lock()
+		stopped, ok := r.stopping[cmd]
+		delete(r.stopping, cmd)
+		r.stoppingMu.Unlock()
+
+		if ok {
+			close(stopped)
+			return
+		}
+		onExit(err)
+	}
+}
+
+// watch restarts the processes whenever files in dir change.
+func (r *appRestarter) watch(dir string) error {
+	stopWatch, err := standalone.WatchDir(dir, watchDebounceInterval, r.restart)
+	if err != nil {
+		return err
+	}
+	r.stopWatch = stopWatch
+	return nil
+}
+
+// close stops watching for changes and waits for a restart in progress to finish.
+func (r *appRestarter) close() {
+	if r.stopWatch != nil {
+		r.stopWatch()
+	}
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+}
+
+func (r *appRestarter) restart() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+
+	if r.restartSidecar {
+		print.InfoStatusEvent(os.Stdout, "Changes detected, restarting Dapr and your app")
+	} else {
+		print.InfoStatusEvent(os.Stdout, "Changes detected, restarting your app")
+	}
+
+	r.stop(r.output.AppCMD)
+
+	if r.restartSidecar {
+		r.stop(r.output.DaprCMD)
+
+		daprCMD := cloneCmd(r.output.DaprCMD)
+		daprCMD.Stdout = r.daprOut
+		daprCMD.Stderr = r.daprOut
+		err := daprCMD.Start()
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error restarting Dapr: %s", err)
+			return
+		}
+		r.output.DaprCMD = daprCMD
+		r.output.DaprErr = nil
+		limitSidecar(r.output)
+
+		onExit := r.exitHandler(daprCMD, r.onDaprExit)
+		go func() {
+			err := daprCMD.Wait()
+			r.daprOut.Flush()
+			onExit(err)
+		}()
+
+		r.waitForSidecar()
+	}
+
+	if r.output.AppCMD != nil {
+		err := r.startApp()
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error restarting app: %s", err)
+			return
+		}
+	}
+
+	print.SuccessStatusEvent(os.Stdout, "Restarted successfully")
+}
+
+// restartOnFailure restarts the app after a backoff if it exited with exitErr and the restart policy allows it.
+// It returns true if the app is restarted.
+func (r *appRestarter) restartOnFailure(exitErr error) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed || !r.restartPolicy.OnFailure || exitErr == nil {
+		return false
+	}
+	if !r.restartPolicy.ShouldRestart(exitErr, r.restarts) {
+		print.WarningStatusEvent(os.Stdout, "Your app was restarted %d times, not restarting it again", r.restarts)
+		return false
+	}
+
+	r.restarts++
+	restarts := r.restarts
+	exited := r.output.AppCMD
+	backoff := standalone.RestartBackoff(restarts)
+	print.InfoStatusEvent(os.Stdout, "Restarting your app in %s (restart %d)", backoff, restarts)
+
+	go func() {
+		time.Sleep(backoff)
+		err := r.restartExited(exited)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error restarting app: %s", err)
+			r.onAppExit(err)
+		}
+	}()
+	return true
+}
+
+// restartExited starts the app again unless `dapr run` is shutting down, or the app was already restarted
+// because of changes since exited exited.
+func (r *appRestarter) restartExited(exited *exec.Cmd) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed || r.output.AppCMD != exited {
+		return nil
+	}
+
+	err := r.startApp()
+	if err != nil {
+		return err
+	}
+	r.putRestarts()
+	return nil
+}
+
+// startApp starts the app again with the command it was last started with. It must be called with mu held.
+func (r *appRestarter) startApp() error {
+	appCMD := cloneCmd(r.output.AppCMD)
+	err := startProcess(appCMD, r.appOut, nil, r.exitHandler(appCMD, r.onAppExit))
+	if err != nil {
+		return err
+	}
+	r.output.AppCMD = appCMD
+	r.output.AppErr = nil
+	putAppPID(r.output, unixDomainSocket)
+	return nil
+}
+
+// putRestarts sets the number of restarts of the app in the metadata of its sidecar, so that `dapr list` shows it.
+func (r *appRestarter) putRestarts() {
+	err := metadata.Put(r.output.DaprHTTPPort, standalone.AppRestartsMetadataKey, strconv.Itoa(r.restarts), r.output.AppID, unixDomainSocket)
+	if err != nil {
+		print.WarningStatusEvent(os.Stdout, "Could not update sidecar metadata for %s: %s", standalone.AppRestartsMetadataKey, err.Error())
+	}
+}
+
+// waitForSidecar waits for a restarted sidecar to listen and restores the metadata set by `dapr run`.
+func (r *appRestarter) waitForSidecar() {
+	var err error
+	if unixDomainSocket != "" {
+		err = utils.IsDaprListeningOnSocket(utils.GetSocket(unixDomainSocket, r.output.AppID, "http"), time.Duration(runtimeWaitTimeoutInSeconds)*time.Second)
+	} else {
+		err = utils.IsDaprListeningOnPort(r.output.DaprHTTPPort, time.Duration(runtimeWaitTimeoutInSeconds)*time.Second)
+	}
+	if err != nil {
+		print.WarningStatusEvent(os.Stdout, "Dapr sidecar might not be responding: %s", err.Error())
+		return
+	}
+
+	err = metadata.Put(r.output.DaprHTTPPort, "cliPID", strconv.Itoa(os.Getpid()), r.output.AppID, unixDomainSocket)
+	if err != nil {
+		print.WarningStatusEvent(os.Stdout, "Could not update sidecar metadata for cliPID: %s", err.Error())
+	}
+	putNamespace(r.output, runNamespace, unixDomainSocket)
+	if r.appCommand != "" {
+		err = metadata.Put(r.output.DaprHTTPPort, "appCommand", r.appCommand, r.output.AppID, unixDomainSocket)
+		if err != nil {
+			print.WarningStatusEvent(os.Stdout, "Could not update sidecar metadata for appCommand: %s", err.Error())
+		}
+	}
+	if r.restarts > 0 {
+		r.putRestarts()
+	}
+}
+
+// stop kills cmd and waits for it to exit.
+func (r *appRestarter) stop(cmd *exec.Cmd) {
+	if cmd == nil || cmd.Process == nil {
+		return
+	}
+
+	stopped := make(chan struct{})
+	r.stoppingMu.Lock()
+	r.stopping[cmd] = stopped
+	r.stoppingMu.Unlock()
+
+	err := cmd.Process.Kill()
+	if err != nil {
+		// The process has already exited.
+		r.stoppingMu.Lock()
+		delete(r.stopping, cmd)
+		r.stoppingMu.Unlock()
+		return
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(processStopTimeout):
+		print.WarningStatusEvent(os.Stdout, "Timed out waiting for process %d to exit", cmd.Process.Pid)
+	}
+}
+
+// cloneCmd returns a new command with the same path, arguments, environment and working directory as cmd.
+func cloneCmd(cmd *exec.Cmd) *exec.Cmd {
+	clone := exec.Command(cmd.Path, cmd.Args[1:]...)
+	clone.Env = cmd.Env
+	clone.Dir = cmd.Dir
+	return clone
+}
+
+// putAppPID sets the PID of the app in the metadata of its sidecar, so that `dapr list` shows the resource usage of the app.
+func putAppPID(output *standalone.RunOutput, socket string) {
+	err := metadata.Put(output.DaprHTTPPort, "appPID", strconv.Itoa(output.AppCMD.Process.Pid), output.AppID, socket)
+	if err != nil {
+		print.WarningStatusEvent(os.Stdout, "Could not update sidecar metadata for appPID: %s", err.Error())
+	}
+}
+
+// putNamespace sets the namespace of the app in the metadata of its sidecar, so that `dapr list` and `dapr stop`
+// can select the apps of a namespace.
+func putNamespace(output *standalone.RunOutput, namespace, socket string) {
+	if namespace == "" {
+		return
+	}
+	err := metadata.Put(output.DaprHTTPPort, standalone.NamespaceMetadataKey, namespace, output.AppID, socket)
+	if err != nil {
+		print.WarningStatusEvent(os.Stdout, "Could not update sidecar metadata for %s: %s", standalone.NamespaceMetadataKey, err.Error())
+	}
+}
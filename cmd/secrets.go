@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+// hiddenSecretValue replaces the values of secrets listed without --show-values.
+const hiddenSecretValue = "********"
+
+var (
+	secretsAppID      string
+	secretsStore      string
+	secretsKey        string
+	secretsMetadata   map[string]string
+	secretsShowValues bool
+	secretsSocket     string
+)
+
+var SecretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Read the secrets of a secret store through a running sidecar. Supported platforms: Self-hosted",
+	Long: `Read the secrets of a secret store through a running sidecar.
+Secrets are read with the secrets API of the sidecar, so the secret scopes of the configuration of the app apply.
+`,
+}
+
+var SecretsGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get a secret of a secret store. Supported platforms: Self-hosted",
+	Example: `
+# Print the value of a secret
+dapr secrets get --store localsecretstore --key db-password
+
+# Get a secret of another namespace from the Kubernetes secret store, through the sidecar of an app
+dapr secrets get --app-id myapp --store kubernetes --key db --metadata namespace=prod --output-format json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkUnixDomainSocket(secretsSocket)
+		secrets, err := standalone.NewClient().GetSecret(secretsAppID, secretsStore, secretsKey, secretsMetadata, secretsSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		if len(secrets) == 1 && print.GetRenderer().Interactive() {
+			fmt.Println(secrets[0].Value)
+			return
+		}
+		printSecrets(secrets)
+	},
+}
+
+var SecretsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the secrets of a secret store that the app is allowed to read. Supported platforms: Self-hosted",
+	Long: `List the secrets of a secret store that the app is allowed to read, with the bulk secrets API.
+Not every secret store supports listing its secrets. Values are hidden unless --show-values is given.
+`,
+	Example: `
+# List the secrets of a secret store
+dapr secrets list --store localsecretstore
+
+# List the secrets with their values
+dapr secrets list --store localsecretstore --show-values
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkUnixDomainSocket(secretsSocket)
+		secrets, err := standalone.NewClient().ListSecrets(secretsAppID, secretsStore, secretsMetadata, secretsSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		if len(secrets) == 0 && print.GetRenderer().Interactive() {
+			print.InfoStatusEvent(os.Stdout, "No secrets found in store %s", secretsStore)
+			return
+		}
+		if !secretsShowValues {
+			for i := range secrets {
+				secrets[i].Value = hiddenSecretValue
+			}
+		}
+		printSecrets(secrets)
+	},
+}
+
+func printSecrets(secrets []standalone.SecretOutput) {
+	if err := print.WriteTable(os.Stdout, secrets, false); err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+}
+
+func init() {
+	for _, c := range []*cobra.Command{SecretsGetCmd, SecretsListCmd} {
+		c.Flags().StringVarP(&secretsAppID, "app-id", "a", "", "The ID of the app whose sidecar is used. Required if more than one app is running")
+		c.Flags().StringVarP(&secretsStore, "store", "s", "", "The name of the secret store component")
+		c.Flags().StringToStringVarP(&secretsMetadata, "metadata", "m", nil, "The metadata of the request to the secret store, as key=value pairs, for example namespace=prod")
+		c.Flags().StringVarP(&secretsSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Dapr API servers will use Unix Domain Sockets")
+		c.Flags().BoolP("help", "h", false, "Print this help message")
+		c.MarkFlagRequired("store")
+		SecretsCmd.AddCommand(c)
+	}
+	SecretsGetCmd.Flags().StringVarP(&secretsKey, "key", "", "", "The name of the secret to get")
+	SecretsGetCmd.MarkFlagRequired("key")
+	SecretsListCmd.Flags().BoolVar(&secretsShowValues, "show-values", false, "Print the values of the secrets")
+
+	RootCmd.AddCommand(SecretsCmd)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+var ServicesCmd = &cobra.Command{
+	Use:   "services",
+	Short: "Manage the placement and scheduler services of a slim installation installed with dapr init --slim --install-services. Supported platforms: Self-hosted",
+}
+
+var ServicesStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the services of the slim installation",
+	Example: `
+# Start the services of the slim installation
+dapr services start
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := standalone.StartServices(); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Services started")
+	},
+}
+
+var ServicesStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the services of the slim installation. They start again after a reboot",
+	Example: `
+# Stop the services of the slim installation
+dapr services stop
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := standalone.StopServices(); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Services stopped")
+	},
+}
+
+var ServicesStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the services of the slim installation are installed and running",
+	Example: `
+# Show the status of the services of the slim installation
+dapr services status
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		statuses, err := standalone.ServicesStatus()
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		if err = print.WriteTable(os.Stdout, statuses, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{ServicesStartCmd, ServicesStopCmd, ServicesStatusCmd} {
+		c.Flags().BoolP("help", "h", false, "Print this help message")
+		ServicesCmd.AddCommand(c)
+	}
+	RootCmd.AddCommand(ServicesCmd)
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+var (
+	stateAppID     string
+	stateStore     string
+	stateKeys      []string
+	stateValue     string
+	stateValueFile string
+	stateItemsFile string
+	stateQuery     string
+	stateQueryFile string
+	stateSocket    string
+)
+
+var StateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Get, save, delete and query the keys of a state store through a running sidecar. Supported platforms: Self-hosted",
+}
+
+var StateGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get keys of a state store. Supported platforms: Self-hosted",
+	Example: `
+# Print the value of a key
+dapr state get --store statestore --key order1
+
+# List the values of keys, read with the bulk API
+dapr state get --store statestore --key order1,order2 --output-format json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkUnixDomainSocket(stateSocket)
+		items, err := standalone.NewClient().GetState(stateAppID, stateStore, stateKeys, stateSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error getting the state: %s", err)
+			exit(1)
+		}
+		if len(items) == 1 && len(stateKeys) == 1 && print.GetRenderer().Interactive() {
+			fmt.Println(items[0].Value)
+			return
+		}
+		printStateItems(items)
+	},
+}
+
+var StateSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Save keys in a state store. Supported platforms: Self-hosted",
+	Long: `Save keys in a state store.
+A value that is not valid JSON is saved as a string.
+`,
+	Example: `
+# Save a key
+dapr state set --store statestore --key order1 --value '{"id":1}'
+
+# Save a key with the content of a file, or of stdin with -
+dapr state set --store statestore --key order1 --value-file order.json
+cat order.json | dapr state set --store statestore --key order1 --value-file -
+
+# Seed a state store with the items of a file, a JSON array of {"key": ..., "value": ...}
+dapr state set --store statestore --file seed.json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		items, err := stateItemsToSave()
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		checkUnixDomainSocket(stateSocket)
+		if err = standalone.NewClient().SaveState(stateAppID, stateStore, items, stateSocket); err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error saving the state: %s", err)
+			exit(1)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Saved %d key(s) in state store %s", len(items), stateStore)
+	},
+}
+
+var StateDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete keys from a state store. Supported platforms: Self-hosted",
+	Example: `
+# Delete keys
+dapr state delete --store statestore --key order1,order2
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkUnixDomainSocket(stateSocket)
+		if err := standalone.NewClient().DeleteState(stateAppID, stateStore, stateKeys, stateSocket); err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error deleting the state: %s", err)
+			exit(1)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Deleted %d key(s) from state store %s", len(stateKeys), stateStore)
+	},
+}
+
+var StateQueryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Query a state store. Supported platforms: Self-hosted",
+	Long: `Query a state store with the JSON query syntax of the state query API.
+The state store must support the state query API, see https://docs.dapr.io/developing-applications/building-blocks/state-management/state-store-query-api/.
+`,
+	Example: `
+# List the keys that match a query
+dapr state query --store statestore --query '{"filter":{"EQ":{"status":"pending"}},"sort":[{"key":"id"}]}'
+
+# Run the query of a file
+dapr state query --store statestore --query-file query.json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if stateQuery != "" && stateQueryFile != "" {
+			print.FailureStatusEvent(os.Stderr, "Only one of --query and --query-file allowed in the same command")
+			exit(1)
+		}
+		query := []byte(stateQuery)
+		if stateQueryFile != "" {
+			var err error
+			if query, err = readInputFile(stateQueryFile); err != nil {
+				print.FailureStatusEvent(os.Stderr, "Error reading the query from '%s'. Error: %s", stateQueryFile, err)
+				exit(1)
+			}
+		}
+		checkUnixDomainSocket(stateSocket)
+		items, err := standalone.NewClient().QueryState(stateAppID, stateStore, query, stateSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error querying the state: %s", err)
+			exit(1)
+		}
+		printStateItems(items)
+	},
+}
+
+// stateItemsToSave returns the items given by --file, or the key given by --key with the value of --value or --value-file.
+func stateItemsToSave() ([]standalone.StateItem, error) {
+	if stateItemsFile != "" {
+		if len(stateKeys) > 0 || stateValue != "" || stateValueFile != "" {
+			return nil, errors.New("--file cannot be used with --key, --value or --value-file")
+		}
+		b, err := readInputFile(stateItemsFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading the items from '%s'. Error: %w", stateItemsFile, err)
+		}
+		var items []standalone.StateItem
+		if err = json.Unmarshal(b, &items); err != nil {
+			return nil, fmt.Errorf("error parsing the items in '%s', expected a JSON array of {\"key\": ..., \"value\": ...}. Error: %w", stateItemsFile, err)
+		}
+		return items, nil
+	}
+
+	if len(stateKeys) != 1 {
+		return nil, errors.New("exactly one --key is required, or --file with the items to save")
+	}
+	if stateValue != "" && stateValueFile != "" {
+		return nil, errors.New("only one of --value and --value-file allowed in the same command")
+	}
+	value := []byte(stateValue)
+	if stateValueFile != "" {
+		var err error
+		if value, err = readInputFile(stateValueFile); err != nil {
+			return nil, fmt.Errorf("error reading the value from '%s'. Error: %w", stateValueFile, err)
+		}
+	}
+	return []standalone.StateItem{{Key: stateKeys[0], Value: standalone.ParseStateValue(value)}}, nil
+}
+
+// readInputFile returns the content of the file at path, or of stdin if path is "-".
+func readInputFile(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+func printStateItems(items []standalone.StateItemOutput) {
+	if err := print.WriteTable(os.Stdout, items, false); err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+}
+
+func init() {
+	for _, c := range []*cobra.Command{StateGetCmd, StateSetCmd, StateDeleteCmd, StateQueryCmd} {
+		c.Flags().StringVarP(&stateAppID, "app-id", "a", "", "The ID of the app whose sidecar is used. Required if more than one app is running")
+		c.Flags().StringVarP(&stateStore, "store", "s", "", "The name of the state store component")
+		c.Flags().StringVarP(&stateSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Dapr API servers will use Unix Domain Sockets")
+		c.Flags().BoolP("help", "h", false, "Print this help message")
+		c.MarkFlagRequired("store")
+		StateCmd.AddCommand(c)
+	}
+	StateGetCmd.Flags().StringSliceVarP(&stateKeys, "key", "", []string{}, "The keys to get, as a comma-separated list")
+	StateGetCmd.MarkFlagRequired("key")
+	StateDeleteCmd.Flags().StringSliceVarP(&stateKeys, "key", "", []string{}, "The keys to delete, as a comma-separated list")
+	StateDeleteCmd.MarkFlagRequired("key")
+	StateSetCmd.Flags().StringSliceVarP(&stateKeys, "key", "", []string{}, "The key to save")
+	StateSetCmd.Flags().StringVarP(&stateValue, "value", "d", "", "The value to save")
+	StateSetCmd.Flags().StringVarP(&stateValueFile, "value-file", "", "", "A file containing the value to save, or - to read it from stdin")
+	StateSetCmd.Flags().StringVarP(&stateItemsFile, "file", "f", "", "A file containing a JSON array of items to save, or - to read it from stdin")
+	StateQueryCmd.Flags().StringVarP(&stateQuery, "query", "q", "", "The JSON serialized state query. Defaults to a query of all keys")
+	StateQueryCmd.Flags().StringVarP(&stateQueryFile, "query-file", "f", "", "A file containing the JSON serialized state query, or - to read it from stdin")
+
+	RootCmd.AddCommand(StateCmd)
+}
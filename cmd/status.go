@@ -14,54 +14,216 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"reflect"
+	"strings"
+	"time"
 
-	"github.com/gocarina/gocsv"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/dapr/cli/pkg/kubernetes"
 	"github.com/dapr/cli/pkg/print"
-	"github.com/dapr/cli/utils"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+const statusWatchInterval = 2 * time.Second
+
+var (
+	statusWatch   bool
+	statusTimeout time.Duration
+	statusOutput  string
 )
 
 var StatusCmd = &cobra.Command{
 	Use:   "status",
-	Short: "Show the health status of Dapr services. Supported platforms: Kubernetes",
+	Short: "Show the health status of Dapr services. Supported platforms: Kubernetes and self-hosted",
 	Example: `
 # Get status of Dapr services from Kubernetes
 dapr status -k 
+
+# Wait up to 10 minutes for the Dapr services in Kubernetes to be healthy, e.g. to gate a deployment pipeline
+dapr status -k --watch --timeout 10m
+
+# Get status of the self-hosted Dapr services and of the sidecars started with dapr run
+dapr status
+
+# Get status of Dapr services from Kubernetes as YAML, for yq
+dapr status -k --output yaml
 `,
-	Run: func(cmd *cobra.Command, args []string) {
-		sc, err := kubernetes.NewStatusClient()
-		if err != nil {
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if err := print.SetOutputFlag(statusOutput); err != nil {
 			print.FailureStatusEvent(os.Stderr, err.Error())
-			os.Exit(1)
+			exit(1)
 		}
-		status, err := sc.Status()
+		viper.BindPFlag("network", cmd.Flags().Lookup("network"))
+		viper.BindPFlag("container-runtime", cmd.Flags().Lookup("container-runtime"))
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if k8s {
+			sc, err := kubernetes.NewStatusClient()
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			if statusWatch {
+				watchKubernetesStatus(sc)
+				return
+			}
+			status, err := sc.Status()
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			if len(status) == 0 {
+				print.FailureStatusEvent(os.Stderr, "No status returned. Is Dapr initialized in your cluster?")
+				exit(1)
+			}
+			printKubernetesStatus(status, sc)
+			return
+		}
+
+		if statusWatch {
+			print.FailureStatusEvent(os.Stderr, "The --watch flag is only supported together with --kubernetes")
+			exit(1)
+		}
+		status, err := standalone.Status(viper.GetString("container-runtime"), viper.GetString("network"))
 		if err != nil {
 			print.FailureStatusEvent(os.Stderr, err.Error())
-			os.Exit(1)
-		}
-		if len(status) == 0 {
-			print.FailureStatusEvent(os.Stderr, "No status returned. Is Dapr initialized in your cluster?")
-			os.Exit(1)
+			exit(1)
 		}
-		table, err := gocsv.MarshalString(status)
+		err = print.WriteTable(os.Stdout, status, false)
 		if err != nil {
 			print.FailureStatusEvent(os.Stderr, err.Error())
-			os.Exit(1)
+			exit(1)
 		}
-
-		utils.PrintTable(table)
 	},
 	PostRun: func(cmd *cobra.Command, args []string) {
-		kubernetes.CheckForCertExpiry()
+		if k8s {
+			kubernetes.CheckForCertExpiry()
+		}
 	},
 }
 
+// printKubernetesStatus prints the status of the control plane services, followed by its Helm release, its CRDs and
+// the services whose images differ from the release, and exits with an error if any of the services is unhealthy.
+func printKubernetesStatus(status []kubernetes.StatusOutput, sc *kubernetes.StatusClient) {
+	err := print.WriteTable(os.Stdout, status, false)
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+	if details, err := sc.Details(status[0].Namespace); err != nil {
+		print.WarningStatusEvent(os.Stderr, "Failed to get the Helm release and the CRDs of Dapr: %s", err)
+	} else {
+		printKubernetesStatusDetails(details)
+	}
+	if unhealthy := kubernetes.UnhealthyServices(status); len(unhealthy) > 0 {
+		print.FailureStatusEvent(os.Stderr, "Unhealthy Dapr services: %s", strings.Join(unhealthy, ", "))
+		exit(1)
+	}
+}
+
+// printKubernetesStatusDetails prints the Helm release and the CRDs of the control plane in text output, and warns
+// about the services whose images differ from the release in every output format.
+func printKubernetesStatusDetails(details *kubernetes.StatusDetails) {
+	interactive := print.GetRenderer().Interactive()
+	if interactive {
+		fmt.Println()
+		if r := details.Release; r != nil {
+			print.InfoStatusEvent(os.Stdout, "Helm release %s in namespace %s: chart version %s, app version %s, revision %d (%s)",
+				r.Name, r.Namespace, r.ChartVersion, r.AppVersion, r.Revision, r.Status)
+		} else {
+			print.WarningStatusEvent(os.Stdout, "No Helm release of Dapr found. The running images cannot be compared with the installed chart")
+		}
+		if len(details.CRDs) > 0 {
+			fmt.Println()
+			if err := print.WriteTable(os.Stdout, details.CRDs, false); err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+		}
+	}
+
+	if len(details.Drift) == 0 {
+		if interactive && details.Release != nil {
+			print.SuccessStatusEvent(os.Stdout, "The running images match Helm release %s", details.Release.Name)
+		}
+		return
+	}
+	if !interactive {
+		for _, d := range details.Drift {
+			print.WarningStatusEvent(os.Stderr, "Pod %s of %s runs %s instead of %s of Helm release %s",
+				d.Pod, d.Service, d.Running, d.Release, details.Release.Name)
+		}
+		return
+	}
+	fmt.Println()
+	print.WarningStatusEvent(os.Stdout, "The running images of %d pods differ from Helm release %s:", len(details.Drift), details.Release.Name)
+	if err := print.WriteTable(os.Stdout, details.Drift, false); err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+}
+
+// watchKubernetesStatus refreshes the status of the control plane services until all of them are healthy.
+// In text output every change of the status is printed, otherwise only the last status.
+func watchKubernetesStatus(sc *kubernetes.StatusClient) {
+	ctx, cancel := context.WithTimeout(context.Background(), statusTimeout)
+	defer cancel()
+
+	interactive := print.GetRenderer().Interactive()
+	var printed []kubernetes.StatusOutput
+	status, err := sc.WaitForHealthy(ctx, statusWatchInterval, func(status []kubernetes.StatusOutput) {
+		if !interactive || reflect.DeepEqual(status, printed) {
+			return
+		}
+		if printed != nil {
+			fmt.Println()
+		}
+		printed = status
+		if len(status) == 0 {
+			print.PendingStatusEvent(os.Stdout, "Waiting for the Dapr services to be installed")
+			return
+		}
+		if err := print.WriteTable(os.Stdout, status, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		if !interactive {
+			print.WriteTable(os.Stdout, status, false)
+		}
+		if len(status) == 0 {
+			print.FailureStatusEvent(os.Stderr, "Timed out after %s. No status returned. Is Dapr initialized in your cluster?", statusTimeout)
+		} else {
+			print.FailureStatusEvent(os.Stderr, "Timed out after %s waiting for the Dapr services to be healthy. Unhealthy Dapr services: %s",
+				statusTimeout, strings.Join(kubernetes.UnhealthyServices(status), ", "))
+		}
+		exit(1)
+	}
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, err.Error())
+		exit(1)
+	}
+	if !interactive {
+		printKubernetesStatus(status, sc)
+		return
+	}
+	print.SuccessStatusEvent(os.Stdout, "All Dapr services are healthy")
+}
+
 func init() {
 	StatusCmd.Flags().BoolVarP(&k8s, "kubernetes", "k", false, "Show the health status of Dapr services on Kubernetes cluster")
+	StatusCmd.Flags().BoolVarP(&statusWatch, "watch", "w", false, "Refresh the status until all Dapr services on the Kubernetes cluster are healthy")
+	StatusCmd.Flags().DurationVar(&statusTimeout, "timeout", 5*time.Minute, "The time to wait for the Dapr services to be healthy with --watch")
+	StatusCmd.Flags().String("network", "", "The Docker network the self-hosted Dapr services were installed on")
+	StatusCmd.Flags().String("container-runtime", standalone.DockerContainerRuntime, "The container runtime the self-hosted Dapr services run in. Valid values are: docker, podman")
+	StatusCmd.Flags().StringVarP(&statusOutput, "output", "o", "", "The output format of the status. Valid values are: json, yaml, or table (default). Messages are then printed in the same format")
 	StatusCmd.Flags().BoolP("help", "h", false, "Print this help message")
-	StatusCmd.MarkFlagRequired("kubernetes")
 	RootCmd.AddCommand(StatusCmd)
 }
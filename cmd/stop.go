@@ -15,6 +15,8 @@ package cmd
 
 import (
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -22,32 +24,150 @@ import (
 	"github.com/dapr/cli/pkg/standalone"
 )
 
-var stopAppID string
+var (
+	stopAppID     string
+	stopAll       bool
+	stopTimeout   int
+	stopRunFile   string
+	stopDryRun    bool
+	stopNamespace string
+)
 
 var StopCmd = &cobra.Command{
-	Use:   "stop",
+	Use:   "stop [app-id...]",
 	Short: "Stop Dapr instances and their associated apps. Supported platforms: Self-hosted",
 	Example: `
 # Stop Dapr application
 dapr stop --app-id <ID>
+
+# Stop all Dapr applications started with dapr run
+dapr stop --all
+
+# Stop Dapr application and kill it if it is still running after 30 seconds
+dapr stop --app-id <ID> --timeout 30
+
+# Stop several Dapr applications, and all applications whose ID starts with order-
+dapr stop checkout payments "order-*"
+
+# Stop all Dapr applications started from a run file
+dapr stop -f dapr.yaml
+
+# Print the processes that would be signaled and killed without stopping the apps
+dapr stop --all --dry-run
+
+# Stop all Dapr applications started with dapr run --namespace shop
+dapr stop --namespace shop
 `,
 	Run: func(cmd *cobra.Command, args []string) {
+		timeout := time.Duration(stopTimeout) * time.Second
+		if stopNamespace != "" {
+			if stopAppID != "" || len(args) > 0 || stopRunFile != "" {
+				print.FailureStatusEvent(os.Stderr, "App IDs and --run-file cannot be given together with --namespace, which stops all apps of the namespace")
+				exit(1)
+			}
+			// The apps are matched by namespace, as apps in other namespaces can use the same IDs.
+			stopAll = true
+		}
+		if stopAll {
+			if stopAppID != "" || len(args) > 0 {
+				print.FailureStatusEvent(os.Stderr, "App IDs cannot be given together with --all")
+				exit(1)
+			}
+			if stopDryRun {
+				printStopActions(nil, stopNamespace, timeout)
+				return
+			}
+			err := standalone.StopAll(stopNamespace, timeout, printStopResult)
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, "failed to stop apps: %s", err)
+				exit(1)
+			}
+			return
+		}
+
+		if stopRunFile != "" {
+			if stopAppID != "" || len(args) > 0 {
+				print.FailureStatusEvent(os.Stderr, "App IDs cannot be given together with --run-file")
+				exit(1)
+			}
+			apps, err := standalone.ParseRunFile(stopRunFile)
+			if err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			for _, app := range apps {
+				args = append(args, app.AppID)
+			}
+		}
 		if stopAppID != "" {
 			args = append(args, stopAppID)
 		}
-		for _, appID := range args {
-			err := standalone.Stop(appID)
-			if err != nil {
-				print.FailureStatusEvent(os.Stderr, "failed to stop app id %s: %s", appID, err)
-			} else {
-				print.SuccessStatusEvent(os.Stdout, "app stopped successfully: %s", appID)
+		if len(args) == 0 {
+			print.FailureStatusEvent(os.Stderr, "Pass the app IDs to stop, --all or --run-file")
+			exit(1)
+		}
+
+		if stopDryRun {
+			printStopActions(args, "", timeout)
+			return
+		}
+
+		if len(args) == 1 && stopRunFile == "" && !strings.ContainsAny(args[0], "*?[") {
+			printStopResult(args[0], standalone.Stop(args[0], timeout))
+			return
+		}
+		results, err := standalone.StopApps(args, timeout)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "failed to stop apps: %s", err)
+			exit(1)
+		}
+		err = print.WriteTable(os.Stdout, results, false)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		for _, result := range results {
+			if !result.Stopped() {
+				exit(1)
 			}
 		}
 	},
 }
 
+func printStopResult(appID string, err error) {
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, "failed to stop app id %s: %s", appID, err)
+	} else {
+		print.SuccessStatusEvent(os.Stdout, "app stopped successfully: %s", appID)
+	}
+}
+
+// printStopActions prints the actions of stopping the apps matching patterns, or all apps of namespace if patterns
+// is empty, and exits with an error if a pattern matches no app.
+func printStopActions(patterns []string, namespace string, timeout time.Duration) {
+	actions, unmatched, err := standalone.StopActions(patterns, namespace, timeout)
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, "failed to stop apps: %s", err)
+		exit(1)
+	}
+	for _, action := range actions {
+		print.DryRunStatusEvent(os.Stdout, "Would %s", action)
+	}
+	if len(unmatched) > 0 {
+		print.FailureStatusEvent(os.Stderr, "couldn't find app id %s", strings.Join(unmatched, ", "))
+		exit(1)
+	}
+	print.InfoStatusEvent(os.Stdout, "Dry run, no app was stopped.")
+}
+
 func init() {
+	StopCmd.ValidArgsFunction = completeAppIDs
 	StopCmd.Flags().StringVarP(&stopAppID, "app-id", "a", "", "The application id to be stopped")
+	StopCmd.Flags().BoolVar(&stopAll, "all", false, "Stop all apps started with dapr run")
+	StopCmd.Flags().IntVar(&stopTimeout, "timeout", 0, "The number of seconds to wait for an app to exit before it is killed. 0 does not wait")
+	StopCmd.Flags().StringVarP(&stopRunFile, "run-file", "f", "", "Stop the apps of a run file started with dapr run -f")
+	StopCmd.Flags().StringVarP(&stopNamespace, "namespace", "n", "", "Stop all apps started with dapr run --namespace in this namespace")
+	StopCmd.Flags().BoolVar(&stopDryRun, "dry-run", false, "Print the processes that would be signaled and killed without stopping the apps")
 	StopCmd.Flags().BoolP("help", "h", false, "Print this help message")
 	RootCmd.AddCommand(StopCmd)
 }
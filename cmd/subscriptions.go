@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/kubernetes"
+	"github.com/dapr/cli/pkg/metadata"
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+var (
+	subscriptionsAppID     string
+	subscriptionsPodName   string
+	subscriptionsNamespace string
+	subscriptionsSocket    string
+)
+
+var SubscriptionsCmd = &cobra.Command{
+	Use:   "subscriptions",
+	Short: "Inspect the pub/sub subscriptions of the sidecars. Supported platforms: Kubernetes and self-hosted",
+}
+
+var SubscriptionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the pub/sub subscriptions registered by the sidecar of an app. Supported platforms: Kubernetes and self-hosted",
+	Long: `List the programmatic and declarative pub/sub subscriptions registered by the sidecar of an app, with their
+pub/sub, topic, routes and dead-letter topic. Use it to find out why a handler of the app does not receive events.
+The type of the subscriptions is shown with Dapr 1.11 and later.
+`,
+	Example: `
+# List the subscriptions of the app orders
+dapr subscriptions list --app-id orders
+
+# List the subscriptions of the app orders in Kubernetes
+dapr subscriptions list -k --app-id orders --namespace shop
+
+# List the subscriptions of the app orders as JSON
+dapr subscriptions list --app-id orders --output-format json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var subscriptions []metadata.SubscriptionOutput
+		var err error
+		if kubernetesMode {
+			if subscriptionsAppID == "" {
+				print.FailureStatusEvent(os.Stderr, "The --app-id flag is required in Kubernetes mode")
+				exit(1)
+			}
+			subscriptions, err = kubernetes.ListSubscriptions(subscriptionsAppID, subscriptionsPodName, subscriptionsNamespace)
+		} else {
+			checkUnixDomainSocket(subscriptionsSocket)
+			subscriptions, err = standalone.NewClient().ListSubscriptions(subscriptionsAppID, subscriptionsSocket)
+		}
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error listing the subscriptions: %s", err)
+			exit(1)
+		}
+		if len(subscriptions) == 0 && print.GetRenderer().Interactive() {
+			print.InfoStatusEvent(os.Stdout, "No subscriptions are registered by the sidecar. Check that the app is subscribed to topics and that its components are loaded")
+			return
+		}
+		if err = print.WriteTable(os.Stdout, subscriptions, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+	PostRun: func(cmd *cobra.Command, args []string) {
+		if kubernetesMode {
+			kubernetes.CheckForCertExpiry()
+		}
+	},
+}
+
+func init() {
+	SubscriptionsListCmd.Flags().BoolVarP(&kubernetesMode, "kubernetes", "k", false, "List the subscriptions of an app in a Kubernetes cluster")
+	SubscriptionsListCmd.Flags().StringVarP(&subscriptionsAppID, "app-id", "a", "", "The ID of the app. Required in Kubernetes mode, or if more than one app is running")
+	SubscriptionsListCmd.Flags().StringVarP(&subscriptionsPodName, "pod-name", "p", "", "The name of the pod, in case the app has multiple pods in Kubernetes. Defaults to the first running pod")
+	SubscriptionsListCmd.Flags().StringVarP(&subscriptionsNamespace, "namespace", "n", "default", "The Kubernetes namespace in which the app is deployed")
+	SubscriptionsListCmd.Flags().StringVarP(&subscriptionsSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Dapr API servers will use Unix Domain Sockets")
+	SubscriptionsListCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	SubscriptionsCmd.AddCommand(SubscriptionsListCmd)
+	RootCmd.AddCommand(SubscriptionsCmd)
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	path_filepath "path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/dapr/cli/pkg/kubernetes"
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+	"github.com/dapr/cli/pkg/supportbundle"
+	"github.com/dapr/cli/pkg/telemetry"
+)
+
+// maxBundleLogSize is the size of the end of each self-hosted log file that is added to the support bundle.
+const maxBundleLogSize = 1024 * 1024
+
+var (
+	supportBundleOutput string
+	supportBundleTail   int64
+)
+
+var SupportBundleCmd = &cobra.Command{
+	Use:   "support-bundle",
+	Short: "Gather the versions, logs, components and status of Dapr into a zip file to attach to bug reports. Supported platforms: Kubernetes and self-hosted",
+	Example: `
+# Gather the diagnostics of the self-hosted installation into dapr-support-bundle-<time>.zip
+dapr support-bundle
+
+# Gather the diagnostics of the Dapr control plane in Kubernetes into a specific file
+dapr support-bundle -k --output ./bundle.zip
+
+# Gather the last 2000 lines of the logs of each control plane container
+dapr support-bundle -k --tail 2000
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		viper.BindPFlag("network", cmd.Flags().Lookup("network"))
+		viper.BindPFlag("container-runtime", cmd.Flags().Lookup("container-runtime"))
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		output := supportBundleOutput
+		if output == "" {
+			output = fmt.Sprintf("dapr-support-bundle-%s.zip", time.Now().Format("20060102-150405"))
+		}
+		f, err := os.Create(output)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error creating the support bundle: %s", err)
+			exit(1)
+		}
+		defer f.Close()
+
+		bundle := supportbundle.NewWriter(f)
+		addSelfHostedDiagnostics(bundle, viper.GetString("container-runtime"), viper.GetString("network"))
+		if kubernetesMode {
+			addKubernetesDiagnostics(bundle, supportBundleTail)
+		}
+		if err = bundle.Close(); err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error writing the support bundle: %s", err)
+			exit(1)
+		}
+
+		for _, e := range bundle.Errors() {
+			print.WarningStatusEvent(os.Stderr, "Not included: %s", e)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Support bundle with %d files written to %s", len(bundle.Files()), output)
+		print.InfoStatusEvent(os.Stdout, "Secrets in component manifests, credentials in URLs and the flag values of the command history are redacted. Review the bundle before attaching it to an issue.")
+	},
+}
+
+// addSelfHostedDiagnostics adds the versions, the command history, the logs, the components, the running apps and
+// the status of the containers of the self-hosted installation to bundle.
+func addSelfHostedDiagnostics(bundle *supportbundle.Writer, containerRuntime, dockerNetwork string) {
+	add := func(part string, err error) {
+		if err != nil {
+			bundle.AddError(part, err)
+		}
+	}
+
+	add("versions", bundle.AddJSON("versions.json", map[string]string{
+		"cli":       daprVer.CliVersion,
+		"runtime":   strings.TrimSpace(standalone.GetRuntimeVersion()),
+		"dashboard": strings.TrimSpace(standalone.GetDashboardVersion()),
+		"gitCommit": standalone.GitCommit(),
+		"os":        runtime.GOOS,
+		"arch":      runtime.GOARCH,
+	}))
+
+	// The history holds the invocations of dapr init and the other commands, with their errors.
+	history, err := telemetry.ReadHistory(standalone.DefaultHistoryFilePath(), 0)
+	if err == nil {
+		for i := range history {
+			history[i].Command = telemetry.RedactCommandLine(history[i].Command)
+			history[i].Error = supportbundle.RedactURL(history[i].Error)
+		}
+		err = bundle.AddJSON("history.json", history)
+	}
+	add("history", err)
+
+	add("logs", addFiles(bundle, standalone.DefaultLogsDirPath(), "logs", func(b []byte) ([]byte, error) {
+		return supportbundle.Tail(b, maxBundleLogSize), nil
+	}))
+	add("components", addFiles(bundle, standalone.DefaultComponentsDirPath(), "components", supportbundle.RedactManifest))
+	if b, err := os.ReadFile(standalone.DefaultConfigFilePath()); err == nil {
+		add("configuration", bundle.Add("config.yaml", b))
+	} else if !errors.Is(err, os.ErrNotExist) {
+		add("configuration", err)
+	}
+
+	apps, err := standalone.List()
+	if err == nil {
+		err = bundle.AddJSON("list.json", apps)
+	}
+	add("list", err)
+
+	status, err := standalone.Status(containerRuntime, dockerNetwork)
+	if err == nil {
+		err = bundle.AddJSON("status.json", status)
+	}
+	add("status", err)
+}
+
+// addFiles adds the YAML and log files of dir and its subdirectories, such as the logs of the apps of a namespace,
+// to bundle under prefix, transformed by transform. A missing directory is not an error.
+func addFiles(bundle *supportbundle.Writer, dir, prefix string, transform func([]byte) ([]byte, error)) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			if err = addFiles(bundle, path_filepath.Join(dir, entry.Name()), prefix+"/"+entry.Name(), transform); err != nil {
+				bundle.AddError(prefix+"/"+entry.Name(), err)
+			}
+			continue
+		}
+		switch path_filepath.Ext(entry.Name()) {
+		case ".yaml", ".yml", ".log", ".1":
+		default:
+			continue
+		}
+		b, err := os.ReadFile(path_filepath.Join(dir, entry.Name()))
+		if err == nil {
+			b, err = transform(b)
+		}
+		if err == nil {
+			err = bundle.Add(prefix+"/"+entry.Name(), b)
+		}
+		if err != nil {
+			bundle.AddError(prefix+"/"+entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+// addKubernetesDiagnostics adds the status of the control plane, the apps, the redacted components and the logs of
+// the control plane containers in the cluster to bundle.
+func addKubernetesDiagnostics(bundle *supportbundle.Writer, tail int64) {
+	add := func(part string, err error) {
+		if err != nil {
+			bundle.AddError(part, err)
+		}
+	}
+
+	statusClient, err := kubernetes.NewStatusClient()
+	if err == nil {
+		var status []kubernetes.StatusOutput
+		if status, err = statusClient.Status(); err == nil {
+			err = bundle.AddJSON("kubernetes/status.json", status)
+		}
+	}
+	add("kubernetes status", err)
+
+	apps, err := kubernetes.List("", "")
+	if err == nil {
+		err = bundle.AddJSON("kubernetes/list.json", apps)
+	}
+	add("kubernetes list", err)
+
+	components, err := kubernetes.ComponentManifests()
+	add("kubernetes components", err)
+	for _, key := range sortedKeys(components) {
+		b, err := supportbundle.RedactManifest(components[key])
+		if err == nil {
+			err = bundle.Add("kubernetes/components/"+strings.ReplaceAll(key, "/", "_")+".yaml", b)
+		}
+		add("kubernetes/components/"+key, err)
+	}
+
+	logs, logErrs, err := kubernetes.ControlPlaneLogs(context.Background(), tail)
+	add("kubernetes logs", err)
+	for _, key := range sortedKeys(logs) {
+		add("kubernetes/logs/"+key, bundle.Add("kubernetes/logs/"+key+".log", logs[key]))
+	}
+	for key, err := range logErrs {
+		add("kubernetes/logs/"+key, err)
+	}
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func init() {
+	SupportBundleCmd.Flags().BoolVarP(&kubernetesMode, "kubernetes", "k", false, "Also gather the status, apps, components and control plane logs of the Kubernetes cluster")
+	SupportBundleCmd.Flags().StringVarP(&supportBundleOutput, "output", "o", "", "The zip file to write the support bundle to. Defaults to dapr-support-bundle-<time>.zip in the current directory")
+	SupportBundleCmd.Flags().Int64Var(&supportBundleTail, "tail", 500, "The number of most recent lines of the logs of each control plane container to gather with --kubernetes")
+	SupportBundleCmd.Flags().String("network", "", "The Docker network the self-hosted Dapr services were installed on")
+	SupportBundleCmd.Flags().String("container-runtime", standalone.DockerContainerRuntime, "The container runtime the self-hosted Dapr services run in. Valid values are: docker, podman")
+	SupportBundleCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	RootCmd.AddCommand(SupportBundleCmd)
+}
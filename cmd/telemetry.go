@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+	"github.com/dapr/cli/pkg/telemetry"
+)
+
+var telemetryEndpoint string
+
+var TelemetryCmd = &cobra.Command{
+	Use:   "telemetry",
+	Short: "Opt in to or out of anonymous usage telemetry",
+	Long: `Opt in to or out of anonymous usage telemetry. Telemetry is disabled unless it is enabled with dapr telemetry enable.
+Once enabled, an event with the name of every command, the names of its flags, its outcome and duration, the CLI
+version and the platform is sent to the telemetry endpoint. Flag values, arguments and app output are never sent.
+Events are tied by a random install ID, which is removed when telemetry is disabled.`,
+}
+
+var TelemetryEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Opt in to anonymous usage telemetry",
+	Example: `
+# Opt in to anonymous usage telemetry
+dapr telemetry enable
+
+# Opt in, sending the events to a collector of your own
+dapr telemetry enable --endpoint https://collector.example.com/v1/events
+`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if telemetryEndpoint != "" {
+			if u, err := url.Parse(telemetryEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				print.FailureStatusEvent(os.Stderr, "Invalid telemetry endpoint %q: must be an http or https URL", telemetryEndpoint)
+				exit(1)
+			}
+		}
+		settings, err := telemetry.Enable(standalone.DefaultTelemetryFilePath(), telemetryEndpoint)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Telemetry enabled. Thank you for helping to improve Dapr")
+		if settings.EffectiveEndpoint() == "" {
+			print.WarningStatusEvent(os.Stdout, "This build of the CLI has no telemetry endpoint, so no events are sent until one is given with --endpoint")
+		}
+	},
+}
+
+var TelemetryDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Opt out of anonymous usage telemetry",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := telemetry.Disable(standalone.DefaultTelemetryFilePath()); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Telemetry disabled")
+	},
+}
+
+// telemetryStatusOutput is the output of `dapr telemetry status`.
+type telemetryStatusOutput struct {
+	Enabled   bool   `csv:"ENABLED"    json:"enabled"   yaml:"enabled"`
+	Endpoint  string `csv:"ENDPOINT"   json:"endpoint"  yaml:"endpoint"`
+	InstallID string `csv:"INSTALL ID" json:"installId" yaml:"installId"`
+}
+
+var TelemetryStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Print whether anonymous usage telemetry is enabled",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		settings, err := telemetry.LoadSettings(standalone.DefaultTelemetryFilePath())
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		status := []telemetryStatusOutput{{
+			Enabled:   settings.Enabled,
+			Endpoint:  settings.EffectiveEndpoint(),
+			InstallID: settings.InstallID,
+		}}
+		if err = print.WriteTable(os.Stdout, status, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	TelemetryEnableCmd.Flags().StringVar(&telemetryEndpoint, "endpoint", "", "The URL of the collector to send the events to, instead of the default one of the CLI")
+	for _, c := range []*cobra.Command{TelemetryEnableCmd, TelemetryDisableCmd, TelemetryStatusCmd} {
+		c.Flags().BoolP("help", "h", false, "Print this help message")
+		TelemetryCmd.AddCommand(c)
+	}
+	RootCmd.AddCommand(TelemetryCmd)
+}
@@ -14,9 +14,11 @@ limitations under the License.
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
@@ -29,6 +31,15 @@ var (
 	uninstallNamespace  string
 	uninstallKubernetes bool
 	uninstallAll        bool
+	uninstallDryRun     bool
+	uninstallKeepRedis  bool
+	uninstallKeepBin    bool
+	uninstallContainers bool
+	uninstallDeleteCRDs bool
+	uninstallDeleteNS   bool
+	uninstallForce      bool
+	uninstallBackupDir  string
+	uninstallYes        bool
 )
 
 // UninstallCmd is a command from removing a Dapr installation.
@@ -42,27 +53,92 @@ dapr uninstall
 # Uninstall from self-hosted mode and remove .dapr directory, Redis, Placement and Zipkin containers
 dapr uninstall --all
 
+# Uninstall from self-hosted mode when Dapr was initialized with Podman
+dapr uninstall --container-runtime podman
+
+# List what would be removed from self-hosted mode without removing it
+dapr uninstall --all --dry-run
+
+# Print the Helm release and CRDs that would be removed from Kubernetes without removing them
+dapr uninstall -k --all --dry-run
+
+# Reset self-hosted mode but keep the Redis container with its data and the downloaded binaries
+dapr uninstall --all --keep-redis --keep-bin
+
+# Remove only the Placement, Redis and Zipkin containers in self-hosted mode
+dapr uninstall --containers-only
+
 # Uninstall from Kubernetes
 dapr uninstall -k
+
+# Uninstall from Kubernetes and remove the Dapr CRDs and namespace, exporting the remaining Dapr resources first
+dapr uninstall -k --delete-crds --delete-namespace --backup-dir ./dapr-backup
 `,
 	PreRun: func(cmd *cobra.Command, args []string) {
 		viper.BindPFlag("network", cmd.Flags().Lookup("network"))
 		viper.BindPFlag("install-path", cmd.Flags().Lookup("install-path"))
+		viper.BindPFlag("container-runtime", cmd.Flags().Lookup("container-runtime"))
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		var err error
 
 		if uninstallKubernetes {
-			print.InfoStatusEvent(os.Stdout, "Removing Dapr from your cluster...")
-			err = kubernetes.Uninstall(uninstallNamespace, uninstallAll, timeout)
+			if uninstallKeepRedis || uninstallKeepBin || uninstallContainers {
+				print.FailureStatusEvent(os.Stderr, "The --keep-redis, --keep-bin and --containers-only flags are only supported in self-hosted mode")
+				exit(1)
+			}
+			if uninstallDryRun {
+				print.InfoStatusEvent(os.Stdout, "Dry run, listing what would be removed from your cluster...")
+			} else {
+				print.InfoStatusEvent(os.Stdout, "Removing Dapr from your cluster...")
+			}
+			conf := kubernetes.UninstallConfig{
+				Namespace:       uninstallNamespace,
+				Timeout:         timeout,
+				DryRun:          uninstallDryRun,
+				DeleteCRDs:      uninstallAll || uninstallDeleteCRDs,
+				DeleteNamespace: uninstallDeleteNS,
+				Force:           uninstallForce,
+				BackupDir:       uninstallBackupDir,
+			}
+			// Ask before deleting Dapr resources only when someone can answer. Resources that are only orphaned are
+			// printed as warnings, so that scripted uninstalls are not stopped by a prompt.
+			deletesResources := conf.DeleteCRDs || conf.DeleteNamespace
+			if deletesResources && !uninstallYes && isatty.IsTerminal(os.Stdin.Fd()) && print.GetRenderer().Interactive() {
+				conf.Confirm = func(resources []kubernetes.DaprResource) (bool, error) {
+					return kubernetes.ConfirmUninstall(os.Stdin, os.Stdout, conf, resources)
+				}
+			}
+			err = kubernetes.Uninstall(conf)
+			if errors.Is(err, kubernetes.ErrUninstallAborted) {
+				print.InfoStatusEvent(os.Stdout, "Uninstall aborted, Dapr was not removed.")
+				return
+			}
 		} else {
-			print.InfoStatusEvent(os.Stdout, "Removing Dapr from your machine...")
-			dockerNetwork := viper.GetString("network")
-			err = standalone.Uninstall(uninstallAll, dockerNetwork)
+			if uninstallDeleteCRDs || uninstallDeleteNS || uninstallForce || uninstallBackupDir != "" {
+				print.FailureStatusEvent(os.Stderr, "The --delete-crds, --delete-namespace, --force and --backup-dir flags are only supported in Kubernetes mode")
+				exit(1)
+			}
+			if uninstallDryRun {
+				print.InfoStatusEvent(os.Stdout, "Dry run, listing what would be removed from your machine...")
+			} else {
+				print.InfoStatusEvent(os.Stdout, "Removing Dapr from your machine...")
+			}
+			err = standalone.Uninstall(standalone.UninstallConfig{
+				All:              uninstallAll,
+				DockerNetwork:    viper.GetString("network"),
+				ContainerRuntime: viper.GetString("container-runtime"),
+				DryRun:           uninstallDryRun,
+				KeepRedis:        uninstallKeepRedis,
+				KeepBin:          uninstallKeepBin,
+				ContainersOnly:   uninstallContainers,
+			})
 		}
 
 		if err != nil {
 			print.FailureStatusEvent(os.Stderr, fmt.Sprintf("Error removing Dapr: %s", err))
+		} else if uninstallDryRun {
+			print.InfoStatusEvent(os.Stdout, "Dry run, Dapr was not removed.")
 		} else {
 			print.SuccessStatusEvent(os.Stdout, "Dapr has been removed successfully")
 		}
@@ -72,8 +148,18 @@ dapr uninstall -k
 func init() {
 	UninstallCmd.Flags().BoolVarP(&uninstallKubernetes, "kubernetes", "k", false, "Uninstall Dapr from a Kubernetes cluster")
 	UninstallCmd.Flags().UintVarP(&timeout, "timeout", "", 300, "The timeout for the Kubernetes uninstall")
-	UninstallCmd.Flags().BoolVar(&uninstallAll, "all", false, "Remove .dapr directory, Redis, Placement and Zipkin containers on local machine, and CRDs on a Kubernetes cluster")
+	UninstallCmd.Flags().BoolVar(&uninstallAll, "all", false, "Remove .dapr directory, Redis, Placement, Zipkin, Kafka and PostgreSQL containers on local machine, and CRDs on a Kubernetes cluster")
+	UninstallCmd.Flags().BoolVar(&uninstallDryRun, "dry-run", false, "List the directories, containers and images, or the Helm release and CRDs in Kubernetes, that would be removed without removing them")
+	UninstallCmd.Flags().BoolVar(&uninstallKeepRedis, "keep-redis", false, "Keep the Redis container and its data in self-hosted mode")
+	UninstallCmd.Flags().BoolVar(&uninstallKeepBin, "keep-bin", false, "Keep the downloaded binaries, the runtime versions installed side by side and the Dapr image in self-hosted mode")
+	UninstallCmd.Flags().BoolVar(&uninstallContainers, "containers-only", false, "Only remove the Placement, Redis and Zipkin containers in self-hosted mode, keeping all files and images")
+	UninstallCmd.Flags().BoolVar(&uninstallDeleteCRDs, "delete-crds", false, "Remove the Dapr CRDs, and with them all components, configurations and subscriptions, from a Kubernetes cluster")
+	UninstallCmd.Flags().BoolVar(&uninstallDeleteNS, "delete-namespace", false, "Remove the namespace Dapr is installed in from a Kubernetes cluster. It is not removed if other workloads run in it, unless --force is given")
+	UninstallCmd.Flags().BoolVar(&uninstallForce, "force", false, "Remove the namespace with --delete-namespace also if workloads other than Dapr's run in it")
+	UninstallCmd.Flags().StringVar(&uninstallBackupDir, "backup-dir", "", "Export the Dapr components, configurations and subscriptions in a Kubernetes cluster to this directory before removing Dapr")
+	UninstallCmd.Flags().BoolVar(&uninstallYes, "yes", false, "Remove Dapr from a Kubernetes cluster without asking for confirmation when Dapr resources remain in it")
 	UninstallCmd.Flags().String("network", "", "The Docker network from which to remove the Dapr runtime")
+	UninstallCmd.Flags().String("container-runtime", standalone.DockerContainerRuntime, "The container runtime Dapr was initialized with. Valid values are: docker, podman")
 	UninstallCmd.Flags().StringVarP(&uninstallNamespace, "namespace", "n", "dapr-system", "The Kubernetes namespace to uninstall Dapr from")
 	UninstallCmd.Flags().BoolP("help", "h", false, "Print this help message")
 	RootCmd.AddCommand(UninstallCmd)
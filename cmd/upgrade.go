@@ -22,24 +22,91 @@ import (
 
 	"github.com/dapr/cli/pkg/kubernetes"
 	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
 )
 
-var upgradeRuntimeVersion string
+var (
+	upgradeRuntimeVersion   string
+	upgradeDashboardVersion string
+	upgradeDryRun           bool
+	upgradeExportValues     string
+	upgradeForce            bool
+	upgradeCLIVersion       string
+	upgradeCLIMirrorURL     string
+	upgradeHelmRepo         string
+	upgradeChart            string
+)
 
 var UpgradeCmd = &cobra.Command{
 	Use:   "upgrade",
-	Short: "Upgrades or downgrades a Dapr control plane installation in a cluster. Supported platforms: Kubernetes",
+	Short: "Upgrades or downgrades a Dapr installation. Supported platforms: Kubernetes and self-hosted",
 	PreRun: func(cmd *cobra.Command, args []string) {
 		viper.BindPFlag("image-registry", cmd.Flags().Lookup("image-registry"))
+		viper.BindPFlag("network", cmd.Flags().Lookup("network"))
+		viper.BindPFlag("container-runtime", cmd.Flags().Lookup("container-runtime"))
+		applyNetworkFlags()
 	},
 	Example: `
 # Upgrade Dapr in Kubernetes
-dapr upgrade -k
+dapr upgrade -k --runtime-version 1.8.0
+
+# Upgrade Dapr in self-hosted mode to the latest version
+dapr upgrade
+
+# Upgrade or downgrade Dapr in self-hosted mode to a specific version
+dapr upgrade --runtime-version 1.8.0
+
+# Upgrade Dapr in Kubernetes from the chart in an OCI registry it was installed from
+dapr upgrade -k --runtime-version 1.8.0 --chart oci://registry.example.com/charts/dapr
+
+# Check if Dapr in Kubernetes can be upgraded, and print the upgrade, without upgrading it
+dapr upgrade -k --runtime-version 1.8.0 --dry-run
+
+# Print the downloads and containers of an upgrade in self-hosted mode without upgrading
+dapr upgrade --runtime-version 1.8.0 --dry-run
+
+# Export the Helm values of the Dapr control plane in Kubernetes without upgrading it, for example to move to GitOps
+dapr upgrade -k --dry-run --export-values dapr-values.yaml
+
+# Upgrade the Dapr CLI to the latest version
+dapr upgrade cli
 
 # See more at: https://docs.dapr.io/getting-started/
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		imageRegistryFlag := strings.TrimSpace(viper.GetString("image-registry"))
+		if !kubernetesMode {
+			if upgradeExportValues != "" {
+				print.FailureStatusEvent(os.Stderr, "The --export-values flag is only supported with --kubernetes")
+				exit(1)
+			}
+			if upgradeHelmRepo != "" || upgradeChart != "" {
+				print.FailureStatusEvent(os.Stderr, "--helm-repo and --chart are only supported in Kubernetes mode")
+				exit(1)
+			}
+			upgradeStandalone(imageRegistryFlag)
+			return
+		}
+		if upgradeHelmRepo != "" && upgradeChart != "" {
+			print.FailureStatusEvent(os.Stderr, "Only one of --helm-repo and --chart can be given")
+			exit(1)
+		}
+		chartSource := kubernetes.ChartSource{RepoURL: upgradeHelmRepo, Chart: upgradeChart}
+		if upgradeExportValues != "" {
+			exportValues(upgradeExportValues)
+		}
+		if upgradeDryRun && upgradeRuntimeVersion == "" {
+			if upgradeExportValues != "-" {
+				print.InfoStatusEvent(os.Stdout, "Dry run, Dapr was not upgraded.")
+			}
+			return
+		}
+		if upgradeRuntimeVersion == "" {
+			print.FailureStatusEvent(os.Stderr, "The --runtime-version flag is required to upgrade Dapr in Kubernetes")
+			exit(1)
+		}
+		upgradePreflight(upgradeRuntimeVersion, chartSource)
+
 		imageRegistryURI := ""
 		var err error
 
@@ -51,35 +118,187 @@ dapr upgrade -k
 		}
 		if err != nil {
 			print.FailureStatusEvent(os.Stderr, err.Error())
-			os.Exit(1)
+			exit(1)
 		}
 		err = kubernetes.Upgrade(kubernetes.UpgradeConfig{
 			RuntimeVersion:   upgradeRuntimeVersion,
 			Args:             values,
 			Timeout:          timeout,
 			ImageRegistryURI: imageRegistryURI,
+			ChartSource:      chartSource,
+			DryRun:           upgradeDryRun,
 		})
 		if err != nil {
 			print.FailureStatusEvent(os.Stderr, "Failed to upgrade Dapr: %s", err)
-			os.Exit(1)
+			exit(1)
+		}
+		if upgradeDryRun {
+			print.InfoStatusEvent(os.Stdout, "Dry run, Dapr was not upgraded.")
+			return
 		}
 		print.SuccessStatusEvent(os.Stdout, "Dapr control plane successfully upgraded to version %s. Make sure your deployments are restarted to pick up the latest sidecar version.", upgradeRuntimeVersion)
 	},
 	PostRun: func(cmd *cobra.Command, args []string) {
-		kubernetes.CheckForCertExpiry()
+		if kubernetesMode {
+			kubernetes.CheckForCertExpiry()
+		}
 	},
 }
 
+var UpgradeCLICmd = &cobra.Command{
+	Use:   "cli",
+	Short: "Upgrade or downgrade the Dapr CLI binary. Supported platforms: Kubernetes and self-hosted",
+	Long: `Upgrade or downgrade the Dapr CLI binary.
+The release archive is downloaded from GitHub, or from a mirror given by --mirror-url, and its checksum is verified.
+The running binary is then replaced. Downloads use the proxy set by the HTTPS_PROXY and NO_PROXY environment variables.
+`,
+	Example: `
+# Upgrade the Dapr CLI to the latest version
+dapr upgrade cli
+
+# Upgrade or downgrade the Dapr CLI to a specific version
+dapr upgrade cli --version 1.9.0
+
+# Upgrade the Dapr CLI from a mirror of the release archives, serving <mirror-url>/v<version>/<archive>
+dapr upgrade cli --version 1.9.0 --mirror-url https://mirror.example.com/dapr/cli
+`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		applyNetworkFlags()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		warnForSkipVerify()
+		currentVersion := daprVer.CliVersion
+		version, upgraded, err := standalone.UpgradeCLI(standalone.UpgradeCLIConfig{
+			Version:            upgradeCLIVersion,
+			CurrentVersion:     currentVersion,
+			MirrorURL:          upgradeCLIMirrorURL,
+			InsecureSkipVerify: skipVerify,
+			Force:              upgradeForce,
+		})
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Failed to upgrade the Dapr CLI: %s", err)
+			exit(1)
+		}
+		if !upgraded {
+			print.InfoStatusEvent(os.Stdout, "The Dapr CLI is already at version %s. Use --force to install it again", version)
+			return
+		}
+		print.SuccessStatusEvent(os.Stdout, "Dapr CLI upgraded from version %s to %s", currentVersion, version)
+	},
+}
+
+// upgradePreflight runs the pre-flight checks of a Kubernetes upgrade to the chart of source and exits if they
+// found blockers, unless --force is set.
+func upgradePreflight(targetVersion string, source kubernetes.ChartSource) {
+	status, err := kubernetes.GetDaprResourcesStatus()
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, "Failed to upgrade Dapr: %s", err)
+		exit(1)
+	}
+	print.InfoStatusEvent(os.Stdout, "Running pre-flight checks...")
+	report, err := kubernetes.UpgradePreflight(targetVersion, status, source)
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, "Pre-flight checks failed: %s", err)
+		exit(1)
+	}
+	for _, warning := range report.Warnings {
+		print.WarningStatusEvent(os.Stdout, warning)
+	}
+	for _, blocker := range report.Blockers {
+		print.FailureStatusEvent(os.Stderr, blocker)
+	}
+	if len(report.Blockers) == 0 {
+		print.SuccessStatusEvent(os.Stdout, "Pre-flight checks passed")
+		return
+	}
+	if !upgradeForce {
+		print.FailureStatusEvent(os.Stderr, "Found %d blocker(s) for the upgrade to %s. Resolve them or use --force to upgrade anyway", len(report.Blockers), targetVersion)
+		exit(1)
+	}
+	print.WarningStatusEvent(os.Stdout, "Found %d blocker(s) for the upgrade to %s. Upgrading anyway because --force is set", len(report.Blockers), targetVersion)
+}
+
+// exportValues writes the Helm values of the Dapr control plane to path, or to stdout if path is "-".
+func exportValues(path string) {
+	exported, err := kubernetes.ExportValues()
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, "Failed to export the Helm values: %s", err)
+		exit(1)
+	}
+	b, err := exported.YAML()
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, "Failed to export the Helm values: %s", err)
+		exit(1)
+	}
+	for _, omitted := range exported.Omitted {
+		print.WarningStatusEvent(os.Stderr, "The %s values hold secrets and were not exported. Use `dapr mtls export` to export the certificates.", omitted)
+	}
+	if path == "-" {
+		os.Stdout.Write(b)
+		return
+	}
+	// #nosec G306
+	if err = os.WriteFile(path, b, 0o644); err != nil {
+		print.FailureStatusEvent(os.Stderr, "Failed to write the Helm values: %s", err)
+		exit(1)
+	}
+	print.SuccessStatusEvent(os.Stdout, "Helm values of Dapr version %s in namespace %s exported to %s", exported.Version, exported.Namespace, path)
+}
+
+func upgradeStandalone(imageRegistryURI string) {
+	if len(imageRegistryURI) != 0 {
+		warnForPrivateRegFeat()
+	}
+	warnForSkipVerify()
+	runtimeVersion := upgradeRuntimeVersion
+	if runtimeVersion == "" {
+		runtimeVersion = "latest"
+	}
+	err := standalone.Upgrade(standalone.UpgradeConfig{
+		RuntimeVersion:     runtimeVersion,
+		DashboardVersion:   upgradeDashboardVersion,
+		DockerNetwork:      viper.GetString("network"),
+		ImageRegistryURL:   imageRegistryURI,
+		ContainerRuntime:   viper.GetString("container-runtime"),
+		InsecureSkipVerify: skipVerify,
+		DryRun:             upgradeDryRun,
+	})
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, "Failed to upgrade Dapr: %s", err)
+		exit(1)
+	}
+	if upgradeDryRun {
+		print.InfoStatusEvent(os.Stdout, "Dry run, Dapr was not upgraded.")
+		return
+	}
+	print.InfoStatusEvent(os.Stdout, "Restart your apps with `dapr run` to pick up the new runtime version.")
+}
+
 func init() {
 	UpgradeCmd.Flags().BoolVarP(&kubernetesMode, "kubernetes", "k", false, "Upgrade or downgrade Dapr in a Kubernetes cluster")
 	UpgradeCmd.Flags().UintVarP(&timeout, "timeout", "", 300, "The timeout for the Kubernetes upgrade")
-	UpgradeCmd.Flags().StringVarP(&upgradeRuntimeVersion, "runtime-version", "", "", "The version of the Dapr runtime to upgrade or downgrade to, for example: 1.0.0")
+	UpgradeCmd.Flags().StringVarP(&upgradeRuntimeVersion, "runtime-version", "", "", "The version of the Dapr runtime to upgrade or downgrade to, for example: 1.0.0. Defaults to the latest version in self-hosted mode")
+	UpgradeCmd.Flags().StringVarP(&upgradeDashboardVersion, "dashboard-version", "", "latest", "The version of the Dapr dashboard to upgrade or downgrade to in self-hosted mode, for example: 1.0.0")
+	UpgradeCmd.Flags().String("network", "", "The Docker network on which Dapr was initialized in self-hosted mode")
+	UpgradeCmd.Flags().String("container-runtime", standalone.DockerContainerRuntime, "The container runtime Dapr was initialized with in self-hosted mode. Valid values are: docker, podman")
+	UpgradeCmd.Flags().BoolVarP(&upgradeDryRun, "dry-run", "", false, "Print the downloads, containers, CRDs and Helm upgrade without upgrading Dapr. In Kubernetes, the pre-flight checks are run first, a diff of the resources that would change is printed, and --export-values can be used without --runtime-version to only export the Helm values")
+	UpgradeCmd.Flags().StringVarP(&upgradeExportValues, "export-values", "", "", "Export the Helm values of the Dapr control plane in Kubernetes to a file, or to stdout with -")
+	UpgradeCmd.Flags().BoolVarP(&upgradeForce, "force", "", false, "Upgrade Dapr in a Kubernetes cluster even if the pre-flight checks found blockers")
+	UpgradeCmd.Flags().StringVar(&upgradeHelmRepo, "helm-repo", "", "The URL of the Helm repository to upgrade the Dapr chart from in Kubernetes, such as an internal mirror. Credentials are read from the Helm repositories config")
+	UpgradeCmd.Flags().StringVar(&upgradeChart, "chart", "", "The Dapr chart to upgrade to in Kubernetes, as an OCI reference such as oci://registry/dapr/dapr, or as repo/chart of a repository added with helm repo add")
 	UpgradeCmd.Flags().BoolP("help", "h", false, "Print this help message")
 	UpgradeCmd.Flags().StringArrayVar(&values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	UpgradeCmd.Flags().String("image-registry", "", "Custom/Private docker image repository URL")
+	addNetworkFlags(UpgradeCmd)
+	UpgradeCmd.Flags().BoolVarP(&skipVerify, "insecure-skip-verify", "", false, "Do not verify the checksums of the downloaded binaries in self-hosted mode")
 
-	UpgradeCmd.MarkFlagRequired("runtime-version")
-	UpgradeCmd.MarkFlagRequired("kubernetes")
+	UpgradeCLICmd.Flags().StringVarP(&upgradeCLIVersion, "version", "", "latest", "The version of the Dapr CLI to upgrade or downgrade to, for example: 1.9.0")
+	UpgradeCLICmd.Flags().StringVarP(&upgradeCLIMirrorURL, "mirror-url", "", "", "The URL of a mirror of the CLI release archives, used instead of GitHub. Requires --version")
+	addNetworkFlags(UpgradeCLICmd)
+	UpgradeCLICmd.Flags().BoolVarP(&skipVerify, "insecure-skip-verify", "", false, "Do not verify the checksum of the downloaded CLI")
+	UpgradeCLICmd.Flags().BoolVarP(&upgradeForce, "force", "", false, "Install the version even if it is the current version of the CLI")
+	UpgradeCLICmd.Flags().BoolP("help", "h", false, "Print this help message")
+	UpgradeCmd.AddCommand(UpgradeCLICmd)
 
 	RootCmd.AddCommand(UpgradeCmd)
 }
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+var UseCmd = &cobra.Command{
+	Use:   "use [runtime-version]",
+	Short: "Switch the default Dapr runtime between the versions installed side by side. Supported platforms: Self-hosted",
+	Long: `Switch the default Dapr runtime between the versions installed side by side with dapr init --side-by-side.
+Apps started with dapr run afterwards use the daprd binary of the chosen version. Without a version, the installed
+versions are listed.`,
+	Example: `
+# List the installed runtime versions
+dapr use
+
+# Install runtime version 1.9.0 next to the installed one and make it the default
+dapr init --runtime-version 1.9.0 --side-by-side
+dapr use 1.9.0
+`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 1 {
+			if err := standalone.UseRuntimeVersion(args[0]); err != nil {
+				print.FailureStatusEvent(os.Stderr, err.Error())
+				exit(1)
+			}
+			return
+		}
+
+		versions, err := standalone.ListRuntimeVersions()
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		if len(versions) == 0 && print.GetRenderer().Interactive() {
+			print.InfoStatusEvent(os.Stdout, "No runtime versions are installed. Run `dapr init` to install Dapr")
+			return
+		}
+		if err = print.WriteTable(os.Stdout, versions, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	UseCmd.Flags().BoolP("help", "h", false, "Print this help message")
+	RootCmd.AddCommand(UseCmd)
+}
@@ -14,18 +14,24 @@ limitations under the License.
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+	"github.com/dapr/cli/pkg/version"
 )
 
 const cliVersionTemplateString = "CLI version: %s \nRuntime version: %s\n"
 
-var output string
+var (
+	output      string
+	checkLatest bool
+	runtimeOnly bool
+)
 
 var VersionCmd = &cobra.Command{
 	Use:   "version",
@@ -33,33 +39,108 @@ var VersionCmd = &cobra.Command{
 	Example: `
 # Version for Dapr
 dapr version --output json
+
+# Version for Dapr as YAML, for yq
+dapr version --output yaml
+
+# Version of the local Dapr runtime only, for scripts
+dapr version --runtime-only
+
+# Version for Dapr, with upgrade hints if a newer CLI or runtime is released
+dapr version --check-latest
 `,
 	Run: func(cmd *cobra.Command, args []string) {
-		if output != "" && output != "json" {
+		if output != "" && output != "json" && output != "yaml" {
 			print.FailureStatusEvent(os.Stdout, "An invalid output format was specified.")
-			os.Exit(1)
+			exit(1)
+		}
+		// json and yaml select the renderer of WriteDocument.
+		if err := print.SetOutputFlag(output); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		if runtimeOnly {
+			printRuntimeVersion()
+			return
+		}
+		info := daprVer
+		info.DashboardVersion = strings.TrimSpace(standalone.GetDashboardVersion())
+		info.GitCommit = standalone.GitCommit()
+		if checkLatest {
+			var err error
+			if info.LatestCliVersion, err = version.GetCLIVersion(); err != nil {
+				print.WarningStatusEvent(os.Stderr, "Failed to get the latest CLI version: %s", err)
+			}
+			if info.LatestRuntimeVersion, err = version.GetDaprVersion(); err != nil {
+				print.WarningStatusEvent(os.Stderr, "Failed to get the latest runtime version: %s", err)
+			}
 		}
+
 		switch output {
 		case "":
 			// normal output.
-			fmt.Printf(cliVersionTemplateString, daprVer.CliVersion, daprVer.RuntimeVersion)
-		case "json":
-			// json output.
-			b, err := json.Marshal(daprVer)
-			if err != nil {
+			fmt.Printf(cliVersionTemplateString, info.CliVersion, info.RuntimeVersion)
+			fmt.Printf("Dashboard version: %s\n", info.DashboardVersion)
+			if info.GitCommit != "" {
+				fmt.Printf("Git commit: %s\n", info.GitCommit)
+			}
+			if checkLatest {
+				printUpgradeHints(info)
+			}
+		case "json", "yaml":
+			buildInfo := standalone.GetBuildInfo(info.CliVersion)
+			info.BuildInfo = &buildInfo
+			if err := print.WriteDocument(os.Stdout, info); err != nil {
 				print.FailureStatusEvent(os.Stderr, err.Error())
-				os.Exit(1)
+				exit(1)
 			}
-			fmt.Printf("%s\n", string(b))
 		default:
 			// fail and exit.
-			os.Exit(1)
+			exit(1)
 		}
 	},
 }
 
+// printRuntimeVersion prints the version of the local runtime parsed from its build info, or its build info in the
+// output format. It fails if the runtime is not installed.
+func printRuntimeVersion() {
+	runtimeInfo := standalone.GetBuildInfo(daprVer.CliVersion).Runtime
+	if runtimeInfo == nil {
+		print.FailureStatusEvent(os.Stderr, "The Dapr runtime is not installed. Run dapr init to install it.")
+		exit(1)
+	}
+	switch output {
+	case "json", "yaml":
+		if err := print.WriteDocument(os.Stdout, runtimeInfo); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	default:
+		fmt.Println(runtimeInfo.Version)
+	}
+}
+
+// printUpgradeHints prints how to upgrade the CLI and the runtime if they are older than their latest release.
+func printUpgradeHints(info daprVersion) {
+	upToDate := true
+	if version.IsOutdated(info.CliVersion, info.LatestCliVersion) {
+		upToDate = false
+		print.InfoStatusEvent(os.Stdout, "CLI version %s is available. Upgrade with: dapr upgrade cli", info.LatestCliVersion)
+	}
+	if version.IsOutdated(info.RuntimeVersion, info.LatestRuntimeVersion) {
+		upToDate = false
+		print.InfoStatusEvent(os.Stdout, "Runtime version %s is available. Upgrade with: dapr upgrade --runtime-version %s, or dapr upgrade -k --runtime-version %s in Kubernetes",
+			info.LatestRuntimeVersion, info.LatestRuntimeVersion, info.LatestRuntimeVersion)
+	}
+	if upToDate && info.LatestCliVersion != "" && info.LatestRuntimeVersion != "" {
+		print.SuccessStatusEvent(os.Stdout, "The CLI and the runtime are up to date")
+	}
+}
+
 func init() {
 	VersionCmd.Flags().BoolP("help", "h", false, "Print this help message")
-	VersionCmd.Flags().StringVarP(&output, "output", "o", "", "The output format of the version command. Valid values are: json.")
+	VersionCmd.Flags().StringVarP(&output, "output", "o", "", "The output format of the version command. Valid values are: json or yaml")
+	VersionCmd.Flags().BoolVar(&checkLatest, "check-latest", false, "Compare the CLI and runtime versions with their latest releases on GitHub, and print how to upgrade them")
+	VersionCmd.Flags().BoolVar(&runtimeOnly, "runtime-only", false, "Print only the version of the local Dapr runtime, or its build info with --output")
 	RootCmd.AddCommand(VersionCmd)
 }
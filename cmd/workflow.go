@@ -0,0 +1,233 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+var (
+	workflowAppID      string
+	workflowComponent  string
+	workflowSocket     string
+	workflowStateStore string
+	workflowInstanceID string
+	workflowName       string
+	workflowInput      string
+	workflowInputFile  string
+	workflowEventName  string
+)
+
+var WorkflowCmd = &cobra.Command{
+	Use:   "workflow",
+	Short: "Start, list and manage the workflow instances of a running app. Supported platforms: Self-hosted",
+}
+
+var WorkflowListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the workflow instances of an app. Supported platforms: Self-hosted",
+	Long: `List the workflow instances of an app with their status.
+The instances are read from the actor state store, which must support the state query API, see https://docs.dapr.io/developing-applications/building-blocks/state-management/state-store-query-api/.
+`,
+	Example: `
+# List the workflow instances of the app orders
+dapr workflow list --app-id orders
+
+# List the workflow instances stored in the actor state store actorstore
+dapr workflow list --app-id orders --state-store actorstore --output-format json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkUnixDomainSocket(workflowSocket)
+		workflows, err := standalone.NewClient().ListWorkflows(workflowAppID, workflowComponent, workflowStateStore, workflowSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error listing the workflow instances: %s", err)
+			exit(1)
+		}
+		if len(workflows) == 0 && print.GetRenderer().Interactive() {
+			print.InfoStatusEvent(os.Stdout, "No workflow instances found")
+			return
+		}
+		if err = print.WriteTable(os.Stdout, workflows, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+}
+
+var WorkflowStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start a workflow instance. Supported platforms: Self-hosted",
+	Example: `
+# Start an instance of the workflow OrderProcessing
+dapr workflow start --app-id orders --workflow OrderProcessing --input '{"id":1}'
+
+# Start an instance with a chosen instance ID and the input of a file, or of stdin with -
+dapr workflow start --app-id orders --workflow OrderProcessing --instance-id order-1 --input-file order.json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		input, err := workflowData(workflowInput, workflowInputFile, "--input", "--input-file")
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		checkUnixDomainSocket(workflowSocket)
+		instanceID, err := standalone.NewClient().StartWorkflow(workflowAppID, workflowComponent, workflowName, workflowInstanceID, input, workflowSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error starting the workflow: %s", err)
+			exit(1)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Started workflow %s with instance ID %s", workflowName, instanceID)
+	},
+}
+
+var WorkflowTerminateCmd = workflowActionCmd("terminate", "Terminate a running workflow instance", "Terminated",
+	func(c standalone.Client) error {
+		return c.TerminateWorkflow(workflowAppID, workflowComponent, workflowInstanceID, workflowSocket)
+	})
+
+var WorkflowPauseCmd = workflowActionCmd("pause", "Suspend a running workflow instance", "Paused",
+	func(c standalone.Client) error {
+		return c.PauseWorkflow(workflowAppID, workflowComponent, workflowInstanceID, workflowSocket)
+	})
+
+var WorkflowResumeCmd = workflowActionCmd("resume", "Resume a suspended workflow instance", "Resumed",
+	func(c standalone.Client) error {
+		return c.ResumeWorkflow(workflowAppID, workflowComponent, workflowInstanceID, workflowSocket)
+	})
+
+var WorkflowPurgeCmd = workflowActionCmd("purge", "Remove the state and history of a completed, failed or terminated workflow instance", "Purged",
+	func(c standalone.Client) error {
+		return c.PurgeWorkflow(workflowAppID, workflowComponent, workflowInstanceID, workflowSocket)
+	})
+
+var WorkflowRaiseEventCmd = &cobra.Command{
+	Use:   "raise-event",
+	Short: "Send an event to a workflow instance. Supported platforms: Self-hosted",
+	Example: `
+# Send the event ApprovalReceived to the instance order-1
+dapr workflow raise-event --app-id orders --instance-id order-1 --event-name ApprovalReceived --data '{"approved":true}'
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := workflowData(workflowInput, workflowInputFile, "--data", "--data-file")
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+		checkUnixDomainSocket(workflowSocket)
+		err = standalone.NewClient().RaiseWorkflowEvent(workflowAppID, workflowComponent, workflowInstanceID, workflowEventName, data, workflowSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error raising the event: %s", err)
+			exit(1)
+		}
+		print.SuccessStatusEvent(os.Stdout, "Raised event %s of workflow instance %s", workflowEventName, workflowInstanceID)
+	},
+}
+
+var WorkflowHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List the history events of a workflow instance. Supported platforms: Self-hosted",
+	Long: `List the history events of a workflow instance.
+The history is read from the actor state store, which must support the state query API, see https://docs.dapr.io/developing-applications/building-blocks/state-management/state-store-query-api/.
+`,
+	Example: `
+# List the history of the instance order-1
+dapr workflow history --app-id orders --instance-id order-1
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkUnixDomainSocket(workflowSocket)
+		history, err := standalone.NewClient().WorkflowHistory(workflowAppID, workflowStateStore, workflowInstanceID, workflowSocket)
+		if err != nil {
+			print.FailureStatusEvent(os.Stderr, "Error getting the workflow history: %s", err)
+			exit(1)
+		}
+		if err = print.WriteTable(os.Stdout, history, false); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			exit(1)
+		}
+	},
+}
+
+// workflowActionCmd returns the command that runs a lifecycle operation on a workflow instance.
+func workflowActionCmd(use, short, done string, action func(c standalone.Client) error) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short + ". Supported platforms: Self-hosted",
+		Example: `
+dapr workflow ` + use + ` --app-id orders --instance-id order-1
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			checkUnixDomainSocket(workflowSocket)
+			if err := action(standalone.NewClient()); err != nil {
+				print.FailureStatusEvent(os.Stderr, "Error: %s", err)
+				exit(1)
+			}
+			print.SuccessStatusEvent(os.Stdout, "%s workflow instance %s", done, workflowInstanceID)
+		},
+	}
+}
+
+// workflowData returns the value of the data flag, or the content of the file of the file flag.
+func workflowData(value, file, valueFlag, fileFlag string) ([]byte, error) {
+	if value != "" && file != "" {
+		return nil, fmt.Errorf("only one of %s and %s allowed in the same command", valueFlag, fileFlag)
+	}
+	if file == "" {
+		return []byte(value), nil
+	}
+	b, err := readInputFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading '%s'. Error: %w", file, err)
+	}
+	return b, nil
+}
+
+func init() {
+	all := []*cobra.Command{
+		WorkflowListCmd, WorkflowStartCmd, WorkflowTerminateCmd, WorkflowPauseCmd, WorkflowResumeCmd,
+		WorkflowPurgeCmd, WorkflowRaiseEventCmd, WorkflowHistoryCmd,
+	}
+	for _, c := range all {
+		c.Flags().StringVarP(&workflowAppID, "app-id", "a", "", "The ID of the app running the workflows. Required if more than one app is running")
+		c.Flags().StringVarP(&workflowSocket, "unix-domain-socket", "u", "", "Path to a unix domain socket dir. If specified, Dapr API servers will use Unix Domain Sockets")
+		c.Flags().BoolP("help", "h", false, "Print this help message")
+		if c != WorkflowHistoryCmd {
+			c.Flags().StringVarP(&workflowComponent, "component", "c", standalone.DefaultWorkflowComponent, "The name of the workflow component")
+		}
+		if c != WorkflowListCmd && c != WorkflowStartCmd {
+			c.Flags().StringVarP(&workflowInstanceID, "instance-id", "i", "", "The ID of the workflow instance")
+			c.MarkFlagRequired("instance-id")
+		}
+		WorkflowCmd.AddCommand(c)
+	}
+	for _, c := range []*cobra.Command{WorkflowListCmd, WorkflowHistoryCmd} {
+		c.Flags().StringVarP(&workflowStateStore, "state-store", "s", "statestore", "The name of the actor state store component")
+	}
+	WorkflowStartCmd.Flags().StringVarP(&workflowName, "workflow", "w", "", "The name of the workflow to start")
+	WorkflowStartCmd.Flags().StringVarP(&workflowInstanceID, "instance-id", "i", "", "The ID of the new instance. Defaults to a random ID")
+	WorkflowStartCmd.Flags().StringVarP(&workflowInput, "input", "d", "", "The JSON serialized input of the workflow")
+	WorkflowStartCmd.Flags().StringVarP(&workflowInputFile, "input-file", "", "", "A file containing the input of the workflow, or - to read it from stdin")
+	WorkflowStartCmd.MarkFlagRequired("workflow")
+	WorkflowRaiseEventCmd.Flags().StringVarP(&workflowEventName, "event-name", "e", "", "The name of the event")
+	WorkflowRaiseEventCmd.Flags().StringVarP(&workflowInput, "data", "d", "", "The JSON serialized data of the event")
+	WorkflowRaiseEventCmd.Flags().StringVarP(&workflowInputFile, "data-file", "", "", "A file containing the data of the event, or - to read it from stdin")
+	WorkflowRaiseEventCmd.MarkFlagRequired("event-name")
+
+	RootCmd.AddCommand(WorkflowCmd)
+}
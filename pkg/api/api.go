@@ -23,6 +23,15 @@ type Metadata struct {
 	ID                string                      `json:"id"`
 	ActiveActorsCount []MetadataActiveActorsCount `json:"actors"`
 	Extended          map[string]string           `json:"extended"`
+	Subscriptions     []MetadataSubscription      `json:"subscriptions"`
+	Components        []MetadataComponent         `json:"components"`
+}
+
+// MetadataComponent is a component loaded by the sidecar.
+type MetadataComponent struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Version string `json:"version"`
 }
 
 // MetadataActiveActorsCount contain actorType and count of actors each type has.
@@ -30,3 +39,20 @@ type MetadataActiveActorsCount struct {
 	Type  string `json:"type"`
 	Count int    `json:"count"`
 }
+
+// MetadataSubscription is a pub/sub subscription registered by the sidecar.
+type MetadataSubscription struct {
+	PubsubName      string                     `json:"pubsubname"`
+	Topic           string                     `json:"topic"`
+	DeadLetterTopic string                     `json:"deadLetterTopic,omitempty"`
+	Rules           []MetadataSubscriptionRule `json:"rules,omitempty"`
+	// Type is PROGRAMMATIC or DECLARATIVE. It is only returned by Dapr 1.11 and later.
+	Type string `json:"type,omitempty"`
+}
+
+// MetadataSubscriptionRule routes the events of a subscription that match an expression to a path of the app.
+// The rule without a match expression is the default route.
+type MetadataSubscriptionRule struct {
+	Match string `json:"match,omitempty"`
+	Path  string `json:"path"`
+}
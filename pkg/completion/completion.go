@@ -0,0 +1,219 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package completion
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// The shells that completion scripts are generated for.
+const (
+	Bash       = "bash"
+	Zsh        = "zsh"
+	Fish       = "fish"
+	PowerShell = "powershell"
+)
+
+// Shells are the shells that completion scripts can be installed for.
+var Shells = []string{Bash, Zsh, Fish, PowerShell}
+
+// profileComment marks the lines added to the profile of a shell.
+const profileComment = "# dapr shell completion"
+
+// Installation is where the completion script of a shell was installed.
+type Installation struct {
+	Shell string
+	// Script is the file the completion script was written to.
+	Script string
+	// Profile is the startup file of the shell that loads the script. It is empty if the shell loads it
+	// from its completions directory.
+	Profile string
+	// ProfileUpdated is true if the lines that load the script were added to Profile.
+	ProfileUpdated bool
+}
+
+// environment is the operating system and the environment of the user that scripts are installed for.
+type environment struct {
+	goos   string
+	home   string
+	getenv func(string) string
+	// hasPwsh returns true if PowerShell 7 is installed, which has another profile than Windows PowerShell on Windows.
+	hasPwsh func() bool
+}
+
+func currentEnvironment() (environment, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return environment{}, fmt.Errorf("cannot find the home directory: %w", err)
+	}
+	return environment{
+		goos:   runtime.GOOS,
+		home:   home,
+		getenv: os.Getenv,
+		hasPwsh: func() bool {
+			_, err := exec.LookPath("pwsh")
+			return err == nil
+		},
+	}, nil
+}
+
+// DetectShell returns the shell of the user, from the SHELL environment variable, or PowerShell on Windows.
+func DetectShell() (string, error) {
+	return detectShell(runtime.GOOS, os.Getenv)
+}
+
+func detectShell(goos string, getenv func(string) string) (string, error) {
+	if shell := getenv("SHELL"); shell != "" {
+		name := strings.TrimSuffix(filepath.Base(shell), ".exe")
+		if name == "pwsh" {
+			return PowerShell, nil
+		}
+		for _, s := range Shells {
+			if name == s {
+				return s, nil
+			}
+		}
+		return "", fmt.Errorf("unsupported shell %s. Supported shells are: %s", name, strings.Join(Shells, ", "))
+	}
+	if goos == "windows" || getenv("PSModulePath") != "" {
+		return PowerShell, nil
+	}
+	return "", errors.New("cannot detect the shell. Pass the shell as an argument")
+}
+
+// Install writes the completion script of shell to the Dapr directory in the home directory of the user, or to
+// the completions directory of fish, and adds the lines that load it to the profile of the shell unless they are
+// already there.
+func Install(shell string, script []byte) (Installation, error) {
+	env, err := currentEnvironment()
+	if err != nil {
+		return Installation{}, err
+	}
+	return install(env, shell, script)
+}
+
+func install(env environment, shell string, script []byte) (Installation, error) {
+	inst, err := installPaths(env, shell)
+	if err != nil {
+		return Installation{}, err
+	}
+	if err = os.MkdirAll(filepath.Dir(inst.Script), 0o755); err != nil {
+		return Installation{}, err
+	}
+	// #nosec G306
+	if err = os.WriteFile(inst.Script, script, 0o644); err != nil {
+		return Installation{}, fmt.Errorf("error writing the completion script: %w", err)
+	}
+	if inst.Profile == "" {
+		return inst, nil
+	}
+	inst.ProfileUpdated, err = addToProfile(inst.Profile, profileLines(shell, inst.Script))
+	if err != nil {
+		return Installation{}, fmt.Errorf("error updating %s: %w", inst.Profile, err)
+	}
+	return inst, nil
+}
+
+// installPaths returns the script and the profile of a shell.
+func installPaths(env environment, shell string) (Installation, error) {
+	daprDir := filepath.Join(env.home, ".dapr")
+	configHome := env.getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(env.home, ".config")
+	}
+
+	inst := Installation{Shell: shell}
+	switch shell {
+	case Bash:
+		inst.Script = filepath.Join(daprDir, "completion.bash.inc")
+		// Terminals on macOS start login shells, which read .bash_profile instead of .bashrc.
+		inst.Profile = filepath.Join(env.home, ".bashrc")
+		if env.goos == "darwin" {
+			inst.Profile = filepath.Join(env.home, ".bash_profile")
+		}
+	case Zsh:
+		inst.Script = filepath.Join(daprDir, "completion.zsh.inc")
+		zdotdir := env.getenv("ZDOTDIR")
+		if zdotdir == "" {
+			zdotdir = env.home
+		}
+		inst.Profile = filepath.Join(zdotdir, ".zshrc")
+	case Fish:
+		// fish loads completions from this directory when the command is first completed.
+		inst.Script = filepath.Join(configHome, "fish", "completions", "dapr.fish")
+	case PowerShell:
+		inst.Script = filepath.Join(daprDir, "completion.ps1")
+		inst.Profile = filepath.Join(configHome, "powershell", "Microsoft.PowerShell_profile.ps1")
+		if env.goos == "windows" {
+			dir := "WindowsPowerShell"
+			if env.hasPwsh() {
+				dir = "PowerShell"
+			}
+			inst.Profile = filepath.Join(env.home, "Documents", dir, "Microsoft.PowerShell_profile.ps1")
+		}
+	default:
+		return Installation{}, fmt.Errorf("unsupported shell %s. Supported shells are: %s", shell, strings.Join(Shells, ", "))
+	}
+	return inst, nil
+}
+
+// profileLines returns the lines that load the completion script in the profile of a shell.
+func profileLines(shell, script string) []string {
+	switch shell {
+	case Zsh:
+		// The script registers the completion with compdef, which is only defined once compinit has run.
+		return []string{
+			"(( $+functions[compdef] )) || { autoload -U compinit && compinit }",
+			fmt.Sprintf("source '%s'", script),
+		}
+	case PowerShell:
+		return []string{fmt.Sprintf(". '%s'", script)}
+	default:
+		return []string{fmt.Sprintf("source '%s'", script)}
+	}
+}
+
+// addToProfile appends lines to profile, creating it if needed, unless the last of them is already in it.
+// It returns true if the profile was changed.
+func addToProfile(profile string, lines []string) (bool, error) {
+	content, err := os.ReadFile(profile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	if bytes.Contains(content, []byte(lines[len(lines)-1])) {
+		return false, nil
+	}
+	if err = os.MkdirAll(filepath.Dir(profile), 0o755); err != nil {
+		return false, err
+	}
+	f, err := os.OpenFile(profile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	block := "\n" + profileComment + "\n" + strings.Join(lines, "\n") + "\n"
+	if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+		block = "\n" + block
+	}
+	_, err = f.WriteString(block)
+	return err == nil, err
+}
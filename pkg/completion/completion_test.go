@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package completion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envFunc(vars map[string]string) func(string) string {
+	return func(key string) string {
+		return vars[key]
+	}
+}
+
+func TestDetectShell(t *testing.T) {
+	tests := []struct {
+		name     string
+		goos     string
+		env      map[string]string
+		expected string
+		err      bool
+	}{
+		{name: "bash", goos: "linux", env: map[string]string{"SHELL": "/bin/bash"}, expected: Bash},
+		{name: "zsh on macOS", goos: "darwin", env: map[string]string{"SHELL": "/bin/zsh"}, expected: Zsh},
+		{name: "fish", goos: "linux", env: map[string]string{"SHELL": "/usr/local/bin/fish"}, expected: Fish},
+		{name: "pwsh", goos: "linux", env: map[string]string{"SHELL": "/usr/bin/pwsh"}, expected: PowerShell},
+		{name: "windows", goos: "windows", env: map[string]string{}, expected: PowerShell},
+		{name: "unsupported shell", goos: "linux", env: map[string]string{"SHELL": "/bin/tcsh"}, err: true},
+		{name: "unknown shell", goos: "linux", env: map[string]string{}, err: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			shell, err := detectShell(tc.goos, envFunc(tc.env))
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, shell)
+		})
+	}
+}
+
+func TestInstallPaths(t *testing.T) {
+	env := environment{goos: "linux", home: "/home/user", getenv: envFunc(map[string]string{}), hasPwsh: func() bool { return true }}
+
+	inst, err := installPaths(env, Bash)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/home/user", ".dapr", "completion.bash.inc"), inst.Script)
+	assert.Equal(t, filepath.Join("/home/user", ".bashrc"), inst.Profile)
+
+	env.goos = "darwin"
+	inst, err = installPaths(env, Bash)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/home/user", ".bash_profile"), inst.Profile)
+
+	inst, err = installPaths(env, Fish)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/home/user", ".config", "fish", "completions", "dapr.fish"), inst.Script)
+	assert.Empty(t, inst.Profile)
+
+	env.goos = "windows"
+	inst, err = installPaths(env, PowerShell)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/home/user", "Documents", "PowerShell", "Microsoft.PowerShell_profile.ps1"), inst.Profile)
+
+	_, err = installPaths(env, "tcsh")
+	assert.Error(t, err)
+}
+
+func TestInstall(t *testing.T) {
+	home := t.TempDir()
+	env := environment{goos: "linux", home: home, getenv: envFunc(map[string]string{}), hasPwsh: func() bool { return false }}
+	profile := filepath.Join(home, ".zshrc")
+	require.NoError(t, os.WriteFile(profile, []byte("export EDITOR=vim"), 0o600))
+
+	inst, err := install(env, Zsh, []byte("#compdef dapr\n"))
+	require.NoError(t, err)
+	assert.True(t, inst.ProfileUpdated)
+	script, err := os.ReadFile(inst.Script)
+	require.NoError(t, err)
+	assert.Equal(t, "#compdef dapr\n", string(script))
+
+	content, err := os.ReadFile(profile)
+	require.NoError(t, err)
+	assert.Equal(t, "export EDITOR=vim\n\n# dapr shell completion\n"+
+		"(( $+functions[compdef] )) || { autoload -U compinit && compinit }\n"+
+		"source '"+inst.Script+"'\n", string(content))
+
+	t.Run("installing again keeps the profile", func(t *testing.T) {
+		inst, err := install(env, Zsh, []byte("#compdef dapr\n"))
+		require.NoError(t, err)
+		assert.False(t, inst.ProfileUpdated)
+		again, err := os.ReadFile(profile)
+		require.NoError(t, err)
+		assert.Equal(t, content, again)
+	})
+}
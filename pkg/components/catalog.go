@@ -0,0 +1,218 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ComponentType describes a Dapr component type and its metadata fields.
+type ComponentType struct {
+	Type        string
+	Version     string
+	Description string
+	Metadata    []MetadataField
+}
+
+// MetadataField describes a metadata field of a component type.
+type MetadataField struct {
+	Name        string
+	Description string
+	Example     string
+	Required    bool
+	// Sensitive fields, such as passwords, should be read from a secret store.
+	Sensitive bool
+	// ReplacedBy is the name of the field replacing this deprecated one.
+	ReplacedBy string
+	// DeprecationNote tells why this deprecated field should not be used, for fields without a replacement.
+	DeprecationNote string
+}
+
+// IsDeprecated returns true if the field should no longer be used.
+func (f MetadataField) IsDeprecated() bool {
+	return f.ReplacedBy != "" || f.DeprecationNote != ""
+}
+
+// DeprecationAdvice returns what to do instead of using the deprecated field.
+func (f MetadataField) DeprecationAdvice() string {
+	if f.ReplacedBy != "" {
+		return fmt.Sprintf("use %s instead", f.ReplacedBy)
+	}
+	return f.DeprecationNote
+}
+
+var catalog = []ComponentType{
+	{
+		Type:        "state.redis",
+		Version:     "v1",
+		Description: "Redis state store",
+		Metadata: []MetadataField{
+			{Name: "redisHost", Description: "Address of the Redis host", Example: "localhost:6379", Required: true},
+			{Name: "redisPassword", Description: "Password of the Redis host", Sensitive: true},
+			{Name: "redisUsername", Description: "Username of the Redis host, for Redis 6 and later"},
+			{Name: "enableTLS", Description: "Connect to Redis over TLS", Example: "false"},
+			{Name: "actorStateStore", Description: "Use this state store for actors", Example: "false"},
+			{Name: "keyPrefix", Description: "Prefix of the keys, one of appid, name or none", Example: "appid"},
+		},
+	},
+	{
+		Type:        "state.postgresql",
+		Version:     "v1",
+		Description: "PostgreSQL state store",
+		Metadata: []MetadataField{
+			{Name: "connectionString", Description: "Connection string of the PostgreSQL database", Example: "host=localhost user=postgres password=example port=5432 connect_timeout=10 database=dapr_test", Required: true, Sensitive: true},
+			{Name: "tableName", Description: "Name of the table to store state in", Example: "state"},
+			{Name: "actorStateStore", Description: "Use this state store for actors", Example: "false"},
+		},
+	},
+	{
+		Type:        "state.mongodb",
+		Version:     "v1",
+		Description: "MongoDB state store",
+		Metadata: []MetadataField{
+			{Name: "host", Description: "Address of the MongoDB host", Example: "localhost:27017", Required: true},
+			{Name: "username", Description: "Username of the MongoDB user"},
+			{Name: "password", Description: "Password of the MongoDB user", Sensitive: true},
+			{Name: "databaseName", Description: "Name of the database", Example: "daprStore"},
+			{Name: "collectionName", Description: "Name of the collection", Example: "daprCollection"},
+		},
+	},
+	{
+		Type:        "state.in-memory",
+		Version:     "v1",
+		Description: "In-memory state store for development and testing",
+	},
+	{
+		Type:        "pubsub.redis",
+		Version:     "v1",
+		Description: "Redis Streams pub/sub",
+		Metadata: []MetadataField{
+			{Name: "redisHost", Description: "Address of the Redis host", Example: "localhost:6379", Required: true},
+			{Name: "redisPassword", Description: "Password of the Redis host", Sensitive: true},
+			{Name: "consumerID", Description: "Consumer group ID, defaults to the app ID"},
+			{Name: "enableTLS", Description: "Connect to Redis over TLS", Example: "false"},
+		},
+	},
+	{
+		Type:        "pubsub.kafka",
+		Version:     "v1",
+		Description: "Apache Kafka pub/sub",
+		Metadata: []MetadataField{
+			{Name: "brokers", Description: "Comma separated list of Kafka brokers", Example: "localhost:9092", Required: true},
+			{Name: "authType", Description: "Authentication type, one of none, password, mtls or oidc", Example: "none", Required: true},
+			{Name: "consumerGroup", Description: "Kafka consumer group to listen on", Example: "group1"},
+			{Name: "clientID", Description: "Client ID used by Kafka to identify the app", Example: "my-dapr-app"},
+			{Name: "saslUsername", Description: "SASL username, if authType is password"},
+			{Name: "saslPassword", Description: "SASL password, if authType is password", Sensitive: true},
+			{Name: "authRequired", Description: "Enable SASL authentication", ReplacedBy: "authType"},
+		},
+	},
+	{
+		Type:        "pubsub.rabbitmq",
+		Version:     "v1",
+		Description: "RabbitMQ pub/sub",
+		Metadata: []MetadataField{
+			{Name: "host", Description: "Connection string of the RabbitMQ host", Example: "amqp://localhost:5672", Required: true, Sensitive: true},
+			{Name: "durable", Description: "Use durable queues", Example: "false"},
+			{Name: "deletedWhenUnused", Description: "Delete queues when they are no longer used", Example: "false"},
+		},
+	},
+	{
+		Type:        "pubsub.in-memory",
+		Version:     "v1",
+		Description: "In-memory pub/sub for development and testing",
+	},
+	{
+		Type:        "bindings.cron",
+		Version:     "v1",
+		Description: "Cron binding that triggers the app on a schedule",
+		Metadata: []MetadataField{
+			{Name: "schedule", Description: "Cron expression or interval, such as @every 15m", Example: "@every 15m", Required: true},
+		},
+	},
+	{
+		Type:        "bindings.http",
+		Version:     "v1",
+		Description: "HTTP output binding",
+		Metadata: []MetadataField{
+			{Name: "url", Description: "Base URL of the HTTP endpoint", Example: "http://localhost:8080", Required: true},
+		},
+	},
+	{
+		Type:        "bindings.kafka",
+		Version:     "v1",
+		Description: "Apache Kafka binding",
+		Metadata: []MetadataField{
+			{Name: "brokers", Description: "Comma separated list of Kafka brokers", Example: "localhost:9092", Required: true},
+			{Name: "authType", Description: "Authentication type, one of none, password, mtls or oidc", Example: "none", Required: true},
+			{Name: "topics", Description: "Comma separated list of topics to read from, for input bindings", Example: "topic1"},
+			{Name: "publishTopic", Description: "Topic to publish to, for output bindings", Example: "topic2"},
+			{Name: "consumerGroup", Description: "Kafka consumer group to listen on", Example: "group1"},
+			{Name: "saslPassword", Description: "SASL password, if authType is password", Sensitive: true},
+			{Name: "authRequired", Description: "Enable SASL authentication", ReplacedBy: "authType"},
+		},
+	},
+	{
+		Type:        "secretstores.local.file",
+		Version:     "v1",
+		Description: "Local file secret store for development",
+		Metadata: []MetadataField{
+			{Name: "secretsFile", Description: "Path to the JSON file containing the secrets", Example: "secrets.json", Required: true},
+			{Name: "nestedSeparator", Description: "Separator of nested keys", Example: ":"},
+		},
+	},
+	{
+		Type:        "secretstores.local.env",
+		Version:     "v1",
+		Description: "Secret store reading environment variables",
+	},
+	{
+		Type:        "configuration.redis",
+		Version:     "v1",
+		Description: "Redis configuration store",
+		Metadata: []MetadataField{
+			{Name: "redisHost", Description: "Address of the Redis host", Example: "localhost:6379", Required: true},
+			{Name: "redisPassword", Description: "Password of the Redis host", Sensitive: true},
+		},
+	},
+	{
+		Type:        "lock.redis",
+		Version:     "v1",
+		Description: "Redis distributed lock",
+		Metadata: []MetadataField{
+			{Name: "redisHost", Description: "Address of the Redis host", Example: "localhost:6379", Required: true},
+			{Name: "redisPassword", Description: "Password of the Redis host", Sensitive: true},
+		},
+	},
+}
+
+// Lookup returns the known component type with the given name, such as state.redis.
+func Lookup(componentType string) (ComponentType, bool) {
+	for _, c := range catalog {
+		if c.Type == componentType {
+			return c, true
+		}
+	}
+	return ComponentType{}, false
+}
+
+// Types returns all known component types, sorted by name.
+func Types() []ComponentType {
+	types := append([]ComponentType{}, catalog...)
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Type < types[j].Type
+	})
+	return types
+}
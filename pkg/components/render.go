@@ -0,0 +1,262 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// EnvSecretStore resolves secrets from environment variables, like the secretstores.local.env component.
+	EnvSecretStore = "env"
+	// FileSecretStore resolves secrets from a JSON file, like the secretstores.local.file component.
+	FileSecretStore = "file"
+	// KeyringSecretStore resolves secrets from the keyring of the operating system.
+	KeyringSecretStore = "keyring"
+
+	// DefaultNestedSeparator is the separator of nested keys in a secrets file, as used by secretstores.local.file.
+	DefaultNestedSeparator = ":"
+)
+
+// SecretStore resolves the secrets referenced by component metadata.
+type SecretStore interface {
+	// GetSecret returns the value of key in the secret name.
+	GetSecret(name, key string) (string, error)
+}
+
+// NewSecretStore returns the local secret store of kind. secretsFile is the JSON file used by the file secret store.
+func NewSecretStore(kind, secretsFile, nestedSeparator string) (SecretStore, error) {
+	switch kind {
+	case EnvSecretStore:
+		return envSecretStore{}, nil
+	case FileSecretStore:
+		if secretsFile == "" {
+			return nil, errors.New("a secrets file is required for the file secret store")
+		}
+		return newFileSecretStore(secretsFile, nestedSeparator)
+	case KeyringSecretStore:
+		return keyringSecretStore{}, nil
+	default:
+		return nil, fmt.Errorf("invalid secret store %q. Valid values are: %s, %s, %s", kind, EnvSecretStore, FileSecretStore, KeyringSecretStore)
+	}
+}
+
+// envSecretStore returns the environment variable named after the secret. The key must be empty or the secret name.
+type envSecretStore struct{}
+
+func (envSecretStore) GetSecret(name, key string) (string, error) {
+	if key != "" && key != name {
+		return "", fmt.Errorf("secret %s has no key %s in the env secret store", name, key)
+	}
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return value, nil
+}
+
+// fileSecretStore holds the flattened secrets of a JSON file. Nested keys are joined with the nested separator.
+type fileSecretStore struct {
+	file      string
+	separator string
+	secrets   map[string]string
+}
+
+func newFileSecretStore(file, separator string) (*fileSecretStore, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading secrets file: %w", err)
+	}
+	var raw map[string]interface{}
+	if err = json.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("error parsing secrets file %s: %w", file, err)
+	}
+	if separator == "" {
+		separator = DefaultNestedSeparator
+	}
+	s := &fileSecretStore{file: file, separator: separator, secrets: map[string]string{}}
+	s.flatten("", raw)
+	return s, nil
+}
+
+func (s *fileSecretStore) flatten(prefix string, values map[string]interface{}) {
+	for k, v := range values {
+		if prefix != "" {
+			k = prefix + s.separator + k
+		}
+		switch value := v.(type) {
+		case map[string]interface{}:
+			s.flatten(k, value)
+		case string:
+			s.secrets[k] = value
+		default:
+			s.secrets[k] = fmt.Sprintf("%v", value)
+		}
+	}
+}
+
+// GetSecret returns the secret name, or the key of the secret name for a multi valued secrets file.
+func (s *fileSecretStore) GetSecret(name, key string) (string, error) {
+	if key == "" || key == name {
+		if value, ok := s.secrets[name]; ok {
+			return value, nil
+		}
+	}
+	if key != "" {
+		if value, ok := s.secrets[name+s.separator+key]; ok {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("secret %s not found in %s", name, s.file)
+}
+
+// keyringSecretStore looks secrets up in the keyring of the operating system, with the secret name as the service
+// and the key, or the secret name if there is no key, as the account.
+type keyringSecretStore struct{}
+
+func (keyringSecretStore) GetSecret(name, key string) (string, error) {
+	if key == "" {
+		key = name
+	}
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", name, "-a", key, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", name, "account", key)
+	default:
+		return "", fmt.Errorf("the keyring secret store is not supported on %s", runtime.GOOS)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("secret %s with key %s not found in the keyring: %w", name, key, err)
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// Render reads the component manifests in the file or directory at path and replaces the secretKeyRef
+// of their metadata with the values resolved from store. Manifests of other kinds are left out.
+// The rendered manifests are returned as a multi document YAML file.
+func Render(path string, store SecretStore) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files, err = manifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		docs, err := renderFile(b, store)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		for _, doc := range docs {
+			if out.Len() > 0 {
+				out.WriteString("---\n")
+			}
+			out.Write(doc)
+		}
+	}
+	return out.Bytes(), nil
+}
+
+func renderFile(b []byte, store SecretStore) ([][]byte, error) {
+	docs := [][]byte{}
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	for {
+		var doc yaml.MapSlice
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid YAML: %w", err)
+		}
+		if documentKind(doc) != "Component" {
+			continue
+		}
+		if err = resolveSecrets(doc, store); err != nil {
+			return nil, err
+		}
+		out, err := yaml.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, out)
+	}
+}
+
+// resolveSecrets replaces the secretKeyRef of every metadata item in the spec of the component doc with its value.
+func resolveSecrets(doc yaml.MapSlice, store SecretStore) error {
+	var name string
+	if metadata, ok := mapValue(doc, "metadata").(yaml.MapSlice); ok {
+		name, _ = mapValue(metadata, "name").(string)
+	}
+	spec, ok := mapValue(doc, "spec").(yaml.MapSlice)
+	if !ok {
+		return nil
+	}
+	items, _ := mapValue(spec, "metadata").([]interface{})
+	for _, item := range items {
+		fields, ok := item.(yaml.MapSlice)
+		if !ok {
+			continue
+		}
+		for i, field := range fields {
+			if field.Key != "secretKeyRef" {
+				continue
+			}
+			ref, _ := field.Value.(yaml.MapSlice)
+			secretName, _ := mapValue(ref, "name").(string)
+			secretKey, _ := mapValue(ref, "key").(string)
+			value, err := store.GetSecret(secretName, secretKey)
+			if err != nil {
+				fieldName, _ := mapValue(fields, "name").(string)
+				return fmt.Errorf("component %s: metadata field %s: %w", name, fieldName, err)
+			}
+			fields[i] = yaml.MapItem{Key: "value", Value: value}
+		}
+	}
+	return nil
+}
+
+func mapValue(m yaml.MapSlice, key string) interface{} {
+	for _, item := range m {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return nil
+}
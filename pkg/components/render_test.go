@@ -0,0 +1,137 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const renderComponent = `apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+  name: statestore
+spec:
+  type: state.redis
+  version: v1
+  metadata:
+  - name: redisHost
+    value: localhost:6379
+  - name: redisPassword
+    secretKeyRef:
+      name: redisPassword
+auth:
+  secretStore: localsecretstore
+---
+apiVersion: dapr.io/v1alpha1
+kind: Configuration
+metadata:
+  name: appconfig
+`
+
+func TestRender(t *testing.T) {
+	t.Run("env secret store", func(t *testing.T) {
+		t.Setenv("redisPassword", "s3cret")
+		dir := t.TempDir()
+		writeComponentFile(t, dir, "statestore.yaml", renderComponent)
+
+		store, err := NewSecretStore(EnvSecretStore, "", "")
+		assert.NoError(t, err)
+		b, err := Render(dir, store)
+		assert.NoError(t, err)
+		assert.Equal(t, `apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+  name: statestore
+spec:
+  type: state.redis
+  version: v1
+  metadata:
+  - name: redisHost
+    value: localhost:6379
+  - name: redisPassword
+    value: s3cret
+auth:
+  secretStore: localsecretstore
+`, string(b))
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		dir := t.TempDir()
+		file := writeComponentFile(t, dir, "statestore.yaml", renderComponent)
+
+		store, err := NewSecretStore(EnvSecretStore, "", "")
+		assert.NoError(t, err)
+		_, err = Render(file, store)
+		assert.EqualError(t, err, file+": component statestore: metadata field redisPassword: environment variable redisPassword is not set")
+	})
+
+	t.Run("multiple files", func(t *testing.T) {
+		dir := t.TempDir()
+		writeComponentFile(t, dir, "a.yaml", "apiVersion: dapr.io/v1alpha1\nkind: Component\nmetadata:\n  name: a\n")
+		writeComponentFile(t, dir, "b.yaml", "apiVersion: dapr.io/v1alpha1\nkind: Component\nmetadata:\n  name: b\n")
+
+		b, err := Render(dir, envSecretStore{})
+		assert.NoError(t, err)
+		assert.Equal(t, "apiVersion: dapr.io/v1alpha1\nkind: Component\nmetadata:\n  name: a\n---\napiVersion: dapr.io/v1alpha1\nkind: Component\nmetadata:\n  name: b\n", string(b))
+	})
+}
+
+func TestFileSecretStore(t *testing.T) {
+	dir := t.TempDir()
+	file := writeComponentFile(t, dir, "secrets.json", `{"redisPassword": "s3cret", "db": {"user": "admin", "port": 5432}}`)
+
+	store, err := NewSecretStore(FileSecretStore, file, "")
+	assert.NoError(t, err)
+
+	value, err := store.GetSecret("redisPassword", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+
+	value, err = store.GetSecret("db:user", "db:user")
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", value)
+
+	value, err = store.GetSecret("db", "port")
+	assert.NoError(t, err)
+	assert.Equal(t, "5432", value)
+
+	_, err = store.GetSecret("db", "password")
+	assert.EqualError(t, err, "secret db not found in "+file)
+
+	_, err = NewSecretStore(FileSecretStore, "", "")
+	assert.EqualError(t, err, "a secrets file is required for the file secret store")
+
+	_, err = NewSecretStore(FileSecretStore, filepath.Join(dir, "missing.json"), "")
+	assert.Error(t, err)
+}
+
+func TestEnvSecretStore(t *testing.T) {
+	t.Setenv("MY_SECRET", "value")
+	store := envSecretStore{}
+
+	value, err := store.GetSecret("MY_SECRET", "MY_SECRET")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	_, err = store.GetSecret("MY_SECRET", "other")
+	assert.EqualError(t, err, "secret MY_SECRET has no key other in the env secret store")
+}
+
+func TestNewSecretStore(t *testing.T) {
+	_, err := NewSecretStore("vault", "", "")
+	assert.EqualError(t, err, `invalid secret store "vault". Valid values are: env, file, keyring`)
+}
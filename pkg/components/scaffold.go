@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Scaffold returns a component manifest of the given type and name, ready to be edited.
+// Every metadata field of the type is stubbed with an example value and documented in a comment.
+func Scaffold(componentType, name string) ([]byte, error) {
+	c, ok := Lookup(componentType)
+	if !ok {
+		names := []string{}
+		for _, t := range Types() {
+			names = append(names, t.Type)
+		}
+		return nil, fmt.Errorf("unknown component type %q. Supported types are: %s", componentType, strings.Join(names, ", "))
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid component name %q: %s", name, strings.Join(errs, ", "))
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# %s.\n", c.Description)
+	fmt.Fprintf(&b, "# See https://docs.dapr.io/reference/components-reference/ for all metadata fields.\n")
+	fmt.Fprintf(&b, "apiVersion: dapr.io/v1alpha1\n")
+	fmt.Fprintf(&b, "kind: Component\n")
+	fmt.Fprintf(&b, "metadata:\n")
+	fmt.Fprintf(&b, "  name: %s\n", name)
+	fmt.Fprintf(&b, "spec:\n")
+	fmt.Fprintf(&b, "  type: %s\n", c.Type)
+	fmt.Fprintf(&b, "  version: %s\n", c.Version)
+
+	fields := []MetadataField{}
+	for _, field := range c.Metadata {
+		if !field.IsDeprecated() {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		fmt.Fprintf(&b, "  metadata: []\n")
+		return b.Bytes(), nil
+	}
+
+	fmt.Fprintf(&b, "  metadata:\n")
+	for _, field := range fields {
+		requirement := "Optional"
+		if field.Required {
+			requirement = "Required"
+		}
+		fmt.Fprintf(&b, "  # %s. %s.\n", field.Description, requirement)
+		if field.Sensitive {
+			fmt.Fprintf(&b, "  # Prefer a secretKeyRef to a secret store over a plain value.\n")
+		}
+		fmt.Fprintf(&b, "  - name: %s\n", field.Name)
+		fmt.Fprintf(&b, "    value: %q\n", field.Example)
+	}
+	return b.Bytes(), nil
+}
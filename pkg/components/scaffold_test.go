@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestScaffold(t *testing.T) {
+	t.Run("every known type produces a valid manifest", func(t *testing.T) {
+		for _, c := range Types() {
+			b, err := Scaffold(c.Type, "my-component")
+			assert.NoError(t, err, c.Type)
+
+			var manifest struct {
+				APIVersion string `yaml:"apiVersion"`
+				Kind       string `yaml:"kind"`
+				Metadata   struct {
+					Name string `yaml:"name"`
+				} `yaml:"metadata"`
+				Spec struct {
+					Type     string `yaml:"type"`
+					Version  string `yaml:"version"`
+					Metadata []struct {
+						Name  string `yaml:"name"`
+						Value string `yaml:"value"`
+					} `yaml:"metadata"`
+				} `yaml:"spec"`
+			}
+			assert.NoError(t, yaml.UnmarshalStrict(b, &manifest), c.Type)
+			assert.Equal(t, "dapr.io/v1alpha1", manifest.APIVersion)
+			assert.Equal(t, "Component", manifest.Kind)
+			assert.Equal(t, "my-component", manifest.Metadata.Name)
+			assert.Equal(t, c.Type, manifest.Spec.Type)
+			assert.Equal(t, c.Version, manifest.Spec.Version)
+		}
+	})
+
+	t.Run("deprecated fields are omitted", func(t *testing.T) {
+		b, err := Scaffold("pubsub.kafka", "pubsub")
+		assert.NoError(t, err)
+		assert.Contains(t, string(b), "- name: authType\n")
+		assert.NotContains(t, string(b), "authRequired")
+		assert.Contains(t, string(b), "# Comma separated list of Kafka brokers. Required.\n  - name: brokers\n    value: \"localhost:9092\"\n")
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := Scaffold("state.unknown", "statestore")
+		assert.ErrorContains(t, err, `unknown component type "state.unknown"`)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		_, err := Scaffold("state.redis", "State_Store")
+		assert.ErrorContains(t, err, `invalid component name "State_Store"`)
+	})
+}
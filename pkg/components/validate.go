@@ -0,0 +1,269 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	path_filepath "path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const componentAPIVersion = "dapr.io/v1alpha1"
+
+// Severity is the severity of a validation issue.
+type Severity string
+
+const (
+	// SeverityError marks an issue that prevents Dapr from loading the component.
+	SeverityError Severity = "error"
+	// SeverityWarning marks a likely mistake or a bad practice.
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a problem found in a component manifest.
+type Issue struct {
+	File      string   `json:"file"`
+	Component string   `json:"component,omitempty"`
+	Severity  Severity `json:"severity"`
+	Message   string   `json:"message"`
+}
+
+// sensitiveNameHints are parts of metadata field names that usually hold credentials.
+var sensitiveNameHints = []string{"password", "secret", "token", "apikey", "accesskey", "connectionstring", "credential"}
+
+type componentManifest struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name        string            `yaml:"name"`
+		Namespace   string            `yaml:"namespace"`
+		Labels      map[string]string `yaml:"labels"`
+		Annotations map[string]string `yaml:"annotations"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Type         string             `yaml:"type"`
+		Version      string             `yaml:"version"`
+		IgnoreErrors bool               `yaml:"ignoreErrors"`
+		InitTimeout  string             `yaml:"initTimeout"`
+		Metadata     []manifestMetadata `yaml:"metadata"`
+	} `yaml:"spec"`
+	Auth struct {
+		SecretStore string `yaml:"secretStore"`
+	} `yaml:"auth"`
+	Scopes []string `yaml:"scopes"`
+}
+
+type manifestMetadata struct {
+	Name         string      `yaml:"name"`
+	Value        interface{} `yaml:"value"`
+	SecretKeyRef *struct {
+		Name string `yaml:"name"`
+		Key  string `yaml:"key"`
+	} `yaml:"secretKeyRef"`
+}
+
+// Validate checks the component manifests in the file or directory at path.
+// Manifests of other kinds, such as configurations and subscriptions, are skipped.
+func Validate(path string) ([]Issue, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files, err = manifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	issues := []Issue{}
+	names := map[string]string{}
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, validateFile(file, b, names)...)
+	}
+	return issues, nil
+}
+
+func manifestFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	for _, entry := range entries {
+		ext := path_filepath.Ext(entry.Name())
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			files = append(files, path_filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// validateFile validates every component in the YAML documents of b.
+// names maps the names of the components seen so far to their files, to detect duplicates.
+func validateFile(file string, b []byte, names map[string]string) []Issue {
+	issues := []Issue{}
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	for {
+		var doc yaml.MapSlice
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return issues
+		}
+		if err != nil {
+			return append(issues, Issue{File: file, Severity: SeverityError, Message: fmt.Sprintf("invalid YAML: %s", err)})
+		}
+		if len(doc) == 0 || documentKind(doc) != "Component" {
+			continue
+		}
+
+		docBytes, err := yaml.Marshal(doc)
+		if err != nil {
+			return append(issues, Issue{File: file, Severity: SeverityError, Message: err.Error()})
+		}
+		var component componentManifest
+		if err = yaml.UnmarshalStrict(docBytes, &component); err != nil {
+			issues = append(issues, Issue{File: file, Severity: SeverityError, Message: fmt.Sprintf("invalid component: %s", err)})
+			// Validate the fields that could be read.
+			_ = yaml.Unmarshal(docBytes, &component)
+		}
+
+		for _, issue := range validateComponent(&component) {
+			issue.File = file
+			issue.Component = component.Metadata.Name
+			issues = append(issues, issue)
+		}
+
+		if name := component.Metadata.Name; name != "" {
+			if other, ok := names[name]; ok {
+				issues = append(issues, Issue{File: file, Component: name, Severity: SeverityError, Message: fmt.Sprintf("component name is also used in %s", other)})
+			} else {
+				names[name] = file
+			}
+		}
+	}
+}
+
+func documentKind(doc yaml.MapSlice) string {
+	for _, item := range doc {
+		if key, ok := item.Key.(string); ok && key == "kind" {
+			kind, _ := item.Value.(string)
+			return kind
+		}
+	}
+	return ""
+}
+
+func validateComponent(component *componentManifest) []Issue {
+	issues := []Issue{}
+	addIssue := func(severity Severity, format string, a ...interface{}) {
+		issues = append(issues, Issue{Severity: severity, Message: fmt.Sprintf(format, a...)})
+	}
+
+	if component.APIVersion != componentAPIVersion {
+		addIssue(SeverityError, "apiVersion must be %s, found %q", componentAPIVersion, component.APIVersion)
+	}
+	if component.Metadata.Name == "" {
+		addIssue(SeverityError, "metadata.name is required")
+	} else if errs := validation.IsDNS1123Subdomain(component.Metadata.Name); len(errs) > 0 {
+		addIssue(SeverityError, "metadata.name is invalid: %s", strings.Join(errs, ", "))
+	}
+	if component.Spec.Version == "" {
+		addIssue(SeverityError, "spec.version is required")
+	}
+	if component.Spec.Type == "" {
+		addIssue(SeverityError, "spec.type is required")
+		return issues
+	}
+
+	known, isKnown := Lookup(component.Spec.Type)
+	if !isKnown {
+		addIssue(SeverityWarning, "unknown component type %s, its metadata fields are not checked", component.Spec.Type)
+	} else if component.Spec.Version != "" && component.Spec.Version != known.Version {
+		addIssue(SeverityWarning, "unknown version %s of component type %s, expected %s", component.Spec.Version, component.Spec.Type, known.Version)
+	}
+
+	fields := map[string]MetadataField{}
+	for _, field := range known.Metadata {
+		fields[field.Name] = field
+	}
+	seen := map[string]bool{}
+	for _, item := range component.Spec.Metadata {
+		if item.Name == "" {
+			addIssue(SeverityError, "metadata item without a name")
+			continue
+		}
+		if seen[item.Name] {
+			addIssue(SeverityError, "metadata field %s is set more than once", item.Name)
+		}
+		seen[item.Name] = true
+
+		hasValue := item.Value != nil && fmt.Sprint(item.Value) != ""
+		if hasValue && item.SecretKeyRef != nil {
+			addIssue(SeverityError, "metadata field %s has both a value and a secretKeyRef", item.Name)
+		}
+		if item.SecretKeyRef != nil && item.SecretKeyRef.Name == "" {
+			addIssue(SeverityError, "secretKeyRef of metadata field %s has no name", item.Name)
+		}
+
+		field, isKnownField := fields[item.Name]
+		if isKnown && !isKnownField {
+			addIssue(SeverityWarning, "unknown metadata field %s for component type %s", item.Name, component.Spec.Type)
+		}
+		if field.IsDeprecated() {
+			addIssue(SeverityWarning, "metadata field %s is deprecated, %s", item.Name, field.DeprecationAdvice())
+		}
+		if hasValue && (field.Sensitive || looksSensitive(item.Name)) {
+			addIssue(SeverityWarning, "metadata field %s contains a plaintext secret, use a secretKeyRef to a secret store instead", item.Name)
+		}
+	}
+
+	// A deprecated field still sets the field that replaces it.
+	for _, field := range known.Metadata {
+		if field.ReplacedBy != "" && seen[field.Name] {
+			seen[field.ReplacedBy] = true
+		}
+	}
+	for _, field := range known.Metadata {
+		if field.Required && !seen[field.Name] {
+			addIssue(SeverityError, "required metadata field %s is missing", field.Name)
+		}
+	}
+	return issues
+}
+
+func looksSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, hint := range sensitiveNameHints {
+		if strings.Contains(name, hint) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"os"
+	path_filepath "path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeComponentFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	filePath := path_filepath.Join(dir, name)
+	assert.NoError(t, os.WriteFile(filePath, []byte(content), 0o600))
+	return filePath
+}
+
+func issueMessages(issues []Issue, severity Severity) []string {
+	messages := []string{}
+	for _, issue := range issues {
+		if issue.Severity == severity {
+			messages = append(messages, issue.Message)
+		}
+	}
+	return messages
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("scaffolded components have no errors", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, c := range Types() {
+			b, err := Scaffold(c.Type, "my-component")
+			assert.NoError(t, err)
+			filePath := writeComponentFile(t, dir, "component.yaml", string(b))
+
+			issues, err := Validate(filePath)
+			assert.NoError(t, err)
+			assert.Empty(t, issueMessages(issues, SeverityError), c.Type)
+		}
+	})
+
+	t.Run("invalid component", func(t *testing.T) {
+		filePath := writeComponentFile(t, t.TempDir(), "statestore.yaml", `apiVersion: dapr.io/v1
+kind: Component
+metadata:
+  name: StateStore
+spec:
+  type: state.redis
+  version: v1
+  metadata:
+  - name: redisPassword
+    value: hunter2
+  - name: redisPassword
+    secretKeyRef:
+      name: redis
+      key: password
+  - name: redisHostname
+    value: localhost
+`)
+		issues, err := Validate(filePath)
+		assert.NoError(t, err)
+		errs := issueMessages(issues, SeverityError)
+		assert.Contains(t, errs, `apiVersion must be dapr.io/v1alpha1, found "dapr.io/v1"`)
+		assert.Contains(t, errs, "required metadata field redisHost is missing")
+		assert.Contains(t, errs, "metadata field redisPassword is set more than once")
+		assert.Len(t, errs, 4)
+
+		warnings := issueMessages(issues, SeverityWarning)
+		assert.ElementsMatch(t, []string{
+			"metadata field redisPassword contains a plaintext secret, use a secretKeyRef to a secret store instead",
+			"unknown metadata field redisHostname for component type state.redis",
+		}, warnings)
+		for _, issue := range issues {
+			assert.Equal(t, filePath, issue.File)
+			assert.Equal(t, "StateStore", issue.Component)
+		}
+	})
+
+	t.Run("deprecated fields and unknown types", func(t *testing.T) {
+		filePath := writeComponentFile(t, t.TempDir(), "components.yaml", `apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+  name: pubsub
+spec:
+  type: pubsub.kafka
+  version: v1
+  metadata:
+  - name: brokers
+    value: localhost:9092
+  - name: authRequired
+    value: "false"
+---
+apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+  name: custom
+spec:
+  type: state.custom
+  version: v1
+  metadata:
+  - name: apiToken
+    value: abc
+`)
+		issues, err := Validate(filePath)
+		assert.NoError(t, err)
+		assert.Empty(t, issueMessages(issues, SeverityError))
+		assert.ElementsMatch(t, []string{
+			"metadata field authRequired is deprecated, use authType instead",
+			"unknown component type state.custom, its metadata fields are not checked",
+			"metadata field apiToken contains a plaintext secret, use a secretKeyRef to a secret store instead",
+		}, issueMessages(issues, SeverityWarning))
+	})
+
+	t.Run("directory with duplicate names and other kinds", func(t *testing.T) {
+		dir := t.TempDir()
+		component := `apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+  name: env
+spec:
+  type: secretstores.local.env
+  version: v1
+`
+		writeComponentFile(t, dir, "a.yaml", component)
+		writeComponentFile(t, dir, "b.yml", component)
+		writeComponentFile(t, dir, "config.yaml", "apiVersion: dapr.io/v1alpha1\nkind: Configuration\nmetadata:\n  name: daprConfig\nspec: {}\n")
+		writeComponentFile(t, dir, "notes.txt", "not a component")
+
+		issues, err := Validate(dir)
+		assert.NoError(t, err)
+		assert.Equal(t, []Issue{{
+			File:      path_filepath.Join(dir, "b.yml"),
+			Component: "env",
+			Severity:  SeverityError,
+			Message:   "component name is also used in " + path_filepath.Join(dir, "a.yaml"),
+		}}, issues)
+	})
+
+	t.Run("unknown fields are errors", func(t *testing.T) {
+		filePath := writeComponentFile(t, t.TempDir(), "c.yaml", "apiVersion: dapr.io/v1alpha1\nkind: Component\nmetadata:\n  name: c\nspec:\n  type: state.in-memory\n  version: v1\n  metdata: []\n")
+		issues, err := Validate(filePath)
+		assert.NoError(t, err)
+		errs := issueMessages(issues, SeverityError)
+		assert.Len(t, errs, 1)
+		assert.Contains(t, errs[0], "field metdata not found")
+	})
+}
+
+func TestDeprecationAdvice(t *testing.T) {
+	replaced := MetadataField{Name: "authRequired", ReplacedBy: "authType"}
+	assert.True(t, replaced.IsDeprecated())
+	assert.Equal(t, "use authType instead", replaced.DeprecationAdvice())
+
+	noted := MetadataField{Name: "maxRetries", DeprecationNote: "retries are set by a resiliency policy"}
+	assert.True(t, noted.IsDeprecated())
+	assert.Equal(t, "retries are set by a resiliency policy", noted.DeprecationAdvice())
+
+	assert.False(t, MetadataField{Name: "brokers"}.IsDeprecated())
+}
@@ -0,0 +1,338 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package daemon implements the local HTTP API of `dapr daemon`, which lets IDE extensions and other tools list,
+// run and stop self-hosted apps and read their logs without parsing the output of the CLI.
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+const (
+	// DefaultStopTimeout is the time to wait for an app stopped through the API to exit before it is killed.
+	DefaultStopTimeout = 10 * time.Second
+
+	socketFileName = "daemon.sock"
+	appsPath       = "/v1/apps"
+)
+
+// DefaultSocketPath returns the path of the unix socket the API listens on by default, in the Dapr directory
+// of the user.
+func DefaultSocketPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot find the home directory: %w", err)
+	}
+	return filepath.Join(home, ".dapr", socketFileName), nil
+}
+
+// RunRequest is the body of a request to run an app, with the same options as `dapr run`.
+type RunRequest struct {
+	AppID   string `json:"appId"`
+	AppPort int    `json:"appPort,omitempty"`
+	// Flags are further flags of `dapr run`, such as --components-path ./components.
+	Flags []string `json:"flags,omitempty"`
+	// Command is the command of the app and its arguments. Only the sidecar runs if it is empty.
+	Command []string `json:"command,omitempty"`
+	// Dir is the working directory of the app. It defaults to the working directory of the daemon.
+	Dir string `json:"dir,omitempty"`
+}
+
+// RunResponse is the response to a request to run an app.
+type RunResponse struct {
+	AppID string `json:"appId"`
+	// PID is the process ID of the `dapr run` process that runs the app and its sidecar.
+	PID int `json:"pid"`
+}
+
+// ErrorResponse is the body of a response to a failed request.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Launcher starts `dapr run` with the given arguments in dir and returns its process ID.
+type Launcher func(args []string, dir string) (int, error)
+
+// Server serves the API of the daemon.
+type Server struct {
+	// List returns the running apps.
+	List func() ([]standalone.ListOutput, error)
+	// Launch starts an app.
+	Launch Launcher
+	// Stop stops the app with the given ID.
+	Stop func(appID string, timeout time.Duration) error
+	// Logs writes the logs of the app with the given ID in namespace to w.
+	Logs func(ctx context.Context, w io.Writer, namespace, appID string, tail int, follow bool) error
+}
+
+// NewServer returns a server that manages the self-hosted apps started with executable, the path of the CLI.
+func NewServer(executable string) *Server {
+	return &Server{
+		List:   standalone.List,
+		Launch: CLILauncher(executable),
+		Stop:   standalone.Stop,
+		Logs:   standalone.Logs,
+	}
+}
+
+// CLILauncher returns a Launcher that runs the CLI at executable. The output of `dapr run` is discarded,
+// since the logs of the app and its sidecar are stored in the log file of the app.
+func CLILauncher(executable string) Launcher {
+	return func(args []string, dir string) (int, error) {
+		cmd := exec.Command(executable, args...)
+		cmd.Dir = dir
+		err := cmd.Start()
+		if err != nil {
+			return 0, err
+		}
+		// Wait releases the resources of the process once it exits.
+		go cmd.Wait()
+		return cmd.Process.Pid, nil
+	}
+}
+
+// Listen listens on the unix socket at socketPath. A socket file left behind by a daemon that is no longer running
+// is replaced.
+func Listen(socketPath string) (net.Listener, error) {
+	if _, err := os.Stat(socketPath); err == nil {
+		conn, dialErr := net.DialTimeout("unix", socketPath, time.Second)
+		if dialErr == nil {
+			conn.Close()
+			return nil, fmt.Errorf("a daemon is already listening on %s", socketPath)
+		}
+		err = os.Remove(socketPath)
+		if err != nil {
+			return nil, fmt.Errorf("error removing stale socket %s: %w", socketPath, err)
+		}
+	}
+
+	err := os.MkdirAll(filepath.Dir(socketPath), 0o755)
+	if err != nil {
+		return nil, err
+	}
+	// Only the user running the daemon may start processes through it.
+	l, err := listenUnix(socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("error listening on %s: %w", socketPath, err)
+	}
+	return l, nil
+}
+
+// ServeHTTP routes the requests of the API:
+//
+//	GET    /v1/apps                 lists the running apps
+//	POST   /v1/apps                 runs an app
+//	DELETE /v1/apps/{appId}         stops an app, waiting for the duration given by the timeout query parameter
+//	GET    /v1/apps/{appId}/logs    writes the logs of an app, with the tail and follow query parameters
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if path == appsPath {
+		switch r.Method {
+		case http.MethodGet:
+			s.list(w)
+		case http.MethodPost:
+			s.run(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+		}
+		return
+	}
+
+	appID, rest, ok := strings.Cut(strings.TrimPrefix(path, appsPath+"/"), "/")
+	if !strings.HasPrefix(path, appsPath+"/") || appID == "" || (ok && rest != "logs") {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no such endpoint %s", r.URL.Path))
+		return
+	}
+	switch {
+	case !ok && r.Method == http.MethodDelete:
+		s.stop(w, r, appID)
+	case ok && r.Method == http.MethodGet:
+		s.logs(w, r, appID)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+	}
+}
+
+func (s *Server) list(w http.ResponseWriter) {
+	apps, err := s.List()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, apps)
+}
+
+func (s *Server) run(w http.ResponseWriter, r *http.Request) {
+	var req RunRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if req.AppID == "" {
+		writeError(w, http.StatusBadRequest, errors.New("appId is required"))
+		return
+	}
+	found, err := s.find(req.AppID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if found {
+		writeError(w, http.StatusConflict, fmt.Errorf("app %s is already running", req.AppID))
+		return
+	}
+
+	pid, err := s.Launch(runArgs(req), req.Dir)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("error running app %s: %w", req.AppID, err))
+		return
+	}
+	writeJSON(w, http.StatusAccepted, RunResponse{AppID: req.AppID, PID: pid})
+}
+
+func (s *Server) stop(w http.ResponseWriter, r *http.Request, appID string) {
+	timeout := DefaultStopTimeout
+	if t := r.URL.Query().Get("timeout"); t != "" {
+		var err error
+		timeout, err = time.ParseDuration(t)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid timeout %q: %w", t, err))
+			return
+		}
+	}
+
+	found, err := s.find(appID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, fmt.Errorf("app %s is not running", appID))
+		return
+	}
+	err = s.Stop(appID, timeout)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) logs(w http.ResponseWriter, r *http.Request, appID string) {
+	query := r.URL.Query()
+	tail := -1
+	if t := query.Get("tail"); t != "" {
+		var err error
+		tail, err = strconv.Atoi(t)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid tail %q: %w", t, err))
+			return
+		}
+	}
+	follow := false
+	if f := query.Get("follow"); f != "" {
+		var err error
+		follow, err = strconv.ParseBool(f)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid follow %q: %w", f, err))
+			return
+		}
+	}
+
+	namespace := query.Get("namespace")
+	if err := standalone.ValidateNamespace(namespace); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if _, err := os.Stat(standalone.AppLogFilePath(namespace, appID)); errors.Is(err, os.ErrNotExist) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no logs found for app %s", appID))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	fw := &flushWriter{w: w}
+	fw.flush()
+	// The status is already sent, so an error while writing the logs can only end the response.
+	s.Logs(r.Context(), fw, namespace, appID, tail, follow)
+}
+
+// find returns true if the app with the given ID is running.
+func (s *Server) find(appID string) (bool, error) {
+	apps, err := s.List()
+	if err != nil {
+		return false, err
+	}
+	for _, a := range apps {
+		if a.AppID == appID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// runArgs returns the arguments of `dapr run` for req.
+func runArgs(req RunRequest) []string {
+	args := []string{"run", "--app-id", req.AppID}
+	if req.AppPort > 0 {
+		args = append(args, "--app-port", strconv.Itoa(req.AppPort))
+	}
+	args = append(args, req.Flags...)
+	if len(req.Command) > 0 {
+		args = append(append(args, "--"), req.Command...)
+	}
+	return args
+}
+
+// flushWriter sends every write to the client immediately, so that followed logs are streamed.
+type flushWriter struct {
+	w http.ResponseWriter
+}
+
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	f.flush()
+	return n, err
+}
+
+func (f *flushWriter) flush() {
+	if flusher, ok := f.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/cli/pkg/standalone"
+)
+
+func testServer(apps []standalone.ListOutput) (*Server, *[]string) {
+	calls := []string{}
+	return &Server{
+		List: func() ([]standalone.ListOutput, error) {
+			return apps, nil
+		},
+		Launch: func(args []string, dir string) (int, error) {
+			calls = append(calls, fmt.Sprintf("launch %s in %s", strings.Join(args, " "), dir))
+			return 4242, nil
+		},
+		Stop: func(appID string, timeout time.Duration) error {
+			calls = append(calls, fmt.Sprintf("stop %s after %s", appID, timeout))
+			return nil
+		},
+		Logs: func(ctx context.Context, w io.Writer, namespace, appID string, tail int, follow bool) error {
+			fmt.Fprintf(w, "logs of %s, namespace %q, tail %d, follow %t\n", appID, namespace, tail, follow)
+			return nil
+		},
+	}, &calls
+}
+
+func serve(s *Server, method, target, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return rec
+}
+
+func TestList(t *testing.T) {
+	s, _ := testServer([]standalone.ListOutput{{AppID: "orders", HTTPPort: 3500}})
+
+	rec := serve(s, http.MethodGet, "/v1/apps", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var apps []standalone.ListOutput
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &apps))
+	assert.Equal(t, []standalone.ListOutput{{AppID: "orders", HTTPPort: 3500}}, apps)
+}
+
+func TestRun(t *testing.T) {
+	s, calls := testServer([]standalone.ListOutput{{AppID: "orders"}})
+
+	t.Run("app is started", func(t *testing.T) {
+		rec := serve(s, http.MethodPost, "/v1/apps", `{"appId":"checkout","appPort":3000,"flags":["--log-level","debug"],"command":["node","app.js"],"dir":"/src"}`)
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.JSONEq(t, `{"appId":"checkout","pid":4242}`, rec.Body.String())
+		assert.Equal(t, []string{"launch run --app-id checkout --app-port 3000 --log-level debug -- node app.js in /src"}, *calls)
+	})
+
+	t.Run("running app conflicts", func(t *testing.T) {
+		rec := serve(s, http.MethodPost, "/v1/apps", `{"appId":"orders"}`)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.JSONEq(t, `{"error":"app orders is already running"}`, rec.Body.String())
+	})
+
+	t.Run("app ID is required", func(t *testing.T) {
+		rec := serve(s, http.MethodPost, "/v1/apps", `{"command":["node","app.js"]}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestStop(t *testing.T) {
+	s, calls := testServer([]standalone.ListOutput{{AppID: "orders"}})
+
+	rec := serve(s, http.MethodDelete, "/v1/apps/orders?timeout=5s", "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, []string{"stop orders after 5s"}, *calls)
+
+	rec = serve(s, http.MethodDelete, "/v1/apps/checkout", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = serve(s, http.MethodDelete, "/v1/apps/orders?timeout=soon", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestLogs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+	s, _ := testServer(nil)
+
+	rec := serve(s, http.MethodGet, "/v1/apps/orders/logs", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(standalone.AppLogFilePath("", "orders")), 0o755))
+	require.NoError(t, os.WriteFile(standalone.AppLogFilePath("", "orders"), []byte{}, 0o600))
+	rec = serve(s, http.MethodGet, "/v1/apps/orders/logs?tail=10&follow=true", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "logs of orders, namespace \"\", tail 10, follow true\n", rec.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, serve(s, http.MethodGet, "/v1/apps/orders/logs?namespace=shop", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(s, http.MethodGet, "/v1/apps/orders/logs?namespace=../shop", "").Code)
+	require.NoError(t, os.MkdirAll(filepath.Dir(standalone.AppLogFilePath("shop", "orders")), 0o755))
+	require.NoError(t, os.WriteFile(standalone.AppLogFilePath("shop", "orders"), []byte{}, 0o600))
+	rec = serve(s, http.MethodGet, "/v1/apps/orders/logs?namespace=shop", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "logs of orders, namespace \"shop\", tail -1, follow false\n", rec.Body.String())
+}
+
+func TestRouting(t *testing.T) {
+	s, _ := testServer(nil)
+
+	assert.Equal(t, http.StatusNotFound, serve(s, http.MethodGet, "/v1/apps/orders/metrics", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(s, http.MethodGet, "/v2/apps", "").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(s, http.MethodPut, "/v1/apps", "").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(s, http.MethodGet, "/v1/apps/orders", "").Code)
+}
+
+func TestListen(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "daemon.sock")
+
+	l, err := Listen(socketPath)
+	require.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		info, statErr := os.Stat(socketPath)
+		require.NoError(t, statErr)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "only the user may connect to the socket")
+	}
+
+	_, err = Listen(socketPath)
+	assert.ErrorContains(t, err, "a daemon is already listening")
+
+	l.Close()
+	// Closing a unix listener removes the socket file, so leave a stale one behind.
+	require.NoError(t, os.WriteFile(socketPath, []byte{}, 0o600))
+	l, err = Listen(socketPath)
+	require.NoError(t, err)
+	l.Close()
+}
@@ -320,25 +320,7 @@ func (p *K8sAnnotator) annotateYAML(input []byte, config AnnotateOptions) ([]byt
 		}
 	}
 
-	// Get the dapr annotations and set them on the
-	// resources existing annotation map. This will
-	// override any existing conflicting annotations.
-	if annotations == nil {
-		annotations = make(map[string]string)
-	}
-	daprAnnotations := getDaprAnnotations(&config)
-	for k, v := range daprAnnotations {
-		// TODO: Should we log when we are overwriting?
-		// if _, exists := annotations[k]; exists {}.
-		annotations[k] = v
-	}
-
-	// Check if the app id has been set, if not, we'll
-	// use the resource metadata namespace, kind and name.
-	// For example: namespace-kind-name.
-	if _, appIDSet := annotations[daprAppIDKey]; !appIDSet {
-		annotations[daprAppIDKey] = fmt.Sprintf("%s-%s-%s", ns, kind, name)
-	}
+	annotations = mergeDaprAnnotations(annotations, config, ns, kind, name)
 
 	// Create a patch operation for the annotations.
 	patchOps := []injector.PatchOperation{}
@@ -378,6 +360,30 @@ func (p *K8sAnnotator) annotateYAML(input []byte, config AnnotateOptions) ([]byt
 	return annotatedAsYAML, true, nil
 }
 
+// mergeDaprAnnotations sets the dapr annotations of config on the existing annotations of a resource.
+func mergeDaprAnnotations(annotations map[string]string, config AnnotateOptions, ns, kind, name string) map[string]string {
+	// Get the dapr annotations and set them on the
+	// resources existing annotation map. This will
+	// override any existing conflicting annotations.
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	daprAnnotations := getDaprAnnotations(&config)
+	for k, v := range daprAnnotations {
+		// TODO: Should we log when we are overwriting?
+		// if _, exists := annotations[k]; exists {}.
+		annotations[k] = v
+	}
+
+	// Check if the app id has been set, if not, we'll
+	// use the resource metadata namespace, kind and name.
+	// For example: namespace-kind-name.
+	if _, appIDSet := annotations[daprAppIDKey]; !appIDSet {
+		annotations[daprAppIDKey] = fmt.Sprintf("%s-%s-%s", ns, kind, name)
+	}
+	return annotations
+}
+
 type NamespacedObject interface {
 	GetNamespace() string
 }
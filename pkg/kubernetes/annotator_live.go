@@ -0,0 +1,212 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8s "k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"github.com/dapr/cli/pkg/print"
+)
+
+// liveWorkloadKinds maps the kinds of the workloads that can be annotated in the cluster,
+// with their plural and short names as accepted by kubectl, to the kind.
+var liveWorkloadKinds = map[string]string{
+	deployment:     deployment,
+	"deployments":  deployment,
+	"deploy":       deployment,
+	statefulset:    statefulset,
+	"statefulsets": statefulset,
+	"sts":          statefulset,
+	daemonset:      daemonset,
+	"daemonsets":   daemonset,
+	"ds":           daemonset,
+	replicaset:     replicaset,
+	"replicasets":  replicaset,
+	"rs":           replicaset,
+	job:            job,
+	"jobs":         job,
+	cronjob:        cronjob,
+	"cronjobs":     cronjob,
+	"cj":           cronjob,
+}
+
+// ParseLiveResource returns the kind and name of a workload given as <kind>/<name>, such as deployment/myapp.
+// ok is false if resource is not in that format or the kind cannot be annotated.
+func ParseLiveResource(resource string) (kind, name string, ok bool) {
+	kind, name, found := strings.Cut(resource, "/")
+	if !found || name == "" || strings.Contains(name, "/") {
+		return "", "", false
+	}
+	kind, ok = liveWorkloadKinds[strings.ToLower(kind)]
+	return kind, name, ok
+}
+
+// AnnotateLive adds the dapr annotations to the pod template of a workload in the cluster, given as <kind>/<name>.
+// If patch is set, the workload is patched, which rolls out new pods with the dapr sidecar. Otherwise the annotated
+// manifest of the workload is written to out, without the fields set by the cluster. If dryRun is set with patch,
+// the patch is written to out instead of being applied.
+func AnnotateLive(client k8s.Interface, namespace, resource string, opts AnnotateOptions, patch, dryRun bool, out io.Writer) error {
+	kind, name, ok := ParseLiveResource(resource)
+	if !ok {
+		return fmt.Errorf("invalid resource %q. Expected <kind>/<name>, where kind is a deployment, statefulset, daemonset, replicaset, job or cronjob", resource)
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	ctx := context.Background()
+	obj, annotations, err := getLiveWorkload(ctx, client, kind, namespace, name)
+	if err != nil {
+		return fmt.Errorf("error getting %s/%s in namespace %s: %w", kind, name, namespace, err)
+	}
+
+	if !patch {
+		manifest, err := liveManifest(obj)
+		if err != nil {
+			return err
+		}
+		annotator := NewK8sAnnotator(K8sAnnotatorConfig{})
+		annotated, _, err := annotator.annotateYAML(manifest, opts)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(annotated)
+		return err
+	}
+
+	annotations = mergeDaprAnnotations(annotations, opts, namespace, kind, name)
+	template := map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+	}
+	spec := map[string]interface{}{"template": template}
+	if kind == cronjob {
+		spec = map[string]interface{}{"jobTemplate": map[string]interface{}{"spec": spec}}
+	}
+	data, err := json.Marshal(map[string]interface{}{"spec": spec})
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		print.DryRunStatusEvent(out, "Would patch %s/%s in namespace %s with %s", kind, name, namespace, data)
+		return nil
+	}
+	if err = patchLiveWorkload(ctx, client, kind, namespace, name, data); err != nil {
+		return fmt.Errorf("error patching %s/%s in namespace %s: %w", kind, name, namespace, err)
+	}
+	return nil
+}
+
+// getLiveWorkload returns the workload and the annotations of its pod template.
+func getLiveWorkload(ctx context.Context, client k8s.Interface, kind, namespace, name string) (runtime.Object, map[string]string, error) {
+	getOpts := metav1.GetOptions{}
+	switch kind {
+	case deployment:
+		obj, err := client.AppsV1().Deployments(namespace).Get(ctx, name, getOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+		obj.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+		return obj, obj.Spec.Template.Annotations, nil
+	case statefulset:
+		obj, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, getOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+		obj.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("StatefulSet"))
+		return obj, obj.Spec.Template.Annotations, nil
+	case daemonset:
+		obj, err := client.AppsV1().DaemonSets(namespace).Get(ctx, name, getOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+		obj.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("DaemonSet"))
+		return obj, obj.Spec.Template.Annotations, nil
+	case replicaset:
+		obj, err := client.AppsV1().ReplicaSets(namespace).Get(ctx, name, getOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+		obj.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("ReplicaSet"))
+		return obj, obj.Spec.Template.Annotations, nil
+	case job:
+		obj, err := client.BatchV1().Jobs(namespace).Get(ctx, name, getOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+		obj.SetGroupVersionKind(batchv1.SchemeGroupVersion.WithKind("Job"))
+		return obj, obj.Spec.Template.Annotations, nil
+	case cronjob:
+		obj, err := client.BatchV1().CronJobs(namespace).Get(ctx, name, getOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+		obj.SetGroupVersionKind(batchv1.SchemeGroupVersion.WithKind("CronJob"))
+		return obj, obj.Spec.JobTemplate.Spec.Template.Annotations, nil
+	}
+	return nil, nil, fmt.Errorf("%s cannot be annotated", kind)
+}
+
+func patchLiveWorkload(ctx context.Context, client k8s.Interface, kind, namespace, name string, data []byte) error {
+	patchOpts := metav1.PatchOptions{}
+	var err error
+	switch kind {
+	case deployment:
+		_, err = client.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, data, patchOpts)
+	case statefulset:
+		_, err = client.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.MergePatchType, data, patchOpts)
+	case daemonset:
+		_, err = client.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.MergePatchType, data, patchOpts)
+	case replicaset:
+		_, err = client.AppsV1().ReplicaSets(namespace).Patch(ctx, name, types.MergePatchType, data, patchOpts)
+	case job:
+		_, err = client.BatchV1().Jobs(namespace).Patch(ctx, name, types.MergePatchType, data, patchOpts)
+	case cronjob:
+		_, err = client.BatchV1().CronJobs(namespace).Patch(ctx, name, types.MergePatchType, data, patchOpts)
+	default:
+		err = fmt.Errorf("%s cannot be annotated", kind)
+	}
+	return err
+}
+
+// liveManifest returns the YAML manifest of a workload in the cluster, without its status and the metadata
+// set by the cluster, so it can be applied again.
+func liveManifest(obj runtime.Object) ([]byte, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var manifest map[string]interface{}
+	if err = json.Unmarshal(b, &manifest); err != nil {
+		return nil, err
+	}
+	delete(manifest, "status")
+	if metadata, ok := manifest["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"creationTimestamp", "generation", "managedFields", "resourceVersion", "selfLink", "uid"} {
+			delete(metadata, field)
+		}
+	}
+	return yaml.Marshal(manifest)
+}
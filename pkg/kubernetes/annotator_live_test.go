@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+)
+
+func TestParseLiveResource(t *testing.T) {
+	kind, name, ok := ParseLiveResource("deploy/myapp")
+	assert.True(t, ok)
+	assert.Equal(t, deployment, kind)
+	assert.Equal(t, "myapp", name)
+
+	kind, _, ok = ParseLiveResource("CronJobs/nightly")
+	assert.True(t, ok)
+	assert.Equal(t, cronjob, kind)
+
+	for _, resource := range []string{"myapp", "pod/myapp", "deployment/", "deployment/a/b", "./deploy.yaml"} {
+		_, _, ok = ParseLiveResource(resource)
+		assert.False(t, ok, resource)
+	}
+}
+
+func TestAnnotateLive(t *testing.T) {
+	newClient := func() *fake.Clientset {
+		return fake.NewSimpleClientset(
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "myapp", Namespace: "apps", ResourceVersion: "42"},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"existing": "true"}},
+					},
+				},
+				Status: appsv1.DeploymentStatus{Replicas: 1},
+			},
+			&batchv1.CronJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+			},
+		)
+	}
+	opts := NewAnnotateOptions(WithAppID("myapp"), WithAppPort(8080))
+
+	t.Run("print the annotated manifest", func(t *testing.T) {
+		var out bytes.Buffer
+		err := AnnotateLive(newClient(), "apps", "deployment/myapp", opts, false, false, &out)
+		require.NoError(t, err)
+
+		var manifest appsv1.Deployment
+		require.NoError(t, yaml.Unmarshal(out.Bytes(), &manifest))
+		assert.Equal(t, "Deployment", manifest.Kind)
+		assert.Empty(t, manifest.ResourceVersion)
+		assert.Zero(t, manifest.Status.Replicas)
+		assert.Equal(t, map[string]string{
+			"existing":     "true",
+			daprEnabledKey: "true",
+			daprAppIDKey:   "myapp",
+			daprAppPortKey: "8080",
+		}, manifest.Spec.Template.Annotations)
+	})
+
+	t.Run("patch the deployment", func(t *testing.T) {
+		client := newClient()
+		err := AnnotateLive(client, "apps", "deploy/myapp", opts, true, false, nil)
+		require.NoError(t, err)
+
+		patched, err := client.AppsV1().Deployments("apps").Get(context.Background(), "myapp", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "true", patched.Spec.Template.Annotations["existing"])
+		assert.Equal(t, "myapp", patched.Spec.Template.Annotations[daprAppIDKey])
+		assert.Equal(t, "8080", patched.Spec.Template.Annotations[daprAppPortKey])
+	})
+
+	t.Run("dry run prints the patch", func(t *testing.T) {
+		client := newClient()
+		var out bytes.Buffer
+		err := AnnotateLive(client, "apps", "deploy/myapp", opts, true, true, &out)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "Would patch deployment/myapp in namespace apps with ")
+		assert.Contains(t, out.String(), `"dapr.io/app-id":"myapp"`)
+
+		unchanged, err := client.AppsV1().Deployments("apps").Get(context.Background(), "myapp", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, unchanged.Spec.Template.Annotations[daprAppIDKey])
+	})
+
+	t.Run("patch the cronjob with a generated app id", func(t *testing.T) {
+		client := newClient()
+		err := AnnotateLive(client, "", "cronjob/nightly", NewAnnotateOptions(), true, false, nil)
+		require.NoError(t, err)
+
+		patched, err := client.BatchV1().CronJobs("default").Get(context.Background(), "nightly", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "default-cronjob-nightly", patched.Spec.JobTemplate.Spec.Template.Annotations[daprAppIDKey])
+	})
+
+	t.Run("workload not found", func(t *testing.T) {
+		err := AnnotateLive(newClient(), "default", "statefulset/missing", opts, true, false, nil)
+		assert.ErrorContains(t, err, "error getting statefulset/missing in namespace default")
+	})
+
+	t.Run("invalid resource", func(t *testing.T) {
+		err := AnnotateLive(newClient(), "default", "pod/myapp", opts, true, false, nil)
+		assert.ErrorContains(t, err, "invalid resource")
+	})
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	helm "helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/repo"
+
+	"github.com/dapr/cli/utils"
+)
+
+// ChartSource is where the Dapr Helm chart is pulled from. The zero value is the Dapr Helm repository, or the
+// repository given by DAPR_HELM_REPO_URL.
+type ChartSource struct {
+	// RepoURL is the URL of a Helm repository with a chart named dapr, such as an internal mirror.
+	RepoURL string
+	// Chart is a chart reference used instead of a repository: an OCI reference such as oci://registry/dapr/dapr,
+	// or a repo/chart name of a repository added with helm repo add.
+	Chart string
+}
+
+// Custom returns true if the chart is not pulled from the default repository.
+func (s ChartSource) Custom() bool {
+	return s.RepoURL != "" || s.Chart != ""
+}
+
+// String returns the chart reference, or the URL of the repository the chart is pulled from.
+func (s ChartSource) String() string {
+	if s.Chart != "" {
+		return s.Chart
+	}
+	return s.repoURL()
+}
+
+func (s ChartSource) repoURL() string {
+	if s.RepoURL != "" {
+		return s.RepoURL
+	}
+	return helmRepoURL()
+}
+
+// newPull returns a pull of the chart of source and the reference of the chart to pull. Credentials are read from
+// DAPR_HELM_REPO_USERNAME and DAPR_HELM_REPO_PASSWORD, or else from the repositories and registry configs of Helm.
+func newPull(config *helm.Configuration, source ChartSource) (*helm.Pull, string, error) {
+	settings := cli.New()
+	pull := helm.NewPullWithOpts(helm.WithConfig(config))
+	pull.Settings = settings
+	pull.Username = utils.GetEnv("DAPR_HELM_REPO_USERNAME", "")
+	pull.Password = utils.GetEnv("DAPR_HELM_REPO_PASSWORD", "")
+	pull.CaFile = utils.CACertFile()
+
+	if source.Chart != "" {
+		if registry.IsOCI(source.Chart) {
+			client, err := registry.NewClient(registry.ClientOptCredentialsFile(settings.RegistryConfig))
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to create the OCI registry client: %w", err)
+			}
+			config.RegistryClient = client
+		}
+		return pull, source.Chart, nil
+	}
+
+	pull.RepoURL = source.repoURL()
+	if entry := repositoryEntry(settings.RepositoryConfig, pull.RepoURL); entry != nil && pull.Username == "" {
+		pull.Username = entry.Username
+		pull.Password = entry.Password
+		pull.CertFile = entry.CertFile
+		pull.KeyFile = entry.KeyFile
+		pull.InsecureSkipTLSverify = entry.InsecureSkipTLSverify
+		if pull.CaFile == "" {
+			pull.CaFile = entry.CAFile
+		}
+	}
+	return pull, daprReleaseName, nil
+}
+
+// repositoryEntry returns the repository with url in the Helm repositories config at path, or nil if there is none.
+func repositoryEntry(path, url string) *repo.Entry {
+	f, err := repo.LoadFile(path)
+	if err != nil {
+		return nil
+	}
+	for _, entry := range f.Repositories {
+		if strings.TrimSuffix(entry.URL, "/") == strings.TrimSuffix(url, "/") {
+			return entry
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	helm "helm.sh/helm/v3/pkg/action"
+)
+
+func TestChartSource(t *testing.T) {
+	assert.False(t, ChartSource{}.Custom())
+	assert.Equal(t, daprHelmRepo, ChartSource{}.String())
+	assert.Equal(t, "https://charts.example.com", ChartSource{RepoURL: "https://charts.example.com"}.String())
+	assert.Equal(t, "oci://registry.example.com/dapr/dapr", ChartSource{Chart: "oci://registry.example.com/dapr/dapr"}.String())
+}
+
+func TestNewPull(t *testing.T) {
+	dir := t.TempDir()
+	repositories := filepath.Join(dir, "repositories.yaml")
+	require.NoError(t, os.WriteFile(repositories, []byte(`apiVersion: v1
+repositories:
+- name: mirror
+  url: https://charts.example.com/dapr/
+  username: user
+  password: secret
+  caFile: /etc/ssl/mirror.pem
+`), 0o600))
+	t.Setenv("HELM_REPOSITORY_CONFIG", repositories)
+	t.Setenv("HELM_REGISTRY_CONFIG", filepath.Join(dir, "registry.json"))
+	t.Setenv("DAPR_HELM_REPO_USERNAME", "")
+
+	t.Run("credentials of a repository are read from the Helm config", func(t *testing.T) {
+		pull, chartRef, err := newPull(&helm.Configuration{}, ChartSource{RepoURL: "https://charts.example.com/dapr"})
+		require.NoError(t, err)
+		assert.Equal(t, "dapr", chartRef)
+		assert.Equal(t, "https://charts.example.com/dapr", pull.RepoURL)
+		assert.Equal(t, "user", pull.Username)
+		assert.Equal(t, "secret", pull.Password)
+		assert.Equal(t, "/etc/ssl/mirror.pem", pull.CaFile)
+	})
+
+	t.Run("OCI charts are pulled with a registry client", func(t *testing.T) {
+		config := &helm.Configuration{}
+		pull, chartRef, err := newPull(config, ChartSource{Chart: "oci://registry.example.com/dapr/dapr"})
+		require.NoError(t, err)
+		assert.Equal(t, "oci://registry.example.com/dapr/dapr", chartRef)
+		assert.Empty(t, pull.RepoURL)
+		assert.NotNil(t, config.RegistryClient)
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"flag"
 	"sync"
 
+	"k8s.io/client-go/dynamic"
 	k8s "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 
@@ -91,6 +92,19 @@ func Client() (*k8s.Clientset, error) {
 	return k8s.NewForConfig(config)
 }
 
+// CurrentNamespace returns the namespace of the current context of the kubeconfig, or the default namespace if it
+// has none.
+func CurrentNamespace() (string, error) {
+	doOnce.Do(func() {
+		flag.Parse()
+	})
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = *kubeconfig
+	namespace, _, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{}).Namespace()
+	return namespace, err
+}
+
 // DaprClient returns a new Kubernetes Dapr client.
 func DaprClient() (scheme.Interface, error) {
 	config, err := getConfig()
@@ -99,3 +113,12 @@ func DaprClient() (scheme.Interface, error) {
 	}
 	return scheme.NewForConfig(config)
 }
+
+// DynamicClient returns a new Kubernetes client for resources without a typed client, such as Dapr subscriptions.
+func DynamicClient() (dynamic.Interface, error) {
+	config, err := getConfig()
+	if err != nil {
+		return nil, err
+	}
+	return dynamic.NewForConfig(config)
+}
@@ -0,0 +1,345 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	configurationKind = "Configuration"
+	configurationCRD  = "configurations.dapr.io"
+	// configurationAPIVersion is the apiVersion of the Dapr Configuration resources.
+	configurationAPIVersion = "dapr.io/" + daprCRDVersion
+
+	// ConfigurationCreated, ConfigurationConfigured and ConfigurationUnchanged are the results of applying a
+	// configuration.
+	ConfigurationCreated    = "created"
+	ConfigurationConfigured = "configured"
+	ConfigurationUnchanged  = "unchanged"
+)
+
+var configurationsResource = schema.GroupVersionResource{Group: "dapr.io", Version: daprCRDVersion, Resource: "configurations"}
+
+// ConfigurationApplyResult is the outcome of applying a configuration of a file.
+type ConfigurationApplyResult struct {
+	Namespace string
+	Name      string
+	// Result is ConfigurationCreated, ConfigurationConfigured or ConfigurationUnchanged.
+	Result string
+}
+
+// ConfigurationValidationError lists the problems of the configurations of a file found by their CRD schema.
+type ConfigurationValidationError struct {
+	Problems []string
+}
+
+func (e *ConfigurationValidationError) Error() string {
+	return "invalid configuration:\n  " + strings.Join(e.Problems, "\n  ")
+}
+
+// ApplyConfigurations creates the Dapr Configuration resources of the file at path, or updates them if they exist.
+// The configurations are validated against the schema of the Configuration CRD of the cluster first, and none is
+// applied if any of them is invalid. If dryRun is set, the configurations are only validated and compared.
+func ApplyConfigurations(path, namespace string, dryRun bool) ([]ConfigurationApplyResult, error) {
+	b, err := readManifestFile(path)
+	if err != nil {
+		return nil, err
+	}
+	configurations, err := parseConfigurations(b)
+	if err != nil {
+		return nil, err
+	}
+	crdValidation, err := configurationCRDValidation()
+	if err != nil {
+		return nil, err
+	}
+	client, err := DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	return applyConfigurations(client, crdValidation, configurations, namespace, dryRun)
+}
+
+func readManifestFile(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	return b, nil
+}
+
+func applyConfigurations(client dynamic.Interface, crdValidation *apiextensionsv1.CustomResourceValidation, configurations []*unstructured.Unstructured, namespace string, dryRun bool) ([]ConfigurationApplyResult, error) {
+	problems := []string{}
+	for _, c := range configurations {
+		if err := setConfigurationNamespace(c, namespace); err != nil {
+			return nil, err
+		}
+		for _, p := range validateConfiguration(c, crdValidation) {
+			problems = append(problems, fmt.Sprintf("%s/%s: %s", c.GetNamespace(), c.GetName(), p))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &ConfigurationValidationError{Problems: problems}
+	}
+
+	results := []ConfigurationApplyResult{}
+	for _, c := range configurations {
+		result, err := applyConfiguration(client, c, dryRun)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, ConfigurationApplyResult{Namespace: c.GetNamespace(), Name: c.GetName(), Result: result})
+	}
+	return results, nil
+}
+
+// applyConfiguration creates c, or replaces the spec of the existing configuration with the spec of c.
+func applyConfiguration(client dynamic.Interface, c *unstructured.Unstructured, dryRun bool) (string, error) {
+	resource := client.Resource(configurationsResource).Namespace(c.GetNamespace())
+	existing, err := resource.Get(context.TODO(), c.GetName(), meta_v1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		if !dryRun {
+			if _, err = resource.Create(context.TODO(), c, meta_v1.CreateOptions{}); err != nil {
+				return "", fmt.Errorf("error creating configuration %s/%s: %w", c.GetNamespace(), c.GetName(), err)
+			}
+		}
+		return ConfigurationCreated, nil
+	} else if err != nil {
+		return "", fmt.Errorf("error getting configuration %s/%s: %w", c.GetNamespace(), c.GetName(), err)
+	}
+
+	if sameConfiguration(existing, c) {
+		return ConfigurationUnchanged, nil
+	}
+	if !dryRun {
+		c.SetResourceVersion(existing.GetResourceVersion())
+		if _, err = resource.Update(context.TODO(), c, meta_v1.UpdateOptions{}); err != nil {
+			return "", fmt.Errorf("error updating configuration %s/%s: %w", c.GetNamespace(), c.GetName(), err)
+		}
+	}
+	return ConfigurationConfigured, nil
+}
+
+// sameConfiguration returns true if the spec, the labels and the annotations of a and b are equal.
+func sameConfiguration(a, b *unstructured.Unstructured) bool {
+	equal := func(x, y interface{}) bool {
+		bx, errX := json.Marshal(x)
+		by, errY := json.Marshal(y)
+		return errX == nil && errY == nil && string(bx) == string(by)
+	}
+	return equal(a.Object["spec"], b.Object["spec"]) && equal(a.GetLabels(), b.GetLabels()) && equal(a.GetAnnotations(), b.GetAnnotations())
+}
+
+// setConfigurationNamespace sets the namespace of c to namespace, or to the default namespace if neither is set.
+func setConfigurationNamespace(c *unstructured.Unstructured, namespace string) error {
+	switch {
+	case namespace == "" && c.GetNamespace() == "":
+		c.SetNamespace(meta_v1.NamespaceDefault)
+	case namespace == "":
+	case c.GetNamespace() == "":
+		c.SetNamespace(namespace)
+	case c.GetNamespace() != namespace:
+		return fmt.Errorf("the namespace of configuration %s is %s, which does not match --namespace %s", c.GetName(), c.GetNamespace(), namespace)
+	}
+	return nil
+}
+
+// parseConfigurations returns the Dapr Configuration resources of a YAML or JSON manifest, which may hold several
+// documents. Documents of other kinds are an error.
+func parseConfigurations(manifest []byte) ([]*unstructured.Unstructured, error) {
+	configurations := []*unstructured.Unstructured{}
+	for i, doc := range manifestSeparator.Split(string(manifest), -1) {
+		obj := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return nil, fmt.Errorf("error parsing document %d: %w", i+1, err)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+		c := &unstructured.Unstructured{Object: obj}
+		if c.GetKind() != configurationKind || c.GetAPIVersion() != configurationAPIVersion {
+			return nil, fmt.Errorf("document %d is a %s of %s, expected a %s of %s", i+1, c.GetKind(), c.GetAPIVersion(), configurationKind, configurationAPIVersion)
+		}
+		if c.GetName() == "" {
+			return nil, fmt.Errorf("document %d has no metadata.name", i+1)
+		}
+		configurations = append(configurations, c)
+	}
+	if len(configurations) == 0 {
+		return nil, errors.New("no configurations found")
+	}
+	return configurations, nil
+}
+
+// configurationCRDValidation returns the schema of the served version of the Configuration CRD of the cluster.
+func configurationCRDValidation() (*apiextensionsv1.CustomResourceValidation, error) {
+	config, err := getConfig()
+	if err != nil {
+		return nil, err
+	}
+	extClient, err := apiextensionsclient.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	crd, err := extClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), configurationCRD, meta_v1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("the %s CRD is not installed. Is Dapr initialized in your cluster?", configurationCRD)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get CRD %s: %w", configurationCRD, err)
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Name == daprCRDVersion && v.Served {
+			return v.Schema, nil
+		}
+	}
+	return nil, fmt.Errorf("the %s CRD does not serve version %s", configurationCRD, daprCRDVersion)
+}
+
+// validateConfiguration returns the problems of c found by the schema of its CRD: fields of a wrong type or with
+// an invalid value, and fields the schema does not know, which the API server would drop silently.
+func validateConfiguration(c *unstructured.Unstructured, crdValidation *apiextensionsv1.CustomResourceValidation) []string {
+	if crdValidation == nil || crdValidation.OpenAPIV3Schema == nil {
+		return nil
+	}
+	internal := &apiextensions.CustomResourceValidation{}
+	if err := apiextensionsv1.Convert_v1_CustomResourceValidation_To_apiextensions_CustomResourceValidation(crdValidation, internal, nil); err != nil {
+		return []string{fmt.Sprintf("the schema of the CRD cannot be read: %s", err)}
+	}
+	validator, _, err := validation.NewSchemaValidator(internal)
+	if err != nil {
+		return []string{fmt.Sprintf("the schema of the CRD cannot be read: %s", err)}
+	}
+
+	problems := []string{}
+	for _, e := range validation.ValidateCustomResource(nil, c.Object, validator) {
+		problems = append(problems, e.Error())
+	}
+	spec := crdValidation.OpenAPIV3Schema.Properties["spec"]
+	problems = append(problems, unknownFields("spec", c.Object["spec"], &spec)...)
+	sort.Strings(problems)
+	return problems
+}
+
+// unknownFields returns the fields of value, at path, that are not in the properties of schema. Objects whose
+// schema keeps unknown fields or has no properties are not checked.
+func unknownFields(path string, value interface{}, schema *apiextensionsv1.JSONSchemaProps) []string {
+	if schema == nil || (schema.XPreserveUnknownFields != nil && *schema.XPreserveUnknownFields) {
+		return nil
+	}
+	problems := []string{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			for key, item := range v {
+				problems = append(problems, unknownFields(path+"."+key, item, schema.AdditionalProperties.Schema)...)
+			}
+			return problems
+		}
+		if len(schema.Properties) == 0 {
+			return nil
+		}
+		for key, item := range v {
+			prop, ok := schema.Properties[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s: unknown field", path, key))
+				continue
+			}
+			problems = append(problems, unknownFields(path+"."+key, item, &prop)...)
+		}
+	case []interface{}:
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return nil
+		}
+		for i, item := range v {
+			problems = append(problems, unknownFields(fmt.Sprintf("%s[%d]", path, i), item, schema.Items.Schema)...)
+		}
+	}
+	return problems
+}
+
+// DeleteConfiguration deletes the Dapr Configuration resource name in namespace.
+func DeleteConfiguration(name, namespace string) error {
+	client, err := DynamicClient()
+	if err != nil {
+		return err
+	}
+	err = client.Resource(configurationsResource).Namespace(namespace).Delete(context.TODO(), name, meta_v1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("configuration %s not found in namespace %s", name, namespace)
+	} else if err != nil {
+		return fmt.Errorf("error deleting configuration %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// WriteConfiguration writes the manifest of the Dapr Configuration resource name in namespace to w as yaml or json,
+// without the metadata set by the cluster, so that it can be edited and applied again.
+func WriteConfiguration(w io.Writer, name, namespace, outputFormat string) error {
+	client, err := DynamicClient()
+	if err != nil {
+		return err
+	}
+	c, err := client.Resource(configurationsResource).Namespace(namespace).Get(context.TODO(), name, meta_v1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("configuration %s not found in namespace %s", name, namespace)
+	} else if err != nil {
+		return fmt.Errorf("error getting configuration %s/%s: %w", namespace, name, err)
+	}
+	return writeManifest(w, c, outputFormat)
+}
+
+func writeManifest(w io.Writer, obj *unstructured.Unstructured, outputFormat string) error {
+	b, err := backupManifest(obj)
+	if err != nil {
+		return err
+	}
+	switch outputFormat {
+	case "", "yaml":
+	case "json":
+		var v map[string]interface{}
+		if err = yaml.Unmarshal(b, &v); err != nil {
+			return err
+		}
+		if b, err = json.MarshalIndent(v, "", "  "); err != nil {
+			return err
+		}
+		b = append(b, '\n')
+	default:
+		return fmt.Errorf("invalid output format %q. Valid values are: yaml, json", outputFormat)
+	}
+	_, err = w.Write(b)
+	return err
+}
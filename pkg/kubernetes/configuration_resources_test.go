@@ -0,0 +1,208 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// testConfigurationSchema is a subset of the schema of the Dapr Configuration CRD.
+func testConfigurationSchema() *apiextensionsv1.CustomResourceValidation {
+	preserve := true
+	return &apiextensionsv1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"apiVersion": {Type: "string"},
+				"kind":       {Type: "string"},
+				"metadata":   {Type: "object"},
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"tracing": {
+							Type: "object",
+							Properties: map[string]apiextensionsv1.JSONSchemaProps{
+								"samplingRate": {Type: "string"},
+							},
+						},
+						"features": {
+							Type: "array",
+							Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{
+								Type:     "object",
+								Required: []string{"name", "enabled"},
+								Properties: map[string]apiextensionsv1.JSONSchemaProps{
+									"name":    {Type: "string"},
+									"enabled": {Type: "boolean"},
+								},
+							}},
+						},
+						"secrets": {Type: "object", XPreserveUnknownFields: &preserve},
+					},
+				},
+			},
+		},
+	}
+}
+
+const testConfigurationManifest = `apiVersion: dapr.io/v1alpha1
+kind: Configuration
+metadata:
+  name: tracing
+spec:
+  tracing:
+    samplingRate: "1"
+---
+apiVersion: dapr.io/v1alpha1
+kind: Configuration
+metadata:
+  name: features
+  namespace: apps
+spec:
+  features:
+  - name: Actor.Reentrancy
+    enabled: true
+`
+
+func TestParseConfigurations(t *testing.T) {
+	t.Run("multiple documents", func(t *testing.T) {
+		configurations, err := parseConfigurations([]byte(testConfigurationManifest))
+		require.NoError(t, err)
+		require.Len(t, configurations, 2)
+		assert.Equal(t, "tracing", configurations[0].GetName())
+		assert.Equal(t, "apps", configurations[1].GetNamespace())
+	})
+
+	t.Run("other kinds are rejected", func(t *testing.T) {
+		_, err := parseConfigurations([]byte("apiVersion: dapr.io/v1alpha1\nkind: Component\nmetadata:\n  name: statestore\n"))
+		assert.ErrorContains(t, err, "document 1 is a Component")
+	})
+
+	t.Run("name is required", func(t *testing.T) {
+		_, err := parseConfigurations([]byte("apiVersion: dapr.io/v1alpha1\nkind: Configuration\nspec: {}\n"))
+		assert.ErrorContains(t, err, "no metadata.name")
+	})
+
+	t.Run("empty manifest", func(t *testing.T) {
+		_, err := parseConfigurations([]byte("---\n"))
+		assert.ErrorContains(t, err, "no configurations found")
+	})
+}
+
+func TestValidateConfiguration(t *testing.T) {
+	parse := func(spec string) *unstructured.Unstructured {
+		configurations, err := parseConfigurations([]byte("apiVersion: dapr.io/v1alpha1\nkind: Configuration\nmetadata:\n  name: c\nspec:\n" + spec))
+		require.NoError(t, err)
+		return configurations[0]
+	}
+
+	assert.Empty(t, validateConfiguration(parse("  tracing:\n    samplingRate: \"1\"\n  secrets:\n    anything: goes\n"), testConfigurationSchema()))
+
+	problems := validateConfiguration(parse("  tracing:\n    samplingRate: 1\n    zipkin: {}\n  features:\n  - name: Resiliency\n    enable: true\n"), testConfigurationSchema())
+	require.Len(t, problems, 4)
+	assert.Equal(t, "spec.features.enabled: Required value", problems[0])
+	assert.Equal(t, "spec.features[0].enable: unknown field", problems[1])
+	assert.Contains(t, problems[2], "spec.tracing.samplingRate in body must be of type string")
+	assert.Equal(t, "spec.tracing.zipkin: unknown field", problems[3])
+
+	assert.Empty(t, validateConfiguration(parse("  unknown: true\n"), nil), "no schema, no validation")
+}
+
+func TestApplyConfigurations(t *testing.T) {
+	newClient := func(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+		listKinds := map[schema.GroupVersionResource]string{configurationsResource: "ConfigurationList"}
+		return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, objects...)
+	}
+	parse := func() []*unstructured.Unstructured {
+		configurations, err := parseConfigurations([]byte(testConfigurationManifest))
+		require.NoError(t, err)
+		return configurations
+	}
+
+	t.Run("create, update and unchanged", func(t *testing.T) {
+		existing := parse()[1]
+		existing.Object["spec"] = map[string]interface{}{}
+		client := newClient(existing, parse()[0].DeepCopy())
+		// The first configuration has no namespace, so the existing copy of it is in no namespace and not found.
+		results, err := applyConfigurations(client, testConfigurationSchema(), parse(), "", false)
+		require.NoError(t, err)
+		assert.Equal(t, []ConfigurationApplyResult{
+			{Namespace: "default", Name: "tracing", Result: ConfigurationCreated},
+			{Namespace: "apps", Name: "features", Result: ConfigurationConfigured},
+		}, results)
+
+		updated, err := client.Resource(configurationsResource).Namespace("apps").Get(context.TODO(), "features", meta_v1.GetOptions{})
+		require.NoError(t, err)
+		features, _, _ := unstructured.NestedSlice(updated.Object, "spec", "features")
+		assert.Len(t, features, 1)
+
+		results, err = applyConfigurations(client, testConfigurationSchema(), parse(), "", false)
+		require.NoError(t, err)
+		assert.Equal(t, ConfigurationUnchanged, results[0].Result)
+		assert.Equal(t, ConfigurationUnchanged, results[1].Result)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		client := newClient()
+		results, err := applyConfigurations(client, testConfigurationSchema(), parse()[:1], "apps", true)
+		require.NoError(t, err)
+		assert.Equal(t, ConfigurationCreated, results[0].Result)
+		_, err = client.Resource(configurationsResource).Namespace("apps").Get(context.TODO(), "tracing", meta_v1.GetOptions{})
+		assert.Error(t, err, "nothing is created on a dry run")
+	})
+
+	t.Run("namespace mismatch", func(t *testing.T) {
+		_, err := applyConfigurations(newClient(), testConfigurationSchema(), parse(), "other", false)
+		assert.ErrorContains(t, err, "does not match --namespace other")
+	})
+
+	t.Run("invalid configurations are not applied", func(t *testing.T) {
+		client := newClient()
+		configurations := parse()
+		configurations[1].Object["spec"] = map[string]interface{}{"tracing": map[string]interface{}{"sampling": "1"}}
+		_, err := applyConfigurations(client, testConfigurationSchema(), configurations, "", false)
+		var validationErr *ConfigurationValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []string{"apps/features: spec.tracing.sampling: unknown field"}, validationErr.Problems)
+		_, err = client.Resource(configurationsResource).Namespace("default").Get(context.TODO(), "tracing", meta_v1.GetOptions{})
+		assert.Error(t, err, "the valid configuration is not applied either")
+	})
+}
+
+func TestWriteManifest(t *testing.T) {
+	obj := daprResource("Configuration", "default", "tracing", nil)
+	obj.SetResourceVersion("42")
+	obj.Object["spec"] = map[string]interface{}{"tracing": map[string]interface{}{"samplingRate": "1"}}
+
+	var out bytes.Buffer
+	require.NoError(t, writeManifest(&out, obj, "yaml"))
+	assert.Contains(t, out.String(), "samplingRate: \"1\"")
+	assert.NotContains(t, out.String(), "resourceVersion")
+
+	out.Reset()
+	require.NoError(t, writeManifest(&out, obj, "json"))
+	assert.Contains(t, out.String(), "\"samplingRate\": \"1\"")
+
+	assert.Error(t, writeManifest(&out, obj, "table"))
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"io"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// DeploymentApp is an app to generate a Deployment for with GenerateDeployments.
+type DeploymentApp struct {
+	AppID   string
+	Image   string
+	AppPort int
+	Env     map[string]string
+	// Annotations are the Dapr annotations of the pods, the dapr.io/app-id annotation is always set from AppID.
+	Annotations AnnotateOptions
+}
+
+// GenerateDeployments writes a Deployment of one replica for each app, with the Dapr annotations that inject
+// its sidecar, as a multi-document YAML. An empty namespace leaves it to the namespace of kubectl apply.
+func GenerateDeployments(apps []DeploymentApp, namespace string, out io.Writer) error {
+	for i, app := range apps {
+		b, err := yaml.Marshal(newAppDeployment(app, namespace))
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err = io.WriteString(out, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err = out.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newAppDeployment(app DeploymentApp, namespace string) *appsv1.Deployment {
+	labels := map[string]string{"app": app.AppID}
+	app.Annotations.appID = &app.AppID
+
+	container := corev1.Container{ // nolint:exhaustivestruct
+		Name:  app.AppID,
+		Image: app.Image,
+	}
+	if app.AppPort > 0 {
+		container.Ports = []corev1.ContainerPort{{ContainerPort: int32(app.AppPort)}} // nolint:exhaustivestruct
+	}
+	names := make([]string, 0, len(app.Env))
+	for name := range app.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		container.Env = append(container.Env, corev1.EnvVar{Name: name, Value: app.Env[name]}) // nolint:exhaustivestruct
+	}
+
+	replicas := int32(1)
+	return &appsv1.Deployment{ // nolint:exhaustivestruct
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{ // nolint:exhaustivestruct
+			Name:      app.AppID,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{ // nolint:exhaustivestruct
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels}, // nolint:exhaustivestruct
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{ // nolint:exhaustivestruct
+					Labels:      labels,
+					Annotations: getDaprAnnotations(&app.Annotations),
+				},
+				Spec: corev1.PodSpec{ // nolint:exhaustivestruct
+					Containers: []corev1.Container{container},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestGenerateDeployments(t *testing.T) {
+	apps := []DeploymentApp{
+		{
+			AppID:       "orders",
+			Image:       "registry.io/orders:latest",
+			AppPort:     3000,
+			Env:         map[string]string{"B": "2", "A": "1"},
+			Annotations: NewAnnotateOptions(WithAppPort(3000), WithLogLevel("debug")),
+		},
+		{AppID: "checkout", Image: "checkout:latest"},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, GenerateDeployments(apps, "shop", &out))
+	docs := strings.Split(out.String(), "---\n")
+	require.Len(t, docs, 2)
+
+	var orders appsv1.Deployment
+	require.NoError(t, yaml.Unmarshal([]byte(docs[0]), &orders))
+	assert.Equal(t, "Deployment", orders.Kind)
+	assert.Equal(t, "shop", orders.Namespace)
+	assert.Equal(t, map[string]string{
+		"dapr.io/enabled":   "true",
+		"dapr.io/app-id":    "orders",
+		"dapr.io/app-port":  "3000",
+		"dapr.io/log-level": "debug",
+	}, orders.Spec.Template.Annotations)
+	assert.Equal(t, orders.Spec.Selector.MatchLabels, orders.Spec.Template.Labels)
+	container := orders.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "registry.io/orders:latest", container.Image)
+	assert.Equal(t, int32(3000), container.Ports[0].ContainerPort)
+	assert.Equal(t, "A", container.Env[0].Name)
+
+	var checkout appsv1.Deployment
+	require.NoError(t, yaml.Unmarshal([]byte(docs[1]), &checkout))
+	assert.Equal(t, "checkout", checkout.Spec.Template.Annotations["dapr.io/app-id"])
+	assert.Empty(t, checkout.Spec.Template.Spec.Containers[0].Ports)
+}
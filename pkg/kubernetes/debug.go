@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+
+	"github.com/phayes/freeport"
+	core_v1 "k8s.io/api/core/v1"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+// SidecarPort is a port of a sidecar forwarded to the local machine by `dapr debug`.
+type SidecarPort struct {
+	Name       string `csv:"NAME"        json:"name"       yaml:"name"`
+	LocalPort  int    `csv:"LOCAL PORT"  json:"localPort"  yaml:"localPort"`
+	RemotePort int    `csv:"REMOTE PORT" json:"remotePort" yaml:"remotePort"`
+}
+
+// DebugSession holds the port-forwards to the sidecar of a pod.
+type DebugSession struct {
+	Pod   string
+	Ports []SidecarPort
+
+	namespace    string
+	client       k8s.Interface
+	portForwards []*PortForward
+}
+
+// sidecarPortArgs are the arguments of the sidecar container that set the ports forwarded by `dapr debug`,
+// with the port used by the sidecar if they are not set.
+var sidecarPortArgs = []struct {
+	name        string
+	arg         string
+	defaultPort int
+}{
+	{name: "http", arg: "--dapr-http-port", defaultPort: 3500},
+	{name: "grpc", arg: "--dapr-grpc-port", defaultPort: 50001},
+	{name: "metrics", arg: "--metrics-port", defaultPort: 9090},
+}
+
+// StartDebugSession forwards the HTTP, gRPC and metrics ports of the sidecar of appID to the local machine.
+// The sidecar of podName is used if given, otherwise the sidecar of the first running pod of the app.
+// Each port is forwarded to the same local port if it is free, otherwise to a random free port.
+// Stop must be called to close the port-forwards.
+func StartDebugSession(appID, podName, namespace string) (*DebugSession, error) {
+	config, client, err := GetKubeConfigClient()
+	if err != nil {
+		return nil, err
+	}
+	pods, err := ListPods(client, namespace, nil)
+	if err != nil {
+		return nil, err
+	}
+	pod, err := findSidecarPod(pods.Items, appID, podName)
+	if err != nil {
+		return nil, fmt.Errorf("%w in namespace %s", err, namespace)
+	}
+
+	session := &DebugSession{Pod: pod.Name, namespace: namespace, client: client}
+	for _, port := range sidecarPorts(pod) {
+		if port.LocalPort, err = localPort(port.RemotePort); err != nil {
+			session.Stop()
+			return nil, err
+		}
+		portForward, err := NewPortForward(config, namespace, pod.Name, "localhost", port.LocalPort, port.RemotePort, false)
+		if err == nil {
+			err = portForward.Init()
+		}
+		if err != nil {
+			session.Stop()
+			return nil, fmt.Errorf("error forwarding the %s port of %s: %w", port.Name, pod.Name, err)
+		}
+		session.portForwards = append(session.portForwards, portForward)
+		session.Ports = append(session.Ports, port)
+	}
+	return session, nil
+}
+
+// Port returns the local port the sidecar port with the given name is forwarded to, or 0 if it is not forwarded.
+func (s *DebugSession) Port(name string) int {
+	for _, port := range s.Ports {
+		if port.Name == name {
+			return port.LocalPort
+		}
+	}
+	return 0
+}
+
+// FollowLogs writes the logs of the sidecar to w as they are written, starting with the last tail lines,
+// until ctx is done or the sidecar stops.
+func (s *DebugSession) FollowLogs(ctx context.Context, w io.Writer, tail int64) error {
+	opts := &core_v1.PodLogOptions{Container: daprdContainerName, Follow: true}
+	if tail >= 0 {
+		opts.TailLines = &tail
+	}
+	stream, err := s.client.CoreV1().Pods(s.namespace).GetLogs(s.Pod, opts).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get logs of %s: %w", s.Pod, err)
+	}
+	defer stream.Close()
+	_, err = io.Copy(w, stream)
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("could not get logs of %s: %w", s.Pod, err)
+	}
+	return nil
+}
+
+// Stop closes the port-forwards.
+func (s *DebugSession) Stop() {
+	for _, portForward := range s.portForwards {
+		portForward.Stop()
+	}
+	s.portForwards = nil
+}
+
+// findSidecarPod returns the pod named podName if given, otherwise the first running pod with the sidecar of appID.
+func findSidecarPod(pods []core_v1.Pod, appID, podName string) (core_v1.Pod, error) {
+	for _, pod := range pods {
+		if pod.Status.Phase != core_v1.PodRunning || (podName != "" && pod.Name != podName) {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			if container.Name == daprdContainerName && containerArg(container, appIDContainerArgName) == appID {
+				return pod, nil
+			}
+		}
+	}
+	if podName != "" {
+		return core_v1.Pod{}, fmt.Errorf("no running pod %s with the sidecar of app %s found", podName, appID)
+	}
+	return core_v1.Pod{}, fmt.Errorf("no running pod with the sidecar of app %s found", appID)
+}
+
+// sidecarPorts returns the ports of the sidecar of a pod.
+func sidecarPorts(pod core_v1.Pod) []SidecarPort {
+	ports := []SidecarPort{}
+	for _, container := range pod.Spec.Containers {
+		if container.Name != daprdContainerName {
+			continue
+		}
+		for _, p := range sidecarPortArgs {
+			port := p.defaultPort
+			if value, err := strconv.Atoi(containerArg(container, p.arg)); err == nil {
+				port = value
+			}
+			ports = append(ports, SidecarPort{Name: p.name, RemotePort: port})
+		}
+	}
+	return ports
+}
+
+// containerArg returns the value of an argument of a container, or an empty string if it is not set.
+func containerArg(container core_v1.Container, name string) string {
+	for i, arg := range container.Args {
+		if arg == name && i+1 < len(container.Args) {
+			return container.Args[i+1]
+		}
+	}
+	return ""
+}
+
+// localPort returns port if it is free on the local machine, otherwise a random free port.
+func localPort(port int) (int, error) {
+	l, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", port))
+	if err == nil {
+		l.Close()
+		return port, nil
+	}
+	return freeport.GetFreePort()
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFindSidecarPod(t *testing.T) {
+	daprPod := func(name, appID string, phase core_v1.PodPhase, args ...string) core_v1.Pod {
+		return core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name},
+			Spec: core_v1.PodSpec{
+				Containers: []core_v1.Container{
+					{Name: "app"},
+					{Name: "daprd", Args: append([]string{"--mode", "kubernetes", "--app-id", appID}, args...)},
+				},
+			},
+			Status: core_v1.PodStatus{Phase: phase},
+		}
+	}
+	pods := []core_v1.Pod{
+		daprPod("orders-0", "orders", core_v1.PodPending),
+		daprPod("checkout-1", "checkout", core_v1.PodRunning),
+		daprPod("orders-1", "orders", core_v1.PodRunning, "--dapr-http-port", "3501", "--metrics-port", "invalid"),
+		daprPod("orders-2", "orders", core_v1.PodRunning),
+	}
+
+	t.Run("first running pod of the app", func(t *testing.T) {
+		pod, err := findSidecarPod(pods, "orders", "")
+		require.NoError(t, err)
+		assert.Equal(t, "orders-1", pod.Name)
+		assert.Equal(t, []SidecarPort{
+			{Name: "http", RemotePort: 3501},
+			{Name: "grpc", RemotePort: 50001},
+			{Name: "metrics", RemotePort: 9090},
+		}, sidecarPorts(pod))
+	})
+
+	t.Run("pod name", func(t *testing.T) {
+		pod, err := findSidecarPod(pods, "orders", "orders-2")
+		require.NoError(t, err)
+		assert.Equal(t, "orders-2", pod.Name)
+
+		_, err = findSidecarPod(pods, "orders", "checkout-1")
+		assert.EqualError(t, err, "no running pod checkout-1 with the sidecar of app orders found")
+	})
+
+	t.Run("unknown app", func(t *testing.T) {
+		_, err := findSidecarPod(pods, "payments", "")
+		assert.EqualError(t, err, "no running pod with the sidecar of app payments found")
+	})
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"fmt"
+)
+
+// CheckClusterReachable checks whether the cluster of the current kubeconfig context can be reached.
+func CheckClusterReachable() (string, error) {
+	config, client, err := GetKubeConfigClient()
+	if err != nil {
+		return "", fmt.Errorf("cannot load kubeconfig: %w", err)
+	}
+	version, err := client.Discovery().ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("cannot reach cluster at %s: %w", config.Host, err)
+	}
+	return fmt.Sprintf("cluster at %s is reachable, Kubernetes version %s", config.Host, version.GitVersion), nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/utils"
+)
+
+// redactedValue replaces the keys of the trust chain in the Helm values printed by --dry-run.
+const redactedValue = "<redacted>"
+
+// installActions returns the actions of installing the given version of Dapr, as printed by `dapr init -k --dry-run`.
+func installActions(config InitConfiguration, version string) ([]string, error) {
+	values, err := chartValues(config)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := []string{"create namespace " + config.Namespace}
+	if config.ChartSource.Custom() {
+		actions = append(actions, "install the CRDs of the chart")
+	} else {
+		actions = append(actions, applyCRDsActions(fmt.Sprintf("v%s", version))...)
+	}
+	return append(actions, fmt.Sprintf("install chart %s version %s from %s as release %s in namespace %s with values %s",
+		daprReleaseName, chartVersion(version), config.ChartSource, daprReleaseName, config.Namespace, b)), nil
+}
+
+// upgradeActions returns the actions of upgrading the release of Dapr in namespace, as printed by
+// `dapr upgrade -k --dry-run`. The keys of the trust chain in values are redacted.
+func upgradeActions(conf UpgradeConfig, namespace string, values map[string]interface{}, downgrade bool) ([]string, error) {
+	b, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := []string{}
+	switch {
+	case downgrade:
+	case conf.ChartSource.Custom():
+		actions = append(actions, "apply the CRDs of the chart")
+	default:
+		actions = append(actions, applyCRDsActions(fmt.Sprintf("v%s", conf.RuntimeVersion))...)
+	}
+	return append(actions, fmt.Sprintf("upgrade release %s in namespace %s to chart version %s from %s with values %s",
+		daprReleaseName, namespace, chartVersion(conf.RuntimeVersion), conf.ChartSource, b)), nil
+}
+
+// uninstallActions returns the actions of removing Dapr with conf, as printed by `dapr uninstall -k --dry-run`.
+// resources are the Dapr custom resources that remain in the cluster.
+func uninstallActions(conf UninstallConfig, resources []DaprResource) []string {
+	actions := []string{}
+	if conf.BackupDir != "" && len(resources) > 0 {
+		actions = append(actions, fmt.Sprintf("export %d Dapr resources to %s", len(resources), conf.BackupDir))
+	}
+	actions = append(actions, fmt.Sprintf("uninstall release %s from namespace %s", daprReleaseName, conf.Namespace))
+	if conf.DeleteCRDs {
+		for _, crd := range crdsFullResources {
+			actions = append(actions, "run kubectl delete crd "+crd)
+		}
+	}
+	if conf.DeleteNamespace {
+		actions = append(actions, "delete namespace "+conf.Namespace)
+	}
+	for _, r := range resources {
+		if fate := resourceFate(conf, r); fate == orphanedFate {
+			actions = append(actions, fmt.Sprintf("orphan %s", r))
+		} else {
+			actions = append(actions, fmt.Sprintf("delete %s %s", r, strings.TrimPrefix(fate, "deleted ")))
+		}
+	}
+	return actions
+}
+
+// applyCRDsActions returns the actions of applyCRDs.
+func applyCRDsActions(version string) []string {
+	actions := []string{}
+	for _, crd := range crds {
+		actions = append(actions, "run kubectl apply -f "+crdURL(version, crd))
+	}
+	return actions
+}
+
+func helmRepoURL() string {
+	return utils.GetEnv("DAPR_HELM_REPO_URL", daprHelmRepo)
+}
+
+func printDryRunActions(actions []string) {
+	for _, action := range actions {
+		print.DryRunStatusEvent(os.Stdout, "Would %s", action)
+	}
+}
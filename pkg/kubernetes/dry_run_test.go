@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallActions(t *testing.T) {
+	actions, err := installActions(InitConfiguration{
+		Namespace:  "dapr-system",
+		EnableMTLS: true,
+		Args:       []string{"dapr_operator.replicaCount=2"},
+	}, "1.9.0")
+	require.NoError(t, err)
+
+	require.Len(t, actions, len(crds)+2)
+	assert.Equal(t, "create namespace dapr-system", actions[0])
+	assert.Equal(t, "run kubectl apply -f https://raw.githubusercontent.com/dapr/dapr/v1.9.0/charts/dapr/crds/components.yaml", actions[1])
+	assert.Equal(t, `install chart dapr version 1.9.0 from https://dapr.github.io/helm-charts as release dapr in namespace dapr-system with values `+
+		`{"dapr_operator":{"replicaCount":2},"global":{"ha":{"enabled":false},"mtls":{"enabled":true}}}`, actions[len(actions)-1])
+
+	actions, err = installActions(InitConfiguration{
+		Namespace:   "dapr-system",
+		ChartSource: ChartSource{Chart: "oci://registry.example.com/charts/dapr"},
+	}, "1.9.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"create namespace dapr-system",
+		"install the CRDs of the chart",
+		`install chart dapr version 1.9.0 from oci://registry.example.com/charts/dapr as release dapr in namespace dapr-system with values ` +
+			`{"global":{"ha":{"enabled":false},"mtls":{"enabled":false}}}`,
+	}, actions, "the CRDs of a custom chart are installed from the chart")
+}
+
+func TestUpgradeActions(t *testing.T) {
+	values := map[string]interface{}{"global": map[string]interface{}{"ha": map[string]interface{}{"enabled": true}}}
+
+	t.Run("upgrade applies the CRDs", func(t *testing.T) {
+		actions, err := upgradeActions(UpgradeConfig{RuntimeVersion: "1.9.0"}, "dapr-system", values, false)
+		require.NoError(t, err)
+		require.Len(t, actions, len(crds)+1)
+		assert.Equal(t, `upgrade release dapr in namespace dapr-system to chart version 1.9.0 from https://dapr.github.io/helm-charts with values {"global":{"ha":{"enabled":true}}}`, actions[len(crds)])
+	})
+
+	t.Run("downgrade skips the CRDs", func(t *testing.T) {
+		actions, err := upgradeActions(UpgradeConfig{RuntimeVersion: "1.8.0"}, "dapr-system", values, true)
+		require.NoError(t, err)
+		assert.Len(t, actions, 1)
+	})
+
+	t.Run("custom chart", func(t *testing.T) {
+		conf := UpgradeConfig{RuntimeVersion: "1.9.0", ChartSource: ChartSource{Chart: "oci://registry.example.com/dapr/dapr"}}
+		actions, err := upgradeActions(conf, "dapr-system", values, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"apply the CRDs of the chart",
+			`upgrade release dapr in namespace dapr-system to chart version 1.9.0 from oci://registry.example.com/dapr/dapr with values {"global":{"ha":{"enabled":true}}}`,
+		}, actions)
+	})
+}
+
+func TestUninstallActions(t *testing.T) {
+	conf := UninstallConfig{Namespace: "dapr-system"}
+	assert.Equal(t, []string{"uninstall release dapr from namespace dapr-system"}, uninstallActions(conf, nil))
+
+	conf.DeleteCRDs = true
+	actions := uninstallActions(conf, nil)
+	require.Len(t, actions, len(crdsFullResources)+1)
+	assert.Equal(t, "run kubectl delete crd components.dapr.io", actions[1])
+
+	resources := []DaprResource{
+		{Kind: "Component", Namespace: "default", Name: "statestore"},
+		{Kind: "Configuration", Namespace: "dapr-system", Name: "tracing"},
+	}
+	conf = UninstallConfig{Namespace: "dapr-system", DeleteNamespace: true, BackupDir: "backup"}
+	assert.Equal(t, []string{
+		"export 2 Dapr resources to backup",
+		"uninstall release dapr from namespace dapr-system",
+		"delete namespace dapr-system",
+		"orphan Component default/statestore",
+		"delete Configuration dapr-system/tracing with its namespace",
+	}, uninstallActions(conf, resources))
+}
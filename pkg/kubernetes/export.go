@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	helm "helm.sh/helm/v3/pkg/action"
+	"sigs.k8s.io/yaml"
+)
+
+// defaultChartRegistry is the image registry used by the Dapr Helm chart when global.registry is not set.
+const defaultChartRegistry = "docker.io/daprio"
+
+// ExportedValues are the Helm values of the Dapr control plane running in a cluster.
+type ExportedValues struct {
+	Namespace string
+	Version   string
+	Values    map[string]interface{}
+	// Omitted lists the values left out of the export because they hold secrets.
+	Omitted []string
+}
+
+// ExportValues reads the Dapr control plane running in the cluster and returns the Helm values to install it with.
+// The values given when Dapr was installed are kept, and the values the CLI sets from its flags are
+// derived from the running deployment. The certificates and keys of the trust chain are not exported.
+func ExportValues() (*ExportedValues, error) {
+	status, err := GetDaprResourcesStatus()
+	if err != nil {
+		return nil, err
+	}
+	namespace := status[0].Namespace
+
+	helmConf, err := helmConfig(namespace)
+	if err != nil {
+		return nil, err
+	}
+	release, err := GetDaprHelmChartName(helmConf)
+	if err != nil {
+		return nil, err
+	}
+	if release == "" {
+		return nil, errors.New("dapr was not installed with Helm or the Dapr CLI and its values cannot be exported")
+	}
+	userValues, err := helm.NewGetValues(helmConf).Run(release)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the values of release %s: %w", release, err)
+	}
+
+	mtls, err := IsMTLSEnabled()
+	if err != nil {
+		return nil, err
+	}
+	registry, err := operatorImageRegistry(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	values, omitted := exportChartValues(userValues, highAvailabilityEnabled(status), mtls, registry)
+	return &ExportedValues{
+		Namespace: namespace,
+		Version:   GetDaprVersion(status),
+		Values:    values,
+		Omitted:   omitted,
+	}, nil
+}
+
+// YAML returns the values as a Helm values file.
+func (e *ExportedValues) YAML() ([]byte, error) {
+	b, err := yaml.Marshal(e.Values)
+	if err != nil {
+		return nil, err
+	}
+	header := fmt.Sprintf("# Helm values of the Dapr control plane version %s in namespace %s.\n", e.Version, e.Namespace)
+	return append([]byte(header), b...), nil
+}
+
+// exportChartValues merges the user supplied values of a release with the values derived from the running control plane.
+// It returns the merged values and the dotted paths of the values that were omitted because they hold secrets.
+func exportChartValues(userValues map[string]interface{}, haMode, mtls bool, registry string) (map[string]interface{}, []string) {
+	values := map[string]interface{}{}
+	mergeValues(values, userValues)
+
+	global := map[string]interface{}{
+		"ha":   map[string]interface{}{"enabled": haMode},
+		"mtls": map[string]interface{}{"enabled": mtls},
+	}
+	if registry != "" && registry != defaultChartRegistry {
+		global["registry"] = registry
+	}
+	mergeValues(values, map[string]interface{}{"global": global})
+
+	var omitted []string
+	if sentry, ok := values["dapr_sentry"].(map[string]interface{}); ok {
+		if _, ok := sentry["tls"]; ok {
+			delete(sentry, "tls")
+			omitted = append(omitted, "dapr_sentry.tls")
+		}
+		if len(sentry) == 0 {
+			delete(values, "dapr_sentry")
+		}
+	}
+	return values, omitted
+}
+
+// operatorImageRegistry returns the registry of the image the Dapr operator runs with.
+func operatorImageRegistry(namespace string) (string, error) {
+	client, err := Client()
+	if err != nil {
+		return "", err
+	}
+	pods, err := ListPods(client, namespace, map[string]string{"app": operatorName})
+	if err != nil {
+		return "", err
+	}
+	if len(pods.Items) == 0 || len(pods.Items[0].Spec.Containers) == 0 {
+		return "", nil
+	}
+	return imageRegistry(pods.Items[0].Spec.Containers[0].Image), nil
+}
+
+// imageRegistry returns the registry part of an image, for example ghcr.io/dapr for ghcr.io/dapr/operator:1.9.0.
+func imageRegistry(image string) string {
+	i := strings.LastIndex(image, "/")
+	if i < 0 {
+		return ""
+	}
+	return image[:i]
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+const (
+	// IssueNotInjected, IssueCrashLooping and IssueCreateFailed are the issues reported by InjectionIssues.
+	IssueNotInjected  = "sidecar not injected"
+	IssueCrashLooping = "sidecar crash-looping"
+	IssueCreateFailed = "pod creation failed"
+
+	// injectorWebhookName is the name of the mutating webhook of the Dapr sidecar injector.
+	injectorWebhookName = "sidecar-injector.dapr.io"
+	crashLoopBackOff    = "CrashLoopBackOff"
+)
+
+// InjectionIssue is a pod annotated for Dapr whose sidecar is missing or failing, or a workload whose pods the
+// sidecar injector prevents from being created.
+type InjectionIssue struct {
+	Namespace string `csv:"NAMESPACE" json:"namespace" yaml:"namespace"`
+	// Object is the pod, or the workload whose pods cannot be created, such as replicaset/orders-5d8f.
+	Object  string `csv:"OBJECT"  json:"object"  yaml:"object"`
+	AppID   string `csv:"APP ID"  json:"appId"   yaml:"appId"`
+	Issue   string `csv:"ISSUE"   json:"issue"   yaml:"issue"`
+	Details string `csv:"DETAILS" json:"details" yaml:"details"`
+}
+
+// InjectionIssues returns the sidecar injection issues in namespace, or in all namespaces if namespace is empty:
+// pods annotated with dapr.io/enabled without a daprd container, pods whose daprd container is crash-looping, and
+// workloads whose pods were rejected by the injector webhook. The errors of the webhook are taken from the events
+// of the pods and their owners. If labelSelector is not empty, only the pods matching it are checked.
+func InjectionIssues(namespace, labelSelector string) ([]InjectionIssue, error) {
+	if _, err := labels.Parse(labelSelector); err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", labelSelector, err)
+	}
+	client, err := Client()
+	if err != nil {
+		return nil, err
+	}
+	return injectionIssues(context.TODO(), client, namespace, labelSelector)
+}
+
+func injectionIssues(ctx context.Context, client k8s.Interface, namespace, labelSelector string) ([]InjectionIssue, error) {
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, meta_v1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	events, err := client.CoreV1().Events(namespace).List(ctx, meta_v1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	webhookErrors := injectorWebhookErrors(events.Items)
+
+	issues := []InjectionIssue{}
+	// lastCreated is the time the latest pod of each owner was created.
+	lastCreated := map[eventObject]time.Time{}
+	for _, pod := range pods.Items {
+		if issue, ok := podInjectionIssue(pod, webhookErrors); ok {
+			issues = append(issues, issue)
+		}
+		for _, owner := range pod.OwnerReferences {
+			object := eventObject{pod.Namespace, strings.ToLower(owner.Kind) + "/" + owner.Name}
+			if pod.CreationTimestamp.Time.After(lastCreated[object]) {
+				lastCreated[object] = pod.CreationTimestamp.Time
+			}
+		}
+	}
+	if labelSelector == "" {
+		// The pods rejected by the webhook do not exist, so their workloads cannot be matched by a selector.
+		for object, webhookErr := range webhookErrors {
+			// A workload that created a pod after the error has recovered from it.
+			if strings.HasPrefix(object.name, "pod/") || lastCreated[object].After(webhookErr.time) {
+				continue
+			}
+			issues = append(issues, InjectionIssue{Namespace: object.namespace, Object: object.name, Issue: IssueCreateFailed, Details: webhookErr.message})
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Namespace != issues[j].Namespace {
+			return issues[i].Namespace < issues[j].Namespace
+		}
+		return issues[i].Object < issues[j].Object
+	})
+	return issues, nil
+}
+
+// podInjectionIssue returns the issue of a pod annotated for Dapr, if it has one.
+func podInjectionIssue(pod core_v1.Pod, webhookErrors map[eventObject]webhookError) (InjectionIssue, bool) {
+	if !strings.EqualFold(pod.Annotations[daprEnabledKey], "true") || pod.DeletionTimestamp != nil {
+		return InjectionIssue{}, false
+	}
+	issue := InjectionIssue{Namespace: pod.Namespace, Object: "pod/" + pod.Name, AppID: pod.Annotations[daprAppIDKey]}
+
+	injected := false
+	for _, c := range pod.Spec.Containers {
+		if c.Name == sidecarContainerName {
+			injected = true
+		}
+	}
+	if !injected {
+		issue.Issue = IssueNotInjected
+		issue.Details = webhookErrors[eventObject{pod.Namespace, "pod/" + pod.Name}].message
+		for _, owner := range pod.OwnerReferences {
+			if issue.Details == "" {
+				issue.Details = webhookErrors[eventObject{pod.Namespace, strings.ToLower(owner.Kind) + "/" + owner.Name}].message
+			}
+		}
+		if issue.Details == "" {
+			issue.Details = "the pod was created while the sidecar injector was unavailable, or before Dapr was installed. Check the injector with dapr status -k and restart the pod"
+		}
+		return issue, true
+	}
+
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name != sidecarContainerName || cs.State.Waiting == nil || cs.State.Waiting.Reason != crashLoopBackOff {
+			continue
+		}
+		issue.Issue = IssueCrashLooping
+		issue.Details = fmt.Sprintf("restarted %d times", cs.RestartCount)
+		if t := cs.LastTerminationState.Terminated; t != nil {
+			issue.Details += fmt.Sprintf(", last exit code %d", t.ExitCode)
+			if msg := strings.TrimSpace(t.Message); msg != "" {
+				issue.Details += ": " + msg
+			} else if t.Reason != "" {
+				issue.Details += " (" + t.Reason + ")"
+			}
+		}
+		return issue, true
+	}
+	return InjectionIssue{}, false
+}
+
+// eventObject is the object of an event, as namespace and kind/name with a lowercase kind.
+type eventObject struct {
+	namespace string
+	name      string
+}
+
+// webhookError is an error of the injector webhook reported by an event.
+type webhookError struct {
+	message string
+	time    time.Time
+}
+
+// injectorWebhookErrors returns the latest error of the injector webhook of each object with events.
+func injectorWebhookErrors(events []core_v1.Event) map[eventObject]webhookError {
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+	errs := map[eventObject]webhookError{}
+	for _, e := range events {
+		if e.Type != core_v1.EventTypeWarning || !strings.Contains(e.Message, injectorWebhookName) {
+			continue
+		}
+		object := eventObject{e.InvolvedObject.Namespace, strings.ToLower(e.InvolvedObject.Kind) + "/" + e.InvolvedObject.Name}
+		errs[object] = webhookError{message: e.Message, time: eventTime(e)}
+	}
+	return errs
+}
+
+// eventTime returns the time an event last occurred.
+func eventTime(e core_v1.Event) time.Time {
+	if !e.LastTimestamp.IsZero() {
+		return e.LastTimestamp.Time
+	}
+	if !e.EventTime.IsZero() {
+		return e.EventTime.Time
+	}
+	return e.FirstTimestamp.Time
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestInjectionIssues(t *testing.T) {
+	now := time.Now()
+	pod := func(name, owner string, injected bool, created time.Time) *core_v1.Pod {
+		p := &core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				Labels:            map[string]string{"app": name},
+				Annotations:       map[string]string{daprEnabledKey: "true", daprAppIDKey: name},
+				CreationTimestamp: meta_v1.NewTime(created),
+				OwnerReferences:   []meta_v1.OwnerReference{{Kind: "ReplicaSet", Name: owner}},
+			},
+			Spec: core_v1.PodSpec{Containers: []core_v1.Container{{Name: "app"}}},
+		}
+		if injected {
+			p.Spec.Containers = append(p.Spec.Containers, core_v1.Container{Name: sidecarContainerName})
+		}
+		return p
+	}
+	event := func(kind, name, message string, at time.Time) *core_v1.Event {
+		return &core_v1.Event{
+			ObjectMeta:     meta_v1.ObjectMeta{Name: name + "-event", Namespace: "default"},
+			InvolvedObject: core_v1.ObjectReference{Kind: kind, Name: name, Namespace: "default"},
+			Type:           core_v1.EventTypeWarning,
+			Message:        message,
+			LastTimestamp:  meta_v1.NewTime(at),
+		}
+	}
+	webhookMessage := `Error creating: Internal error occurred: failed calling webhook "sidecar-injector.dapr.io": connection refused`
+
+	healthy := pod("healthy", "healthy-1", true, now)
+	notInjected := pod("orders", "orders-1", false, now.Add(-time.Hour))
+	notAnnotated := pod("plain", "plain-1", false, now)
+	notAnnotated.Annotations = nil
+	crashing := pod("checkout", "checkout-1", true, now)
+	crashing.Status.ContainerStatuses = []core_v1.ContainerStatus{{
+		Name:                 sidecarContainerName,
+		RestartCount:         4,
+		State:                core_v1.ContainerState{Waiting: &core_v1.ContainerStateWaiting{Reason: crashLoopBackOff}},
+		LastTerminationState: core_v1.ContainerState{Terminated: &core_v1.ContainerStateTerminated{ExitCode: 1, Message: "error loading components"}},
+	}}
+	client := fake.NewSimpleClientset(
+		healthy, notInjected, notAnnotated, crashing,
+		event("ReplicaSet", "orders-1", webhookMessage, now.Add(-2*time.Hour)),
+		event("ReplicaSet", "payments-1", webhookMessage, now),
+		// The replica set created a pod after the error, so it recovered.
+		event("ReplicaSet", "healthy-1", webhookMessage, now.Add(-time.Minute)),
+		event("ReplicaSet", "other-1", "Error creating: pods is forbidden: exceeded quota", now),
+	)
+
+	issues, err := injectionIssues(context.Background(), client, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, []InjectionIssue{
+		{Namespace: "default", Object: "pod/checkout", AppID: "checkout", Issue: IssueCrashLooping, Details: "restarted 4 times, last exit code 1: error loading components"},
+		{Namespace: "default", Object: "pod/orders", AppID: "orders", Issue: IssueNotInjected, Details: webhookMessage},
+		{Namespace: "default", Object: "replicaset/payments-1", Issue: IssueCreateFailed, Details: webhookMessage},
+	}, issues)
+
+	issues, err = injectionIssues(context.Background(), client, "", "app=orders")
+	require.NoError(t, err)
+	require.Len(t, issues, 1, "workloads without pods are not matched by a selector")
+	assert.Equal(t, "pod/orders", issues[0].Object)
+
+	noEvents := fake.NewSimpleClientset(pod("orders", "orders-1", false, now))
+	issues, err = injectionIssues(context.Background(), noEvents, "", "")
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Contains(t, issues[0].Details, "sidecar injector was unavailable")
+}
@@ -24,15 +24,14 @@ import (
 	helm "helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
-	"helm.sh/helm/v3/pkg/cli"
 	v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/helm/pkg/strvals"
+	"sigs.k8s.io/yaml"
 
 	"github.com/dapr/cli/pkg/print"
 	cli_ver "github.com/dapr/cli/pkg/version"
-	"github.com/dapr/cli/utils"
 )
 
 const (
@@ -47,15 +46,37 @@ type InitConfiguration struct {
 	EnableMTLS       bool
 	EnableHA         bool
 	Args             []string
+	ValueFiles       []string
 	Wait             bool
 	Timeout          uint
 	ImageRegistryURI string
+	// ChartSource is where the Dapr Helm chart is pulled from. The CRDs of a custom chart are installed from the
+	// chart rather than from GitHub.
+	ChartSource ChartSource
+	// DryRun prints the namespace, CRDs and Helm release that would be installed without installing them.
+	DryRun bool
 }
 
 // Init deploys the Dapr operator using the supplied runtime version.
 func Init(config InitConfiguration) error {
+	if config.DryRun {
+		version, err := getVersion(config.Version)
+		if err != nil {
+			return err
+		}
+		actions, err := installActions(config, version)
+		if err != nil {
+			return err
+		}
+		printDryRunActions(actions)
+		return nil
+	}
+
 	msg := "Deploying the Dapr control plane to your cluster..."
 
+	start := time.Now()
+	deployStep := print.BeginStep(os.Stdout, "Deploying the control plane")
+	defer deployStep.End(print.Failure)
 	stopSpinning := print.Spinner(os.Stdout, msg)
 	defer stopSpinning(print.Failure)
 	// nolint
@@ -65,7 +86,24 @@ func Init(config InitConfiguration) error {
 	}
 
 	stopSpinning(print.Success)
+	deployStep.End(print.Success)
 
+	if config.Wait {
+		waitStep := print.BeginStep(os.Stdout, "Waiting for the control plane")
+		defer waitStep.End(print.Failure)
+		// The rollout of each component is followed instead of waiting for the Helm release as a whole.
+		_, client, err := GetKubeConfigClient()
+		if err != nil {
+			return err
+		}
+		remaining := time.Duration(config.Timeout)*time.Second - time.Since(start)
+		print.InfoStatusEvent(os.Stdout, "Waiting for the Dapr control plane to be ready...")
+		err = WaitForControlPlane(client, config.Namespace, remaining, os.Stdout)
+		if err != nil {
+			return err
+		}
+		waitStep.End(print.Success)
+	}
 	return nil
 }
 
@@ -85,6 +123,23 @@ func createNamespace(namespace string) error {
 	return nil
 }
 
+// Namespaces returns the names of all namespaces in the cluster.
+func Namespaces() ([]string, error) {
+	client, err := Client()
+	if err != nil {
+		return nil, err
+	}
+	list, err := client.CoreV1().Namespaces().List(context.TODO(), meta_v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list.Items))
+	for _, ns := range list.Items {
+		names = append(names, ns.GetName())
+	}
+	return names, nil
+}
+
 func helmConfig(namespace string) (*helm.Configuration, error) {
 	ac := helm.Configuration{}
 	flags := &genericclioptions.ConfigFlags{
@@ -94,12 +149,13 @@ func helmConfig(namespace string) (*helm.Configuration, error) {
 	return &ac, err
 }
 
+// getVersion returns version, or the latest release of the runtime if it is latest.
 func getVersion(version string) (string, error) {
 	if version == latestVersion {
 		var err error
 		version, err = cli_ver.GetDaprVersion()
 		if err != nil {
-			return "", fmt.Errorf("cannot get the latest release version: %w", err)
+			return "", fmt.Errorf("cannot get the latest release version: '%w'. Try specifying --runtime-version=<desired_version>", err)
 		}
 		version = strings.TrimPrefix(version, "v")
 	}
@@ -122,13 +178,12 @@ func locateChartFile(dirPath string) (string, error) {
 	return filepath.Join(dirPath, files[0].Name()), nil
 }
 
-func daprChart(version string, config *helm.Configuration) (*chart.Chart, error) {
-	pull := helm.NewPullWithOpts(helm.WithConfig(config))
-	pull.RepoURL = utils.GetEnv("DAPR_HELM_REPO_URL", daprHelmRepo)
-	pull.Username = utils.GetEnv("DAPR_HELM_REPO_USERNAME", "")
-	pull.Password = utils.GetEnv("DAPR_HELM_REPO_PASSWORD", "")
-
-	pull.Settings = &cli.EnvSettings{}
+// daprChart pulls the chart of the given version of Dapr from source.
+func daprChart(version string, config *helm.Configuration, source ChartSource) (*chart.Chart, error) {
+	pull, chartRef, err := newPull(config, source)
+	if err != nil {
+		return nil, err
+	}
 
 	if version != latestVersion {
 		pull.Version = chartVersion(version)
@@ -142,9 +197,9 @@ func daprChart(version string, config *helm.Configuration) (*chart.Chart, error)
 
 	pull.DestDir = dir
 
-	_, err = pull.Run(daprReleaseName)
+	_, err = pull.Run(chartRef)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to pull the Dapr chart from %s: %w", source, err)
 	}
 
 	chartPath, err := locateChartFile(dir)
@@ -163,16 +218,54 @@ func chartValues(config InitConfiguration) (map[string]interface{}, error) {
 	if len(config.ImageRegistryURI) != 0 {
 		globalVals = append(globalVals, fmt.Sprintf("global.registry=%s", config.ImageRegistryURI))
 	}
-	globalVals = append(globalVals, config.Args...)
 
 	for _, v := range globalVals {
 		if err := strvals.ParseInto(v, chartVals); err != nil {
 			return nil, err
 		}
 	}
+
+	// Like Helm, values files override the defaults and values set on the command line override both.
+	for _, file := range config.ValueFiles {
+		fileVals, err := readValuesFile(file)
+		if err != nil {
+			return nil, err
+		}
+		mergeValues(chartVals, fileVals)
+	}
+	for _, v := range config.Args {
+		if err := strvals.ParseInto(v, chartVals); err != nil {
+			return nil, err
+		}
+	}
 	return chartVals, nil
 }
 
+func readValuesFile(filePath string) (map[string]interface{}, error) {
+	b, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading values file: %w", err)
+	}
+	vals := map[string]interface{}{}
+	if err = yaml.Unmarshal(b, &vals); err != nil {
+		return nil, fmt.Errorf("error parsing values file %s: %w", filePath, err)
+	}
+	return vals, nil
+}
+
+// mergeValues merges src into dst. Nested tables are merged, other values in src replace those in dst.
+func mergeValues(dst, src map[string]interface{}) {
+	for k, v := range src {
+		srcTable, srcIsTable := v.(map[string]interface{})
+		dstTable, dstIsTable := dst[k].(map[string]interface{})
+		if srcIsTable && dstIsTable {
+			mergeValues(dstTable, srcTable)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
 func install(config InitConfiguration) error {
 	err := createNamespace(config.Namespace)
 	if err != nil {
@@ -184,25 +277,27 @@ func install(config InitConfiguration) error {
 		return err
 	}
 
-	daprChart, err := daprChart(config.Version, helmConf)
+	daprChart, err := daprChart(config.Version, helmConf, config.ChartSource)
 	if err != nil {
 		return err
 	}
 
-	version, err := getVersion(config.Version)
-	if err != nil {
-		return err
-	}
+	// Helm installs the CRDs of a custom chart, which can be used where GitHub is not reachable.
+	if !config.ChartSource.Custom() {
+		version, err := getVersion(config.Version)
+		if err != nil {
+			return err
+		}
 
-	err = applyCRDs(fmt.Sprintf("v%s", version))
-	if err != nil {
-		return err
+		err = applyCRDs(fmt.Sprintf("v%s", version))
+		if err != nil {
+			return err
+		}
 	}
 
 	installClient := helm.NewInstall(helmConf)
 	installClient.ReleaseName = daprReleaseName
 	installClient.Namespace = config.Namespace
-	installClient.Wait = config.Wait
 	installClient.Timeout = time.Duration(config.Timeout) * time.Second
 
 	values, err := chartValues(config)
@@ -210,6 +305,8 @@ func install(config InitConfiguration) error {
 		return err
 	}
 
+	print.DebugStatusEvent(os.Stderr, "Installing chart %s version %s with values %v", daprChart.Name(), daprChart.Metadata.Version, values)
+
 	if _, err = installClient.Run(daprChart, values); err != nil {
 		return err
 	}
@@ -217,6 +314,7 @@ func install(config InitConfiguration) error {
 }
 
 func debugLogf(format string, v ...interface{}) {
+	print.DebugStatusEvent(os.Stderr, format, v...)
 }
 
 func confirmExist(cfg *helm.Configuration) (bool, error) {
@@ -0,0 +1,64 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChartValues(t *testing.T) {
+	dir := t.TempDir()
+	valuesFile := filepath.Join(dir, "values.yaml")
+	err := os.WriteFile(valuesFile, []byte(`global:
+  ha:
+    enabled: true
+  nodeSelector:
+    kubernetes.io/os: linux
+dapr_operator:
+  replicaCount: 2
+`), 0o600)
+	assert.NoError(t, err)
+
+	t.Run("values files override defaults", func(t *testing.T) {
+		vals, err := chartValues(InitConfiguration{EnableMTLS: true, ValueFiles: []string{valuesFile}})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"global": map[string]interface{}{
+				"ha":           map[string]interface{}{"enabled": true},
+				"mtls":         map[string]interface{}{"enabled": true},
+				"nodeSelector": map[string]interface{}{"kubernetes.io/os": "linux"},
+			},
+			"dapr_operator": map[string]interface{}{"replicaCount": float64(2)},
+		}, vals)
+	})
+
+	t.Run("set values override values files", func(t *testing.T) {
+		vals, err := chartValues(InitConfiguration{
+			ValueFiles: []string{valuesFile},
+			Args:       []string{"dapr_operator.replicaCount=3", "global.ha.enabled=false"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"replicaCount": int64(3)}, vals["dapr_operator"])
+		assert.Equal(t, map[string]interface{}{"enabled": false}, vals["global"].(map[string]interface{})["ha"])
+	})
+
+	t.Run("missing values file", func(t *testing.T) {
+		_, err := chartValues(InitConfiguration{ValueFiles: []string{filepath.Join(dir, "missing.yaml")}})
+		assert.ErrorContains(t, err, "error reading values file")
+	})
+}
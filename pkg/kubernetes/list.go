@@ -14,7 +14,14 @@ limitations under the License.
 package kubernetes
 
 import (
+	"context"
+	"fmt"
 	"sort"
+	"strings"
+
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/dapr/cli/pkg/age"
 )
@@ -24,26 +31,38 @@ type ListOutput struct {
 	Namespace string `csv:"NAMESPACE" json:"namespace" yaml:"namespace"`
 	AppID     string `csv:"APP ID"    json:"appId"     yaml:"appId"`
 	AppPort   string `csv:"APP PORT"  json:"appPort"   yaml:"appPort"`
+	Pod       string `csv:"-"         json:"pod"       yaml:"pod"       wide:"POD"` // Only displayed in wide table.
+	Restarts  int32  `csv:"RESTARTS"  json:"restarts"  yaml:"restarts"`
+	Status    string `csv:"-"         json:"status"    yaml:"status"    wide:"STATUS"` // Only displayed in wide table.
 	Age       string `csv:"AGE"       json:"age"       yaml:"age"`
 	Created   string `csv:"CREATED"   json:"created"   yaml:"created"`
 }
 
-// List outputs all the applications.
-func List(namespace string) ([]ListOutput, error) {
+// List outputs all the applications in namespace, or in all namespaces if namespace is empty.
+// If labelSelector is not empty, only the applications of pods matching it, such as app=foo, are listed.
+func List(namespace, labelSelector string) ([]ListOutput, error) {
+	if _, err := labels.Parse(labelSelector); err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", labelSelector, err)
+	}
+
 	client, err := Client()
 	if err != nil {
 		return nil, err
 	}
 
-	podList, err := ListPods(client, namespace, nil)
+	podList, err := client.CoreV1().Pods(namespace).List(context.TODO(), meta_v1.ListOptions{LabelSelector: labelSelector})
 	if err != nil {
 		return nil, err
 	}
+	return listApps(podList.Items), nil
+}
 
+// listApps returns the Dapr applications of the pods with a Dapr sidecar.
+func listApps(pods []core_v1.Pod) []ListOutput {
 	l := []ListOutput{}
-	for _, p := range podList.Items {
+	for _, p := range pods {
 		for _, c := range p.Spec.Containers {
-			if c.Name == "daprd" {
+			if c.Name == sidecarContainerName {
 				lo := ListOutput{}
 				for i, a := range c.Args {
 					if a == "--app-port" {
@@ -54,7 +73,12 @@ func List(namespace string) ([]ListOutput, error) {
 						lo.AppID = id
 					}
 				}
+				for _, cs := range p.Status.ContainerStatuses {
+					lo.Restarts += cs.RestartCount
+				}
+				lo.Status = strings.ToLower(string(p.Status.Phase))
 				lo.Namespace = p.GetNamespace()
+				lo.Pod = p.GetName()
 				lo.Created = p.CreationTimestamp.Format("2006-01-02 15:04.05")
 				lo.Age = age.GetAge(p.CreationTimestamp.Time)
 				l = append(l, lo)
@@ -63,8 +87,8 @@ func List(namespace string) ([]ListOutput, error) {
 	}
 
 	// list sort by namespace.
-	sort.Slice(l, func(i, j int) bool {
+	sort.SliceStable(l, func(i, j int) bool {
 		return l[i].Namespace > l[j].Namespace
 	})
-	return l, nil
+	return l
 }
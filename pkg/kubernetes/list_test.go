@@ -0,0 +1,63 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestListApps(t *testing.T) {
+	daprPod := func(namespace, name, appID string, restarts ...int32) core_v1.Pod {
+		pod := core_v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: core_v1.PodSpec{
+				Containers: []core_v1.Container{
+					{Name: "app"},
+					{Name: "daprd", Args: []string{"--mode", "kubernetes", "--app-id", appID, "--app-port", "3000"}},
+				},
+			},
+		}
+		pod.Status.Phase = core_v1.PodRunning
+		for _, r := range restarts {
+			pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, core_v1.ContainerStatus{RestartCount: r})
+		}
+		return pod
+	}
+	pods := []core_v1.Pod{
+		daprPod("apps", "checkout-1", "checkout", 2, 1),
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "redis", Namespace: "apps"}, Spec: core_v1.PodSpec{Containers: []core_v1.Container{{Name: "redis"}}}},
+		daprPod("default", "orders-1", "orders"),
+	}
+
+	list := listApps(pods)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "default", list[0].Namespace)
+	assert.Equal(t, "orders", list[0].AppID)
+	assert.Equal(t, int32(0), list[0].Restarts)
+	assert.Equal(t, "apps", list[1].Namespace)
+	assert.Equal(t, "checkout", list[1].AppID)
+	assert.Equal(t, "checkout-1", list[1].Pod)
+	assert.Equal(t, "3000", list[1].AppPort)
+	assert.Equal(t, int32(3), list[1].Restarts)
+	assert.Equal(t, "running", list[1].Status)
+}
+
+func TestListInvalidSelector(t *testing.T) {
+	_, err := List("", "app in (")
+	assert.ErrorContains(t, err, `invalid label selector "app in ("`)
+}
@@ -14,73 +14,178 @@ limitations under the License.
 package kubernetes
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"io"
 	"os"
+	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+
+	"github.com/dapr/cli/pkg/print"
 )
 
 const (
 	daprdContainerName    = "daprd"
 	appIDContainerArgName = "--app-id"
+
+	// LogsContainerDaprd selects the logs of the sidecar container.
+	LogsContainerDaprd = "daprd"
+	// LogsContainerApp selects the logs of the app container, the first container of the pod that is not the sidecar.
+	LogsContainerApp = "app"
+
+	maxLogLineSize = 1024 * 1024
 )
 
-// Logs fetches Dapr sidecar logs from Kubernetes.
-func Logs(appID, podName, namespace string) error {
+// LogsOptions are the options of Logs.
+type LogsOptions struct {
+	AppID string
+	// PodName restricts the logs to one pod. The logs of all pods of the app are printed if it is empty.
+	PodName   string
+	Namespace string
+	// Container is LogsContainerDaprd or LogsContainerApp. It defaults to LogsContainerDaprd.
+	Container string
+	Follow    bool
+	// Since only prints the lines newer than the duration, if it is not 0.
+	Since time.Duration
+	// Tail is the number of most recent lines to print of each pod. All lines are printed if it is negative.
+	Tail int64
+	// Previous prints the logs of the previous instance of the container, such as one that crashed.
+	Previous bool
+}
+
+// Logs writes the logs of the pods with the sidecar of an app to w. If the app has several pods, their logs are
+// streamed at the same time and every line is prefixed with the name of its pod.
+func Logs(ctx context.Context, w io.Writer, opts LogsOptions) error {
 	client, err := Client()
 	if err != nil {
 		return err
 	}
+	return streamLogs(ctx, client, w, opts)
+}
 
-	if namespace == "" {
-		namespace = corev1.NamespaceDefault
+func streamLogs(ctx context.Context, client k8s.Interface, w io.Writer, opts LogsOptions) error {
+	if opts.Namespace == "" {
+		opts.Namespace = corev1.NamespaceDefault
+	}
+	if opts.Container == "" {
+		opts.Container = LogsContainerDaprd
+	}
+	if opts.Container != LogsContainerDaprd && opts.Container != LogsContainerApp {
+		return fmt.Errorf("invalid container %q, valid values are: %s, %s", opts.Container, LogsContainerApp, LogsContainerDaprd)
 	}
 
-	pods, err := ListPods(client, namespace, nil)
+	pods, err := client.CoreV1().Pods(opts.Namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return fmt.Errorf("could not get logs %w", err)
 	}
-
-	if podName == "" {
-		// no pod name specified. in case of multiple pods, the first one will be selected.
-		var foundDaprPod bool
-		for _, pod := range pods.Items {
-			if foundDaprPod {
+	appPods := []corev1.Pod{}
+	for _, pod := range pods.Items {
+		if opts.PodName != "" && pod.Name != opts.PodName {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			if container.Name == daprdContainerName && containerArg(container, appIDContainerArgName) == opts.AppID {
+				appPods = append(appPods, pod)
 				break
 			}
-			for _, container := range pod.Spec.Containers {
-				if container.Name == daprdContainerName {
-					// find app ID.
-					for i, arg := range container.Args {
-						if arg == appIDContainerArgName {
-							id := container.Args[i+1]
-							if id == appID {
-								podName = pod.Name
-								foundDaprPod = true
-								break
-							}
-						}
-					}
-				}
-			}
 		}
-		if !foundDaprPod {
-			return fmt.Errorf("could not get logs. Please check app-id (%s) and namespace (%s)", appID, namespace)
+	}
+	if len(appPods) == 0 {
+		if opts.PodName != "" {
+			return fmt.Errorf("could not get logs. Please check app-id (%s), pod-name (%s) and namespace (%s)", opts.AppID, opts.PodName, opts.Namespace)
 		}
+		return fmt.Errorf("could not get logs. Please check app-id (%s) and namespace (%s)", opts.AppID, opts.Namespace)
 	}
 
-	getLogsRequest := client.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{Container: daprdContainerName, Follow: false})
-	logStream, err := getLogsRequest.Stream(context.TODO())
-	if err != nil {
-		return fmt.Errorf("could not get logs. Please check pod-name (%s). Error - %w", podName, err)
+	logOpts := &corev1.PodLogOptions{Follow: opts.Follow, Previous: opts.Previous} // nolint:exhaustivestruct
+	if opts.Since > 0 {
+		seconds := int64(opts.Since.Seconds())
+		if seconds < 1 {
+			seconds = 1
+		}
+		logOpts.SinceSeconds = &seconds
+	}
+	if opts.Tail >= 0 {
+		logOpts.TailLines = &opts.Tail
+	}
+
+	var (
+		lock sync.Mutex
+		wg   sync.WaitGroup
+	)
+	errs := make([]error, len(appPods))
+	for i, pod := range appPods {
+		prefix := ""
+		if len(appPods) > 1 {
+			prefix = "[" + pod.Name + "] "
+		}
+		podLogOpts := *logOpts
+		podLogOpts.Container = logsContainer(pod, opts.Container)
+		wg.Add(1)
+		go func(i int, pod corev1.Pod) {
+			defer wg.Done()
+			errs[i] = copyPodLogs(ctx, client, pod, &podLogOpts, prefix, w, &lock)
+		}(i, pod)
+	}
+	wg.Wait()
+
+	failed := 0
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		failed++
+		if len(appPods) > 1 {
+			print.WarningStatusEvent(os.Stderr, "Could not get the logs of pod %s: %s", appPods[i].Name, err)
+		}
+	}
+	if failed == len(appPods) {
+		if len(appPods) == 1 {
+			return fmt.Errorf("could not get logs. Please check pod-name (%s). Error - %w", appPods[0].Name, errs[0])
+		}
+		return fmt.Errorf("could not get the logs of any pod of app %s", opts.AppID)
 	}
-	defer logStream.Close()
-	_, err = io.Copy(os.Stdout, logStream)
+	return nil
+}
+
+// logsContainer returns the name of the container of a pod selected by LogsOptions.Container.
+func logsContainer(pod corev1.Pod, container string) string {
+	if container == LogsContainerApp {
+		for _, c := range pod.Spec.Containers {
+			if c.Name != daprdContainerName {
+				return c.Name
+			}
+		}
+	}
+	return daprdContainerName
+}
+
+// copyPodLogs writes the lines of the logs of a pod to w, each prefixed with prefix. The lock serializes the lines
+// of the pods that are streamed at the same time.
+func copyPodLogs(ctx context.Context, client k8s.Interface, pod corev1.Pod, opts *corev1.PodLogOptions, prefix string, w io.Writer, lock *sync.Mutex) error {
+	stream, err := client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).Stream(ctx)
 	if err != nil {
-		return fmt.Errorf("could not get logs %w", err)
+		return err
 	}
+	defer stream.Close()
 
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineSize)
+	for scanner.Scan() {
+		lock.Lock()
+		_, err = fmt.Fprintln(w, prefix+scanner.Text())
+		lock.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	if err = scanner.Err(); err != nil && ctx.Err() == nil {
+		return err
+	}
 	return nil
 }
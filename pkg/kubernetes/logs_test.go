@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newLogsTestPod(name, appID string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "app"},
+			{Name: daprdContainerName, Args: []string{"--mode", "kubernetes", "--app-id", appID}},
+		}},
+	}
+}
+
+func TestStreamLogs(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		newLogsTestPod("orders-1", "orders"),
+		newLogsTestPod("orders-2", "orders"),
+		newLogsTestPod("checkout-1", "checkout"),
+	)
+
+	t.Run("single pod is not prefixed", func(t *testing.T) {
+		var out bytes.Buffer
+		err := streamLogs(context.Background(), client, &out, LogsOptions{AppID: "checkout", Tail: -1})
+		require.NoError(t, err)
+		// The fake client returns the same logs for every pod.
+		assert.Equal(t, "fake logs\n", out.String())
+	})
+
+	t.Run("pods of an app are prefixed", func(t *testing.T) {
+		var out bytes.Buffer
+		err := streamLogs(context.Background(), client, &out, LogsOptions{AppID: "orders", Tail: 10, Container: LogsContainerApp})
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		sort.Strings(lines)
+		assert.Equal(t, []string{"[orders-1] fake logs", "[orders-2] fake logs"}, lines)
+	})
+
+	t.Run("pod name", func(t *testing.T) {
+		var out bytes.Buffer
+		err := streamLogs(context.Background(), client, &out, LogsOptions{AppID: "orders", PodName: "orders-2", Tail: -1})
+		require.NoError(t, err)
+		assert.Equal(t, "fake logs\n", out.String())
+
+		err = streamLogs(context.Background(), client, &out, LogsOptions{AppID: "orders", PodName: "checkout-1", Tail: -1})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown app", func(t *testing.T) {
+		err := streamLogs(context.Background(), client, &bytes.Buffer{}, LogsOptions{AppID: "unknown", Tail: -1})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid container", func(t *testing.T) {
+		err := streamLogs(context.Background(), client, &bytes.Buffer{}, LogsOptions{AppID: "orders", Container: "sidecar"})
+		assert.Error(t, err)
+	})
+}
+
+func TestLogsContainer(t *testing.T) {
+	pod := newLogsTestPod("orders-1", "orders")
+	assert.Equal(t, "app", logsContainer(*pod, LogsContainerApp))
+	assert.Equal(t, daprdContainerName, logsContainer(*pod, LogsContainerDaprd))
+}
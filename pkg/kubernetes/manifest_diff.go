@@ -0,0 +1,232 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v2"
+	helm "helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+
+	"github.com/dapr/cli/pkg/print"
+)
+
+// diffContextLines is the number of unchanged lines printed around the changes of a resource.
+const diffContextLines = 3
+
+// manifestSeparator splits the documents of a rendered manifest.
+var manifestSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// manifestDiff is the change of a Kubernetes resource made by an upgrade.
+type manifestDiff struct {
+	// Resource is the kind, and the namespace and name, of the resource.
+	Resource string
+	// Change is added, removed or changed.
+	Change string
+	// Diff is the unified diff of the manifest of the resource. It is empty for secrets, whose content is not printed.
+	Diff string
+}
+
+// manifestResource is the identity of a resource in a rendered manifest.
+type manifestResource struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+}
+
+// splitManifest returns the documents of a rendered manifest by resource, as "Kind namespace/name".
+// Documents that are empty or not a Kubernetes resource are skipped.
+func splitManifest(manifest string) map[string]string {
+	docs := map[string]string{}
+	for _, doc := range manifestSeparator.Split(manifest, -1) {
+		var r manifestResource
+		if err := yaml.Unmarshal([]byte(doc), &r); err != nil || r.Kind == "" || r.Metadata.Name == "" {
+			continue
+		}
+		name := r.Metadata.Name
+		if r.Metadata.Namespace != "" {
+			name = r.Metadata.Namespace + "/" + name
+		}
+		docs[r.Kind+" "+name] = strings.TrimSpace(stripSourceComments(doc)) + "\n"
+	}
+	return docs
+}
+
+// stripSourceComments removes the "# Source:" comments Helm adds to rendered templates, so that moving a resource
+// to another template is not a change.
+func stripSourceComments(doc string) string {
+	lines := strings.Split(doc, "\n")
+	kept := lines[:0]
+	for _, l := range lines {
+		if !strings.HasPrefix(l, "# Source: ") {
+			kept = append(kept, l)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// crdManifest returns the CRDs of c as a rendered manifest.
+func crdManifest(c *chart.Chart) string {
+	var b strings.Builder
+	for _, crd := range c.CRDObjects() {
+		b.WriteString("---\n")
+		b.Write(crd.File.Data)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// diffManifests returns the changes between the resources of current and target, sorted by resource.
+func diffManifests(current, target map[string]string) ([]manifestDiff, error) {
+	resources := map[string]struct{}{}
+	for r := range current {
+		resources[r] = struct{}{}
+	}
+	for r := range target {
+		resources[r] = struct{}{}
+	}
+	names := make([]string, 0, len(resources))
+	for r := range resources {
+		names = append(names, r)
+	}
+	sort.Strings(names)
+
+	diffs := []manifestDiff{}
+	for _, r := range names {
+		from, inCurrent := current[r]
+		to, inTarget := target[r]
+		d := manifestDiff{Resource: r, Change: "changed"}
+		switch {
+		case !inCurrent:
+			d.Change = "added"
+		case !inTarget:
+			d.Change = "removed"
+		case from == to:
+			continue
+		}
+		if !strings.HasPrefix(r, "Secret ") {
+			diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(from),
+				B:        difflib.SplitLines(to),
+				FromFile: "current",
+				ToFile:   "upgraded",
+				Context:  diffContextLines,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error computing the changes of %s: %w", r, err)
+			}
+			d.Diff = diff
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs, nil
+}
+
+// upgradeDiff renders the release upgraded to target and returns its changes to the installed release.
+// The CRDs are compared with those of the installed chart, unless they are skipped by a downgrade.
+func upgradeDiff(helmConf *helm.Configuration, upgradeClient *helm.Upgrade, target *chart.Chart, vals map[string]interface{}, downgrade bool) ([]manifestDiff, error) {
+	releaseName, err := GetDaprHelmChartName(helmConf)
+	if err != nil {
+		return nil, err
+	}
+	current, err := helm.NewGet(helmConf).Run(releaseName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting release %s: %w", releaseName, err)
+	}
+
+	upgradeClient.DryRun = true
+	upgraded, err := upgradeClient.Run(releaseName, target, vals)
+	if err != nil {
+		return nil, fmt.Errorf("error rendering the upgraded release: %w", err)
+	}
+
+	currentManifest, upgradedManifest := current.Manifest, upgraded.Manifest
+	if current.Chart != nil && !downgrade {
+		currentManifest += "\n" + crdManifest(current.Chart)
+		upgradedManifest += "\n" + crdManifest(target)
+	}
+	return diffManifests(splitManifest(currentManifest), splitManifest(upgradedManifest))
+}
+
+// printManifestDiffs prints diffs, with added lines in green and removed lines in red unless colors are disabled.
+// Structured output formats get a dry-run event per changed resource.
+func printManifestDiffs(w io.Writer, diffs []manifestDiff) {
+	if len(diffs) == 0 {
+		print.DryRunStatusEvent(w, "No Kubernetes resource would change")
+		return
+	}
+	if !print.GetRenderer().Interactive() {
+		for _, d := range diffs {
+			print.DryRunStatusEvent(w, "Would %s %s\n%s", changeVerb(d.Change), d.Resource, d.Diff)
+		}
+		return
+	}
+
+	print.DryRunStatusEvent(w, "Changes to the Kubernetes resources (%d):", len(diffs))
+	for _, d := range diffs {
+		fmt.Fprintf(w, "\n%s (%s)\n", print.WhiteBold(d.Resource), d.Change)
+		if d.Diff == "" {
+			fmt.Fprintln(w, "  (content hidden)")
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(d.Diff, "\n"), "\n") {
+			switch {
+			case strings.HasPrefix(line, "--- current"), strings.HasPrefix(line, "+++ upgraded"):
+				fmt.Fprintln(w, line)
+			case strings.HasPrefix(line, "+"):
+				fmt.Fprintln(w, print.Green(line))
+			case strings.HasPrefix(line, "-"):
+				fmt.Fprintln(w, print.Red(line))
+			case strings.HasPrefix(line, "@@"):
+				fmt.Fprintln(w, print.Blue(line))
+			default:
+				fmt.Fprintln(w, line)
+			}
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+func changeVerb(change string) string {
+	switch change {
+	case "added":
+		return "add"
+	case "removed":
+		return "remove"
+	default:
+		return "change"
+	}
+}
+
+// printUpgradeDiff prints the changes of the upgrade, or a warning if they cannot be rendered.
+func printUpgradeDiff(helmConf *helm.Configuration, upgradeClient *helm.Upgrade, version string, source ChartSource, vals map[string]interface{}, downgrade bool) {
+	target, err := daprChart(version, helmConf, source)
+	if err == nil {
+		var diffs []manifestDiff
+		if diffs, err = upgradeDiff(helmConf, upgradeClient, target, vals, downgrade); err == nil {
+			printManifestDiffs(os.Stdout, diffs)
+			return
+		}
+	}
+	print.WarningStatusEvent(os.Stdout, "Could not render the changes of the upgrade: %s", err)
+}
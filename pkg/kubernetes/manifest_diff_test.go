@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const currentManifest = `---
+# Source: dapr/charts/dapr_operator/templates/dapr_operator_deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dapr-operator
+  namespace: dapr-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: dapr-operator
+        image: docker.io/daprio/operator:1.8.0
+---
+# Source: dapr/charts/dapr_sentry/templates/dapr_sentry_secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: dapr-trust-bundle
+  namespace: dapr-system
+data:
+  ca.crt: Y3VycmVudA==
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: dashboard-reader
+  namespace: dapr-system
+`
+
+const upgradedManifest = `---
+# Source: dapr/charts/dapr_operator/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dapr-operator
+  namespace: dapr-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: dapr-operator
+        image: docker.io/daprio/operator:1.9.0
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: dapr-trust-bundle
+  namespace: dapr-system
+data:
+  ca.crt: PHJlZGFjdGVkPg==
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: resiliencies.dapr.io
+`
+
+func TestSplitManifest(t *testing.T) {
+	docs := splitManifest(currentManifest + "---\n# empty document\n")
+
+	assert.Len(t, docs, 3)
+	assert.Contains(t, docs, "Deployment dapr-system/dapr-operator")
+	assert.Contains(t, docs, "Secret dapr-system/dapr-trust-bundle")
+	assert.NotContains(t, docs["Deployment dapr-system/dapr-operator"], "# Source:")
+}
+
+func TestDiffManifests(t *testing.T) {
+	diffs, err := diffManifests(splitManifest(currentManifest), splitManifest(upgradedManifest))
+	require.NoError(t, err)
+
+	require.Len(t, diffs, 4)
+	assert.Equal(t, "CustomResourceDefinition resiliencies.dapr.io", diffs[0].Resource)
+	assert.Equal(t, "added", diffs[0].Change)
+	assert.Contains(t, diffs[0].Diff, "+kind: CustomResourceDefinition")
+
+	assert.Equal(t, "Deployment dapr-system/dapr-operator", diffs[1].Resource)
+	assert.Equal(t, "changed", diffs[1].Change)
+	assert.Contains(t, diffs[1].Diff, "-        image: docker.io/daprio/operator:1.8.0\n+        image: docker.io/daprio/operator:1.9.0\n")
+	assert.NotContains(t, diffs[1].Diff, "Source", "moving a resource to another template is not a change")
+
+	assert.Equal(t, manifestDiff{Resource: "Secret dapr-system/dapr-trust-bundle", Change: "changed"}, diffs[2], "the content of secrets is not printed")
+	assert.Equal(t, "removed", diffs[3].Change)
+
+	diffs, err = diffManifests(splitManifest(currentManifest), splitManifest(currentManifest))
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+}
+
+func TestPrintManifestDiffs(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	t.Cleanup(func() { color.NoColor = noColor })
+
+	diffs, err := diffManifests(splitManifest(currentManifest), splitManifest(upgradedManifest))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	printManifestDiffs(&buf, diffs)
+	out := buf.String()
+	assert.Contains(t, out, "Changes to the Kubernetes resources (4):")
+	assert.Contains(t, out, "Deployment dapr-system/dapr-operator (changed)\n--- current\n+++ upgraded\n")
+	assert.Contains(t, out, "Secret dapr-system/dapr-trust-bundle (changed)\n  (content hidden)\n")
+
+	buf.Reset()
+	printManifestDiffs(&buf, nil)
+	assert.Contains(t, buf.String(), "No Kubernetes resource would change")
+}
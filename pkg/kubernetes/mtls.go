@@ -26,17 +26,36 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
 
 	"github.com/dapr/cli/pkg/print"
 	"github.com/dapr/dapr/pkg/apis/configuration/v1alpha1"
 )
 
 const (
-	systemConfigName         = "daprsystem"
-	trustBundleSecretName    = "dapr-trust-bundle" // nolint:gosec
-	warningDaysForCertExpiry = 30                  // in days.
+	systemConfigName      = "daprsystem"
+	trustBundleSecretName = "dapr-trust-bundle" // nolint:gosec
+	// DefaultTrustAnchorsSecretName is the name of the Secret that ExportTrustAnchorsSecret exports the root certificate to.
+	DefaultTrustAnchorsSecretName = "dapr-trust-anchors" // nolint:gosec
+	trustAnchorsKey               = "ca.crt"
+	// DefaultCertExpiryWarningDays is the number of days before the expiry of the root certificate
+	// from which Kubernetes commands warn about it.
+	DefaultCertExpiryWarningDays = 30
 )
 
+var certExpiryWarningDays = DefaultCertExpiryWarningDays
+
+// CertExpiryStatus describes how close the root certificate of a cluster is to its expiry.
+type CertExpiryStatus struct {
+	Expiry         time.Time `json:"expiry"`
+	HoursRemaining int       `json:"hoursRemaining"`
+	DaysRemaining  int       `json:"daysRemaining"`
+	WarningDays    int       `json:"warningDays"`
+	Expired        bool      `json:"expired"`
+	ExpiringSoon   bool      `json:"expiringSoon"`
+}
+
 func IsMTLSEnabled() (bool, error) {
 	c, err := getSystemConfig()
 	if err != nil {
@@ -108,30 +127,128 @@ func ExportTrustChain(outputDir string) error {
 	return nil
 }
 
-// Check and warn if cert expiry is less than `warningDaysForCertExpiry` days.
+// ExportTrustAnchorsSecret returns a Secret named name in namespace that holds the root certificate of the trust
+// chain of the cluster as ca.crt, for workloads outside the Dapr system namespace that must trust the certificates
+// of the sidecars. The issuer certificate and key are not exported.
+// If apply is set, the Secret is created in the cluster, or updated if it exists.
+func ExportTrustAnchorsSecret(name, namespace string, apply bool) (*corev1.Secret, error) {
+	source, err := getTrustChainSecret()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := newTrustAnchorsSecret(source, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !apply {
+		return secret, nil
+	}
+	_, client, err := GetKubeConfigClient()
+	if err != nil {
+		return nil, err
+	}
+	return secret, applySecret(context.Background(), client, secret)
+}
+
+// MarshalSecret returns the manifest of a Secret, ready to be applied with kubectl.
+func MarshalSecret(secret *corev1.Secret) ([]byte, error) {
+	return yaml.Marshal(secret)
+}
+
+func newTrustAnchorsSecret(source *corev1.Secret, name, namespace string) (*corev1.Secret, error) {
+	ca, ok := source.Data[trustAnchorsKey]
+	if !ok || len(ca) == 0 {
+		return nil, errors.New("root certificate not loaded yet, please try again in few minutes")
+	}
+	if namespace == "" {
+		namespace = corev1.NamespaceDefault
+	}
+	return &corev1.Secret{ // nolint:exhaustivestruct
+		TypeMeta: meta_v1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: meta_v1.ObjectMeta{ // nolint:exhaustivestruct
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{trustAnchorsKey: ca},
+	}, nil
+}
+
+// applySecret creates the Secret, or replaces the data of the existing Secret with the same name.
+func applySecret(ctx context.Context, client k8s.Interface, secret *corev1.Secret) error {
+	secrets := client.CoreV1().Secrets(secret.Namespace)
+	existing, err := secrets.Get(ctx, secret.Name, meta_v1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = secrets.Create(ctx, secret, meta_v1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	existing.Data = secret.Data
+	_, err = secrets.Update(ctx, existing, meta_v1.UpdateOptions{})
+	return err
+}
+
+// SetCertExpiryWarningDays sets the number of days before the expiry of the root certificate
+// from which CheckForCertExpiry warns about it. Zero or less disables the warning.
+func SetCertExpiryWarningDays(days int) {
+	certExpiryWarningDays = days
+}
+
+// Check and warn if cert expiry is within the configured number of warning days.
 func CheckForCertExpiry() {
-	expiry, err := Expiry()
+	if certExpiryWarningDays <= 0 {
+		return
+	}
+	status, err := GetCertExpiryStatus(certExpiryWarningDays)
 	// The intent is to warn for certificate expiry, only when it can be fetched.
 	// Do not show any kind of errors with normal command flow.
 	if err != nil {
 		return
 	}
-	daysRemaining := int(expiry.Sub(time.Now().UTC()).Hours() / 24)
-
-	if daysRemaining < warningDaysForCertExpiry {
-		warningMessage := ""
-		switch {
-		case daysRemaining == 0:
-			warningMessage = "Dapr root certificate of your Kubernetes cluster expires today."
-		case daysRemaining < 0:
-			warningMessage = "Dapr root certificate of your Kubernetes cluster has expired."
-		default:
-			warningMessage = fmt.Sprintf("Dapr root certificate of your Kubernetes cluster expires in %v days.", daysRemaining)
-		}
-		helpMessage := "Please see docs.dapr.io for certificate renewal instructions to avoid service interruptions."
-		print.WarningStatusEvent(os.Stdout,
-			fmt.Sprintf("%s Expiry date: %s. \n %s", warningMessage, expiry.Format(time.RFC1123), helpMessage))
+
+	if status.ExpiringSoon || status.Expired {
+		print.WarningStatusEvent(os.Stdout, status.Message())
+	}
+}
+
+// GetCertExpiryStatus returns the expiry status of the root certificate.
+// It is expiring soon if it expires within warningDays days.
+func GetCertExpiryStatus(warningDays int) (*CertExpiryStatus, error) {
+	expiry, err := Expiry()
+	if err != nil {
+		return nil, err
+	}
+	return newCertExpiryStatus(*expiry, time.Now().UTC(), warningDays), nil
+}
+
+func newCertExpiryStatus(expiry, now time.Time, warningDays int) *CertExpiryStatus {
+	remaining := expiry.Sub(now)
+	daysRemaining := int(remaining.Hours() / 24)
+	return &CertExpiryStatus{
+		Expiry:         expiry,
+		HoursRemaining: int(remaining.Hours()),
+		DaysRemaining:  daysRemaining,
+		WarningDays:    warningDays,
+		Expired:        remaining <= 0,
+		ExpiringSoon:   remaining > 0 && daysRemaining < warningDays,
+	}
+}
+
+// Message returns the warning to show for a root certificate that expires soon or has expired.
+func (s *CertExpiryStatus) Message() string {
+	warningMessage := ""
+	switch {
+	case s.Expired:
+		warningMessage = "Dapr root certificate of your Kubernetes cluster has expired."
+	case s.DaysRemaining == 0:
+		warningMessage = "Dapr root certificate of your Kubernetes cluster expires today."
+	default:
+		warningMessage = fmt.Sprintf("Dapr root certificate of your Kubernetes cluster expires in %v days.", s.DaysRemaining)
 	}
+	helpMessage := "Please see docs.dapr.io for certificate renewal instructions to avoid service interruptions."
+	return fmt.Sprintf("%s Expiry date: %s. \n %s", warningMessage, s.Expiry.Format(time.RFC1123), helpMessage)
 }
 
 func getTrustChainSecret() (*corev1.Secret, error) {
@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCertExpiryStatus(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		expiry       time.Time
+		expiringSoon bool
+		expired      bool
+		message      string
+	}{
+		{
+			name:    "valid for longer than the warning days",
+			expiry:  now.Add(90 * 24 * time.Hour),
+			message: "Dapr root certificate of your Kubernetes cluster expires in 90 days.",
+		},
+		{
+			name:         "within the warning days",
+			expiry:       now.Add(10*24*time.Hour + time.Hour),
+			expiringSoon: true,
+			message:      "Dapr root certificate of your Kubernetes cluster expires in 10 days.",
+		},
+		{
+			name:         "expires today",
+			expiry:       now.Add(3 * time.Hour),
+			expiringSoon: true,
+			message:      "Dapr root certificate of your Kubernetes cluster expires today.",
+		},
+		{
+			name:    "expired",
+			expiry:  now.Add(-time.Hour),
+			expired: true,
+			message: "Dapr root certificate of your Kubernetes cluster has expired.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := newCertExpiryStatus(tc.expiry, now, 30)
+			assert.Equal(t, tc.expiringSoon, status.ExpiringSoon)
+			assert.Equal(t, tc.expired, status.Expired)
+			assert.Equal(t, 30, status.WarningDays)
+			assert.Contains(t, status.Message(), tc.message)
+		})
+	}
+}
+
+func TestNewTrustAnchorsSecret(t *testing.T) {
+	source := &corev1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{Name: trustBundleSecretName, Namespace: "dapr-system"},
+		Data: map[string][]byte{
+			"ca.crt":     []byte("root"),
+			"issuer.crt": []byte("issuer"),
+			"issuer.key": []byte("key"),
+		},
+	}
+
+	secret, err := newTrustAnchorsSecret(source, "trust-anchors", "payments")
+	require.NoError(t, err)
+	assert.Equal(t, "trust-anchors", secret.Name)
+	assert.Equal(t, "payments", secret.Namespace)
+	assert.Equal(t, map[string][]byte{"ca.crt": []byte("root")}, secret.Data)
+
+	b, err := MarshalSecret(secret)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "kind: Secret")
+	assert.NotContains(t, string(b), "issuer")
+
+	_, err = newTrustAnchorsSecret(&corev1.Secret{}, "trust-anchors", "payments")
+	assert.Error(t, err)
+}
+
+func TestApplySecret(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	secret := &corev1.Secret{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "trust-anchors", Namespace: "payments"},
+		Data:       map[string][]byte{"ca.crt": []byte("root")},
+	}
+
+	require.NoError(t, applySecret(context.Background(), client, secret))
+	secret.Data = map[string][]byte{"ca.crt": []byte("new root")}
+	require.NoError(t, applySecret(context.Background(), client, secret))
+
+	got, err := client.CoreV1().Secrets("payments").Get(context.Background(), "trust-anchors", meta_v1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new root"), got.Data["ca.crt"])
+}
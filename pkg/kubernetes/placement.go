@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/phayes/freeport"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	core_v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/dapr/cli/pkg/placement"
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/dapr/pkg/credentials"
+	"github.com/dapr/dapr/pkg/sentry/certs"
+	"github.com/dapr/dapr/pkg/sentry/csr"
+)
+
+const (
+	placementServerName = "dapr-placement-server"
+	placementPort       = 50005
+	// placementTLSServerName is the name in the certificate of the placement server.
+	placementTLSServerName = "cluster.local"
+	// placementClientCertTTL is the validity of the certificate the CLI signs to connect to the placement server.
+	placementClientCertTTL = 10 * time.Minute
+)
+
+// DumpPlacement returns the placement table of the Dapr control plane, read through a port-forward to
+// the placement server. In a highly available control plane, the placement table is read from the leader.
+// If mTLS is enabled, the CLI connects with a short-lived certificate signed by the issuer of the cluster.
+func DumpPlacement(ctx context.Context) ([]placement.TableOutput, string, error) {
+	config, client, err := GetKubeConfigClient()
+	if err != nil {
+		return nil, "", err
+	}
+	namespace, err := GetDaprNamespace()
+	if err != nil {
+		return nil, "", err
+	}
+	pods, err := ListPods(client, namespace, map[string]string{"app": placementServerName})
+	if err != nil {
+		return nil, "", err
+	}
+	podNames := []string{}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == core_v1.PodRunning {
+			podNames = append(podNames, pod.Name)
+		}
+	}
+	if len(podNames) == 0 {
+		return nil, "", fmt.Errorf("no running %s pods found in namespace %s", placementServerName, namespace)
+	}
+	sort.Strings(podNames)
+
+	dialOpt := grpc.WithTransportCredentials(insecure.NewCredentials())
+	if mtls, mtlsErr := IsMTLSEnabled(); mtlsErr == nil && mtls {
+		if dialOpt, err = placementClientCredentials(); err != nil {
+			return nil, "", fmt.Errorf("error creating a client certificate for the placement server: %w", err)
+		}
+	}
+
+	for _, podName := range podNames {
+		rows, version, err := dumpPlacementPod(ctx, config, namespace, podName, dialOpt)
+		if errors.Is(err, placement.ErrNotLeader) {
+			print.DebugStatusEvent(os.Stderr, "Placement server %s is not the leader", podName)
+			continue
+		}
+		return rows, version, err
+	}
+	return nil, "", fmt.Errorf("none of the placement servers in namespace %s is the leader. Try again once the leader is elected", namespace)
+}
+
+func dumpPlacementPod(ctx context.Context, config *rest.Config, namespace, podName string, dialOpt grpc.DialOption) ([]placement.TableOutput, string, error) {
+	localPort, err := freeport.GetFreePort()
+	if err != nil {
+		return nil, "", err
+	}
+	portForward, err := NewPortForward(config, namespace, podName, "localhost", localPort, placementPort, false)
+	if err != nil {
+		return nil, "", err
+	}
+	if err = portForward.Init(); err != nil {
+		return nil, "", fmt.Errorf("error forwarding a port to %s: %w", podName, err)
+	}
+	defer portForward.Stop()
+
+	return placement.Dump(ctx, fmt.Sprintf("localhost:%d", localPort), dialOpt)
+}
+
+// placementClientCredentials returns the transport credentials of a workload certificate signed by the issuer
+// in the trust bundle of the cluster, as the placement server only accepts clients with such a certificate.
+func placementClientCredentials() (grpc.DialOption, error) {
+	secret, err := getTrustChainSecret()
+	if err != nil {
+		return nil, err
+	}
+	certChain, err := signPlacementClientCert(secret.Data["ca.crt"], secret.Data["issuer.crt"], secret.Data["issuer.key"])
+	if err != nil {
+		return nil, err
+	}
+	opts, err := credentials.GetClientOptions(certChain, placementTLSServerName)
+	if err != nil {
+		return nil, err
+	}
+	return opts[0], nil
+}
+
+// signPlacementClientCert returns the certificate chain of a new workload certificate signed by the issuer.
+func signPlacementClientCert(rootCert, issuerCert, issuerKey []byte) (*credentials.CertChain, error) {
+	issuerCerts, err := certs.DecodePEMCertificates(issuerCert)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issuer certificate in secret %s: %w", trustBundleSecretName, err)
+	}
+	if len(issuerCerts) == 0 {
+		return nil, fmt.Errorf("no issuer certificate in secret %s", trustBundleSecretName)
+	}
+	signingKey, err := certs.DecodePEMKey(issuerKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issuer key in secret %s: %w", trustBundleSecretName, err)
+	}
+
+	key, err := certs.GenerateECPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	request := &x509.CertificateRequest{SignatureAlgorithm: x509.ECDSAWithSHA256}
+	cert, err := csr.GenerateCSRCertificate(request, placementTLSServerName, nil, issuerCerts[0], &key.PublicKey, signingKey, placementClientCertTTL, time.Minute, false)
+	if err != nil {
+		return nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &credentials.CertChain{
+		RootCA: rootCert,
+		// The issuer certificate completes the chain to the root certificate.
+		Cert: append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}), issuerCert...),
+		Key:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/sentry/ca"
+	"github.com/dapr/dapr/pkg/sentry/certs"
+)
+
+func TestSignPlacementClientCert(t *testing.T) {
+	rootKey, err := certs.GenerateECPrivateKey()
+	require.NoError(t, err)
+	_, rootCert, issuerCert, issuerKey, err := ca.GetNewSelfSignedCertificates(rootKey, time.Hour, time.Minute)
+	require.NoError(t, err)
+
+	t.Run("certificate chains to the root", func(t *testing.T) {
+		certChain, err := signPlacementClientCert(rootCert, issuerCert, issuerKey)
+		require.NoError(t, err)
+		assert.Equal(t, rootCert, certChain.RootCA)
+
+		keyPair, err := tls.X509KeyPair(certChain.Cert, certChain.Key)
+		require.NoError(t, err)
+		require.Len(t, keyPair.Certificate, 2)
+		leaf, err := x509.ParseCertificate(keyPair.Certificate[0])
+		require.NoError(t, err)
+		intermediate, err := x509.ParseCertificate(keyPair.Certificate[1])
+		require.NoError(t, err)
+
+		roots := x509.NewCertPool()
+		require.True(t, roots.AppendCertsFromPEM(rootCert))
+		intermediates := x509.NewCertPool()
+		intermediates.AddCert(intermediate)
+		_, err = leaf.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(placementClientCertTTL), leaf.NotAfter, time.Minute)
+	})
+
+	t.Run("invalid issuer key", func(t *testing.T) {
+		_, err := signPlacementClientCert(rootCert, issuerCert, []byte("invalid"))
+		assert.ErrorContains(t, err, "invalid issuer key in secret dapr-trust-bundle")
+	})
+
+	t.Run("missing issuer certificate", func(t *testing.T) {
+		_, err := signPlacementClientCert(rootCert, nil, issuerKey)
+		assert.EqualError(t, err, "no issuer certificate in secret dapr-trust-bundle")
+	})
+}
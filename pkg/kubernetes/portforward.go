@@ -39,6 +39,8 @@ type PortForward struct {
 	EmitLogs   bool
 	StopCh     chan struct{}
 	ReadyCh    chan struct{}
+
+	doneCh chan struct{}
 }
 
 // NewPortForward returns an instance of PortForward struct that can be used
@@ -91,6 +93,7 @@ func NewPortForward(
 		EmitLogs:   emitLogs,
 		StopCh:     make(chan struct{}, 1),
 		ReadyCh:    make(chan struct{}),
+		doneCh:     make(chan struct{}),
 	}, nil
 }
 
@@ -124,9 +127,10 @@ func (pf *PortForward) run() error {
 // This function blocks until connection is established.
 // Note: Caller should call Stop() to finish the connection.
 func (pf *PortForward) Init() error {
-	failure := make(chan error)
+	failure := make(chan error, 1)
 
 	go func() {
+		defer close(pf.doneCh)
 		if err := pf.run(); err != nil {
 			failure <- err
 		}
@@ -154,3 +158,9 @@ func (pf *PortForward) Stop() {
 func (pf *PortForward) GetStop() <-chan struct{} {
 	return pf.StopCh
 }
+
+// Done returns a channel that is closed once the port forwarding has ended,
+// either because Stop was called or because the connection to the pod was lost, for example when it restarted.
+func (pf *PortForward) Done() <-chan struct{} {
+	return pf.doneCh
+}
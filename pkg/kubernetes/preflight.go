@@ -0,0 +1,253 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	core_v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/dapr/cli/pkg/components"
+	v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+)
+
+const (
+	// daprCRDVersion is the version served by the Dapr CRDs that do not list their versions.
+	daprCRDVersion = "v1alpha1"
+	// sidecarContainerName is the name of the container injected into Dapr enabled pods.
+	sidecarContainerName = "daprd"
+	// maxSidecarMinorSkew is the number of minor versions sidecars may be behind the control plane.
+	maxSidecarMinorSkew = 1
+)
+
+// PreflightReport is the result of the checks run before upgrading the Dapr control plane.
+type PreflightReport struct {
+	// Blockers are problems that make the upgrade fail or break running apps.
+	Blockers []string
+	// Warnings are problems that do not prevent the upgrade.
+	Warnings []string
+}
+
+// UpgradePreflight checks that the Dapr control plane in the cluster can be upgraded to targetVersion, whose chart
+// is pulled from source. It verifies the CRDs, the versions of the sidecars and the specs of the components.
+func UpgradePreflight(targetVersion string, status []StatusOutput, source ChartSource) (*PreflightReport, error) {
+	target, err := version.NewVersion(targetVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid runtime version %s: %w", targetVersion, err)
+	}
+
+	config, err := getConfig()
+	if err != nil {
+		return nil, err
+	}
+	extClient, err := apiextensionsclient.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	client, err := Client()
+	if err != nil {
+		return nil, err
+	}
+	daprClient, err := DaprClient()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PreflightReport{}
+	report.Warnings = append(report.Warnings, checkControlPlaneSkew(target, GetDaprVersion(status))...)
+
+	crds := []apiextensionsv1.CustomResourceDefinition{}
+	for _, name := range crdsFullResources {
+		crd, err := extClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), name, meta_v1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("CRD %s is not installed and will be created", name))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get CRD %s: %w", name, err)
+		}
+		crds = append(crds, *crd)
+	}
+	// The CRDs of the target version are those of its chart. Without them, only the installed CRDs are checked.
+	targetCRDs := map[string]apiextensionsv1.CustomResourceDefinition{}
+	if len(status) > 0 {
+		targetCRDs, err = chartCRDs(targetVersion, status[0].Namespace, source)
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("could not load the CRDs of Dapr %s, checking the stored versions against the installed CRDs only: %s", target, err))
+		}
+	}
+	report.Blockers = append(report.Blockers, checkCRDs(target, crds, targetCRDs)...)
+
+	pods, err := ListPodsInterface(client, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	report.Blockers = append(report.Blockers, checkSidecarSkew(target, pods.Items)...)
+
+	list, err := daprClient.ComponentsV1alpha1().Components(meta_v1.NamespaceAll).List(meta_v1.ListOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to list components: %w", err)
+	}
+	if err == nil {
+		blockers, warnings := checkComponents(list.Items)
+		report.Blockers = append(report.Blockers, blockers...)
+		report.Warnings = append(report.Warnings, warnings...)
+	}
+	return report, nil
+}
+
+// checkControlPlaneSkew warns when the upgrade skips minor versions of the control plane.
+func checkControlPlaneSkew(target *version.Version, currentVersion string) []string {
+	current, err := version.NewVersion(currentVersion)
+	if err != nil {
+		return nil
+	}
+	if minorSkew(target, current) > 1 {
+		return []string{fmt.Sprintf("the upgrade from %s to %s skips minor versions. Upgrading one minor version at a time is recommended", current, target)}
+	}
+	return nil
+}
+
+// chartCRDs returns the CRDs of the chart of targetVersion pulled from source, by name.
+func chartCRDs(targetVersion, namespace string, source ChartSource) (map[string]apiextensionsv1.CustomResourceDefinition, error) {
+	helmConf, err := helmConfig(namespace)
+	if err != nil {
+		return nil, err
+	}
+	c, err := daprChart(targetVersion, helmConf, source)
+	if err != nil {
+		return nil, err
+	}
+	return parseCRDs(crdManifest(c))
+}
+
+// parseCRDs returns the CRDs of a manifest by name.
+func parseCRDs(manifest string) (map[string]apiextensionsv1.CustomResourceDefinition, error) {
+	crds := map[string]apiextensionsv1.CustomResourceDefinition{}
+	for _, doc := range manifestSeparator.Split(manifest, -1) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		var crd apiextensionsv1.CustomResourceDefinition
+		if err := yaml.Unmarshal([]byte(doc), &crd); err != nil {
+			return nil, fmt.Errorf("invalid CRD: %w", err)
+		}
+		if crd.Kind == "CustomResourceDefinition" {
+			crds[crd.Name] = crd
+		}
+	}
+	return crds, nil
+}
+
+// checkCRDs reports Dapr CRDs with objects stored in a version that the CRD of the target version does not serve,
+// such as subscriptions stored as v2alpha1 when the target only serves v1alpha1. The versions served by the target
+// are those of its CRD in targetCRDs, or of the installed CRD if the target has none, as that one is kept.
+// CRDs without versions serve daprCRDVersion.
+func checkCRDs(target *version.Version, crds []apiextensionsv1.CustomResourceDefinition, targetCRDs map[string]apiextensionsv1.CustomResourceDefinition) []string {
+	blockers := []string{}
+	for _, installed := range crds {
+		crd, ok := targetCRDs[installed.Name]
+		if !ok {
+			crd = installed
+		}
+		served := []string{}
+		isServed := map[string]bool{}
+		for _, v := range crd.Spec.Versions {
+			if v.Served {
+				served = append(served, v.Name)
+				isServed[v.Name] = true
+			}
+		}
+		if len(crd.Spec.Versions) == 0 {
+			served = append(served, daprCRDVersion)
+			isServed[daprCRDVersion] = true
+		}
+		for _, stored := range installed.Status.StoredVersions {
+			if !isServed[stored] {
+				blockers = append(blockers, fmt.Sprintf("CRD %s has objects stored as %s, which Dapr %s does not serve. Migrate them to %s before upgrading", installed.Name, stored, target, strings.Join(served, " or ")))
+			}
+		}
+	}
+	return blockers
+}
+
+// checkSidecarSkew reports the pods whose sidecar is more than one minor version behind the target version.
+// Sidecars with a version that is not semantic, such as latest or edge, are not checked.
+func checkSidecarSkew(target *version.Version, pods []core_v1.Pod) []string {
+	blockers := []string{}
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			if container.Name != sidecarContainerName {
+				continue
+			}
+			image := container.Image
+			sidecar, err := version.NewVersion(image[strings.LastIndex(image, ":")+1:])
+			if err != nil {
+				continue
+			}
+			if minorSkew(target, sidecar) > maxSidecarMinorSkew {
+				blockers = append(blockers, fmt.Sprintf("sidecar of pod %s/%s runs Dapr %s, more than %d minor version behind %s. Upgrade one minor version at a time and restart the pod in between", pod.Namespace, pod.Name, sidecar, maxSidecarMinorSkew, target))
+			}
+		}
+	}
+	sort.Strings(blockers)
+	return blockers
+}
+
+// checkComponents reports components that use deprecated metadata fields or an unknown version of their type.
+func checkComponents(list []v1alpha1.Component) ([]string, []string) {
+	blockers := []string{}
+	warnings := []string{}
+	for _, c := range list {
+		known, ok := components.Lookup(c.Spec.Type)
+		if !ok {
+			continue
+		}
+		name := c.Namespace + "/" + c.Name
+		if c.Spec.Version != known.Version {
+			warnings = append(warnings, fmt.Sprintf("component %s uses version %s of %s, expected %s", name, c.Spec.Version, c.Spec.Type, known.Version))
+		}
+		for _, item := range c.Spec.Metadata {
+			for _, field := range known.Metadata {
+				if field.Name == item.Name && field.IsDeprecated() {
+					blockers = append(blockers, fmt.Sprintf("component %s uses the deprecated metadata field %s, %s", name, item.Name, field.DeprecationAdvice()))
+				}
+			}
+		}
+	}
+	return blockers, warnings
+}
+
+// minorSkew returns the number of minor versions a is ahead of b. Versions of different major versions
+// are considered to be far apart.
+func minorSkew(a, b *version.Version) int {
+	as, bs := a.Segments(), b.Segments()
+	if as[0] != bs[0] {
+		if as[0] > bs[0] {
+			return math.MaxInt
+		}
+		return 0
+	}
+	return as[1] - bs[1]
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	core_v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+)
+
+func sidecarPod(name, image string) core_v1.Pod {
+	return core_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: core_v1.PodSpec{
+			Containers: []core_v1.Container{
+				{Name: "app", Image: "myapp:1.0.0"},
+				{Name: "daprd", Image: image},
+			},
+		},
+	}
+}
+
+func TestCheckSidecarSkew(t *testing.T) {
+	target := version.Must(version.NewVersion("1.9.0"))
+	pods := []core_v1.Pod{
+		sidecarPod("current", "docker.io/daprio/daprd:1.9.0"),
+		sidecarPod("one-behind", "docker.io/daprio/daprd:1.8.4"),
+		sidecarPod("two-behind", "ghcr.io/dapr/daprd:1.7.0"),
+		sidecarPod("edge", "docker.io/daprio/daprd:edge"),
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "no-sidecar"}, Spec: core_v1.PodSpec{Containers: []core_v1.Container{{Name: "app", Image: "myapp:0.1.0"}}}},
+	}
+
+	blockers := checkSidecarSkew(target, pods)
+	assert.Equal(t, []string{
+		"sidecar of pod default/two-behind runs Dapr 1.7.0, more than 1 minor version behind 1.9.0. Upgrade one minor version at a time and restart the pod in between",
+	}, blockers)
+}
+
+func TestCheckControlPlaneSkew(t *testing.T) {
+	target := version.Must(version.NewVersion("1.9.0"))
+	assert.Empty(t, checkControlPlaneSkew(target, "1.8.0"))
+	assert.Empty(t, checkControlPlaneSkew(target, "1.10.0"))
+	assert.Empty(t, checkControlPlaneSkew(target, "edge"))
+	assert.Len(t, checkControlPlaneSkew(target, "1.7.1"), 1)
+	assert.Len(t, checkControlPlaneSkew(target, "0.11.3"), 1)
+}
+
+func TestCheckCRDs(t *testing.T) {
+	target := version.Must(version.NewVersion("1.9.0"))
+	crd := func(name string, stored []string, served ...string) apiextensionsv1.CustomResourceDefinition {
+		c := apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: meta_v1.ObjectMeta{Name: name},
+			Status:     apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: stored},
+		}
+		for _, v := range served {
+			c.Spec.Versions = append(c.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{Name: v, Served: true})
+		}
+		return c
+	}
+	crds := []apiextensionsv1.CustomResourceDefinition{
+		crd("components.dapr.io", []string{"v1alpha1"}, "v1alpha1"),
+		crd("subscriptions.dapr.io", []string{"v1alpha1", "v2alpha1"}, "v1alpha1", "v2alpha1"),
+		crd("configurations.dapr.io", []string{"v1alpha1"}),
+		crd("resiliencies.dapr.io", []string{"v1alpha1", "v2alpha1"}, "v1alpha1", "v2alpha1"),
+	}
+
+	assert.Empty(t, checkCRDs(target, crds, nil), "without the target CRDs, the installed CRDs serve their stored versions")
+
+	targetCRDs := map[string]apiextensionsv1.CustomResourceDefinition{
+		"components.dapr.io":    crd("components.dapr.io", nil, "v1alpha1"),
+		"subscriptions.dapr.io": crd("subscriptions.dapr.io", nil, "v1alpha1", "v2alpha1"),
+		"resiliencies.dapr.io":  crd("resiliencies.dapr.io", nil, "v1alpha1"),
+	}
+	assert.Equal(t, []string{
+		"CRD resiliencies.dapr.io has objects stored as v2alpha1, which Dapr 1.9.0 does not serve. Migrate them to v1alpha1 before upgrading",
+	}, checkCRDs(target, crds, targetCRDs), "subscriptions stored as v2alpha1 are served by the target and not blockers")
+}
+
+func TestParseCRDs(t *testing.T) {
+	manifest := `---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: subscriptions.dapr.io
+spec:
+  versions:
+  - name: v1alpha1
+    served: true
+  - name: v2alpha1
+    served: true
+    storage: true
+---
+# comment only
+`
+	crds, err := parseCRDs(manifest)
+	require.NoError(t, err)
+	require.Contains(t, crds, "subscriptions.dapr.io")
+	assert.Len(t, crds["subscriptions.dapr.io"].Spec.Versions, 2)
+	assert.True(t, crds["subscriptions.dapr.io"].Spec.Versions[1].Served)
+}
+
+func TestCheckComponents(t *testing.T) {
+	component := func(name, componentType, componentVersion string, fields ...string) v1alpha1.Component {
+		c := v1alpha1.Component{ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "default"}}
+		c.Spec.Type = componentType
+		c.Spec.Version = componentVersion
+		for _, field := range fields {
+			c.Spec.Metadata = append(c.Spec.Metadata, v1alpha1.MetadataItem{Name: field})
+		}
+		return c
+	}
+	list := []v1alpha1.Component{
+		component("statestore", "state.redis", "v1", "redisHost"),
+		component("pubsub", "pubsub.kafka", "v1", "brokers", "authRequired"),
+		component("old", "state.redis", "v0"),
+		component("custom", "state.custom", "v1", "anything"),
+	}
+
+	blockers, warnings := checkComponents(list)
+	assert.Equal(t, []string{"component default/pubsub uses the deprecated metadata field authRequired, use authType instead"}, blockers)
+	assert.Equal(t, []string{"component default/old uses version v0 of state.redis, expected v1"}, warnings)
+}
@@ -14,15 +14,21 @@ limitations under the License.
 package kubernetes
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"time"
 
 	helm "helm.sh/helm/v3/pkg/action"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "k8s.io/client-go/kubernetes"
 	"k8s.io/helm/pkg/strvals"
 
 	"github.com/dapr/cli/pkg/print"
@@ -99,7 +105,7 @@ func renewCertificate(rootCert, issuerCert, issuerKey []byte, timeout uint) erro
 		return err
 	}
 
-	daprChart, err := daprChart(daprVersion, helmConf)
+	daprChart, err := daprChart(daprVersion, helmConf, ChartSource{})
 	if err != nil {
 		return err
 	}
@@ -182,3 +188,88 @@ func GenerateNewCertificates(validUntil time.Duration, privateKeyFile string) ([
 	}
 	return rootCertPem, issuerCertPem, issuerKeyPem, nil
 }
+
+// restartedAtAnnotation is the pod template annotation set by `kubectl rollout restart`.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// SidecarWorkload is a deployment, statefulset or daemonset whose pods have a Dapr sidecar.
+type SidecarWorkload struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// String returns the workload as <kind>/<name>.
+func (w SidecarWorkload) String() string {
+	return fmt.Sprintf("%s/%s", w.Kind, w.Name)
+}
+
+// ListSidecarWorkloads returns the deployments, statefulsets and daemonsets in all namespaces whose pod template
+// enables the Dapr sidecar, sorted by namespace, kind and name.
+func ListSidecarWorkloads(client k8s.Interface) ([]SidecarWorkload, error) {
+	ctx := context.Background()
+	listOpts := metav1.ListOptions{}
+	workloads := []SidecarWorkload{}
+	add := func(kind string, meta metav1.ObjectMeta, annotations map[string]string) {
+		if enabled, _ := strconv.ParseBool(annotations[daprEnabledKey]); enabled {
+			workloads = append(workloads, SidecarWorkload{Kind: kind, Namespace: meta.Namespace, Name: meta.Name})
+		}
+	}
+
+	deployments, err := client.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, listOpts)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deployments.Items {
+		add(deployment, d.ObjectMeta, d.Spec.Template.Annotations)
+	}
+	statefulSets, err := client.AppsV1().StatefulSets(metav1.NamespaceAll).List(ctx, listOpts)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range statefulSets.Items {
+		add(statefulset, s.ObjectMeta, s.Spec.Template.Annotations)
+	}
+	daemonSets, err := client.AppsV1().DaemonSets(metav1.NamespaceAll).List(ctx, listOpts)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range daemonSets.Items {
+		add(daemonset, d.ObjectMeta, d.Spec.Template.Annotations)
+	}
+
+	sort.SliceStable(workloads, func(i, j int) bool {
+		if workloads[i].Namespace != workloads[j].Namespace {
+			return workloads[i].Namespace < workloads[j].Namespace
+		}
+		if workloads[i].Kind != workloads[j].Kind {
+			return workloads[i].Kind < workloads[j].Kind
+		}
+		return workloads[i].Name < workloads[j].Name
+	})
+	return workloads, nil
+}
+
+// RestartSidecarWorkloads starts a rolling restart of the workloads, like `kubectl rollout restart`, so that their
+// sidecars are injected with the new root certificate. It does not wait for the rollouts to complete.
+func RestartSidecarWorkloads(client k8s.Interface, workloads []SidecarWorkload) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{restartedAtAnnotation: time.Now().Format(time.RFC3339)},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, w := range workloads {
+		print.InfoStatusEvent(os.Stdout, "Restarting %s in namespace %s", w, w.Namespace)
+		if err = patchLiveWorkload(context.Background(), client, w.Kind, w.Namespace, w.Name, data); err != nil {
+			return fmt.Errorf("error restarting %s in namespace %s: %w", w, w.Namespace, err)
+		}
+	}
+	return nil
+}
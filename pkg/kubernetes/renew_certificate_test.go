@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRestartSidecarWorkloads(t *testing.T) {
+	template := func(enabled string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{daprEnabledKey: enabled}}}
+	}
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "shop"},
+			Spec:       appsv1.DeploymentSpec{Template: template("true")},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "shop"},
+			Spec:       appsv1.DeploymentSpec{Template: template("false")},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "ledger", Namespace: "bank"},
+			Spec:       appsv1.StatefulSetSpec{Template: template("true")},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "shop"},
+			Spec:       appsv1.DaemonSetSpec{Template: template("true")},
+		},
+	)
+
+	workloads, err := ListSidecarWorkloads(client)
+	require.NoError(t, err)
+	assert.Equal(t, []SidecarWorkload{
+		{Kind: statefulset, Namespace: "bank", Name: "ledger"},
+		{Kind: daemonset, Namespace: "shop", Name: "agent"},
+		{Kind: deployment, Namespace: "shop", Name: "orders"},
+	}, workloads)
+
+	require.NoError(t, RestartSidecarWorkloads(client, workloads))
+	d, err := client.AppsV1().Deployments("shop").Get(context.Background(), "orders", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, d.Spec.Template.Annotations[restartedAtAnnotation])
+	assert.Equal(t, "true", d.Spec.Template.Annotations[daprEnabledKey])
+	d, err = client.AppsV1().Deployments("shop").Get(context.Background(), "frontend", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, d.Spec.Template.Annotations[restartedAtAnnotation])
+}
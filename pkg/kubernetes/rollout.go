@@ -0,0 +1,254 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8s "k8s.io/client-go/kubernetes"
+
+	"github.com/dapr/cli/pkg/print"
+)
+
+// rolloutPollInterval is the interval at which the rollout of the control plane is checked by `dapr init -k --wait`.
+const rolloutPollInterval = 2 * time.Second
+
+// controlPlaneWorkload is a workload of the control plane whose rollout is followed by `dapr init -k --wait`.
+type controlPlaneWorkload struct {
+	Name string
+	Kind string
+}
+
+// controlPlaneWorkloads are the workloads of the control plane. Workloads that are not part of the installed
+// version, such as the scheduler before Dapr 1.14, are skipped.
+var controlPlaneWorkloads = []controlPlaneWorkload{
+	{Name: "dapr-operator", Kind: deployment},
+	{Name: "dapr-sentry", Kind: deployment},
+	{Name: "dapr-placement-server", Kind: statefulset},
+	{Name: "dapr-sidecar-injector", Kind: deployment},
+	{Name: "dapr-scheduler-server", Kind: statefulset},
+}
+
+// rolloutStatus is the rollout status of a workload of the control plane.
+type rolloutStatus struct {
+	Ready    int32
+	Desired  int32
+	Selector labels.Selector
+}
+
+func (s rolloutStatus) done() bool {
+	return s.Ready >= s.Desired
+}
+
+// getRolloutStatus returns the rollout status of a workload, or a not found error if it does not exist.
+func getRolloutStatus(ctx context.Context, client k8s.Interface, namespace string, w controlPlaneWorkload) (rolloutStatus, error) {
+	switch w.Kind {
+	case deployment:
+		d, err := client.AppsV1().Deployments(namespace).Get(ctx, w.Name, metav1.GetOptions{})
+		if err != nil {
+			return rolloutStatus{}, err
+		}
+		selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+		if err != nil {
+			return rolloutStatus{}, err
+		}
+		desired := int32(1)
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+		ready := d.Status.AvailableReplicas
+		if d.Status.UpdatedReplicas < ready {
+			ready = d.Status.UpdatedReplicas
+		}
+		return rolloutStatus{Ready: ready, Desired: desired, Selector: selector}, nil
+	case statefulset:
+		s, err := client.AppsV1().StatefulSets(namespace).Get(ctx, w.Name, metav1.GetOptions{})
+		if err != nil {
+			return rolloutStatus{}, err
+		}
+		selector, err := metav1.LabelSelectorAsSelector(s.Spec.Selector)
+		if err != nil {
+			return rolloutStatus{}, err
+		}
+		desired := int32(1)
+		if s.Spec.Replicas != nil {
+			desired = *s.Spec.Replicas
+		}
+		return rolloutStatus{Ready: s.Status.ReadyReplicas, Desired: desired, Selector: selector}, nil
+	}
+	return rolloutStatus{}, fmt.Errorf("unsupported kind %s", w.Kind)
+}
+
+// WaitForControlPlane waits until the pods of all workloads of the control plane in namespace are ready, and writes
+// the progress of each workload and the warning events of its pods to w. If the timeout expires, the status and the
+// events of the pods that are not ready are written to w, and an error is returned.
+func WaitForControlPlane(client k8s.Interface, namespace string, timeout time.Duration, w io.Writer) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return waitForControlPlane(ctx, client, namespace, rolloutPollInterval, w)
+}
+
+func waitForControlPlane(ctx context.Context, client k8s.Interface, namespace string, interval time.Duration, w io.Writer) error {
+	pending := []controlPlaneWorkload{}
+	for _, workload := range controlPlaneWorkloads {
+		if _, err := getRolloutStatus(ctx, client, namespace, workload); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		pending = append(pending, workload)
+	}
+
+	progress := map[string]string{}
+	seenEvents := map[string]bool{}
+	statuses := map[string]rolloutStatus{}
+	for {
+		events, err := podEvents(ctx, client, namespace)
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+		remaining := []controlPlaneWorkload{}
+		for _, workload := range pending {
+			status, err := getRolloutStatus(ctx, client, namespace, workload)
+			if err != nil {
+				if ctx.Err() != nil {
+					remaining = append(remaining, workload)
+					continue
+				}
+				return err
+			}
+			statuses[workload.Name] = status
+			if status.done() {
+				print.SuccessStatusEvent(w, "%s is ready (%d/%d pods)", workload.Name, status.Ready, status.Desired)
+				continue
+			}
+			remaining = append(remaining, workload)
+
+			msg := fmt.Sprintf("%s: %d/%d pods ready", workload.Name, status.Ready, status.Desired)
+			if progress[workload.Name] != msg {
+				progress[workload.Name] = msg
+				print.PendingStatusEvent(w, msg)
+			}
+			pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: status.Selector.String()})
+			if err != nil {
+				continue
+			}
+			for _, pod := range pods.Items {
+				for _, e := range events[pod.Name] {
+					key := string(e.UID) + e.Reason + e.Message
+					if e.Type == core_v1.EventTypeWarning && !seenEvents[key] {
+						seenEvents[key] = true
+						print.WarningStatusEvent(w, "%s: pod %s: %s: %s", workload.Name, pod.Name, e.Reason, e.Message)
+					}
+				}
+			}
+		}
+		pending = remaining
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return controlPlaneTimeout(client, namespace, pending, statuses, w)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// controlPlaneTimeout writes the status and the events of the pods of the workloads that are not ready,
+// and returns an error naming them.
+func controlPlaneTimeout(client k8s.Interface, namespace string, pending []controlPlaneWorkload, statuses map[string]rolloutStatus, w io.Writer) error {
+	// The context of the wait has expired, so the details are read with a short timeout of their own.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	events, _ := podEvents(ctx, client, namespace)
+
+	names := make([]string, 0, len(pending))
+	for _, workload := range pending {
+		names = append(names, workload.Name)
+		status := statuses[workload.Name]
+		print.FailureStatusEvent(w, "%s is not ready: %d/%d pods ready", workload.Name, status.Ready, status.Desired)
+		if status.Selector == nil {
+			continue
+		}
+		pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: status.Selector.String()})
+		if err != nil {
+			continue
+		}
+		for _, pod := range pods.Items {
+			if isPodReady(pod) {
+				continue
+			}
+			fmt.Fprintf(w, "  Pod %s: %s\n", pod.Name, podState(pod))
+			for _, e := range events[pod.Name] {
+				fmt.Fprintf(w, "    %s %s: %s\n", e.Type, e.Reason, e.Message)
+			}
+		}
+	}
+	return errors.New("timed out waiting for the Dapr control plane: " + strings.Join(names, ", ") + " not ready")
+}
+
+// podEvents returns the events of the pods in namespace by pod name, oldest first.
+func podEvents(ctx context.Context, client k8s.Interface, namespace string) (map[string][]core_v1.Event, error) {
+	list, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	events := map[string][]core_v1.Event{}
+	for _, e := range list.Items {
+		if e.InvolvedObject.Kind == "Pod" {
+			events[e.InvolvedObject.Name] = append(events[e.InvolvedObject.Name], e)
+		}
+	}
+	for _, e := range events {
+		sort.SliceStable(e, func(i, j int) bool {
+			return e[i].LastTimestamp.Before(&e[j].LastTimestamp)
+		})
+	}
+	return events, nil
+}
+
+func isPodReady(pod core_v1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == core_v1.PodReady {
+			return c.Status == core_v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// podState returns the phase of a pod, with the reason a container of the pod is waiting or has terminated.
+func podState(pod core_v1.Pod) string {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+			return fmt.Sprintf("%s (%s: %s)", pod.Status.Phase, cs.Name, strings.TrimSpace(cs.State.Waiting.Reason+" "+cs.State.Waiting.Message))
+		}
+		if cs.State.Terminated != nil {
+			return fmt.Sprintf("%s (%s: %s, exit code %d)", pod.Status.Phase, cs.Name, cs.State.Terminated.Reason, cs.State.Terminated.ExitCode)
+		}
+	}
+	return string(pod.Status.Phase)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWaitForControlPlane(t *testing.T) {
+	replicas := int32(1)
+	selector := func(app string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}}
+	}
+	objects := func(placementReady int32) *fake.Clientset {
+		return fake.NewSimpleClientset(
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "dapr-operator", Namespace: "dapr-system"},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas, Selector: selector("dapr-operator")},
+				Status:     appsv1.DeploymentStatus{AvailableReplicas: 1, UpdatedReplicas: 1},
+			},
+			&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "dapr-placement-server", Namespace: "dapr-system"},
+				Spec:       appsv1.StatefulSetSpec{Replicas: &replicas, Selector: selector("dapr-placement-server")},
+				Status:     appsv1.StatefulSetStatus{ReadyReplicas: placementReady},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "dapr-placement-server-0", Namespace: "dapr-system", Labels: map[string]string{"app": "dapr-placement-server"}},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodPending,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:  "dapr-placement-server",
+						State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"}},
+					}},
+				},
+			},
+			&corev1.Event{
+				ObjectMeta:     metav1.ObjectMeta{Name: "e1", Namespace: "dapr-system", UID: "e1"},
+				InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "dapr-placement-server-0"},
+				Type:           corev1.EventTypeWarning,
+				Reason:         "Failed",
+				Message:        "Failed to pull image",
+			},
+		)
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		var out bytes.Buffer
+		err := waitForControlPlane(context.Background(), objects(1), "dapr-system", time.Millisecond, &out)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "dapr-operator is ready (1/1 pods)")
+		assert.Contains(t, out.String(), "dapr-placement-server is ready (1/1 pods)")
+		assert.NotContains(t, out.String(), "dapr-sentry")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		var out bytes.Buffer
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := waitForControlPlane(ctx, objects(0), "dapr-system", 5*time.Millisecond, &out)
+		require.EqualError(t, err, "timed out waiting for the Dapr control plane: dapr-placement-server not ready")
+		assert.Contains(t, out.String(), "dapr-placement-server: 0/1 pods ready")
+		assert.Contains(t, out.String(), "pod dapr-placement-server-0: Failed: Failed to pull image")
+		assert.Contains(t, out.String(), "Pod dapr-placement-server-0: Pending (dapr-placement-server: ImagePullBackOff Back-off pulling image)")
+		assert.Contains(t, out.String(), "Warning Failed: Failed to pull image")
+	})
+}
@@ -13,24 +13,260 @@ limitations under the License.
 
 package kubernetes
 
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	core_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	k8s "k8s.io/client-go/kubernetes"
+
+	"github.com/dapr/cli/pkg/print"
+)
+
+const (
+	// runAppContainerName is the name of the app container of a pod created by `dapr run -k`.
+	runAppContainerName = "app"
+	// runLabel marks the pods created by `dapr run -k`.
+	runLabel = "dapr.io/cli-run"
+
+	runAppLogPrefix  = "== APP == "
+	runDaprLogPrefix = "== DAPR == "
+
+	runPollInterval = time.Second
+	// runExitTimeout is the time to wait for the app container to terminate after its logs ended.
+	runExitTimeout = 10 * time.Second
+	// runDeleteTimeout is the time to wait for the deletion of the pod to be accepted when `dapr run -k` stops.
+	runDeleteTimeout = 30 * time.Second
+	// DefaultRunStartTimeout is the default time to wait for the pod created by `dapr run -k` to be running.
+	DefaultRunStartTimeout = 5 * time.Minute
+)
+
 // RunConfig represents the application configuration parameters.
 type RunConfig struct {
-	AppID         string
-	AppPort       int
-	HTTPPort      int
-	GRPCPort      int
-	CodeDirectory string
-	Arguments     []string
-	Image         string
+	AppID     string
+	AppPort   int
+	Image     string
+	Namespace string
+	// Arguments replace the arguments of the entrypoint of the image if they are given.
+	Arguments []string
+	// Env are the environment variables of the app as KEY=VALUE.
+	Env []string
+	// Annotations are further options of the sidecar, see NewAnnotateOptions.
+	Annotations []AnnoteOption
+	// StartTimeout limits the time to wait for the pod to be running. It defaults to DefaultRunStartTimeout.
+	StartTimeout time.Duration
+}
+
+// Run creates a pod running the image of config with a Dapr sidecar, streams the logs of the app and the sidecar
+// to out until the app exits or ctx is done, and deletes the pod.
+func Run(ctx context.Context, client k8s.Interface, config RunConfig, out io.Writer) error {
+	pod, err := runPod(config)
+	if err != nil {
+		return err
+	}
+	pod, err = client.CoreV1().Pods(pod.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("error creating pod: %w", err)
+	}
+	print.InfoStatusEvent(os.Stdout, "Created pod %s in namespace %s", pod.Name, pod.Namespace)
+	defer deleteRunPod(client, pod)
+
+	startTimeout := config.StartTimeout
+	if startTimeout <= 0 {
+		startTimeout = DefaultRunStartTimeout
+	}
+	pod, err = waitForRunPod(ctx, client, pod, startTimeout)
+	if err != nil || ctx.Err() != nil {
+		return err
+	}
+	if !hasSidecar(pod) {
+		print.WarningStatusEvent(os.Stdout, "The Dapr sidecar was not injected into pod %s. Check that Dapr is installed in the cluster with dapr status -k", pod.Name)
+	}
+	print.SuccessStatusEvent(os.Stdout, "You're up and running! Both Dapr and your app logs will appear here.\n")
+
+	return streamRunLogs(ctx, client, pod, out)
+}
+
+// runPod returns the pod that runs the app of config with a Dapr sidecar.
+func runPod(config RunConfig) (*core_v1.Pod, error) {
+	if config.AppID == "" {
+		return nil, fmt.Errorf("an app ID is required")
+	}
+	if config.Image == "" {
+		return nil, fmt.Errorf("an image is required")
+	}
+	namespace := config.Namespace
+	if namespace == "" {
+		namespace = core_v1.NamespaceDefault
+	}
+
+	options := append([]AnnoteOption{WithAppID(config.AppID)}, config.Annotations...)
+	if config.AppPort > 0 {
+		options = append(options, WithAppPort(config.AppPort))
+	}
+	opts := NewAnnotateOptions(options...)
+
+	container := core_v1.Container{
+		Name:  runAppContainerName,
+		Image: config.Image,
+		Args:  config.Arguments,
+	}
+	if config.AppPort > 0 {
+		container.Ports = []core_v1.ContainerPort{{ContainerPort: int32(config.AppPort)}}
+	}
+	for _, env := range config.Env {
+		name, value, ok := strings.Cut(env, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid environment variable %q: must be KEY=VALUE", env)
+		}
+		container.Env = append(container.Env, core_v1.EnvVar{Name: name, Value: value})
+	}
+
+	return &core_v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-%s", config.AppID, rand.String(5)),
+			Namespace:   namespace,
+			Labels:      map[string]string{"app": config.AppID, runLabel: "true"},
+			Annotations: getDaprAnnotations(&opts),
+		},
+		Spec: core_v1.PodSpec{
+			Containers:    []core_v1.Container{container},
+			RestartPolicy: core_v1.RestartPolicyNever,
+		},
+	}, nil
+}
+
+// waitForRunPod waits for pod to be running, or to have completed already, and returns its current state.
+// It returns an error if the image of the app cannot be pulled.
+func waitForRunPod(ctx context.Context, client k8s.Interface, pod *core_v1.Pod, timeout time.Duration) (*core_v1.Pod, error) {
+	stopSpinning := print.Spinner(os.Stdout, "Waiting for pod %s to be running", pod.Name)
+	defer stopSpinning(print.Failure)
+
+	deadline := time.Now().Add(timeout)
+	for {
+		current, err := client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			if ctx.Err() != nil {
+				return pod, nil
+			}
+			return nil, fmt.Errorf("error getting pod %s: %w", pod.Name, err)
+		}
+		switch current.Status.Phase {
+		case core_v1.PodRunning, core_v1.PodSucceeded, core_v1.PodFailed:
+			stopSpinning(print.Success)
+			return current, nil
+		}
+		for _, status := range current.Status.ContainerStatuses {
+			if waiting := status.State.Waiting; waiting != nil && isPullError(waiting.Reason) {
+				return nil, fmt.Errorf("container %s of pod %s cannot start: %s: %s", status.Name, pod.Name, waiting.Reason, waiting.Message)
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out after %s waiting for pod %s to be running", timeout, pod.Name)
+		}
+
+		select {
+		case <-ctx.Done():
+			return pod, nil
+		case <-time.After(runPollInterval):
+		}
+	}
+}
+
+// hasSidecar returns true if the Dapr sidecar was injected into pod.
+func hasSidecar(pod *core_v1.Pod) bool {
+	for _, c := range pod.Spec.Containers {
+		if c.Name == daprdContainerName {
+			return true
+		}
+	}
+	return false
 }
 
-// RunOutput represents the run output.
-type RunOutput struct {
-	Message string
+func isPullError(reason string) bool {
+	return reason == "ErrImagePull" || reason == "ImagePullBackOff" || reason == "InvalidImageName"
+}
+
+// streamRunLogs writes the logs of the app and the sidecar to out until the app exits or ctx is done.
+// It returns an error if the app exited with an error.
+func streamRunLogs(ctx context.Context, client k8s.Interface, pod *core_v1.Pod, out io.Writer) error {
+	daprCtx, stopDapr := context.WithCancel(ctx)
+	defer stopDapr()
+
+	var (
+		lock sync.Mutex
+		wg   sync.WaitGroup
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		opts := &core_v1.PodLogOptions{Container: daprdContainerName, Follow: true} // nolint:exhaustivestruct
+		err := copyPodLogs(daprCtx, client, *pod, opts, runDaprLogPrefix, out, &lock)
+		if err != nil && daprCtx.Err() == nil {
+			print.WarningStatusEvent(os.Stdout, "Could not get the logs of the Dapr sidecar: %s", err)
+		}
+	}()
+
+	opts := &core_v1.PodLogOptions{Container: runAppContainerName, Follow: true} // nolint:exhaustivestruct
+	err := copyPodLogs(ctx, client, *pod, opts, runAppLogPrefix, out, &lock)
+	stopDapr()
+	wg.Wait()
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error getting the logs of the app: %w", err)
+	}
+	return appExitError(ctx, client, pod)
+}
+
+// appExitError waits for the app container of pod to terminate after its logs ended, and returns an error if it
+// terminated with a non-zero exit code.
+func appExitError(ctx context.Context, client k8s.Interface, pod *core_v1.Pod) error {
+	deadline := time.Now().Add(runExitTimeout)
+	for {
+		current, err := client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("error getting pod %s: %w", pod.Name, err)
+		}
+		for _, status := range current.Status.ContainerStatuses {
+			if status.Name != runAppContainerName || status.State.Terminated == nil {
+				continue
+			}
+			if code := status.State.Terminated.ExitCode; code != 0 {
+				return fmt.Errorf("the app exited with code %d", code)
+			}
+			print.SuccessStatusEvent(os.Stdout, "Exited App successfully")
+			return nil
+		}
+		if time.Now().After(deadline) {
+			print.WarningStatusEvent(os.Stdout, "The logs of the app ended, but the app is still running")
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(runPollInterval):
+		}
+	}
 }
 
-// Run executes the application based on the run configuration.
-func Run(config *RunConfig) (*RunOutput, error) {
-	//nolint
-	return nil, nil
+// deleteRunPod deletes the pod created by `dapr run -k`, also when the context of the run was cancelled.
+func deleteRunPod(client k8s.Interface, pod *core_v1.Pod) {
+	ctx, cancel := context.WithTimeout(context.Background(), runDeleteTimeout)
+	defer cancel()
+	err := client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+	if err != nil {
+		print.FailureStatusEvent(os.Stderr, "Error deleting pod %s: %s. Delete it with kubectl delete pod %s -n %s", pod.Name, err, pod.Name, pod.Namespace)
+		return
+	}
+	print.SuccessStatusEvent(os.Stdout, "Deleted pod %s", pod.Name)
 }
@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestRunPod(t *testing.T) {
+	pod, err := runPod(RunConfig{
+		AppID:       "orders",
+		AppPort:     3000,
+		Image:       "orders:dev",
+		Arguments:   []string{"--verbose"},
+		Env:         []string{"LOG_LEVEL=debug"},
+		Annotations: []AnnoteOption{WithLogLevel("debug")},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(pod.Name, "orders-"))
+	assert.Equal(t, "default", pod.Namespace)
+	assert.Equal(t, map[string]string{"app": "orders", runLabel: "true"}, pod.Labels)
+	assert.Equal(t, map[string]string{
+		daprEnabledKey:  "true",
+		daprAppIDKey:    "orders",
+		daprAppPortKey:  "3000",
+		daprLogLevelKey: "debug",
+	}, pod.Annotations)
+	assert.Equal(t, corev1.RestartPolicyNever, pod.Spec.RestartPolicy)
+
+	require.Len(t, pod.Spec.Containers, 1)
+	container := pod.Spec.Containers[0]
+	assert.Equal(t, "orders:dev", container.Image)
+	assert.Equal(t, []string{"--verbose"}, container.Args)
+	assert.Equal(t, []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}}, container.Env)
+	assert.Equal(t, int32(3000), container.Ports[0].ContainerPort)
+
+	_, err = runPod(RunConfig{AppID: "orders"})
+	assert.Error(t, err)
+	_, err = runPod(RunConfig{AppID: "orders", Image: "orders:dev", Env: []string{"LOG_LEVEL"}})
+	assert.Error(t, err)
+}
+
+// newRunTestClient returns a client whose pods are running with an injected sidecar as soon as they are created,
+// and whose app container terminated with exitCode.
+func newRunTestClient(exitCode int32) *fake.Clientset {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: daprdContainerName})
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:  runAppContainerName,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}},
+		}}
+		return false, nil, nil
+	})
+	return client
+}
+
+func TestRun(t *testing.T) {
+	t.Run("logs are streamed and the pod is deleted", func(t *testing.T) {
+		client := newRunTestClient(0)
+		var out bytes.Buffer
+		err := Run(context.Background(), client, RunConfig{AppID: "orders", Image: "orders:dev", Namespace: "shop"}, &out)
+		require.NoError(t, err)
+
+		// The fake client returns the same logs for every container.
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		sort.Strings(lines)
+		assert.Equal(t, []string{runAppLogPrefix + "fake logs", runDaprLogPrefix + "fake logs"}, lines)
+
+		pods, err := client.CoreV1().Pods("shop").List(context.Background(), metav1.ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, pods.Items)
+	})
+
+	t.Run("app exit code is returned", func(t *testing.T) {
+		client := newRunTestClient(3)
+		err := Run(context.Background(), client, RunConfig{AppID: "orders", Image: "orders:dev"}, &bytes.Buffer{})
+		assert.EqualError(t, err, "the app exited with code 3")
+	})
+
+	t.Run("image pull errors fail", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+			pod.Status.Phase = corev1.PodPending
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+				Name:  runAppContainerName,
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull", Message: "not found"}},
+			}}
+			return false, nil, nil
+		})
+		err := Run(context.Background(), client, RunConfig{AppID: "orders", Image: "orders:missing"}, &bytes.Buffer{})
+		assert.ErrorContains(t, err, "ErrImagePull: not found")
+
+		pods, err := client.CoreV1().Pods("default").List(context.Background(), metav1.ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, pods.Items)
+	})
+}
@@ -14,11 +14,14 @@ limitations under the License.
 package kubernetes
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	k8s "k8s.io/client-go/kubernetes"
 
@@ -146,5 +149,44 @@ func (s *StatusClient) Status() ([]StatusOutput, error) {
 	}
 
 	wg.Wait()
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
 	return statuses, nil
 }
+
+// UnhealthyServices returns the names of the control plane services that are not running or not healthy.
+func UnhealthyServices(statuses []StatusOutput) []string {
+	unhealthy := []string{}
+	for _, s := range statuses {
+		if s.Status != "Running" || s.Healthy != "True" {
+			unhealthy = append(unhealthy, s.Name)
+		}
+	}
+	sort.Strings(unhealthy)
+	return unhealthy
+}
+
+// WaitForHealthy polls the status of the control plane services every interval until all of them are healthy,
+// and calls onStatus with every status read. It returns the last status read, with the error of ctx if it is done
+// before the services are healthy. No services are never healthy, as Dapr is not installed yet.
+func (s *StatusClient) WaitForHealthy(ctx context.Context, interval time.Duration, onStatus func([]StatusOutput)) ([]StatusOutput, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		statuses, err := s.Status()
+		if err != nil {
+			return nil, err
+		}
+		onStatus(statuses)
+		if len(statuses) > 0 && len(UnhealthyServices(statuses)) == 0 {
+			return statuses, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return statuses, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,222 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	helm "helm.sh/helm/v3/pkg/action"
+	core_v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ReleaseStatus is the Helm release the Dapr control plane is installed with.
+type ReleaseStatus struct {
+	Name         string
+	Namespace    string
+	ChartVersion string
+	AppVersion   string
+	Revision     int
+	Status       string
+}
+
+// CRDStatus is the state of a Dapr CRD in the cluster.
+type CRDStatus struct {
+	Name string `csv:"CRD"`
+	// Served are the versions served by the CRD.
+	Served string `csv:"SERVED"`
+	// Storage is the version new objects are stored as.
+	Storage string `csv:"STORAGE"`
+	// Stored are the versions objects have been stored as, which must be served to read them.
+	Stored  string `csv:"STORED"`
+	Created string `csv:"CREATED"`
+}
+
+// ImageDrift is a control plane service running an image that differs from the image of its Helm release,
+// for example after the image of its deployment was changed with kubectl.
+type ImageDrift struct {
+	Service string `csv:"SERVICE"`
+	Pod     string `csv:"POD"`
+	Release string `csv:"RELEASE IMAGE"`
+	Running string `csv:"RUNNING IMAGE"`
+}
+
+// StatusDetails are the versions of the Dapr control plane recorded in the cluster, beyond the health of its services.
+type StatusDetails struct {
+	// Release is nil if Dapr was not installed with Helm or the Dapr CLI.
+	Release *ReleaseStatus
+	// CRDs are the Dapr CRDs that are installed.
+	CRDs []CRDStatus
+	// Drift are the services whose running image differs from the image of the release.
+	Drift []ImageDrift
+}
+
+// Details returns the Helm release, the CRDs and the image drift of the Dapr control plane in namespace.
+func (s *StatusClient) Details(namespace string) (*StatusDetails, error) {
+	details := &StatusDetails{}
+
+	config, err := getConfig()
+	if err != nil {
+		return nil, err
+	}
+	extClient, err := apiextensionsclient.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range crdsFullResources {
+		crd, err := extClient.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), name, meta_v1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get CRD %s: %w", name, err)
+		}
+		details.CRDs = append(details.CRDs, crdStatus(*crd))
+	}
+
+	helmConf, err := helmConfig(namespace)
+	if err != nil {
+		return nil, err
+	}
+	releaseName, err := GetDaprHelmChartName(helmConf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the Helm releases: %w", err)
+	}
+	if releaseName == "" {
+		return details, nil
+	}
+	release, err := helm.NewGet(helmConf).Run(releaseName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting release %s: %w", releaseName, err)
+	}
+	details.Release = &ReleaseStatus{
+		Name:      release.Name,
+		Namespace: release.Namespace,
+		Revision:  release.Version,
+	}
+	if release.Chart != nil && release.Chart.Metadata != nil {
+		details.Release.ChartVersion = release.Chart.Metadata.Version
+		details.Release.AppVersion = release.Chart.Metadata.AppVersion
+	}
+	if release.Info != nil {
+		details.Release.Status = release.Info.Status.String()
+	}
+
+	pods := []core_v1.Pod{}
+	for _, label := range controlPlaneLabels {
+		list, err := s.client.CoreV1().Pods(namespace).List(context.TODO(), meta_v1.ListOptions{
+			LabelSelector: labels.FormatLabels(map[string]string{"app": label}),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the pods of %s: %w", label, err)
+		}
+		pods = append(pods, list.Items...)
+	}
+	details.Drift = imageDrift(manifestImages(release.Manifest), pods)
+	return details, nil
+}
+
+// crdStatus returns the versions of crd, with the storage version first among the served versions.
+func crdStatus(crd apiextensionsv1.CustomResourceDefinition) CRDStatus {
+	status := CRDStatus{
+		Name:    crd.Name,
+		Stored:  strings.Join(crd.Status.StoredVersions, ","),
+		Created: crd.CreationTimestamp.Format("2006-01-02 15:04.05"),
+	}
+	served := []string{}
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			status.Storage = v.Name
+		}
+		if v.Served {
+			served = append(served, v.Name)
+		}
+	}
+	status.Served = strings.Join(served, ",")
+	return status
+}
+
+// workloadManifest is the part of a rendered deployment, stateful set or daemon set that identifies its pods and
+// their images.
+type workloadManifest struct {
+	Kind string `yaml:"kind"`
+	Spec struct {
+		Template struct {
+			Metadata struct {
+				Labels map[string]string `yaml:"labels"`
+			} `yaml:"metadata"`
+			Spec struct {
+				Containers []struct {
+					Image string `yaml:"image"`
+				} `yaml:"containers"`
+			} `yaml:"spec"`
+		} `yaml:"template"`
+	} `yaml:"spec"`
+}
+
+// manifestImages returns the image of the first container of each workload of a rendered manifest, by the app
+// label of its pods.
+func manifestImages(manifest string) map[string]string {
+	images := map[string]string{}
+	for _, doc := range splitManifest(manifest) {
+		var w workloadManifest
+		if err := yaml.Unmarshal([]byte(doc), &w); err != nil {
+			continue
+		}
+		switch w.Kind {
+		case "Deployment", "StatefulSet", "DaemonSet":
+		default:
+			continue
+		}
+		app := w.Spec.Template.Metadata.Labels["app"]
+		if app == "" || len(w.Spec.Template.Spec.Containers) == 0 {
+			continue
+		}
+		images[app] = w.Spec.Template.Spec.Containers[0].Image
+	}
+	return images
+}
+
+// imageDrift returns the pods whose first container runs an image other than the image in expected for their
+// app label. Pods of services that are not in expected are skipped.
+func imageDrift(expected map[string]string, pods []core_v1.Pod) []ImageDrift {
+	drift := []ImageDrift{}
+	for _, pod := range pods {
+		app := pod.Labels["app"]
+		image, ok := expected[app]
+		if !ok || len(pod.Spec.Containers) == 0 || pod.Spec.Containers[0].Image == image {
+			continue
+		}
+		drift = append(drift, ImageDrift{
+			Service: app,
+			Pod:     pod.Name,
+			Release: image,
+			Running: pod.Spec.Containers[0].Image,
+		})
+	}
+	sort.Slice(drift, func(i, j int) bool {
+		if drift[i].Service != drift[j].Service {
+			return drift[i].Service < drift[j].Service
+		}
+		return drift[i].Pod < drift[j].Pod
+	})
+	return drift
+}
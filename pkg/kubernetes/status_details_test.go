@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core_v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const statusTestManifest = `---
+# Source: dapr/charts/dapr_operator/templates/dapr_operator_deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dapr-operator
+  namespace: dapr-system
+spec:
+  template:
+    metadata:
+      labels:
+        app: dapr-operator
+    spec:
+      containers:
+      - name: dapr-operator
+        image: docker.io/daprio/operator:1.8.0
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: dapr-placement-server
+  namespace: dapr-system
+spec:
+  template:
+    metadata:
+      labels:
+        app: dapr-placement-server
+    spec:
+      containers:
+      - name: dapr-placement-server
+        image: docker.io/daprio/placement:1.8.0
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: dapr-api
+  namespace: dapr-system
+spec:
+  selector:
+    app: dapr-operator
+`
+
+func controlPlanePod(name, app, image string) core_v1.Pod {
+	return core_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "dapr-system", Labels: map[string]string{"app": app}},
+		Spec:       core_v1.PodSpec{Containers: []core_v1.Container{{Image: image}}},
+	}
+}
+
+func TestManifestImages(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"dapr-operator":         "docker.io/daprio/operator:1.8.0",
+		"dapr-placement-server": "docker.io/daprio/placement:1.8.0",
+	}, manifestImages(statusTestManifest))
+	assert.Empty(t, manifestImages(""))
+}
+
+func TestImageDrift(t *testing.T) {
+	expected := manifestImages(statusTestManifest)
+
+	t.Run("no drift", func(t *testing.T) {
+		pods := []core_v1.Pod{
+			controlPlanePod("dapr-operator-1", "dapr-operator", "docker.io/daprio/operator:1.8.0"),
+			controlPlanePod("dapr-placement-server-0", "dapr-placement-server", "docker.io/daprio/placement:1.8.0"),
+		}
+		assert.Empty(t, imageDrift(expected, pods))
+	})
+
+	t.Run("changed images", func(t *testing.T) {
+		pods := []core_v1.Pod{
+			controlPlanePod("dapr-placement-server-0", "dapr-placement-server", "docker.io/daprio/placement:1.7.4"),
+			controlPlanePod("dapr-operator-2", "dapr-operator", "docker.io/daprio/operator:1.8.0"),
+			controlPlanePod("dapr-operator-1", "dapr-operator", "myregistry/operator:1.8.0-patched"),
+		}
+		assert.Equal(t, []ImageDrift{
+			{Service: "dapr-operator", Pod: "dapr-operator-1", Release: "docker.io/daprio/operator:1.8.0", Running: "myregistry/operator:1.8.0-patched"},
+			{Service: "dapr-placement-server", Pod: "dapr-placement-server-0", Release: "docker.io/daprio/placement:1.8.0", Running: "docker.io/daprio/placement:1.7.4"},
+		}, imageDrift(expected, pods))
+	})
+
+	t.Run("services not in the release are skipped", func(t *testing.T) {
+		pods := []core_v1.Pod{controlPlanePod("dapr-dashboard-1", "dapr-dashboard", "docker.io/daprio/dashboard:0.10.0")}
+		assert.Empty(t, imageDrift(expected, pods))
+	})
+}
+
+func TestCRDStatus(t *testing.T) {
+	crd := apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "components.dapr.io"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: true, Storage: true},
+				{Name: "v1alpha2", Served: true},
+				{Name: "v1beta1"},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: []string{"v1alpha1", "v1alpha2"}},
+	}
+	status := crdStatus(crd)
+	assert.Equal(t, "components.dapr.io", status.Name)
+	assert.Equal(t, "v1alpha1,v1alpha2", status.Served)
+	assert.Equal(t, "v1alpha1", status.Storage)
+	assert.Equal(t, "v1alpha1,v1alpha2", status.Stored)
+}
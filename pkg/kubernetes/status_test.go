@@ -14,6 +14,7 @@ limitations under the License.
 package kubernetes
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
@@ -232,3 +233,44 @@ func TestControlPlaneServices(t *testing.T) {
 		assert.Equal(t, replicas, stat.Replicas, "expected replicas to match")
 	}
 }
+
+func TestUnhealthyServices(t *testing.T) {
+	unhealthy := UnhealthyServices([]StatusOutput{
+		{Name: "dapr-sentry", Status: "Running", Healthy: "True"},
+		{Name: "dapr-sidecar-injector", Status: "Waiting (ImagePullBackOff)", Healthy: "False"},
+		{Name: "dapr-operator", Status: "Running", Healthy: "False"},
+	})
+	assert.Equal(t, []string{"dapr-operator", "dapr-sidecar-injector"}, unhealthy)
+}
+
+func TestWaitForHealthy(t *testing.T) {
+	running := v1.ContainerState{Running: &v1.ContainerStateRunning{}}
+
+	t.Run("healthy services", func(t *testing.T) {
+		k8s := newTestSimpleK8s(newDaprControlPlanePod("dapr-sentry-0", "dapr-sentry", time.Now(), running, true))
+		calls := 0
+		status, err := k8s.WaitForHealthy(context.Background(), time.Millisecond, func([]StatusOutput) { calls++ })
+		assert.NoError(t, err)
+		assert.Len(t, status, 1)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("timeout with unhealthy services", func(t *testing.T) {
+		k8s := newTestSimpleK8s(newDaprControlPlanePod("dapr-sentry-0", "dapr-sentry", time.Now(), running, false))
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		calls := 0
+		status, err := k8s.WaitForHealthy(ctx, 10*time.Millisecond, func([]StatusOutput) { calls++ })
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, []string{"dapr-sentry"}, UnhealthyServices(status))
+		assert.Greater(t, calls, 1)
+	})
+
+	t.Run("dapr not installed", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		status, err := newTestSimpleK8s().WaitForHealthy(ctx, 5*time.Millisecond, func([]StatusOutput) {})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Empty(t, status)
+	})
+}
@@ -0,0 +1,37 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/dapr/cli/pkg/metadata"
+)
+
+// ListSubscriptions returns the pub/sub subscriptions registered by the sidecar of appID, read from its metadata
+// endpoint through a port-forward. The sidecar of podName is used if given, otherwise the sidecar of the first
+// running pod of the app.
+func ListSubscriptions(appID, podName, namespace string) ([]metadata.SubscriptionOutput, error) {
+	session, err := StartDebugSession(appID, podName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	defer session.Stop()
+
+	appMetadata, err := metadata.Get(session.Port("http"), appID, "")
+	if err != nil {
+		return nil, fmt.Errorf("error getting the metadata of the sidecar of pod %s: %w", session.Pod, err)
+	}
+	return metadata.Subscriptions(appMetadata), nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	k8s "k8s.io/client-go/kubernetes"
+)
+
+var componentsResource = schema.GroupVersionResource{Group: "dapr.io", Version: daprCRDVersion, Resource: "components"}
+
+// ControlPlaneLogs returns the last tail lines of the logs of every container of the pods of the Dapr control plane,
+// keyed by namespace/pod/container. The logs of containers that cannot be read are returned as errors.
+func ControlPlaneLogs(ctx context.Context, tail int64) (map[string][]byte, map[string]error, error) {
+	client, err := Client()
+	if err != nil {
+		return nil, nil, err
+	}
+	return controlPlaneLogs(ctx, client, tail)
+}
+
+func controlPlaneLogs(ctx context.Context, client k8s.Interface, tail int64) (map[string][]byte, map[string]error, error) {
+	logs := map[string][]byte{}
+	errs := map[string]error{}
+	for _, label := range controlPlaneLabels {
+		pods, err := ListPodsInterface(client, map[string]string{"app": label})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list the pods of %s: %w", label, err)
+		}
+		for _, pod := range pods.Items {
+			for _, container := range pod.Spec.Containers {
+				key := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.Name, container.Name)
+				b, err := podLogs(ctx, client, pod, &corev1.PodLogOptions{Container: container.Name, TailLines: &tail}) // nolint:exhaustivestruct
+				if err != nil {
+					errs[key] = err
+					continue
+				}
+				logs[key] = b
+			}
+		}
+	}
+	return logs, errs, nil
+}
+
+func podLogs(ctx context.Context, client k8s.Interface, pod corev1.Pod, opts *corev1.PodLogOptions) ([]byte, error) {
+	stream, err := client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, opts).Stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+	return io.ReadAll(stream)
+}
+
+// ComponentManifests returns the manifests of the Dapr components in all namespaces, keyed by namespace/name,
+// without the metadata set by the cluster. Their secrets are not redacted.
+func ComponentManifests() (map[string][]byte, error) {
+	client, err := DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	return componentManifests(client)
+}
+
+func componentManifests(client dynamic.Interface) (map[string][]byte, error) {
+	list, err := client.Resource(componentsResource).List(context.TODO(), meta_v1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		return map[string][]byte{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list components: %w", err)
+	}
+	manifests := map[string][]byte{}
+	for i := range list.Items {
+		item := &list.Items[i]
+		b, err := backupManifest(item)
+		if err != nil {
+			return nil, err
+		}
+		manifests[item.GetNamespace()+"/"+item.GetName()] = b
+	}
+	return manifests, nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestControlPlaneLogs(t *testing.T) {
+	pod := func(name, app string, containers ...string) *corev1.Pod {
+		p := &corev1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "dapr-system", Labels: map[string]string{"app": app}}}
+		for _, c := range containers {
+			p.Spec.Containers = append(p.Spec.Containers, corev1.Container{Name: c})
+		}
+		return p
+	}
+	client := fake.NewSimpleClientset(
+		pod("dapr-operator-1", "dapr-operator", "dapr-operator"),
+		pod("dapr-sentry-1", "dapr-sentry", "dapr-sentry", "proxy"),
+		pod("orders-1", "orders", "orders", "daprd"),
+	)
+
+	logs, errs, err := controlPlaneLogs(context.Background(), client, 100)
+	require.NoError(t, err)
+	assert.Empty(t, errs)
+	// The fake client returns the same logs for every container.
+	assert.Equal(t, map[string][]byte{
+		"dapr-system/dapr-operator-1/dapr-operator": []byte("fake logs"),
+		"dapr-system/dapr-sentry-1/dapr-sentry":     []byte("fake logs"),
+		"dapr-system/dapr-sentry-1/proxy":           []byte("fake logs"),
+	}, logs, "only the pods of the control plane are included")
+}
+
+func TestComponentManifests(t *testing.T) {
+	component := daprResource("Component", "default", "statestore", nil)
+	component.SetResourceVersion("7")
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{componentsResource: "ComponentList"}, component)
+
+	manifests, err := componentManifests(client)
+	require.NoError(t, err)
+	require.Contains(t, manifests, "default/statestore")
+	assert.Contains(t, string(manifests["default/statestore"]), "name: statestore")
+	assert.NotContains(t, string(manifests["default/statestore"]), "resourceVersion")
+}
@@ -14,18 +14,89 @@ limitations under the License.
 package kubernetes
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	helm "helm.sh/helm/v3/pkg/action"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	k8s "k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
 
 	"github.com/dapr/cli/pkg/print"
 	"github.com/dapr/cli/utils"
 )
 
-// Uninstall removes Dapr from a Kubernetes cluster.
-func Uninstall(namespace string, uninstallAll bool, timeout uint) error {
-	config, err := helmConfig(namespace)
+// orphanedFate is the fate of the Dapr custom resources that are kept when Dapr is removed.
+const orphanedFate = "orphaned"
+
+// protectedNamespaces are the namespaces of the cluster itself, which --delete-namespace refuses to delete.
+var protectedNamespaces = []string{meta_v1.NamespaceDefault, meta_v1.NamespaceSystem, meta_v1.NamespacePublic, "kube-node-lease"}
+
+// ErrUninstallAborted is returned by Uninstall when the removal is not confirmed.
+var ErrUninstallAborted = errors.New("uninstall aborted")
+
+// daprResourceKinds are the Dapr custom resources that are listed before Dapr is removed.
+var daprResourceKinds = []struct {
+	kind string
+	gvr  schema.GroupVersionResource
+}{
+	{"Component", schema.GroupVersionResource{Group: "dapr.io", Version: "v1alpha1", Resource: "components"}},
+	{"Configuration", schema.GroupVersionResource{Group: "dapr.io", Version: "v1alpha1", Resource: "configurations"}},
+	{"Subscription", schema.GroupVersionResource{Group: "dapr.io", Version: "v1alpha1", Resource: "subscriptions"}},
+}
+
+// UninstallConfig represents the options of removing Dapr from a Kubernetes cluster.
+type UninstallConfig struct {
+	Namespace string
+	Timeout   uint
+	// DryRun prints the actions of the removal instead of removing Dapr.
+	DryRun bool
+	// DeleteCRDs removes the Dapr CRDs, and with them all Dapr custom resources.
+	DeleteCRDs bool
+	// DeleteNamespace removes the namespace Dapr is installed in once the release is removed.
+	DeleteNamespace bool
+	// Force removes the namespace with DeleteNamespace also if workloads other than Dapr's run in it.
+	Force bool
+	// BackupDir is the directory the Dapr custom resources are exported to before Dapr is removed, if not empty.
+	BackupDir string
+	// Confirm is asked whether to continue when Dapr custom resources remain in the cluster. If it is nil,
+	// the resources are printed as warnings and the removal continues.
+	Confirm func(resources []DaprResource) (bool, error)
+}
+
+// DaprResource is a Dapr custom resource, such as a component, applied in the cluster.
+type DaprResource struct {
+	Kind      string
+	Namespace string
+	Name      string
+
+	object *unstructured.Unstructured
+}
+
+func (r DaprResource) String() string {
+	return fmt.Sprintf("%s %s/%s", r.Kind, r.Namespace, r.Name)
+}
+
+// Uninstall removes Dapr from a Kubernetes cluster. If conf.DryRun is set, the Helm release, the CRDs and the
+// namespace that would be removed, and the Dapr custom resources that would be orphaned, are printed instead.
+func Uninstall(conf UninstallConfig) error {
+	if conf.DeleteNamespace {
+		if err := checkNamespaceDeletable(conf.Namespace); err != nil {
+			return err
+		}
+	}
+	config, err := helmConfig(conf.Namespace)
 	if err != nil {
 		return err
 	}
@@ -40,15 +111,55 @@ func Uninstall(namespace string, uninstallAll bool, timeout uint) error {
 		return nil
 	}
 
+	if conf.DeleteNamespace && !conf.Force {
+		client, clientErr := Client()
+		if clientErr != nil {
+			return clientErr
+		}
+		if err = checkNamespaceWorkloads(client, conf.Namespace); err != nil {
+			return err
+		}
+	}
+
+	dynamicClient, err := DynamicClient()
+	if err != nil {
+		return err
+	}
+	resources, err := listDaprResources(dynamicClient)
+	if err != nil {
+		// The resources are only listed to warn about them, unless they must be exported first.
+		if conf.BackupDir != "" {
+			return err
+		}
+		print.WarningStatusEvent(os.Stderr, "Could not list the Dapr resources that remain in the cluster: %s", err)
+	}
+
+	if conf.DryRun {
+		printDryRunActions(uninstallActions(conf, resources))
+		return nil
+	}
+
+	if len(resources) > 0 {
+		if err = confirmUninstall(conf, resources); err != nil {
+			return err
+		}
+		if conf.BackupDir != "" {
+			if err = backupResources(conf.BackupDir, resources); err != nil {
+				return err
+			}
+			print.SuccessStatusEvent(os.Stdout, "Exported %d Dapr resources to %s", len(resources), conf.BackupDir)
+		}
+	}
+
 	uninstallClient := helm.NewUninstall(config)
-	uninstallClient.Timeout = time.Duration(timeout) * time.Second
+	uninstallClient.Timeout = time.Duration(conf.Timeout) * time.Second
 	_, err = uninstallClient.Run(daprReleaseName)
 
 	if err != nil {
 		return err
 	}
 
-	if uninstallAll {
+	if conf.DeleteCRDs {
 		for _, crd := range crdsFullResources {
 			_, err := utils.RunCmdAndWait("kubectl", "delete", "crd", crd)
 			if err != nil {
@@ -57,5 +168,205 @@ func Uninstall(namespace string, uninstallAll bool, timeout uint) error {
 		}
 	}
 
+	if conf.DeleteNamespace {
+		return deleteNamespace(conf.Namespace)
+	}
+	return nil
+}
+
+// confirmUninstall prints the Dapr custom resources that remain in the cluster and asks conf.Confirm whether to
+// continue, if it is set.
+func confirmUninstall(conf UninstallConfig, resources []DaprResource) error {
+	if conf.Confirm == nil {
+		for _, r := range resources {
+			print.WarningStatusEvent(os.Stderr, "%s will be %s", r, resourceFate(conf, r))
+		}
+		return nil
+	}
+	ok, err := conf.Confirm(resources)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrUninstallAborted
+	}
+	return nil
+}
+
+// ConfirmUninstall lists the Dapr custom resources that remain in the cluster on out, with whether they would be
+// deleted or orphaned by removing Dapr with conf, and reads from in whether to continue.
+func ConfirmUninstall(in io.Reader, out io.Writer, conf UninstallConfig, resources []DaprResource) (bool, error) {
+	fmt.Fprintln(out, "The following Dapr resources remain in the cluster:")
+	for _, r := range resources {
+		fmt.Fprintf(out, "  %s (%s)\n", r, resourceFate(conf, r))
+	}
+	if conf.BackupDir == "" {
+		fmt.Fprintln(out, "Use --backup-dir to export them before Dapr is removed.")
+	}
+
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, "Continue removing Dapr? (y/N): ")
+		if !scanner.Scan() {
+			return false, scanner.Err()
+		}
+		switch strings.ToLower(strings.TrimSpace(scanner.Text())) {
+		case "", "n", "no":
+			return false, nil
+		case "y", "yes":
+			return true, nil
+		}
+		fmt.Fprintln(out, "Please answer yes or no")
+	}
+}
+
+// resourceFate returns whether r is deleted or orphaned by removing Dapr with conf.
+func resourceFate(conf UninstallConfig, r DaprResource) string {
+	switch {
+	case conf.DeleteCRDs:
+		return "deleted with its CRD"
+	case conf.DeleteNamespace && r.Namespace == conf.Namespace:
+		return "deleted with its namespace"
+	default:
+		return orphanedFate
+	}
+}
+
+// listDaprResources returns the Dapr custom resources in all namespaces, except for those of the Dapr Helm release,
+// which are removed with it. Kinds whose CRD is not installed are skipped.
+func listDaprResources(client dynamic.Interface) ([]DaprResource, error) {
+	resources := []DaprResource{}
+	for _, k := range daprResourceKinds {
+		list, err := client.Resource(k.gvr).List(context.TODO(), meta_v1.ListOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", k.gvr.Resource, err)
+		}
+		for i := range list.Items {
+			item := &list.Items[i]
+			if item.GetAnnotations()["meta.helm.sh/release-name"] == daprReleaseName {
+				continue
+			}
+			resources = append(resources, DaprResource{
+				Kind:      k.kind,
+				Namespace: item.GetNamespace(),
+				Name:      item.GetName(),
+				object:    item,
+			})
+		}
+	}
+	return resources, nil
+}
+
+// backupResources writes the manifest of each resource to a file in dir, so they can be applied again. The files are
+// only readable by the user, since components often hold credentials inline.
+func backupResources(dir string, resources []DaprResource) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create backup directory %s: %w", dir, err)
+	}
+	for _, r := range resources {
+		b, err := backupManifest(r.object)
+		if err != nil {
+			return fmt.Errorf("failed to export %s: %w", r, err)
+		}
+		path := filepath.Join(dir, fmt.Sprintf("%s-%s-%s.yaml", strings.ToLower(r.Kind), r.Namespace, r.Name))
+		if err = os.WriteFile(path, b, 0o600); err != nil {
+			return fmt.Errorf("failed to export %s: %w", r, err)
+		}
+	}
+	return nil
+}
+
+// backupManifest returns the YAML manifest of obj without its status and the metadata set by the cluster.
+func backupManifest(obj *unstructured.Unstructured) ([]byte, error) {
+	o := obj.DeepCopy()
+	unstructured.RemoveNestedField(o.Object, "status")
+	for _, field := range []string{"managedFields", "resourceVersion", "uid", "creationTimestamp", "generation", "selfLink"} {
+		unstructured.RemoveNestedField(o.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(o.Object, "metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration")
+	if len(o.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(o.Object, "metadata", "annotations")
+	}
+	return yaml.Marshal(o.Object)
+}
+
+// checkNamespaceDeletable returns an error if namespace is one of the namespaces of the cluster itself.
+func checkNamespaceDeletable(namespace string) error {
+	for _, ns := range protectedNamespaces {
+		if namespace == ns {
+			return fmt.Errorf("refusing to delete the %s namespace, which belongs to the cluster. Remove --delete-namespace to uninstall Dapr from it", namespace)
+		}
+	}
+	return nil
+}
+
+// checkNamespaceWorkloads returns an error if Deployments, StatefulSets or Pods other than Dapr's run in namespace,
+// as deleting the namespace would delete them too.
+func checkNamespaceWorkloads(client k8s.Interface, namespace string) error {
+	workloads, err := namespaceWorkloads(client, namespace)
+	if err != nil {
+		return fmt.Errorf("could not list the workloads of the %s namespace before deleting it: %w", namespace, err)
+	}
+	if len(workloads) > 0 {
+		return fmt.Errorf("refusing to delete the %s namespace, which also holds %s. Use --force to delete them with it", namespace, strings.Join(workloads, ", "))
+	}
+	return nil
+}
+
+// namespaceWorkloads returns the Deployments, StatefulSets and Pods in namespace that are not part of the Dapr
+// release. Pods of ReplicaSets and StatefulSets are left out, as their Deployments and StatefulSets are listed.
+func namespaceWorkloads(client k8s.Interface, namespace string) ([]string, error) {
+	ctx := context.TODO()
+	workloads := []string{}
+	deployments, err := client.AppsV1().Deployments(namespace).List(ctx, meta_v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range deployments.Items {
+		if !isDaprWorkload(d.ObjectMeta) {
+			workloads = append(workloads, "Deployment "+d.Name)
+		}
+	}
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(ctx, meta_v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range statefulSets.Items {
+		if !isDaprWorkload(s.ObjectMeta) {
+			workloads = append(workloads, "StatefulSet "+s.Name)
+		}
+	}
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, meta_v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if owner := meta_v1.GetControllerOf(p); owner != nil && (owner.Kind == "ReplicaSet" || owner.Kind == "StatefulSet") {
+			continue
+		}
+		if !isDaprWorkload(p.ObjectMeta) {
+			workloads = append(workloads, "Pod "+p.Name)
+		}
+	}
+	return workloads, nil
+}
+
+// isDaprWorkload returns true if the object was installed by the Dapr Helm release.
+func isDaprWorkload(meta meta_v1.ObjectMeta) bool {
+	return meta.Annotations["meta.helm.sh/release-name"] == daprReleaseName || meta.Labels["app.kubernetes.io/part-of"] == "dapr"
+}
+
+func deleteNamespace(namespace string) error {
+	client, err := Client()
+	if err != nil {
+		return err
+	}
+	err = client.CoreV1().Namespaces().Delete(context.TODO(), namespace, meta_v1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete namespace %s: %w", namespace, err)
+	}
 	return nil
 }
@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func daprResource(kind, namespace, name string, annotations map[string]string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("dapr.io/v1alpha1")
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetAnnotations(annotations)
+	return obj
+}
+
+func TestListDaprResources(t *testing.T) {
+	listKinds := map[schema.GroupVersionResource]string{}
+	for _, k := range daprResourceKinds {
+		listKinds[k.gvr] = k.kind + "List"
+	}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds,
+		daprResource("Component", "default", "statestore", nil),
+		daprResource("Configuration", "dapr-system", "daprsystem", map[string]string{"meta.helm.sh/release-name": "dapr"}),
+		daprResource("Subscription", "orders", "order-sub", nil),
+	)
+
+	resources, err := listDaprResources(client)
+	require.NoError(t, err)
+	names := []string{}
+	for _, r := range resources {
+		names = append(names, r.String())
+	}
+	assert.Equal(t, []string{"Component default/statestore", "Subscription orders/order-sub"}, names, "resources of the release are removed with it")
+}
+
+func TestBackupResources(t *testing.T) {
+	obj := daprResource("Component", "default", "statestore", map[string]string{
+		"kubectl.kubernetes.io/last-applied-configuration": "{}",
+	})
+	obj.SetResourceVersion("42")
+	obj.SetUID("3f0b3a8c")
+	require.NoError(t, unstructured.SetNestedField(obj.Object, "state.redis", "spec", "type"))
+
+	dir := filepath.Join(t.TempDir(), "backup")
+	err := backupResources(dir, []DaprResource{{Kind: "Component", Namespace: "default", Name: "statestore", object: obj}})
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(filepath.Join(dir, "component-default-statestore.yaml"))
+	require.NoError(t, err)
+	if goruntime.GOOS != "windows" {
+		info, err := os.Stat(filepath.Join(dir, "component-default-statestore.yaml"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "backups can hold credentials")
+	}
+	assert.Equal(t, `apiVersion: dapr.io/v1alpha1
+kind: Component
+metadata:
+  name: statestore
+  namespace: default
+spec:
+  type: state.redis
+`, string(b))
+}
+
+func TestConfirmUninstall(t *testing.T) {
+	resources := []DaprResource{{Kind: "Component", Namespace: "default", Name: "statestore"}}
+	conf := UninstallConfig{Namespace: "dapr-system"}
+
+	var out bytes.Buffer
+	ok, err := ConfirmUninstall(strings.NewReader("maybe\nyes\n"), &out, conf, resources)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Contains(t, out.String(), "Component default/statestore (orphaned)")
+	assert.Contains(t, out.String(), "Please answer yes or no")
+
+	conf.DeleteCRDs = true
+	out.Reset()
+	ok, err = ConfirmUninstall(strings.NewReader("\n"), &out, conf, resources)
+	require.NoError(t, err)
+	assert.False(t, ok, "the removal is not confirmed by default")
+	assert.Contains(t, out.String(), "Component default/statestore (deleted with its CRD)")
+
+	ok, err = ConfirmUninstall(strings.NewReader(""), &out, conf, resources)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestCheckNamespaceDeletable(t *testing.T) {
+	assert.NoError(t, checkNamespaceDeletable("dapr-system"))
+	for _, ns := range []string{"default", "kube-system", "kube-public", "kube-node-lease"} {
+		assert.Error(t, checkNamespaceDeletable(ns), ns)
+	}
+}
+
+func TestCheckNamespaceWorkloads(t *testing.T) {
+	helmRelease := map[string]string{"meta.helm.sh/release-name": "dapr"}
+	controller := true
+	daprObjects := []runtime.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "dapr-operator", Namespace: "dapr-system", Annotations: helmRelease}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "dapr-placement-server", Namespace: "dapr-system", Annotations: helmRelease}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: "dapr-placement-server-0", Namespace: "dapr-system",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: "dapr-placement-server", Controller: &controller}},
+		}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "shop"}},
+	}
+	assert.NoError(t, checkNamespaceWorkloads(fake.NewSimpleClientset(daprObjects...), "dapr-system"))
+
+	objects := append(daprObjects,
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "redis-exporter", Namespace: "dapr-system"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "dapr-system"}},
+	)
+	err := checkNamespaceWorkloads(fake.NewSimpleClientset(objects...), "dapr-system")
+	assert.EqualError(t, err, "refusing to delete the dapr-system namespace, which also holds Deployment redis-exporter, Pod debug. Use --force to delete them with it")
+}
@@ -15,11 +15,12 @@ package kubernetes
 
 import (
 	"fmt"
-	"net/http"
 	"os"
+	"path/filepath"
 	"time"
 
 	helm "helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
 	"k8s.io/helm/pkg/strvals"
 
 	"github.com/hashicorp/go-version"
@@ -49,6 +50,12 @@ type UpgradeConfig struct {
 	Args             []string
 	Timeout          uint
 	ImageRegistryURI string
+	// ChartSource is where the Dapr Helm chart is pulled from. The CRDs of a custom chart are applied from the
+	// chart rather than from GitHub.
+	ChartSource ChartSource
+	// DryRun prints the CRDs and the Helm upgrade that would be applied, and the changes to the Kubernetes
+	// resources, without applying them.
+	DryRun bool
 }
 
 func Upgrade(conf UpgradeConfig) error {
@@ -65,11 +72,6 @@ func Upgrade(conf UpgradeConfig) error {
 		return err
 	}
 
-	daprChart, err := daprChart(conf.RuntimeVersion, helmConf)
-	if err != nil {
-		return err
-	}
-
 	upgradeClient := helm.NewUpgrade(helmConf)
 	upgradeClient.ResetValues = true
 	upgradeClient.Namespace = status[0].Namespace
@@ -77,8 +79,6 @@ func Upgrade(conf UpgradeConfig) error {
 	upgradeClient.Wait = true
 	upgradeClient.Timeout = time.Duration(conf.Timeout) * time.Second
 
-	print.InfoStatusEvent(os.Stdout, "Starting upgrade...")
-
 	mtls, err := IsMTLSEnabled()
 	if err != nil {
 		return err
@@ -89,7 +89,9 @@ func Upgrade(conf UpgradeConfig) error {
 	var issuerCert []byte
 	var issuerKey []byte
 
-	if mtls {
+	if mtls && conf.DryRun {
+		ca, issuerCert, issuerKey = []byte(redactedValue), []byte(redactedValue), []byte(redactedValue)
+	} else if mtls {
 		secret, sErr := getTrustChainSecret()
 		if sErr != nil {
 			return sErr
@@ -106,11 +108,37 @@ func Upgrade(conf UpgradeConfig) error {
 		return err
 	}
 
-	if !isDowngrade(conf.RuntimeVersion, daprVersion) {
-		err = applyCRDs(fmt.Sprintf("v%s", conf.RuntimeVersion))
+	downgrade := isDowngrade(conf.RuntimeVersion, daprVersion)
+	if conf.DryRun {
+		actions, err := upgradeActions(conf, status[0].Namespace, vals, downgrade)
+		if err != nil {
+			return err
+		}
+		printDryRunActions(actions)
+		printUpgradeDiff(helmConf, upgradeClient, conf.RuntimeVersion, conf.ChartSource, vals, downgrade)
+		return nil
+	}
+
+	print.InfoStatusEvent(os.Stdout, "Starting upgrade...")
+
+	daprChart, err := daprChart(conf.RuntimeVersion, helmConf, conf.ChartSource)
+	if err != nil {
+		return err
+	}
+
+	if !downgrade {
+		crdsStep := print.BeginStep(os.Stdout, "Applying the CRDs")
+		defer crdsStep.End(print.Failure)
+		// Helm does not upgrade the CRDs of a chart, so those of a custom chart are applied here.
+		if conf.ChartSource.Custom() {
+			err = applyChartCRDs(daprChart)
+		} else {
+			err = applyCRDs(fmt.Sprintf("v%s", conf.RuntimeVersion))
+		}
 		if err != nil {
 			return err
 		}
+		crdsStep.End(print.Success)
 	} else {
 		print.InfoStatusEvent(os.Stdout, "Downgrade detected, skipping CRDs.")
 	}
@@ -120,9 +148,14 @@ func Upgrade(conf UpgradeConfig) error {
 		return err
 	}
 
+	releaseStep := print.BeginStep(os.Stdout, "Upgrading the Helm release")
+	defer releaseStep.End(print.Failure)
+	print.DebugStatusEvent(os.Stderr, "Upgrading release %s to chart version %s", chart, daprChart.Metadata.Version)
+
 	if _, err = upgradeClient.Run(chart, daprChart, vals); err != nil {
 		return err
 	}
+	releaseStep.End(print.Success)
 	return nil
 }
 
@@ -140,9 +173,9 @@ func highAvailabilityEnabled(status []StatusOutput) bool {
 
 func applyCRDs(version string) error {
 	for _, crd := range crds {
-		url := fmt.Sprintf("https://raw.githubusercontent.com/dapr/dapr/%s/charts/dapr/crds/%s.yaml", version, crd)
+		url := crdURL(version, crd)
 
-		resp, _ := http.Get(url) // nolint:gosec
+		resp, _ := utils.NewHTTPClient(0).Get(url) // nolint:gosec,noctx
 		if resp != nil && resp.StatusCode == 200 {
 			defer resp.Body.Close()
 
@@ -155,6 +188,27 @@ func applyCRDs(version string) error {
 	return nil
 }
 
+// applyChartCRDs applies the CRDs of the chart c.
+func applyChartCRDs(c *chart.Chart) error {
+	dir, err := createTempDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	manifestPath := filepath.Join(dir, "crds.yaml")
+	if err = os.WriteFile(manifestPath, []byte(crdManifest(c)), 0o600); err != nil {
+		return fmt.Errorf("error writing the CRDs of the chart: %w", err)
+	}
+	_, err = utils.RunCmdAndWait("kubectl", "apply", "-f", manifestPath)
+	return err
+}
+
+// crdURL returns the URL of the manifest of a CRD of the given version of Dapr.
+func crdURL(version, crd string) string {
+	return fmt.Sprintf("https://raw.githubusercontent.com/dapr/dapr/%s/charts/dapr/crds/%s.yaml", version, crd)
+}
+
 func upgradeChartValues(ca, issuerCert, issuerKey string, haMode, mtls bool, conf UpgradeConfig) (map[string]interface{}, error) {
 	chartVals := map[string]interface{}{}
 	globalVals := conf.Args
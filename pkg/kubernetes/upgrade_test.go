@@ -76,3 +76,48 @@ func TestIsDowngrade(t *testing.T) {
 	assert.False(t, isDowngrade("1.4.0-rc.5", "1.3.0"))
 	assert.False(t, isDowngrade("1.4.0", "1.3.0"))
 }
+
+func TestExportChartValues(t *testing.T) {
+	t.Run("derived values are merged into the user values", func(t *testing.T) {
+		user := map[string]interface{}{
+			"global": map[string]interface{}{
+				"logAsJson": true,
+				"ha":        map[string]interface{}{"enabled": false, "replicaCount": 5},
+			},
+			"dapr_operator": map[string]interface{}{"replicaCount": 2},
+		}
+		values, omitted := exportChartValues(user, true, true, "ghcr.io/dapr")
+		assert.Empty(t, omitted)
+		assert.Equal(t, map[string]interface{}{
+			"global": map[string]interface{}{
+				"logAsJson": true,
+				"ha":        map[string]interface{}{"enabled": true, "replicaCount": 5},
+				"mtls":      map[string]interface{}{"enabled": true},
+				"registry":  "ghcr.io/dapr",
+			},
+			"dapr_operator": map[string]interface{}{"replicaCount": 2},
+		}, values)
+	})
+
+	t.Run("default registry is not exported", func(t *testing.T) {
+		values, _ := exportChartValues(map[string]interface{}{}, false, false, defaultChartRegistry)
+		assert.NotContains(t, values["global"], "registry")
+	})
+
+	t.Run("trust chain is omitted", func(t *testing.T) {
+		user := map[string]interface{}{
+			"dapr_sentry": map[string]interface{}{
+				"tls": map[string]interface{}{"issuer": map[string]interface{}{"keyPEM": "key"}},
+			},
+		}
+		values, omitted := exportChartValues(user, false, true, "")
+		assert.Equal(t, []string{"dapr_sentry.tls"}, omitted)
+		assert.NotContains(t, values, "dapr_sentry")
+	})
+}
+
+func TestImageRegistry(t *testing.T) {
+	assert.Equal(t, "ghcr.io/dapr", imageRegistry("ghcr.io/dapr/operator:1.9.0"))
+	assert.Equal(t, "docker.io/daprio", imageRegistry("docker.io/daprio/operator:1.9.0"))
+	assert.Equal(t, "", imageRegistry("operator:1.9.0"))
+}
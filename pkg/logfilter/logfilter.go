@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logfilter filters the log lines of Dapr sidecars by level and highlights the matches of a pattern.
+package logfilter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+)
+
+// Levels are the log levels of daprd, from the most to the least verbose.
+var Levels = []string{"debug", "info", "warn", "error", "fatal"}
+
+// lineLevel matches the level of a daprd line, logged as text (level=info) or as JSON ("level":"info").
+var lineLevel = regexp.MustCompile(`(?:^|[\s{,])(?:level=|"level":\s*)"?([a-zA-Z]+)`)
+
+// highlight is the style of the matches of the pattern.
+var highlight = color.New(color.FgBlack, color.BgYellow)
+
+// Config represents the options of a Writer.
+type Config struct {
+	// Level is the least severe level of the daprd lines that are written. Lines without a level, such as those of
+	// apps, are always written. All lines are written if it is empty.
+	Level string
+	// Pattern is a regular expression whose matches are highlighted. Nothing is highlighted if it is empty.
+	Pattern string
+}
+
+// Writer writes the lines written to it to another writer, except for those filtered out by level, with the matches
+// of a pattern highlighted.
+type Writer struct {
+	mu      sync.Mutex
+	out     io.Writer
+	level   int
+	pattern *regexp.Regexp
+	buf     []byte
+}
+
+// NewWriter returns a Writer that writes to out with the given options.
+func NewWriter(out io.Writer, config Config) (*Writer, error) {
+	w := &Writer{out: out, level: -1}
+	if config.Level != "" {
+		w.level = levelIndex(config.Level)
+		if w.level < 0 {
+			return nil, fmt.Errorf("invalid log level %q. Valid values are: %s", config.Level, strings.Join(Levels, ", "))
+		}
+	}
+	if config.Pattern != "" {
+		pattern, err := regexp.Compile(config.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", config.Pattern, err)
+		}
+		w.pattern = pattern
+	}
+	return w, nil
+}
+
+// Write writes the complete lines of p. The rest is kept until its line ends or Flush is called.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := string(w.buf[:i+1])
+		w.buf = w.buf[i+1:]
+		if err := w.writeLine(line); err != nil {
+			return len(p), err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush writes the last line, if it does not end with a line break.
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+	line := string(w.buf)
+	w.buf = nil
+	return w.writeLine(line)
+}
+
+func (w *Writer) writeLine(line string) error {
+	if !w.keep(line) {
+		return nil
+	}
+	if w.pattern != nil {
+		line = w.pattern.ReplaceAllStringFunc(line, func(match string) string {
+			return highlight.Sprint(match)
+		})
+	}
+	_, err := io.WriteString(w.out, line)
+	return err
+}
+
+// keep returns true if line has no level or a level at least as severe as the level of w.
+func (w *Writer) keep(line string) bool {
+	if w.level < 0 {
+		return true
+	}
+	level, ok := LineLevel(line)
+	if !ok {
+		return true
+	}
+	return levelIndex(level) >= w.level
+}
+
+// LineLevel returns the level of a daprd log line as one of Levels, if it has one.
+func LineLevel(line string) (string, bool) {
+	match := lineLevel.FindStringSubmatch(line)
+	if match == nil {
+		return "", false
+	}
+	i := levelIndex(match[1])
+	if i < 0 {
+		return "", false
+	}
+	return Levels[i], true
+}
+
+// levelIndex returns the position of level in Levels, or -1 if it is not a level of daprd.
+// The warning and panic levels of logrus are treated as warn and fatal.
+func levelIndex(level string) int {
+	switch level = strings.ToLower(level); level {
+	case "warning":
+		level = "warn"
+	case "panic":
+		level = "fatal"
+	}
+	for i, l := range Levels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
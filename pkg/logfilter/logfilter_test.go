@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logfilter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+const testLogs = `== DAPR == time="2022-08-01T10:00:00Z" level=debug msg="loading components" app_id=orders scope=dapr.runtime
+== DAPR == time="2022-08-01T10:00:01Z" level=info msg="component loaded. name: statestore" app_id=orders scope=dapr.runtime
+== APP == listening on port 3000
+== DAPR == time="2022-08-01T10:00:02Z" level=warning msg="app channel not ready" app_id=orders scope=dapr.runtime
+{"app_id":"orders","level":"error","msg":"error invoking app","scope":"dapr.runtime"}
+`
+
+func TestLineLevel(t *testing.T) {
+	tests := []struct {
+		line  string
+		level string
+		ok    bool
+	}{
+		{`time="2022-08-01T10:00:00Z" level=info msg="starting Dapr Runtime"`, "info", true},
+		{`level=WARNING msg="retrying"`, "warn", true},
+		{`{"level":"fatal","msg":"failed"}`, "fatal", true},
+		{`{"app_id":"orders", "level": "debug"}`, "debug", true},
+		{`msg="the log level=unknown"`, "", false},
+		{`listening on port 3000`, "", false},
+		{`sublevel=info`, "", false},
+	}
+	for _, tc := range tests {
+		level, ok := LineLevel(tc.line)
+		assert.Equal(t, tc.ok, ok, tc.line)
+		assert.Equal(t, tc.level, level, tc.line)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	t.Run("level", func(t *testing.T) {
+		var out bytes.Buffer
+		w, err := NewWriter(&out, Config{Level: "warn"})
+		assert.NoError(t, err)
+
+		_, err = w.Write([]byte(testLogs))
+		assert.NoError(t, err)
+		assert.Equal(t, `== APP == listening on port 3000
+== DAPR == time="2022-08-01T10:00:02Z" level=warning msg="app channel not ready" app_id=orders scope=dapr.runtime
+{"app_id":"orders","level":"error","msg":"error invoking app","scope":"dapr.runtime"}
+`, out.String())
+	})
+
+	t.Run("partial lines", func(t *testing.T) {
+		var out bytes.Buffer
+		w, err := NewWriter(&out, Config{Level: "info"})
+		assert.NoError(t, err)
+
+		w.Write([]byte("level=debug msg=one\nlevel=info "))
+		assert.Equal(t, "", out.String())
+		w.Write([]byte("msg=two\nlevel=error"))
+		assert.Equal(t, "level=info msg=two\n", out.String())
+		assert.NoError(t, w.Flush())
+		assert.Equal(t, "level=info msg=two\nlevel=error", out.String())
+	})
+
+	t.Run("pattern", func(t *testing.T) {
+		colorNoColorBefore := color.NoColor
+		t.Cleanup(func() { color.NoColor = colorNoColorBefore })
+		color.NoColor = false
+
+		var out bytes.Buffer
+		w, err := NewWriter(&out, Config{Pattern: "state[a-z]+"})
+		assert.NoError(t, err)
+		w.Write([]byte("component loaded. name: statestore, type: state.redis\n"))
+		assert.Equal(t, "component loaded. name: \x1b[30;43mstatestore\x1b[0m, type: state.redis\n", out.String())
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := NewWriter(nil, Config{Level: "verbose"})
+		assert.EqualError(t, err, `invalid log level "verbose". Valid values are: debug, info, warn, error, fatal`)
+		_, err = NewWriter(nil, Config{Pattern: "("})
+		assert.Error(t, err)
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 
 	retryablehttp "github.com/hashicorp/go-retryablehttp"
@@ -93,6 +94,56 @@ func Put(httpPort int, key, value, appID, socket string) error {
 	return nil
 }
 
+// SubscriptionOutput is a pub/sub subscription registered by a sidecar.
+type SubscriptionOutput struct {
+	PubsubName      string             `csv:"PUBSUB NAME"       json:"pubsubName"                yaml:"pubsubName"`
+	Topic           string             `csv:"TOPIC"             json:"topic"                     yaml:"topic"`
+	Routes          SubscriptionRoutes `csv:"ROUTES"            json:"routes"                    yaml:"routes"`
+	DeadLetterTopic string             `csv:"DEAD LETTER TOPIC" json:"deadLetterTopic,omitempty" yaml:"deadLetterTopic,omitempty"`
+	Type            string             `csv:"TYPE"              json:"type,omitempty"            yaml:"type,omitempty"`
+}
+
+// SubscriptionRoutes are the routing rules of a subscription.
+type SubscriptionRoutes []api.MetadataSubscriptionRule
+
+// MarshalCSV prints the routes in tables as their paths, with the match expression of the rules that have one.
+func (r SubscriptionRoutes) MarshalCSV() (string, error) {
+	routes := make([]string, 0, len(r))
+	for _, rule := range r {
+		if rule.Match == "" {
+			routes = append(routes, rule.Path)
+		} else {
+			routes = append(routes, fmt.Sprintf("%s (%s)", rule.Path, rule.Match))
+		}
+	}
+	return strings.Join(routes, ", "), nil
+}
+
+// Subscriptions returns the subscriptions of the metadata of a sidecar, sorted by pub/sub and topic.
+func Subscriptions(m *api.Metadata) []SubscriptionOutput {
+	subscriptions := make([]SubscriptionOutput, 0, len(m.Subscriptions))
+	for _, s := range m.Subscriptions {
+		routes := SubscriptionRoutes(s.Rules)
+		if routes == nil {
+			routes = SubscriptionRoutes{}
+		}
+		subscriptions = append(subscriptions, SubscriptionOutput{
+			PubsubName:      s.PubsubName,
+			Topic:           s.Topic,
+			Routes:          routes,
+			DeadLetterTopic: s.DeadLetterTopic,
+			Type:            strings.ToLower(s.Type),
+		})
+	}
+	sort.SliceStable(subscriptions, func(i, j int) bool {
+		if subscriptions[i].PubsubName != subscriptions[j].PubsubName {
+			return subscriptions[i].PubsubName < subscriptions[j].PubsubName
+		}
+		return subscriptions[i].Topic < subscriptions[j].Topic
+	})
+	return subscriptions
+}
+
 func makeMetadataGetEndpoint(httpPort int) string {
 	if httpPort == 0 {
 		return fmt.Sprintf("http://unix/v%s/metadata", api.RuntimeAPIVersion)
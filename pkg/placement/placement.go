@@ -0,0 +1,116 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	placementv1pb "github.com/dapr/dapr/pkg/proto/placement/v1"
+)
+
+// updateOperation is the operation of the placement order that holds the placement tables.
+const updateOperation = "update"
+
+// ErrNotLeader is returned by Dump if the placement server is not the leader of a highly available placement cluster.
+// Only the leader disseminates the placement tables.
+var ErrNotLeader = errors.New("placement server is not the leader")
+
+// TableOutput is a host of an actor type in the placement table, with the share of virtual nodes
+// of the consistent hash ring of the actor type it owns.
+type TableOutput struct {
+	ActorType    string `csv:"ACTOR TYPE" json:"actorType"    yaml:"actorType"`
+	Host         string `csv:"HOST"       json:"host"         yaml:"host"`
+	AppID        string `csv:"APP ID"     json:"appId"        yaml:"appId"`
+	VNodes       int    `csv:"VNODES"     json:"vnodes"       yaml:"vnodes"`
+	Share        string `csv:"SHARE"      json:"share"        yaml:"share"`
+	TableVersion string `csv:"-"          json:"tableVersion" yaml:"tableVersion"`
+}
+
+// Dump returns the placement table of the placement server at address. The CLI connects like a sidecar
+// that hosts no actors, so it is not added to the placement table and does not trigger a rebalancing.
+func Dump(ctx context.Context, address string, opts ...grpc.DialOption) ([]TableOutput, string, error) {
+	conn, err := grpc.DialContext(ctx, address, opts...)
+	if err != nil {
+		return nil, "", fmt.Errorf("error connecting to the placement server at %s: %w", address, err)
+	}
+	defer conn.Close()
+
+	stream, err := placementv1pb.NewPlacementClient(conn).ReportDaprStatus(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("error connecting to the placement server at %s: %w", address, err)
+	}
+	defer stream.CloseSend()
+
+	hostname, _ := os.Hostname()
+	host := &placementv1pb.Host{
+		Name: fmt.Sprintf("dapr-cli-%s-%d", hostname, os.Getpid()),
+		Id:   "dapr-cli",
+	}
+	if err = stream.Send(host); err != nil {
+		return nil, "", fmt.Errorf("error reading the placement table: %w", err)
+	}
+	for {
+		order, err := stream.Recv()
+		if err != nil {
+			if status.Code(err) == codes.FailedPrecondition {
+				return nil, "", ErrNotLeader
+			}
+			return nil, "", fmt.Errorf("error reading the placement table: %w", err)
+		}
+		if order.GetOperation() == updateOperation {
+			return tableRows(order.GetTables()), order.GetTables().GetVersion(), nil
+		}
+	}
+}
+
+// tableRows returns the hosts of every actor type in the placement tables, sorted by actor type and host.
+func tableRows(tables *placementv1pb.PlacementTables) []TableOutput {
+	rows := []TableOutput{}
+	for actorType, table := range tables.GetEntries() {
+		vnodes := map[string]int{}
+		for _, host := range table.GetHosts() {
+			vnodes[host]++
+		}
+		total := len(table.GetHosts())
+		for name, host := range table.GetLoadMap() {
+			share := 0.0
+			if total > 0 {
+				share = float64(vnodes[name]) / float64(total) * 100
+			}
+			rows = append(rows, TableOutput{
+				ActorType:    actorType,
+				Host:         name,
+				AppID:        host.GetId(),
+				VNodes:       vnodes[name],
+				Share:        fmt.Sprintf("%.1f%%", share),
+				TableVersion: tables.GetVersion(),
+			})
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].ActorType != rows[j].ActorType {
+			return rows[i].ActorType < rows[j].ActorType
+		}
+		return rows[i].Host < rows[j].Host
+	})
+	return rows
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package placement
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	placementv1pb "github.com/dapr/dapr/pkg/proto/placement/v1"
+)
+
+var testTables = &placementv1pb.PlacementTables{
+	Version: "42",
+	Entries: map[string]*placementv1pb.PlacementTable{
+		"Order": {
+			Hosts: map[uint64]string{
+				1: "10.0.0.2:50002", 2: "10.0.0.1:50002", 3: "10.0.0.1:50002", 4: "10.0.0.2:50002",
+				5: "10.0.0.1:50002", 6: "10.0.0.1:50002", 7: "10.0.0.1:50002", 8: "10.0.0.2:50002",
+			},
+			LoadMap: map[string]*placementv1pb.Host{
+				"10.0.0.1:50002": {Name: "10.0.0.1:50002", Id: "orders"},
+				"10.0.0.2:50002": {Name: "10.0.0.2:50002", Id: "orders"},
+			},
+		},
+		"Cart": {
+			Hosts:   map[uint64]string{1: "10.0.0.3:50002"},
+			LoadMap: map[string]*placementv1pb.Host{"10.0.0.3:50002": {Name: "10.0.0.3:50002", Id: "carts"}},
+		},
+	},
+}
+
+type mockPlacementServer struct {
+	placementv1pb.UnimplementedPlacementServer
+	notLeader bool
+	// reported receives the host reported by the client.
+	reported chan *placementv1pb.Host
+}
+
+func (m *mockPlacementServer) ReportDaprStatus(stream placementv1pb.Placement_ReportDaprStatusServer) error {
+	if m.notLeader {
+		return status.Error(codes.FailedPrecondition, "only leader can serve the request")
+	}
+	host, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	m.reported <- host
+	for _, order := range []*placementv1pb.PlacementOrder{
+		{Operation: "lock"},
+		{Operation: "update", Tables: testTables},
+		{Operation: "unlock"},
+	} {
+		if err = stream.Send(order); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startPlacementServer(t *testing.T, server *mockPlacementServer) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	placementv1pb.RegisterPlacementServer(s, server)
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+	return l.Addr().String()
+}
+
+func TestDump(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	t.Run("dump the placement table", func(t *testing.T) {
+		server := &mockPlacementServer{reported: make(chan *placementv1pb.Host, 1)}
+		address := startPlacementServer(t, server)
+
+		rows, version, err := Dump(ctx, address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		assert.Equal(t, "42", version)
+		assert.Equal(t, []TableOutput{
+			{ActorType: "Cart", Host: "10.0.0.3:50002", AppID: "carts", VNodes: 1, Share: "100.0%", TableVersion: "42"},
+			{ActorType: "Order", Host: "10.0.0.1:50002", AppID: "orders", VNodes: 5, Share: "62.5%", TableVersion: "42"},
+			{ActorType: "Order", Host: "10.0.0.2:50002", AppID: "orders", VNodes: 3, Share: "37.5%", TableVersion: "42"},
+		}, rows)
+
+		reported := <-server.reported
+		assert.Empty(t, reported.GetEntities(), "the CLI must not register as an actor host")
+	})
+
+	t.Run("placement server is not the leader", func(t *testing.T) {
+		address := startPlacementServer(t, &mockPlacementServer{notLeader: true})
+		_, _, err := Dump(ctx, address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert.ErrorIs(t, err, ErrNotLeader)
+	})
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plugin discovers and runs CLI plugins, executables named dapr-<name> in the PATH that are run
+// as `dapr <name>`, like kubectl plugins.
+package plugin
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Prefix is the prefix of the file name of a plugin.
+const Prefix = "dapr-"
+
+// ListOutput represents a plugin found in the PATH.
+type ListOutput struct {
+	Name string `csv:"NAME" json:"name" yaml:"name"`
+	Path string `csv:"PATH" json:"path" yaml:"path"`
+	// Shadowed is true if the plugin is not run because a plugin with the same name comes first in the PATH.
+	Shadowed bool `csv:"-" json:"shadowed" yaml:"shadowed"`
+}
+
+// List returns the plugins in the directories of pathEnv, a list like the PATH environment variable,
+// sorted by name in the order of the directories.
+func List(pathEnv string) []ListOutput {
+	plugins := []ListOutput{}
+	found := map[string]bool{}
+	for _, dir := range filepath.SplitList(pathEnv) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			// Directories in the PATH that do not exist or cannot be read are skipped, as by the shell.
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok || entry.IsDir() || !isExecutable(filepath.Join(dir, entry.Name())) {
+				continue
+			}
+			plugins = append(plugins, ListOutput{Name: name, Path: filepath.Join(dir, entry.Name()), Shadowed: found[name]})
+			found[name] = true
+		}
+	}
+	sort.SliceStable(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins
+}
+
+// Find returns the path of the plugin that handles args, the arguments of the CLI without the program name,
+// and the arguments to pass to it. The plugin of the longest sequence of leading arguments that are not flags
+// is used, so `dapr deploy app foo` runs dapr-deploy-app with foo if it exists, otherwise dapr-deploy with app foo.
+func Find(args []string) (string, []string, bool) {
+	names := []string{}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		names = append(names, arg)
+	}
+	for n := len(names); n > 0; n-- {
+		path, err := exec.LookPath(Prefix + strings.Join(names[:n], "-"))
+		if err == nil {
+			return path, args[n:], true
+		}
+	}
+	return "", nil, false
+}
+
+// Run runs the plugin at path with args, connected to the standard input and outputs of the CLI,
+// and returns its exit code.
+func Run(path string, args []string) (int, error) {
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
+
+// pluginName returns the name of the plugin of a file name, without the prefix and the extension of
+// executables on Windows.
+func pluginName(fileName string) (string, bool) {
+	if !strings.HasPrefix(fileName, Prefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(fileName, Prefix)
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name, name != ""
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	// On Windows, executables are recognized by their extension.
+	return runtime.GOOS == "windows" || info.Mode()&0o111 != 0
+}
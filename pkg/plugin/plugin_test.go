@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeScript(t *testing.T, dir, name, script string, mode os.FileMode) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script+"\n"), mode))
+}
+
+func TestPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts in this test")
+	}
+	dir1, dir2 := t.TempDir(), t.TempDir()
+	writeScript(t, dir1, "dapr-hello", "exit 0", 0o755)
+	writeScript(t, dir1, "dapr-deploy-app", `echo "$@"; exit 3`, 0o755)
+	writeScript(t, dir1, "dapr-notexec", "exit 0", 0o644)
+	writeScript(t, dir1, "other", "exit 0", 0o755)
+	writeScript(t, dir2, "dapr-hello", "exit 0", 0o755)
+	writeScript(t, dir2, "dapr-deploy", "exit 0", 0o755)
+	pathEnv := strings.Join([]string{dir1, filepath.Join(dir1, "missing"), dir2}, string(os.PathListSeparator))
+	t.Setenv("PATH", pathEnv)
+
+	t.Run("list", func(t *testing.T) {
+		assert.Equal(t, []ListOutput{
+			{Name: "deploy", Path: filepath.Join(dir2, "dapr-deploy")},
+			{Name: "deploy-app", Path: filepath.Join(dir1, "dapr-deploy-app")},
+			{Name: "hello", Path: filepath.Join(dir1, "dapr-hello")},
+			{Name: "hello", Path: filepath.Join(dir2, "dapr-hello"), Shadowed: true},
+		}, List(pathEnv))
+	})
+
+	t.Run("find the longest name", func(t *testing.T) {
+		path, args, ok := Find([]string{"deploy", "app", "orders", "--wait"})
+		require.True(t, ok)
+		assert.Equal(t, filepath.Join(dir1, "dapr-deploy-app"), path)
+		assert.Equal(t, []string{"orders", "--wait"}, args)
+
+		path, args, ok = Find([]string{"deploy", "--app", "orders"})
+		require.True(t, ok)
+		assert.Equal(t, filepath.Join(dir2, "dapr-deploy"), path)
+		assert.Equal(t, []string{"--app", "orders"}, args)
+	})
+
+	t.Run("unknown plugin", func(t *testing.T) {
+		_, _, ok := Find([]string{"notexec"})
+		assert.False(t, ok)
+		_, _, ok = Find([]string{"--help"})
+		assert.False(t, ok)
+	})
+
+	t.Run("exit code", func(t *testing.T) {
+		code, err := Run(filepath.Join(dir1, "dapr-deploy-app"), []string{"orders"})
+		require.NoError(t, err)
+		assert.Equal(t, 3, code)
+	})
+}
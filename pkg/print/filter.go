@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package print
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// FilterRows returns the rows of a table, a slice of structs, whose fields match all filters. The keys of filters
+// are the `json` tags of the fields in kebab case, such as app-id for appId, and values are compared with the
+// formatted fields, ignoring case. The returned slice has the type of rows.
+func FilterRows(rows interface{}, filters map[string]string) (interface{}, error) {
+	elemType, err := tableRowType(rows)
+	if err != nil {
+		return nil, err
+	}
+	fields := filterFields(elemType)
+	indexes := make(map[string]int, len(filters))
+	for key := range filters {
+		index, ok := fields[key]
+		if !ok {
+			keys := make([]string, 0, len(fields))
+			for k := range fields {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			return nil, fmt.Errorf("invalid filter key %q. Valid keys are: %s", key, strings.Join(keys, ", "))
+		}
+		indexes[key] = index
+	}
+
+	v := reflect.ValueOf(rows)
+	filtered := reflect.MakeSlice(v.Type(), 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		row := reflect.Indirect(v.Index(i))
+		match := true
+		for key, value := range filters {
+			if !strings.EqualFold(fmt.Sprintf("%v", row.Field(indexes[key]).Interface()), value) {
+				match = false
+				break
+			}
+		}
+		if match {
+			filtered = reflect.Append(filtered, v.Index(i))
+		}
+	}
+	return filtered.Interface(), nil
+}
+
+// filterFields returns the indexes of the fields of a row type by filter key.
+func filterFields(elemType reflect.Type) map[string]int {
+	fields := map[string]int{}
+	for i := 0; i < elemType.NumField(); i++ {
+		name, _, _ := strings.Cut(elemType.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[kebabCase(name)] = i
+	}
+	return fields
+}
+
+// kebabCase converts a camel case name, such as appId, to kebab case, such as app-id.
+func kebabCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// WriteTemplate writes each row of rows, a slice of structs, with the Go template text, such as
+// '{{.AppID}} {{.HTTPPort}}', followed by a new line.
+func WriteTemplate(w io.Writer, rows interface{}, text string) error {
+	if _, err := tableRowType(rows); err != nil {
+		return err
+	}
+	tmpl, err := template.New("row").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	v := reflect.ValueOf(rows)
+	for i := 0; i < v.Len(); i++ {
+		if err = tmpl.Execute(w, v.Index(i).Interface()); err != nil {
+			return fmt.Errorf("error executing template: %w", err)
+		}
+		if !strings.HasSuffix(text, "\n") {
+			fmt.Fprintln(w)
+		}
+	}
+	return nil
+}
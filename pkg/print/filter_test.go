@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package print
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type filterRow struct {
+	AppID    string `json:"appId"`
+	HTTPPort int    `json:"httpPort"`
+	Status   string `json:"status,omitempty"`
+	Internal string `json:"-"`
+}
+
+var filterRows = []filterRow{
+	{AppID: "orders", HTTPPort: 3500, Status: "Running"},
+	{AppID: "checkout", HTTPPort: 3501, Status: "stopped"},
+	{AppID: "payments", HTTPPort: 3502, Status: "running"},
+}
+
+func TestFilterRows(t *testing.T) {
+	t.Run("all filters match", func(t *testing.T) {
+		rows, err := FilterRows(filterRows, map[string]string{"status": "running", "http-port": "3502"})
+		require.NoError(t, err)
+		assert.Equal(t, []filterRow{filterRows[2]}, rows)
+	})
+
+	t.Run("values ignore case", func(t *testing.T) {
+		rows, err := FilterRows(filterRows, map[string]string{"status": "RUNNING"})
+		require.NoError(t, err)
+		assert.Equal(t, []filterRow{filterRows[0], filterRows[2]}, rows)
+	})
+
+	t.Run("no filters", func(t *testing.T) {
+		rows, err := FilterRows(filterRows, nil)
+		require.NoError(t, err)
+		assert.Equal(t, filterRows, rows)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := FilterRows(filterRows, map[string]string{"internal": "x"})
+		assert.EqualError(t, err, `invalid filter key "internal". Valid keys are: app-id, http-port, status`)
+	})
+}
+
+func TestWriteTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteTemplate(&buf, filterRows[:2], "{{.AppID}} {{.HTTPPort}}"))
+	assert.Equal(t, "orders 3500\ncheckout 3501\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteTemplate(&buf, filterRows[:1], "{{.AppID}}\n"))
+	assert.Equal(t, "orders\n", buf.String())
+
+	assert.ErrorContains(t, WriteTemplate(&buf, filterRows, "{{.AppID"), "invalid template")
+	assert.ErrorContains(t, WriteTemplate(&buf, filterRows, "{{.Missing}}"), "error executing template")
+}
@@ -14,10 +14,10 @@ limitations under the License.
 package print
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
-	"runtime"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,6 +31,37 @@ const (
 
 type Result bool
 
+// LogLevel is the minimum severity of the status events that are printed.
+type LogLevel int
+
+const (
+	DebugLevel LogLevel = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+var logLevelNames = map[LogLevel]string{
+	DebugLevel: "debug",
+	InfoLevel:  "info",
+	WarnLevel:  "warn",
+	ErrorLevel: "error",
+}
+
+func (l LogLevel) String() string {
+	return logLevelNames[l]
+}
+
+// ParseLogLevel converts a log level name, such as "debug", into a LogLevel.
+func ParseLogLevel(level string) (LogLevel, error) {
+	for l, name := range logLevelNames {
+		if strings.EqualFold(level, name) {
+			return l, nil
+		}
+	}
+	return InfoLevel, fmt.Errorf("invalid log level %q. Valid values are: debug, info, warn, or error", level)
+}
+
 const (
 	Success Result = true
 	Failure Result = false
@@ -46,69 +77,124 @@ var (
 	WhiteBold = color.New(color.FgWhite, color.Bold).SprintFunc()
 )
 
-var logAsJSON bool
+var (
+	noColor  bool
+	logLevel = InfoLevel
+	// failureHook is called with the message of every failure event, see SetFailureHook.
+	failureHook func(msg string)
+)
+
+func init() {
+	// See https://no-color.org.
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		DisableColor()
+	}
+}
+
+// SetFailureHook sets a function that is called with the message of every failure event, also if failures are not
+// printed at the selected log level. Most commands exit right after a failure, so it is the last chance to record it.
+func SetFailureHook(hook func(msg string)) {
+	failureHook = hook
+}
 
+// EnableJSONFormat selects the JSON renderer. It is the same as SetOutputFormat(JSONFormat).
 func EnableJSONFormat() {
-	logAsJSON = true
+	renderer = jsonRenderer{}
 }
 
+// IsJSONLogEnabled returns true if status events are printed as JSON.
 func IsJSONLogEnabled() bool {
-	return logAsJSON
+	_, ok := renderer.(jsonRenderer)
+	return ok
+}
+
+// SetLogLevel sets the minimum level of the status events that are printed.
+func SetLogLevel(level LogLevel) {
+	logLevel = level
+}
+
+// GetLogLevel returns the minimum level of the status events that are printed.
+func GetLogLevel() LogLevel {
+	return logLevel
+}
+
+// IsLevelEnabled returns true if status events of the given level are printed.
+func IsLevelEnabled(level LogLevel) bool {
+	return level >= logLevel
+}
+
+// DisableColor turns off ANSI colors, emoji and spinners in all output.
+func DisableColor() {
+	noColor = true
+	color.NoColor = true
+}
+
+// IsColorDisabled returns true if colored output was turned off explicitly.
+func IsColorDisabled() bool {
+	return noColor
+}
+
+// isPlainText returns true if output to w must not contain emoji or spinners.
+// This is the case when colors are disabled, when w is not a terminal, for example when output is piped to a file
+// or collected in CI logs, and on Windows, where status events have colored ASCII markers on terminals that support them.
+func isPlainText(w io.Writer) bool {
+	return outputStyle(w) != emojiStyle
+}
+
+// DebugStatusEvent reports troubleshooting details, printed only at debug level.
+func DebugStatusEvent(w io.Writer, fmtstr string, a ...interface{}) {
+	statusEvent(w, DebugLevel, "debug", "🔍", fmt.Sprintf(fmtstr, a...))
 }
 
 // SuccessStatusEvent reports on a success event.
 func SuccessStatusEvent(w io.Writer, fmtstr string, a ...interface{}) {
-	if logAsJSON {
-		logJSON(w, "success", fmt.Sprintf(fmtstr, a...))
-	} else if runtime.GOOS == windowsOS {
-		fmt.Fprintf(w, "%s\n", fmt.Sprintf(fmtstr, a...))
-	} else {
-		fmt.Fprintf(w, "✅  %s\n", fmt.Sprintf(fmtstr, a...))
-	}
+	statusEvent(w, InfoLevel, "success", "✅", fmt.Sprintf(fmtstr, a...))
 }
 
-// FailureStatusEvent reports on a failure event.
+// FailureStatusEvent reports on a failure event. The error code printed by JSONSchemaV2 is the code of the first
+// argument that is an error created with NewCodedError, or DefaultErrorCode.
 func FailureStatusEvent(w io.Writer, fmtstr string, a ...interface{}) {
-	if logAsJSON {
-		logJSON(w, "failure", fmt.Sprintf(fmtstr, a...))
-	} else if runtime.GOOS == windowsOS {
-		fmt.Fprintf(w, "%s\n", fmt.Sprintf(fmtstr, a...))
-	} else {
-		fmt.Fprintf(w, "❌  %s\n", fmt.Sprintf(fmtstr, a...))
+	msg := fmt.Sprintf(fmtstr, a...)
+	if failureHook != nil {
+		failureHook(msg)
+	}
+	if !IsLevelEnabled(ErrorLevel) {
+		return
 	}
+	if r, ok := renderer.(structuredRenderer); ok {
+		r.writeStatusLog(w, newStatusLog("failure", msg, failureErrorCode(a)))
+		return
+	}
+	renderer.StatusEvent(w, "failure", "❌", msg)
 }
 
 // WarningStatusEvent reports on a failure event.
 func WarningStatusEvent(w io.Writer, fmtstr string, a ...interface{}) {
-	if logAsJSON {
-		logJSON(w, "warning", fmt.Sprintf(fmtstr, a...))
-	} else if runtime.GOOS == windowsOS {
-		fmt.Fprintf(w, "%s\n", fmt.Sprintf(fmtstr, a...))
-	} else {
-		fmt.Fprintf(w, "⚠  %s\n", fmt.Sprintf(fmtstr, a...))
-	}
+	statusEvent(w, WarnLevel, "warning", "⚠", fmt.Sprintf(fmtstr, a...))
 }
 
 // PendingStatusEvent reports on a pending event.
 func PendingStatusEvent(w io.Writer, fmtstr string, a ...interface{}) {
-	if logAsJSON {
-		logJSON(w, "pending", fmt.Sprintf(fmtstr, a...))
-	} else if runtime.GOOS == windowsOS {
-		fmt.Fprintf(w, "%s\n", fmt.Sprintf(fmtstr, a...))
-	} else {
-		fmt.Fprintf(w, "⌛  %s\n", fmt.Sprintf(fmtstr, a...))
-	}
+	statusEvent(w, InfoLevel, "pending", "⌛", fmt.Sprintf(fmtstr, a...))
 }
 
 // InfoStatusEvent reports status information on an event.
 func InfoStatusEvent(w io.Writer, fmtstr string, a ...interface{}) {
-	if logAsJSON {
-		logJSON(w, "info", fmt.Sprintf(fmtstr, a...))
-	} else if runtime.GOOS == windowsOS {
-		fmt.Fprintf(w, "%s\n", fmt.Sprintf(fmtstr, a...))
-	} else {
-		fmt.Fprintf(w, "ℹ️  %s\n", fmt.Sprintf(fmtstr, a...))
+	statusEvent(w, InfoLevel, "info", "ℹ️", fmt.Sprintf(fmtstr, a...))
+}
+
+// DryRunStatusEvent reports on an action that a command run with --dry-run would take, without taking it.
+// It is printed like an info event, with the status dry-run in JSON and YAML output.
+func DryRunStatusEvent(w io.Writer, fmtstr string, a ...interface{}) {
+	statusEvent(w, InfoLevel, "dry-run", "📝", fmt.Sprintf(fmtstr, a...))
+}
+
+func statusEvent(w io.Writer, level LogLevel, status, emoji, msg string) {
+	if !IsLevelEnabled(level) {
+		return
 	}
+
+	renderer.StatusEvent(w, status, emoji, msg)
 }
 
 func Spinner(w io.Writer, fmtstr string, a ...interface{}) func(result Result) {
@@ -116,23 +202,35 @@ func Spinner(w io.Writer, fmtstr string, a ...interface{}) func(result Result) {
 	var once sync.Once
 	var s *spinner.Spinner
 
-	if logAsJSON {
-		logJSON(w, "pending", msg)
-	} else if runtime.GOOS == windowsOS {
-		fmt.Fprintf(w, "%s\n", msg)
+	if !IsLevelEnabled(InfoLevel) {
+		// Only failures are reported when info events are not printed.
+		return func(result Result) {
+			once.Do(func() {
+				if !result {
+					FailureStatusEvent(w, msg)
+				}
+			})
+		}
+	} else if !renderer.Interactive() {
+		renderer.StatusEvent(w, "pending", "⌛", msg)
+	} else if isPlainText(w) {
+		fmt.Fprintf(w, "%s%s\n", stepIndent(), msg)
 
 		return func(Result) {} // Return a dummy func
 	} else {
 		s = spinner.New(spinner.CharSets[0], 100*time.Millisecond)
 		s.Writer = w
+		s.Prefix = stepIndent()
 		s.Color("cyan")
 		s.Suffix = fmt.Sprintf("  %s", msg)
 		s.Start()
+		setActiveSpinner(s, s.Suffix)
 	}
 
 	return func(result Result) {
 		once.Do(func() {
 			if s != nil {
+				setActiveSpinner(nil, "")
 				s.Stop()
 			}
 			if result {
@@ -143,27 +241,3 @@ func Spinner(w io.Writer, fmtstr string, a ...interface{}) func(result Result) {
 		})
 	}
 }
-
-func logJSON(w io.Writer, status, message string) {
-	type jsonLog struct {
-		Time    time.Time `json:"time"`
-		Status  string    `json:"status"`
-		Message string    `json:"msg"`
-	}
-
-	l := jsonLog{
-		Time:    time.Now().UTC(),
-		Status:  status,
-		Message: message,
-	}
-	jsonBytes, err := json.Marshal(&l)
-	if err != nil {
-		// Fall back on printing the simple message without JSON.
-		// This is unlikely.
-		fmt.Fprintln(w, message)
-
-		return
-	}
-
-	fmt.Fprintf(w, "%s\n", string(jsonBytes))
-}
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package print
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusEventsArePlainWhenNotATerminal(t *testing.T) {
+	var buf bytes.Buffer
+	SuccessStatusEvent(&buf, "done %s", "now")
+	FailureStatusEvent(&buf, "failed")
+	assert.Equal(t, "done now\nfailed\n", buf.String())
+}
+
+func TestDisableColor(t *testing.T) {
+	noColorBefore, colorNoColorBefore := noColor, color.NoColor
+	defer func() {
+		noColor, color.NoColor = noColorBefore, colorNoColorBefore
+	}()
+
+	DisableColor()
+	assert.True(t, IsColorDisabled())
+	assert.True(t, color.NoColor)
+	assert.Equal(t, "text", Green("text"))
+}
+
+func TestLogLevel(t *testing.T) {
+	defer SetLogLevel(InfoLevel)
+
+	t.Run("parse log level", func(t *testing.T) {
+		level, err := ParseLogLevel("DEBUG")
+		assert.NoError(t, err)
+		assert.Equal(t, DebugLevel, level)
+
+		_, err = ParseLogLevel("verbose")
+		assert.Error(t, err)
+	})
+
+	t.Run("debug events are hidden by default", func(t *testing.T) {
+		SetLogLevel(InfoLevel)
+		var buf bytes.Buffer
+		DebugStatusEvent(&buf, "details")
+		InfoStatusEvent(&buf, "info")
+		assert.Equal(t, "info\n", buf.String())
+	})
+
+	t.Run("debug events are printed at debug level", func(t *testing.T) {
+		SetLogLevel(DebugLevel)
+		var buf bytes.Buffer
+		DebugStatusEvent(&buf, "details")
+		assert.Equal(t, "details\n", buf.String())
+	})
+
+	t.Run("only failures are printed at error level", func(t *testing.T) {
+		SetLogLevel(ErrorLevel)
+		var buf bytes.Buffer
+		InfoStatusEvent(&buf, "info")
+		WarningStatusEvent(&buf, "warning")
+		FailureStatusEvent(&buf, "failure")
+		stop := Spinner(&buf, "spinning")
+		stop(Failure)
+		assert.Equal(t, "failure\nspinning\n", buf.String())
+	})
+}
+
+func TestFailureHook(t *testing.T) {
+	defer SetFailureHook(nil)
+
+	failures := []string{}
+	SetFailureHook(func(msg string) {
+		failures = append(failures, msg)
+	})
+
+	var buf bytes.Buffer
+	FailureStatusEvent(&buf, "failed with %d", 3)
+	WarningStatusEvent(&buf, "warning")
+	assert.Equal(t, "failed with 3\nwarning\n", buf.String())
+	assert.Equal(t, []string{"failed with 3"}, failures)
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/briandowns/spinner"
+)
+
+const (
+	progressRenderInterval = 100 * time.Millisecond
+	progressJSONInterval   = time.Second
+	// progressPlainStep is the percentage between progress lines when output is not a terminal.
+	progressPlainStep = 25
+)
+
+var (
+	progressMu          sync.Mutex
+	activeSpinner       *spinner.Spinner
+	activeSpinnerSuffix string
+	activeBars          []*ProgressBar
+)
+
+// ProgressBar reports the progress of a download, such as a binary or a container image.
+// On a terminal, the progress of all active bars is shown next to the running spinner,
+// or on its own line if there is none. When JSON logging is enabled, progress events are printed instead.
+type ProgressBar struct {
+	w    io.Writer
+	name string
+
+	mu          sync.Mutex
+	total       int64
+	current     int64
+	start       time.Time
+	lastReport  time.Time
+	lastPercent int
+	finished    bool
+}
+
+// NewProgressBar starts reporting the progress of name. A total of zero or less means the size is unknown.
+func NewProgressBar(w io.Writer, name string, total int64) *ProgressBar {
+	p := &ProgressBar{
+		w:     w,
+		name:  name,
+		total: total,
+		start: time.Now(),
+	}
+
+	progressMu.Lock()
+	activeBars = append(activeBars, p)
+	progressMu.Unlock()
+	return p
+}
+
+// Write adds the length of b to the progress, so that a ProgressBar can be used with io.MultiWriter or io.TeeReader.
+func (p *ProgressBar) Write(b []byte) (int, error) {
+	p.Add(int64(len(b)))
+	return len(b), nil
+}
+
+// Add adds n bytes to the progress.
+func (p *ProgressBar) Add(n int64) {
+	p.mu.Lock()
+	p.current += n
+	p.mu.Unlock()
+	p.report(false)
+}
+
+// Set sets the progress to current bytes out of total bytes.
+func (p *ProgressBar) Set(current, total int64) {
+	p.mu.Lock()
+	p.current = current
+	p.total = total
+	p.mu.Unlock()
+	p.report(false)
+}
+
+// Finish stops reporting progress.
+func (p *ProgressBar) Finish() {
+	p.mu.Lock()
+	if p.finished {
+		p.mu.Unlock()
+		return
+	}
+	p.finished = true
+	p.mu.Unlock()
+
+	p.report(true)
+
+	progressMu.Lock()
+	for i, bar := range activeBars {
+		if bar == p {
+			activeBars = append(activeBars[:i], activeBars[i+1:]...)
+			break
+		}
+	}
+	progressMu.Unlock()
+	updateSpinnerProgress()
+}
+
+// String returns the name, percentage, downloaded bytes and estimated remaining time of the download.
+func (p *ProgressBar) String() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.name + " " + formatProgress(p.current, p.total, time.Since(p.start))
+}
+
+func (p *ProgressBar) report(final bool) {
+	if !IsLevelEnabled(InfoLevel) {
+		return
+	}
+
+	p.mu.Lock()
+	now := time.Now()
+	current, total := p.current, p.total
+	percent := percentage(current, total)
+
+	var show bool
+	switch {
+	case IsJSONLogEnabled():
+		show = final || now.Sub(p.lastReport) >= progressJSONInterval
+	case !renderer.Interactive() || isPlainText(p.w):
+		// Print a line every few percent, and when finished unless 100% was already printed.
+		show = (final && p.lastPercent < 100) || (total > 0 && percent/progressPlainStep > p.lastPercent/progressPlainStep)
+	default:
+		show = final || now.Sub(p.lastReport) >= progressRenderInterval
+	}
+	if !show {
+		p.mu.Unlock()
+		return
+	}
+	p.lastReport = now
+	p.lastPercent = percent
+	p.mu.Unlock()
+
+	switch {
+	case IsJSONLogEnabled():
+		logProgressJSON(p.w, p.name, current, total)
+	case !renderer.Interactive() || isPlainText(p.w):
+		renderer.StatusEvent(p.w, "progress", "", p.String())
+	default:
+		if !updateSpinnerProgress() {
+			fmt.Fprintf(p.w, "\r%s\033[K", p.String())
+			if final {
+				fmt.Fprintln(p.w)
+			}
+		}
+	}
+}
+
+// updateSpinnerProgress shows the progress of all active bars next to the running spinner.
+// It returns false if there is no running spinner.
+func updateSpinnerProgress() bool {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	if activeSpinner == nil {
+		return false
+	}
+
+	suffix := activeSpinnerSuffix
+	if len(activeBars) == 1 {
+		suffix += "  " + activeBars[0].String()
+	} else if len(activeBars) > 1 {
+		var current, total int64
+		var start time.Time
+		for _, bar := range activeBars {
+			bar.mu.Lock()
+			current += bar.current
+			total += bar.total
+			if start.IsZero() || bar.start.Before(start) {
+				start = bar.start
+			}
+			bar.mu.Unlock()
+		}
+		suffix += fmt.Sprintf("  %d downloads %s", len(activeBars), formatProgress(current, total, time.Since(start)))
+	}
+
+	activeSpinner.Lock()
+	activeSpinner.Suffix = suffix
+	activeSpinner.Unlock()
+	return true
+}
+
+func setActiveSpinner(s *spinner.Spinner, suffix string) {
+	progressMu.Lock()
+	activeSpinner = s
+	activeSpinnerSuffix = suffix
+	progressMu.Unlock()
+}
+
+// formatProgress returns, for example, "45% (12.3 MB/27.1 MB, ETA 3s)", or "12.3 MB" if the total is unknown.
+func formatProgress(current, total int64, elapsed time.Duration) string {
+	if total <= 0 {
+		return FormatBytes(current)
+	}
+
+	eta := "unknown"
+	if current > 0 && elapsed > 0 {
+		rate := float64(current) / elapsed.Seconds()
+		remaining := time.Duration(float64(total-current)/rate) * time.Second
+		eta = remaining.Round(time.Second).String()
+	}
+	return fmt.Sprintf("%d%% (%s/%s, ETA %s)", percentage(current, total), FormatBytes(current), FormatBytes(total), eta)
+}
+
+func percentage(current, total int64) int {
+	if total <= 0 {
+		return 0
+	}
+	if current >= total {
+		return 100
+	}
+	return int(current * 100 / total)
+}
+
+// FormatBytes returns n bytes in a human readable unit, such as 1.5 MB.
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %s", float64(n)/float64(div), strings.Split("KB MB GB TB", " ")[exp])
+}
+
+func logProgressJSON(w io.Writer, name string, current, total int64) {
+	type jsonProgress struct {
+		statusLog
+		Current int64 `json:"current"`
+		Total   int64 `json:"total,omitempty"`
+		Percent int   `json:"percent,omitempty"`
+	}
+
+	l := jsonProgress{
+		statusLog: newStatusLog("progress", name, ""),
+		Current:   current,
+		Total:     total,
+		Percent:   percentage(current, total),
+	}
+	jsonBytes, err := json.Marshal(&l)
+	if err != nil {
+		return
+	}
+
+	fmt.Fprintf(w, "%s\n", string(jsonBytes))
+}
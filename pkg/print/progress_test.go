@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package print
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatProgress(t *testing.T) {
+	assert.Equal(t, "512 B", formatProgress(512, 0, time.Second))
+	assert.Equal(t, "50% (1.0 MB/2.0 MB, ETA 2s)", formatProgress(1<<20, 2<<20, 2*time.Second))
+	assert.Equal(t, "0% (0 B/1.5 KB, ETA unknown)", formatProgress(0, 1536, time.Second))
+}
+
+func TestProgressBarPlainText(t *testing.T) {
+	var buf bytes.Buffer
+	bar := NewProgressBar(&buf, "daprd", 100)
+	for i := 0; i < 10; i++ {
+		bar.Write(make([]byte, 10))
+	}
+	bar.Finish()
+	bar.Finish()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "daprd 30% (30 B/100 B"))
+	assert.True(t, strings.HasPrefix(lines[3], "daprd 100% (100 B/100 B"))
+	assert.Empty(t, activeBars)
+}
+
+func TestProgressBarJSON(t *testing.T) {
+	defer SetOutputFormat(TextFormat)
+	EnableJSONFormat()
+
+	var buf bytes.Buffer
+	bar := NewProgressBar(&buf, "daprd", 200)
+	bar.Set(50, 200)
+	bar.Finish()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+
+	var event map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &event))
+	assert.Equal(t, "progress", event["status"])
+	assert.Equal(t, "daprd", event["msg"])
+	assert.Equal(t, float64(50), event["current"])
+	assert.Equal(t, float64(200), event["total"])
+	assert.Equal(t, float64(25), event["percent"])
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	k8syaml "sigs.k8s.io/yaml"
+)
+
+const (
+	// TextFormat prints status events as lines, prefixed with emoji on a terminal, or with ASCII markers such as [OK]
+	// on Windows terminals, and tables as columns.
+	TextFormat = "text"
+	// JSONFormat prints status events as JSON lines and tables as JSON arrays.
+	JSONFormat = "json"
+	// YAMLFormat prints status events and tables as YAML documents.
+	YAMLFormat = "yaml"
+	// GitHubActionsFormat prints failures, warnings and debug events as GitHub Actions workflow commands,
+	// so they are shown as annotations of the workflow run.
+	GitHubActionsFormat = "github-actions"
+)
+
+// Renderer formats the status events and tables printed by all commands.
+type Renderer interface {
+	// StatusEvent prints msg with the given status, such as "success" or "failure".
+	// emoji is shown next to msg by renderers that support it.
+	StatusEvent(w io.Writer, status, emoji, msg string)
+	// Table prints rows, a slice of structs. See WriteTable for the struct tags that define the columns.
+	Table(w io.Writer, rows interface{}, wide bool) error
+	// Interactive returns true if spinners and progress bars can be redrawn in place.
+	Interactive() bool
+}
+
+var renderers = map[string]Renderer{
+	TextFormat:          textRenderer{},
+	JSONFormat:          jsonRenderer{},
+	YAMLFormat:          yamlRenderer{},
+	GitHubActionsFormat: githubActionsRenderer{},
+}
+
+var renderer Renderer = textRenderer{}
+
+// SetOutputFormat selects the renderer of all status events and tables by its format name.
+func SetOutputFormat(format string) error {
+	r, ok := renderers[strings.ToLower(format)]
+	if !ok {
+		return fmt.Errorf("invalid output format %q. Valid values are: %s, %s, %s, or %s", format, TextFormat, JSONFormat, YAMLFormat, GitHubActionsFormat)
+	}
+	renderer = r
+	return nil
+}
+
+// GetRenderer returns the renderer selected with SetOutputFormat.
+func GetRenderer() Renderer {
+	return renderer
+}
+
+// SetOutputFlag selects the renderer for the --output flag of a command that prints structured data, such as
+// status or version: json or yaml select the renderer of that format, and table, or no value, keeps the renderer
+// selected with --output-format.
+func SetOutputFlag(output string) error {
+	switch strings.ToLower(output) {
+	case "", "table":
+		return nil
+	case JSONFormat, YAMLFormat:
+		return SetOutputFormat(output)
+	default:
+		return fmt.Errorf("invalid output format %q. Valid values are: %s, %s, or table", output, JSONFormat, YAMLFormat)
+	}
+}
+
+// WriteDocument writes v, a struct or a map, as a YAML document if the YAML renderer is selected, or else as an
+// indented JSON document. The keys of the YAML document are those of the JSON document, so that scripts can switch
+// between jq and yq without renaming fields.
+func WriteDocument(w io.Writer, v interface{}) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, ok := renderer.(yamlRenderer); ok {
+		if b, err = k8syaml.JSONToYAML(b); err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
+
+type textRenderer struct{}
+
+func (textRenderer) StatusEvent(w io.Writer, status, emoji, msg string) {
+	fmt.Fprintf(w, "%s%s%s\n", stepIndent(), statusPrefix(outputStyle(w), status, emoji), msg)
+}
+
+func (textRenderer) Table(w io.Writer, rows interface{}, wide bool) error {
+	return writeTextTable(w, rows, wide)
+}
+
+func (textRenderer) Interactive() bool {
+	return true
+}
+
+// structuredRenderer is implemented by the renderers that print status events in the JSON schema.
+type structuredRenderer interface {
+	writeStatusLog(w io.Writer, log statusLog)
+}
+
+type jsonRenderer struct{}
+
+func (r jsonRenderer) StatusEvent(w io.Writer, status, emoji, msg string) {
+	r.writeStatusLog(w, newStatusLog(status, msg, ""))
+}
+
+func (jsonRenderer) writeStatusLog(w io.Writer, log statusLog) {
+	jsonBytes, err := json.Marshal(log)
+	if err != nil {
+		// Fall back on printing the simple message without JSON.
+		// This is unlikely.
+		fmt.Fprintln(w, log.Message)
+
+		return
+	}
+
+	fmt.Fprintf(w, "%s\n", string(jsonBytes))
+}
+
+func (jsonRenderer) Table(w io.Writer, rows interface{}, wide bool) error {
+	if _, err := tableRowType(rows); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(rows, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
+
+func (jsonRenderer) Interactive() bool {
+	return false
+}
+
+type yamlRenderer struct{}
+
+func (r yamlRenderer) StatusEvent(w io.Writer, status, emoji, msg string) {
+	r.writeStatusLog(w, newStatusLog(status, msg, ""))
+}
+
+func (yamlRenderer) writeStatusLog(w io.Writer, log statusLog) {
+	b, err := yaml.Marshal(log)
+	if err != nil {
+		fmt.Fprintln(w, log.Message)
+
+		return
+	}
+
+	fmt.Fprintf(w, "---\n%s", b)
+}
+
+func (yamlRenderer) Table(w io.Writer, rows interface{}, wide bool) error {
+	if _, err := tableRowType(rows); err != nil {
+		return err
+	}
+	b, err := yaml.Marshal(rows)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func (yamlRenderer) Interactive() bool {
+	return false
+}
+
+// githubActionsRenderer prints workflow commands, see
+// https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions.
+type githubActionsRenderer struct{}
+
+var githubActionsCommands = map[string]string{
+	"failure": "error",
+	"warning": "warning",
+	"debug":   "debug",
+}
+
+// githubActionsEscaper escapes the message of a workflow command, which must fit on one line.
+var githubActionsEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+
+func (githubActionsRenderer) StatusEvent(w io.Writer, status, emoji, msg string) {
+	if command, ok := githubActionsCommands[status]; ok {
+		fmt.Fprintf(w, "::%s::%s\n", command, githubActionsEscaper.Replace(msg))
+		return
+	}
+	fmt.Fprintf(w, "%s\n", msg)
+}
+
+func (githubActionsRenderer) Table(w io.Writer, rows interface{}, wide bool) error {
+	return writeTextTable(w, rows, wide)
+}
+
+func (githubActionsRenderer) Interactive() bool {
+	return false
+}
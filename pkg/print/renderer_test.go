@@ -0,0 +1,183 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package print
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type rendererRow struct {
+	Name  string `csv:"NAME" json:"name" yaml:"name"`
+	Ports int    `csv:"PORTS" json:"ports" yaml:"ports"`
+}
+
+func TestSetOutputFormat(t *testing.T) {
+	defer SetOutputFormat(TextFormat)
+
+	assert.NoError(t, SetOutputFormat("JSON"))
+	assert.True(t, IsJSONLogEnabled())
+	assert.NoError(t, SetOutputFormat(TextFormat))
+	assert.False(t, IsJSONLogEnabled())
+	assert.EqualError(t, SetOutputFormat("xml"), `invalid output format "xml". Valid values are: text, json, yaml, or github-actions`)
+	assert.Equal(t, textRenderer{}, GetRenderer())
+}
+
+func TestSetOutputFlag(t *testing.T) {
+	defer SetOutputFormat(TextFormat)
+
+	assert.NoError(t, SetOutputFlag("yaml"))
+	assert.Equal(t, yamlRenderer{}, GetRenderer())
+	assert.False(t, IsJSONLogEnabled())
+	assert.NoError(t, SetOutputFlag("table"))
+	assert.Equal(t, yamlRenderer{}, GetRenderer(), "table keeps the renderer of --output-format")
+	assert.NoError(t, SetOutputFlag("json"))
+	assert.True(t, IsJSONLogEnabled())
+	assert.EqualError(t, SetOutputFlag("github-actions"), `invalid output format "github-actions". Valid values are: json, yaml, or table`)
+}
+
+func TestWriteDocument(t *testing.T) {
+	defer SetOutputFormat(TextFormat)
+	doc := struct {
+		CLIVersion string   `json:"Cli version"`
+		Ports      []int    `json:"ports"`
+		Omitted    string   `json:"omitted,omitempty"`
+		Names      []string `json:"names"`
+	}{CLIVersion: "1.9.0", Ports: []int{3500}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteDocument(&buf, doc))
+	assert.Equal(t, "{\n  \"Cli version\": \"1.9.0\",\n  \"ports\": [\n    3500\n  ],\n  \"names\": null\n}\n", buf.String())
+
+	assert.NoError(t, SetOutputFormat(YAMLFormat))
+	buf.Reset()
+	assert.NoError(t, WriteDocument(&buf, doc))
+	assert.Equal(t, "Cli version: 1.9.0\nnames: null\nports:\n- 3500\n", buf.String(), "the keys are those of the JSON document")
+}
+
+func TestRenderers(t *testing.T) {
+	defer SetOutputFormat(TextFormat)
+	rows := []rendererRow{{Name: "placement", Ports: 1}}
+
+	t.Run("json", func(t *testing.T) {
+		assert.NoError(t, SetOutputFormat(JSONFormat))
+		var buf bytes.Buffer
+		WarningStatusEvent(&buf, "careful")
+		var event map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &event))
+		assert.Equal(t, "warning", event["status"])
+		assert.Equal(t, "careful", event["msg"])
+
+		buf.Reset()
+		DryRunStatusEvent(&buf, "Would remove %s", "dapr_redis")
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &event))
+		assert.Equal(t, "dry-run", event["status"])
+		assert.Equal(t, "Would remove dapr_redis", event["msg"])
+
+		buf.Reset()
+		assert.NoError(t, WriteTable(&buf, rows, false))
+		assert.JSONEq(t, `[{"name":"placement","ports":1}]`, buf.String())
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		assert.NoError(t, SetOutputFormat(YAMLFormat))
+		var buf bytes.Buffer
+		InfoStatusEvent(&buf, "hello")
+		assert.True(t, strings.HasPrefix(buf.String(), "---\ntime: "))
+		assert.Contains(t, buf.String(), "status: info\nmsg: hello\n")
+
+		buf.Reset()
+		assert.NoError(t, WriteTable(&buf, rows, false))
+		assert.Equal(t, "- name: placement\n  ports: 1\n", buf.String())
+	})
+
+	t.Run("github actions", func(t *testing.T) {
+		assert.NoError(t, SetOutputFormat(GitHubActionsFormat))
+		var buf bytes.Buffer
+		FailureStatusEvent(&buf, "failed: 100%% broken\nsee logs")
+		WarningStatusEvent(&buf, "careful")
+		SuccessStatusEvent(&buf, "done")
+		assert.Equal(t, "::error::failed: 100%25 broken%0Asee logs\n::warning::careful\ndone\n", buf.String())
+
+		buf.Reset()
+		assert.NoError(t, WriteTable(&buf, rows, false))
+		assert.Contains(t, buf.String(), "NAME")
+		assert.Contains(t, buf.String(), "placement")
+	})
+
+	t.Run("spinners print pending and result events", func(t *testing.T) {
+		assert.NoError(t, SetOutputFormat(GitHubActionsFormat))
+		var buf bytes.Buffer
+		stop := Spinner(&buf, "installing")
+		stop(Failure)
+		assert.Equal(t, "installing\n::error::installing\n", buf.String())
+	})
+
+	t.Run("tables reject rows that are not structs", func(t *testing.T) {
+		assert.NoError(t, SetOutputFormat(JSONFormat))
+		assert.Error(t, WriteTable(&bytes.Buffer{}, []string{"a"}, false))
+	})
+}
+
+func TestJSONSchemaVersion(t *testing.T) {
+	defer SetOutputFormat(TextFormat)
+	defer SetJSONSchemaVersion(JSONSchemaV1)
+	defer SetCommandContext("", "")
+	assert.NoError(t, SetOutputFormat(JSONFormat))
+
+	t.Run("v1", func(t *testing.T) {
+		var buf bytes.Buffer
+		FailureStatusEvent(&buf, "failed")
+		var event map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &event))
+		assert.Len(t, event, 3)
+		assert.Equal(t, "failed", event["msg"])
+	})
+
+	t.Run("v2", func(t *testing.T) {
+		assert.NoError(t, SetJSONSchemaVersion(JSONSchemaV2))
+		SetCommandContext("dapr invoke", "orders")
+
+		var buf bytes.Buffer
+		err := fmt.Errorf("wrapped: %w", NewCodedError("ERR_APP_NOT_FOUND", errors.New("app ID orders not found")))
+		FailureStatusEvent(&buf, "Error invoking app: %s", err)
+		var event map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &event))
+		assert.Equal(t, float64(JSONSchemaV2), event["schemaVersion"])
+		assert.Equal(t, "dapr invoke", event["command"])
+		assert.Equal(t, "orders", event["appId"])
+		assert.Equal(t, "ERR_APP_NOT_FOUND", event["errorCode"])
+		assert.Equal(t, "Error invoking app: wrapped: app ID orders not found", event["msg"])
+		assert.Contains(t, event, "durationMs")
+
+		buf.Reset()
+		FailureStatusEvent(&buf, "failed")
+		assert.Contains(t, buf.String(), `"errorCode":"`+DefaultErrorCode+`"`)
+
+		buf.Reset()
+		SuccessStatusEvent(&buf, "done")
+		assert.NotContains(t, buf.String(), "errorCode")
+		assert.Contains(t, buf.String(), `"schemaVersion":2`)
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		assert.EqualError(t, SetJSONSchemaVersion(3), "invalid JSON schema version 3. Valid values are: 1 or 2")
+	})
+}
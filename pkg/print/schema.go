@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package print
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// JSONSchemaV1 is the schema of the status events printed as JSON or YAML with the time, status and msg fields.
+	JSONSchemaV1 = 1
+	// JSONSchemaV2 adds the schemaVersion, command, appId, errorCode and durationMs fields to JSONSchemaV1.
+	JSONSchemaV2 = 2
+
+	// DefaultErrorCode is the error code of failure events that are not caused by an error with a code.
+	DefaultErrorCode = "ERR_COMMAND_FAILED"
+)
+
+var (
+	jsonSchemaVersion = JSONSchemaV1
+	commandName       string
+	commandAppID      string
+	commandStart      = time.Now()
+)
+
+// SetJSONSchemaVersion selects the schema of the status events printed as JSON or YAML.
+func SetJSONSchemaVersion(version int) error {
+	if version != JSONSchemaV1 && version != JSONSchemaV2 {
+		return fmt.Errorf("invalid JSON schema version %d. Valid values are: %d or %d", version, JSONSchemaV1, JSONSchemaV2)
+	}
+	jsonSchemaVersion = version
+	return nil
+}
+
+// SetCommandContext sets the command and app ID added to the status events by JSONSchemaV2.
+func SetCommandContext(command, appID string) {
+	commandName = command
+	commandAppID = appID
+}
+
+// codedError is an error with a code that is added to failure events by JSONSchemaV2.
+type codedError struct {
+	code string
+	err  error
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+// NewCodedError returns err with a code, such as ERR_APP_NOT_FOUND, that is added to the failure events
+// it is printed in, so automation can tell failures apart without parsing the message.
+func NewCodedError(code string, err error) error {
+	return &codedError{code: code, err: err}
+}
+
+// ErrorCode returns the code of err, or of the first error it wraps that has one, or an empty string.
+func ErrorCode(err error) string {
+	var coded *codedError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	return ""
+}
+
+// failureErrorCode returns the code of the first error in the arguments of a failure event that has one,
+// or DefaultErrorCode.
+func failureErrorCode(args []interface{}) string {
+	for _, arg := range args {
+		if err, ok := arg.(error); ok {
+			if code := ErrorCode(err); code != "" {
+				return code
+			}
+		}
+	}
+	return DefaultErrorCode
+}
+
+type statusLog struct {
+	SchemaVersion int       `json:"schemaVersion,omitempty" yaml:"schemaVersion,omitempty"`
+	Time          time.Time `json:"time"                    yaml:"time"`
+	Status        string    `json:"status"                  yaml:"status"`
+	Message       string    `json:"msg"                     yaml:"msg"`
+	Command       string    `json:"command,omitempty"       yaml:"command,omitempty"`
+	AppID         string    `json:"appId,omitempty"         yaml:"appId,omitempty"`
+	ErrorCode     string    `json:"errorCode,omitempty"     yaml:"errorCode,omitempty"`
+	DurationMs    *int64    `json:"durationMs,omitempty"    yaml:"durationMs,omitempty"`
+	Step          *stepInfo `json:"step,omitempty"          yaml:"step,omitempty"`
+}
+
+// newStatusLog returns a status event in the selected schema. errorCode is only set for failure events.
+func newStatusLog(status, msg, errorCode string) statusLog {
+	now := time.Now().UTC()
+	log := statusLog{
+		Time:    now,
+		Status:  status,
+		Message: msg,
+		Step:    currentStep(),
+	}
+	if jsonSchemaVersion < JSONSchemaV2 {
+		return log
+	}
+	duration := now.Sub(commandStart).Milliseconds()
+	log.SchemaVersion = jsonSchemaVersion
+	log.Command = commandName
+	log.AppID = commandAppID
+	log.ErrorCode = errorCode
+	log.DurationMs = &duration
+	return log
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package print
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// stepIndentation is the indentation of the status events of a step below its title in text output.
+const stepIndentation = "   "
+
+var (
+	stepMu      sync.Mutex
+	activeSteps []*Step
+)
+
+// Step is a phase of a long operation, such as installing the binaries of `dapr init`. Status events printed while
+// a step is active are indented below its title in text output, and steps begun while another step is active are
+// nested in it. In JSON and YAML output, the begin and end of a step are printed as step-start and step-end events,
+// and the events of a step carry its name and depth.
+type Step struct {
+	w     io.Writer
+	title string
+	depth int
+	start time.Time
+	once  sync.Once
+}
+
+// stepInfo is the step of a status event printed as JSON or YAML.
+type stepInfo struct {
+	Name      string `json:"name"                yaml:"name"`
+	Depth     int    `json:"depth"               yaml:"depth"`
+	Result    string `json:"result,omitempty"    yaml:"result,omitempty"`
+	ElapsedMs *int64 `json:"elapsedMs,omitempty" yaml:"elapsedMs,omitempty"`
+}
+
+// BeginStep prints the title of a step and makes it the active step until End is called.
+func BeginStep(w io.Writer, fmtstr string, a ...interface{}) *Step {
+	stepMu.Lock()
+	s := &Step{
+		w:     w,
+		title: fmt.Sprintf(fmtstr, a...),
+		depth: len(activeSteps),
+		start: time.Now(),
+	}
+	stepMu.Unlock()
+
+	if IsLevelEnabled(InfoLevel) {
+		switch r := renderer.(type) {
+		case structuredRenderer:
+			r.writeStatusLog(w, s.statusLog("step-start", s.title, nil))
+		case githubActionsRenderer:
+			// Workflow logs cannot nest groups, so only top-level steps are collapsible.
+			if s.depth == 0 {
+				fmt.Fprintf(w, "::group::%s\n", githubActionsEscaper.Replace(s.title))
+			} else {
+				r.StatusEvent(w, "step", "", s.title)
+			}
+		default:
+			renderer.StatusEvent(w, "step", "⌛", s.title)
+		}
+	}
+
+	stepMu.Lock()
+	activeSteps = append(activeSteps, s)
+	stepMu.Unlock()
+	return s
+}
+
+// End prints the result and the duration of the step and ends it, along with the steps nested in it that have not
+// ended yet. Only the first call has an effect, so that End(Failure) can be deferred right after BeginStep.
+func (s *Step) End(result Result) {
+	s.once.Do(func() {
+		var nested []*Step
+		stepMu.Lock()
+		for i, step := range activeSteps {
+			if step == s {
+				nested = append(nested, activeSteps[i+1:]...)
+				activeSteps = activeSteps[:i]
+				break
+			}
+		}
+		stepMu.Unlock()
+		// The nested steps end with this step without printing their result.
+		for _, step := range nested {
+			step.once.Do(func() {})
+		}
+
+		level, status, emoji, msg := InfoLevel, "success", "✅", s.title+": done in "
+		if !result {
+			level, status, emoji, msg = ErrorLevel, "failure", "❌", s.title+": failed after "
+		}
+		if !IsLevelEnabled(level) {
+			return
+		}
+		elapsed := time.Since(s.start)
+		msg += formatStepDuration(elapsed)
+
+		switch r := renderer.(type) {
+		case structuredRenderer:
+			elapsedMs := elapsed.Milliseconds()
+			log := s.statusLog("step-end", s.title, &elapsedMs)
+			log.Step.Result = status
+			r.writeStatusLog(s.w, log)
+		case githubActionsRenderer:
+			if s.depth == 0 {
+				fmt.Fprintln(s.w, "::endgroup::")
+			}
+			r.StatusEvent(s.w, status, emoji, msg)
+		default:
+			renderer.StatusEvent(s.w, status, emoji, msg)
+		}
+	})
+}
+
+func (s *Step) statusLog(status, msg string, elapsedMs *int64) statusLog {
+	log := newStatusLog(status, msg, "")
+	log.Step = &stepInfo{Name: s.title, Depth: s.depth, ElapsedMs: elapsedMs}
+	return log
+}
+
+// currentStep returns the innermost active step, or nil if there is none.
+func currentStep() *stepInfo {
+	stepMu.Lock()
+	defer stepMu.Unlock()
+	if len(activeSteps) == 0 {
+		return nil
+	}
+	s := activeSteps[len(activeSteps)-1]
+	return &stepInfo{Name: s.title, Depth: s.depth}
+}
+
+// stepIndent returns the indentation of the status events printed as text in the active steps.
+func stepIndent() string {
+	stepMu.Lock()
+	defer stepMu.Unlock()
+	return strings.Repeat(stepIndentation, len(activeSteps))
+}
+
+// formatStepDuration returns, for example, "450ms" or "2.1s".
+func formatStepDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
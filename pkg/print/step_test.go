@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package print
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepsPlainText(t *testing.T) {
+	var buf bytes.Buffer
+	install := BeginStep(&buf, "Installing %s", "binaries")
+	InfoStatusEvent(&buf, "Downloading daprd")
+	extract := BeginStep(&buf, "Extracting")
+	InfoStatusEvent(&buf, "Extracted daprd")
+	// Ending the outer step also ends the nested one.
+	install.End(Failure)
+	extract.End(Success)
+	install.End(Success)
+	InfoStatusEvent(&buf, "Done")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 6)
+	assert.Equal(t, "Installing binaries", lines[0])
+	assert.Equal(t, "   Downloading daprd", lines[1])
+	assert.Equal(t, "   Extracting", lines[2])
+	assert.Equal(t, "      Extracted daprd", lines[3])
+	assert.True(t, strings.HasPrefix(lines[4], "Installing binaries: failed after "))
+	assert.Equal(t, "Done", lines[5])
+	assert.Empty(t, activeSteps)
+}
+
+func TestStepsJSON(t *testing.T) {
+	defer SetOutputFormat(TextFormat)
+	EnableJSONFormat()
+
+	var buf bytes.Buffer
+	step := BeginStep(&buf, "Installing binaries")
+	InfoStatusEvent(&buf, "Downloading daprd")
+	step.End(Success)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	var event map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &event))
+	assert.Equal(t, "step-start", event["status"])
+	assert.Equal(t, map[string]interface{}{"name": "Installing binaries", "depth": float64(0)}, event["step"])
+
+	event = nil
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+	assert.Equal(t, "Downloading daprd", event["msg"])
+	assert.Equal(t, map[string]interface{}{"name": "Installing binaries", "depth": float64(0)}, event["step"])
+
+	event = nil
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &event))
+	assert.Equal(t, "step-end", event["status"])
+	stepEvent := event["step"].(map[string]interface{})
+	assert.Equal(t, "success", stepEvent["result"])
+	assert.Contains(t, stepEvent, "elapsedMs")
+}
+
+func TestStepsGitHubActions(t *testing.T) {
+	defer SetOutputFormat(TextFormat)
+	assert.NoError(t, SetOutputFormat(GitHubActionsFormat))
+
+	var buf bytes.Buffer
+	step := BeginStep(&buf, "Installing binaries")
+	step.End(Failure)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "::group::Installing binaries", lines[0])
+	assert.Equal(t, "::endgroup::", lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "::error::Installing binaries: failed after "))
+}
+
+func TestFormatStepDuration(t *testing.T) {
+	assert.Equal(t, "450ms", formatStepDuration(450*time.Millisecond+300*time.Microsecond))
+	assert.Equal(t, "2.1s", formatStepDuration(2140*time.Millisecond))
+	assert.Equal(t, "1m5.3s", formatStepDuration(65300*time.Millisecond))
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package print
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+type tableColumn struct {
+	header string
+	index  int
+}
+
+// TableWriter returns a table writer using the borderless layout shared by all CLI commands.
+func TableWriter(w io.Writer) *tablewriter.Table {
+	table := tablewriter.NewWriter(w)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetBorder(false)
+	table.SetHeaderLine(false)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("")
+	table.SetRowSeparator("")
+	table.SetColumnSeparator("")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	return table
+}
+
+// WriteTable renders rows, a slice of structs, as a table with the renderer selected by SetOutputFormat.
+// Column headers are read from the `csv` struct tag. Fields tagged with `csv:"-"` are
+// skipped, unless wide is set and the field also has a `wide` tag naming its column.
+func WriteTable(w io.Writer, rows interface{}, wide bool) error {
+	return renderer.Table(w, rows, wide)
+}
+
+// tableRowType returns the struct type of the rows of a table.
+func tableRowType(rows interface{}) (reflect.Type, error) {
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("table rows must be a slice, got %s", v.Kind())
+	}
+
+	elemType := v.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("table rows must be structs, got %s", elemType.Kind())
+	}
+	return elemType, nil
+}
+
+func writeTextTable(w io.Writer, rows interface{}, wide bool) error {
+	elemType, err := tableRowType(rows)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(rows)
+
+	columns := tableColumns(elemType, wide)
+	headers := make([]string, 0, len(columns))
+	for _, c := range columns {
+		headers = append(headers, c.header)
+	}
+
+	table := TableWriter(w)
+	table.SetHeader(headers)
+	for i := 0; i < v.Len(); i++ {
+		row := reflect.Indirect(v.Index(i))
+		cells := make([]string, 0, len(columns))
+		for _, c := range columns {
+			cells = append(cells, fmt.Sprintf("%v", row.Field(c.index).Interface()))
+		}
+		table.Append(cells)
+	}
+	table.Render()
+
+	return nil
+}
+
+func tableColumns(t reflect.Type, wide bool) []tableColumn {
+	columns := []tableColumn{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// unexported field.
+			continue
+		}
+
+		header := field.Tag.Get("csv")
+		if header == "-" {
+			header = ""
+			if wide {
+				header = field.Tag.Get("wide")
+			}
+		} else if header == "" {
+			header = field.Name
+		}
+
+		if header != "" {
+			columns = append(columns, tableColumn{header: header, index: i})
+		}
+	}
+	return columns
+}
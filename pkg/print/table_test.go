@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package print
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tableRow struct {
+	Name    string `csv:"NAME"`
+	Command string `csv:"COMMAND"`
+	Size    int    `csv:"-" wide:"SIZE"`
+	Hidden  bool   `csv:"-"`
+}
+
+func TestWriteTable(t *testing.T) {
+	rows := []tableRow{
+		{Name: "app1", Command: "node app.js, --port 3000", Size: 4, Hidden: true},
+	}
+
+	t.Run("default columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := WriteTable(&buf, rows, false)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "NAME")
+		assert.Contains(t, buf.String(), "node app.js, --port 3000")
+		assert.NotContains(t, buf.String(), "SIZE")
+		assert.NotContains(t, buf.String(), "HIDDEN")
+	})
+
+	t.Run("wide columns", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := WriteTable(&buf, rows, true)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "SIZE")
+		assert.NotContains(t, buf.String(), "HIDDEN")
+	})
+
+	t.Run("rows must be a slice of structs", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.Error(t, WriteTable(&buf, rows[0], false))
+		assert.Error(t, WriteTable(&buf, []string{"a"}, false))
+	})
+}
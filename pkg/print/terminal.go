@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package print
+
+import (
+	"io"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+)
+
+// terminalStyle is the decoration of the text output to a writer.
+type terminalStyle int
+
+const (
+	// plainStyle has no colors, emoji or spinners, for output that is not a terminal or with colors disabled.
+	plainStyle terminalStyle = iota
+	// asciiStyle prefixes status events with colored ASCII markers such as [OK], for Windows terminals, whose fonts
+	// often lack emoji. Spinners are not shown.
+	asciiStyle
+	// emojiStyle prefixes status events with emoji and shows spinners.
+	emojiStyle
+)
+
+// asciiMarker is the marker of a status event in asciiStyle.
+type asciiMarker struct {
+	text  string
+	color *color.Color
+}
+
+// asciiMarkers are the markers of the status events in asciiStyle. Events with other statuses have no marker.
+var asciiMarkers = map[string]asciiMarker{
+	"success": {"[OK]", color.New(color.FgHiGreen, color.Bold)},
+	"failure": {"[FAIL]", color.New(color.FgHiRed, color.Bold)},
+	"warning": {"[WARN]", color.New(color.FgHiYellow, color.Bold)},
+	"info":    {"[INFO]", color.New(color.FgHiBlue, color.Bold)},
+	"pending": {"[WAIT]", color.New(color.FgCyan)},
+	"step":    {"[STEP]", color.New(color.FgCyan)},
+	"debug":   {"[DEBUG]", color.New(color.FgWhite)},
+	"dry-run": {"[DRY-RUN]", color.New(color.FgHiBlue, color.Bold)},
+}
+
+var (
+	virtualTerminalMu sync.Mutex
+	// virtualTerminals caches whether ANSI escape sequences could be enabled for the console of a file descriptor.
+	virtualTerminals = map[uintptr]bool{}
+)
+
+// outputStyle returns the decoration of the text output to w.
+func outputStyle(w io.Writer) terminalStyle {
+	if noColor {
+		return plainStyle
+	}
+	f, ok := w.(*os.File)
+	if !ok || (!isatty.IsTerminal(f.Fd()) && !isatty.IsCygwinTerminal(f.Fd())) {
+		return plainStyle
+	}
+	if runtime.GOOS != windowsOS {
+		return emojiStyle
+	}
+	if modernWindowsTerminal(os.Getenv) || isatty.IsCygwinTerminal(f.Fd()) || supportsVirtualTerminal(f) {
+		return asciiStyle
+	}
+	return plainStyle
+}
+
+// modernWindowsTerminal returns true if the environment shows a Windows terminal that renders ANSI colors,
+// such as Windows Terminal, ConEmu or the terminal of Visual Studio Code.
+func modernWindowsTerminal(getenv func(string) string) bool {
+	return getenv("WT_SESSION") != "" || getenv("ConEmuANSI") == "ON" || getenv("TERM_PROGRAM") == "vscode" || getenv("ANSICON") != ""
+}
+
+// supportsVirtualTerminal returns true if virtual terminal processing, which renders ANSI escape sequences,
+// is or could be enabled for the console of f.
+func supportsVirtualTerminal(f *os.File) bool {
+	virtualTerminalMu.Lock()
+	defer virtualTerminalMu.Unlock()
+	enabled, ok := virtualTerminals[f.Fd()]
+	if !ok {
+		enabled = enableVirtualTerminal(f)
+		virtualTerminals[f.Fd()] = enabled
+	}
+	return enabled
+}
+
+// statusPrefix returns the decoration of a status event in style, followed by a separator, or an empty string.
+func statusPrefix(style terminalStyle, status, emoji string) string {
+	switch style {
+	case emojiStyle:
+		return emoji + "  "
+	case asciiStyle:
+		if marker, ok := asciiMarkers[status]; ok {
+			return marker.color.Sprint(marker.text) + " "
+		}
+	}
+	return ""
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package print
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusPrefix(t *testing.T) {
+	colorNoColorBefore := color.NoColor
+	t.Cleanup(func() { color.NoColor = colorNoColorBefore })
+
+	color.NoColor = true
+	assert.Equal(t, "", statusPrefix(plainStyle, "success", "✅"))
+	assert.Equal(t, "✅  ", statusPrefix(emojiStyle, "success", "✅"))
+	assert.Equal(t, "[OK] ", statusPrefix(asciiStyle, "success", "✅"))
+	assert.Equal(t, "[FAIL] ", statusPrefix(asciiStyle, "failure", "❌"))
+	assert.Equal(t, "[WARN] ", statusPrefix(asciiStyle, "warning", "⚠"))
+	assert.Equal(t, "", statusPrefix(asciiStyle, "progress", ""), "events without a marker are not decorated")
+
+	color.NoColor = false
+	assert.Equal(t, "\x1b[92;1m[OK]\x1b[0m ", statusPrefix(asciiStyle, "success", "✅"), "markers keep their colors")
+}
+
+func TestModernWindowsTerminal(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+	assert.True(t, modernWindowsTerminal(env(map[string]string{"WT_SESSION": "3f0b3a8c"})))
+	assert.True(t, modernWindowsTerminal(env(map[string]string{"ConEmuANSI": "ON"})))
+	assert.True(t, modernWindowsTerminal(env(map[string]string{"TERM_PROGRAM": "vscode"})))
+	assert.False(t, modernWindowsTerminal(env(map[string]string{"ConEmuANSI": "OFF"})))
+	assert.False(t, modernWindowsTerminal(env(nil)))
+}
+
+func TestOutputStyle(t *testing.T) {
+	assert.Equal(t, plainStyle, outputStyle(&bytes.Buffer{}), "output that is not a terminal is plain")
+}
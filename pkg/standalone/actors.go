@@ -0,0 +1,179 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/dapr/cli/pkg/api"
+	"github.com/dapr/cli/pkg/metadata"
+)
+
+// actorStateKeySeparator separates the app ID, actor type, actor ID and key of an actor state key.
+const actorStateKeySeparator = "||"
+
+// ActorsOutput is an actor type registered with the placement service by the sidecar of an app.
+type ActorsOutput struct {
+	ActorType    string `csv:"ACTOR TYPE"    json:"actorType"    yaml:"actorType"`
+	AppID        string `csv:"APP ID"        json:"appId"        yaml:"appId"`
+	HTTPPort     int    `csv:"HTTP PORT"     json:"httpPort"     yaml:"httpPort"`
+	ActiveActors int    `csv:"ACTIVE ACTORS" json:"activeActors" yaml:"activeActors"`
+}
+
+// ActorStateOutput is a key of the state of an actor, returned by a state query.
+type ActorStateOutput struct {
+	ActorID string     `csv:"ACTOR ID" json:"actorId" yaml:"actorId"`
+	Key     string     `csv:"KEY"      json:"key"     yaml:"key"`
+	Value   StateValue `csv:"VALUE"    json:"value"   yaml:"value"`
+}
+
+// ListActors returns the actor types registered by the sidecars of the running apps, with their number of
+// active actors. If actorType is not empty, only that actor type is returned.
+func (s *Standalone) ListActors(actorType string) ([]ActorsOutput, error) {
+	list, err := s.process.List()
+	if err != nil {
+		return nil, err
+	}
+
+	actors := []ActorsOutput{}
+	for _, lo := range list {
+		if lo.HTTPPort == 0 {
+			continue
+		}
+		appMetadata, err := metadata.Get(lo.HTTPPort, lo.AppID, "")
+		if err != nil {
+			return nil, fmt.Errorf("error getting the metadata of app %s: %w", lo.AppID, err)
+		}
+		for _, a := range appMetadata.ActiveActorsCount {
+			if actorType != "" && a.Type != actorType {
+				continue
+			}
+			actors = append(actors, ActorsOutput{
+				ActorType:    a.Type,
+				AppID:        lo.AppID,
+				HTTPPort:     lo.HTTPPort,
+				ActiveActors: a.Count,
+			})
+		}
+	}
+	sort.SliceStable(actors, func(i, j int) bool {
+		if actors[i].ActorType != actors[j].ActorType {
+			return actors[i].ActorType < actors[j].ActorType
+		}
+		return actors[i].AppID < actors[j].AppID
+	})
+	return actors, nil
+}
+
+// GetActorState returns the value of key in the state of an actor, read through the sidecar of the app that hosts
+// the actor type. appID can be empty if only one running app hosts the actor type.
+func (s *Standalone) GetActorState(appID, actorType, actorID, key, socket string) (StateValue, error) {
+	if actorType == "" || actorID == "" || key == "" {
+		return nil, errors.New("the actor type, actor ID and key are required")
+	}
+	appID, err := s.actorHost(appID, actorType)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("v%s/actors/%s/%s/state/%s", api.RuntimeAPIVersion, url.PathEscape(actorType), url.PathEscape(actorID), url.PathEscape(key))
+	endpoint, httpc, err := s.sidecarEndpoint(appID, socket, path)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := httpc.Get(endpoint) //nolint:noctx
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+	if r.StatusCode == http.StatusNoContent {
+		return nil, fmt.Errorf("key %s not found in the state of actor %s/%s", key, actorType, actorID)
+	}
+	body, err := readStateResponse(r)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the state of actor %s/%s: %w", actorType, actorID, err)
+	}
+	return StateValue(body), nil
+}
+
+// QueryActorState runs query against the actor state store storeName through the sidecar of the app that hosts the
+// actor type, and returns the keys of the state of the actors of that type. If actorID is not empty, only the keys of
+// that actor are returned. The state store must support the state query API.
+func (s *Standalone) QueryActorState(appID, actorType, actorID, storeName string, query []byte, socket string) ([]ActorStateOutput, error) {
+	if actorType == "" || storeName == "" {
+		return nil, errors.New("the actor type and state store are required")
+	}
+	appID, err := s.actorHost(appID, actorType)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.queryState(appID, storeName, query, socket)
+	if err != nil {
+		return nil, err
+	}
+
+	state := []ActorStateOutput{}
+	for _, result := range results {
+		id, key, ok := parseActorStateKey(result.Key, appID, actorType)
+		if !ok || (actorID != "" && id != actorID) {
+			continue
+		}
+		state = append(state, ActorStateOutput{ActorID: id, Key: key, Value: StateValue(result.Data)})
+	}
+	return state, nil
+}
+
+// actorHost returns appID, or the ID of the only running app that hosts actorType if appID is empty.
+func (s *Standalone) actorHost(appID, actorType string) (string, error) {
+	if appID != "" {
+		return appID, nil
+	}
+	actors, err := s.ListActors(actorType)
+	if err != nil {
+		return "", err
+	}
+	switch len(actors) {
+	case 0:
+		return "", fmt.Errorf("no running app hosts actor type %s", actorType)
+	case 1:
+		return actors[0].AppID, nil
+	}
+	appIDs := make([]string, 0, len(actors))
+	for _, a := range actors {
+		appIDs = append(appIDs, a.AppID)
+	}
+	return "", fmt.Errorf("actor type %s is hosted by the apps %s. Use --app-id to choose one", actorType, strings.Join(appIDs, ", "))
+}
+
+// parseActorStateKey returns the actor ID and key of a key of the actor state store, which is stored as
+// "<app ID>||<actor type>||<actor ID>||<key>". The app ID prefix is removed by some versions of the query API.
+// ok is false if the key is not part of the state of an actor of actorType hosted by appID.
+func parseActorStateKey(stateKey, appID, actorType string) (actorID, key string, ok bool) {
+	parts := strings.SplitN(stateKey, actorStateKeySeparator, 4)
+	if len(parts) == 4 && parts[0] == appID && parts[1] == actorType {
+		return parts[2], parts[3], true
+	}
+	parts = strings.SplitN(stateKey, actorStateKeySeparator, 3)
+	if len(parts) == 3 && parts[0] == actorType {
+		return parts[1], parts[2], true
+	}
+	return "", "", false
+}
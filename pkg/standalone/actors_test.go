@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// actorsTestHandler serves the metadata of a sidecar that hosts actorTypes, and the actor state APIs.
+func actorsTestHandler(t *testing.T, appID string, actorTypes []string, queryResponses []string) http.Handler {
+	t.Helper()
+	queries := 0
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1.0/metadata":
+			actors := []map[string]interface{}{}
+			for i, actorType := range actorTypes {
+				actors = append(actors, map[string]interface{}{"type": actorType, "count": i + 1})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": appID, "actors": actors})
+		case r.URL.Path == "/v1.0/actors/MyActor/1/state/balance":
+			w.Write([]byte(`{"amount":10}`))
+		case r.URL.Path == "/v1.0/actors/MyActor/2/state/balance":
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/v1.0-alpha1/state/statestore/query":
+			body, _ := io.ReadAll(r.Body)
+			var q map[string]interface{}
+			assert.NoError(t, json.Unmarshal(body, &q))
+			if queries > 0 {
+				assert.Equal(t, fmt.Sprintf("%d", queries), q["page"].(map[string]interface{})["token"])
+			}
+			w.Write([]byte(queryResponses[queries]))
+			queries++
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestListActors(t *testing.T) {
+	ts1, port1 := getTestServerFunc(actorsTestHandler(t, "app1", []string{"MyActor", "Other"}, nil))
+	ts1.Start()
+	defer ts1.Close()
+	ts2, port2 := getTestServerFunc(actorsTestHandler(t, "app2", []string{"MyActor"}, nil))
+	ts2.Start()
+	defer ts2.Close()
+
+	client := &Standalone{
+		process: &mockDaprProcess{
+			Lo: []ListOutput{
+				{AppID: "app2", HTTPPort: port2},
+				{AppID: "app1", HTTPPort: port1},
+				{AppID: "socket-app"},
+			},
+		},
+	}
+
+	t.Run("all actor types", func(t *testing.T) {
+		actors, err := client.ListActors("")
+		require.NoError(t, err)
+		assert.Equal(t, []ActorsOutput{
+			{ActorType: "MyActor", AppID: "app1", HTTPPort: port1, ActiveActors: 1},
+			{ActorType: "MyActor", AppID: "app2", HTTPPort: port2, ActiveActors: 1},
+			{ActorType: "Other", AppID: "app1", HTTPPort: port1, ActiveActors: 2},
+		}, actors)
+	})
+
+	t.Run("one actor type", func(t *testing.T) {
+		actors, err := client.ListActors("Other")
+		require.NoError(t, err)
+		assert.Equal(t, []ActorsOutput{{ActorType: "Other", AppID: "app1", HTTPPort: port1, ActiveActors: 2}}, actors)
+	})
+
+	t.Run("actor host must be chosen", func(t *testing.T) {
+		_, err := client.GetActorState("", "MyActor", "1", "balance", "")
+		assert.EqualError(t, err, "actor type MyActor is hosted by the apps app1, app2. Use --app-id to choose one")
+	})
+
+	t.Run("no actor host", func(t *testing.T) {
+		_, err := client.GetActorState("", "Missing", "1", "balance", "")
+		assert.EqualError(t, err, "no running app hosts actor type Missing")
+	})
+}
+
+func TestGetActorState(t *testing.T) {
+	ts, port := getTestServerFunc(actorsTestHandler(t, "app1", []string{"MyActor"}, nil))
+	ts.Start()
+	defer ts.Close()
+
+	client := &Standalone{
+		process: &mockDaprProcess{
+			Lo: []ListOutput{{AppID: "app1", HTTPPort: port}},
+		},
+	}
+
+	t.Run("found", func(t *testing.T) {
+		value, err := client.GetActorState("", "MyActor", "1", "balance", "")
+		require.NoError(t, err)
+		assert.Equal(t, `{"amount":10}`, value.String())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.GetActorState("app1", "MyActor", "2", "balance", "")
+		assert.EqualError(t, err, "key balance not found in the state of actor MyActor/2")
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		_, err := client.GetActorState("app1", "MyActor", "1", "", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestQueryActorState(t *testing.T) {
+	responses := []string{
+		`{"results":[{"key":"app1||MyActor||1||balance","data":{"amount":10}},{"key":"app1||Other||1||balance","data":1}],"token":"1"}`,
+		`{"results":[{"key":"MyActor||2||balance","data":{"amount":20}},{"key":"app2||MyActor||3||balance","data":1},{"key":"plain","data":1}]}`,
+	}
+
+	t.Run("all actors", func(t *testing.T) {
+		ts, port := getTestServerFunc(actorsTestHandler(t, "app1", []string{"MyActor"}, responses))
+		ts.Start()
+		defer ts.Close()
+		client := &Standalone{process: &mockDaprProcess{Lo: []ListOutput{{AppID: "app1", HTTPPort: port}}}}
+
+		state, err := client.QueryActorState("", "MyActor", "", "statestore", nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, []ActorStateOutput{
+			{ActorID: "1", Key: "balance", Value: StateValue(`{"amount":10}`)},
+			{ActorID: "2", Key: "balance", Value: StateValue(`{"amount":20}`)},
+		}, state)
+	})
+
+	t.Run("one actor", func(t *testing.T) {
+		ts, port := getTestServerFunc(actorsTestHandler(t, "app1", []string{"MyActor"}, responses))
+		ts.Start()
+		defer ts.Close()
+		client := &Standalone{process: &mockDaprProcess{Lo: []ListOutput{{AppID: "app1", HTTPPort: port}}}}
+
+		state, err := client.QueryActorState("app1", "MyActor", "2", "statestore", []byte(`{"page":{"limit":2}}`), "")
+		require.NoError(t, err)
+		assert.Equal(t, []ActorStateOutput{{ActorID: "2", Key: "balance", Value: StateValue(`{"amount":20}`)}}, state)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		client := &Standalone{process: &mockDaprProcess{}}
+		_, err := client.QueryActorState("app1", "MyActor", "", "statestore", []byte("{"), "")
+		assert.ErrorContains(t, err, "invalid state query")
+	})
+}
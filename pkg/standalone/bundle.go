@@ -15,12 +15,22 @@ package standalone
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	path_filepath "path/filepath"
 	"strings"
+
+	"github.com/dapr/cli/pkg/print"
+	cli_ver "github.com/dapr/cli/pkg/version"
+	"github.com/dapr/cli/utils"
 )
 
-const bundleDetailsFileName = "details.json"
+const (
+	bundleDetailsFileName = "details.json"
+	bundleBinarySubDir    = "dist"
+	bundleImageSubDir     = "docker"
+)
 
 type bundleDetails struct {
 	RuntimeVersion    *string `json:"daprd"`
@@ -62,3 +72,110 @@ func (b *bundleDetails) getPlacementImageName() string {
 func (b *bundleDetails) getPlacementImageFileName() string {
 	return *b.DaprImageFileName
 }
+
+// DownloadBundle downloads the runtime, placement and dashboard binaries and the Dapr container image
+// into outputDir, creating a bundle that can be installed offline with `dapr init --from-dir`.
+// The checksums of the binaries are verified unless insecureSkipVerify is set.
+func DownloadBundle(runtimeVersion, dashboardVersion, cliVersion, imageRegistryURL, outputDir string, insecureSkipVerify bool) error {
+	var err error
+	outputDir = strings.TrimSpace(outputDir)
+	if outputDir == "" {
+		return errors.New("an output directory is required to download the installation bundle")
+	}
+
+	if !utils.IsDockerInstalled() {
+		return errors.New("could not connect to Docker. Docker is required to save the Dapr container image to the bundle")
+	}
+
+	if len(strings.TrimSpace(imageRegistryURL)) == 0 {
+		defaultImageRegistryName, err = utils.GetDefaultRegistry(githubContainerRegistryName, dockerContainerRegistryName)
+		if err != nil {
+			return err
+		}
+	}
+
+	if runtimeVersion == latestVersion {
+		runtimeVersion, err = cli_ver.GetDaprVersion()
+		if err != nil {
+			return fmt.Errorf("cannot get the latest release version: '%w'. Try specifying --runtime-version=<desired_version>", err)
+		}
+	}
+
+	if dashboardVersion == latestVersion {
+		dashboardVersion, err = cli_ver.GetDashboardVersion()
+		if err != nil {
+			return fmt.Errorf("cannot get the latest dashboard version: '%w'. Try specifying --dashboard-version=<desired_version>", err)
+		}
+	}
+
+	binaryDir := path_filepath.Join(outputDir, bundleBinarySubDir)
+	imageDir := path_filepath.Join(outputDir, bundleImageSubDir)
+	for _, dir := range []string{binaryDir, imageDir} {
+		err = os.MkdirAll(dir, 0o755)
+		if err != nil {
+			return fmt.Errorf("error creating bundle directory %s: %w", dir, err)
+		}
+	}
+
+	print.InfoStatusEvent(os.Stdout, "Downloading runtime version %s and dashboard version %s to %s", runtimeVersion, dashboardVersion, outputDir)
+
+	stopSpinning := print.Spinner(os.Stdout, "Downloading binaries and container image...")
+	defer stopSpinning(print.Failure)
+
+	binaries := []struct {
+		filePrefix string
+		version    string
+		githubRepo string
+	}{
+		{daprRuntimeFilePrefix, runtimeVersion, cli_ver.DaprGitHubRepo},
+		{placementServiceFilePrefix, runtimeVersion, cli_ver.DaprGitHubRepo},
+		{dashboardFilePrefix, dashboardVersion, cli_ver.DashboardGitHubRepo},
+	}
+	for _, b := range binaries {
+		_, err = downloadBinary(binaryDir, b.version, b.filePrefix, b.githubRepo, insecureSkipVerify)
+		if err != nil {
+			return fmt.Errorf("error downloading %s binary: %w", b.filePrefix, err)
+		}
+	}
+
+	imgInfo := daprImageInfo{
+		ghcrImageName:      daprGhcrImageName,
+		dockerHubImageName: daprDockerImageName,
+		imageRegistryURL:   imageRegistryURL,
+		imageRegistryName:  defaultImageRegistryName,
+	}
+	image, err := getPlacementImageName(imgInfo, initInfo{runtimeVersion: runtimeVersion})
+	if err != nil {
+		return err
+	}
+	imageFileName := fmt.Sprintf("%s-%s.tar", daprGhcrImageName, runtimeVersion)
+	err = saveDockerImage(image, path_filepath.Join(imageDir, imageFileName))
+	if err != nil {
+		return err
+	}
+
+	details := bundleDetails{
+		RuntimeVersion:    &runtimeVersion,
+		DashboardVersion:  &dashboardVersion,
+		CLIVersion:        &cliVersion,
+		BinarySubDir:      stringPtr(bundleBinarySubDir),
+		ImageSubDir:       stringPtr(bundleImageSubDir),
+		DaprImageName:     &image,
+		DaprImageFileName: &imageFileName,
+	}
+	b, err := json.MarshalIndent(details, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(path_filepath.Join(outputDir, bundleDetailsFileName), b, 0o600)
+	if err != nil {
+		return fmt.Errorf("error writing bundle details: %w", err)
+	}
+
+	stopSpinning(print.Success)
+	return nil
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -101,3 +101,8 @@ func TestParseDetailsMissingFile(t *testing.T) {
 	err = bd.readAndParseDetails(f.Name())
 	assert.Error(t, err, "expected error on parsing missing details file")
 }
+
+func TestDownloadBundleRequiresOutputDir(t *testing.T) {
+	err := DownloadBundle("1.8.0", "0.10.0", "1.8.0", "", " ", false)
+	assert.EqualError(t, err, "an output directory is required to download the installation bundle")
+}
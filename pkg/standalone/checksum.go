@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/utils"
+)
+
+const (
+	// checksumFileExt is the extension of the checksum files published next to the release archives.
+	checksumFileExt = ".sha256"
+
+	checksumDownloadTimeout = 30 * time.Second
+	// maxChecksumFileSize limits the size of a checksum file read from a release.
+	maxChecksumFileSize = 64 * 1024
+)
+
+// verifyChecksum compares the SHA256 checksum of the file at filePath, downloaded from fileURL,
+// with the checksum published at fileURL with the .sha256 extension.
+func verifyChecksum(filePath, fileURL string) error {
+	fileName := path.Base(fileURL)
+	expected, err := downloadChecksum(fileURL + checksumFileExt)
+	if err != nil {
+		return fmt.Errorf("could not get the checksum of %s: %w. Use --insecure-skip-verify to install without verifying it", fileName, err)
+	}
+	want, err := parseChecksum(expected, fileName)
+	if err != nil {
+		return err
+	}
+
+	got, err := fileSHA256(filePath)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(want, got) {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s. The download may be corrupted or tampered with", fileName, want, got)
+	}
+	print.DebugStatusEvent(os.Stderr, "Verified checksum %s of %s", got, fileName)
+	return nil
+}
+
+func downloadChecksum(url string) (string, error) {
+	resp, err := utils.NewHTTPClient(checksumDownloadTimeout).Get(url) //nolint:noctx
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("download of %s failed with %d", url, resp.StatusCode)
+	}
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxChecksumFileSize))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// parseChecksum returns the checksum of fileName from the content of a checksum file. The file holds either
+// a single checksum or lines of a checksum followed by a file name, as written by sha256sum.
+func parseChecksum(content, fileName string) (string, error) {
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) == 1 && len(lines) == 1 || len(fields) > 1 && strings.TrimPrefix(fields[1], "*") == fileName {
+			if _, err := hex.DecodeString(fields[0]); err != nil || len(fields[0]) != sha256.Size*2 {
+				return "", fmt.Errorf("invalid checksum %q for %s", fields[0], fileName)
+			}
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("no checksum found for %s", fileName)
+}
+
+func fileSHA256(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("error computing checksum of %s: %w", filePath, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// helloSHA256 is the SHA256 checksum of "hello".
+const helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func TestVerifyChecksum(t *testing.T) {
+	checksums := map[string]string{
+		"/daprd.tar.gz.sha256":     helloSHA256 + "  daprd.tar.gz\n",
+		"/tampered.tar.gz.sha256":  "0000000000000000000000000000000000000000000000000000000000000000",
+		"/dashboard.tar.gz.sha256": helloSHA256,
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checksum, ok := checksums[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(checksum))
+	}))
+	defer ts.Close()
+
+	file := filepath.Join(t.TempDir(), "archive")
+	assert.NoError(t, os.WriteFile(file, []byte("hello"), 0o600))
+
+	t.Run("checksum matches", func(t *testing.T) {
+		assert.NoError(t, verifyChecksum(file, ts.URL+"/daprd.tar.gz"))
+		assert.NoError(t, verifyChecksum(file, ts.URL+"/dashboard.tar.gz"))
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		err := verifyChecksum(file, ts.URL+"/tampered.tar.gz")
+		assert.EqualError(t, err, "checksum mismatch for tampered.tar.gz: expected 0000000000000000000000000000000000000000000000000000000000000000, got "+helloSHA256+". The download may be corrupted or tampered with")
+	})
+
+	t.Run("checksum not published", func(t *testing.T) {
+		err := verifyChecksum(file, ts.URL+"/placement.tar.gz")
+		assert.ErrorContains(t, err, "could not get the checksum of placement.tar.gz")
+		assert.ErrorContains(t, err, "Use --insecure-skip-verify to install without verifying it")
+	})
+}
+
+func TestParseChecksum(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected string
+		err      string
+	}{
+		{name: "single checksum", content: helloSHA256 + "\n", expected: helloSHA256},
+		{name: "sha256sum format", content: helloSHA256 + "  daprd.tar.gz", expected: helloSHA256},
+		{name: "sha256sum binary format", content: helloSHA256 + " *daprd.tar.gz", expected: helloSHA256},
+		{name: "multiple files", content: "0000  placement.tar.gz\n" + helloSHA256 + "  daprd.tar.gz\n", expected: helloSHA256},
+		{name: "other file", content: helloSHA256 + "  placement.tar.gz", err: "no checksum found for daprd.tar.gz"},
+		{name: "invalid checksum", content: "not-a-checksum", err: `invalid checksum "not-a-checksum" for daprd.tar.gz`},
+		{name: "empty", content: "", err: "no checksum found for daprd.tar.gz"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checksum, err := parseChecksum(tc.content, "daprd.tar.gz")
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, checksum)
+		})
+	}
+}
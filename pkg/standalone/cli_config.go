@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	path_filepath "path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// loadCLIConfig reads the CLI configuration file at path. A missing file is an empty configuration.
+func loadCLIConfig(path string) (CLIConfig, error) {
+	var config CLIConfig
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, fmt.Errorf("error reading CLI configuration: %w", err)
+	}
+	if err = yaml.Unmarshal(b, &config); err != nil {
+		return config, fmt.Errorf("error parsing CLI configuration in %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// saveCLIConfig writes config to the CLI configuration file at path. Comments in the file are not kept.
+func saveCLIConfig(path string, config CLIConfig) error {
+	b, err := yaml.Marshal(&config)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(path_filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error saving CLI configuration: %w", err)
+	}
+	if err = os.WriteFile(path, b, 0o600); err != nil {
+		return fmt.Errorf("error saving CLI configuration: %w", err)
+	}
+	return nil
+}
+
+// saveInitLayout records the resources directory and the Dapr configuration file chosen by dapr init in the CLI
+// configuration file, so that they are the defaults of the other commands. Empty values keep the current ones.
+func saveInitLayout(resourcesPath, configFile string) error {
+	path := DefaultCLIConfigFilePath()
+	config, err := loadCLIConfig(path)
+	if err != nil {
+		return err
+	}
+	if resourcesPath != "" {
+		config.ResourcesPath = resourcesPath
+	}
+	if configFile != "" {
+		config.ConfigFile = configFile
+	}
+	return saveCLIConfig(path, config)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+	"os"
+	path_filepath "path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cli_ver "github.com/dapr/cli/pkg/version"
+)
+
+func TestSaveInitLayout(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	defaultComponentsDir, defaultConfigFile := DefaultComponentsDirPath(), DefaultConfigFilePath()
+	require.NoError(t, os.MkdirAll(defaultDaprDirPath(), 0o755))
+	require.NoError(t, os.WriteFile(DefaultCLIConfigFilePath(), []byte(testCLIConfig), 0o600))
+
+	require.NoError(t, saveInitLayout("/work/dapr/resources", ""))
+	assert.Equal(t, "/work/dapr/resources", DefaultComponentsDirPath())
+	assert.Equal(t, defaultConfigFile, DefaultConfigFilePath())
+
+	require.NoError(t, saveInitLayout("", "/work/dapr/config.yaml"))
+	assert.Equal(t, "/work/dapr/resources", DefaultComponentsDirPath())
+	assert.Equal(t, "/work/dapr/config.yaml", DefaultConfigFilePath())
+
+	profile, err := LoadRunProfile(DefaultCLIConfigFilePath(), "debug")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"DEBUG": "true"}, profile.Env, "the run profiles are kept")
+
+	require.NoError(t, os.Remove(DefaultCLIConfigFilePath()))
+	assert.Equal(t, defaultComponentsDir, DefaultComponentsDirPath())
+}
+
+func TestInstallActionsCustomLayout(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	setAirGapInit("")
+	dir := t.TempDir()
+
+	actions := installActions(initInfo{
+		runtimeVersion: "1.9.0",
+		components:     []string{RedisInitComponent},
+		resourcesPath:  path_filepath.Join(dir, "resources"),
+		configFile:     path_filepath.Join(dir, "config.yaml"),
+	})
+	assert.Equal(t, []string{
+		fmt.Sprintf("download %s to %s", cli_ver.ReleaseURL(cli_ver.DaprGitHubRepo, "1.9.0", binaryName(daprRuntimeFilePrefix)), defaultDaprBinPath()),
+		"write component file " + path_filepath.Join(dir, "resources", "pubsub.yaml"),
+		"write component file " + path_filepath.Join(dir, "resources", "statestore.yaml"),
+		"write configuration file " + path_filepath.Join(dir, "config.yaml"),
+		"record the resources directory and configuration file in " + DefaultCLIConfigFilePath(),
+	}, actions)
+}
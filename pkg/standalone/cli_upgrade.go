@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	path_filepath "path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/dapr/cli/pkg/print"
+	cli_ver "github.com/dapr/cli/pkg/version"
+)
+
+const (
+	cliFilePrefix = "dapr"
+	// oldCLISuffix is the suffix of the replaced binary on Windows, where a running binary cannot be overwritten.
+	oldCLISuffix = ".old"
+)
+
+// UpgradeCLIConfig represents the options for upgrading the CLI binary.
+type UpgradeCLIConfig struct {
+	// Version is the version to install. Defaults to the latest release.
+	Version string
+	// CurrentVersion is the version of the running CLI.
+	CurrentVersion string
+	// MirrorURL replaces https://github.com/dapr/cli/releases/download as the location of the release archives,
+	// which are read from <MirrorURL>/v<version>/<archive>.
+	MirrorURL string
+	// InsecureSkipVerify disables the verification of the checksum of the downloaded archive.
+	InsecureSkipVerify bool
+	// Force installs the version even if it is the current version.
+	Force bool
+	// ExecutablePath is the path of the binary to replace. Defaults to the running executable.
+	ExecutablePath string
+}
+
+// UpgradeCLI downloads a release of the CLI, verifies its checksum and replaces the binary with it.
+// The new binary is written next to the current one and renamed over it, so the CLI is never left half-written.
+// It returns the installed version, and false if the CLI already was at that version.
+func UpgradeCLI(config UpgradeCLIConfig) (string, bool, error) {
+	version := strings.TrimPrefix(config.Version, "v")
+	if version == "" || version == latestVersion {
+		if config.MirrorURL != "" {
+			return "", false, errors.New("a version is required to upgrade the CLI from a mirror")
+		}
+		var err error
+		if version, err = cli_ver.GetCLIVersion(); err != nil {
+			return "", false, fmt.Errorf("cannot get the latest release version: %w", err)
+		}
+	}
+	if !config.Force && version == strings.TrimPrefix(config.CurrentVersion, "v") {
+		return version, false, nil
+	}
+
+	exePath, err := cliExecutablePath(config.ExecutablePath)
+	if err != nil {
+		return "", false, err
+	}
+	// Download next to the binary, so that the new binary can be renamed over it.
+	tempDir, err := os.MkdirTemp(path_filepath.Dir(exePath), ".dapr-upgrade-")
+	if err != nil {
+		if runtime.GOOS != daprWindowsOS && errors.Is(err, os.ErrPermission) {
+			return "", false, fmt.Errorf("cannot write to %s: %w - please run with sudo", path_filepath.Dir(exePath), err)
+		}
+		return "", false, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	fileURL := cliReleaseURL(config.MirrorURL, version)
+	archivePath, err := downloadFile(tempDir, fileURL)
+	if err != nil {
+		return "", false, fmt.Errorf("error downloading the CLI: %w", err)
+	}
+	if !config.InsecureSkipVerify {
+		if err = verifyChecksum(archivePath, fileURL); err != nil {
+			return "", false, err
+		}
+	}
+
+	binaryPath, err := extractFile(archivePath, tempDir, cliFilePrefix)
+	if err != nil {
+		return "", false, err
+	}
+	if binaryPath == "" {
+		return "", false, fmt.Errorf("no %s binary found in %s", cliFilePrefix, path_filepath.Base(fileURL))
+	}
+	if err = makeExecutable(binaryPath); err != nil {
+		return "", false, fmt.Errorf("error making the CLI binary executable: %w", err)
+	}
+
+	if err = replaceExecutable(binaryPath, exePath); err != nil {
+		return "", false, fmt.Errorf("error replacing %s: %w", exePath, err)
+	}
+	print.DebugStatusEvent(os.Stderr, "Replaced %s with the CLI from %s", exePath, fileURL)
+	return version, true, nil
+}
+
+// cliReleaseURL returns the URL of the CLI archive of the version for this platform.
+func cliReleaseURL(mirrorURL, version string) string {
+	if mirrorURL == "" {
+		return cli_ver.ReleaseURL(cli_ver.CLIGitHubRepo, version, binaryName(cliFilePrefix))
+	}
+	return fmt.Sprintf("%s/v%s/%s", strings.TrimSuffix(mirrorURL, "/"), version, binaryName(cliFilePrefix))
+}
+
+func cliExecutablePath(exePath string) (string, error) {
+	if exePath == "" {
+		var err error
+		if exePath, err = os.Executable(); err != nil {
+			return "", fmt.Errorf("cannot find the CLI binary: %w", err)
+		}
+	}
+	// Replace the binary that a symlink, such as one created by a package manager, points to.
+	resolved, err := path_filepath.EvalSymlinks(exePath)
+	if err != nil {
+		return "", fmt.Errorf("cannot find the CLI binary: %w", err)
+	}
+	return resolved, nil
+}
+
+// replaceExecutable renames newPath over exePath. On Windows, the running binary is moved aside first
+// and removed on the next upgrade.
+func replaceExecutable(newPath, exePath string) error {
+	if runtime.GOOS != daprWindowsOS {
+		return os.Rename(newPath, exePath)
+	}
+
+	oldPath := exePath + oldCLISuffix
+	os.Remove(oldPath)
+	if err := os.Rename(exePath, oldPath); err != nil {
+		return err
+	}
+	if err := os.Rename(newPath, exePath); err != nil {
+		// Restore the previous binary.
+		os.Rename(oldPath, exePath)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cliArchive returns a release archive of the CLI for this platform holding a binary with the given content.
+func cliArchive(t *testing.T, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if runtime.GOOS == daprWindowsOS {
+		zw := zip.NewWriter(&buf)
+		w, err := zw.Create("dapr.exe")
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+		return buf.Bytes()
+	}
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "dapr", Mode: 0o755, Size: int64(len(content))}))
+	_, err := tw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestUpgradeCLI(t *testing.T) {
+	archive := cliArchive(t, "new cli")
+	sum := sha256.Sum256(archive)
+	archivePath := "/v1.9.0/" + binaryName(cliFilePrefix)
+	files := map[string][]byte{
+		archivePath:                                              archive,
+		archivePath + checksumFileExt:                            []byte(hex.EncodeToString(sum[:])),
+		"/v1.9.1/" + binaryName(cliFilePrefix):                   archive,
+		"/v1.9.1/" + binaryName(cliFilePrefix) + checksumFileExt: []byte("0000000000000000000000000000000000000000000000000000000000000000"),
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(b)
+	}))
+	defer ts.Close()
+
+	newExecutable := func(t *testing.T) string {
+		t.Helper()
+		exePath := filepath.Join(t.TempDir(), binaryFilePath("", cliFilePrefix))
+		require.NoError(t, os.WriteFile(exePath, []byte("old cli"), 0o700))
+		return exePath
+	}
+	assertContent := func(t *testing.T, path, expected string) {
+		t.Helper()
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(b))
+	}
+
+	t.Run("upgrade from a mirror", func(t *testing.T) {
+		exePath := newExecutable(t)
+		version, upgraded, err := UpgradeCLI(UpgradeCLIConfig{Version: "v1.9.0", CurrentVersion: "1.8.0", MirrorURL: ts.URL + "/", ExecutablePath: exePath})
+		require.NoError(t, err)
+		assert.Equal(t, "1.9.0", version)
+		assert.True(t, upgraded)
+		assertContent(t, exePath, "new cli")
+
+		entries, err := os.ReadDir(filepath.Dir(exePath))
+		require.NoError(t, err)
+		expected := []string{filepath.Base(exePath)}
+		if runtime.GOOS == daprWindowsOS {
+			expected = append(expected, filepath.Base(exePath)+oldCLISuffix)
+		}
+		names := []string{}
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		assert.ElementsMatch(t, expected, names, "the download directory is removed")
+	})
+
+	t.Run("upgrade through a symlink", func(t *testing.T) {
+		if runtime.GOOS == daprWindowsOS {
+			t.Skip("symlinks require privileges on Windows")
+		}
+		exePath := newExecutable(t)
+		link := filepath.Join(t.TempDir(), "dapr")
+		require.NoError(t, os.Symlink(exePath, link))
+		_, _, err := UpgradeCLI(UpgradeCLIConfig{Version: "1.9.0", MirrorURL: ts.URL, ExecutablePath: link})
+		require.NoError(t, err)
+		assertContent(t, exePath, "new cli")
+	})
+
+	t.Run("already at the version", func(t *testing.T) {
+		exePath := newExecutable(t)
+		version, upgraded, err := UpgradeCLI(UpgradeCLIConfig{Version: "1.9.0", CurrentVersion: "1.9.0", MirrorURL: ts.URL, ExecutablePath: exePath})
+		require.NoError(t, err)
+		assert.Equal(t, "1.9.0", version)
+		assert.False(t, upgraded)
+		assertContent(t, exePath, "old cli")
+
+		_, upgraded, err = UpgradeCLI(UpgradeCLIConfig{Version: "1.9.0", CurrentVersion: "1.9.0", MirrorURL: ts.URL, ExecutablePath: exePath, Force: true})
+		require.NoError(t, err)
+		assert.True(t, upgraded)
+		assertContent(t, exePath, "new cli")
+	})
+
+	t.Run("checksum mismatch keeps the binary", func(t *testing.T) {
+		exePath := newExecutable(t)
+		_, _, err := UpgradeCLI(UpgradeCLIConfig{Version: "1.9.1", MirrorURL: ts.URL, ExecutablePath: exePath})
+		assert.ErrorContains(t, err, "checksum mismatch")
+		assertContent(t, exePath, "old cli")
+
+		_, upgraded, err := UpgradeCLI(UpgradeCLIConfig{Version: "1.9.1", MirrorURL: ts.URL, ExecutablePath: exePath, InsecureSkipVerify: true})
+		require.NoError(t, err)
+		assert.True(t, upgraded)
+		assertContent(t, exePath, "new cli")
+	})
+
+	t.Run("version not found", func(t *testing.T) {
+		exePath := newExecutable(t)
+		_, _, err := UpgradeCLI(UpgradeCLIConfig{Version: "2.0.0", MirrorURL: ts.URL, ExecutablePath: exePath})
+		assert.ErrorContains(t, err, "version not found")
+		assertContent(t, exePath, "old cli")
+	})
+
+	t.Run("mirror requires a version", func(t *testing.T) {
+		_, _, err := UpgradeCLI(UpgradeCLIConfig{MirrorURL: ts.URL})
+		assert.EqualError(t, err, "a version is required to upgrade the CLI from a mirror")
+	})
+}
+
+func TestCLIReleaseURL(t *testing.T) {
+	archive := binaryName(cliFilePrefix)
+	assert.Equal(t, "https://github.com/dapr/cli/releases/download/v1.9.0/"+archive, cliReleaseURL("", "1.9.0"))
+	assert.Equal(t, "https://mirror.example.com/dapr/v1.9.0/"+archive, cliReleaseURL("https://mirror.example.com/dapr/", "1.9.0"))
+}
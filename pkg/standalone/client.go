@@ -13,6 +13,15 @@ limitations under the License.
 
 package standalone
 
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/dapr/cli/pkg/metadata"
+)
+
 type DaprProcess interface {
 	List() ([]ListOutput, error)
 }
@@ -22,9 +31,75 @@ type daprProcess struct{}
 // Client is the interface the wraps all the methods exposed by the Dapr CLI.
 type Client interface {
 	// Invoke is a command to invoke a remote or local dapr instance.
-	Invoke(appID, method string, data []byte, verb string, socket string) (string, error)
+	Invoke(ctx context.Context, appID, method string, data []byte, verb, contentType string, headers http.Header, query url.Values, socket string) (string, error)
+	// InvokeGRPC is a command to invoke a remote or local dapr instance using the gRPC API.
+	InvokeGRPC(ctx context.Context, appID, method string, data []byte, verb, contentType string, headers http.Header, query url.Values, socket string) (string, error)
 	// Publish is used to publish event to a topic in a pubsub for an app ID.
-	Publish(publishAppID, pubsubName, topic string, payload []byte, socket string, metadata map[string]interface{}) error
+	Publish(ctx context.Context, publishAppID, pubsubName, topic string, payload []byte, headers http.Header, socket string, metadata map[string]interface{}) error
+	// BulkPublish is used to publish multiple events to a topic in a pubsub for an app ID in a single call.
+	BulkPublish(ctx context.Context, publishAppID, pubsubName, topic string, events [][]byte, headers http.Header, socket string, metadata map[string]interface{}) (BulkPublishResult, error)
+	// ListActors returns the actor types registered by the running apps and their number of active actors.
+	ListActors(actorType string) ([]ActorsOutput, error)
+	// ListSubscriptions returns the pub/sub subscriptions registered by the sidecar of an app.
+	ListSubscriptions(appID, socket string) ([]metadata.SubscriptionOutput, error)
+	// GetActorState returns the value of a key in the state of an actor.
+	GetActorState(appID, actorType, actorID, key, socket string) (StateValue, error)
+	// QueryActorState returns the keys of the state of the actors of a type that match a state query.
+	QueryActorState(appID, actorType, actorID, storeName string, query []byte, socket string) ([]ActorStateOutput, error)
+	// GetConfiguration returns the items of a configuration store.
+	GetConfiguration(appID, storeName string, keys []string, metadata map[string]string, socket string) ([]ConfigurationItemOutput, error)
+	// SubscribeConfiguration calls onUpdate with the changed items of a configuration store until ctx is done.
+	SubscribeConfiguration(ctx context.Context, appID, storeName string, keys []string, metadata map[string]string, socket string, onUpdate func([]ConfigurationItemOutput)) error
+	// GetSecret returns the values of a secret of a secret store.
+	GetSecret(appID, storeName, key string, metadata map[string]string, socket string) ([]SecretOutput, error)
+	// ListSecrets returns the values of all secrets of a secret store that the app is allowed to read.
+	ListSecrets(appID, storeName string, metadata map[string]string, socket string) ([]SecretOutput, error)
+	// Health checks the health of the sidecar of an app, waiting up to timeout for it to be healthy.
+	Health(ctx context.Context, appID, socket string, timeout time.Duration) (HealthOutput, error)
+	// GetState returns the values of keys in a state store.
+	GetState(appID, storeName string, keys []string, socket string) ([]StateItemOutput, error)
+	// SaveState saves items in a state store.
+	SaveState(appID, storeName string, items []StateItem, socket string) error
+	// DeleteState deletes keys from a state store.
+	DeleteState(appID, storeName string, keys []string, socket string) error
+	// QueryState returns the keys of a state store that match a state query.
+	QueryState(appID, storeName string, query []byte, socket string) ([]StateItemOutput, error)
+	// StartWorkflow starts a workflow instance and returns its instance ID.
+	StartWorkflow(appID, component, workflowName, instanceID string, input []byte, socket string) (string, error)
+	// GetWorkflow returns the status of a workflow instance.
+	GetWorkflow(appID, component, instanceID, socket string) (WorkflowOutput, error)
+	// ListWorkflows returns the status of the workflow instances of an app.
+	ListWorkflows(appID, component, storeName, socket string) ([]WorkflowOutput, error)
+	// WorkflowHistory returns the history events of a workflow instance.
+	WorkflowHistory(appID, storeName, instanceID, socket string) ([]WorkflowHistoryOutput, error)
+	// TerminateWorkflow terminates a running workflow instance.
+	TerminateWorkflow(appID, component, instanceID, socket string) error
+	// PauseWorkflow suspends a running workflow instance.
+	PauseWorkflow(appID, component, instanceID, socket string) error
+	// ResumeWorkflow resumes a suspended workflow instance.
+	ResumeWorkflow(appID, component, instanceID, socket string) error
+	// PurgeWorkflow removes the state and history of a workflow instance.
+	PurgeWorkflow(appID, component, instanceID, socket string) error
+	// RaiseWorkflowEvent sends an event to a workflow instance.
+	RaiseWorkflowEvent(appID, component, instanceID, eventName string, data []byte, socket string) error
+	// TestComponent performs a round trip against a component through a sidecar.
+	TestComponent(name string, opts ComponentTestOptions) ([]ComponentTestOutput, error)
+	// ScheduleJob schedules a job of the scheduler, replacing a job with the same name.
+	ScheduleJob(appID, name string, job Job, socket string) error
+	// GetJob returns a job of the scheduler.
+	GetJob(appID, name, socket string) (JobOutput, error)
+	// ListJobs returns the jobs of an app that were scheduled with the CLI.
+	ListJobs(appID, socket string) ([]JobOutput, error)
+	// DeleteJob deletes a job of the scheduler.
+	DeleteJob(appID, name, socket string) error
+	// TryLock tries to acquire a lock of a lock store.
+	TryLock(appID, storeName, resourceID, owner string, expiryInSeconds int, socket string) (LockOutput, error)
+	// Unlock releases a lock of a lock store.
+	Unlock(appID, storeName, resourceID, owner, socket string) (LockOutput, error)
+	// Encrypt encrypts data with a cryptography component.
+	Encrypt(appID, component string, data []byte, opts EncryptOptions, socket string) ([]byte, error)
+	// Decrypt decrypts data with a cryptography component.
+	Decrypt(appID, component string, data []byte, keyName, socket string) ([]byte, error)
 }
 
 type Standalone struct {
@@ -34,3 +109,18 @@ type Standalone struct {
 func NewClient() Client {
 	return &Standalone{process: &daprProcess{}}
 }
+
+// sidecarProcess lists a single sidecar reachable on local ports.
+type sidecarProcess struct {
+	sidecar ListOutput
+}
+
+func (p *sidecarProcess) List() ([]ListOutput, error) {
+	return []ListOutput{p.sidecar}, nil
+}
+
+// NewSidecarClient returns a client that calls the sidecar of appID on the given local HTTP and gRPC ports instead of
+// the sidecars run by the CLI, such as a sidecar in a Kubernetes cluster whose ports are forwarded to the local machine.
+func NewSidecarClient(appID string, httpPort, grpcPort int) Client {
+	return &Standalone{process: &sidecarProcess{sidecar: ListOutput{AppID: appID, HTTPPort: httpPort, GRPCPort: grpcPort}}}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	cloudEventSpecVersion = "1.0"
+	// DefaultCloudEventType is the type of the events that the sidecar wraps in a CloudEvents envelope itself.
+	DefaultCloudEventType = "com.dapr.event.sent"
+)
+
+// CloudEventOptions are the attributes of the CloudEvents envelope of a published event.
+type CloudEventOptions struct {
+	// ID defaults to a random UUID.
+	ID     string
+	Source string
+	// Type defaults to DefaultCloudEventType.
+	Type string
+}
+
+// cloudEvent is a CloudEvents envelope in the JSON format.
+type cloudEvent struct {
+	SpecVersion     string      `json:"specversion"`
+	ID              string      `json:"id"`
+	Source          string      `json:"source"`
+	Type            string      `json:"type"`
+	DataContentType string      `json:"datacontenttype"`
+	Data            interface{} `json:"data"`
+}
+
+// WrapCloudEvent returns payload in a CloudEvents envelope with the attributes of opts. The sidecar publishes an
+// envelope as is, instead of wrapping the payload in one with attributes of its own.
+func WrapCloudEvent(payload []byte, opts CloudEventOptions) ([]byte, error) {
+	if opts.Source == "" {
+		return nil, fmt.Errorf("the source of the CloudEvents envelope is required")
+	}
+	event := cloudEvent{
+		SpecVersion: cloudEventSpecVersion,
+		ID:          opts.ID,
+		Source:      opts.Source,
+		Type:        opts.Type,
+	}
+	if event.ID == "" {
+		id, err := randomUUID()
+		if err != nil {
+			return nil, err
+		}
+		event.ID = id
+	}
+	if event.Type == "" {
+		event.Type = DefaultCloudEventType
+	}
+	if json.Valid(payload) {
+		event.DataContentType = "application/json"
+		event.Data = json.RawMessage(payload)
+	} else {
+		event.DataContentType = "text/plain"
+		event.Data = string(payload)
+	}
+	return json.Marshal(event)
+}
+
+// ParsePublishMetadata returns the publish metadata given as JSON objects, such as {"ttlInSeconds":"10"},
+// or as comma-separated key=value pairs, such as ttlInSeconds=10,partitionKey=order1.
+func ParsePublishMetadata(values []string) (map[string]interface{}, error) {
+	metadata := map[string]interface{}{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, "{") {
+			if err := json.Unmarshal([]byte(value), &metadata); err != nil {
+				return nil, fmt.Errorf("error parsing metadata as JSON: %w", err)
+			}
+			continue
+		}
+		for _, pair := range strings.Split(value, ",") {
+			k, v, ok := strings.Cut(pair, "=")
+			if !ok || strings.TrimSpace(k) == "" {
+				return nil, fmt.Errorf("invalid metadata %q: must be key=value or a JSON object", pair)
+			}
+			metadata[strings.TrimSpace(k)] = v
+		}
+	}
+	return metadata, nil
+}
+
+// randomUUID returns a random version 4 UUID.
+func randomUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating event ID: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
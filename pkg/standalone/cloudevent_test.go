@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapCloudEvent(t *testing.T) {
+	t.Run("attributes are set", func(t *testing.T) {
+		b, err := WrapCloudEvent([]byte(`{"orderId":1}`), CloudEventOptions{ID: "order-1", Source: "checkout", Type: "com.example.order.created"})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"specversion":"1.0","id":"order-1","source":"checkout","type":"com.example.order.created","datacontenttype":"application/json","data":{"orderId":1}}`, string(b))
+		assert.Equal(t, "application/cloudevents+json", publishContentType(b))
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		b, err := WrapCloudEvent([]byte("hello"), CloudEventOptions{Source: "myapp"})
+		require.NoError(t, err)
+		var event cloudEvent
+		require.NoError(t, json.Unmarshal(b, &event))
+		assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), event.ID)
+		assert.Equal(t, DefaultCloudEventType, event.Type)
+		assert.Equal(t, "text/plain", event.DataContentType)
+		assert.Equal(t, "hello", event.Data)
+	})
+
+	t.Run("source is required", func(t *testing.T) {
+		_, err := WrapCloudEvent([]byte("hello"), CloudEventOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestParsePublishMetadata(t *testing.T) {
+	metadata, err := ParsePublishMetadata([]string{`{"rawPayload":"true"}`, "ttlInSeconds=10,partitionKey=order=1"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"rawPayload": "true", "ttlInSeconds": "10", "partitionKey": "order=1"}, metadata)
+
+	_, err = ParsePublishMetadata([]string{"ttlInSeconds"})
+	assert.Error(t, err)
+	_, err = ParsePublishMetadata([]string{`{"ttlInSeconds":`})
+	assert.Error(t, err)
+}
@@ -14,6 +14,7 @@ limitations under the License.
 package standalone
 
 import (
+	"fmt"
 	"os"
 	path_filepath "path/filepath"
 	"runtime"
@@ -24,9 +25,42 @@ const (
 	defaultDaprBinDirName    = "bin"
 	defaultComponentsDirName = "components"
 	defaultConfigFileName    = "config.yaml"
+	defaultCLIConfigFileName = "config"
+	defaultHistoryFileName   = "history"
+	defaultTelemetryFileName = "telemetry.json"
+	defaultVersionCacheName  = "versions.json"
 )
 
+// installPath is the Dapr directory set with SetInstallPath. It replaces the .dapr directory of the user.
+var installPath string
+
+// SetInstallPath sets the directory that holds the binaries, components and configuration of the self-hosted
+// installation, instead of the .dapr directory of the user.
+func SetInstallPath(path string) error {
+	absPath, err := path_filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("invalid install path %q: %w", path, err)
+	}
+	installPath = absPath
+	return nil
+}
+
+// absPathOrEmpty returns path as an absolute path, or an empty string if path is empty.
+func absPathOrEmpty(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	absPath, err := path_filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid path %q: %w", path, err)
+	}
+	return absPath, nil
+}
+
 func defaultDaprDirPath() string {
+	if installPath != "" {
+		return installPath
+	}
 	homeDir, _ := os.UserHomeDir()
 	return path_filepath.Join(homeDir, defaultDaprDirName)
 }
@@ -43,10 +77,41 @@ func binaryFilePath(binaryDir string, binaryFilePrefix string) string {
 	return binaryPath
 }
 
+// DefaultComponentsDirPath returns the resources directory set by dapr init --resources-path, or else the
+// components directory of the installation.
 func DefaultComponentsDirPath() string {
+	if config, err := loadCLIConfig(DefaultCLIConfigFilePath()); err == nil && config.ResourcesPath != "" {
+		return config.ResourcesPath
+	}
 	return path_filepath.Join(defaultDaprDirPath(), defaultComponentsDirName)
 }
 
+// DefaultConfigFilePath returns the Dapr configuration file set by dapr init --config-file, or else the
+// configuration file of the installation.
 func DefaultConfigFilePath() string {
+	if config, err := loadCLIConfig(DefaultCLIConfigFilePath()); err == nil && config.ConfigFile != "" {
+		return config.ConfigFile
+	}
 	return path_filepath.Join(defaultDaprDirPath(), defaultConfigFileName)
 }
+
+// DefaultCLIConfigFilePath returns the path of the configuration file of the CLI, which holds the run profiles.
+// It is not to be confused with the Dapr configuration file returned by DefaultConfigFilePath.
+func DefaultCLIConfigFilePath() string {
+	return path_filepath.Join(defaultDaprDirPath(), defaultCLIConfigFileName)
+}
+
+// DefaultHistoryFilePath returns the path of the file that records the recent invocations of the CLI.
+func DefaultHistoryFilePath() string {
+	return path_filepath.Join(defaultDaprDirPath(), defaultHistoryFileName)
+}
+
+// DefaultTelemetryFilePath returns the path of the file that holds the telemetry settings of the CLI.
+func DefaultTelemetryFilePath() string {
+	return path_filepath.Join(defaultDaprDirPath(), defaultTelemetryFileName)
+}
+
+// DefaultVersionCacheFilePath returns the path of the file that caches the latest versions looked up on GitHub.
+func DefaultVersionCacheFilePath() string {
+	return path_filepath.Join(defaultDaprDirPath(), defaultVersionCacheName)
+}
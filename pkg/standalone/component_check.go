@@ -0,0 +1,365 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapr/cli/pkg/metadata"
+)
+
+const (
+	// DefaultComponentTestTopic is the topic `dapr components test` publishes to for pub/sub components.
+	DefaultComponentTestTopic = "dapr-cli-test"
+	// DefaultComponentTestTimeout is the time `dapr components test` waits for a temporary sidecar to load its components.
+	DefaultComponentTestTimeout = 30 * time.Second
+
+	componentTestRequestTimeout = 30 * time.Second
+	componentTestHealthInterval = 250 * time.Millisecond
+	componentTestKeyPrefix      = "dapr-cli-test-"
+	componentTestErrorLines     = 10
+
+	componentTestOK     = "OK"
+	componentTestFailed = "FAILED"
+)
+
+// ComponentTestOptions are the options of TestComponent.
+type ComponentTestOptions struct {
+	// AppID selects the running sidecar that tests the component. If it is empty, a temporary sidecar is started
+	// with the resources of ResourcesPath.
+	AppID         string
+	Socket        string
+	ResourcesPath string
+	// Topic is the topic to publish to for pub/sub components. It defaults to DefaultComponentTestTopic.
+	Topic string
+	// SecretKey is the secret to read for secret stores. All secrets are read if it is empty.
+	SecretKey string
+	// Timeout is the time to wait for a temporary sidecar to load its components.
+	Timeout time.Duration
+}
+
+// Latency is the duration of a step of a component test. It is printed in milliseconds.
+type Latency time.Duration
+
+// MarshalCSV prints the latency rounded to a tenth of a millisecond in tables.
+func (l Latency) MarshalCSV() (string, error) {
+	return time.Duration(l).Round(100 * time.Microsecond).String(), nil
+}
+
+// MarshalJSON returns the latency in milliseconds.
+func (l Latency) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatFloat(float64(l)/float64(time.Millisecond), 'f', 1, 64)), nil
+}
+
+// MarshalYAML returns the latency in milliseconds.
+func (l Latency) MarshalYAML() (interface{}, error) {
+	return float64(l) / float64(time.Millisecond), nil
+}
+
+// ComponentTestOutput is the result of a step of a component test.
+type ComponentTestOutput struct {
+	Step    string  `csv:"STEP"    json:"step"            yaml:"step"`
+	Result  string  `csv:"RESULT"  json:"result"          yaml:"result"`
+	Latency Latency `csv:"LATENCY" json:"latencyMs"       yaml:"latencyMs"`
+	Error   string  `csv:"ERROR"   json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// componentTestSidecar is the Dapr API of the sidecar that runs a component test.
+type componentTestSidecar struct {
+	httpPort int
+	appID    string
+	socket   string
+	endpoint func(path string) string
+	httpc    *http.Client
+}
+
+// TestComponent performs a round trip against the component name through a sidecar: a set, get and delete of a
+// key for state stores, a publish for pub/sub, and a read for secret stores. It returns the result and the latency
+// of each step, and an error if the component cannot be tested at all, for example because it failed to load.
+func (s *Standalone) TestComponent(name string, opts ComponentTestOptions) ([]ComponentTestOutput, error) {
+	var sidecar componentTestSidecar
+	if opts.AppID != "" {
+		list, err := s.process.List()
+		if err != nil {
+			return nil, err
+		}
+		instance, err := getSidecar(list, opts.AppID)
+		if err != nil {
+			return nil, err
+		}
+		_, httpc, err := s.sidecarEndpoint(instance.AppID, opts.Socket, "")
+		if err != nil {
+			return nil, err
+		}
+		sidecar = componentTestSidecar{
+			httpPort: instance.HTTPPort,
+			appID:    instance.AppID,
+			socket:   opts.Socket,
+			httpc:    httpc,
+			endpoint: func(path string) string {
+				if opts.Socket != "" {
+					return "http://unix/" + path
+				}
+				return fmt.Sprintf("http://localhost:%d/%s", instance.HTTPPort, path)
+			},
+		}
+	} else {
+		temp, err := startComponentTestSidecar(opts.ResourcesPath, opts.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		defer temp.stop()
+		sidecar = temp.sidecar
+	}
+	sidecar.httpc.Timeout = componentTestRequestTimeout
+
+	componentType, err := sidecar.componentType(name)
+	if err != nil {
+		return nil, err
+	}
+	return sidecar.test(name, componentType, opts)
+}
+
+// componentType returns the type of the component name loaded by the sidecar, such as state.redis.
+func (c componentTestSidecar) componentType(name string) (string, error) {
+	socket := c.socket
+	httpPort := c.httpPort
+	if socket != "" {
+		httpPort = 0
+	}
+	appMetadata, err := metadata.Get(httpPort, c.appID, socket)
+	if err != nil {
+		return "", fmt.Errorf("error getting the components of the sidecar: %w", err)
+	}
+	names := make([]string, 0, len(appMetadata.Components))
+	for _, component := range appMetadata.Components {
+		if component.Name == name {
+			return component.Type, nil
+		}
+		names = append(names, component.Name)
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("component %s not found, the sidecar has no components", name)
+	}
+	return "", fmt.Errorf("component %s not found, the components of the sidecar are: %s", name, strings.Join(names, ", "))
+}
+
+func (c componentTestSidecar) test(name, componentType string, opts ComponentTestOptions) ([]ComponentTestOutput, error) {
+	name = url.PathEscape(name)
+	switch {
+	case strings.HasPrefix(componentType, "state."):
+		key := componentTestKeyPrefix + strconv.FormatInt(time.Now().UnixNano(), 10)
+		value := strconv.Quote(key)
+		results := []ComponentTestOutput{
+			c.step("set", func() error {
+				body := fmt.Sprintf(`[{"key":%q,"value":%s}]`, key, value)
+				return c.do(http.MethodPost, "v1.0/state/"+name, body, nil)
+			}),
+		}
+		if results[0].Result != componentTestOK {
+			return results, nil
+		}
+		results = append(results, c.step("get", func() error {
+			var got json.RawMessage
+			if err := c.do(http.MethodGet, "v1.0/state/"+name+"/"+url.PathEscape(key), "", &got); err != nil {
+				return err
+			}
+			if string(got) != value {
+				return fmt.Errorf("got %s instead of the value that was set", got)
+			}
+			return nil
+		}))
+		return append(results, c.step("delete", func() error {
+			return c.do(http.MethodDelete, "v1.0/state/"+name+"/"+url.PathEscape(key), "", nil)
+		})), nil
+	case strings.HasPrefix(componentType, "pubsub."):
+		topic := opts.Topic
+		if topic == "" {
+			topic = DefaultComponentTestTopic
+		}
+		return []ComponentTestOutput{c.step("publish to "+topic, func() error {
+			return c.do(http.MethodPost, "v1.0/publish/"+name+"/"+url.PathEscape(topic), `{"test":"dapr-cli"}`, nil)
+		})}, nil
+	case strings.HasPrefix(componentType, "secretstores."):
+		if opts.SecretKey != "" {
+			return []ComponentTestOutput{c.step("get "+opts.SecretKey, func() error {
+				return c.do(http.MethodGet, "v1.0/secrets/"+name+"/"+url.PathEscape(opts.SecretKey), "", nil)
+			})}, nil
+		}
+		return []ComponentTestOutput{c.step("bulk get", func() error {
+			return c.do(http.MethodGet, "v1.0/secrets/"+name+"/bulk", "", nil)
+		})}, nil
+	}
+	return nil, fmt.Errorf("testing components of type %s is not supported, only state stores, pub/sub and secret stores can be tested", componentType)
+}
+
+// step runs a step of a component test and measures its latency.
+func (c componentTestSidecar) step(name string, run func() error) ComponentTestOutput {
+	start := time.Now()
+	err := run()
+	output := ComponentTestOutput{Step: name, Result: componentTestOK, Latency: Latency(time.Since(start))}
+	if err != nil {
+		output.Result = componentTestFailed
+		output.Error = err.Error()
+	}
+	return output
+}
+
+// do calls the Dapr API and decodes the JSON response into result if it is not nil.
+func (c componentTestSidecar) do(method, path, body string, result interface{}) error {
+	req, err := http.NewRequestWithContext(context.Background(), method, c.endpoint(path), strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	r, err := c.httpc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	b, err := readStateResponse(r)
+	if err != nil || result == nil {
+		return err
+	}
+	if len(b) == 0 {
+		return errors.New("empty response")
+	}
+	return json.Unmarshal(b, result)
+}
+
+// componentTestProcess is a temporary sidecar started by TestComponent.
+type componentTestProcess struct {
+	sidecar componentTestSidecar
+	output  *RunOutput
+	exited  chan struct{}
+}
+
+func (p *componentTestProcess) stop() {
+	_ = p.output.DaprCMD.Process.Kill()
+	<-p.exited
+}
+
+// startComponentTestSidecar starts a sidecar with the resources of resourcesPath and waits until it has loaded them.
+// If the sidecar exits, for example because a component failed to initialize, the errors it logged are returned.
+func startComponentTestSidecar(resourcesPath string, timeout time.Duration) (*componentTestProcess, error) {
+	if resourcesPath == "" {
+		resourcesPath = DefaultComponentsDirPath()
+	}
+	if timeout <= 0 {
+		timeout = DefaultComponentTestTimeout
+	}
+	config := &RunConfig{
+		AppID:              fmt.Sprintf("dapr-cli-components-test-%d", os.Getpid()),
+		ComponentsPath:     resourcesPath,
+		Protocol:           "http",
+		LogLevel:           "info",
+		MaxRequestBodySize: -1,
+		HTTPReadBufferSize: -1,
+	}
+	if _, err := os.Stat(DefaultConfigFilePath()); err == nil {
+		config.ConfigFile = DefaultConfigFilePath()
+	}
+	output, err := Run(config)
+	if err != nil {
+		return nil, fmt.Errorf("error starting a sidecar with the resources of %s: %w", resourcesPath, err)
+	}
+
+	var logs lockedBuffer
+	output.DaprCMD.Stdout = &logs
+	output.DaprCMD.Stderr = &logs
+	if err = output.DaprCMD.Start(); err != nil {
+		return nil, err
+	}
+	p := &componentTestProcess{
+		output: output,
+		exited: make(chan struct{}),
+		sidecar: componentTestSidecar{
+			httpPort: output.DaprHTTPPort,
+			appID:    output.AppID,
+			httpc:    &http.Client{Timeout: componentTestRequestTimeout},
+			endpoint: func(path string) string {
+				return fmt.Sprintf("http://localhost:%d/%s", output.DaprHTTPPort, path)
+			},
+		},
+	}
+	go func() {
+		_ = output.DaprCMD.Wait()
+		close(p.exited)
+	}()
+
+	deadline := time.After(timeout)
+	for {
+		if p.sidecar.do(http.MethodGet, "v1.0/healthz", "", nil) == nil {
+			return p, nil
+		}
+		select {
+		case <-p.exited:
+			return nil, fmt.Errorf("the sidecar exited while loading the components:\n%s", sidecarErrors(logs.String()))
+		case <-deadline:
+			p.stop()
+			return nil, fmt.Errorf("timed out waiting for the sidecar to load the components:\n%s", sidecarErrors(logs.String()))
+		case <-time.After(componentTestHealthInterval):
+		}
+	}
+}
+
+// sidecarErrors returns the error lines of the logs of a sidecar, or its last lines if it logged no errors.
+func sidecarErrors(logs string) string {
+	lines := strings.Split(strings.TrimSpace(logs), "\n")
+	errorLines := []string{}
+	for _, line := range lines {
+		if strings.Contains(line, "level=error") || strings.Contains(line, "level=fatal") ||
+			strings.Contains(line, `"level":"error"`) || strings.Contains(line, `"level":"fatal"`) {
+			errorLines = append(errorLines, line)
+		}
+	}
+	if len(errorLines) == 0 {
+		errorLines = lines
+	}
+	if len(errorLines) > componentTestErrorLines {
+		errorLines = errorLines[len(errorLines)-componentTestErrorLines:]
+	}
+	return strings.Join(errorLines, "\n")
+}
+
+// lockedBuffer is a buffer that can be written by a process while it is read.
+type lockedBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.String()
+}
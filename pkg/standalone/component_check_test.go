@@ -0,0 +1,148 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// componentTestAPI serves the metadata, state, publish and secrets APIs of a sidecar with a state store statestore,
+// a pub/sub pubsub that rejects events, a secret store secretstore and a binding.
+type componentTestAPI struct {
+	lock      sync.Mutex
+	state     map[string][]byte
+	published []string
+}
+
+func (a *componentTestAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	switch path := r.URL.Path; {
+	case path == "/v1.0/metadata":
+		w.Write([]byte(`{"id":"testapp","components":[
+			{"name":"statestore","type":"state.redis","version":"v1"},
+			{"name":"pubsub","type":"pubsub.kafka","version":"v1"},
+			{"name":"secretstore","type":"secretstores.local.file","version":"v1"},
+			{"name":"cron","type":"bindings.cron","version":"v1"}]}`))
+	case path == "/v1.0/state/statestore" && r.Method == http.MethodPost:
+		var items []struct {
+			Key   string          `json:"key"`
+			Value json.RawMessage `json:"value"`
+		}
+		json.NewDecoder(r.Body).Decode(&items)
+		for _, item := range items {
+			a.state[item.Key] = item.Value
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case strings.HasPrefix(path, "/v1.0/state/statestore/"):
+		key := strings.TrimPrefix(path, "/v1.0/state/statestore/")
+		if r.Method == http.MethodDelete {
+			delete(a.state, key)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Write(a.state[key])
+	case strings.HasPrefix(path, "/v1.0/publish/pubsub/"):
+		body, _ := io.ReadAll(r.Body)
+		a.published = append(a.published, strings.TrimPrefix(path, "/v1.0/publish/pubsub/")+" "+string(body))
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"errorCode":"ERR_PUBSUB_PUBLISH_MESSAGE","message":"broker unreachable"}`))
+	case path == "/v1.0/secrets/secretstore/bulk":
+		w.Write([]byte(`{"password":{"password":"secret"}}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestTestComponent(t *testing.T) {
+	api := &componentTestAPI{state: map[string][]byte{}}
+	ts, port := getTestServerFunc(api)
+	ts.Start()
+	defer ts.Close()
+
+	client := &Standalone{
+		process: &mockDaprProcess{
+			Lo: []ListOutput{{AppID: "testapp", HTTPPort: port}},
+		},
+	}
+	opts := ComponentTestOptions{AppID: "testapp"}
+
+	t.Run("state store", func(t *testing.T) {
+		results, err := client.TestComponent("statestore", opts)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		for i, step := range []string{"set", "get", "delete"} {
+			assert.Equal(t, step, results[i].Step)
+			assert.Equal(t, "OK", results[i].Result, results[i].Error)
+		}
+		assert.Empty(t, api.state)
+	})
+
+	t.Run("pub/sub", func(t *testing.T) {
+		results, err := client.TestComponent("pubsub", ComponentTestOptions{AppID: "testapp", Topic: "orders"})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "publish to orders", results[0].Step)
+		assert.Equal(t, "FAILED", results[0].Result)
+		assert.Contains(t, results[0].Error, "broker unreachable")
+		assert.Equal(t, []string{`orders {"test":"dapr-cli"}`}, api.published)
+	})
+
+	t.Run("secret store", func(t *testing.T) {
+		results, err := client.TestComponent("secretstore", opts)
+		require.NoError(t, err)
+		assert.Equal(t, "bulk get", results[0].Step)
+		assert.Equal(t, "OK", results[0].Result)
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := client.TestComponent("cron", opts)
+		assert.ErrorContains(t, err, "bindings.cron")
+	})
+
+	t.Run("unknown component", func(t *testing.T) {
+		_, err := client.TestComponent("unknown", opts)
+		assert.ErrorContains(t, err, "statestore, pubsub, secretstore, cron")
+	})
+}
+
+func TestSidecarErrors(t *testing.T) {
+	logs := `time="1" level=info msg="starting Dapr Runtime"
+time="2" level=error msg="failed to init component statestore: NOAUTH"
+time="3" level=fatal msg="process component statestore error"
+`
+	assert.Equal(t, `time="2" level=error msg="failed to init component statestore: NOAUTH"
+time="3" level=fatal msg="process component statestore error"`, sidecarErrors(logs))
+	assert.Equal(t, "starting", sidecarErrors("starting\n"))
+}
+
+func TestLatencyMarshal(t *testing.T) {
+	latency := Latency(12345 * time.Microsecond)
+	s, err := latency.MarshalCSV()
+	require.NoError(t, err)
+	assert.Equal(t, "12.3ms", s)
+	b, err := json.Marshal(latency)
+	require.NoError(t, err)
+	assert.Equal(t, "12.3", string(b))
+}
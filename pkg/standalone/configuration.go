@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+// ConfigurationItemOutput is an item of a configuration store.
+type ConfigurationItemOutput struct {
+	Key      string            `csv:"KEY"     json:"key"                yaml:"key"`
+	Value    string            `csv:"VALUE"   json:"value"              yaml:"value"`
+	Version  string            `csv:"VERSION" json:"version"            yaml:"version"`
+	Metadata map[string]string `csv:"-"       json:"metadata,omitempty" yaml:"metadata,omitempty"`
+}
+
+// GetConfiguration returns the items of keys in the configuration store storeName, read through the sidecar of appID.
+// All items are returned if keys is empty. appID can be empty if only one app is running.
+func (s *Standalone) GetConfiguration(appID, storeName string, keys []string, metadata map[string]string, socket string) ([]ConfigurationItemOutput, error) {
+	if storeName == "" {
+		return nil, errors.New("the configuration store is required")
+	}
+	conn, err := s.dialSidecarGRPC(appID, socket)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	resp, err := runtimev1pb.NewDaprClient(conn).GetConfigurationAlpha1(context.Background(), &runtimev1pb.GetConfigurationRequest{
+		StoreName: storeName,
+		Keys:      keys,
+		Metadata:  metadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting the configuration from store %s: %w", storeName, err)
+	}
+	return configurationItems(resp.GetItems()), nil
+}
+
+// SubscribeConfiguration subscribes to the changes of keys in the configuration store storeName through the sidecar
+// of appID, and calls onUpdate with the changed items until ctx is done. All keys are watched if keys is empty.
+func (s *Standalone) SubscribeConfiguration(ctx context.Context, appID, storeName string, keys []string, metadata map[string]string, socket string, onUpdate func([]ConfigurationItemOutput)) error {
+	if storeName == "" {
+		return errors.New("the configuration store is required")
+	}
+	conn, err := s.dialSidecarGRPC(appID, socket)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := runtimev1pb.NewDaprClient(conn)
+	stream, err := client.SubscribeConfigurationAlpha1(ctx, &runtimev1pb.SubscribeConfigurationRequest{
+		StoreName: storeName,
+		Keys:      keys,
+		Metadata:  metadata,
+	})
+	if err != nil {
+		return fmt.Errorf("error subscribing to the configuration of store %s: %w", storeName, err)
+	}
+
+	id := ""
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("error receiving the configuration of store %s: %w", storeName, err)
+		}
+		if id == "" {
+			id = resp.GetId()
+		}
+		if len(resp.GetItems()) > 0 {
+			onUpdate(configurationItems(resp.GetItems()))
+		}
+	}
+
+	if id != "" {
+		// The subscription context is done, so the unsubscribe request gets its own.
+		_, err = client.UnsubscribeConfigurationAlpha1(context.Background(), &runtimev1pb.UnsubscribeConfigurationRequest{
+			StoreName: storeName,
+			Id:        id,
+		})
+		if err != nil {
+			return fmt.Errorf("error unsubscribing from the configuration of store %s: %w", storeName, err)
+		}
+	}
+	return nil
+}
+
+func configurationItems(items []*commonv1pb.ConfigurationItem) []ConfigurationItemOutput {
+	out := make([]ConfigurationItemOutput, 0, len(items))
+	for _, item := range items {
+		out = append(out, ConfigurationItemOutput{
+			Key:      item.GetKey(),
+			Value:    item.GetValue(),
+			Version:  item.GetVersion(),
+			Metadata: item.GetMetadata(),
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
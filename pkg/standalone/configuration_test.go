@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetConfiguration(t *testing.T) {
+	s, l, port := getTestGRPCServer()
+	go s.Serve(l)
+	defer s.Stop()
+
+	client := &Standalone{
+		process: &mockDaprProcess{
+			Lo: []ListOutput{{AppID: "testapp", GRPCPort: port}},
+		},
+	}
+
+	t.Run("get keys", func(t *testing.T) {
+		items, err := client.GetConfiguration("", "configstore", []string{"b", "a"}, nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, []ConfigurationItemOutput{
+			{Key: "a", Value: "a-value", Version: "1"},
+			{Key: "b", Value: "b-value", Version: "1"},
+		}, items)
+	})
+
+	t.Run("store not found", func(t *testing.T) {
+		_, err := client.GetConfiguration("testapp", "missing", []string{"a"}, nil, "")
+		assert.ErrorContains(t, err, "configuration store missing not found")
+	})
+
+	t.Run("missing store", func(t *testing.T) {
+		_, err := client.GetConfiguration("testapp", "", nil, nil, "")
+		assert.EqualError(t, err, "the configuration store is required")
+	})
+
+	t.Run("appID not found", func(t *testing.T) {
+		_, err := client.GetConfiguration("invalid", "configstore", nil, nil, "")
+		assert.EqualError(t, err, "couldn't find a running Dapr instance")
+	})
+}
+
+func TestSubscribeConfiguration(t *testing.T) {
+	s, l, port := getTestGRPCServer()
+	go s.Serve(l)
+	defer s.Stop()
+
+	client := &Standalone{
+		process: &mockDaprProcess{
+			Lo: []ListOutput{{AppID: "testapp", GRPCPort: port}},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := [][]ConfigurationItemOutput{}
+	err := client.SubscribeConfiguration(ctx, "testapp", "configstore", []string{"a"}, nil, "", func(items []ConfigurationItemOutput) {
+		updates = append(updates, items)
+		cancel()
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]ConfigurationItemOutput{{{Key: "a", Value: "a-updated", Version: "2"}}}, updates)
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/utils"
+)
+
+const (
+	// DockerContainerRuntime is the name of the Docker container runtime.
+	DockerContainerRuntime = "docker"
+	// PodmanContainerRuntime is the name of the Podman container runtime.
+	PodmanContainerRuntime = "podman"
+)
+
+// ContainerRuntime runs the containers of a self-hosted Dapr installation.
+type ContainerRuntime interface {
+	// Name returns the name of the command line tool of the runtime.
+	Name() string
+	// CheckRunning returns an error if the runtime is not installed or not running.
+	CheckRunning() error
+	// Run runs the command line tool of the runtime with the given arguments and returns its output.
+	Run(args ...string) (string, error)
+	// PullImage pulls the given image.
+	PullImage(image string) error
+	// LoadImage loads an image archive read from in.
+	LoadImage(in io.Reader) error
+	// QualifyImage returns the name under which the runtime stores the given image.
+	QualifyImage(image string) string
+}
+
+// NewContainerRuntime returns the container runtime with the given name.
+func NewContainerRuntime(name string) (ContainerRuntime, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case DockerContainerRuntime, "":
+		return &dockerRuntime{}, nil
+	case PodmanContainerRuntime:
+		return &podmanRuntime{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported container runtime %q. Supported values are: %s, %s", name, DockerContainerRuntime, PodmanContainerRuntime)
+	}
+}
+
+type dockerRuntime struct{}
+
+func (d *dockerRuntime) Name() string {
+	return DockerContainerRuntime
+}
+
+func (d *dockerRuntime) CheckRunning() error {
+	if !utils.IsDockerInstalled() {
+		return errors.New("could not connect to Docker. Docker may not be installed or running")
+	}
+	return nil
+}
+
+func (d *dockerRuntime) Run(args ...string) (string, error) {
+	return runContainerRuntime(DockerContainerRuntime, args...)
+}
+
+// PullImage pulls the image with the Docker API to report the download progress.
+// If that fails, for example because the registry requires credentials only known to the Docker CLI, the CLI is used instead.
+func (d *dockerRuntime) PullImage(image string) error {
+	err := pullImageWithProgress(image)
+	if err == nil {
+		return nil
+	}
+	print.DebugStatusEvent(os.Stderr, "Could not pull %s with the Docker API, falling back to the Docker CLI: %s", image, err)
+	_, err = d.Run("pull", image)
+	return err
+}
+
+func (d *dockerRuntime) LoadImage(in io.Reader) error {
+	return runImageLoad(DockerContainerRuntime, in)
+}
+
+func (d *dockerRuntime) QualifyImage(image string) string {
+	return image
+}
+
+// podmanRuntime runs containers with Podman, either as root or rootless.
+type podmanRuntime struct{}
+
+func (p *podmanRuntime) Name() string {
+	return PodmanContainerRuntime
+}
+
+func (p *podmanRuntime) CheckRunning() error {
+	if _, err := exec.LookPath(PodmanContainerRuntime); err != nil {
+		return errors.New("could not find Podman. Podman may not be installed")
+	}
+	if _, err := p.Run("info"); err != nil {
+		return fmt.Errorf("could not connect to Podman. Podman may not be running: %w", err)
+	}
+	return nil
+}
+
+func (p *podmanRuntime) Run(args ...string) (string, error) {
+	return runContainerRuntime(PodmanContainerRuntime, args...)
+}
+
+func (p *podmanRuntime) PullImage(image string) error {
+	_, err := p.Run("pull", image)
+	return err
+}
+
+func (p *podmanRuntime) LoadImage(in io.Reader) error {
+	return runImageLoad(PodmanContainerRuntime, in)
+}
+
+// QualifyImage prefixes images without a registry with docker.io,
+// since Podman does not resolve short image names to Docker Hub unless configured to do so.
+func (p *podmanRuntime) QualifyImage(image string) string {
+	if i := strings.Index(image, "/"); i > 0 {
+		registry := image[:i]
+		if strings.ContainsAny(registry, ".:") || registry == "localhost" {
+			return image
+		}
+	}
+	return "docker.io/" + image
+}
+
+// runContainerRuntime runs the command line tool of a container runtime and returns its output.
+func runContainerRuntime(name string, args ...string) (string, error) {
+	print.DebugStatusEvent(os.Stderr, "Running command: %s %s", name, strings.Join(args, " "))
+	return utils.RunCmdAndWait(name, args...)
+}
+
+// pullImageWithProgress pulls the image with the Docker API, reporting the combined progress of all layers.
+func pullImageWithProgress(image string) error {
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	ctx := context.Background()
+	events, err := cli.ImagePull(ctx, image, types.ImagePullOptions{})
+	if err != nil {
+		return err
+	}
+	defer events.Close()
+
+	type layerProgress struct {
+		current int64
+		total   int64
+	}
+	layers := map[string]layerProgress{}
+	bar := print.NewProgressBar(os.Stdout, image, 0)
+	defer bar.Finish()
+
+	decoder := json.NewDecoder(events)
+	for {
+		var event struct {
+			ID             string `json:"id"`
+			Status         string `json:"status"`
+			Error          string `json:"error"`
+			ProgressDetail struct {
+				Current int64 `json:"current"`
+				Total   int64 `json:"total"`
+			} `json:"progressDetail"`
+		}
+		err = decoder.Decode(&event)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if event.Error != "" {
+			return errors.New(event.Error)
+		}
+
+		switch {
+		case event.Status == "Downloading" && event.ProgressDetail.Total > 0:
+			layers[event.ID] = layerProgress{current: event.ProgressDetail.Current, total: event.ProgressDetail.Total}
+		case event.Status == "Download complete":
+			layer := layers[event.ID]
+			layer.current = layer.total
+			layers[event.ID] = layer
+		default:
+			continue
+		}
+
+		var current, total int64
+		for _, layer := range layers {
+			current += layer.current
+			total += layer.total
+		}
+		bar.Set(current, total)
+	}
+}
+
+func runImageLoad(name string, in io.Reader) error {
+	subProcess := exec.Command(name, "load")
+
+	stdin, err := subProcess.StdinPipe()
+	if err != nil {
+		return err
+	}
+	defer stdin.Close()
+
+	subProcess.Stdout = os.Stdout
+	subProcess.Stderr = os.Stderr
+
+	if err = subProcess.Start(); err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(stdin, in); err != nil {
+		return err
+	}
+
+	stdin.Close()
+
+	if err = subProcess.Wait(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewContainerRuntime(t *testing.T) {
+	testCases := []struct {
+		name         string
+		expectedName string
+		expectErr    bool
+	}{
+		{name: "", expectedName: DockerContainerRuntime},
+		{name: "docker", expectedName: DockerContainerRuntime},
+		{name: "Podman", expectedName: PodmanContainerRuntime},
+		{name: "containerd", expectErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			containerRuntime, err := NewContainerRuntime(tc.name)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedName, containerRuntime.Name())
+		})
+	}
+}
+
+func TestQualifyImage(t *testing.T) {
+	testCases := []struct {
+		image          string
+		expectedDocker string
+		expectedPodman string
+	}{
+		{image: "redis:6", expectedDocker: "redis:6", expectedPodman: "docker.io/redis:6"},
+		{image: "daprio/dapr:1.8.0", expectedDocker: "daprio/dapr:1.8.0", expectedPodman: "docker.io/daprio/dapr:1.8.0"},
+		{image: "ghcr.io/dapr/dapr:1.8.0", expectedDocker: "ghcr.io/dapr/dapr:1.8.0", expectedPodman: "ghcr.io/dapr/dapr:1.8.0"},
+		{image: "localhost:5000/dapr/dapr", expectedDocker: "localhost:5000/dapr/dapr", expectedPodman: "localhost:5000/dapr/dapr"},
+		{image: "localhost/dapr", expectedDocker: "localhost/dapr", expectedPodman: "localhost/dapr"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.image, func(t *testing.T) {
+			assert.Equal(t, tc.expectedDocker, (&dockerRuntime{}).QualifyImage(tc.image))
+			assert.Equal(t, tc.expectedPodman, (&podmanRuntime{}).QualifyImage(tc.image))
+		})
+	}
+}
+
+// fakeContainerRuntime records the commands and pulls, and stores the images in images.
+type fakeContainerRuntime struct {
+	images   map[string]bool
+	commands []string
+	pulls    []string
+}
+
+func (f *fakeContainerRuntime) Name() string        { return "fake" }
+func (f *fakeContainerRuntime) CheckRunning() error { return nil }
+
+func (f *fakeContainerRuntime) Run(args ...string) (string, error) {
+	f.commands = append(f.commands, strings.Join(args, " "))
+	if len(args) == 3 && args[0] == "image" && args[1] == "inspect" && !f.images[args[2]] {
+		return "", errors.New("no such image")
+	}
+	return "", nil
+}
+
+func (f *fakeContainerRuntime) PullImage(image string) error {
+	f.pulls = append(f.pulls, image)
+	return errors.New("network is unreachable")
+}
+
+func (f *fakeContainerRuntime) LoadImage(in io.Reader) error     { return nil }
+func (f *fakeContainerRuntime) QualifyImage(image string) string { return image }
+
+func TestPullImageIfMissing(t *testing.T) {
+	containerRuntime := &fakeContainerRuntime{images: map[string]bool{"daprio/dapr:1.9.0": true}}
+
+	assert.True(t, pullImageIfMissing(containerRuntime, "daprio/dapr:1.9.0"))
+	assert.Empty(t, containerRuntime.pulls, "a local image is not pulled")
+
+	assert.False(t, pullImageIfMissing(containerRuntime, "redis:6"))
+	assert.Equal(t, []string{"redis:6"}, containerRuntime.pulls)
+	assert.Equal(t, []string{"image inspect daprio/dapr:1.9.0", "image inspect redis:6"}, containerRuntime.commands)
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+	"io"
+	path_filepath "path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	composePlacementService  = "placement"
+	composeComponentsPath    = "/components"
+	composeConfigFilePath    = "/config/config.yaml"
+	composePlacementPort     = 50005
+	composeSidecarNameSuffix = "-dapr"
+)
+
+// composeFile is a Docker Compose file.
+type composeFile struct {
+	Services map[string]composeService `yaml:"services"`
+}
+
+// composeService is a service of a Docker Compose file.
+type composeService struct {
+	Image       string            `yaml:"image,omitempty"`
+	Build       string            `yaml:"build,omitempty"`
+	Command     []string          `yaml:"command,omitempty"`
+	Ports       []string          `yaml:"ports,omitempty"`
+	Environment map[string]string `yaml:"environment,omitempty"`
+	Volumes     []string          `yaml:"volumes,omitempty"`
+	DependsOn   []string          `yaml:"depends_on,omitempty"`
+	NetworkMode string            `yaml:"network_mode,omitempty"`
+}
+
+// GenerateCompose writes a Docker Compose file that runs the apps of a run file parsed by ParseRunFile, with the
+// sidecars of runtimeVersion and a placement service. Each app is built from the Dockerfile of its directory, and
+// its sidecar shares the network of the app, so they reach each other on localhost as with `dapr run`.
+// Paths are written relative to baseDir, the directory the Compose file is saved in.
+func GenerateCompose(apps []RunConfig, runtimeVersion, baseDir string, out io.Writer) error {
+	image := getPlacementImageWithTag(daprDockerImageName, runtimeVersion)
+	compose := composeFile{Services: map[string]composeService{
+		composePlacementService: {
+			Image:   image,
+			Command: []string{"./placement", "--port", strconv.Itoa(composePlacementPort)},
+		},
+	}}
+
+	for _, app := range apps {
+		if _, ok := compose.Services[app.AppID]; ok {
+			return fmt.Errorf("app ID %q is used by another service of the Compose file", app.AppID)
+		}
+		ports := []string{}
+		for _, port := range []int{app.AppPort, app.HTTPPort, app.GRPCPort} {
+			if port > 0 {
+				ports = append(ports, fmt.Sprintf("%d:%d", port, port))
+			}
+		}
+		compose.Services[app.AppID] = composeService{
+			Build:       composePath(baseDir, app.AppDirPath),
+			Ports:       ports,
+			Environment: app.Env,
+		}
+
+		sidecar := composeService{
+			Image:       image,
+			Command:     append([]string{"./daprd"}, composeSidecarArgs(app)...),
+			Environment: app.Env,
+			DependsOn:   []string{app.AppID, composePlacementService},
+			NetworkMode: "service:" + app.AppID,
+		}
+		if app.ComponentsPath != "" {
+			sidecar.Volumes = append(sidecar.Volumes, composePath(baseDir, app.ComponentsPath)+":"+composeComponentsPath)
+		}
+		if app.ConfigFile != "" {
+			sidecar.Volumes = append(sidecar.Volumes, composePath(baseDir, app.ConfigFile)+":"+composeConfigFilePath)
+		}
+		compose.Services[app.AppID+composeSidecarNameSuffix] = sidecar
+	}
+
+	b, err := yaml.Marshal(compose)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(b)
+	return err
+}
+
+// composeSidecarArgs returns the arguments of daprd for an app of a Compose file.
+func composeSidecarArgs(app RunConfig) []string {
+	args := []string{
+		"--app-id", app.AppID,
+		"--placement-host-address", fmt.Sprintf("%s:%d", composePlacementService, composePlacementPort),
+	}
+	flags := map[string]string{}
+	if app.AppPort > 0 {
+		flags["app-port"] = strconv.Itoa(app.AppPort)
+	}
+	if app.HTTPPort > 0 {
+		flags["dapr-http-port"] = strconv.Itoa(app.HTTPPort)
+	}
+	if app.GRPCPort > 0 {
+		flags["dapr-grpc-port"] = strconv.Itoa(app.GRPCPort)
+	}
+	if app.MetricsPort > 0 {
+		flags["metrics-port"] = strconv.Itoa(app.MetricsPort)
+	}
+	if app.Protocol != "" {
+		flags["app-protocol"] = app.Protocol
+	}
+	if app.LogLevel != "" {
+		flags["log-level"] = app.LogLevel
+	}
+	if app.MaxConcurrency > 0 {
+		flags["app-max-concurrency"] = strconv.Itoa(app.MaxConcurrency)
+	}
+	if app.MaxRequestBodySize > 0 {
+		flags["dapr-http-max-request-size"] = strconv.Itoa(app.MaxRequestBodySize)
+	}
+	if app.HTTPReadBufferSize > 0 {
+		flags["dapr-http-read-buffer-size"] = strconv.Itoa(app.HTTPReadBufferSize)
+	}
+	if app.ComponentsPath != "" {
+		flags["components-path"] = composeComponentsPath
+	}
+	if app.ConfigFile != "" {
+		flags["config"] = composeConfigFilePath
+	}
+	if app.EnableProfiling {
+		flags["enable-profiling"] = "true"
+	}
+	if app.AppSSL {
+		flags["app-ssl"] = "true"
+	}
+	if app.EnableAPILogging {
+		flags["enable-api-logging"] = "true"
+	}
+
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, "--"+name, flags[name])
+	}
+	return args
+}
+
+// composePath returns path relative to baseDir, in the ./dir form of Compose files. Paths outside baseDir stay absolute.
+func composePath(baseDir, path string) string {
+	rel, err := path_filepath.Rel(baseDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(path_filepath.Separator)) {
+		return path_filepath.ToSlash(path)
+	}
+	if rel == "." {
+		return rel
+	}
+	return "./" + path_filepath.ToSlash(rel)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"bytes"
+	path_filepath "path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestGenerateCompose(t *testing.T) {
+	baseDir := t.TempDir()
+	apps := []RunConfig{
+		{
+			AppID:          "orders",
+			AppPort:        3000,
+			HTTPPort:       3500,
+			AppDirPath:     path_filepath.Join(baseDir, "orders"),
+			ComponentsPath: path_filepath.Join(baseDir, "resources"),
+			LogLevel:       "debug",
+			Env:            map[string]string{"DEBUG": "true"},
+		},
+		{AppID: "checkout", AppDirPath: baseDir},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, GenerateCompose(apps, "1.13.0", baseDir, &out))
+	var compose composeFile
+	require.NoError(t, yaml.Unmarshal(out.Bytes(), &compose))
+
+	assert.Len(t, compose.Services, 5)
+	assert.Equal(t, composeService{
+		Build:       "./orders",
+		Ports:       []string{"3000:3000", "3500:3500"},
+		Environment: map[string]string{"DEBUG": "true"},
+	}, compose.Services["orders"])
+	assert.Equal(t, composeService{
+		Image: "daprio/dapr:1.13.0",
+		Command: []string{
+			"./daprd", "--app-id", "orders", "--placement-host-address", "placement:50005",
+			"--app-port", "3000", "--components-path", "/components", "--dapr-http-port", "3500", "--log-level", "debug",
+		},
+		Environment: map[string]string{"DEBUG": "true"},
+		Volumes:     []string{"./resources:/components"},
+		DependsOn:   []string{"orders", "placement"},
+		NetworkMode: "service:orders",
+	}, compose.Services["orders-dapr"])
+	assert.Equal(t, ".", compose.Services["checkout"].Build)
+	assert.Equal(t, []string{"./placement", "--port", "50005"}, compose.Services["placement"].Command)
+
+	t.Run("app ID of the placement service", func(t *testing.T) {
+		err := GenerateCompose([]RunConfig{{AppID: "placement"}}, "latest", baseDir, &out)
+		assert.Error(t, err)
+	})
+}
+
+func TestComposePath(t *testing.T) {
+	baseDir := path_filepath.Join(string(path_filepath.Separator), "work", "apps")
+	assert.Equal(t, "./orders/resources", composePath(baseDir, path_filepath.Join(baseDir, "orders", "resources")))
+	assert.Equal(t, ".", composePath(baseDir, baseDir))
+	assert.Equal(t, "/work/resources", composePath(baseDir, path_filepath.Join(string(path_filepath.Separator), "work", "resources")))
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// cryptoAPIVersion is the version of the Dapr API that provides cryptography.
+const cryptoAPIVersion = "1.0-alpha1"
+
+// EncryptOptions are the options of encrypting data with a cryptography component.
+type EncryptOptions struct {
+	// KeyName is the name of the key of the component to encrypt with.
+	KeyName string
+	// KeyWrapAlgorithm is the algorithm that wraps the data encryption key, such as RSA-OAEP-256 or AES.
+	KeyWrapAlgorithm string
+	// DataEncryptionCipher is the cipher of the data, aes-gcm or chacha20-poly1305. The sidecar picks it if empty.
+	DataEncryptionCipher string
+	// DecryptionKeyName is the name of the key to decrypt with, if it differs from KeyName.
+	DecryptionKeyName string
+	// OmitDecryptionKeyName leaves the name of the key out of the encrypted data.
+	OmitDecryptionKeyName bool
+}
+
+// Encrypt encrypts data with the cryptography component through the sidecar of appID, and returns the encrypted data.
+func (s *Standalone) Encrypt(appID, component string, data []byte, opts EncryptOptions, socket string) ([]byte, error) {
+	if component == "" || opts.KeyName == "" || opts.KeyWrapAlgorithm == "" {
+		return nil, errors.New("the cryptography component, the key name and the key wrap algorithm are required")
+	}
+	headers := http.Header{}
+	headers.Set("dapr-key-name", opts.KeyName)
+	headers.Set("dapr-key-wrap-algorithm", opts.KeyWrapAlgorithm)
+	if opts.DataEncryptionCipher != "" {
+		headers.Set("dapr-data-encryption-cipher", opts.DataEncryptionCipher)
+	}
+	if opts.DecryptionKeyName != "" {
+		headers.Set("dapr-decryption-key-name", opts.DecryptionKeyName)
+	}
+	if opts.OmitDecryptionKeyName {
+		headers.Set("dapr-omit-decryption-key-name", "true")
+	}
+	return s.putCrypto(appID, "encrypt", component, data, headers, socket)
+}
+
+// Decrypt decrypts data with the cryptography component through the sidecar of appID, and returns the decrypted
+// data. keyName is required if the name of the key was omitted from the encrypted data.
+func (s *Standalone) Decrypt(appID, component string, data []byte, keyName, socket string) ([]byte, error) {
+	if component == "" {
+		return nil, errors.New("the cryptography component is required")
+	}
+	headers := http.Header{}
+	if keyName != "" {
+		headers.Set("dapr-key-name", keyName)
+	}
+	return s.putCrypto(appID, "decrypt", component, data, headers, socket)
+}
+
+// putCrypto sends data to the cryptography API operation of component and returns the body of the response.
+func (s *Standalone) putCrypto(appID, operation, component string, data []byte, headers http.Header, socket string) ([]byte, error) {
+	endpoint, httpc, err := s.sidecarEndpoint(appID, socket, fmt.Sprintf("v%s/crypto/%s/%s", cryptoAPIVersion, url.PathEscape(component), operation))
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPut, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = headers
+	req.Header.Set("Content-Type", "application/octet-stream")
+	r, err := httpc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+	if r.StatusCode < 200 || r.StatusCode >= 300 {
+		// Errors are JSON, like those of the state API.
+		_, err = readStateResponse(r)
+		return nil, err
+	}
+	return io.ReadAll(r.Body)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrypto(t *testing.T) {
+	// The test cryptography component reverses the data and prefixes it with the name of the key.
+	ts, port := getTestServerFunc(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/v1.0-alpha1/crypto/localstorage/encrypt":
+			if r.Method != http.MethodPut || r.Header.Get("dapr-key-wrap-algorithm") != "RSA" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write(append([]byte(r.Header.Get("dapr-key-name")+":"), reverse(body)...))
+		case "/v1.0-alpha1/crypto/localstorage/decrypt":
+			keyName, data, _ := bytes.Cut(body, []byte(":"))
+			if h := r.Header.Get("dapr-key-name"); h != "" && h != string(keyName) {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"errorCode":"ERR_CRYPTO","message":"failed to unwrap key"}`))
+				return
+			}
+			w.Write(reverse(data))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	ts.Start()
+	defer ts.Close()
+
+	client := &Standalone{
+		process: &mockDaprProcess{
+			Lo: []ListOutput{{AppID: "testapp", HTTPPort: port}},
+		},
+	}
+
+	encrypted, err := client.Encrypt("", "localstorage", []byte("secret"), EncryptOptions{KeyName: "mykey", KeyWrapAlgorithm: "RSA"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "mykey:terces", string(encrypted))
+
+	decrypted, err := client.Decrypt("", "localstorage", encrypted, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(decrypted))
+
+	_, err = client.Decrypt("", "localstorage", encrypted, "otherkey", "")
+	assert.ErrorContains(t, err, "failed to unwrap key")
+
+	_, err = client.Encrypt("", "localstorage", []byte("secret"), EncryptOptions{KeyName: "mykey"}, "")
+	assert.Error(t, err, "the key wrap algorithm is required")
+}
+
+func reverse(b []byte) []byte {
+	reversed := make([]byte, len(b))
+	for i, c := range b {
+		reversed[len(b)-1-i] = c
+	}
+	return reversed
+}
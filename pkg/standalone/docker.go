@@ -20,47 +20,16 @@ import (
 	"os/exec"
 	path_filepath "path/filepath"
 	"strings"
-
-	"github.com/dapr/cli/utils"
 )
 
-func runDockerLoad(in io.Reader) error {
-	subProcess := exec.Command("docker", "load")
-
-	stdin, err := subProcess.StdinPipe()
-	if err != nil {
-		return err
-	}
-	defer stdin.Close()
-
-	subProcess.Stdout = os.Stdout
-	subProcess.Stderr = os.Stderr
-
-	if err = subProcess.Start(); err != nil {
-		return err
-	}
-
-	if _, err = io.Copy(stdin, in); err != nil {
-		return err
-	}
-
-	stdin.Close()
-
-	if err = subProcess.Wait(); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func loadDocker(dir string, dockerImageFileName string) error {
+func loadDocker(containerRuntime ContainerRuntime, dir string, dockerImageFileName string) error {
 	var imageFile io.Reader
 	var err error
 	imageFile, err = os.Open(path_filepath.Join(dir, dockerImageFileName))
 	if err != nil {
 		return fmt.Errorf("fail to read docker image file %s: %w", dockerImageFileName, err)
 	}
-	err = runDockerLoad(imageFile)
+	err = containerRuntime.LoadImage(imageFile)
 	if err != nil {
 		return fmt.Errorf("fail to load docker image from file %s: %w", dockerImageFileName, err)
 	}
@@ -69,7 +38,7 @@ func loadDocker(dir string, dockerImageFileName string) error {
 }
 
 // check if the container either exists and stopped or is running.
-func confirmContainerIsRunningOrExists(containerName string, isRunning bool) (bool, error) {
+func confirmContainerIsRunningOrExists(containerRuntime ContainerRuntime, containerName string, isRunning bool) (bool, error) {
 	// e.g. docker ps --filter name=dapr_redis --filter status=running --format {{.Names}}.
 
 	args := []string{"ps", "--all", "--filter", "name=" + containerName}
@@ -79,7 +48,7 @@ func confirmContainerIsRunningOrExists(containerName string, isRunning bool) (bo
 	}
 
 	args = append(args, "--format", "{{.Names}}")
-	response, err := utils.RunCmdAndWait("docker", args...)
+	response, err := containerRuntime.Run(args...)
 	response = strings.TrimSuffix(response, "\n")
 
 	// If 'docker ps' failed due to some reason.
@@ -107,7 +76,7 @@ func isContainerRunError(err error) bool {
 	return false
 }
 
-func parseDockerError(component string, err error) error {
+func parseDockerError(containerRuntime ContainerRuntime, component string, err error) error {
 	//nolint
 	if exitError, ok := err.(*exec.ExitError); ok {
 		exitCode := exitError.ExitCode()
@@ -115,17 +84,36 @@ func parseDockerError(component string, err error) error {
 			return fmt.Errorf("failed to launch %s. Is it already running?", component)
 		}
 		if exitCode == 127 {
-			return fmt.Errorf("failed to launch %s. Make sure Docker is installed and running", component)
+			return fmt.Errorf("failed to launch %s. Make sure %s is installed and running", component, containerRuntime.Name())
 		}
 	}
 	return err
 }
 
-func tryPullImage(imageName string) bool {
-	args := []string{
-		"pull",
-		imageName,
-	}
-	_, err := utils.RunCmdAndWait("docker", args...)
+func tryPullImage(containerRuntime ContainerRuntime, imageName string) bool {
+	err := containerRuntime.PullImage(imageName)
 	return err == nil
 }
+
+// pullImageIfMissing pulls the image unless it is already stored by the container runtime, so that re-running
+// dapr init offline or in an air-gapped network does not wait for a pull that fails. It returns true if the image
+// is available.
+func pullImageIfMissing(containerRuntime ContainerRuntime, imageName string) bool {
+	if _, err := containerRuntime.Run("image", "inspect", imageName); err == nil {
+		return true
+	}
+	return tryPullImage(containerRuntime, imageName)
+}
+
+// saveDockerImage pulls the image and saves it as a tar archive to filePath.
+func saveDockerImage(image, filePath string) error {
+	docker := &dockerRuntime{}
+	if !tryPullImage(docker, image) {
+		return fmt.Errorf("fail to pull docker image %s", image)
+	}
+	_, err := docker.Run("save", "-o", filePath, image)
+	if err != nil {
+		return fmt.Errorf("fail to save docker image %s: %w", image, err)
+	}
+	return nil
+}
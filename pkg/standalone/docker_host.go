@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// dockerHostEnvVar is the environment variable of the Docker CLI and API that selects the Docker daemon.
+const dockerHostEnvVar = "DOCKER_HOST"
+
+// dockerHostSchemes are the schemes of the addresses of a Docker daemon.
+var dockerHostSchemes = []string{"unix", "npipe", "tcp", "ssh", "http", "https"}
+
+// SetDockerHost sets the Docker daemon that runs the containers of a self-hosted installation, such as
+// tcp://build-agent:2376 or ssh://user@host. It is passed to the Docker CLI and API as DOCKER_HOST.
+func SetDockerHost(host string) error {
+	u, err := url.Parse(host)
+	if err != nil {
+		return fmt.Errorf("invalid Docker host %q: %w", host, err)
+	}
+	valid := false
+	for _, scheme := range dockerHostSchemes {
+		valid = valid || u.Scheme == scheme
+	}
+	if !valid {
+		return fmt.Errorf("invalid Docker host %q. Supported schemes are: %s", host, strings.Join(dockerHostSchemes, ", "))
+	}
+	return os.Setenv(dockerHostEnvVar, host)
+}
+
+// DockerHostName returns the host name of the Docker daemon given by DOCKER_HOST if it runs on another machine,
+// or an empty string if it is local.
+func DockerHostName() string {
+	u, err := url.Parse(os.Getenv(dockerHostEnvVar))
+	if err != nil {
+		return ""
+	}
+	switch u.Scheme {
+	case "tcp", "ssh", "http", "https":
+	default:
+		return ""
+	}
+	switch host := u.Hostname(); host {
+	case "", daprDefaultHost, "127.0.0.1", "::1":
+		return ""
+	default:
+		return host
+	}
+}
+
+// ServiceHost returns the host that the placement, Redis and Zipkin containers started by `dapr init` are reached
+// at: the host of a remote Docker daemon, or localhost.
+func ServiceHost() string {
+	if host := DockerHostName(); host != "" {
+		return host
+	}
+	return daprDefaultHost
+}
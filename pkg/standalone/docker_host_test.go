@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDockerHostName(t *testing.T) {
+	testCases := []struct {
+		dockerHost string
+		expected   string
+	}{
+		{"", ""},
+		{"unix:///var/run/docker.sock", ""},
+		{"npipe:////./pipe/docker_engine", ""},
+		{"tcp://127.0.0.1:2375", ""},
+		{"tcp://localhost:2375", ""},
+		{"tcp://docker:2375", "docker"},
+		{"tcp://10.0.0.5:2376", "10.0.0.5"},
+		{"ssh://ci@build-agent", "build-agent"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.dockerHost, func(t *testing.T) {
+			t.Setenv(dockerHostEnvVar, tc.dockerHost)
+			assert.Equal(t, tc.expected, DockerHostName())
+			if tc.expected == "" {
+				assert.Equal(t, "localhost", ServiceHost())
+				assert.Equal(t, "localhost", initContainerHost(DaprRedisContainerName, ""))
+			} else {
+				assert.Equal(t, tc.expected, ServiceHost())
+				assert.Equal(t, tc.expected, initContainerHost(DaprRedisContainerName, ""))
+			}
+			assert.Equal(t, DaprRedisContainerName, initContainerHost(DaprRedisContainerName, "dapr-network"))
+		})
+	}
+}
+
+func TestSetDockerHost(t *testing.T) {
+	t.Setenv(dockerHostEnvVar, "")
+
+	assert.NoError(t, SetDockerHost("ssh://ci@build-agent"))
+	assert.Equal(t, "ssh://ci@build-agent", os.Getenv(dockerHostEnvVar))
+
+	assert.Error(t, SetDockerHost("build-agent:2375"))
+	assert.Error(t, SetDockerHost("ftp://build-agent"))
+	assert.Equal(t, "ssh://ci@build-agent", os.Getenv(dockerHostEnvVar))
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/dapr/cli/utils"
+)
+
+const (
+	// DefaultDaprHTTPPort is the default HTTP port of the Dapr sidecar.
+	DefaultDaprHTTPPort = 3500
+	// DefaultDaprGRPCPort is the default gRPC port of the Dapr sidecar.
+	DefaultDaprGRPCPort = 50001
+)
+
+// PlacementPort returns the host port of the placement service started by `dapr init`.
+func PlacementPort() int {
+	if runtime.GOOS == daprWindowsOS {
+		return 6050
+	}
+	return 50005
+}
+
+// CheckContainerRuntime checks whether Docker or Podman is available.
+func CheckContainerRuntime() (string, error) {
+	if utils.IsDockerInstalled() {
+		return "Docker is running", nil
+	}
+	if _, err := exec.LookPath("podman"); err == nil {
+		if _, err = utils.RunCmdAndWait("podman", "info"); err == nil {
+			return "Podman is running", nil
+		}
+		return "", errors.New("podman is installed but not running")
+	}
+	return "", errors.New("neither Docker nor Podman is installed or running")
+}
+
+// CheckPortAvailable checks whether nothing is listening on the given port.
+func CheckPortAvailable(port int) (string, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%v", port))
+	if err != nil {
+		return "", fmt.Errorf("port %v is already in use", port)
+	}
+	listener.Close()
+	return fmt.Sprintf("port %v is available", port), nil
+}
+
+// CheckPlacementPort checks whether the placement port is used by the placement service only: the placement
+// container, or the placement binary of a slim installation run as a process or as a service.
+func CheckPlacementPort(containerRuntimeName, dockerNetwork string) (string, error) {
+	containerRuntime, err := NewContainerRuntime(containerRuntimeName)
+	if err != nil {
+		return "", err
+	}
+	running, _ := confirmContainerIsRunningOrExists(containerRuntime, utils.CreateContainerName(DaprPlacementContainerName, dockerNetwork), true)
+	if running {
+		return fmt.Sprintf("port %v is used by the placement service", PlacementPort()), nil
+	}
+	msg, err := CheckPortAvailable(PlacementPort())
+	if err != nil && slimPlacementInstalled() {
+		return fmt.Sprintf("port %v is used by the placement service of the slim installation", PlacementPort()), nil
+	}
+	return msg, err
+}
+
+// slimPlacementInstalled returns true if the placement binary of a slim installation is installed.
+func slimPlacementInstalled() bool {
+	_, err := os.Stat(binaryFilePath(defaultDaprBinPath(), placementServiceFilePrefix))
+	return err == nil
+}
+
+// CheckBinary checks whether the binary with the given name is installed in the dapr bin directory and returns its version.
+func CheckBinary(binaryFilePrefix string) (string, error) {
+	binaryPath := binaryFilePath(defaultDaprBinPath(), binaryFilePrefix)
+	if _, err := os.Stat(binaryPath); err != nil {
+		return "", fmt.Errorf("%s binary not found at %s", binaryFilePrefix, binaryPath)
+	}
+
+	out, err := exec.Command(binaryPath, "--version").Output()
+	if err != nil {
+		// The placement binary does not support --version.
+		return fmt.Sprintf("%s is installed at %s", binaryFilePrefix, binaryPath), nil //nolint:nilerr
+	}
+	return fmt.Sprintf("%s version %s is installed", binaryFilePrefix, strings.TrimSpace(string(out))), nil
+}
+
+// CheckContainer checks whether the container with the given name is running in the given container runtime.
+func CheckContainer(containerRuntimeName, containerName, dockerNetwork string) (string, error) {
+	containerRuntime, err := NewContainerRuntime(containerRuntimeName)
+	if err != nil {
+		return "", err
+	}
+	name := utils.CreateContainerName(containerName, dockerNetwork)
+	running, err := confirmContainerIsRunningOrExists(containerRuntime, name, true)
+	if !running {
+		return "", err
+	}
+	return fmt.Sprintf("container %s is running", name), nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPortAvailable(t *testing.T) {
+	l, err := net.Listen("tcp", ":0")
+	assert.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+
+	_, err = CheckPortAvailable(port)
+	assert.EqualError(t, err, fmt.Sprintf("port %v is already in use", port))
+
+	l.Close()
+	msg, err := CheckPortAvailable(port)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("port %v is available", port), msg)
+}
+
+func TestCheckBinaryNotInstalled(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", t.TempDir())
+
+	_, err := CheckBinary("daprd")
+	assert.ErrorContains(t, err, "daprd binary not found")
+}
+
+func TestSlimPlacementInstalled(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", t.TempDir())
+	assert.False(t, slimPlacementInstalled())
+
+	binDir := defaultDaprBinPath()
+	assert.NoError(t, os.MkdirAll(binDir, 0o755))
+	assert.NoError(t, os.WriteFile(binaryFilePath(binDir, placementServiceFilePrefix), nil, 0o755))
+	assert.True(t, slimPlacementInstalled())
+}
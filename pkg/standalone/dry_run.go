@@ -0,0 +1,192 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+	"os"
+	path_filepath "path/filepath"
+	"strings"
+
+	"github.com/dapr/cli/pkg/print"
+	cli_ver "github.com/dapr/cli/pkg/version"
+	"github.com/dapr/cli/utils"
+)
+
+// printInitActions prints the actions of an installation, in the order they are taken, without taking them.
+func printInitActions(info initInfo) error {
+	containers, err := containerActions(info, func(name string) (bool, error) {
+		return confirmContainerIsRunningOrExists(info.containerRuntime, name, false)
+	})
+	if err != nil {
+		return err
+	}
+	for _, action := range append(installActions(info), containers...) {
+		print.DryRunStatusEvent(os.Stdout, "Would %s", action)
+	}
+	return nil
+}
+
+// binaryRelease is a binary installed from the release archive of a Dapr repo on GitHub.
+type binaryRelease struct {
+	version, prefix, repo string
+}
+
+// installActions returns the binaries that an installation downloads or extracts, and the default files it writes,
+// as the actions printed by --dry-run, such as "download <url> to <dir>". Files that already exist are kept by the
+// installation and not listed.
+func installActions(info initInfo) []string {
+	actions := []string{}
+	binDir := defaultDaprBinPath()
+	binaries := []binaryRelease{{info.runtimeVersion, daprRuntimeFilePrefix, cli_ver.DaprGitHubRepo}}
+	if info.slimMode {
+		binaries = append(binaries, binaryRelease{info.runtimeVersion, placementServiceFilePrefix, cli_ver.DaprGitHubRepo})
+	}
+	if info.dashboardVersion != "" {
+		binaries = append(binaries, binaryRelease{info.dashboardVersion, dashboardFilePrefix, cli_ver.DashboardGitHubRepo})
+	}
+	for _, b := range binaries {
+		if isAirGapInit {
+			actions = append(actions, fmt.Sprintf("extract %s to %s", path_filepath.Join(info.fromDir, *info.bundleDet.BinarySubDir, binaryName(b.prefix)), binDir))
+		} else {
+			actions = append(actions, fmt.Sprintf("download %s to %s", cli_ver.ReleaseURL(b.repo, b.version, binaryName(b.prefix)), binDir))
+		}
+	}
+
+	if !info.slimMode && !isAirGapInit {
+		for _, file := range initComponentFiles(info.components, info.dockerNetwork) {
+			if filePath := path_filepath.Join(info.resourcesDir(), file.name); !fileExists(filePath) {
+				actions = append(actions, "write component file "+filePath)
+			}
+		}
+	}
+	if configFile := info.configFilePath(); !fileExists(configFile) {
+		actions = append(actions, "write configuration file "+configFile)
+	}
+	if info.resourcesPath != "" || info.configFile != "" {
+		actions = append(actions, "record the resources directory and configuration file in "+DefaultCLIConfigFilePath())
+	}
+	return actions
+}
+
+// containerActions returns the containers that an installation runs, as the actions printed by --dry-run.
+// exists returns true if a container with the given name exists. Like the installation, it fails if the
+// placement container exists.
+func containerActions(info initInfo, exists func(name string) (bool, error)) ([]string, error) {
+	if info.slimMode {
+		return []string{}, nil
+	}
+
+	placementContainerName := utils.CreateContainerName(DaprPlacementContainerName, info.dockerNetwork)
+	ok, err := exists(placementContainerName)
+	if err != nil {
+		return nil, err
+	} else if ok {
+		return nil, fmt.Errorf("%s container exists or is running. %s", placementContainerName, errInstallTemplate)
+	}
+	actions, err := placementActions(info, placementContainerName)
+	if err != nil {
+		return nil, err
+	}
+
+	if isAirGapInit {
+		return actions, nil
+	}
+	for _, c := range initContainers {
+		if !info.withComponent(c.component) {
+			continue
+		}
+		containerName := utils.CreateContainerName(c.name, info.dockerNetwork)
+		ok, err := exists(containerName)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			actions = append(actions, containerRuntimeCommand(info.containerRuntime, []string{"start", containerName}))
+			continue
+		}
+		imageName, err := resolveImageURI(daprImageInfo{
+			ghcrImageName:      c.ghcrImageName,
+			dockerHubImageName: c.dockerImage,
+			imageRegistryURL:   info.imageRegistryURL,
+			imageRegistryName:  defaultImageRegistryName,
+		})
+		if err != nil {
+			return nil, err
+		}
+		args := c.runArgs(containerName, info.containerRuntime.QualifyImage(imageName), info.dockerNetwork)
+		actions = append(actions, containerRuntimeCommand(info.containerRuntime, args))
+	}
+	return actions, nil
+}
+
+// placementActions returns the actions that run the placement container with the given name.
+func placementActions(info initInfo, placementContainerName string) ([]string, error) {
+	actions := []string{}
+	var image string
+	if isAirGapInit {
+		image = info.bundleDet.getPlacementImageName()
+		imagePath := path_filepath.Join(info.fromDir, *info.bundleDet.ImageSubDir, info.bundleDet.getPlacementImageFileName())
+		actions = append(actions, "load image "+imagePath)
+	} else {
+		var err error
+		image, err = resolveImageURI(daprImageInfo{
+			ghcrImageName:      daprGhcrImageName,
+			dockerHubImageName: daprDockerImageName,
+			imageRegistryURL:   info.imageRegistryURL,
+			imageRegistryName:  defaultImageRegistryName,
+		})
+		if err != nil {
+			return nil, err
+		}
+		image = getPlacementImageWithTag(image, info.runtimeVersion)
+	}
+	args := append(placementContainerArgs(placementContainerName, info), info.containerRuntime.QualifyImage(image))
+	return append(actions, containerRuntimeCommand(info.containerRuntime, args)), nil
+}
+
+// printUpgradeActions prints the actions of an upgrade, in the order they are taken, without taking them.
+func printUpgradeActions(info initInfo, daprBinDir string) error {
+	actions := []string{fmt.Sprintf("back up %s to %s", daprBinDir, daprBinDir+binBackupDirSuffix)}
+	actions = append(actions, installActions(info)...)
+	if !info.slimMode {
+		placementContainerName := utils.CreateContainerName(DaprPlacementContainerName, info.dockerNetwork)
+		exists, err := confirmContainerIsRunningOrExists(info.containerRuntime, placementContainerName, false)
+		if err != nil {
+			return err
+		}
+		if exists {
+			actions = append(actions, containerRuntimeCommand(info.containerRuntime, []string{"rm", "--force", placementContainerName}))
+		}
+		placement, err := placementActions(info, placementContainerName)
+		if err != nil {
+			return err
+		}
+		actions = append(actions, placement...)
+	}
+	for _, action := range actions {
+		print.DryRunStatusEvent(os.Stdout, "Would %s", action)
+	}
+	return nil
+}
+
+// containerRuntimeCommand returns the action of running the container runtime with the given arguments.
+func containerRuntimeCommand(containerRuntime ContainerRuntime, args []string) string {
+	return "run " + containerRuntime.Name() + " " + strings.Join(args, " ")
+}
+
+func fileExists(filePath string) bool {
+	_, err := os.Stat(filePath)
+	return err == nil
+}
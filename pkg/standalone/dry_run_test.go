@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+	"os"
+	path_filepath "path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cli_ver "github.com/dapr/cli/pkg/version"
+)
+
+func TestInstallActions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	setAirGapInit("")
+	binDir := defaultDaprBinPath()
+
+	t.Run("default installation", func(t *testing.T) {
+		actions := installActions(initInfo{
+			runtimeVersion:   "1.9.0",
+			dashboardVersion: "0.11.0",
+			components:       DefaultInitComponents,
+		})
+		assert.Equal(t, []string{
+			fmt.Sprintf("download %s to %s", cli_ver.ReleaseURL(cli_ver.DaprGitHubRepo, "1.9.0", binaryName(daprRuntimeFilePrefix)), binDir),
+			fmt.Sprintf("download %s to %s", cli_ver.ReleaseURL(cli_ver.DashboardGitHubRepo, "0.11.0", binaryName(dashboardFilePrefix)), binDir),
+			"write component file " + path_filepath.Join(DefaultComponentsDirPath(), "pubsub.yaml"),
+			"write component file " + path_filepath.Join(DefaultComponentsDirPath(), "statestore.yaml"),
+			"write configuration file " + DefaultConfigFilePath(),
+		}, actions)
+	})
+
+	t.Run("slim installation keeps existing files", func(t *testing.T) {
+		require.NoError(t, os.MkdirAll(defaultDaprDirPath(), 0o755))
+		require.NoError(t, os.WriteFile(DefaultConfigFilePath(), []byte{}, 0o600))
+
+		actions := installActions(initInfo{runtimeVersion: "1.9.0", slimMode: true})
+		assert.Equal(t, []string{
+			fmt.Sprintf("download %s to %s", cli_ver.ReleaseURL(cli_ver.DaprGitHubRepo, "1.9.0", binaryName(daprRuntimeFilePrefix)), binDir),
+			fmt.Sprintf("download %s to %s", cli_ver.ReleaseURL(cli_ver.DaprGitHubRepo, "1.9.0", binaryName(placementServiceFilePrefix)), binDir),
+		}, actions)
+	})
+}
+
+func TestContainerActions(t *testing.T) {
+	setAirGapInit("")
+	info := initInfo{
+		runtimeVersion:   "1.9.0",
+		imageRegistryURL: "registry.example.com",
+		containerRuntime: &dockerRuntime{},
+		components:       DefaultInitComponents,
+	}
+
+	t.Run("existing containers are started", func(t *testing.T) {
+		actions, err := containerActions(info, func(name string) (bool, error) {
+			return name == DaprRedisContainerName, nil
+		})
+		require.NoError(t, err)
+		require.Len(t, actions, 3)
+		assert.Equal(t, fmt.Sprintf("run docker run --name dapr_placement --restart always -d --entrypoint ./placement -p %d:50005 registry.example.com/dapr/dapr:1.9.0", PlacementPort()), actions[0])
+		assert.Equal(t, "run docker start dapr_redis", actions[1])
+		assert.Contains(t, actions[2], "run docker run --name dapr_zipkin ")
+	})
+
+	t.Run("existing placement container fails", func(t *testing.T) {
+		_, err := containerActions(info, func(name string) (bool, error) {
+			return true, nil
+		})
+		assert.ErrorContains(t, err, "dapr_placement container exists or is running")
+	})
+
+	t.Run("slim installation runs no containers", func(t *testing.T) {
+		slim := info
+		slim.slimMode = true
+		actions, err := containerActions(slim, nil)
+		require.NoError(t, err)
+		assert.Empty(t, actions)
+	})
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ParseEnvFile returns the variables of a .env file. Each line holds a KEY=VALUE pair, optionally
+// prefixed with export. Blank lines and lines starting with # are ignored. Values can be enclosed in
+// single quotes, taken literally, or in double quotes, in which \n, \t, \" and \\ are unescaped.
+// Unquoted values end at a # preceded by a space, and are trimmed.
+func ParseEnvFile(path string) (map[string]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading env file: %w", err)
+	}
+
+	env := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, err := parseEnvVar(line)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing env file %s at line %d: %w", path, n, err)
+		}
+		env[key] = value
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading env file: %w", err)
+	}
+	return env, nil
+}
+
+// ParseEnvVar returns the key and the value of a variable given as KEY=VALUE.
+func ParseEnvVar(v string) (string, string, error) {
+	key, value, found := strings.Cut(v, "=")
+	key = strings.TrimSpace(key)
+	if !found || key == "" || strings.ContainsAny(key, " \t") {
+		return "", "", fmt.Errorf("invalid environment variable %q. Expected KEY=VALUE", v)
+	}
+	return key, value, nil
+}
+
+func parseEnvVar(line string) (string, string, error) {
+	key, value, err := ParseEnvVar(line)
+	if err != nil {
+		return "", "", err
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return key, value, nil
+	}
+
+	switch quote := value[0]; quote {
+	case '\'', '"':
+		end := closingQuote(value, quote)
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated quoted value of %s", key)
+		}
+		if rest := strings.TrimSpace(value[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", "", fmt.Errorf("unexpected characters after the quoted value of %s", key)
+		}
+		value = value[1:end]
+		if quote == '"' {
+			value = strings.NewReplacer(`\n`, "\n", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(value)
+		}
+	default:
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+	}
+	return key, value, nil
+}
+
+// closingQuote returns the index of the quote that ends the value starting with it, or -1 if there is none.
+// Escaped quotes are skipped in double-quoted values.
+func closingQuote(value string, quote byte) int {
+	for i := 1; i < len(value); i++ {
+		switch {
+		case quote == '"' && value[i] == '\\':
+			i++
+		case value[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// MergeEnv returns the variables of base, overridden by the variables of the env files in order and then by vars,
+// given as KEY=VALUE.
+func MergeEnv(base map[string]string, envFiles, vars []string) (map[string]string, error) {
+	env := make(map[string]string, len(base))
+	for key, value := range base {
+		env[key] = value
+	}
+	for _, path := range envFiles {
+		fileEnv, err := ParseEnvFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range fileEnv {
+			env[key] = value
+		}
+	}
+	for _, v := range vars {
+		key, value, err := ParseEnvVar(v)
+		if err != nil {
+			return nil, err
+		}
+		env[key] = value
+	}
+	return env, nil
+}
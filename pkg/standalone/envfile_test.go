@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeEnvFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestParseEnvFile(t *testing.T) {
+	t.Run("parse variables", func(t *testing.T) {
+		path := writeEnvFile(t, `# database
+DB_HOST=localhost
+export DB_PORT = 5432
+EMPTY=
+GREETING="hello \"world\"\nbye" # quoted
+RAW='no $expansion \n here'
+URL=http://localhost:3000/#anchor # comment
+`)
+		env, err := ParseEnvFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"DB_HOST":  "localhost",
+			"DB_PORT":  "5432",
+			"EMPTY":    "",
+			"GREETING": "hello \"world\"\nbye",
+			"RAW":      `no $expansion \n here`,
+			"URL":      "http://localhost:3000/#anchor",
+		}, env)
+	})
+
+	t.Run("invalid lines", func(t *testing.T) {
+		for _, content := range []string{"NO_VALUE", "=value", "A B=c", `QUOTED="unterminated`, `QUOTED='a' b`} {
+			_, err := ParseEnvFile(writeEnvFile(t, content))
+			assert.ErrorContains(t, err, "at line 1", content)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := ParseEnvFile(filepath.Join(t.TempDir(), "missing.env"))
+		assert.ErrorContains(t, err, "error reading env file")
+	})
+}
+
+func TestMergeEnv(t *testing.T) {
+	first := writeEnvFile(t, "A=first\nB=first\nC=first\n")
+	second := writeEnvFile(t, "B=second\nC=second\n")
+
+	env, err := MergeEnv(map[string]string{"A": "base", "D": "base"}, []string{first, second}, []string{"C=flag", "E=x=y"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"A": "first", "B": "second", "C": "flag", "D": "base", "E": "x=y"}, env)
+
+	_, err = MergeEnv(nil, nil, []string{"INVALID"})
+	assert.EqualError(t, err, `invalid environment variable "INVALID". Expected KEY=VALUE`)
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
+)
+
+// mockDaprGRPCServer echoes the payload of invoke requests, prefixed by the method and content type.
+// The query string and the x-test metadata are appended when present.
+type mockDaprGRPCServer struct {
+	runtimev1pb.UnimplementedDaprServer
+}
+
+func (m *mockDaprGRPCServer) InvokeService(ctx context.Context, req *runtimev1pb.InvokeServiceRequest) (*commonv1pb.InvokeResponse, error) {
+	msg := req.GetMessage()
+	resp := fmt.Sprintf("%s %s %s %s", msg.GetMethod(), msg.GetHttpExtension().GetVerb(), msg.GetContentType(), msg.GetData().GetValue())
+	if qs := msg.GetHttpExtension().GetQuerystring(); qs != "" {
+		resp += " " + qs
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("x-test")) > 0 {
+		resp += " " + strings.Join(md.Get("x-test"), ",")
+	}
+	return &commonv1pb.InvokeResponse{Data: &anypb.Any{Value: []byte(resp)}}, nil
+}
+
+// GetConfigurationAlpha1 returns an item with the value "<key>-value" for every requested key.
+func (m *mockDaprGRPCServer) GetConfigurationAlpha1(ctx context.Context, req *runtimev1pb.GetConfigurationRequest) (*runtimev1pb.GetConfigurationResponse, error) {
+	if req.GetStoreName() != "configstore" {
+		return nil, fmt.Errorf("configuration store %s not found", req.GetStoreName())
+	}
+	items := []*commonv1pb.ConfigurationItem{}
+	for _, key := range req.GetKeys() {
+		items = append(items, &commonv1pb.ConfigurationItem{Key: key, Value: key + "-value", Version: "1"})
+	}
+	return &runtimev1pb.GetConfigurationResponse{Items: items}, nil
+}
+
+// SubscribeConfigurationAlpha1 sends the subscription ID, then one update with version 2 of every requested key.
+func (m *mockDaprGRPCServer) SubscribeConfigurationAlpha1(req *runtimev1pb.SubscribeConfigurationRequest, stream runtimev1pb.Dapr_SubscribeConfigurationAlpha1Server) error {
+	if err := stream.Send(&runtimev1pb.SubscribeConfigurationResponse{Id: "subscription"}); err != nil {
+		return err
+	}
+	items := []*commonv1pb.ConfigurationItem{}
+	for _, key := range req.GetKeys() {
+		items = append(items, &commonv1pb.ConfigurationItem{Key: key, Value: key + "-updated", Version: "2"})
+	}
+	if err := stream.Send(&runtimev1pb.SubscribeConfigurationResponse{Id: "subscription", Items: items}); err != nil {
+		return err
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+func (m *mockDaprGRPCServer) UnsubscribeConfigurationAlpha1(ctx context.Context, req *runtimev1pb.UnsubscribeConfigurationRequest) (*runtimev1pb.UnsubscribeConfigurationResponse, error) {
+	if req.GetId() != "subscription" {
+		return nil, fmt.Errorf("subscription %s not found", req.GetId())
+	}
+	return &runtimev1pb.UnsubscribeConfigurationResponse{Ok: true}, nil
+}
+
+func getTestGRPCServer() (*grpc.Server, net.Listener, int) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("grpc test: failed to listen: %v", err))
+	}
+	s := grpc.NewServer()
+	runtimev1pb.RegisterDaprServer(s, &mockDaprGRPCServer{})
+	return s, l, l.Addr().(*net.TCPAddr).Port
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dapr/cli/pkg/api"
+)
+
+const (
+	// DefaultHealthTimeout is the time dapr health --wait waits for the sidecar to be healthy.
+	DefaultHealthTimeout = 60 * time.Second
+
+	healthPollInterval = 500 * time.Millisecond
+	healthCheckTimeout = 2 * time.Second
+	healthOK           = "ok"
+)
+
+// HealthOutput is the health of the sidecar of an app.
+type HealthOutput struct {
+	AppID   string `csv:"APP ID"  json:"appId"   yaml:"appId"`
+	Healthy bool   `csv:"HEALTHY" json:"healthy" yaml:"healthy"`
+	// Sidecar is ok once the sidecar is ready, or else the reason it is not.
+	Sidecar string `csv:"SIDECAR" json:"sidecar" yaml:"sidecar"`
+	// Outbound is ok once the components of the sidecar are initialized, so that the app can call the Dapr API.
+	Outbound string `csv:"OUTBOUND" json:"outbound" yaml:"outbound"`
+}
+
+// Health checks the health endpoints of the sidecar of appID, or of the only running sidecar if appID is empty.
+// If timeout is not 0, the checks are repeated until the sidecar is healthy or timeout elapses, also waiting for
+// the sidecar to start. The returned output is unhealthy if the sidecar runs but is not healthy in time.
+func (s *Standalone) Health(ctx context.Context, appID, socket string, timeout time.Duration) (HealthOutput, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	for {
+		health, err := s.checkHealth(ctx, appID, socket)
+		if (err == nil && health.Healthy) || timeout == 0 {
+			return health, err
+		}
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return health, fmt.Errorf("timed out after %s waiting for the sidecar: %w", timeout, err)
+			}
+			return health, nil
+		case <-time.After(healthPollInterval):
+		}
+	}
+}
+
+func (s *Standalone) checkHealth(ctx context.Context, appID, socket string) (HealthOutput, error) {
+	l, err := s.process.List()
+	if err != nil {
+		return HealthOutput{AppID: appID}, err
+	}
+	instance, err := getSidecar(l, appID)
+	if err != nil {
+		return HealthOutput{AppID: appID}, err
+	}
+
+	health := HealthOutput{
+		AppID:    instance.AppID,
+		Sidecar:  probeHealth(ctx, instance, socket, "healthz"),
+		Outbound: probeHealth(ctx, instance, socket, "healthz/outbound"),
+	}
+	health.Healthy = health.Sidecar == healthOK && health.Outbound == healthOK
+	return health, nil
+}
+
+// probeHealth returns ok if the health endpoint path of the sidecar instance responds with a success status,
+// or else the reason it does not.
+func probeHealth(ctx context.Context, instance ListOutput, socket, path string) string {
+	endpoint, httpc := instanceEndpoint(instance, socket, fmt.Sprintf("v%s/%s", api.RuntimeAPIVersion, path))
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err.Error()
+	}
+	r, err := httpc.Do(req)
+	if err != nil {
+		return "unreachable"
+	}
+	defer r.Body.Close()
+	if r.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Sprintf("unhealthy (status %d)", r.StatusCode)
+	}
+	return healthOK
+}
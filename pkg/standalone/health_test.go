@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth(t *testing.T) {
+	// The outbound health endpoint fails for the first checks, until the components are initialized.
+	var outboundChecks int32
+	ts, port := getTestServerFunc(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1.0/healthz":
+			w.WriteHeader(http.StatusNoContent)
+		case "/v1.0/healthz/outbound":
+			if atomic.AddInt32(&outboundChecks, 1) <= 2 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ts.Start()
+	defer ts.Close()
+
+	client := &Standalone{
+		process: &mockDaprProcess{
+			Lo: []ListOutput{{AppID: "testapp", HTTPPort: port}},
+		},
+	}
+
+	t.Run("single check", func(t *testing.T) {
+		health, err := client.Health(context.Background(), "", "", 0)
+		require.NoError(t, err)
+		assert.Equal(t, HealthOutput{AppID: "testapp", Sidecar: "ok", Outbound: "unhealthy (status 500)"}, health)
+	})
+
+	t.Run("wait until healthy", func(t *testing.T) {
+		health, err := client.Health(context.Background(), "testapp", "", 5*time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, HealthOutput{AppID: "testapp", Healthy: true, Sidecar: "ok", Outbound: "ok"}, health)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&outboundChecks))
+	})
+
+	t.Run("app not running", func(t *testing.T) {
+		_, err := client.Health(context.Background(), "other", "", 0)
+		assert.Error(t, err)
+
+		_, err = client.Health(context.Background(), "other", "", time.Second)
+		assert.ErrorContains(t, err, "timed out after 1s waiting for the sidecar")
+	})
+
+	t.Run("sidecar unreachable", func(t *testing.T) {
+		unreachable := &Standalone{process: &mockDaprProcess{Lo: []ListOutput{{AppID: "testapp", HTTPPort: 1}}}}
+		health, err := unreachable.Health(context.Background(), "testapp", "", time.Second)
+		require.NoError(t, err)
+		assert.False(t, health.Healthy)
+		assert.Equal(t, "unreachable", health.Sidecar)
+	})
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dapr/cli/pkg/print"
+	cli_ver "github.com/dapr/cli/pkg/version"
+	"github.com/dapr/cli/utils"
+)
+
+const (
+	dashboardDockerImageName = "daprio/dashboard"
+	dashboardGhcrImageName   = "dashboard"
+)
+
+// PullImagesConfig represents the options of pulling the images of an installation ahead of dapr init.
+type PullImagesConfig struct {
+	RuntimeVersion   string
+	DashboardVersion string
+	// Components are the init components whose images are pulled, such as redis.
+	Components []string
+	// Registry is the registry to retag the images for, as given to dapr init --image-registry. The images are
+	// not retagged if it is empty.
+	Registry string
+	// Push pushes the retagged images to Registry.
+	Push             bool
+	ContainerRuntime string
+}
+
+// ImageOutput is a Dapr image stored by the container runtime.
+type ImageOutput struct {
+	Repository string `csv:"REPOSITORY" json:"repository" yaml:"repository"`
+	Tag        string `csv:"TAG" json:"tag" yaml:"tag"`
+	ID         string `csv:"IMAGE ID" json:"id" yaml:"id"`
+	Size       string `csv:"SIZE" json:"size" yaml:"size"`
+	UsedBy     string `csv:"USED BY" json:"usedBy" yaml:"usedBy"`
+}
+
+// installImage is an image that an installation runs.
+type installImage struct {
+	usedBy             string
+	ghcrImageName      string
+	dockerHubImageName string
+	// tag is the version of the image, or empty for the images of the init components, which are not pinned.
+	tag string
+}
+
+// name returns the image in the registry of imageInfo.
+func (i installImage) name(imageInfo daprImageInfo) (string, error) {
+	imageInfo.ghcrImageName = i.ghcrImageName
+	imageInfo.dockerHubImageName = i.dockerHubImageName
+	image, err := resolveImageURI(imageInfo)
+	if err != nil || i.tag == "" {
+		return image, err
+	}
+	return getPlacementImageWithTag(image, i.tag), nil
+}
+
+// installImages returns the images of an installation of the given versions and init components. The Dapr image
+// runs the sidecar in a container, and the placement and scheduler services. The dashboard image is only run in
+// Kubernetes.
+func installImages(runtimeVersion, dashboardVersion string, components []string) []installImage {
+	images := []installImage{{
+		usedBy:             "daprd, placement, scheduler",
+		ghcrImageName:      daprGhcrImageName,
+		dockerHubImageName: daprDockerImageName,
+		tag:                runtimeVersion,
+	}}
+	for _, c := range initContainers {
+		if containsString(components, c.component) {
+			images = append(images, installImage{usedBy: c.description, ghcrImageName: c.ghcrImageName, dockerHubImageName: c.dockerImage})
+		}
+	}
+	return append(images, installImage{
+		usedBy:             "dashboard",
+		ghcrImageName:      dashboardGhcrImageName,
+		dockerHubImageName: dashboardDockerImageName,
+		tag:                dashboardVersion,
+	})
+}
+
+// PullImages pulls the images that dapr init needs, so that it does not download them, and retags them for the
+// registry of config, so that dapr init --image-registry finds them. It pulls all images even if some fail, and
+// returns an error listing the failed images.
+func PullImages(config PullImagesConfig) error {
+	if err := ValidateInitComponents(config.Components); err != nil {
+		return err
+	}
+	containerRuntime, err := NewContainerRuntime(config.ContainerRuntime)
+	if err != nil {
+		return err
+	}
+	if err = containerRuntime.CheckRunning(); err != nil {
+		return err
+	}
+	registryName, err := utils.GetDefaultRegistry(githubContainerRegistryName, dockerContainerRegistryName)
+	if err != nil {
+		return err
+	}
+
+	if config.RuntimeVersion == latestVersion {
+		if config.RuntimeVersion, err = cli_ver.GetDaprVersion(); err != nil {
+			return fmt.Errorf("cannot get the latest release version: '%w'. Try specifying --runtime-version=<desired_version>", err)
+		}
+	}
+	if config.DashboardVersion == latestVersion {
+		if config.DashboardVersion, err = cli_ver.GetDashboardVersion(); err != nil {
+			return fmt.Errorf("cannot get the latest dashboard version: '%w'. Try specifying --dashboard-version=<desired_version>", err)
+		}
+	}
+
+	failed := []string{}
+	images := installImages(config.RuntimeVersion, config.DashboardVersion, config.Components)
+	for _, image := range images {
+		if err = pullImage(containerRuntime, image, registryName, config.Registry, config.Push); err != nil {
+			print.FailureStatusEvent(os.Stderr, err.Error())
+			failed = append(failed, image.dockerHubImageName)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to pull %d of %d images: %s", len(failed), len(images), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// pullImage pulls image from the default registry, or from Docker Hub if it cannot be pulled from GHCR like
+// dapr init does, and tags and pushes it for mirror if it is not empty.
+func pullImage(containerRuntime ContainerRuntime, image installImage, registryName, mirror string, push bool) error {
+	name, err := image.name(daprImageInfo{imageRegistryName: registryName})
+	if err != nil {
+		return err
+	}
+	name = containerRuntime.QualifyImage(name)
+	print.InfoStatusEvent(os.Stdout, "Pulling %s", name)
+	if err = containerRuntime.PullImage(name); err != nil && registryName == githubContainerRegistryName {
+		print.InfoStatusEvent(os.Stdout, "%s not found in Github container registry, pulling it from Docker Hub", name)
+		name, _ = image.name(daprImageInfo{imageRegistryName: dockerContainerRegistryName})
+		name = containerRuntime.QualifyImage(name)
+		err = containerRuntime.PullImage(name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to pull %s: %w", name, err)
+	}
+	if mirror == "" {
+		return nil
+	}
+
+	target, err := image.name(daprImageInfo{imageRegistryURL: mirror})
+	if err != nil {
+		return err
+	}
+	if _, err = containerRuntime.Run("tag", name, target); err != nil {
+		return fmt.Errorf("failed to tag %s as %s: %w", name, target, err)
+	}
+	print.InfoStatusEvent(os.Stdout, "Tagged %s as %s", name, target)
+	if !push {
+		return nil
+	}
+	if _, err = containerRuntime.Run("push", target); err != nil {
+		return fmt.Errorf("failed to push %s: %w", target, err)
+	}
+	print.InfoStatusEvent(os.Stdout, "Pushed %s", target)
+	return nil
+}
+
+// ListImages returns the Dapr images stored by the container runtime with the given name: the images of the
+// runtime, the dashboard and the init components, from Docker Hub, GHCR or a mirror.
+func ListImages(containerRuntimeName string) ([]ImageOutput, error) {
+	containerRuntime, err := NewContainerRuntime(containerRuntimeName)
+	if err != nil {
+		return nil, err
+	}
+	if err = containerRuntime.CheckRunning(); err != nil {
+		return nil, err
+	}
+	out, err := containerRuntime.Run("images", "--format", "{{.Repository}}\t{{.Tag}}\t{{.ID}}\t{{.Size}}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the images: %w", err)
+	}
+	return parseImages(out, installImages("", "", InitComponents)), nil
+}
+
+// parseImages returns the images of the output of the images command of a container runtime that are one of
+// images, with one image per line as repository, tag, ID and size separated by tabs.
+func parseImages(out string, images []installImage) []ImageOutput {
+	list := []ImageOutput{}
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Split(strings.TrimSpace(scanner.Text()), "\t")
+		if len(fields) != 4 {
+			continue
+		}
+		if usedBy := imageUsedBy(fields[0], images); usedBy != "" {
+			list = append(list, ImageOutput{Repository: fields[0], Tag: fields[1], ID: fields[2], Size: fields[3], UsedBy: usedBy})
+		}
+	}
+	return list
+}
+
+// imageUsedBy returns what runs the image with the given repository if it is one of images, on Docker Hub or in
+// the layout of GHCR and of the registries of dapr init --image-registry, or an empty string otherwise.
+func imageUsedBy(repository string, images []installImage) string {
+	repository = strings.TrimPrefix(strings.TrimPrefix(repository, "docker.io/"), "library/")
+	for _, image := range images {
+		dockerHubRepository := strings.SplitN(image.dockerHubImageName, ":", 2)[0]
+		if repository == dockerHubRepository || strings.HasSuffix(repository, "/dapr/"+image.ghcrImageName) {
+			return image.usedBy
+		}
+	}
+	return ""
+}
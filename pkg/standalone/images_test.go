@@ -0,0 +1,64 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallImages(t *testing.T) {
+	names := func(imageInfo daprImageInfo) []string {
+		names := []string{}
+		for _, image := range installImages("1.8.0", "0.10.0", DefaultInitComponents) {
+			name, err := image.name(imageInfo)
+			require.NoError(t, err)
+			names = append(names, name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"daprio/dapr:1.8.0", "redis:6", "openzipkin/zipkin", "daprio/dashboard:0.10.0"},
+		names(daprImageInfo{imageRegistryName: dockerContainerRegistryName}))
+	assert.Equal(t, []string{"ghcr.io/dapr/dapr:1.8.0", "ghcr.io/dapr/3rdparty/redis", "ghcr.io/dapr/3rdparty/zipkin", "ghcr.io/dapr/dashboard:0.10.0"},
+		names(daprImageInfo{imageRegistryName: githubContainerRegistryName}))
+	assert.Equal(t, []string{"mirror.example.com/dapr/dapr:1.8.0", "mirror.example.com/dapr/3rdparty/redis", "mirror.example.com/dapr/3rdparty/zipkin", "mirror.example.com/dapr/dashboard:0.10.0"},
+		names(daprImageInfo{imageRegistryURL: "mirror.example.com"}), "the images are retagged as dapr init --image-registry expects them")
+
+	images := installImages("1.8.0", "0.10.0", []string{KafkaInitComponent})
+	require.Len(t, images, 3)
+	assert.Equal(t, "Kafka pub/sub", images[1].usedBy)
+
+	_, err := images[0].name(daprImageInfo{imageRegistryURL: "docker.io"})
+	assert.Error(t, err)
+}
+
+func TestParseImages(t *testing.T) {
+	out := "daprio/dapr\t1.8.0\t1a2b3c\t210MB\n" +
+		"docker.io/library/redis\t6\t4d5e6f\t117MB\n" +
+		"mirror.example.com/dapr/3rdparty/zipkin\tlatest\t7a8b9c\t156MB\n" +
+		"ghcr.io/dapr/dashboard\t0.10.0\t0d1e2f\t39MB\n" +
+		"nginx\tlatest\t3a4b5c\t142MB\n" +
+		"malformed line\n"
+
+	images := parseImages(out, installImages("", "", InitComponents))
+	assert.Equal(t, []ImageOutput{
+		{Repository: "daprio/dapr", Tag: "1.8.0", ID: "1a2b3c", Size: "210MB", UsedBy: "daprd, placement, scheduler"},
+		{Repository: "docker.io/library/redis", Tag: "6", ID: "4d5e6f", Size: "117MB", UsedBy: "Redis state store"},
+		{Repository: "mirror.example.com/dapr/3rdparty/zipkin", Tag: "latest", ID: "7a8b9c", Size: "156MB", UsedBy: "Zipkin tracing"},
+		{Repository: "ghcr.io/dapr/dashboard", Tag: "0.10.0", ID: "0d1e2f", Size: "39MB", UsedBy: "dashboard"},
+	}, images)
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dapr/cli/utils"
+)
+
+const (
+	postgresPassword = "dapr"
+	stateStoreName   = "statestore"
+	pubSubName       = "pubsub"
+)
+
+// initContainer is the container run by dapr init for one of the init components.
+type initContainer struct {
+	component string
+	// name is the name of the container, and its alias in the Docker network.
+	name string
+	// description names the container in the errors of the container runtime.
+	description   string
+	ghcrImageName string
+	dockerImage   string
+	port          int
+	// env returns the environment variables of the container, given the host it is reached at.
+	env func(host string) []string
+}
+
+// initContainers are the containers of the init components, in the order of InitComponents.
+var initContainers = []initContainer{
+	{
+		component:     RedisInitComponent,
+		name:          DaprRedisContainerName,
+		description:   "Redis state store",
+		ghcrImageName: redisGhcrImageName,
+		dockerImage:   redisDockerImageName,
+		port:          6379,
+	},
+	{
+		component:     ZipkinInitComponent,
+		name:          DaprZipkinContainerName,
+		description:   "Zipkin tracing",
+		ghcrImageName: zipkinGhcrImageName,
+		dockerImage:   zipkinDockerImageName,
+		port:          9411,
+	},
+	{
+		component:     KafkaInitComponent,
+		name:          DaprKafkaContainerName,
+		description:   "Kafka pub/sub",
+		ghcrImageName: kafkaGhcrImageName,
+		dockerImage:   kafkaDockerImageName,
+		port:          9092,
+		env: func(host string) []string {
+			// A single broker in KRaft mode, which needs no ZooKeeper.
+			return []string{
+				"KAFKA_CFG_NODE_ID=0",
+				"KAFKA_CFG_PROCESS_ROLES=controller,broker",
+				"KAFKA_CFG_LISTENERS=PLAINTEXT://:9092,CONTROLLER://:9093",
+				fmt.Sprintf("KAFKA_CFG_ADVERTISED_LISTENERS=PLAINTEXT://%s:9092", host),
+				"KAFKA_CFG_LISTENER_SECURITY_PROTOCOL_MAP=CONTROLLER:PLAINTEXT,PLAINTEXT:PLAINTEXT",
+				"KAFKA_CFG_CONTROLLER_LISTENER_NAMES=CONTROLLER",
+				"KAFKA_CFG_CONTROLLER_QUORUM_VOTERS=0@localhost:9093",
+			}
+		},
+	},
+	{
+		component:     PostgresInitComponent,
+		name:          DaprPostgresContainerName,
+		description:   "PostgreSQL state store",
+		ghcrImageName: postgresGhcrImageName,
+		dockerImage:   postgresDockerImageName,
+		port:          5432,
+		env: func(string) []string {
+			return []string{"POSTGRES_PASSWORD=" + postgresPassword}
+		},
+	},
+}
+
+// initContainerHost returns the host the container with the given name is reached at by the sidecars.
+func initContainerHost(name, dockerNetwork string) string {
+	if dockerNetwork != "" {
+		// Default to network scoped alias of the container names when a dockerNetwork is specified.
+		return name
+	}
+	return ServiceHost()
+}
+
+// run starts the container, or creates it if it does not exist, if its component is set up by the installation.
+func (c initContainer) run(wg *sync.WaitGroup, errorChan chan<- error, info initInfo) {
+	defer wg.Done()
+
+	if info.slimMode || isAirGapInit || !info.withComponent(c.component) {
+		return
+	}
+
+	containerName := utils.CreateContainerName(c.name, info.dockerNetwork)
+
+	exists, err := confirmContainerIsRunningOrExists(info.containerRuntime, containerName, false)
+	if err != nil {
+		errorChan <- err
+		return
+	}
+
+	var args []string
+	if exists {
+		// do not create container again if it exists.
+		args = []string{"start", containerName}
+	} else {
+		imageName, err := resolveImageURI(daprImageInfo{
+			ghcrImageName:      c.ghcrImageName,
+			dockerHubImageName: c.dockerImage,
+			imageRegistryURL:   info.imageRegistryURL,
+			imageRegistryName:  defaultImageRegistryName,
+		})
+		if err != nil {
+			errorChan <- err
+			return
+		}
+		args = c.runArgs(containerName, info.containerRuntime.QualifyImage(imageName), info.dockerNetwork)
+		// Pull a missing image before running it to report the download progress. Errors are reported by run.
+		pullImageIfMissing(info.containerRuntime, info.containerRuntime.QualifyImage(imageName))
+	}
+	_, err = info.containerRuntime.Run(args...)
+
+	if err != nil {
+		runError := isContainerRunError(err)
+		if !runError {
+			errorChan <- parseDockerError(info.containerRuntime, c.description, err)
+		} else {
+			errorChan <- fmt.Errorf("%s %s failed with: %w", info.containerRuntime.Name(), args, err)
+		}
+		return
+	}
+	errorChan <- nil
+}
+
+// runArgs returns the arguments of the container runtime to create and start the container.
+func (c initContainer) runArgs(containerName, imageName, dockerNetwork string) []string {
+	args := []string{
+		"run",
+		"--name", containerName,
+		"--restart", "always",
+		"-d",
+	}
+	if dockerNetwork != "" {
+		args = append(args, "--network", dockerNetwork, "--network-alias", c.name)
+	} else {
+		args = append(args, "-p", fmt.Sprintf("%d:%d", c.port, c.port))
+	}
+	if c.env != nil {
+		for _, e := range c.env(initContainerHost(c.name, dockerNetwork)) {
+			args = append(args, "-e", e)
+		}
+	}
+	return append(args, imageName)
+}
+
+// initComponentFile is a component file created by dapr init in the default components directory.
+type initComponentFile struct {
+	name      string
+	component component
+}
+
+// initComponentFiles returns the component files of the init components. The first state store and the first
+// pub/sub in the order of components are named statestore and pubsub, and the state store is used for actors.
+// Further ones are prefixed with the name of their init component, e.g. kafka-pubsub.
+func initComponentFiles(components []string, dockerNetwork string) []initComponentFile {
+	files := []initComponentFile{}
+	names := map[string]bool{}
+	add := func(initComponent, defaultName, componentType string, metadata ...componentMetadataItem) {
+		name := defaultName
+		if names[name] {
+			name = initComponent + "-" + defaultName
+		} else if defaultName == stateStoreName {
+			metadata = append(metadata, componentMetadataItem{Name: "actorStateStore", Value: "true"})
+		}
+		names[name] = true
+		files = append(files, initComponentFile{
+			name:      name + ".yaml",
+			component: newComponent(name, componentType, metadata),
+		})
+	}
+
+	for _, c := range components {
+		switch c {
+		case RedisInitComponent:
+			redisHost := fmt.Sprintf("%s:6379", initContainerHost(DaprRedisContainerName, dockerNetwork))
+			add(c, pubSubName, "pubsub.redis",
+				componentMetadataItem{Name: "redisHost", Value: redisHost},
+				componentMetadataItem{Name: "redisPassword", Value: ""})
+			add(c, stateStoreName, "state.redis",
+				componentMetadataItem{Name: "redisHost", Value: redisHost},
+				componentMetadataItem{Name: "redisPassword", Value: ""})
+		case KafkaInitComponent:
+			add(c, pubSubName, "pubsub.kafka",
+				componentMetadataItem{Name: "brokers", Value: fmt.Sprintf("%s:9092", initContainerHost(DaprKafkaContainerName, dockerNetwork))},
+				componentMetadataItem{Name: "consumerGroup", Value: "{appID}"},
+				componentMetadataItem{Name: "authType", Value: "none"})
+		case PostgresInitComponent:
+			connectionString := fmt.Sprintf("host=%s port=5432 user=postgres password=%s database=postgres sslmode=disable connect_timeout=10",
+				initContainerHost(DaprPostgresContainerName, dockerNetwork), postgresPassword)
+			add(c, stateStoreName, "state.postgresql",
+				componentMetadataItem{Name: "connectionString", Value: connectionString})
+		}
+	}
+	return files
+}
+
+func newComponent(name, componentType string, metadata []componentMetadataItem) component {
+	c := component{
+		APIVersion: "dapr.io/v1alpha1",
+		Kind:       "Component",
+	}
+	c.Metadata.Name = name
+	c.Spec.Type = componentType
+	c.Spec.Version = "v1"
+	c.Spec.Metadata = metadata
+	return c
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitComponentFiles(t *testing.T) {
+	fileNames := func(files []initComponentFile) []string {
+		names := []string{}
+		for _, f := range files {
+			names = append(names, f.name)
+		}
+		return names
+	}
+
+	t.Run("default components", func(t *testing.T) {
+		files := initComponentFiles(DefaultInitComponents, "")
+		require.Equal(t, []string{"pubsub.yaml", "statestore.yaml"}, fileNames(files))
+		assert.Equal(t, "pubsub.redis", files[0].component.Spec.Type)
+		assert.Equal(t, []componentMetadataItem{
+			{Name: "redisHost", Value: "localhost:6379"},
+			{Name: "redisPassword", Value: ""},
+			{Name: "actorStateStore", Value: "true"},
+		}, files[1].component.Spec.Metadata)
+	})
+
+	t.Run("kafka and postgres in a docker network", func(t *testing.T) {
+		files := initComponentFiles([]string{KafkaInitComponent, PostgresInitComponent, ZipkinInitComponent}, "dapr-network")
+		require.Equal(t, []string{"pubsub.yaml", "statestore.yaml"}, fileNames(files))
+		assert.Equal(t, "pubsub", files[0].component.Metadata.Name)
+		assert.Equal(t, "pubsub.kafka", files[0].component.Spec.Type)
+		assert.Contains(t, files[0].component.Spec.Metadata, componentMetadataItem{Name: "brokers", Value: "dapr_kafka:9092"})
+		assert.Equal(t, "statestore", files[1].component.Metadata.Name)
+		assert.Equal(t, "state.postgresql", files[1].component.Spec.Type)
+		assert.Contains(t, files[1].component.Spec.Metadata[0].Value, "host=dapr_postgres ")
+		assert.Equal(t, componentMetadataItem{Name: "actorStateStore", Value: "true"}, files[1].component.Spec.Metadata[1])
+	})
+
+	t.Run("first component of a kind gets the default name", func(t *testing.T) {
+		files := initComponentFiles([]string{PostgresInitComponent, RedisInitComponent, KafkaInitComponent}, "")
+		require.Equal(t, []string{"statestore.yaml", "pubsub.yaml", "redis-statestore.yaml", "kafka-pubsub.yaml"}, fileNames(files))
+		assert.Equal(t, "state.postgresql", files[0].component.Spec.Type)
+		assert.Equal(t, "redis-statestore", files[2].component.Metadata.Name)
+		assert.NotContains(t, files[2].component.Spec.Metadata, componentMetadataItem{Name: "actorStateStore", Value: "true"})
+	})
+
+	t.Run("no components", func(t *testing.T) {
+		assert.Empty(t, initComponentFiles([]string{ZipkinInitComponent}, ""))
+	})
+}
+
+func TestInitContainerRunArgs(t *testing.T) {
+	var kafka initContainer
+	for _, c := range initContainers {
+		if c.component == KafkaInitComponent {
+			kafka = c
+		}
+	}
+
+	args := kafka.runArgs("dapr_kafka", "bitnami/kafka:3.4", "")
+	assert.Equal(t, []string{"run", "--name", "dapr_kafka", "--restart", "always", "-d", "-p", "9092:9092"}, args[:8])
+	assert.Contains(t, args, "KAFKA_CFG_ADVERTISED_LISTENERS=PLAINTEXT://localhost:9092")
+	assert.Equal(t, "bitnami/kafka:3.4", args[len(args)-1])
+
+	args = kafka.runArgs("dapr_kafka_dapr-network", "bitnami/kafka:3.4", "dapr-network")
+	assert.Equal(t, []string{"--network", "dapr-network", "--network-alias", "dapr_kafka"}, args[6:10])
+	assert.Contains(t, args, "KAFKA_CFG_ADVERTISED_LISTENERS=PLAINTEXT://dapr_kafka:9092")
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// InitOptions are the choices of a self-hosted installation made with dapr init --interactive.
+type InitOptions struct {
+	RuntimeVersion   string
+	DashboardVersion string
+	SlimMode         bool
+	ContainerRuntime string
+	Components       []string
+
+	// The options below are not prompted for, but are given as flags and kept in the printed command.
+	DockerNetwork      string
+	ImageRegistryURL   string
+	InsecureSkipVerify bool
+	ResourcesPath      string
+	ConfigFile         string
+	InstallServices    bool
+	CACertFile         string
+	GitHubMirror       string
+}
+
+// initComponentPrompts are the questions asked for each of DefaultInitComponents.
+var initComponentPrompts = map[string]string{
+	RedisInitComponent:  "Run Redis and create the default state store and pub/sub components?",
+	ZipkinInitComponent: "Run Zipkin and enable tracing in the default configuration?",
+}
+
+// PromptInitOptions asks the user for the options of a self-hosted installation on out and reads the answers from in.
+// An empty answer keeps the value of defaults.
+func PromptInitOptions(in io.Reader, out io.Writer, defaults InitOptions) (InitOptions, error) {
+	p := &prompter{scanner: bufio.NewScanner(in), out: out}
+	opts := defaults
+
+	var err error
+	if opts.RuntimeVersion, err = p.ask("Dapr runtime version", defaults.RuntimeVersion); err != nil {
+		return opts, err
+	}
+
+	mode := "container"
+	if defaults.SlimMode {
+		mode = "slim"
+	}
+	if mode, err = p.choose("Installation mode, slim runs placement as a process without containers", []string{"container", "slim"}, mode); err != nil {
+		return opts, err
+	}
+	opts.SlimMode = mode == "slim"
+
+	if !opts.SlimMode {
+		if opts.ContainerRuntime, err = p.choose("Container runtime", []string{DockerContainerRuntime, PodmanContainerRuntime}, defaults.ContainerRuntime); err != nil {
+			return opts, err
+		}
+	}
+
+	dashboard, err := p.confirm("Install the Dapr dashboard?", defaults.DashboardVersion != "")
+	if err != nil {
+		return opts, err
+	}
+	opts.DashboardVersion = ""
+	if dashboard {
+		opts.DashboardVersion = defaults.DashboardVersion
+		if opts.DashboardVersion == "" {
+			opts.DashboardVersion = latestVersion
+		}
+	}
+
+	if opts.SlimMode {
+		return opts, nil
+	}
+	opts.Components = []string{}
+	for _, c := range DefaultInitComponents {
+		ok, err := p.confirm(initComponentPrompts[c], containsString(defaults.Components, c))
+		if err != nil {
+			return opts, err
+		}
+		if ok {
+			opts.Components = append(opts.Components, c)
+		}
+	}
+	return opts, nil
+}
+
+// Command returns the dapr init command that installs Dapr with the options without prompting.
+// Flags that are set to their default value are left out.
+func (o InitOptions) Command() string {
+	args := []string{"dapr", "init"}
+	if o.RuntimeVersion != "" && o.RuntimeVersion != latestVersion {
+		args = append(args, "--runtime-version", o.RuntimeVersion)
+	}
+	if o.SlimMode {
+		args = append(args, "--slim")
+	}
+	switch o.DashboardVersion {
+	case "":
+		args = append(args, "--dashboard-version", `""`)
+	case latestVersion:
+	default:
+		args = append(args, "--dashboard-version", o.DashboardVersion)
+	}
+	if o.InsecureSkipVerify {
+		args = append(args, "--insecure-skip-verify")
+	}
+	args = appendFlag(args, "--resources-path", o.ResourcesPath)
+	args = appendFlag(args, "--config-file", o.ConfigFile)
+	args = appendFlag(args, "--cacert", o.CACertFile)
+	args = appendFlag(args, "--github-mirror", o.GitHubMirror)
+	if o.SlimMode {
+		if o.InstallServices {
+			args = append(args, "--install-services")
+		}
+		return strings.Join(args, " ")
+	}
+	if o.ContainerRuntime != "" && o.ContainerRuntime != DockerContainerRuntime {
+		args = append(args, "--container-runtime", o.ContainerRuntime)
+	}
+	args = appendFlag(args, "--network", o.DockerNetwork)
+	args = appendFlag(args, "--image-registry", o.ImageRegistryURL)
+	if strings.Join(o.Components, ",") != strings.Join(DefaultInitComponents, ",") {
+		args = append(args, fmt.Sprintf("--components=%q", strings.Join(o.Components, ",")))
+	}
+	return strings.Join(args, " ")
+}
+
+// appendFlag appends the flag with value to args if value is not empty. Values that a shell would split or
+// expand are quoted.
+func appendFlag(args []string, flag, value string) []string {
+	if value == "" {
+		return args
+	}
+	if strings.ContainsAny(value, " \t\"'$`\\*?&;|<>()#~") {
+		value = fmt.Sprintf("%q", value)
+	}
+	return append(args, flag, value)
+}
+
+// prompter asks questions on out and reads one line answer for each from scanner.
+type prompter struct {
+	scanner *bufio.Scanner
+	out     io.Writer
+}
+
+// ask returns the answer to question, or def if the answer is empty.
+func (p *prompter) ask(question, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, def)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+	if !p.scanner.Scan() {
+		if err := p.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", errors.New("no answer given, the input was closed")
+	}
+	answer := strings.TrimSpace(p.scanner.Text())
+	if answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// choose asks question until the answer is one of options.
+func (p *prompter) choose(question string, options []string, def string) (string, error) {
+	for {
+		answer, err := p.ask(fmt.Sprintf("%s (%s)", question, strings.Join(options, "/")), def)
+		if err != nil {
+			return "", err
+		}
+		if containsString(options, strings.ToLower(answer)) {
+			return strings.ToLower(answer), nil
+		}
+		fmt.Fprintf(p.out, "Please answer one of: %s\n", strings.Join(options, ", "))
+	}
+}
+
+// confirm asks a yes or no question until the answer is either.
+func (p *prompter) confirm(question string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	for {
+		answer, err := p.ask(fmt.Sprintf("%s (%s)", question, hint), "")
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(p.out, "Please answer yes or no")
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
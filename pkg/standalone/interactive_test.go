@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromptInitOptions(t *testing.T) {
+	defaults := InitOptions{
+		RuntimeVersion:   latestVersion,
+		DashboardVersion: latestVersion,
+		ContainerRuntime: DockerContainerRuntime,
+		Components:       DefaultInitComponents,
+	}
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected InitOptions
+		command  string
+	}{
+		{
+			name:     "defaults",
+			input:    "\n\n\n\n\n\n",
+			expected: defaults,
+			command:  "dapr init",
+		},
+		{
+			name:  "container mode",
+			input: "1.8.0\ncontainer\npodman\nn\ny\nno\n",
+			expected: InitOptions{
+				RuntimeVersion:   "1.8.0",
+				ContainerRuntime: PodmanContainerRuntime,
+				Components:       []string{RedisInitComponent},
+			},
+			command: `dapr init --runtime-version 1.8.0 --dashboard-version "" --container-runtime podman --components="redis"`,
+		},
+		{
+			name:  "slim mode",
+			input: "\nslim\n\n",
+			expected: InitOptions{
+				RuntimeVersion:   latestVersion,
+				DashboardVersion: latestVersion,
+				SlimMode:         true,
+				ContainerRuntime: DockerContainerRuntime,
+				Components:       DefaultInitComponents,
+			},
+			command: "dapr init --slim",
+		},
+		{
+			name:  "invalid answers are asked again",
+			input: "\nfull\ncontainer\ncri-o\ndocker\nmaybe\ny\nn\nn\n",
+			expected: InitOptions{
+				RuntimeVersion:   latestVersion,
+				DashboardVersion: latestVersion,
+				ContainerRuntime: DockerContainerRuntime,
+				Components:       []string{},
+			},
+			command: `dapr init --components=""`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			opts, err := PromptInitOptions(strings.NewReader(tc.input), &out, defaults)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, opts)
+			assert.Equal(t, tc.command, opts.Command())
+		})
+	}
+
+	t.Run("closed input", func(t *testing.T) {
+		_, err := PromptInitOptions(strings.NewReader("1.8.0\n"), &bytes.Buffer{}, defaults)
+		assert.EqualError(t, err, "no answer given, the input was closed")
+	})
+}
+
+func TestInitOptionsCommand(t *testing.T) {
+	opts := InitOptions{
+		RuntimeVersion:     "1.9.0",
+		DashboardVersion:   latestVersion,
+		ContainerRuntime:   PodmanContainerRuntime,
+		Components:         DefaultInitComponents,
+		DockerNetwork:      "dapr-net",
+		ImageRegistryURL:   "registry.example.com",
+		InsecureSkipVerify: true,
+		ResourcesPath:      "/home/me/my resources",
+		ConfigFile:         "/home/me/config.yaml",
+		InstallServices:    true,
+		CACertFile:         "corp-ca.pem",
+		GitHubMirror:       "https://mirror.example.com/github",
+	}
+	assert.Equal(t, `dapr init --runtime-version 1.9.0 --insecure-skip-verify --resources-path "/home/me/my resources" `+
+		`--config-file /home/me/config.yaml --cacert corp-ca.pem --github-mirror https://mirror.example.com/github `+
+		`--container-runtime podman --network dapr-net --image-registry registry.example.com`, opts.Command())
+
+	opts.SlimMode = true
+	assert.Equal(t, `dapr init --runtime-version 1.9.0 --slim --insecure-skip-verify --resources-path "/home/me/my resources" `+
+		`--config-file /home/me/config.yaml --cacert corp-ca.pem --github-mirror https://mirror.example.com/github --install-services`,
+		opts.Command(), "the container options are left out of a slim installation")
+}
+
+func TestValidateInitComponents(t *testing.T) {
+	assert.NoError(t, ValidateInitComponents(DefaultInitComponents))
+	assert.NoError(t, ValidateInitComponents([]string{}))
+	assert.NoError(t, ValidateInitComponents([]string{"kafka", "postgres", "zipkin"}))
+	assert.EqualError(t, ValidateInitComponents([]string{"redis", "mongo"}), `invalid component "mongo". Valid values are: redis, zipkin, kafka, postgres`)
+}
@@ -16,17 +16,111 @@ package standalone
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net"
 	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/dapr/cli/pkg/api"
+	"github.com/dapr/cli/pkg/print"
 	"github.com/dapr/cli/utils"
+	commonv1pb "github.com/dapr/dapr/pkg/proto/common/v1"
+	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
 )
 
+// DefaultInvokeContentType is the content type of the invoke payload if none is given.
+const DefaultInvokeContentType = "application/json"
+
+// ErrCodeAppNotFound is the error code of the failures caused by an app that is not running.
+const ErrCodeAppNotFound = "ERR_APP_NOT_FOUND"
+
+// ParseHeaders parses headers given as "name:value". A header can be given more than once.
+func ParseHeaders(headers []string) (http.Header, error) {
+	parsed := http.Header{}
+	for _, header := range headers {
+		name, value, ok := strings.Cut(header, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid header %q. Headers must be given as name:value", header)
+		}
+		parsed.Add(name, strings.TrimSpace(value))
+	}
+	return parsed, nil
+}
+
+// ParseQuery parses query parameters given as "name=value". A parameter can be given more than once.
+func ParseQuery(params []string) (url.Values, error) {
+	parsed := url.Values{}
+	for _, param := range params {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid query parameter %q. Query parameters must be given as name=value", param)
+		}
+		parsed.Add(name, value)
+	}
+	return parsed, nil
+}
+
+// MultipartPayload returns a multipart/form-data payload of fields given as "name=value", or as "name=@path" to
+// send the content of the file at path, and its content type with the boundary of the parts.
+func MultipartPayload(fields []string) ([]byte, string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, field := range fields {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok || name == "" {
+			return nil, "", fmt.Errorf("invalid form field %q. Form fields must be given as name=value or name=@file", field)
+		}
+		if !strings.HasPrefix(value, "@") {
+			if err := writer.WriteField(name, value); err != nil {
+				return nil, "", err
+			}
+			continue
+		}
+
+		filePath := strings.TrimPrefix(value, "@")
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, "", fmt.Errorf("error reading form field %s: %w", name, err)
+		}
+		contentType := mime.TypeByExtension(filepath.Ext(filePath))
+		if contentType == "" {
+			contentType = http.DetectContentType(content)
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{"name": name, "filename": filepath.Base(filePath)}))
+		header.Set("Content-Type", contentType)
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err = part.Write(content); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return body.Bytes(), writer.FormDataContentType(), nil
+}
+
 // Invoke is a command to invoke a remote or local dapr instance.
-func (s *Standalone) Invoke(appID, method string, data []byte, verb string, path string) (string, error) {
+// The headers are sent in addition to the content type, which they can override, and the query is appended to the method.
+func (s *Standalone) Invoke(ctx context.Context, appID, method string, data []byte, verb, contentType string, headers http.Header, query url.Values, path string) (string, error) {
 	list, err := s.process.List()
 	if err != nil {
 		return "", err
@@ -34,12 +128,19 @@ func (s *Standalone) Invoke(appID, method string, data []byte, verb string, path
 
 	for _, lo := range list {
 		if lo.AppID == appID {
-			url := makeEndpoint(lo, method)
-			req, err := http.NewRequest(verb, url, bytes.NewBuffer(data))
+			url := appendQuery(makeEndpoint(lo, method), query)
+			req, err := http.NewRequestWithContext(ctx, verb, url, bytes.NewBuffer(data))
 			if err != nil {
 				return "", err
 			}
-			req.Header.Set("Content-Type", "application/json")
+			if contentType == "" {
+				contentType = DefaultInvokeContentType
+			}
+			req.Header.Set("Content-Type", contentType)
+			for name, values := range headers {
+				req.Header[http.CanonicalHeaderKey(name)] = values
+			}
+			printHeaders("Request", req.Header)
 
 			var httpc http.Client
 
@@ -56,30 +157,146 @@ func (s *Standalone) Invoke(appID, method string, data []byte, verb string, path
 				return "", err
 			}
 			defer r.Body.Close()
+			print.DebugStatusEvent(os.Stderr, "Response status: %s", r.Status)
+			printHeaders("Response", r.Header)
 			return handleResponse(r)
 		}
 	}
 
-	return "", fmt.Errorf("app ID %s not found", appID)
+	return "", print.NewCodedError(ErrCodeAppNotFound, fmt.Errorf("app ID %s not found", appID))
+}
+
+// InvokeGRPC invokes a method on a local dapr instance using the gRPC API of its sidecar.
+// The headers are sent as gRPC metadata and the query as the query string of the HTTP extension.
+func (s *Standalone) InvokeGRPC(ctx context.Context, appID, method string, data []byte, verb, contentType string, headers http.Header, query url.Values, path string) (string, error) {
+	httpVerb, err := grpcVerb(verb)
+	if err != nil {
+		return "", err
+	}
+	list, err := s.process.List()
+	if err != nil {
+		return "", err
+	}
+
+	for _, lo := range list {
+		if lo.AppID != appID {
+			continue
+		}
+
+		address := fmt.Sprintf("127.0.0.1:%v", lo.GRPCPort)
+		if path != "" {
+			address = "unix://" + utils.GetSocket(path, appID, "grpc")
+		}
+		conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return "", err
+		}
+		defer conn.Close()
+
+		if contentType == "" {
+			contentType = DefaultInvokeContentType
+		}
+		req := &runtimev1pb.InvokeServiceRequest{
+			Id: appID,
+			Message: &commonv1pb.InvokeRequest{
+				Method:      method,
+				Data:        &anypb.Any{Value: data},
+				ContentType: contentType,
+				HttpExtension: &commonv1pb.HTTPExtension{
+					Verb:        httpVerb,
+					Querystring: query.Encode(),
+				},
+			},
+		}
+
+		md := metadata.MD{}
+		for name, values := range headers {
+			md.Append(name, values...)
+		}
+		if len(md) > 0 {
+			ctx = metadata.NewOutgoingContext(ctx, md)
+			printHeaders("Request", http.Header(md))
+		}
+
+		var respHeaders metadata.MD
+		resp, err := runtimev1pb.NewDaprClient(conn).InvokeService(ctx, req, grpc.Header(&respHeaders))
+		if err != nil {
+			return "", err
+		}
+		printHeaders("Response", http.Header(respHeaders))
+		return string(resp.GetData().GetValue()), nil
+	}
+
+	return "", print.NewCodedError(ErrCodeAppNotFound, fmt.Errorf("app ID %s not found", appID))
+}
+
+// grpcVerb returns the HTTP extension verb of a gRPC invocation for the given HTTP verb.
+func grpcVerb(verb string) (commonv1pb.HTTPExtension_Verb, error) {
+	v, ok := commonv1pb.HTTPExtension_Verb_value[strings.ToUpper(verb)]
+	if !ok || commonv1pb.HTTPExtension_Verb(v) == commonv1pb.HTTPExtension_NONE {
+		valid := make([]string, 0, len(commonv1pb.HTTPExtension_Verb_value))
+		for name, value := range commonv1pb.HTTPExtension_Verb_value {
+			if commonv1pb.HTTPExtension_Verb(value) != commonv1pb.HTTPExtension_NONE {
+				valid = append(valid, name)
+			}
+		}
+		sort.Strings(valid)
+		return commonv1pb.HTTPExtension_NONE, fmt.Errorf("invalid verb %q. Valid values are: %s", verb, strings.Join(valid, ", "))
+	}
+	return commonv1pb.HTTPExtension_Verb(v), nil
 }
 
 func makeEndpoint(lo ListOutput, method string) string {
 	return fmt.Sprintf("http://127.0.0.1:%s/v%s/invoke/%s/method/%s", fmt.Sprintf("%v", lo.HTTPPort), api.RuntimeAPIVersion, lo.AppID, method)
 }
 
-func handleResponse(response *http.Response) (string, error) {
-	if response.StatusCode < 200 || response.StatusCode >= 400 {
-		return "", fmt.Errorf("%s", response.Status)
+// appendQuery appends query to endpoint, which can already have a query string.
+func appendQuery(endpoint string, query url.Values) string {
+	if len(query) == 0 {
+		return endpoint
 	}
+	if strings.Contains(endpoint, "?") {
+		return endpoint + "&" + query.Encode()
+	}
+	return endpoint + "?" + query.Encode()
+}
 
+// printHeaders prints the headers of an invocation in verbose mode.
+func printHeaders(kind string, headers http.Header) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		print.DebugStatusEvent(os.Stderr, "%s header %s: %s", kind, name, strings.Join(headers[name], ", "))
+	}
+}
+
+// IndentJSON returns data indented if it is a JSON document, or else data unchanged.
+func IndentJSON(data []byte) []byte {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		return data
+	}
+	return indented.Bytes()
+}
+
+// handleResponse returns the body of a successful response, or an error with the status and the body of a
+// response with an error status code.
+func handleResponse(response *http.Response) (string, error) {
 	rb, err := io.ReadAll(response.Body)
 	if err != nil {
 		return "", err
 	}
 
-	if len(rb) > 0 {
-		return string(rb), nil
+	if response.StatusCode < 200 || response.StatusCode >= 400 {
+		msg := response.Status
+		if body := bytes.TrimSpace(rb); len(body) > 0 {
+			msg = fmt.Sprintf("%s: %s", msg, body)
+		}
+		return "", &statusError{statusCode: response.StatusCode, msg: msg}
 	}
 
-	return "", nil
+	return string(rb), nil
 }
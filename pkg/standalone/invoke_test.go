@@ -14,8 +14,16 @@ limitations under the License.
 package standalone
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 
@@ -105,7 +113,7 @@ func TestInvoke(t *testing.T) {
 					},
 				}
 
-				res, err := client.Invoke(tc.appID, tc.method, []byte(tc.resp), "GET", socket)
+				res, err := client.Invoke(context.Background(), tc.appID, tc.method, []byte(tc.resp), "GET", "", nil, nil, socket)
 				if tc.errorExpected {
 					assert.Error(t, err, "expected an error")
 					assert.Equal(t, tc.errString, err.Error(), "expected error strings to match")
@@ -137,7 +145,7 @@ func TestInvoke(t *testing.T) {
 						Err: tc.listErr,
 					},
 				}
-				res, err := client.Invoke(tc.appID, tc.method, []byte(tc.resp), "POST", socket)
+				res, err := client.Invoke(context.Background(), tc.appID, tc.method, []byte(tc.resp), "POST", "", nil, nil, socket)
 				if tc.errorExpected {
 					assert.Error(t, err, "expected an error")
 					assert.Equal(t, tc.errString, err.Error(), "expected error strings to match")
@@ -169,7 +177,7 @@ func TestInvoke(t *testing.T) {
 						Err: tc.listErr,
 					},
 				}
-				res, err := client.Invoke(tc.appID, tc.method, []byte(tc.resp), "DELETE", socket)
+				res, err := client.Invoke(context.Background(), tc.appID, tc.method, []byte(tc.resp), "DELETE", "", nil, nil, socket)
 				if tc.errorExpected {
 					assert.Error(t, err, "expected an error")
 					assert.Equal(t, tc.errString, err.Error(), "expected error strings to match")
@@ -202,7 +210,7 @@ func TestInvoke(t *testing.T) {
 						Err: tc.listErr,
 					},
 				}
-				res, err := client.Invoke(tc.appID, tc.method, []byte(tc.resp), "PUT", socket)
+				res, err := client.Invoke(context.Background(), tc.appID, tc.method, []byte(tc.resp), "PUT", "", nil, nil, socket)
 				if tc.errorExpected {
 					assert.Error(t, err, "expected an error")
 					assert.Equal(t, tc.errString, err.Error(), "expected error strings to match")
@@ -214,3 +222,196 @@ func TestInvoke(t *testing.T) {
 		}
 	}
 }
+
+func TestInvokeGRPC(t *testing.T) {
+	s, l, port := getTestGRPCServer()
+	go s.Serve(l)
+	defer s.Stop()
+
+	client := &Standalone{
+		process: &mockDaprProcess{
+			Lo: []ListOutput{{AppID: "testapp", GRPCPort: port}},
+		},
+	}
+
+	t.Run("invoke with default content type", func(t *testing.T) {
+		res, err := client.InvokeGRPC(context.Background(), "testapp", "test", []byte(`{"key":"value"}`), "post", "", nil, nil, "")
+		assert.NoError(t, err)
+		assert.Equal(t, `test POST application/json {"key":"value"}`, res)
+	})
+
+	t.Run("invoke with content type", func(t *testing.T) {
+		res, err := client.InvokeGRPC(context.Background(), "testapp", "test", []byte("payload"), "GET", "application/x-protobuf", nil, nil, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "test GET application/x-protobuf payload", res)
+	})
+
+	t.Run("invoke with headers and query", func(t *testing.T) {
+		headers := http.Header{"X-Test": []string{"a", "b"}}
+		query := url.Values{"id": []string{"1"}, "name": []string{"x y"}}
+		res, err := client.InvokeGRPC(context.Background(), "testapp", "test", nil, "GET", "", headers, query, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "test GET application/json  id=1&name=x+y a,b", res)
+	})
+
+	t.Run("invalid verb", func(t *testing.T) {
+		_, err := client.InvokeGRPC(context.Background(), "testapp", "test", nil, "FETCH", "", nil, nil, "")
+		assert.ErrorContains(t, err, `invalid verb "FETCH". Valid values are: CONNECT, DELETE, GET,`)
+
+		_, err = client.InvokeGRPC(context.Background(), "testapp", "test", nil, "none", "", nil, nil, "")
+		assert.ErrorContains(t, err, `invalid verb "none"`)
+	})
+
+	t.Run("appID not found", func(t *testing.T) {
+		_, err := client.InvokeGRPC(context.Background(), "invalid", "test", nil, "GET", "", nil, nil, "")
+		assert.EqualError(t, err, "app ID invalid not found")
+	})
+
+	t.Run("list apps error", func(t *testing.T) {
+		errClient := &Standalone{process: &mockDaprProcess{Err: assert.AnError}}
+		_, err := errClient.InvokeGRPC(context.Background(), "testapp", "test", nil, "GET", "", nil, nil, "")
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestInvokeHeadersAndQuery(t *testing.T) {
+	ts, port := getTestServerFunc(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Response", "ok")
+		fmt.Fprintf(w, "%s %s %s %s", r.URL.Path, r.URL.RawQuery, r.Header.Get("Authorization"), r.Header.Get("Content-Type"))
+	}))
+	ts.Start()
+	defer ts.Close()
+
+	client := &Standalone{
+		process: &mockDaprProcess{
+			Lo: []ListOutput{{AppID: "testapp", HTTPPort: port}},
+		},
+	}
+
+	t.Run("headers and query are sent", func(t *testing.T) {
+		headers := http.Header{"authorization": []string{"Bearer token"}}
+		query := url.Values{"id": []string{"1"}}
+		res, err := client.Invoke(context.Background(), "testapp", "test", nil, "GET", "", headers, query, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "/v1.0/invoke/testapp/method/test id=1 Bearer token application/json", res)
+	})
+
+	t.Run("query is appended to a method with a query string", func(t *testing.T) {
+		res, err := client.Invoke(context.Background(), "testapp", "test?a=b", nil, "GET", "", nil, url.Values{"id": []string{"1"}}, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "/v1.0/invoke/testapp/method/test a=b&id=1  application/json", res)
+	})
+
+	t.Run("header overrides content type", func(t *testing.T) {
+		headers := http.Header{"Content-Type": []string{"text/plain"}}
+		res, err := client.Invoke(context.Background(), "testapp", "test", nil, "POST", "application/json", headers, nil, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "/v1.0/invoke/testapp/method/test   text/plain", res)
+	})
+}
+
+func TestInvokeErrorStatus(t *testing.T) {
+	ts, port := getTestServerFunc(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1.0/invoke/testapp/method/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintln(w, `{"error":"invalid order"}`)
+	}))
+	ts.Start()
+	defer ts.Close()
+
+	client := &Standalone{
+		process: &mockDaprProcess{
+			Lo: []ListOutput{{AppID: "testapp", HTTPPort: port}},
+		},
+	}
+
+	_, err := client.Invoke(context.Background(), "testapp", "order", nil, "POST", "", nil, nil, "")
+	assert.EqualError(t, err, `400 Bad Request: {"error":"invalid order"}`, "the body of an error response is part of the error")
+
+	_, err = client.Invoke(context.Background(), "testapp", "missing", nil, "GET", "", nil, nil, "")
+	assert.EqualError(t, err, "404 Not Found")
+}
+
+func TestSidecarClient(t *testing.T) {
+	ts, port := getTestServerFunc(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.URL.Path)
+	}))
+	ts.Start()
+	defer ts.Close()
+
+	client := NewSidecarClient("testapp", port, 0)
+	res, err := client.Invoke(context.Background(), "testapp", "test", nil, "GET", "", nil, nil, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "/v1.0/invoke/testapp/method/test", res)
+
+	err = client.Publish(context.Background(), "testapp", "pubsub", "orders", []byte("{}"), nil, "", map[string]interface{}{})
+	assert.NoError(t, err)
+
+	_, err = client.Invoke(context.Background(), "other", "test", nil, "GET", "", nil, nil, "")
+	assert.EqualError(t, err, "app ID other not found", "only the sidecar of the app is reachable")
+}
+
+func TestIndentJSON(t *testing.T) {
+	assert.Equal(t, "{\n  \"key\": [\n    1,\n    2\n  ]\n}", string(IndentJSON([]byte(`{"key":[1,2]}`))))
+	assert.Equal(t, "plain text", string(IndentJSON([]byte("plain text"))))
+	assert.Equal(t, []byte{0xff, 0x00}, IndentJSON([]byte{0xff, 0x00}), "binary data is unchanged")
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders([]string{"Authorization: Bearer token", "x-test:a", "X-Test: b", "traceparent:00-abc:def-01"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer token", headers.Get("Authorization"))
+	assert.Equal(t, []string{"a", "b"}, headers.Values("X-Test"))
+	assert.Equal(t, "00-abc:def-01", headers.Get("Traceparent"))
+
+	_, err = ParseHeaders([]string{"Authorization"})
+	assert.EqualError(t, err, `invalid header "Authorization". Headers must be given as name:value`)
+
+	_, err = ParseHeaders([]string{": value"})
+	assert.Error(t, err)
+}
+
+func TestParseQuery(t *testing.T) {
+	query, err := ParseQuery([]string{"id=1", "id=2", "filter=a=b", "empty="})
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{"id": []string{"1", "2"}, "filter": []string{"a=b"}, "empty": []string{""}}, query)
+
+	_, err = ParseQuery([]string{"id"})
+	assert.EqualError(t, err, `invalid query parameter "id". Query parameters must be given as name=value`)
+}
+
+func TestMultipartPayload(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "image.png")
+	content := []byte{0x89, 'P', 'N', 'G', 0x00, 0xff}
+	assert.NoError(t, os.WriteFile(filePath, content, 0o600))
+
+	body, contentType, err := MultipartPayload([]string{"description=an avatar", "image=@" + filePath})
+	assert.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	assert.NoError(t, err)
+	assert.Equal(t, "multipart/form-data", mediaType)
+
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	part, err := reader.NextPart()
+	assert.NoError(t, err)
+	assert.Equal(t, "description", part.FormName())
+	value, _ := io.ReadAll(part)
+	assert.Equal(t, "an avatar", string(value))
+
+	part, err = reader.NextPart()
+	assert.NoError(t, err)
+	assert.Equal(t, "image", part.FormName())
+	assert.Equal(t, "image.png", part.FileName())
+	assert.Equal(t, "image/png", part.Header.Get("Content-Type"))
+	value, _ = io.ReadAll(part)
+	assert.Equal(t, content, value)
+
+	_, _, err = MultipartPayload([]string{"image"})
+	assert.EqualError(t, err, `invalid form field "image". Form fields must be given as name=value or name=@file`)
+
+	_, _, err = MultipartPayload([]string{"image=@" + filepath.Join(t.TempDir(), "missing.png")})
+	assert.ErrorContains(t, err, "error reading form field image")
+}
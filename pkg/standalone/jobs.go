@@ -0,0 +1,275 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	path_filepath "path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dapr/cli/pkg/print"
+)
+
+const (
+	// jobsAPIVersion is the version of the Dapr API that manages the jobs of the scheduler.
+	jobsAPIVersion = "1.0-alpha1"
+
+	jobsFileName = "jobs.json"
+)
+
+// Job is a job to schedule, in the format of the jobs API. At least one of Schedule and DueTime is required.
+type Job struct {
+	Schedule string          `json:"schedule,omitempty"`
+	DueTime  string          `json:"dueTime,omitempty"`
+	Repeats  int             `json:"repeats,omitempty"`
+	TTL      string          `json:"ttl,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+}
+
+// JobOutput is a job of the scheduler, as returned by the jobs API.
+type JobOutput struct {
+	Name     string     `csv:"NAME"     json:"name"               yaml:"name"`
+	Schedule string     `csv:"SCHEDULE" json:"schedule,omitempty" yaml:"schedule,omitempty"`
+	DueTime  string     `csv:"DUE TIME" json:"dueTime,omitempty"  yaml:"dueTime,omitempty"`
+	Repeats  int        `csv:"REPEATS"  json:"repeats,omitempty"  yaml:"repeats,omitempty"`
+	TTL      string     `csv:"TTL"      json:"ttl,omitempty"      yaml:"ttl,omitempty"`
+	Data     StateValue `csv:"DATA"     json:"data,omitempty"     yaml:"data,omitempty"`
+}
+
+// errJobNotFound is returned by GetJob if the job does not exist, for example because it has expired.
+var errJobNotFound = errors.New("job not found")
+
+// jobsFilePath returns the file of the names of the jobs scheduled with the CLI, by app ID.
+// It is a variable so that tests can use a temporary file.
+var jobsFilePath = func() string {
+	return path_filepath.Join(defaultDaprDirPath(), jobsFileName)
+}
+
+// ScheduleJob schedules the job name through the sidecar of appID. A job with the same name is replaced.
+// The job is called back on the app when it is due. appID can be empty if only one app is running.
+func (s *Standalone) ScheduleJob(appID, name string, job Job, socket string) error {
+	if name == "" {
+		return errors.New("the job name is required")
+	}
+	if job.Schedule == "" && job.DueTime == "" {
+		return errors.New("a schedule or a due time is required")
+	}
+	instance, err := s.jobsSidecar(appID)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	endpoint, httpc, err := s.sidecarEndpoint(instance.AppID, socket, jobPath(name))
+	if err != nil {
+		return err
+	}
+	r, err := postWithHeaders(context.Background(), httpc, endpoint, "application/json", body, nil)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	if _, err = readStateResponse(r); err != nil {
+		return fmt.Errorf("error scheduling job %s: %w", name, err)
+	}
+	return updateJobNames(instance.AppID, func(names []string) []string {
+		for _, n := range names {
+			if n == name {
+				return names
+			}
+		}
+		return append(names, name)
+	})
+}
+
+// GetJob returns the job name, read through the sidecar of appID.
+func (s *Standalone) GetJob(appID, name, socket string) (JobOutput, error) {
+	if name == "" {
+		return JobOutput{}, errors.New("the job name is required")
+	}
+	instance, err := s.jobsSidecar(appID)
+	if err != nil {
+		return JobOutput{}, err
+	}
+	return s.getJob(instance.AppID, name, socket)
+}
+
+func (s *Standalone) getJob(appID, name, socket string) (JobOutput, error) {
+	endpoint, httpc, err := s.sidecarEndpoint(appID, socket, jobPath(name))
+	if err != nil {
+		return JobOutput{}, err
+	}
+	r, err := httpc.Get(endpoint) //nolint:noctx
+	if err != nil {
+		return JobOutput{}, err
+	}
+	defer r.Body.Close()
+	body, err := readStateResponse(r)
+	if err != nil {
+		if r.StatusCode == http.StatusNotFound || strings.HasSuffix(print.ErrorCode(err), "NOT_FOUND") {
+			return JobOutput{}, fmt.Errorf("%w: %s", errJobNotFound, name)
+		}
+		return JobOutput{}, fmt.Errorf("error getting job %s: %w", name, err)
+	}
+	var job JobOutput
+	if err = json.Unmarshal(body, &job); err != nil {
+		return JobOutput{}, fmt.Errorf("error parsing the response of the jobs API: %w", err)
+	}
+	if job.Name == "" {
+		job.Name = name
+	}
+	return job, nil
+}
+
+// DeleteJob deletes the job name through the sidecar of appID.
+func (s *Standalone) DeleteJob(appID, name, socket string) error {
+	if name == "" {
+		return errors.New("the job name is required")
+	}
+	instance, err := s.jobsSidecar(appID)
+	if err != nil {
+		return err
+	}
+	endpoint, httpc, err := s.sidecarEndpoint(instance.AppID, socket, jobPath(name))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodDelete, endpoint, nil) //nolint:noctx
+	if err != nil {
+		return err
+	}
+	r, err := httpc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	if _, err = readStateResponse(r); err != nil {
+		return fmt.Errorf("error deleting job %s: %w", name, err)
+	}
+	return updateJobNames(instance.AppID, func(names []string) []string {
+		return removeJobName(names, name)
+	})
+}
+
+// ListJobs returns the jobs of appID that were scheduled with the CLI. The jobs API cannot list jobs, so the names
+// of the jobs scheduled with ScheduleJob are kept in a file of the Dapr directory. Jobs that no longer exist, such as
+// jobs that have expired, are removed from it.
+func (s *Standalone) ListJobs(appID, socket string) ([]JobOutput, error) {
+	instance, err := s.jobsSidecar(appID)
+	if err != nil {
+		return nil, err
+	}
+	allNames, err := readJobNames()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := []JobOutput{}
+	names := allNames[instance.AppID]
+	var gone []string
+	for _, name := range names {
+		job, err := s.getJob(instance.AppID, name, socket)
+		if errors.Is(err, errJobNotFound) {
+			gone = append(gone, name)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	if len(gone) > 0 {
+		err = updateJobNames(instance.AppID, func(names []string) []string {
+			for _, name := range gone {
+				names = removeJobName(names, name)
+			}
+			return names
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].Name < jobs[j].Name
+	})
+	return jobs, nil
+}
+
+// jobsSidecar returns the sidecar of appID, or of the only running app if appID is empty.
+func (s *Standalone) jobsSidecar(appID string) (ListOutput, error) {
+	list, err := s.process.List()
+	if err != nil {
+		return ListOutput{}, err
+	}
+	return getSidecar(list, appID)
+}
+
+func jobPath(name string) string {
+	return fmt.Sprintf("v%s/jobs/%s", jobsAPIVersion, url.PathEscape(name))
+}
+
+// readJobNames returns the names of the jobs scheduled with the CLI, by app ID.
+func readJobNames() (map[string][]string, error) {
+	names := map[string][]string{}
+	b, err := os.ReadFile(jobsFilePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return names, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(b, &names); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", jobsFilePath(), err)
+	}
+	return names, nil
+}
+
+// updateJobNames replaces the names of the jobs of appID with the result of update.
+func updateJobNames(appID string, update func(names []string) []string) error {
+	names, err := readJobNames()
+	if err != nil {
+		return err
+	}
+	names[appID] = update(names[appID])
+	if len(names[appID]) == 0 {
+		delete(names, appID)
+	}
+	b, err := json.MarshalIndent(names, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(path_filepath.Dir(jobsFilePath()), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(jobsFilePath(), b, 0o600)
+}
+
+func removeJobName(names []string, name string) []string {
+	kept := names[:0]
+	for _, n := range names {
+		if n != name {
+			kept = append(kept, n)
+		}
+	}
+	return kept
+}
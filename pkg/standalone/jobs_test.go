@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"encoding/json"
+	"net/http"
+	path_filepath "path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jobsTestScheduler is an in-memory scheduler served with the jobs API of a sidecar.
+type jobsTestScheduler struct {
+	lock sync.Mutex
+	jobs map[string]Job
+}
+
+func (s *jobsTestScheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	name := strings.TrimPrefix(r.URL.Path, "/v1.0-alpha1/jobs/")
+	switch r.Method {
+	case http.MethodPost:
+		var job Job
+		json.NewDecoder(r.Body).Decode(&job)
+		s.jobs[name] = job
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet:
+		job, ok := s.jobs[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorCode":"ERR_JOBS_NOT_FOUND"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(struct {
+			Name string `json:"name"`
+			Job
+		}{name, job})
+	case http.MethodDelete:
+		delete(s.jobs, name)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestJobs(t *testing.T) {
+	jobsFile := path_filepath.Join(t.TempDir(), "jobs.json")
+	defaultJobsFilePath := jobsFilePath
+	jobsFilePath = func() string { return jobsFile }
+	defer func() { jobsFilePath = defaultJobsFilePath }()
+
+	scheduler := &jobsTestScheduler{jobs: map[string]Job{}}
+	ts, port := getTestServerFunc(scheduler)
+	ts.Start()
+	defer ts.Close()
+
+	client := &Standalone{
+		process: &mockDaprProcess{
+			Lo: []ListOutput{{AppID: "testapp", HTTPPort: port}},
+		},
+	}
+
+	t.Run("schedule", func(t *testing.T) {
+		err := client.ScheduleJob("", "backup", Job{Schedule: "@every 1h", Data: ParseStateValue([]byte("orders"))}, "")
+		require.NoError(t, err)
+		err = client.ScheduleJob("testapp", "reminder", Job{DueTime: "10s", Repeats: 1}, "")
+		require.NoError(t, err)
+		assert.Equal(t, json.RawMessage(`"orders"`), scheduler.jobs["backup"].Data)
+	})
+
+	t.Run("schedule requires a schedule or a due time", func(t *testing.T) {
+		err := client.ScheduleJob("", "invalid", Job{}, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		job, err := client.GetJob("", "backup", "")
+		require.NoError(t, err)
+		assert.Equal(t, JobOutput{Name: "backup", Schedule: "@every 1h", Data: StateValue(`"orders"`)}, job)
+
+		_, err = client.GetJob("", "unknown", "")
+		assert.ErrorIs(t, err, errJobNotFound)
+	})
+
+	t.Run("list forgets jobs that no longer exist", func(t *testing.T) {
+		delete(scheduler.jobs, "reminder")
+		jobs, err := client.ListJobs("", "")
+		require.NoError(t, err)
+		assert.Equal(t, []JobOutput{{Name: "backup", Schedule: "@every 1h", Data: StateValue(`"orders"`)}}, jobs)
+
+		names, err := readJobNames()
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{"testapp": {"backup"}}, names)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, client.DeleteJob("", "backup", ""))
+		assert.Empty(t, scheduler.jobs)
+
+		jobs, err := client.ListJobs("", "")
+		require.NoError(t, err)
+		assert.Empty(t, jobs)
+	})
+
+	t.Run("app not found", func(t *testing.T) {
+		_, err := client.ListJobs("other", "")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Launcher is the conventional command that runs an app, detected from the files in its directory.
+type Launcher struct {
+	// Language is the language or runtime of the app, such as Node.js.
+	Language string
+	// Manifest is the file the launcher was detected from, such as package.json.
+	Manifest string
+	// Command is the command that runs the app.
+	Command []string
+	// AppPort is the port the app conventionally listens on, or 0 if there is no convention.
+	AppPort int
+}
+
+// launcherDetectors detect the launcher of an app, in order of precedence.
+var launcherDetectors = []func(dir string) (*Launcher, error){
+	detectNodeLauncher,
+	detectDotNetLauncher,
+	detectPythonLauncher,
+	detectGoLauncher,
+}
+
+// DetectLauncher returns the launcher of the app in dir, detected from its package.json, *.csproj, requirements.txt
+// or go.mod, or nil if none is found.
+func DetectLauncher(dir string) (*Launcher, error) {
+	for _, detect := range launcherDetectors {
+		launcher, err := detect(dir)
+		if err != nil || launcher != nil {
+			return launcher, err
+		}
+	}
+	return nil, nil
+}
+
+// detectNodeLauncher runs the start script of package.json with npm, or else its main file with node.
+func detectNodeLauncher(dir string) (*Launcher, error) {
+	b, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var pkg struct {
+		Main    string            `json:"main"`
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err = json.Unmarshal(b, &pkg); err != nil {
+		return nil, fmt.Errorf("error parsing package.json: %w", err)
+	}
+
+	launcher := &Launcher{Language: "Node.js", Manifest: "package.json", AppPort: 3000}
+	if pkg.Scripts["start"] != "" {
+		launcher.Command = []string{"npm", "start"}
+		return launcher, nil
+	}
+	main := pkg.Main
+	if main == "" {
+		main = firstExistingFile(dir, "index.js", "server.js", "app.js")
+	}
+	if main == "" {
+		return nil, nil
+	}
+	launcher.Command = []string{"node", main}
+	return launcher, nil
+}
+
+// detectDotNetLauncher runs the project with dotnet run. A directory with several projects is ambiguous.
+// No port is guessed, as the templates of .NET give every project a random port in its launchSettings.json.
+func detectDotNetLauncher(dir string) (*Launcher, error) {
+	projects, err := filepath.Glob(filepath.Join(dir, "*.csproj"))
+	if err != nil || len(projects) != 1 {
+		return nil, err
+	}
+	return &Launcher{
+		Language: ".NET",
+		Manifest: filepath.Base(projects[0]),
+		Command:  []string{"dotnet", "run"},
+	}, nil
+}
+
+// detectPythonLauncher runs the Django project with manage.py runserver, or else the main file of the app, on the
+// default port of the web framework in requirements.txt.
+func detectPythonLauncher(dir string) (*Launcher, error) {
+	b, err := os.ReadFile(filepath.Join(dir, "requirements.txt"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	requirements := strings.ToLower(string(b))
+	python := "python3"
+	if runtime.GOOS == daprWindowsOS {
+		python = "python"
+	}
+
+	launcher := &Launcher{Language: "Python", Manifest: "requirements.txt"}
+	if strings.Contains(requirements, "django") && firstExistingFile(dir, "manage.py") != "" {
+		launcher.AppPort = 8000
+		launcher.Command = []string{python, "manage.py", "runserver"}
+		return launcher, nil
+	}
+	main := firstExistingFile(dir, "app.py", "main.py", "server.py")
+	if main == "" {
+		return nil, nil
+	}
+	switch {
+	case strings.Contains(requirements, "flask"):
+		launcher.AppPort = 5000
+	case strings.Contains(requirements, "fastapi"), strings.Contains(requirements, "uvicorn"):
+		launcher.AppPort = 8000
+	}
+	launcher.Command = []string{python, main}
+	return launcher, nil
+}
+
+// detectGoLauncher runs the main package of the module with go run. Go apps have no conventional port.
+func detectGoLauncher(dir string) (*Launcher, error) {
+	if firstExistingFile(dir, "go.mod") == "" {
+		return nil, nil
+	}
+	return &Launcher{Language: "Go", Manifest: "go.mod", Command: []string{"go", "run", "."}}, nil
+}
+
+// firstExistingFile returns the first of names that is a file in dir, or an empty string if there is none.
+func firstExistingFile(dir string, names ...string) string {
+	for _, name := range names {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil && !info.IsDir() {
+			return name
+		}
+	}
+	return ""
+}
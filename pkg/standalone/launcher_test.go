@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectLauncher(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		language string
+		command  []string
+		appPort  int
+	}{
+		{
+			name:     "npm start script",
+			files:    map[string]string{"package.json": `{"main": "index.js", "scripts": {"start": "node server.js"}}`},
+			language: "Node.js",
+			command:  []string{"npm", "start"},
+			appPort:  3000,
+		},
+		{
+			name:     "node main file",
+			files:    map[string]string{"package.json": `{"name": "app"}`, "server.js": ""},
+			language: "Node.js",
+			command:  []string{"node", "server.js"},
+			appPort:  3000,
+		},
+		{
+			name:     "dotnet project",
+			files:    map[string]string{"Orders.csproj": "<Project/>"},
+			language: ".NET",
+			command:  []string{"dotnet", "run"},
+		},
+		{
+			name:     "flask app",
+			files:    map[string]string{"requirements.txt": "Flask==2.1.2\ndapr", "app.py": ""},
+			language: "Python",
+			command:  []string{"app.py"},
+			appPort:  5000,
+		},
+		{
+			name:     "django project",
+			files:    map[string]string{"requirements.txt": "django", "manage.py": ""},
+			language: "Python",
+			command:  []string{"manage.py", "runserver"},
+			appPort:  8000,
+		},
+		{
+			name:     "go module",
+			files:    map[string]string{"go.mod": "module app", "requirements.txt": "dapr"},
+			language: "Go",
+			command:  []string{"go", "run", "."},
+		},
+		{
+			name:  "several dotnet projects are ambiguous",
+			files: map[string]string{"Orders.csproj": "", "Orders.Tests.csproj": ""},
+		},
+		{
+			name:  "package.json without anything to run",
+			files: map[string]string{"package.json": `{"name": "lib"}`},
+		},
+		{
+			name: "empty directory",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+			}
+			launcher, err := DetectLauncher(dir)
+			require.NoError(t, err)
+			if tc.language == "" {
+				assert.Nil(t, launcher)
+				return
+			}
+			require.NotNil(t, launcher)
+			assert.Equal(t, tc.language, launcher.Language)
+			assert.Equal(t, tc.appPort, launcher.AppPort)
+			if tc.language == "Python" {
+				// The name of the interpreter depends on the operating system.
+				assert.Equal(t, tc.command, launcher.Command[1:])
+			} else {
+				assert.Equal(t, tc.command, launcher.Command)
+			}
+		})
+	}
+}
+
+func TestDetectLauncherInvalidPackageJSON(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte("{"), 0o600))
+	_, err := DetectLauncher(dir)
+	assert.ErrorContains(t, err, "error parsing package.json")
+}
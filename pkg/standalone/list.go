@@ -29,18 +29,26 @@ import (
 
 // ListOutput represents the application ID, application port and creation time.
 type ListOutput struct {
-	AppID              string `csv:"APP ID"    json:"appId"              yaml:"appId"`
-	HTTPPort           int    `csv:"HTTP PORT" json:"httpPort"           yaml:"httpPort"`
-	GRPCPort           int    `csv:"GRPC PORT" json:"grpcPort"           yaml:"grpcPort"`
-	AppPort            int    `csv:"APP PORT"  json:"appPort"            yaml:"appPort"`
-	MetricsEnabled     bool   `csv:"-"         json:"metricsEnabled"     yaml:"metricsEnabled"` // Not displayed in table, consumed by dashboard.
-	Command            string `csv:"COMMAND"   json:"command"            yaml:"command"`
-	Age                string `csv:"AGE"       json:"age"                yaml:"age"`
-	Created            string `csv:"CREATED"   json:"created"            yaml:"created"`
-	DaprdPID           int    `csv:"DAPRD PID" json:"daprdPid"           yaml:"daprdPid"`
-	CliPID             int    `csv:"CLI PID"   json:"cliPid"             yaml:"cliPid"`
-	MaxRequestBodySize int    `csv:"-"         json:"maxRequestBodySize" yaml:"maxRequestBodySize"` // Additional field, not displayed in table.
-	HTTPReadBufferSize int    `csv:"-"         json:"httpReadBufferSize" yaml:"httpReadBufferSize"` // Additional field, not displayed in table.
+	AppID              string      `csv:"APP ID"    json:"appId"              yaml:"appId"`
+	Namespace          string      `csv:"-"         json:"namespace"          yaml:"namespace"          wide:"NAMESPACE"` // Only displayed in wide table.
+	HTTPPort           int         `csv:"HTTP PORT" json:"httpPort"           yaml:"httpPort"`
+	GRPCPort           int         `csv:"GRPC PORT" json:"grpcPort"           yaml:"grpcPort"`
+	AppPort            int         `csv:"APP PORT"  json:"appPort"            yaml:"appPort"`
+	MetricsEnabled     bool        `csv:"-"         json:"metricsEnabled"     yaml:"metricsEnabled"     wide:"METRICS ENABLED"` // Only displayed in wide table, consumed by dashboard.
+	Command            string      `csv:"COMMAND"   json:"command"            yaml:"command"`
+	Restarts           int         `csv:"RESTARTS"  json:"restarts"           yaml:"restarts"`
+	Status             string      `csv:"-"         json:"status"             yaml:"status"             wide:"STATUS"` // Only displayed in wide table.
+	Age                string      `csv:"AGE"       json:"age"                yaml:"age"`
+	Created            string      `csv:"CREATED"   json:"created"            yaml:"created"`
+	DaprdPID           int         `csv:"DAPRD PID" json:"daprdPid"           yaml:"daprdPid"`
+	CliPID             int         `csv:"CLI PID"   json:"cliPid"             yaml:"cliPid"`
+	AppPID             int         `csv:"-"         json:"appPid"             yaml:"appPid"             wide:"APP PID"` // Only displayed in wide table.
+	DaprdCPU           CPUUsage    `csv:"DAPRD CPU" json:"daprdCpu"           yaml:"daprdCpu"`
+	DaprdMemory        MemoryUsage `csv:"DAPRD MEM" json:"daprdMemory"        yaml:"daprdMemory"`
+	AppCPU             CPUUsage    `csv:"APP CPU"   json:"appCpu"             yaml:"appCpu"`
+	AppMemory          MemoryUsage `csv:"APP MEM"   json:"appMemory"          yaml:"appMemory"`
+	MaxRequestBodySize int         `csv:"-"         json:"maxRequestBodySize" yaml:"maxRequestBodySize" wide:"MAX REQUEST BODY SIZE"` // Additional field, only displayed in wide table.
+	HTTPReadBufferSize int         `csv:"-"         json:"httpReadBufferSize" yaml:"httpReadBufferSize" wide:"HTTP READ BUFFER SIZE"` // Additional field, only displayed in wide table.
 }
 
 func (d *daprProcess) List() ([]ListOutput, error) {
@@ -99,11 +107,17 @@ func List() ([]ListOutput, error) {
 			appID := argumentsMap["--app-id"]
 			appCmd := ""
 			cliPIDString := ""
+			appPIDString := ""
+			restartsString := ""
+			namespace := ""
 			socket := argumentsMap["--unix-domain-socket"]
 			appMetadata, err := metadata.Get(httpPort, appID, socket)
 			if err == nil {
 				appCmd = appMetadata.Extended["appCommand"]
 				cliPIDString = appMetadata.Extended["cliPID"]
+				appPIDString = appMetadata.Extended["appPID"]
+				restartsString = appMetadata.Extended[AppRestartsMetadataKey]
+				namespace = appMetadata.Extended[NamespaceMetadataKey]
 			}
 
 			// Parse functions return an error on bad input.
@@ -112,6 +126,16 @@ func List() ([]ListOutput, error) {
 				cliPID = 0
 			}
 
+			appPID, err := strconv.Atoi(appPIDString)
+			if err != nil {
+				appPID = 0
+			}
+
+			restarts, err := strconv.Atoi(restartsString)
+			if err != nil {
+				restarts = 0
+			}
+
 			daprPID := proc.Pid()
 
 			createUnixTimeMilliseconds, err := procDetails.CreateTime()
@@ -126,12 +150,16 @@ func List() ([]ListOutput, error) {
 				Age:                age.GetAge(createTime),
 				DaprdPID:           daprPID,
 				CliPID:             cliPID,
+				AppPID:             appPID,
 				AppID:              appID,
+				Namespace:          namespace,
 				HTTPPort:           httpPort,
 				GRPCPort:           grpcPort,
 				AppPort:            appPort,
 				MetricsEnabled:     enableMetrics,
 				Command:            utils.TruncateString(appCmd, 20),
+				Restarts:           restarts,
+				Status:             appStatus(appPID),
 				MaxRequestBodySize: maxRequestBodySize,
 				HTTPReadBufferSize: httpReadBufferSize,
 			}
@@ -146,6 +174,17 @@ func List() ([]ListOutput, error) {
 	return list, nil
 }
 
+// appStatus returns the status of an instance: running, or stopped if its app process has exited while the sidecar
+// still runs. Instances without an app are running.
+func appStatus(appPID int) string {
+	if appPID > 0 {
+		if exists, err := process.PidExists(int32(appPID)); err == nil && !exists {
+			return "stopped"
+		}
+	}
+	return "running"
+}
+
 // getIntArg returns the value of the argument as an integer.
 // If the argument is not set, or is not an integer, it returns the default value.
 func getIntArg(argMap map[string]string, argKey string, argDef int) int {
@@ -0,0 +1,197 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// LoadConfig represents the options of sending a request repeatedly, as a quick load test.
+type LoadConfig struct {
+	// Repeat is the number of requests to send.
+	Repeat int
+	// Concurrency is the number of requests that are in flight at the same time.
+	Concurrency int
+	// Interval is the time between the starts of two requests. Requests start as soon as a worker is free
+	// if it is zero.
+	Interval time.Duration
+}
+
+// Validate returns an error if the options cannot be used.
+func (c LoadConfig) Validate() error {
+	if c.Repeat < 1 {
+		return errors.New("the number of requests must be at least 1")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("the concurrency must be at least 1")
+	}
+	if c.Interval < 0 {
+		return errors.New("the interval must not be negative")
+	}
+	return nil
+}
+
+// LoadResult is the outcome of the requests sent by RunLoad.
+type LoadResult struct {
+	Requests int
+	// Errors counts the failed requests by error message.
+	Errors map[string]int
+	// Latencies are the durations of the successful requests, shortest first.
+	Latencies []time.Duration
+	Duration  time.Duration
+}
+
+// LoadSummary is the latency and error summary of a LoadResult.
+type LoadSummary struct {
+	Requests  int    `csv:"REQUESTS" json:"requests" yaml:"requests"`
+	Errors    int    `csv:"ERRORS" json:"errors" yaml:"errors"`
+	Duration  string `csv:"DURATION" json:"duration" yaml:"duration"`
+	PerSecond string `csv:"REQ/S" json:"requestsPerSecond" yaml:"requestsPerSecond"`
+	Min       string `csv:"MIN" json:"min" yaml:"min"`
+	P50       string `csv:"P50" json:"p50" yaml:"p50"`
+	P90       string `csv:"P90" json:"p90" yaml:"p90"`
+	P99       string `csv:"P99" json:"p99" yaml:"p99"`
+	Max       string `csv:"MAX" json:"max" yaml:"max"`
+}
+
+// RunLoad calls request config.Repeat times, with up to config.Concurrency calls at the same time, and returns the
+// latencies and the errors of the calls. It stops starting new calls once ctx is done.
+func RunLoad(ctx context.Context, config LoadConfig, request func(ctx context.Context) error) LoadResult {
+	result := LoadResult{Errors: map[string]int{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan struct{})
+
+	for i := 0; i < config.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				start := time.Now()
+				err := request(ctx)
+				latency := time.Since(start)
+
+				mu.Lock()
+				result.Requests++
+				if err != nil {
+					result.Errors[err.Error()]++
+				} else {
+					result.Latencies = append(result.Latencies, latency)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	start := time.Now()
+	var ticker *time.Ticker
+	if config.Interval > 0 {
+		ticker = time.NewTicker(config.Interval)
+		defer ticker.Stop()
+	}
+send:
+	for i := 0; i < config.Repeat; i++ {
+		if i > 0 && ticker != nil {
+			select {
+			case <-ctx.Done():
+				break send
+			case <-ticker.C:
+			}
+		}
+		select {
+		case <-ctx.Done():
+			break send
+		case jobs <- struct{}{}:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	result.Duration = time.Since(start)
+
+	sort.Slice(result.Latencies, func(i, j int) bool {
+		return result.Latencies[i] < result.Latencies[j]
+	})
+	return result
+}
+
+// ErrorCount returns the number of failed requests.
+func (r LoadResult) ErrorCount() int {
+	count := 0
+	for _, n := range r.Errors {
+		count += n
+	}
+	return count
+}
+
+// Percentile returns the latency that p percent of the successful requests were at most as long as, or 0 if no
+// request succeeded.
+func (r LoadResult) Percentile(p float64) time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	// The nearest rank: the smallest latency with at least p percent of the latencies at or below it.
+	rank := int(math.Ceil(p / 100 * float64(len(r.Latencies))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(r.Latencies) {
+		rank = len(r.Latencies)
+	}
+	return r.Latencies[rank-1]
+}
+
+// Summary returns the number of requests and errors, the rate and the latency percentiles of r.
+// The latencies are "-" if no request succeeded.
+func (r LoadResult) Summary() LoadSummary {
+	s := LoadSummary{
+		Requests:  r.Requests,
+		Errors:    r.ErrorCount(),
+		Duration:  r.Duration.Round(time.Millisecond).String(),
+		PerSecond: "-",
+		Min:       "-",
+		P50:       "-",
+		P90:       "-",
+		P99:       "-",
+		Max:       "-",
+	}
+	if r.Duration > 0 {
+		s.PerSecond = fmt.Sprintf("%.1f", float64(r.Requests)/r.Duration.Seconds())
+	}
+	if len(r.Latencies) > 0 {
+		s.Min = formatLatency(r.Latencies[0])
+		s.P50 = formatLatency(r.Percentile(50))
+		s.P90 = formatLatency(r.Percentile(90))
+		s.P99 = formatLatency(r.Percentile(99))
+		s.Max = formatLatency(r.Latencies[len(r.Latencies)-1])
+	}
+	return s
+}
+
+// formatLatency rounds a latency to a precision that is readable in a table.
+func formatLatency(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
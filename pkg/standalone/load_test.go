@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunLoad(t *testing.T) {
+	t.Run("concurrency and errors", func(t *testing.T) {
+		var calls, inFlight, maxInFlight int32
+		result := RunLoad(context.Background(), LoadConfig{Repeat: 20, Concurrency: 4}, func(ctx context.Context) error {
+			n := atomic.AddInt32(&calls, 1)
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			if n%5 == 0 {
+				return errors.New("500 Internal Server Error")
+			}
+			return nil
+		})
+
+		assert.Equal(t, int32(20), calls)
+		assert.LessOrEqual(t, maxInFlight, int32(4))
+		assert.Equal(t, 20, result.Requests)
+		assert.Equal(t, map[string]int{"500 Internal Server Error": 4}, result.Errors)
+		assert.Equal(t, 4, result.ErrorCount())
+		assert.Len(t, result.Latencies, 16)
+		assert.True(t, result.Latencies[0] <= result.Latencies[15], "latencies are sorted")
+	})
+
+	t.Run("interval", func(t *testing.T) {
+		result := RunLoad(context.Background(), LoadConfig{Repeat: 3, Concurrency: 3, Interval: 20 * time.Millisecond}, func(ctx context.Context) error {
+			return nil
+		})
+		assert.Equal(t, 3, result.Requests)
+		assert.GreaterOrEqual(t, result.Duration, 40*time.Millisecond)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls int32
+		result := RunLoad(ctx, LoadConfig{Repeat: 100, Concurrency: 1}, func(ctx context.Context) error {
+			if atomic.AddInt32(&calls, 1) == 3 {
+				cancel()
+			}
+			return nil
+		})
+		assert.Less(t, result.Requests, 100)
+	})
+}
+
+func TestLoadResultSummary(t *testing.T) {
+	result := LoadResult{Requests: 11, Errors: map[string]int{"timeout": 1}, Duration: 2 * time.Second}
+	for i := 1; i <= 10; i++ {
+		result.Latencies = append(result.Latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	assert.Equal(t, 5*time.Millisecond, result.Percentile(50))
+	assert.Equal(t, 9*time.Millisecond, result.Percentile(90))
+	assert.Equal(t, 10*time.Millisecond, result.Percentile(99))
+	assert.Equal(t, time.Millisecond, result.Percentile(0))
+	assert.Equal(t, LoadSummary{
+		Requests:  11,
+		Errors:    1,
+		Duration:  "2s",
+		PerSecond: "5.5",
+		Min:       "1ms",
+		P50:       "5ms",
+		P90:       "9ms",
+		P99:       "10ms",
+		Max:       "10ms",
+	}, result.Summary())
+
+	empty := LoadResult{Requests: 2, Errors: map[string]int{"refused": 2}}
+	assert.Equal(t, time.Duration(0), empty.Percentile(50))
+	assert.Equal(t, "-", empty.Summary().P50)
+	assert.Equal(t, "-", empty.Summary().PerSecond)
+}
+
+func TestLoadConfigValidate(t *testing.T) {
+	assert.NoError(t, LoadConfig{Repeat: 1, Concurrency: 1}.Validate())
+	assert.Error(t, LoadConfig{Repeat: 0, Concurrency: 1}.Validate())
+	assert.Error(t, LoadConfig{Repeat: 1, Concurrency: 0}.Validate())
+	assert.Error(t, LoadConfig{Repeat: 1, Concurrency: 1, Interval: -time.Second}.Validate())
+}
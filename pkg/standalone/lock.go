@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// lockAPIVersion is the version of the Dapr API that provides distributed locks.
+const lockAPIVersion = "1.0-alpha1"
+
+// unlockStatuses are the results of releasing a lock, by the status code of the unlock API.
+var unlockStatuses = map[int]string{
+	0: "success",
+	1: "lock does not exist",
+	2: "lock belongs to another owner",
+	3: "internal error",
+}
+
+// LockOutput is the result of acquiring or releasing a lock.
+type LockOutput struct {
+	Store      string `csv:"STORE"    json:"store"      yaml:"store"`
+	ResourceID string `csv:"RESOURCE" json:"resourceId" yaml:"resourceId"`
+	Owner      string `csv:"OWNER"    json:"owner"      yaml:"owner"`
+	Success    bool   `csv:"SUCCESS"  json:"success"    yaml:"success"`
+	// Status is the reason a lock could not be released.
+	Status string `csv:"STATUS" json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// TryLock tries to acquire the lock of resourceID in the lock store storeName for owner, expiring after
+// expiryInSeconds, through the sidecar of appID. The output is not successful if another owner holds the lock.
+func (s *Standalone) TryLock(appID, storeName, resourceID, owner string, expiryInSeconds int, socket string) (LockOutput, error) {
+	output := LockOutput{Store: storeName, ResourceID: resourceID, Owner: owner}
+	if storeName == "" || resourceID == "" || owner == "" {
+		return output, errors.New("the lock store, the resource ID and the owner are required")
+	}
+	if expiryInSeconds <= 0 {
+		return output, errors.New("the expiry of the lock must be a positive number of seconds")
+	}
+
+	var resp struct {
+		Success bool `json:"success"`
+	}
+	err := s.postLock(appID, "lock", storeName, map[string]interface{}{
+		"resourceId":      resourceID,
+		"lockOwner":       owner,
+		"expiryInSeconds": expiryInSeconds,
+	}, socket, &resp)
+	output.Success = resp.Success
+	return output, err
+}
+
+// Unlock releases the lock of resourceID in the lock store storeName held by owner, through the sidecar of appID.
+// If the lock is not released, the status of the output tells why.
+func (s *Standalone) Unlock(appID, storeName, resourceID, owner, socket string) (LockOutput, error) {
+	output := LockOutput{Store: storeName, ResourceID: resourceID, Owner: owner}
+	if storeName == "" || resourceID == "" || owner == "" {
+		return output, errors.New("the lock store, the resource ID and the owner are required")
+	}
+
+	var resp struct {
+		Status int `json:"status"`
+	}
+	err := s.postLock(appID, "unlock", storeName, map[string]interface{}{
+		"resourceId": resourceID,
+		"lockOwner":  owner,
+	}, socket, &resp)
+	if err != nil {
+		return output, err
+	}
+	output.Success = resp.Status == 0
+	if !output.Success {
+		output.Status = unlockStatuses[resp.Status]
+		if output.Status == "" {
+			output.Status = fmt.Sprintf("unknown status %d", resp.Status)
+		}
+	}
+	return output, nil
+}
+
+// postLock posts req to the lock API operation of storeName and decodes the response into resp.
+func (s *Standalone) postLock(appID, operation, storeName string, req map[string]interface{}, socket string, resp interface{}) error {
+	endpoint, httpc, err := s.sidecarEndpoint(appID, socket, fmt.Sprintf("v%s/%s/%s", lockAPIVersion, operation, url.PathEscape(storeName)))
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	r, err := postWithHeaders(context.Background(), httpc, endpoint, "application/json", b, nil)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	body, err := readStateResponse(r)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(body, resp); err != nil {
+		return fmt.Errorf("error parsing the %s response: %w", operation, err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lockTestStore is an in-memory lock store served with the lock API of a sidecar. Locks do not expire.
+type lockTestStore struct {
+	lock   sync.Mutex
+	owners map[string]string
+}
+
+func (s *lockTestStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var req struct {
+		ResourceID      string `json:"resourceId"`
+		LockOwner       string `json:"lockOwner"`
+		ExpiryInSeconds int    `json:"expiryInSeconds"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+	switch r.URL.Path {
+	case "/v1.0-alpha1/lock/lockstore":
+		owner, locked := s.owners[req.ResourceID]
+		if !locked {
+			s.owners[req.ResourceID] = req.LockOwner
+		}
+		json.NewEncoder(w).Encode(map[string]bool{"success": !locked || owner == req.LockOwner})
+	case "/v1.0-alpha1/unlock/lockstore":
+		owner, locked := s.owners[req.ResourceID]
+		status := 0
+		switch {
+		case !locked:
+			status = 1
+		case owner != req.LockOwner:
+			status = 2
+		default:
+			delete(s.owners, req.ResourceID)
+		}
+		json.NewEncoder(w).Encode(map[string]int{"status": status})
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errorCode":"ERR_LOCK_STORE_NOT_FOUND","message":"lock store is not found"}`))
+	}
+}
+
+func TestLock(t *testing.T) {
+	ts, port := getTestServerFunc(&lockTestStore{owners: map[string]string{}})
+	ts.Start()
+	defer ts.Close()
+
+	client := &Standalone{
+		process: &mockDaprProcess{
+			Lo: []ListOutput{{AppID: "testapp", HTTPPort: port}},
+		},
+	}
+
+	t.Run("acquire", func(t *testing.T) {
+		output, err := client.TryLock("", "lockstore", "order1", "worker1", 60, "")
+		require.NoError(t, err)
+		assert.Equal(t, LockOutput{Store: "lockstore", ResourceID: "order1", Owner: "worker1", Success: true}, output)
+
+		output, err = client.TryLock("", "lockstore", "order1", "worker2", 60, "")
+		require.NoError(t, err)
+		assert.False(t, output.Success, "the lock is held by another owner")
+	})
+
+	t.Run("release", func(t *testing.T) {
+		output, err := client.Unlock("", "lockstore", "order1", "worker2", "")
+		require.NoError(t, err)
+		assert.False(t, output.Success)
+		assert.Equal(t, "lock belongs to another owner", output.Status)
+
+		output, err = client.Unlock("", "lockstore", "order1", "worker1", "")
+		require.NoError(t, err)
+		assert.Equal(t, LockOutput{Store: "lockstore", ResourceID: "order1", Owner: "worker1", Success: true}, output)
+
+		output, err = client.Unlock("", "lockstore", "order1", "worker1", "")
+		require.NoError(t, err)
+		assert.Equal(t, "lock does not exist", output.Status)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		_, err := client.TryLock("", "lockstore", "order1", "", 60, "")
+		assert.Error(t, err)
+		_, err = client.TryLock("", "lockstore", "order1", "worker1", 0, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown lock store", func(t *testing.T) {
+		_, err := client.TryLock("", "other", "order1", "worker1", 60, "")
+		assert.ErrorContains(t, err, "lock store is not found")
+	})
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+)
+
+const (
+	// AppLogSource is the source of the lines written by an app.
+	AppLogSource = "app"
+	// DaprLogSource is the source of the lines written by a sidecar.
+	DaprLogSource = "daprd"
+)
+
+// logColors are the colors given to the apps in the order they start. The lines of an app are printed in bold,
+// the lines of its sidecar in the plain color.
+var logColors = []color.Attribute{color.FgHiBlue, color.FgHiGreen, color.FgHiMagenta, color.FgHiCyan, color.FgHiYellow}
+
+// LogMultiplexerConfig represents the options of a LogMultiplexer.
+type LogMultiplexerConfig struct {
+	// Out receives the lines that are not filtered.
+	Out io.Writer
+	// LogFile is the path of a file that receives all lines, without colors. It is truncated when opened.
+	LogFile string
+	// Filter holds the sources whose lines are not written to Out: app, daprd, an app ID, or <app ID>/app and
+	// <app ID>/daprd for a single process.
+	Filter []string
+	// MultiApp adds the app ID to the prefix of the lines, to tell apart the processes of several apps.
+	MultiApp bool
+}
+
+// LogMultiplexer combines the output of apps and their sidecars into one stream, with every line prefixed
+// with its source and colored per app.
+type LogMultiplexer struct {
+	mu       sync.Mutex
+	out      io.Writer
+	file     *os.File
+	filter   map[string]bool
+	multiApp bool
+	colors   map[string]int
+}
+
+// NewLogMultiplexer returns a LogMultiplexer for the given options.
+func NewLogMultiplexer(config LogMultiplexerConfig) (*LogMultiplexer, error) {
+	m := &LogMultiplexer{
+		out:      config.Out,
+		filter:   map[string]bool{},
+		multiApp: config.MultiApp,
+		colors:   map[string]int{},
+	}
+	for _, f := range config.Filter {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			return nil, fmt.Errorf("invalid log filter %q. Expected app, daprd, an app ID, <app ID>/app or <app ID>/daprd", f)
+		}
+		m.filter[f] = true
+	}
+	if config.LogFile != "" {
+		file, err := os.OpenFile(config.LogFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("error opening log file %s: %w", config.LogFile, err)
+		}
+		m.file = file
+	}
+	return m, nil
+}
+
+// Writer returns a writer that writes every line written to it as a line of the given source of the app.
+func (m *LogMultiplexer) Writer(appID, source string) LineWriter {
+	m.mu.Lock()
+	if _, ok := m.colors[appID]; !ok {
+		m.colors[appID] = len(m.colors) % len(logColors)
+	}
+	m.mu.Unlock()
+
+	return &lineWriter{writeLine: func(line []byte) {
+		m.writeLine(appID, source, line)
+	}}
+}
+
+// Close closes the log file.
+func (m *LogMultiplexer) Close() error {
+	if m.file == nil {
+		return nil
+	}
+	return m.file.Close()
+}
+
+// Prefix returns the prefix of the lines of the given source of the app.
+func (m *LogMultiplexer) Prefix(appID, source string) string {
+	name := "APP"
+	if source == DaprLogSource {
+		name = "DAPR"
+	}
+	if m.multiApp {
+		return fmt.Sprintf("== %s - %s == ", name, appID)
+	}
+	return fmt.Sprintf("== %s == ", name)
+}
+
+func (m *LogMultiplexer) writeLine(appID, source string, line []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	text := m.Prefix(appID, source) + string(line)
+	if m.file != nil {
+		// Logging must never fail the app, so write errors are ignored.
+		_, _ = m.file.WriteString(text + "\n")
+	}
+	if m.filtered(appID, source) {
+		return
+	}
+	attrs := []color.Attribute{logColors[m.colors[appID]]}
+	if source == AppLogSource {
+		attrs = append(attrs, color.Bold)
+	}
+	fmt.Fprintln(m.out, color.New(attrs...).Sprint(text))
+}
+
+func (m *LogMultiplexer) filtered(appID, source string) bool {
+	return m.filter[source] || m.filter[appID] || m.filter[appID+"/"+source]
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogMultiplexer(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	t.Cleanup(func() {
+		color.NoColor = noColor
+	})
+
+	t.Run("prefix lines of a single app", func(t *testing.T) {
+		var out bytes.Buffer
+		m, err := NewLogMultiplexer(LogMultiplexerConfig{Out: &out})
+		require.NoError(t, err)
+
+		daprOut := m.Writer("myapp", DaprLogSource)
+		fmt.Fprint(daprOut, "starting\r\nrunning")
+		fmt.Fprint(m.Writer("myapp", AppLogSource), "hello\n")
+		assert.Equal(t, "== DAPR == starting\n== APP == hello\n", out.String())
+
+		daprOut.Flush()
+		assert.Equal(t, "== DAPR == starting\n== APP == hello\n== DAPR == running\n", out.String())
+		daprOut.Flush()
+		assert.Equal(t, "== DAPR == starting\n== APP == hello\n== DAPR == running\n", out.String(), "nothing is left to flush")
+		assert.NoError(t, m.Close())
+	})
+
+	t.Run("filter sources and write all lines to the log file", func(t *testing.T) {
+		var out bytes.Buffer
+		logFile := filepath.Join(t.TempDir(), "run.log")
+		m, err := NewLogMultiplexer(LogMultiplexerConfig{
+			Out:      &out,
+			LogFile:  logFile,
+			Filter:   []string{"daprd", "orders/app"},
+			MultiApp: true,
+		})
+		require.NoError(t, err)
+
+		fmt.Fprintln(m.Writer("orders", DaprLogSource), "sidecar of orders")
+		fmt.Fprintln(m.Writer("orders", AppLogSource), "orders")
+		fmt.Fprintln(m.Writer("checkout", DaprLogSource), "sidecar of checkout")
+		fmt.Fprintln(m.Writer("checkout", AppLogSource), "checkout")
+		require.NoError(t, m.Close())
+
+		assert.Equal(t, "== APP - checkout == checkout\n", out.String())
+		b, err := os.ReadFile(logFile)
+		require.NoError(t, err)
+		assert.Equal(t, "== DAPR - orders == sidecar of orders\n"+
+			"== APP - orders == orders\n"+
+			"== DAPR - checkout == sidecar of checkout\n"+
+			"== APP - checkout == checkout\n", string(b))
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		_, err := NewLogMultiplexer(LogMultiplexerConfig{Out: &bytes.Buffer{}, Filter: []string{" "}})
+		assert.ErrorContains(t, err, "invalid log filter")
+	})
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	path_filepath "path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// DaprLogPrefix is the prefix of the sidecar lines in an app log file.
+	DaprLogPrefix = "== DAPR == "
+	// AppLogPrefix is the prefix of the app lines in an app log file.
+	AppLogPrefix = "== APP == "
+
+	defaultLogsDirName = "logs"
+	logFileExt         = ".log"
+	// maxLogFileSize is the size after which a log file is rotated when an app starts.
+	maxLogFileSize  = 10 * 1024 * 1024
+	logPollInterval = 250 * time.Millisecond
+)
+
+// AppLog stores the output of an app and its sidecar started by `dapr run`.
+// All methods can be called on a nil *AppLog, which discards the output.
+type AppLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// DefaultLogsDirPath returns the directory the logs of self-hosted apps are stored in.
+func DefaultLogsDirPath() string {
+	return path_filepath.Join(defaultDaprDirPath(), defaultLogsDirName)
+}
+
+// AppLogFilePath returns the path of the log file of the app with the given ID in namespace. The logs of apps in
+// a namespace are stored in a directory of the namespace, so that apps with the same ID in two namespaces do not
+// share a log file.
+func AppLogFilePath(namespace, appID string) string {
+	return path_filepath.Join(DefaultLogsDirPath(), namespace, appID+logFileExt)
+}
+
+// OpenAppLog opens the log file of the app with the given ID in namespace for appending.
+// The previous file is kept with a ".1" suffix once it has grown too large.
+func OpenAppLog(namespace, appID string) (*AppLog, error) {
+	filePath := AppLogFilePath(namespace, appID)
+	err := os.MkdirAll(path_filepath.Dir(filePath), 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating logs directory: %w", err)
+	}
+
+	if info, statErr := os.Stat(filePath); statErr == nil && info.Size() > maxLogFileSize {
+		err = os.Rename(filePath, filePath+".1")
+		if err != nil {
+			return nil, fmt.Errorf("error rotating log file %s: %w", filePath, err)
+		}
+	}
+
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening log file %s: %w", filePath, err)
+	}
+	return &AppLog{file: file}, nil
+}
+
+// Writer returns a writer that stores every line written to it with the given prefix.
+func (l *AppLog) Writer(prefix string) LineWriter {
+	if l == nil {
+		return &lineWriter{writeLine: func([]byte) {}}
+	}
+	return &lineWriter{writeLine: func(line []byte) {
+		l.writeLine(prefix, line)
+	}}
+}
+
+// Close closes the log file.
+func (l *AppLog) Close() error {
+	if l == nil {
+		return nil
+	}
+	return l.file.Close()
+}
+
+func (l *AppLog) writeLine(prefix string, line []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Logging must never fail the app, so write errors are ignored.
+	_, _ = l.file.WriteString(prefix + string(line) + "\n")
+}
+
+// LineWriter is a writer that passes on the lines written to it. A trailing line without a line ending is kept
+// until more output completes it, or until Flush is called once the process writing the output has exited.
+type LineWriter interface {
+	io.Writer
+	Flush()
+}
+
+// lineWriter splits the output written to it into lines, which are passed to writeLine without the line ending.
+type lineWriter struct {
+	mu        sync.Mutex
+	writeLine func(line []byte)
+	buf       []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.writeLine(bytes.TrimSuffix(w.buf[:i], []byte("\r")))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush passes on the trailing line without a line ending, if any.
+func (w *lineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.writeLine(bytes.TrimSuffix(w.buf, []byte("\r")))
+		w.buf = nil
+	}
+}
+
+// Logs writes the stored logs of the app with the given ID in namespace to w.
+// Only the last tail lines are written if tail is not negative. If follow is true,
+// new lines are written as they are added until ctx is done.
+func Logs(ctx context.Context, w io.Writer, namespace, appID string, tail int, follow bool) error {
+	filePath := AppLogFilePath(namespace, appID)
+	file, err := os.Open(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		if namespace != "" {
+			return fmt.Errorf("no logs found for app %s in namespace %s. Logs are stored for apps started with dapr run", appID, namespace)
+		}
+		return fmt.Errorf("no logs found for app %s. Logs are stored for apps started with dapr run", appID)
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		file.Close()
+	}()
+
+	err = writeTail(w, file, tail)
+	if err != nil || !follow {
+		return err
+	}
+
+	ticker := time.NewTicker(logPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		// Start over from the beginning when the log file was rotated or truncated by a new run.
+		info, statErr := os.Stat(filePath)
+		current, currentErr := file.Stat()
+		offset, seekErr := file.Seek(0, io.SeekCurrent)
+		if statErr == nil && currentErr == nil && seekErr == nil && (!os.SameFile(info, current) || info.Size() < offset) {
+			reopened, openErr := os.Open(filePath)
+			if openErr == nil {
+				file.Close()
+				file = reopened
+			}
+		}
+
+		_, err = io.Copy(w, file)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// writeTail writes the last tail lines of r to w, or all of them if tail is negative.
+func writeTail(w io.Writer, r io.Reader, tail int) error {
+	if tail < 0 {
+		_, err := io.Copy(w, r)
+		return err
+	}
+
+	lines := make([]string, 0, tail)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogFileSize)
+	for scanner.Scan() {
+		if tail == 0 {
+			continue
+		}
+		if len(lines) == tail {
+			lines = lines[1:]
+		}
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// syncBuffer is a bytes.Buffer that can be read while it is written to.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestAppLogs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+
+	t.Run("no logs", func(t *testing.T) {
+		err := Logs(context.Background(), &bytes.Buffer{}, "", "unknown", -1, false)
+		assert.EqualError(t, err, "no logs found for app unknown. Logs are stored for apps started with dapr run")
+	})
+
+	appLog, err := OpenAppLog("", "myapp")
+	assert.NoError(t, err)
+	daprLog := appLog.Writer(DaprLogPrefix)
+	fmt.Fprint(daprLog, "time=1 msg=\"starting\"\ntime=2 msg=")
+	fmt.Fprint(daprLog, "\"started\"\r\n")
+	fmt.Fprintln(appLog.Writer(AppLogPrefix), "hello")
+
+	t.Run("all lines", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, Logs(context.Background(), &out, "", "myapp", -1, false))
+		assert.Equal(t, "== DAPR == time=1 msg=\"starting\"\n== DAPR == time=2 msg=\"started\"\n== APP == hello\n", out.String())
+	})
+
+	t.Run("tail", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.NoError(t, Logs(context.Background(), &out, "", "myapp", 2, false))
+		assert.Equal(t, "== DAPR == time=2 msg=\"started\"\n== APP == hello\n", out.String())
+
+		out.Reset()
+		assert.NoError(t, Logs(context.Background(), &out, "", "myapp", 0, false))
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("follow", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		out := &syncBuffer{}
+		done := make(chan error)
+		go func() {
+			done <- Logs(ctx, out, "", "myapp", 1, true)
+		}()
+
+		assert.Eventually(t, func() bool { return out.String() == "== APP == hello\n" }, time.Second, 10*time.Millisecond)
+		fmt.Fprintln(appLog.Writer(AppLogPrefix), "world")
+		assert.Eventually(t, func() bool { return out.String() == "== APP == hello\n== APP == world\n" }, 2*time.Second, 10*time.Millisecond)
+
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	assert.NoError(t, appLog.Close())
+
+	t.Run("namespace", func(t *testing.T) {
+		shopLog, err := OpenAppLog("shop", "myapp")
+		assert.NoError(t, err)
+		fmt.Fprintln(shopLog.Writer(AppLogPrefix), "shop")
+		assert.NoError(t, shopLog.Close())
+		assert.NotEqual(t, AppLogFilePath("", "myapp"), AppLogFilePath("shop", "myapp"))
+
+		var out bytes.Buffer
+		assert.NoError(t, Logs(context.Background(), &out, "shop", "myapp", -1, false))
+		assert.Equal(t, "== APP == shop\n", out.String(), "apps with the same ID in two namespaces do not share a log file")
+		assert.EqualError(t, Logs(context.Background(), &out, "dev", "myapp", -1, false), "no logs found for app myapp in namespace dev. Logs are stored for apps started with dapr run")
+	})
+
+	t.Run("nil log discards output", func(t *testing.T) {
+		var nilLog *AppLog
+		_, err := fmt.Fprintln(nilLog.Writer(AppLogPrefix), "discarded")
+		assert.NoError(t, err)
+		assert.NoError(t, nilLog.Close())
+	})
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+)
+
+// NamespaceMetadataKey is the key of the namespace of an app in the metadata of its sidecar.
+const NamespaceMetadataKey = "namespace"
+
+// ValidateNamespace returns an error if namespace is not a valid namespace name. An empty namespace is valid.
+func ValidateNamespace(namespace string) error {
+	if namespace == "" {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace %q: %s", namespace, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// FilterNamespace returns the apps running in namespace, or all apps if namespace is empty.
+func FilterNamespace(apps []ListOutput, namespace string) []ListOutput {
+	if namespace == "" {
+		return apps
+	}
+	filtered := []ListOutput{}
+	for _, a := range apps {
+		if a.Namespace == namespace {
+			filtered = append(filtered, a)
+		}
+	}
+	return filtered
+}
+
+// namespaceProcess lists the sidecars run by the CLI in a namespace.
+type namespaceProcess struct {
+	namespace string
+}
+
+func (p *namespaceProcess) List() ([]ListOutput, error) {
+	apps, err := List()
+	if err != nil {
+		return nil, err
+	}
+	return FilterNamespace(apps, p.namespace), nil
+}
+
+// NewNamespaceClient returns a client of the sidecars run by the CLI in namespace, so that an app ID that is also
+// used in another namespace resolves to the app of namespace.
+func NewNamespaceClient(namespace string) Client {
+	return &Standalone{process: &namespaceProcess{namespace: namespace}}
+}
+
+// sidecarEnv returns the additional environment variables of the sidecar. The sidecar of an app in a namespace
+// only loads the components of that namespace, and reports it to the placement service and the scheduler.
+func (config *RunConfig) sidecarEnv() []string {
+	env := config.extraEnv()
+	if config.Namespace != "" {
+		env = append(env, "NAMESPACE="+config.Namespace)
+	}
+	return env
+}
+
+// componentsOutsideNamespace returns the names of the components that the sidecar of an app in namespace does
+// not load, as they are not in the namespace.
+func componentsOutsideNamespace(components []v1alpha1.Component, namespace string) []string {
+	names := []string{}
+	if namespace == "" {
+		return names
+	}
+	for _, c := range components {
+		if c.Namespace != namespace {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
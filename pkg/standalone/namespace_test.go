@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+)
+
+func TestValidateNamespace(t *testing.T) {
+	assert.NoError(t, ValidateNamespace(""))
+	assert.NoError(t, ValidateNamespace("shop"))
+	assert.Error(t, ValidateNamespace("Shop"))
+	assert.Error(t, ValidateNamespace("shop/orders"))
+}
+
+func TestFilterNamespace(t *testing.T) {
+	apps := []ListOutput{{AppID: "orders", Namespace: "shop"}, {AppID: "billing"}, {AppID: "cart", Namespace: "shop"}}
+	assert.Equal(t, apps, FilterNamespace(apps, ""))
+	assert.Equal(t, []ListOutput{apps[0], apps[2]}, FilterNamespace(apps, "shop"))
+	assert.Empty(t, FilterNamespace(apps, "blog"))
+}
+
+func TestNamespaceEnv(t *testing.T) {
+	config := &RunConfig{AppID: "orders", Env: map[string]string{"LOG_LEVEL": "debug"}}
+	assert.Equal(t, []string{"LOG_LEVEL=debug"}, config.sidecarEnv())
+	assert.NotContains(t, config.Environment(), "NAMESPACE=", "an unset namespace is not set for the app")
+
+	config.Namespace = "shop"
+	assert.Equal(t, []string{"LOG_LEVEL=debug", "NAMESPACE=shop"}, config.sidecarEnv())
+	assert.Contains(t, config.Environment(), "NAMESPACE=shop")
+}
+
+func TestComponentsOutsideNamespace(t *testing.T) {
+	components := []v1alpha1.Component{
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "statestore", Namespace: "shop"}},
+		{ObjectMeta: meta_v1.ObjectMeta{Name: "pubsub"}},
+	}
+	assert.Empty(t, componentsOutsideNamespace(components, ""))
+	assert.Equal(t, []string{"pubsub"}, componentsOutsideNamespace(components, "shop"))
+}
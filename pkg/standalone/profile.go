@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// CLIConfig represents the configuration file of the CLI.
+type CLIConfig struct {
+	Profiles map[string]RunProfile `yaml:"profiles,omitempty"`
+	// ResourcesPath and ConfigFile replace the default resources directory and Dapr configuration file.
+	// They are set by dapr init --resources-path and --config-file.
+	ResourcesPath string `yaml:"resourcesPath,omitempty"`
+	ConfigFile    string `yaml:"configFile,omitempty"`
+}
+
+// RunProfile is a named set of `dapr run` flags, such as ports, log level and components path,
+// and of environment variables for the app.
+type RunProfile struct {
+	// Flags maps the names of `dapr run` flags to a value, or to a list of values for repeatable flags.
+	Flags map[string]interface{} `yaml:",inline"`
+	// Env holds additional environment variables of the app and its sidecar.
+	Env map[string]string `yaml:"env,omitempty"`
+}
+
+// LoadRunProfile returns the run profile with the given name from the CLI configuration file at path.
+func LoadRunProfile(path, name string) (*RunProfile, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading run profiles: %w", err)
+	}
+	var config CLIConfig
+	if err = yaml.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("error parsing run profiles in %s: %w", path, err)
+	}
+
+	profile, ok := config.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(config.Profiles))
+		for n := range config.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("run profile %q not found in %s. Available profiles: %s", name, path, strings.Join(names, ", "))
+	}
+	return &profile, nil
+}
+
+// FlagValues returns the values of the flags of the profile as strings, in the format they are given on the command line.
+func (p *RunProfile) FlagValues() (map[string][]string, error) {
+	values := make(map[string][]string, len(p.Flags))
+	for name, value := range p.Flags {
+		switch v := value.(type) {
+		case []interface{}:
+			for _, item := range v {
+				s, err := profileScalar(name, item)
+				if err != nil {
+					return nil, err
+				}
+				values[name] = append(values[name], s)
+			}
+		default:
+			s, err := profileScalar(name, v)
+			if err != nil {
+				return nil, err
+			}
+			values[name] = []string{s}
+		}
+	}
+	return values, nil
+}
+
+func profileScalar(name string, value interface{}) (string, error) {
+	switch value.(type) {
+	case string, bool, int, float64:
+		return fmt.Sprint(value), nil
+	default:
+		return "", fmt.Errorf("invalid value for flag %s in run profile: %v", name, value)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testCLIConfig = `profiles:
+  debug:
+    log-level: debug
+    enable-api-logging: true
+    dapr-http-port: 3500
+    components-path: ./components
+    wait-for:
+    - tcp://localhost:5432
+    - app://orders
+    env:
+      DEBUG: "true"
+  perf:
+    enable-profiling: true
+`
+
+func TestLoadRunProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	assert.NoError(t, os.WriteFile(path, []byte(testCLIConfig), 0o600))
+
+	t.Run("profile flags and env", func(t *testing.T) {
+		profile, err := LoadRunProfile(path, "debug")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"DEBUG": "true"}, profile.Env)
+
+		values, err := profile.FlagValues()
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]string{
+			"log-level":          {"debug"},
+			"enable-api-logging": {"true"},
+			"dapr-http-port":     {"3500"},
+			"components-path":    {"./components"},
+			"wait-for":           {"tcp://localhost:5432", "app://orders"},
+		}, values)
+	})
+
+	t.Run("profile not found", func(t *testing.T) {
+		_, err := LoadRunProfile(path, "prod")
+		assert.EqualError(t, err, `run profile "prod" not found in `+path+`. Available profiles: debug, perf`)
+	})
+
+	t.Run("missing config file", func(t *testing.T) {
+		_, err := LoadRunProfile(filepath.Join(t.TempDir(), "config"), "debug")
+		assert.ErrorContains(t, err, "error reading run profiles")
+	})
+
+	t.Run("invalid flag value", func(t *testing.T) {
+		profile := RunProfile{Flags: map[string]interface{}{"log-level": map[interface{}]interface{}{"a": "b"}}}
+		_, err := profile.FlagValues()
+		assert.EqualError(t, err, "invalid value for flag log-level in run profile: map[a:b]")
+	})
+}
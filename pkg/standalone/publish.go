@@ -19,15 +19,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net"
+	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/dapr/cli/pkg/api"
-	"github.com/dapr/cli/utils"
+	"github.com/dapr/cli/pkg/print"
 )
 
+// bulkPublishAPIVersion is the version of the Dapr API that provides bulk publishing.
+const bulkPublishAPIVersion = "1.0-alpha1"
+
 // Publish publishes payload to topic in pubsub referenced by pubsubName.
-func (s *Standalone) Publish(publishAppID, pubsubName, topic string, payload []byte, socket string, metadata map[string]interface{}) error {
+func (s *Standalone) Publish(ctx context.Context, publishAppID, pubsubName, topic string, payload []byte, headers http.Header, socket string, metadata map[string]interface{}) error {
 	if publishAppID == "" {
 		return errors.New("publishAppID is missing")
 	}
@@ -42,55 +46,139 @@ func (s *Standalone) Publish(publishAppID, pubsubName, topic string, payload []b
 
 	queryParams := getQueryParams(metadata)
 
-	l, err := s.process.List()
+	url, httpc, err := s.sidecarEndpoint(publishAppID, socket, fmt.Sprintf("v%s/publish/%s/%s%s", api.RuntimeAPIVersion, pubsubName, topic, queryParams))
 	if err != nil {
 		return err
 	}
 
-	instance, err := getDaprInstance(l, publishAppID)
+	r, err := postWithHeaders(ctx, httpc, url, publishContentType(payload), payload, headers)
 	if err != nil {
 		return err
 	}
+	defer r.Body.Close()
+	if r.StatusCode >= 300 || r.StatusCode < 200 {
+		fmt.Println(url)
+		return &statusError{statusCode: r.StatusCode, msg: fmt.Sprintf("unexpected status code %d on publishing to %s in %s", r.StatusCode, topic, pubsubName)}
+	}
+
+	return nil
+}
+
+// BulkPublishResult is the outcome of publishing events with the bulk publish API.
+type BulkPublishResult struct {
+	Total         int
+	FailedEntries []BulkPublishFailedEntry `json:"failedEntries"`
+}
+
+// BulkPublishFailedEntry is an event that could not be published. The entry ID is the index of the event.
+type BulkPublishFailedEntry struct {
+	EntryID string `json:"entryId"`
+	Error   string `json:"error"`
+}
+
+type bulkPublishEntry struct {
+	EntryID     string      `json:"entryId"`
+	Event       interface{} `json:"event"`
+	ContentType string      `json:"contentType"`
+}
+
+// BulkPublish publishes all events to topic in pubsub referenced by pubsubName in a single call using the bulk publish API.
+// Events that the sidecar fails to publish are returned in the result rather than as an error.
+func (s *Standalone) BulkPublish(ctx context.Context, publishAppID, pubsubName, topic string, events [][]byte, headers http.Header, socket string, metadata map[string]interface{}) (BulkPublishResult, error) {
+	result := BulkPublishResult{Total: len(events)}
+	if publishAppID == "" {
+		return result, errors.New("publishAppID is missing")
+	}
+
+	if pubsubName == "" {
+		return result, errors.New("pubsubName is missing")
+	}
+
+	if topic == "" {
+		return result, errors.New("topic is missing")
+	}
+
+	if len(events) == 0 {
+		return result, errors.New("no events to publish")
+	}
+
+	entries := make([]bulkPublishEntry, 0, len(events))
+	for i, event := range events {
+		entry := bulkPublishEntry{
+			EntryID:     strconv.Itoa(i),
+			ContentType: publishContentType(event),
+		}
+		if json.Valid(event) {
+			entry.Event = json.RawMessage(event)
+		} else {
+			entry.Event = string(event)
+			entry.ContentType = "text/plain"
+		}
+		entries = append(entries, entry)
+	}
+	body, err := json.Marshal(entries)
+	if err != nil {
+		return result, err
+	}
 
-	url := fmt.Sprintf("http://unix/v%s/publish/%s/%s%s", api.RuntimeAPIVersion, pubsubName, topic, queryParams)
+	queryParams := getQueryParams(metadata)
+	url, httpc, err := s.sidecarEndpoint(publishAppID, socket, fmt.Sprintf("v%s/publish/bulk/%s/%s%s", bulkPublishAPIVersion, pubsubName, topic, queryParams))
+	if err != nil {
+		return result, err
+	}
 
-	var httpc http.Client
-	if socket != "" {
-		httpc.Transport = &http.Transport{
-			DialContext: func(_ context.Context, _, _ string) (net.Conn, error) {
-				return net.Dial("unix", utils.GetSocket(socket, publishAppID, "http"))
-			},
+	r, err := postWithHeaders(ctx, httpc, url, "application/json", body, headers)
+	if err != nil {
+		return result, err
+	}
+	defer r.Body.Close()
+
+	respBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		return result, err
+	}
+	if len(bytes.TrimSpace(respBody)) > 0 {
+		// The response lists the failed entries, also when the status code indicates a failure.
+		if jsonErr := json.Unmarshal(respBody, &result); jsonErr != nil && r.StatusCode < 300 {
+			return result, fmt.Errorf("error parsing bulk publish response: %w", jsonErr)
 		}
-	} else {
-		url = fmt.Sprintf("http://localhost:%s/v%s/publish/%s/%s%s", fmt.Sprintf("%v", instance.HTTPPort), api.RuntimeAPIVersion, pubsubName, topic, queryParams)
+	}
+	if (r.StatusCode >= 300 || r.StatusCode < 200) && len(result.FailedEntries) == 0 {
+		return result, &statusError{statusCode: r.StatusCode, msg: fmt.Sprintf("unexpected status code %d on bulk publishing to %s in %s: %s", r.StatusCode, topic, pubsubName, bytes.TrimSpace(respBody))}
 	}
 
-	contentType := "application/json"
+	return result, nil
+}
 
-	// Detect publishing with CloudEvents envelope.
+// postWithHeaders posts body to url with the given content type and additional headers.
+func postWithHeaders(ctx context.Context, httpc *http.Client, url, contentType string, body []byte, headers http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	req.Header.Set("Content-Type", contentType)
+	return httpc.Do(req)
+}
+
+// publishContentType returns the content type of a CloudEvents envelope if the payload is one, or JSON otherwise.
+func publishContentType(payload []byte) string {
 	var cloudEvent map[string]interface{}
-	if json.Unmarshal(payload, &cloudEvent); err == nil {
+	if err := json.Unmarshal(payload, &cloudEvent); err == nil {
 		_, hasID := cloudEvent["id"]
 		_, hasSource := cloudEvent["source"]
 		_, hasSpecVersion := cloudEvent["specversion"]
 		_, hasType := cloudEvent["type"]
 		_, hasData := cloudEvent["data"]
 		if hasID && hasSource && hasSpecVersion && hasType && hasData {
-			contentType = "application/cloudevents+json"
+			return "application/cloudevents+json"
 		}
 	}
-
-	r, err := httpc.Post(url, contentType, bytes.NewBuffer(payload))
-	if err != nil {
-		return err
-	}
-	defer r.Body.Close()
-	if r.StatusCode >= 300 || r.StatusCode < 200 {
-		fmt.Println(url)
-		return fmt.Errorf("unexpected status code %d on publishing to %s in %s", r.StatusCode, topic, pubsubName)
-	}
-
-	return nil
+	return "application/json"
 }
 
 func getDaprInstance(list []ListOutput, publishAppID string) (ListOutput, error) {
@@ -99,7 +187,7 @@ func getDaprInstance(list []ListOutput, publishAppID string) (ListOutput, error)
 			return list[i], nil
 		}
 	}
-	return ListOutput{}, errors.New("couldn't find a running Dapr instance")
+	return ListOutput{}, print.NewCodedError(ErrCodeAppNotFound, errors.New("couldn't find a running Dapr instance"))
 }
 
 // getQueryParams returns the HTTP query parameter from the metadata map.
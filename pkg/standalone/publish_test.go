@@ -15,6 +15,8 @@ package standalone
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -173,7 +175,7 @@ func TestPublish(t *testing.T) {
 						Err: tc.listErr,
 					},
 				}
-				err := client.Publish(tc.publishAppID, tc.pubsubName, tc.topic, tc.payload, socket, nil)
+				err := client.Publish(context.Background(), tc.publishAppID, tc.pubsubName, tc.topic, tc.payload, nil, socket, nil)
 				if tc.errorExpected {
 					assert.Error(t, err, "expected an error")
 					assert.Equal(t, tc.errString, err.Error(), "expected error strings to match")
@@ -228,3 +230,103 @@ func TestGetQueryParams(t *testing.T) {
 		assert.Equal(t, len(queryParams), strings.Count(queryParams, "&"), "expected query params to not contain any unexpected entries")
 	}
 }
+
+func TestBulkPublish(t *testing.T) {
+	testCases := []struct {
+		name           string
+		events         [][]byte
+		handler        http.HandlerFunc
+		expectedFailed []BulkPublishFailedEntry
+		errString      string
+	}{
+		{
+			name:      "no events",
+			events:    [][]byte{},
+			handler:   handlerTestPathResp("", ""),
+			errString: "no events to publish",
+		},
+		{
+			name:   "all events published",
+			events: [][]byte{[]byte(`{"id":1}`), []byte("plain text")},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.RequestURI != "/v1.0-alpha1/publish/bulk/testPubsubName/testTopic" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				var entries []map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&entries)
+				if len(entries) != 2 || entries[0]["entryId"] != "0" || entries[1]["contentType"] != "text/plain" || entries[1]["event"] != "plain text" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.Write([]byte(`{"failedEntries":[]}`))
+			},
+			expectedFailed: []BulkPublishFailedEntry{},
+		},
+		{
+			name:   "some events failed",
+			events: [][]byte{[]byte(`{"id":1}`), []byte(`{"id":2}`)},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"failedEntries":[{"entryId":"1","error":"broker unavailable"}],"errorCode":"ERR_PUBSUB_PUBLISH_MESSAGE"}`))
+			},
+			expectedFailed: []BulkPublishFailedEntry{{EntryID: "1", Error: "broker unavailable"}},
+		},
+		{
+			name:   "request failed",
+			events: [][]byte{[]byte(`{"id":1}`)},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"errorCode":"ERR_PUBSUB_NOT_FOUND"}`))
+			},
+			errString: `unexpected status code 404 on bulk publishing to testTopic in testPubsubName: {"errorCode":"ERR_PUBSUB_NOT_FOUND"}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts, port := getTestServerFunc(tc.handler)
+			ts.Start()
+			defer ts.Close()
+
+			client := &Standalone{
+				process: &mockDaprProcess{
+					Lo: []ListOutput{{AppID: "myAppID", HTTPPort: port}},
+				},
+			}
+			result, err := client.BulkPublish(context.Background(), "myAppID", "testPubsubName", "testTopic", tc.events, nil, "", nil)
+			if tc.errString != "" {
+				assert.EqualError(t, err, tc.errString)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, len(tc.events), result.Total)
+			assert.Equal(t, tc.expectedFailed, result.FailedEntries)
+		})
+	}
+}
+
+func TestPublishHeaders(t *testing.T) {
+	traceparent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	ts, port := getTestServerFunc(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(TraceparentHeader) != traceparent || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"failedEntries":[]}`))
+	}))
+	ts.Start()
+	defer ts.Close()
+
+	client := &Standalone{
+		process: &mockDaprProcess{
+			Lo: []ListOutput{{AppID: "myAppID", HTTPPort: port}},
+		},
+	}
+	headers := http.Header{}
+	headers.Set(TraceparentHeader, traceparent)
+
+	err := client.Publish(context.Background(), "myAppID", "testPubsubName", "testTopic", []byte(`{"id":1}`), headers, "", nil)
+	assert.NoError(t, err)
+	_, err = client.BulkPublish(context.Background(), "myAppID", "testPubsubName", "testTopic", [][]byte{[]byte(`{"id":1}`)}, headers, "", nil)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// RestartNever is the restart policy that never restarts the app.
+	RestartNever = "no"
+	// RestartOnFailure is the restart policy that restarts the app when it exits with an error.
+	RestartOnFailure = "on-failure"
+
+	// AppRestartsMetadataKey is the key of the number of restarts of the app in the metadata of its sidecar.
+	AppRestartsMetadataKey = "appRestarts"
+
+	restartInitialBackoff = time.Second
+	restartMaxBackoff     = 30 * time.Second
+)
+
+// RestartPolicy is when the app started by `dapr run` is restarted after it exited.
+type RestartPolicy struct {
+	// OnFailure restarts the app when it exits with an error.
+	OnFailure bool
+	// MaxRestarts limits the number of restarts. There is no limit if it is zero.
+	MaxRestarts int
+}
+
+// ParseRestartPolicy parses a restart policy given as `no`, `on-failure` or `on-failure:<max restarts>`.
+func ParseRestartPolicy(policy string) (RestartPolicy, error) {
+	name, maxRestarts, hasMax := strings.Cut(policy, ":")
+	switch {
+	case name == RestartNever && !hasMax:
+		return RestartPolicy{}, nil
+	case name == RestartOnFailure && !hasMax:
+		return RestartPolicy{OnFailure: true}, nil
+	case name == RestartOnFailure:
+		n, err := strconv.Atoi(maxRestarts)
+		if err != nil || n <= 0 {
+			return RestartPolicy{}, fmt.Errorf("invalid maximum number of restarts %q: must be a positive integer", maxRestarts)
+		}
+		return RestartPolicy{OnFailure: true, MaxRestarts: n}, nil
+	default:
+		return RestartPolicy{}, fmt.Errorf("invalid restart policy %q: valid values are %s, %s and %s:<max restarts>", policy, RestartNever, RestartOnFailure, RestartOnFailure)
+	}
+}
+
+// ShouldRestart returns true if an app that exited with exitErr, and was restarted the given number of times
+// before, is restarted.
+func (p RestartPolicy) ShouldRestart(exitErr error, restarts int) bool {
+	if !p.OnFailure || exitErr == nil {
+		return false
+	}
+	return p.MaxRestarts == 0 || restarts < p.MaxRestarts
+}
+
+// RestartBackoff returns the time to wait before the given restart of the app, counted from one. It starts at
+// one second and doubles for every further restart, up to 30 seconds.
+func RestartBackoff(restart int) time.Duration {
+	backoff := restartInitialBackoff
+	for i := 1; i < restart && backoff < restartMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > restartMaxBackoff {
+		return restartMaxBackoff
+	}
+	return backoff
+}
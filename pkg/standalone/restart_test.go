@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRestartPolicy(t *testing.T) {
+	tests := []struct {
+		policy   string
+		expected RestartPolicy
+		err      bool
+	}{
+		{policy: "no", expected: RestartPolicy{}},
+		{policy: "on-failure", expected: RestartPolicy{OnFailure: true}},
+		{policy: "on-failure:3", expected: RestartPolicy{OnFailure: true, MaxRestarts: 3}},
+		{policy: "on-failure:0", err: true},
+		{policy: "on-failure:many", err: true},
+		{policy: "no:3", err: true},
+		{policy: "always", err: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.policy, func(t *testing.T) {
+			policy, err := ParseRestartPolicy(tc.policy)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, policy)
+		})
+	}
+}
+
+func TestShouldRestart(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+
+	assert.False(t, RestartPolicy{}.ShouldRestart(exitErr, 0))
+	assert.False(t, RestartPolicy{OnFailure: true}.ShouldRestart(nil, 0))
+	assert.True(t, RestartPolicy{OnFailure: true}.ShouldRestart(exitErr, 100))
+	assert.True(t, RestartPolicy{OnFailure: true, MaxRestarts: 2}.ShouldRestart(exitErr, 1))
+	assert.False(t, RestartPolicy{OnFailure: true, MaxRestarts: 2}.ShouldRestart(exitErr, 2))
+}
+
+func TestRestartBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, RestartBackoff(1))
+	assert.Equal(t, 2*time.Second, RestartBackoff(2))
+	assert.Equal(t, 16*time.Second, RestartBackoff(5))
+	assert.Equal(t, 30*time.Second, RestartBackoff(6))
+	assert.Equal(t, 30*time.Second, RestartBackoff(100))
+}
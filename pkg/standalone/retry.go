@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dapr/cli/pkg/print"
+)
+
+// DefaultRetryBackoff is the time to wait before the first retry of a request.
+const DefaultRetryBackoff = time.Second
+
+// RetryPolicy is how often and for how long a request to a sidecar is attempted.
+type RetryPolicy struct {
+	// Timeout limits the duration of each attempt. There is no limit if it is zero.
+	Timeout time.Duration
+	// Retries is the number of attempts after the first one failed.
+	Retries int
+	// Backoff is the time to wait before the first retry. It doubles for every further retry.
+	Backoff time.Duration
+}
+
+// statusError is an error status code returned by a sidecar or an app.
+type statusError struct {
+	statusCode int
+	msg        string
+}
+
+func (e *statusError) Error() string {
+	return e.msg
+}
+
+// Do calls attempt until it succeeds, it fails with an error that is not worth retrying, or the retries are used up.
+// Each attempt is passed a context that is done after the timeout of the policy.
+func (p RetryPolicy) Do(ctx context.Context, attempt func(ctx context.Context) error) error {
+	backoff := p.Backoff
+	for i := 0; ; i++ {
+		print.DebugStatusEvent(os.Stderr, "Attempt %d of %d", i+1, p.Retries+1)
+		err := p.attempt(ctx, attempt)
+		if err == nil || i >= p.Retries || !isRetryable(err) {
+			return err
+		}
+		print.DebugStatusEvent(os.Stderr, "Attempt %d of %d failed: %s. Retrying in %s", i+1, p.Retries+1, err, backoff)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (p RetryPolicy) attempt(ctx context.Context, attempt func(ctx context.Context) error) error {
+	if p.Timeout <= 0 {
+		return attempt(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+	err := attempt(ctx)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &timeoutError{timeout: p.Timeout, err: err}
+	}
+	return err
+}
+
+// timeoutError is an attempt that did not complete within the timeout of the retry policy.
+type timeoutError struct {
+	timeout time.Duration
+	err     error
+}
+
+func (e *timeoutError) Error() string {
+	return "timed out after " + e.timeout.String()
+}
+
+func (e *timeoutError) Unwrap() error {
+	return e.err
+}
+
+// isRetryable returns false for errors that another attempt will not fix, such as a bad request.
+// Connection errors, timeouts and server errors are retried.
+func isRetryable(err error) bool {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.statusCode >= http.StatusInternalServerError ||
+			statusErr.statusCode == http.StatusRequestTimeout ||
+			statusErr.statusCode == http.StatusTooManyRequests
+	}
+	if s, ok := status.FromError(err); ok && s.Code() != codes.OK {
+		switch s.Code() {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal, codes.Unknown:
+			return true
+		default:
+			return false
+		}
+	}
+	return true
+}
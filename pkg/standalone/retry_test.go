@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy := RetryPolicy{Retries: 2, Backoff: time.Millisecond}
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		attempts := 0
+		err := policy.Do(context.Background(), func(ctx context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("connection refused")
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("fails after the retries are used up", func(t *testing.T) {
+		attempts := 0
+		err := policy.Do(context.Background(), func(ctx context.Context) error {
+			attempts++
+			return &statusError{statusCode: http.StatusServiceUnavailable, msg: "503 Service Unavailable"}
+		})
+		assert.EqualError(t, err, "503 Service Unavailable")
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		for _, err := range []error{
+			&statusError{statusCode: http.StatusNotFound, msg: "404 Not Found"},
+			status.Error(codes.InvalidArgument, "invalid"),
+		} {
+			attempts := 0
+			returned := policy.Do(context.Background(), func(ctx context.Context) error {
+				attempts++
+				return err
+			})
+			assert.Equal(t, err, returned)
+			assert.Equal(t, 1, attempts)
+		}
+	})
+
+	t.Run("times out each attempt", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		defer ts.Close()
+
+		attempts := 0
+		timeoutPolicy := RetryPolicy{Timeout: 10 * time.Millisecond, Retries: 1, Backoff: time.Millisecond}
+		err := timeoutPolicy.Do(context.Background(), func(ctx context.Context) error {
+			attempts++
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+			if err != nil {
+				return err
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err == nil {
+				resp.Body.Close()
+			}
+			return err
+		})
+		assert.EqualError(t, err, "timed out after 10ms")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 2, attempts)
+	})
+}
@@ -14,12 +14,14 @@ limitations under the License.
 package standalone
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"os/exec"
 	"reflect"
 	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/Pallinder/sillyname-go"
@@ -31,30 +33,62 @@ import (
 	modes "github.com/dapr/dapr/pkg/config/modes"
 )
 
-const sentryDefaultAddress = "localhost:50001"
+const (
+	sentryDefaultAddress = "localhost:50001"
+	// maxFreePortAttempts limits the number of free ports asked from the OS until one is found that is not used by Dapr.
+	maxFreePortAttempts = 100
+)
 
 // RunConfig represents the application configuration parameters.
 type RunConfig struct {
-	AppID              string `env:"APP_ID" arg:"app-id"`
-	AppPort            int    `env:"APP_PORT" arg:"app-port"`
-	HTTPPort           int    `env:"DAPR_HTTP_PORT" arg:"dapr-http-port"`
-	GRPCPort           int    `env:"DAPR_GRPC_PORT" arg:"dapr-grpc-port"`
-	ConfigFile         string `arg:"config"`
-	Protocol           string `arg:"app-protocol"`
-	Arguments          []string
-	EnableProfiling    bool   `arg:"enable-profiling"`
-	ProfilePort        int    `arg:"profile-port"`
-	LogLevel           string `arg:"log-level"`
-	MaxConcurrency     int    `arg:"app-max-concurrency"`
-	PlacementHostAddr  string `arg:"placement-host-address"`
-	ComponentsPath     string `arg:"components-path"`
-	AppSSL             bool   `arg:"app-ssl"`
-	MetricsPort        int    `env:"DAPR_METRICS_PORT" arg:"metrics-port"`
-	MaxRequestBodySize int    `arg:"dapr-http-max-request-size"`
-	HTTPReadBufferSize int    `arg:"dapr-http-read-buffer-size"`
-	UnixDomainSocket   string `arg:"unix-domain-socket"`
-	InternalGRPCPort   int    `arg:"dapr-internal-grpc-port"`
-	EnableAPILogging   bool   `arg:"enable-api-logging"`
+	AppID              string            `env:"APP_ID" arg:"app-id" yaml:"appID"`
+	AppPort            int               `env:"APP_PORT" arg:"app-port" yaml:"appPort"`
+	HTTPPort           int               `env:"DAPR_HTTP_PORT" arg:"dapr-http-port" yaml:"daprHTTPPort"`
+	GRPCPort           int               `env:"DAPR_GRPC_PORT" arg:"dapr-grpc-port" yaml:"daprGRPCPort"`
+	ConfigFile         string            `arg:"config" yaml:"configFilePath"`
+	Protocol           string            `arg:"app-protocol" yaml:"appProtocol"`
+	Arguments          []string          `yaml:"command"`
+	EnableProfiling    bool              `arg:"enable-profiling" yaml:"enableProfiling"`
+	ProfilePort        int               `arg:"profile-port" yaml:"profilePort"`
+	LogLevel           string            `arg:"log-level" yaml:"logLevel"`
+	MaxConcurrency     int               `arg:"app-max-concurrency" yaml:"appMaxConcurrency"`
+	PlacementHostAddr  string            `arg:"placement-host-address" yaml:"placementHostAddress"`
+	ComponentsPath     string            `arg:"components-path" yaml:"resourcesPath"`
+	AppSSL             bool              `arg:"app-ssl" yaml:"appSSL"`
+	MetricsPort        int               `env:"DAPR_METRICS_PORT" arg:"metrics-port" yaml:"metricsPort"`
+	MaxRequestBodySize int               `arg:"dapr-http-max-request-size" yaml:"daprHTTPMaxRequestSize"`
+	HTTPReadBufferSize int               `arg:"dapr-http-read-buffer-size" yaml:"daprHTTPReadBufferSize"`
+	UnixDomainSocket   string            `arg:"unix-domain-socket" yaml:"unixDomainSocket"`
+	InternalGRPCPort   int               `arg:"dapr-internal-grpc-port" yaml:"daprInternalGRPCPort"`
+	EnableAPILogging   bool              `arg:"enable-api-logging" yaml:"enableAPILogging"`
+	AppDirPath         string            `yaml:"appDirPath"`     // Working directory of the app command.
+	Env                map[string]string `yaml:"env"`            // Additional environment variables of the app command and the sidecar.
+	WaitFor            []string          `yaml:"waitFor"`        // Dependencies that must be reachable before the app command is started.
+	WaitForTimeout     int               `yaml:"waitForTimeout"` // Seconds to wait for the dependencies.
+	StrictPorts        bool              `yaml:"strictPorts"`    // Fail if a requested port is in use instead of picking a free port.
+	DaprMaxMemory      string            `yaml:"daprMaxMemory"`  // Maximum memory of the sidecar, such as 512Mi.
+	DaprCPULimit       float64           `yaml:"daprCPULimit"`   // Maximum number of CPUs the sidecar may use, such as 0.5.
+	// Namespace isolates the app from the apps of other namespaces. It is set as NAMESPACE for the app and the sidecar.
+	Namespace string `env:"NAMESPACE" yaml:"namespace"`
+	// SidecarInContainer runs the sidecar in a container of SidecarImage with ContainerRuntime instead of as a process.
+	SidecarInContainer bool   `yaml:"-"`
+	SidecarImage       string `yaml:"-"`
+	ContainerRuntime   string `yaml:"-"`
+}
+
+// PortAssignment is the port used by a sidecar or its app.
+type PortAssignment struct {
+	// Name is the name of the flag that sets the port, such as dapr-http-port.
+	Name string
+	// Requested is the port that was asked for, or 0 if none was.
+	Requested int
+	// Assigned is the port that is used. It differs from Requested if that was in use.
+	Assigned int
+}
+
+// Reassigned returns true if the requested port was in use and a free port is used instead.
+func (p PortAssignment) Reassigned() bool {
+	return p.Requested > 0 && p.Requested != p.Assigned
 }
 
 func (meta *DaprMeta) newAppID() string {
@@ -72,17 +106,21 @@ func (config *RunConfig) validateComponentPath() error {
 		return err
 	}
 	componentsLoader := components.NewStandaloneComponents(modes.StandaloneConfig{ComponentsPath: config.ComponentsPath})
-	_, err = componentsLoader.LoadComponents()
+	comps, err := componentsLoader.LoadComponents()
 	if err != nil {
 		return err
 	}
+	if skipped := componentsOutsideNamespace(comps, config.Namespace); len(skipped) > 0 {
+		print.WarningStatusEvent(os.Stdout, "The components %s in %s are not in namespace %s and are not loaded by the sidecar of %s. Set their metadata.namespace to load them",
+			strings.Join(skipped, ", "), config.ComponentsPath, config.Namespace, config.AppID)
+	}
 	return nil
 }
 
 func (config *RunConfig) validatePlacementHostAddr() error {
 	placementHostAddr := config.PlacementHostAddr
 	if len(placementHostAddr) == 0 {
-		placementHostAddr = "localhost"
+		placementHostAddr = ServiceHost()
 	}
 	if indx := strings.Index(placementHostAddr, ":"); indx == -1 {
 		if runtime.GOOS == daprWindowsOS {
@@ -95,32 +133,42 @@ func (config *RunConfig) validatePlacementHostAddr() error {
 	return nil
 }
 
+// validatePort picks a free port if none is given. If the given port is in use, a free port is picked
+// instead, unless StrictPorts is set.
 func (config *RunConfig) validatePort(portName string, portPtr *int, meta *DaprMeta) error {
-	if *portPtr <= 0 {
-		port, err := freeport.GetFreePort()
-		if err != nil {
-			return err
+	requested := *portPtr
+	if requested < 0 {
+		requested = 0
+	}
+	if requested > 0 {
+		if !meta.portExists(requested) {
+			meta.assignedPorts = append(meta.assignedPorts, PortAssignment{Name: portName, Requested: requested, Assigned: requested})
+			return nil
+		}
+		if config.StrictPorts {
+			return fmt.Errorf("invalid configuration for %s. Port %v is not available", portName, requested)
 		}
-		*portPtr = port
-		return nil
-	}
-
-	if meta.portExists(*portPtr) {
-		return fmt.Errorf("invalid configuration for %s. Port %v is not available", portName, *portPtr)
 	}
-	return nil
-}
 
-func (config *RunConfig) validate() error {
-	meta, err := newDaprMeta()
+	port, err := meta.freePort()
 	if err != nil {
 		return err
 	}
+	*portPtr = port
+	meta.assignedPorts = append(meta.assignedPorts, PortAssignment{Name: portName, Requested: requested, Assigned: port})
+	return nil
+}
 
+func (config *RunConfig) validate(meta *DaprMeta) error {
 	if config.AppID == "" {
 		config.AppID = meta.newAppID()
 	}
 
+	err := ValidateNamespace(config.Namespace)
+	if err != nil {
+		return err
+	}
+
 	err = config.validateComponentPath()
 	if err != nil {
 		return err
@@ -129,29 +177,36 @@ func (config *RunConfig) validate() error {
 	if config.AppPort < 0 {
 		config.AppPort = 0
 	}
+	// The app port can only be checked if the app is started here, an app started separately is already listening on it.
+	if config.AppPort > 0 && len(config.Arguments) > 0 {
+		err = config.validatePort("app-port", &config.AppPort, meta)
+		if err != nil {
+			return err
+		}
+	}
 
-	err = config.validatePort("HTTPPort", &config.HTTPPort, meta)
+	err = config.validatePort("dapr-http-port", &config.HTTPPort, meta)
 	if err != nil {
 		return err
 	}
 
-	err = config.validatePort("GRPCPort", &config.GRPCPort, meta)
+	err = config.validatePort("dapr-grpc-port", &config.GRPCPort, meta)
 	if err != nil {
 		return err
 	}
 
-	err = config.validatePort("MetricsPort", &config.MetricsPort, meta)
+	err = config.validatePort("metrics-port", &config.MetricsPort, meta)
 	if err != nil {
 		return err
 	}
 
-	err = config.validatePort("InternalGRPCPort", &config.InternalGRPCPort, meta)
+	err = config.validatePort("dapr-internal-grpc-port", &config.InternalGRPCPort, meta)
 	if err != nil {
 		return err
 	}
 
 	if config.EnableProfiling {
-		err = config.validatePort("ProfilePort", &config.ProfilePort, meta)
+		err = config.validatePort("profile-port", &config.ProfilePort, meta)
 		if err != nil {
 			return err
 		}
@@ -172,12 +227,22 @@ func (config *RunConfig) validate() error {
 	if err != nil {
 		return err
 	}
+
+	err = ValidateWaitFor(config.WaitFor)
+	if err != nil {
+		return err
+	}
+	if config.WaitForTimeout <= 0 {
+		config.WaitForTimeout = int(DefaultWaitForTimeout.Seconds())
+	}
 	return nil
 }
 
 type DaprMeta struct {
 	ExistingIDs   map[string]bool
 	ExistingPorts map[int]bool
+
+	assignedPorts []PortAssignment
 }
 
 func (meta *DaprMeta) idExists(id string) bool {
@@ -206,6 +271,21 @@ func (meta *DaprMeta) portExists(port int) bool {
 	return false
 }
 
+// freePort returns a free port that is not used by another sidecar or app.
+func (meta *DaprMeta) freePort() (int, error) {
+	for i := 0; i < maxFreePortAttempts; i++ {
+		port, err := freeport.GetFreePort()
+		if err != nil {
+			return 0, err
+		}
+		if _, ok := meta.ExistingPorts[port]; !ok {
+			meta.ExistingPorts[port] = true
+			return port, nil
+		}
+	}
+	return 0, errors.New("could not find a free port")
+}
+
 func newDaprMeta() (*DaprMeta, error) {
 	meta := DaprMeta{}
 	meta.ExistingIDs = make(map[string]bool)
@@ -276,10 +356,35 @@ func (config *RunConfig) getEnv() []string {
 			// ignore unset numeric variables.
 			continue
 		}
+		if value, ok := valueField.(string); ok && value == "" {
+			continue
+		}
 
 		value := fmt.Sprintf("%v", reflect.ValueOf(valueField))
 		env = append(env, fmt.Sprintf("%s=%v", key, value))
 	}
+	return append(env, config.extraEnv()...)
+}
+
+// extraEnv returns the additional environment variables, sorted by key.
+func (config *RunConfig) extraEnv() []string {
+	keys := make([]string, 0, len(config.Env))
+	for key := range config.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	env := make([]string, 0, len(keys))
+	for _, key := range keys {
+		env = append(env, fmt.Sprintf("%s=%s", key, config.Env[key]))
+	}
+	return env
+}
+
+// Environment returns the variables that are set for the app command, sorted by key.
+// The additional variables in Env are also set for the sidecar.
+func (config *RunConfig) Environment() []string {
+	env := config.getEnv()
+	sort.Strings(env)
 	return env
 }
 
@@ -292,13 +397,36 @@ type RunOutput struct {
 	AppID        string
 	AppCMD       *exec.Cmd
 	AppErr       error
+	// Ports are the ports of the sidecar and the app, in the order they were assigned.
+	Ports []PortAssignment
+
+	sidecarLimits SidecarLimits
+}
+
+// LimitSidecar applies the resource limits of the sidecar to its started process, where the operating system
+// limits processes once they are started. It must be called every time DaprCMD is started.
+func (output *RunOutput) LimitSidecar() error {
+	if output.DaprCMD == nil {
+		return nil
+	}
+	return limitSidecarProcess(output.DaprCMD.Process, output.sidecarLimits)
 }
 
 func getDaprCommand(config *RunConfig) (*exec.Cmd, error) {
+	if config.SidecarInContainer {
+		return getSidecarContainerCommand(config)
+	}
+	limits, err := config.sidecarLimits()
+	if err != nil {
+		return nil, err
+	}
 	daprCMD := binaryFilePath(defaultDaprBinPath(), "daprd")
 	args := config.getArgs()
 	cmd := exec.Command(daprCMD, args...)
-	return cmd, nil
+	if env := config.sidecarEnv(); len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	return limitSidecarCommand(cmd, limits)
 }
 
 func mtlsEndpoint(configFile string) string {
@@ -337,6 +465,7 @@ func getAppCommand(config *RunConfig) *exec.Cmd {
 	}
 
 	cmd := exec.Command(command, args...)
+	cmd.Dir = config.AppDirPath
 	cmd.Env = os.Environ()
 	cmd.Env = append(cmd.Env, config.getEnv()...)
 
@@ -344,8 +473,12 @@ func getAppCommand(config *RunConfig) *exec.Cmd {
 }
 
 func Run(config *RunConfig) (*RunOutput, error) {
+	meta, err := newDaprMeta()
+	if err != nil {
+		return nil, err
+	}
 	// nolint
-	err := config.validate()
+	err = config.validate(meta)
 	if err != nil {
 		return nil, err
 	}
@@ -354,6 +487,12 @@ func Run(config *RunConfig) (*RunOutput, error) {
 	if err != nil {
 		return nil, err
 	}
+	// A sidecar run in a container is limited by the container runtime instead.
+	limits := SidecarLimits{}
+	if !config.SidecarInContainer {
+		// The limits were validated by getDaprCommand.
+		limits, _ = config.sidecarLimits()
+	}
 
 	// nolint
 	var appCMD *exec.Cmd = getAppCommand(config)
@@ -365,5 +504,8 @@ func Run(config *RunConfig) (*RunOutput, error) {
 		AppID:        config.AppID,
 		DaprHTTPPort: config.HTTPPort,
 		DaprGRPCPort: config.GRPCPort,
+		Ports:        meta.assignedPorts,
+
+		sidecarLimits: limits,
 	}, nil
 }
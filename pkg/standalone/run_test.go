@@ -15,7 +15,9 @@ package standalone
 
 import (
 	"fmt"
+	"net"
 	"os"
+	"os/exec"
 	"strings"
 	"testing"
 
@@ -191,4 +193,65 @@ func TestRun(t *testing.T) {
 		assertArgumentNotEqual(t, "grpc-port", "-1", output.DaprCMD.Args)
 		assertArgumentNotEqual(t, "metrics-port", "-1", output.DaprCMD.Args)
 	})
+
+	t.Run("run with ports in use", func(t *testing.T) {
+		listener, err := net.Listen("tcp", ":0")
+		assert.NoError(t, err)
+		defer listener.Close()
+		usedPort := listener.Addr().(*net.TCPAddr).Port
+
+		basicConfig.HTTPPort = usedPort
+		basicConfig.GRPCPort = -1
+		basicConfig.AppPort = usedPort
+		basicConfig.Arguments = []string{"MyCommand"}
+		output, err := Run(basicConfig)
+		assert.NoError(t, err)
+
+		assert.NotEqual(t, usedPort, output.DaprHTTPPort)
+		assert.NotEqual(t, usedPort, basicConfig.AppPort)
+		assertArgumentEqual(t, "dapr-http-port", fmt.Sprint(output.DaprHTTPPort), output.DaprCMD.Args)
+		assertArgumentEqual(t, "app-port", fmt.Sprint(basicConfig.AppPort), output.DaprCMD.Args)
+		assert.Contains(t, output.AppCMD.Env, getEnv("APP_PORT", basicConfig.AppPort))
+
+		ports := map[string]PortAssignment{}
+		for _, p := range output.Ports {
+			ports[p.Name] = p
+		}
+		assert.Equal(t, PortAssignment{Name: "app-port", Requested: usedPort, Assigned: basicConfig.AppPort}, ports["app-port"])
+		assert.True(t, ports["dapr-http-port"].Reassigned())
+		assert.False(t, ports["dapr-grpc-port"].Reassigned())
+		assert.Equal(t, 0, ports["dapr-grpc-port"].Requested)
+	})
+
+	t.Run("run with additional env", func(t *testing.T) {
+		basicConfig.HTTPPort = -1
+		basicConfig.StrictPorts = false
+		basicConfig.Arguments = []string{"MyCommand"}
+		basicConfig.Env = map[string]string{"LOG_FORMAT": "json", "DB_HOST": "localhost"}
+		defer func() { basicConfig.Env = nil }()
+		output, err := Run(basicConfig)
+		assert.NoError(t, err)
+
+		for _, cmd := range []*exec.Cmd{output.AppCMD, output.DaprCMD} {
+			assert.Contains(t, cmd.Env, "LOG_FORMAT=json")
+			assert.Contains(t, cmd.Env, "DB_HOST=localhost")
+		}
+		env := basicConfig.Environment()
+		assert.Equal(t, "APP_ID=MyID", env[0])
+		assert.Contains(t, env, "DB_HOST=localhost")
+		assert.Contains(t, env, getEnv("DAPR_HTTP_PORT", output.DaprHTTPPort))
+		assert.IsIncreasing(t, env)
+	})
+
+	t.Run("run with ports in use and strict ports", func(t *testing.T) {
+		listener, err := net.Listen("tcp", ":0")
+		assert.NoError(t, err)
+		defer listener.Close()
+		usedPort := listener.Addr().(*net.TCPAddr).Port
+
+		basicConfig.HTTPPort = usedPort
+		basicConfig.StrictPorts = true
+		_, err = Run(basicConfig)
+		assert.EqualError(t, err, fmt.Sprintf("invalid configuration for dapr-http-port. Port %d is not available", usedPort))
+	})
 }
@@ -0,0 +1,199 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	path_filepath "path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const runFileVersion = 1
+
+// runFileVariable matches ${NAME} and ${NAME:-default} in a run file, and $${ which escapes a literal ${.
+var runFileVariable = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// RunFileConfig represents a run file used to start multiple apps with `dapr run -f`.
+type RunFileConfig struct {
+	Version int           `yaml:"version"`
+	Common  RunFileCommon `yaml:"common"`
+	Apps    []RunConfig   `yaml:"apps"`
+}
+
+// RunFileCommon are the settings shared by all apps of a run file. The settings of an app override them,
+// and the env of an app is merged into the common env.
+type RunFileCommon struct {
+	Env            map[string]string `yaml:"env"`
+	ComponentsPath string            `yaml:"resourcesPath"`
+	ConfigFile     string            `yaml:"configFilePath"`
+	Namespace      string            `yaml:"namespace"`
+}
+
+// ParseRunFile reads the run file at the given path and returns the run configuration
+// of every app in it. Relative paths in the file are resolved against the directory of the run file.
+func ParseRunFile(runFilePath string) ([]RunConfig, error) {
+	b, err := os.ReadFile(runFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading run file: %w", err)
+	}
+
+	content, err := interpolateRunFile(string(b), os.LookupEnv)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing run file %s: %w", runFilePath, err)
+	}
+
+	var runFile RunFileConfig
+	err = yaml.UnmarshalStrict([]byte(content), &runFile)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing run file %s: %w", runFilePath, err)
+	}
+
+	if runFile.Version != runFileVersion {
+		return nil, fmt.Errorf("unsupported run file version %d. Supported version is %d", runFile.Version, runFileVersion)
+	}
+	if len(runFile.Apps) == 0 {
+		return nil, errors.New("no apps found in run file")
+	}
+
+	baseDir, err := path_filepath.Abs(path_filepath.Dir(runFilePath))
+	if err != nil {
+		return nil, err
+	}
+
+	appIDs := map[string]bool{}
+	ports := map[int]string{}
+	for i := range runFile.Apps {
+		app := &runFile.Apps[i]
+		app.applyRunFileCommon(runFile.Common)
+		app.setRunFileDefaults(baseDir)
+
+		if appIDs[app.AppID] {
+			return nil, fmt.Errorf("duplicate app ID %q in run file", app.AppID)
+		}
+		appIDs[app.AppID] = true
+
+		for _, port := range []int{app.AppPort, app.HTTPPort, app.GRPCPort, app.MetricsPort, app.InternalGRPCPort, app.ProfilePort} {
+			if port <= 0 {
+				continue
+			}
+			if other, ok := ports[port]; ok {
+				return nil, fmt.Errorf("port %d is used by both %q and %q in run file", port, other, app.AppID)
+			}
+			ports[port] = app.AppID
+		}
+	}
+	return runFile.Apps, nil
+}
+
+// interpolateRunFile replaces ${NAME} in the content of a run file with the value of the environment variable NAME,
+// as returned by lookup, and ${NAME:-default} with default if NAME is not set or empty. $${ is kept as a literal ${.
+// An error lists the variables that are referenced without a default but not set.
+func interpolateRunFile(content string, lookup func(string) (string, bool)) (string, error) {
+	missing := map[string]struct{}{}
+	content = runFileVariable.ReplaceAllStringFunc(content, func(ref string) string {
+		if ref == "$${" {
+			return "${"
+		}
+		match := runFileVariable.FindStringSubmatch(ref)
+		name, hasDefault, defaultValue := match[1], match[2] != "", match[3]
+		value, ok := lookup(name)
+		switch {
+		case ok && (value != "" || !hasDefault):
+			return value
+		case hasDefault:
+			return defaultValue
+		default:
+			missing[name] = struct{}{}
+			return ""
+		}
+	})
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("environment variables not set: %s. Set them or use ${NAME:-default}", strings.Join(names, ", "))
+	}
+	return content, nil
+}
+
+// applyRunFileCommon applies the common settings of a run file to the settings the app does not set itself.
+func (config *RunConfig) applyRunFileCommon(common RunFileCommon) {
+	if config.ComponentsPath == "" {
+		config.ComponentsPath = common.ComponentsPath
+	}
+	if config.ConfigFile == "" {
+		config.ConfigFile = common.ConfigFile
+	}
+	if config.Namespace == "" {
+		config.Namespace = common.Namespace
+	}
+	if len(common.Env) > 0 {
+		env := make(map[string]string, len(common.Env)+len(config.Env))
+		for key, value := range common.Env {
+			env[key] = value
+		}
+		for key, value := range config.Env {
+			env[key] = value
+		}
+		config.Env = env
+	}
+}
+
+// setRunFileDefaults applies the defaults of `dapr run` flags to values omitted in a run file.
+func (config *RunConfig) setRunFileDefaults(baseDir string) {
+	config.AppDirPath = resolvePath(baseDir, config.AppDirPath)
+	if config.AppID == "" {
+		config.AppID = path_filepath.Base(config.AppDirPath)
+	}
+
+	if config.ComponentsPath == "" {
+		config.ComponentsPath = DefaultComponentsDirPath()
+	} else {
+		config.ComponentsPath = resolvePath(baseDir, config.ComponentsPath)
+	}
+
+	if config.ConfigFile == "" {
+		config.ConfigFile = DefaultConfigFilePath()
+	} else {
+		config.ConfigFile = resolvePath(baseDir, config.ConfigFile)
+	}
+
+	if config.Protocol == "" {
+		config.Protocol = "http"
+	}
+	if config.LogLevel == "" {
+		config.LogLevel = "info"
+	}
+	if config.MaxRequestBodySize == 0 {
+		config.MaxRequestBodySize = -1
+	}
+	if config.HTTPReadBufferSize == 0 {
+		config.HTTPReadBufferSize = -1
+	}
+}
+
+func resolvePath(baseDir, path string) string {
+	if path_filepath.IsAbs(path) {
+		return path
+	}
+	return path_filepath.Join(baseDir, path)
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"os"
+	path_filepath "path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeRunFile(t *testing.T, content string) string {
+	t.Helper()
+	runFilePath := path_filepath.Join(t.TempDir(), "dapr.yaml")
+	err := os.WriteFile(runFilePath, []byte(content), 0o600)
+	assert.NoError(t, err)
+	return runFilePath
+}
+
+func TestParseRunFile(t *testing.T) {
+	t.Run("apps with defaults", func(t *testing.T) {
+		runFilePath := writeRunFile(t, `
+version: 1
+apps:
+  - appDirPath: ./orders
+    appPort: 3000
+    command: ["node", "app.js"]
+    env:
+      DEBUG: "true"
+    resourcesPath: ./components
+  - appID: checkout
+    appDirPath: /tmp/checkout
+    daprHTTPPort: 3500
+`)
+		baseDir := path_filepath.Dir(runFilePath)
+
+		apps, err := ParseRunFile(runFilePath)
+		assert.NoError(t, err)
+		assert.Len(t, apps, 2)
+
+		assert.Equal(t, "orders", apps[0].AppID)
+		assert.Equal(t, path_filepath.Join(baseDir, "orders"), apps[0].AppDirPath)
+		assert.Equal(t, path_filepath.Join(baseDir, "components"), apps[0].ComponentsPath)
+		assert.Equal(t, DefaultConfigFilePath(), apps[0].ConfigFile)
+		assert.Equal(t, []string{"node", "app.js"}, apps[0].Arguments)
+		assert.Equal(t, map[string]string{"DEBUG": "true"}, apps[0].Env)
+		assert.Equal(t, 3000, apps[0].AppPort)
+		assert.Equal(t, "http", apps[0].Protocol)
+		assert.Equal(t, -1, apps[0].MaxRequestBodySize)
+
+		assert.Equal(t, "checkout", apps[1].AppID)
+		assert.Equal(t, "/tmp/checkout", apps[1].AppDirPath)
+		assert.Equal(t, DefaultComponentsDirPath(), apps[1].ComponentsPath)
+		assert.Equal(t, 3500, apps[1].HTTPPort)
+	})
+
+	t.Run("duplicate app IDs", func(t *testing.T) {
+		runFilePath := writeRunFile(t, `
+version: 1
+apps:
+  - appID: orders
+  - appID: orders
+`)
+		_, err := ParseRunFile(runFilePath)
+		assert.EqualError(t, err, `duplicate app ID "orders" in run file`)
+	})
+
+	t.Run("duplicate ports", func(t *testing.T) {
+		runFilePath := writeRunFile(t, `
+version: 1
+apps:
+  - appID: orders
+    appPort: 3000
+  - appID: checkout
+    daprHTTPPort: 3000
+`)
+		_, err := ParseRunFile(runFilePath)
+		assert.EqualError(t, err, `port 3000 is used by both "orders" and "checkout" in run file`)
+	})
+
+	t.Run("unknown fields", func(t *testing.T) {
+		runFilePath := writeRunFile(t, `
+version: 1
+apps:
+  - appId: orders
+`)
+		_, err := ParseRunFile(runFilePath)
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		runFilePath := writeRunFile(t, `
+version: 2
+apps:
+  - appID: orders
+`)
+		_, err := ParseRunFile(runFilePath)
+		assert.EqualError(t, err, "unsupported run file version 2. Supported version is 1")
+	})
+
+	t.Run("no apps", func(t *testing.T) {
+		runFilePath := writeRunFile(t, "version: 1\n")
+		_, err := ParseRunFile(runFilePath)
+		assert.EqualError(t, err, "no apps found in run file")
+	})
+	t.Run("common settings", func(t *testing.T) {
+		runFilePath := writeRunFile(t, `
+version: 1
+common:
+  resourcesPath: ./components
+  configFilePath: ./config.yaml
+  namespace: shop
+  env:
+    DEBUG: "true"
+    REGION: eu
+apps:
+  - appID: orders
+    env:
+      REGION: us
+  - appID: checkout
+    resourcesPath: /tmp/checkout/components
+    namespace: payments
+`)
+		baseDir := path_filepath.Dir(runFilePath)
+
+		apps, err := ParseRunFile(runFilePath)
+		assert.NoError(t, err)
+		assert.Equal(t, path_filepath.Join(baseDir, "components"), apps[0].ComponentsPath)
+		assert.Equal(t, path_filepath.Join(baseDir, "config.yaml"), apps[0].ConfigFile)
+		assert.Equal(t, map[string]string{"DEBUG": "true", "REGION": "us"}, apps[0].Env)
+		assert.Equal(t, "/tmp/checkout/components", apps[1].ComponentsPath)
+		assert.Equal(t, path_filepath.Join(baseDir, "config.yaml"), apps[1].ConfigFile)
+		assert.Equal(t, map[string]string{"DEBUG": "true", "REGION": "eu"}, apps[1].Env)
+		assert.Equal(t, "shop", apps[0].Namespace)
+		assert.Equal(t, "payments", apps[1].Namespace)
+	})
+
+	t.Run("environment variables", func(t *testing.T) {
+		t.Setenv("DAPR_TEST_ORDERS_PORT", "3000")
+		runFilePath := writeRunFile(t, `
+version: 1
+apps:
+  - appID: orders
+    appPort: ${DAPR_TEST_ORDERS_PORT}
+    logLevel: ${DAPR_TEST_LOG_LEVEL:-debug}
+    command: ["sh", "-c", "echo $${HOME}"]
+`)
+		apps, err := ParseRunFile(runFilePath)
+		assert.NoError(t, err)
+		assert.Equal(t, 3000, apps[0].AppPort)
+		assert.Equal(t, "debug", apps[0].LogLevel)
+		assert.Equal(t, []string{"sh", "-c", "echo ${HOME}"}, apps[0].Arguments)
+	})
+
+	t.Run("environment variables not set", func(t *testing.T) {
+		runFilePath := writeRunFile(t, `
+version: 1
+apps:
+  - appID: ${DAPR_TEST_UNSET_B}
+    appDirPath: ${DAPR_TEST_UNSET_A}/${DAPR_TEST_UNSET_B}
+`)
+		_, err := ParseRunFile(runFilePath)
+		assert.ErrorContains(t, err, "environment variables not set: DAPR_TEST_UNSET_A, DAPR_TEST_UNSET_B")
+	})
+}
+
+func TestInterpolateRunFile(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		value, ok := map[string]string{"SET": "value", "EMPTY": ""}[name]
+		return value, ok
+	}
+
+	content, err := interpolateRunFile("${SET} ${EMPTY} ${EMPTY:-default} ${UNSET:-} ${SET:-default} $${SET} $SET", lookup)
+	assert.NoError(t, err)
+	assert.Equal(t, "value  default  value ${SET} $SET", content)
+
+	_, err = interpolateRunFile("${UNSET}", lookup)
+	assert.EqualError(t, err, "environment variables not set: UNSET. Set them or use ${NAME:-default}")
+}
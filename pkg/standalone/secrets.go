@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/dapr/cli/pkg/api"
+)
+
+// SecretOutput is a value of a secret. Secrets of stores such as Kubernetes have more than one value.
+type SecretOutput struct {
+	Secret string `csv:"SECRET" json:"secret" yaml:"secret"`
+	Key    string `csv:"KEY"    json:"key"    yaml:"key"`
+	Value  string `csv:"VALUE"  json:"value"  yaml:"value"`
+}
+
+// GetSecret returns the values of the secret key in the secret store storeName, read through the sidecar of appID.
+// The sidecar only returns secrets allowed by the secret scopes of the configuration of the app.
+func (s *Standalone) GetSecret(appID, storeName, key string, metadata map[string]string, socket string) ([]SecretOutput, error) {
+	if storeName == "" || key == "" {
+		return nil, errors.New("the secret store and the key are required")
+	}
+
+	var values map[string]string
+	err := s.getSecrets(appID, storeName, url.PathEscape(key), metadata, socket, &values)
+	if err != nil {
+		return nil, fmt.Errorf("error getting secret %s from store %s: %w", key, storeName, err)
+	}
+	return secretOutputs(map[string]map[string]string{key: values}), nil
+}
+
+// ListSecrets returns the values of all secrets of the secret store storeName that the app is allowed to read,
+// read through the sidecar of appID with the bulk API. Not every secret store supports it.
+func (s *Standalone) ListSecrets(appID, storeName string, metadata map[string]string, socket string) ([]SecretOutput, error) {
+	if storeName == "" {
+		return nil, errors.New("the secret store is required")
+	}
+
+	var secrets map[string]map[string]string
+	err := s.getSecrets(appID, storeName, "bulk", metadata, socket, &secrets)
+	if err != nil {
+		return nil, fmt.Errorf("error listing the secrets of store %s: %w", storeName, err)
+	}
+	return secretOutputs(secrets), nil
+}
+
+// getSecrets gets path of the secrets API of storeName and decodes the response into v.
+func (s *Standalone) getSecrets(appID, storeName, path string, metadata map[string]string, socket string, v interface{}) error {
+	endpoint, httpc, err := s.sidecarEndpoint(appID, socket, fmt.Sprintf("v%s/secrets/%s/%s", api.RuntimeAPIVersion, url.PathEscape(storeName), path))
+	if err != nil {
+		return err
+	}
+	if len(metadata) > 0 {
+		query := url.Values{}
+		for k, v := range metadata {
+			query.Set("metadata."+k, v)
+		}
+		endpoint += "?" + query.Encode()
+	}
+
+	r, err := httpc.Get(endpoint) //nolint:noctx
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	body, err := readStateResponse(r)
+	if err != nil {
+		if r.StatusCode == http.StatusForbidden {
+			return fmt.Errorf("%w. Access is denied by the secret scopes of the configuration of the app", err)
+		}
+		return err
+	}
+	if err = json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("error parsing the response of the secrets API: %w", err)
+	}
+	return nil
+}
+
+func secretOutputs(secrets map[string]map[string]string) []SecretOutput {
+	out := []SecretOutput{}
+	for secret, values := range secrets {
+		for key, value := range values {
+			out = append(out, SecretOutput{Secret: secret, Key: key, Value: value})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Secret != out[j].Secret {
+			return out[i].Secret < out[j].Secret
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
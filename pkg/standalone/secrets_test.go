@@ -0,0 +1,83 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/cli/pkg/print"
+)
+
+func TestSecrets(t *testing.T) {
+	ts, port := getTestServerFunc(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1.0/secrets/secretstore/db":
+			if r.URL.Query().Get("metadata.namespace") != "prod" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write([]byte(`{"user":"admin","password":"s3cret"}`))
+		case "/v1.0/secrets/secretstore/bulk":
+			w.Write([]byte(`{"db":{"db":"s3cret"},"api-key":{"api-key":"1234"}}`))
+		case "/v1.0/secrets/secretstore/admin":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errorCode":"ERR_PERMISSION_DENIED","message":"access denied by policy"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ts.Start()
+	defer ts.Close()
+
+	client := &Standalone{
+		process: &mockDaprProcess{
+			Lo: []ListOutput{{AppID: "testapp", HTTPPort: port}},
+		},
+	}
+
+	t.Run("get", func(t *testing.T) {
+		secrets, err := client.GetSecret("testapp", "secretstore", "db", map[string]string{"namespace": "prod"}, "")
+		require.NoError(t, err)
+		assert.Equal(t, []SecretOutput{
+			{Secret: "db", Key: "password", Value: "s3cret"},
+			{Secret: "db", Key: "user", Value: "admin"},
+		}, secrets)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		secrets, err := client.ListSecrets("", "secretstore", nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, []SecretOutput{
+			{Secret: "api-key", Key: "api-key", Value: "1234"},
+			{Secret: "db", Key: "db", Value: "s3cret"},
+		}, secrets)
+	})
+
+	t.Run("secret denied by scopes", func(t *testing.T) {
+		_, err := client.GetSecret("testapp", "secretstore", "admin", nil, "")
+		assert.ErrorContains(t, err, "denied by the secret scopes")
+		assert.Equal(t, "ERR_PERMISSION_DENIED", print.ErrorCode(err))
+	})
+
+	t.Run("store and key are required", func(t *testing.T) {
+		_, err := client.GetSecret("testapp", "secretstore", "", nil, "")
+		assert.Error(t, err)
+		_, err = client.ListSecrets("testapp", "", nil, "")
+		assert.Error(t, err)
+	})
+}
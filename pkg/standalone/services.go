@@ -0,0 +1,455 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	path_filepath "path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/utils"
+)
+
+const (
+	schedulerServiceFilePrefix = "scheduler"
+	// serviceNamePrefix is the prefix of the names of the services of a slim installation, such as dapr-placement.
+	serviceNamePrefix = "dapr-"
+	// launchdLabelPrefix is the prefix of the labels of the launchd agents of a slim installation.
+	launchdLabelPrefix = "io.dapr."
+
+	serviceStatusRunning      = "Running"
+	serviceStatusStopped      = "Stopped"
+	serviceStatusNotInstalled = "Not installed"
+)
+
+// launchdPID matches the PID of a running agent in the output of launchctl list.
+var launchdPID = regexp.MustCompile(`"PID"\s*=\s*(\d+);`)
+
+// SlimService is a control plane binary of a slim installation that is run as a service of the operating system,
+// so that it is started again after a reboot.
+type SlimService struct {
+	// Name is the name of the binary, such as placement.
+	Name string
+	// Path is the path of the binary.
+	Path string
+	Args []string
+}
+
+// ServiceName returns the name of the service of the operating system, such as dapr-placement.
+func (s SlimService) ServiceName() string {
+	return serviceNamePrefix + s.Name
+}
+
+// ServiceStatus is the state of a service of a slim installation.
+type ServiceStatus struct {
+	Name       string `csv:"NAME"`
+	Manager    string `csv:"MANAGER"`
+	Status     string `csv:"STATUS"`
+	Definition string `csv:"DEFINITION"`
+}
+
+// serviceManager installs and controls the services of the operating system.
+type serviceManager interface {
+	// Name returns the name of the service manager, such as systemd.
+	Name() string
+	// Definition returns where the definition of a service is stored.
+	Definition(s SlimService) string
+	Install(s SlimService) error
+	Uninstall(s SlimService) error
+	Start(s SlimService) error
+	Stop(s SlimService) error
+	// Status returns serviceStatusRunning, serviceStatusStopped or serviceStatusNotInstalled.
+	Status(s SlimService) (string, error)
+}
+
+// SlimServices returns the services of the slim installation: the placement service, and the scheduler if its
+// binary is installed, as it is only shipped with newer versions of Dapr.
+func SlimServices() ([]SlimService, error) {
+	binDir := defaultDaprBinPath()
+	placement := binaryFilePath(binDir, placementServiceFilePrefix)
+	if _, err := os.Stat(placement); err != nil {
+		return nil, errors.New("the placement binary is not installed. Run dapr init --slim first")
+	}
+	services := []SlimService{{Name: placementServiceFilePrefix, Path: placement}}
+
+	scheduler := binaryFilePath(binDir, schedulerServiceFilePrefix)
+	if _, err := os.Stat(scheduler); err == nil {
+		dataDir := path_filepath.Join(defaultDaprDirPath(), schedulerServiceFilePrefix)
+		services = append(services, SlimService{Name: schedulerServiceFilePrefix, Path: scheduler, Args: []string{"--etcd-data-dir", dataDir}})
+	}
+	return services, nil
+}
+
+// InstallServices installs and starts the services of the slim installation with the service manager of the
+// operating system: systemd user units on Linux, launchd agents on macOS and scheduled tasks on Windows.
+func InstallServices() error {
+	manager, err := newServiceManager(runtime.GOOS)
+	if err != nil {
+		return err
+	}
+	services, err := SlimServices()
+	if err != nil {
+		return err
+	}
+	for _, s := range services {
+		if err = manager.Install(s); err != nil {
+			return fmt.Errorf("error installing the %s service: %w", s.Name, err)
+		}
+		if err = manager.Start(s); err != nil {
+			return fmt.Errorf("error starting the %s service: %w", s.Name, err)
+		}
+		print.InfoStatusEvent(os.Stdout, "%s service installed to %s and started", s.ServiceName(), manager.Definition(s))
+	}
+	return nil
+}
+
+// UninstallServices stops and removes the services of the slim installation that are installed.
+func UninstallServices() error {
+	manager, err := newServiceManager(runtime.GOOS)
+	if err != nil {
+		return err
+	}
+	services, err := SlimServices()
+	if err != nil {
+		return err
+	}
+	for _, s := range services {
+		status, statusErr := manager.Status(s)
+		if statusErr != nil || status == serviceStatusNotInstalled {
+			continue
+		}
+		if err = manager.Uninstall(s); err != nil {
+			return fmt.Errorf("error removing the %s service: %w", s.Name, err)
+		}
+	}
+	return nil
+}
+
+// StartServices starts the installed services of the slim installation.
+func StartServices() error {
+	return controlServices(func(m serviceManager, s SlimService) error { return m.Start(s) })
+}
+
+// StopServices stops the installed services of the slim installation.
+func StopServices() error {
+	return controlServices(func(m serviceManager, s SlimService) error { return m.Stop(s) })
+}
+
+// ServicesStatus returns the state of the services of the slim installation.
+func ServicesStatus() ([]ServiceStatus, error) {
+	manager, err := newServiceManager(runtime.GOOS)
+	if err != nil {
+		return nil, err
+	}
+	services, err := SlimServices()
+	if err != nil {
+		return nil, err
+	}
+	statuses := []ServiceStatus{}
+	for _, s := range services {
+		status, err := manager.Status(s)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the status of the %s service: %w", s.Name, err)
+		}
+		statuses = append(statuses, ServiceStatus{Name: s.ServiceName(), Manager: manager.Name(), Status: status, Definition: manager.Definition(s)})
+	}
+	return statuses, nil
+}
+
+func controlServices(control func(m serviceManager, s SlimService) error) error {
+	manager, err := newServiceManager(runtime.GOOS)
+	if err != nil {
+		return err
+	}
+	services, err := SlimServices()
+	if err != nil {
+		return err
+	}
+	for _, s := range services {
+		status, err := manager.Status(s)
+		if err != nil {
+			return err
+		}
+		if status == serviceStatusNotInstalled {
+			return fmt.Errorf("the %s service is not installed. Run dapr init --slim --install-services first", s.Name)
+		}
+		if err = control(manager, s); err != nil {
+			return fmt.Errorf("error controlling the %s service: %w", s.Name, err)
+		}
+	}
+	return nil
+}
+
+func newServiceManager(goos string) (serviceManager, error) {
+	switch goos {
+	case linuxOS:
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, err
+		}
+		return &systemdManager{dir: path_filepath.Join(dir, "systemd", "user")}, nil
+	case "darwin":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		return &launchdManager{dir: path_filepath.Join(home, "Library", "LaunchAgents"), logsDir: DefaultLogsDirPath()}, nil
+	case daprWindowsOS:
+		return &scheduledTaskManager{}, nil
+	default:
+		return nil, fmt.Errorf("services are not supported on %s", goos)
+	}
+}
+
+// systemdManager runs the services as systemd user units. They start when the user logs in, or at boot once
+// lingering is enabled for the user with loginctl enable-linger.
+type systemdManager struct {
+	dir string
+}
+
+func (m *systemdManager) Name() string { return "systemd" }
+
+func (m *systemdManager) Definition(s SlimService) string {
+	return path_filepath.Join(m.dir, s.ServiceName()+".service")
+}
+
+func (m *systemdManager) Install(s SlimService) error {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(m.Definition(s), []byte(systemdUnit(s)), 0o644); err != nil {
+		return err
+	}
+	if _, err := utils.RunCmdAndWait("systemctl", "--user", "daemon-reload"); err != nil {
+		return err
+	}
+	_, err := utils.RunCmdAndWait("systemctl", "--user", "enable", s.ServiceName())
+	return err
+}
+
+func (m *systemdManager) Uninstall(s SlimService) error {
+	if _, err := utils.RunCmdAndWait("systemctl", "--user", "disable", "--now", s.ServiceName()); err != nil {
+		return err
+	}
+	if err := os.Remove(m.Definition(s)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	_, err := utils.RunCmdAndWait("systemctl", "--user", "daemon-reload")
+	return err
+}
+
+func (m *systemdManager) Start(s SlimService) error {
+	_, err := utils.RunCmdAndWait("systemctl", "--user", "start", s.ServiceName())
+	return err
+}
+
+func (m *systemdManager) Stop(s SlimService) error {
+	_, err := utils.RunCmdAndWait("systemctl", "--user", "stop", s.ServiceName())
+	return err
+}
+
+func (m *systemdManager) Status(s SlimService) (string, error) {
+	if _, err := os.Stat(m.Definition(s)); os.IsNotExist(err) {
+		return serviceStatusNotInstalled, nil
+	}
+	// is-active exits with an error for units that are not active.
+	out, _ := utils.RunCmdAndWait("systemctl", "--user", "is-active", s.ServiceName())
+	if strings.TrimSpace(out) == "active" {
+		return serviceStatusRunning, nil
+	}
+	return serviceStatusStopped, nil
+}
+
+// systemdUnit returns the unit of a service, which is restarted when it fails.
+func systemdUnit(s SlimService) string {
+	command := make([]string, 0, len(s.Args)+1)
+	for _, arg := range append([]string{s.Path}, s.Args...) {
+		if strings.ContainsAny(arg, " \t\"\\") {
+			arg = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+		}
+		command = append(command, arg)
+	}
+	return fmt.Sprintf(`[Unit]
+Description=Dapr %s service
+After=network.target
+
+[Service]
+ExecStart=%s
+Restart=on-failure
+RestartSec=5
+
+[Install]
+WantedBy=default.target
+`, s.Name, strings.Join(command, " "))
+}
+
+// launchdManager runs the services as launchd agents of the user, which start when the user logs in.
+type launchdManager struct {
+	dir     string
+	logsDir string
+}
+
+func (m *launchdManager) Name() string { return "launchd" }
+
+func (m *launchdManager) Definition(s SlimService) string {
+	return path_filepath.Join(m.dir, launchdLabelPrefix+s.Name+".plist")
+}
+
+func (m *launchdManager) Install(s SlimService) error {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.logsDir, 0o755); err != nil {
+		return err
+	}
+	plist, err := launchdPlist(s, path_filepath.Join(m.logsDir, s.ServiceName()+logFileExt))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(m.Definition(s), plist, 0o644)
+}
+
+func (m *launchdManager) Uninstall(s SlimService) error {
+	// unload fails for agents that are not loaded, which are removed all the same.
+	_, _ = utils.RunCmdAndWait("launchctl", "unload", "-w", m.Definition(s))
+	if err := os.Remove(m.Definition(s)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (m *launchdManager) Start(s SlimService) error {
+	_, err := utils.RunCmdAndWait("launchctl", "load", "-w", m.Definition(s))
+	return err
+}
+
+// Stop unloads the agent, as launchd restarts agents that are kept alive when they are only stopped.
+// The agent is loaded again when the user logs in.
+func (m *launchdManager) Stop(s SlimService) error {
+	_, err := utils.RunCmdAndWait("launchctl", "unload", m.Definition(s))
+	return err
+}
+
+func (m *launchdManager) Status(s SlimService) (string, error) {
+	if _, err := os.Stat(m.Definition(s)); os.IsNotExist(err) {
+		return serviceStatusNotInstalled, nil
+	}
+	out, err := utils.RunCmdAndWait("launchctl", "list", launchdLabelPrefix+s.Name)
+	if err != nil || !launchdPID.MatchString(out) {
+		return serviceStatusStopped, nil
+	}
+	return serviceStatusRunning, nil
+}
+
+// launchdPlist returns the property list of the agent of a service, which is kept alive and logs to logFile.
+func launchdPlist(s SlimService, logFile string) ([]byte, error) {
+	escape := func(v string) (string, error) {
+		var b bytes.Buffer
+		err := xml.EscapeText(&b, []byte(v))
+		return b.String(), err
+	}
+	var args strings.Builder
+	for _, arg := range append([]string{s.Path}, s.Args...) {
+		escaped, err := escape(arg)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&args, "\t\t<string>%s</string>\n", escaped)
+	}
+	logFile, err := escape(logFile)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>%s%s</string>
+	<key>ProgramArguments</key>
+	<array>
+%s	</array>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<true/>
+	<key>StandardOutPath</key>
+	<string>%s</string>
+	<key>StandardErrorPath</key>
+	<string>%s</string>
+</dict>
+</plist>
+`, launchdLabelPrefix, s.Name, args.String(), logFile, logFile)), nil
+}
+
+// scheduledTaskManager runs the services as scheduled tasks that start when the user logs in. The binaries do not
+// implement the control protocol of Windows services, so they cannot be registered with the service manager.
+type scheduledTaskManager struct{}
+
+func (m *scheduledTaskManager) Name() string { return "Task Scheduler" }
+
+func (m *scheduledTaskManager) Definition(s SlimService) string {
+	return `\` + s.ServiceName()
+}
+
+func (m *scheduledTaskManager) Install(s SlimService) error {
+	_, err := utils.RunCmdAndWait("schtasks", scheduledTaskArgs(s)...)
+	return err
+}
+
+func (m *scheduledTaskManager) Uninstall(s SlimService) error {
+	_, _ = utils.RunCmdAndWait("schtasks", "/End", "/TN", s.ServiceName())
+	_, err := utils.RunCmdAndWait("schtasks", "/Delete", "/TN", s.ServiceName(), "/F")
+	return err
+}
+
+func (m *scheduledTaskManager) Start(s SlimService) error {
+	_, err := utils.RunCmdAndWait("schtasks", "/Run", "/TN", s.ServiceName())
+	return err
+}
+
+func (m *scheduledTaskManager) Stop(s SlimService) error {
+	_, err := utils.RunCmdAndWait("schtasks", "/End", "/TN", s.ServiceName())
+	return err
+}
+
+func (m *scheduledTaskManager) Status(s SlimService) (string, error) {
+	out, err := utils.RunCmdAndWait("schtasks", "/Query", "/TN", s.ServiceName(), "/FO", "CSV", "/NH")
+	if err != nil {
+		return serviceStatusNotInstalled, nil
+	}
+	if strings.Contains(out, `"Running"`) {
+		return serviceStatusRunning, nil
+	}
+	return serviceStatusStopped, nil
+}
+
+// scheduledTaskArgs returns the arguments of schtasks that create the task of a service, replacing an existing one.
+func scheduledTaskArgs(s SlimService) []string {
+	command := make([]string, 0, len(s.Args)+1)
+	for _, arg := range append([]string{s.Path}, s.Args...) {
+		if strings.ContainsAny(arg, " \t") {
+			arg = `"` + arg + `"`
+		}
+		command = append(command, arg)
+	}
+	return []string{"/Create", "/TN", s.ServiceName(), "/TR", strings.Join(command, " "), "/SC", "ONLOGON", "/RL", "LIMITED", "/F"}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"encoding/xml"
+	path_filepath "path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemdUnit(t *testing.T) {
+	unit := systemdUnit(SlimService{Name: "scheduler", Path: "/home/dapr user/.dapr/bin/scheduler", Args: []string{"--etcd-data-dir", "/home/dapr user/.dapr/scheduler"}})
+	assert.Contains(t, unit, "Description=Dapr scheduler service\n")
+	assert.Contains(t, unit, `ExecStart="/home/dapr user/.dapr/bin/scheduler" --etcd-data-dir "/home/dapr user/.dapr/scheduler"`+"\n")
+	assert.Contains(t, unit, "Restart=on-failure\n")
+	assert.Contains(t, unit, "WantedBy=default.target\n")
+}
+
+func TestLaunchdPlist(t *testing.T) {
+	plist, err := launchdPlist(SlimService{Name: "placement", Path: "/Users/me/.dapr/bin/placement", Args: []string{"--id", "a&b"}}, "/Users/me/.dapr/logs/dapr-placement.log")
+	assert.NoError(t, err)
+
+	var doc struct {
+		Dict struct {
+			Keys   []string `xml:"key"`
+			Values []string `xml:"string"`
+			Array  struct {
+				Strings []string `xml:"string"`
+			} `xml:"array"`
+		} `xml:"dict"`
+	}
+	assert.NoError(t, xml.Unmarshal(plist, &doc), "the property list is valid XML")
+	assert.Equal(t, []string{"Label", "ProgramArguments", "RunAtLoad", "KeepAlive", "StandardOutPath", "StandardErrorPath"}, doc.Dict.Keys)
+	assert.Equal(t, []string{"io.dapr.placement", "/Users/me/.dapr/logs/dapr-placement.log", "/Users/me/.dapr/logs/dapr-placement.log"}, doc.Dict.Values)
+	assert.Equal(t, []string{"/Users/me/.dapr/bin/placement", "--id", "a&b"}, doc.Dict.Array.Strings)
+}
+
+func TestScheduledTaskArgs(t *testing.T) {
+	args := scheduledTaskArgs(SlimService{Name: "placement", Path: `C:\Users\dapr user\.dapr\bin\placement.exe`})
+	assert.Equal(t, []string{"/Create", "/TN", "dapr-placement", "/TR", `"C:\Users\dapr user\.dapr\bin\placement.exe"`, "/SC", "ONLOGON", "/RL", "LIMITED", "/F"}, args)
+}
+
+func TestNewServiceManager(t *testing.T) {
+	s := SlimService{Name: "placement"}
+
+	m, err := newServiceManager("linux")
+	assert.NoError(t, err)
+	assert.Equal(t, "systemd", m.Name())
+	assert.True(t, strings.HasSuffix(m.Definition(s), path_filepath.Join("systemd", "user", "dapr-placement.service")), m.Definition(s))
+
+	m, err = newServiceManager("darwin")
+	assert.NoError(t, err)
+	assert.Equal(t, "launchd", m.Name())
+	assert.True(t, strings.HasSuffix(m.Definition(s), path_filepath.Join("Library", "LaunchAgents", "io.dapr.placement.plist")), m.Definition(s))
+
+	m, err = newServiceManager("windows")
+	assert.NoError(t, err)
+	assert.Equal(t, `\dapr-placement`, m.Definition(s))
+
+	_, err = newServiceManager("plan9")
+	assert.EqualError(t, err, "services are not supported on plan9")
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/dapr/cli/utils"
+)
+
+// sidecarEndpoint returns the URL of path in the Dapr API of the sidecar of appID, or of the only running sidecar if
+// appID is empty, and the HTTP client to call it with.
+func (s *Standalone) sidecarEndpoint(appID, socket, path string) (string, *http.Client, error) {
+	l, err := s.process.List()
+	if err != nil {
+		return "", nil, err
+	}
+
+	instance, err := getSidecar(l, appID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	endpoint, httpc := instanceEndpoint(instance, socket, path)
+	return endpoint, httpc, nil
+}
+
+// instanceEndpoint returns the URL of path in the Dapr API of the sidecar instance, and the HTTP client to call it with.
+func instanceEndpoint(instance ListOutput, socket, path string) (string, *http.Client) {
+	httpc := &http.Client{}
+	if socket != "" {
+		httpc.Transport = &http.Transport{
+			DialContext: func(_ context.Context, _, _ string) (net.Conn, error) {
+				return net.Dial("unix", utils.GetSocket(socket, instance.AppID, "http"))
+			},
+		}
+		return fmt.Sprintf("http://unix/%s", path), httpc
+	}
+	return fmt.Sprintf("http://localhost:%v/%s", instance.HTTPPort, path), httpc
+}
+
+// dialSidecarGRPC connects to the gRPC API of the sidecar of appID, or of the only running sidecar if appID is empty.
+func (s *Standalone) dialSidecarGRPC(appID, socket string) (*grpc.ClientConn, error) {
+	list, err := s.process.List()
+	if err != nil {
+		return nil, err
+	}
+	instance, err := getSidecar(list, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	address := fmt.Sprintf("127.0.0.1:%v", instance.GRPCPort)
+	if socket != "" {
+		address = "unix://" + utils.GetSocket(socket, instance.AppID, "grpc")
+	}
+	return grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+// getSidecar returns the running Dapr instance of appID, or the only running instance if appID is empty.
+func getSidecar(list []ListOutput, appID string) (ListOutput, error) {
+	if appID != "" {
+		return getDaprInstance(list, appID)
+	}
+	switch len(list) {
+	case 0:
+		return ListOutput{}, errors.New("couldn't find a running Dapr instance")
+	case 1:
+		return list[0], nil
+	}
+	appIDs := make([]string, 0, len(list))
+	for _, lo := range list {
+		appIDs = append(appIDs, lo.AppID)
+	}
+	return ListOutput{}, fmt.Errorf("more than one Dapr instance is running: %s. Use --app-id to choose one", strings.Join(appIDs, ", "))
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	// sidecarContainerPrefix is the prefix of the name of the container of a sidecar run by --sidecar-in-container.
+	sidecarContainerPrefix = "dapr_sidecar_"
+	// containerComponentsPath and containerConfigPath are where the components and the configuration of the app
+	// are mounted in the container of its sidecar.
+	containerComponentsPath = "/dapr/components"
+	containerConfigPath     = "/dapr/config.yaml"
+	// dockerHostGateway is the host name of the machine running the CLI inside a container of Docker Desktop.
+	dockerHostGateway = "host.docker.internal"
+	linuxOS           = "linux"
+)
+
+// minAppChannelAddressVersion is the first version of daprd with --app-channel-address, which lets a sidecar in a
+// container reach an app on the host.
+var minAppChannelAddressVersion = version.Must(version.NewVersion("1.11.0"))
+
+// SidecarContainerName returns the name of the container the sidecar of appID runs in with --sidecar-in-container.
+func SidecarContainerName(appID string) string {
+	return sidecarContainerPrefix + appID
+}
+
+// sidecarImage returns the image of the sidecar container: config.SidecarImage, or the Dapr image of the version of
+// the installed runtime.
+func sidecarImage(config *RunConfig) (string, error) {
+	if config.SidecarImage != "" {
+		return config.SidecarImage, nil
+	}
+	version := strings.TrimSpace(GetRuntimeVersion())
+	if version == "" || version == "n/a" {
+		return "", errors.New("the version of the Dapr runtime could not be detected. Run dapr init or use --sidecar-image")
+	}
+	return fmt.Sprintf("%s:%s", daprDockerImageName, version), nil
+}
+
+// getSidecarContainerCommand returns the command that runs the sidecar of config in a container, which is removed
+// once it stops.
+func getSidecarContainerCommand(config *RunConfig) (*exec.Cmd, error) {
+	image, err := sidecarImage(config)
+	if err != nil {
+		return nil, err
+	}
+	containerRuntime, err := NewContainerRuntime(config.ContainerRuntime)
+	if err != nil {
+		return nil, err
+	}
+	args, err := sidecarContainerArgs(config, image, runtime.GOOS)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(containerRuntime.Name(), args...)
+	// The additional variables are passed to the container by name only, so that their values are not printed.
+	if env := config.sidecarEnv(); len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	return cmd, nil
+}
+
+// sidecarContainerArgs returns the arguments of the container runtime that run the sidecar of config in a container
+// of image on the operating system goos. The components and the configuration are mounted read-only.
+// On Linux the container shares the network of the host. Elsewhere, where containers run in a virtual machine,
+// the ports of the sidecar are published and the app, the placement service and sentry are reached through the
+// gateway to the host. The resource limits of the sidecar are limits of the container.
+func sidecarContainerArgs(config *RunConfig, image, goos string) ([]string, error) {
+	limits, err := config.sidecarLimits()
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"run", "--rm", "--name", SidecarContainerName(config.AppID)}
+	args = append(args, containerLimitArgs(limits)...)
+
+	containerConfig := *config
+	if config.ComponentsPath != "" {
+		path, err := filepath.Abs(config.ComponentsPath)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--volume", fmt.Sprintf("%s:%s:ro", path, containerComponentsPath))
+		containerConfig.ComponentsPath = containerComponentsPath
+	}
+	sentryAddress := mtlsEndpoint(config.ConfigFile)
+	if config.ConfigFile != "" {
+		path, err := filepath.Abs(config.ConfigFile)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--volume", fmt.Sprintf("%s:%s:ro", path, containerConfigPath))
+	}
+
+	hostAlias := ""
+	if goos == linuxOS {
+		args = append(args, "--network", "host")
+	} else {
+		hostAlias = dockerHostGateway
+		for _, port := range []int{config.HTTPPort, config.GRPCPort, config.MetricsPort, config.InternalGRPCPort, config.ProfilePort} {
+			if port > 0 {
+				args = append(args, "--publish", fmt.Sprintf("%d:%d", port, port))
+			}
+		}
+		containerConfig.PlacementHostAddr = hostToGateway(config.PlacementHostAddr, hostAlias)
+		if sentryAddress != "" {
+			sentryAddress = hostToGateway(sentryAddress, hostAlias)
+		}
+	}
+
+	for _, kv := range config.sidecarEnv() {
+		key, _, _ := strings.Cut(kv, "=")
+		args = append(args, "--env", key)
+	}
+
+	args = append(args, image, "./daprd")
+	// The configuration is passed separately and mTLS is set up from the configuration on the host, as getArgs
+	// cannot read the mounted one.
+	containerConfig.ConfigFile = ""
+	args = append(args, containerConfig.getArgs()...)
+	if config.ConfigFile != "" {
+		args = append(args, "--config", containerConfigPath)
+	}
+	if sentryAddress != "" {
+		args = append(args, "--enable-mtls", "--sentry-address", sentryAddress)
+	}
+	if hostAlias != "" && config.AppPort > 0 {
+		if err = checkAppChannelAddress(image); err != nil {
+			return nil, err
+		}
+		args = append(args, "--app-channel-address", hostAlias)
+	}
+	return args, nil
+}
+
+// checkAppChannelAddress returns an error if the daprd of image, by the version of its tag, has no
+// --app-channel-address flag. Images whose tag is not a version, such as latest or dev, are assumed to have it.
+func checkAppChannelAddress(image string) error {
+	name := image[strings.LastIndex(image, "/")+1:]
+	_, tag, ok := strings.Cut(name, ":")
+	if !ok {
+		return nil
+	}
+	v, err := version.NewVersion(tag)
+	if err != nil || !v.LessThan(minAppChannelAddressVersion) {
+		return nil
+	}
+	return fmt.Errorf("the sidecar of Dapr %s cannot reach an app on the host from a container on this system, as that requires Dapr %s or later. Use a newer --sidecar-image, or run the sidecar without --sidecar-in-container", v, minAppChannelAddressVersion)
+}
+
+// hostToGateway replaces a local host in addr, given as host:port, with gateway.
+func hostToGateway(addr, gateway string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	switch host {
+	case daprDefaultHost, "127.0.0.1", "::1":
+		return net.JoinHostPort(gateway, port)
+	default:
+		return addr
+	}
+}
+
+// StopSidecarContainer stops the container the sidecar of appID runs in with --sidecar-in-container.
+func StopSidecarContainer(containerRuntime, appID string) error {
+	r, err := NewContainerRuntime(containerRuntime)
+	if err != nil {
+		return err
+	}
+	if _, err = r.Run("stop", SidecarContainerName(appID)); err != nil {
+		return fmt.Errorf("failed to stop the sidecar container of %s: %w", appID, err)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSidecarContainerArgs(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("spec:\n  mtls:\n    enabled: true\n"), 0o600))
+	config := &RunConfig{
+		AppID:             "myapp",
+		AppPort:           3000,
+		HTTPPort:          3500,
+		GRPCPort:          50001,
+		ConfigFile:        configFile,
+		ComponentsPath:    filepath.Join(dir, "components"),
+		PlacementHostAddr: "localhost:50005",
+		Env:               map[string]string{"SECRET": "value"},
+	}
+
+	t.Run("linux shares the network of the host", func(t *testing.T) {
+		args, err := sidecarContainerArgs(config, "daprio/dapr:1.9.0", "linux")
+		require.NoError(t, err)
+		daprd := indexOf(args, "./daprd")
+		require.Greater(t, daprd, 0)
+		assert.Equal(t, []string{
+			"run", "--rm", "--name", "dapr_sidecar_myapp",
+			"--volume", filepath.Join(dir, "components") + ":/dapr/components:ro",
+			"--volume", configFile + ":/dapr/config.yaml:ro",
+			"--network", "host",
+			"--env", "SECRET",
+			"daprio/dapr:1.9.0",
+		}, args[:daprd], "the values of the variables are not passed on the command line")
+		assertArgumentEqual(t, "components-path", "/dapr/components", args)
+		assertArgumentEqual(t, "config", "/dapr/config.yaml", args)
+		assertArgumentEqual(t, "placement-host-address", "localhost:50005", args)
+		assertArgumentEqual(t, "sentry-address", "localhost:50001", args)
+		assert.Contains(t, args, "--enable-mtls")
+		assert.NotContains(t, args, "--app-channel-address")
+	})
+
+	t.Run("other systems publish the ports and reach the host through its gateway", func(t *testing.T) {
+		args, err := sidecarContainerArgs(config, "daprio/dapr:1.11.0", "darwin")
+		require.NoError(t, err)
+		assert.Subset(t, args, []string{"--publish", "3500:3500", "50001:50001", "host.docker.internal:50005", "host.docker.internal:50001"})
+		assert.Equal(t, []string{"--app-channel-address", "host.docker.internal"}, args[len(args)-2:])
+		assert.NotContains(t, args, "--network")
+	})
+
+	t.Run("runtimes without --app-channel-address fail on other systems", func(t *testing.T) {
+		_, err := sidecarContainerArgs(config, "daprio/dapr:1.9.0", "windows")
+		assert.ErrorContains(t, err, "requires Dapr 1.11.0 or later")
+
+		appConfig := *config
+		appConfig.AppPort = 0
+		_, err = sidecarContainerArgs(&appConfig, "daprio/dapr:1.9.0", "windows")
+		assert.NoError(t, err, "sidecars without an app do not need to reach it")
+	})
+}
+
+func TestCheckAppChannelAddress(t *testing.T) {
+	assert.NoError(t, checkAppChannelAddress("daprio/dapr:1.11.0"))
+	assert.NoError(t, checkAppChannelAddress("localhost:5000/daprio/dapr:1.12.1"))
+	assert.NoError(t, checkAppChannelAddress("daprio/dapr:latest"))
+	assert.NoError(t, checkAppChannelAddress("localhost:5000/daprd"))
+	assert.Error(t, checkAppChannelAddress("daprio/dapr:1.8.0"))
+	assert.Error(t, checkAppChannelAddress("ghcr.io/dapr/dapr:1.10.5"))
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestHostToGateway(t *testing.T) {
+	assert.Equal(t, "host.docker.internal:50005", hostToGateway("localhost:50005", dockerHostGateway))
+	assert.Equal(t, "host.docker.internal:50005", hostToGateway("127.0.0.1:50005", dockerHostGateway))
+	assert.Equal(t, "placement.example.com:50005", hostToGateway("placement.example.com:50005", dockerHostGateway))
+	assert.Equal(t, "localhost", hostToGateway("localhost", dockerHostGateway), "addresses without a port are kept")
+}
+
+func TestSidecarImage(t *testing.T) {
+	image, err := sidecarImage(&RunConfig{SidecarImage: "myregistry/daprd:dev"})
+	require.NoError(t, err)
+	assert.Equal(t, "myregistry/daprd:dev", image)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// SidecarLimits are the resources the sidecar of an app may use. The zero value does not limit the sidecar.
+type SidecarLimits struct {
+	// MaxMemory is the maximum memory of the sidecar in bytes, or 0.
+	MaxMemory int64
+	// CPUs is the maximum number of CPUs the sidecar may use, such as 0.5, or 0.
+	CPUs float64
+}
+
+// IsSet returns true if the sidecar is limited.
+func (l SidecarLimits) IsSet() bool {
+	return l.MaxMemory > 0 || l.CPUs > 0
+}
+
+// sidecarLimits returns the limits of the sidecar of config. The memory is a quantity such as 512Mi or 1G.
+func (config *RunConfig) sidecarLimits() (SidecarLimits, error) {
+	limits := SidecarLimits{CPUs: config.DaprCPULimit}
+	if config.DaprCPULimit < 0 {
+		return limits, fmt.Errorf("invalid CPU limit %v of the sidecar: it must not be negative", config.DaprCPULimit)
+	}
+	if config.DaprMaxMemory != "" {
+		q, err := resource.ParseQuantity(config.DaprMaxMemory)
+		if err != nil {
+			return limits, fmt.Errorf("invalid maximum memory %q of the sidecar, such as 512Mi or 1G: %w", config.DaprMaxMemory, err)
+		}
+		if q.Sign() <= 0 {
+			return limits, fmt.Errorf("invalid maximum memory %q of the sidecar: it must be positive", config.DaprMaxMemory)
+		}
+		limits.MaxMemory = q.Value()
+	}
+	return limits, nil
+}
+
+// systemdRunArgs returns the arguments of systemd-run that run a command in a transient scope, whose cgroup
+// enforces limits. The scope is a unit of the service manager of the user, unless system is set.
+func systemdRunArgs(limits SidecarLimits, system bool) []string {
+	args := []string{"--scope", "--quiet", "--collect"}
+	if !system {
+		args = append([]string{"--user"}, args...)
+	}
+	if limits.MaxMemory > 0 {
+		args = append(args, "-p", "MemoryMax="+strconv.FormatInt(limits.MaxMemory, 10))
+	}
+	if limits.CPUs > 0 {
+		args = append(args, "-p", fmt.Sprintf("CPUQuota=%d%%", int(limits.CPUs*100+0.5)))
+	}
+	return append(args, "--")
+}
+
+// containerLimitArgs returns the arguments of the container runtime that limit the container of a sidecar.
+func containerLimitArgs(limits SidecarLimits) []string {
+	args := []string{}
+	if limits.MaxMemory > 0 {
+		args = append(args, "--memory", strconv.FormatInt(limits.MaxMemory, 10))
+	}
+	if limits.CPUs > 0 {
+		args = append(args, "--cpus", strconv.FormatFloat(limits.CPUs, 'f', -1, 64))
+	}
+	return args
+}
+
+// cpuRate returns the CPU rate of a Windows job object that limits its processes to cpus of numCPU CPUs, in
+// hundredths of a percent of the cycles of all CPUs.
+func cpuRate(cpus float64, numCPU int) uint32 {
+	rate := int(cpus / float64(numCPU) * 10000)
+	switch {
+	case rate < 1:
+		return 1
+	case rate > 10000:
+		return 10000
+	default:
+		return uint32(rate)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// limitSidecarCommand returns cmd run by systemd-run in a scope whose cgroup enforces limits.
+func limitSidecarCommand(cmd *exec.Cmd, limits SidecarLimits) (*exec.Cmd, error) {
+	if !limits.IsSet() {
+		return cmd, nil
+	}
+	systemdRun, err := exec.LookPath("systemd-run")
+	if err != nil {
+		return nil, errors.New("limiting the resources of the sidecar on Linux requires systemd-run, which was not found")
+	}
+	args := systemdRunArgs(limits, os.Geteuid() == 0)
+	args = append(args, cmd.Path)
+	args = append(args, cmd.Args[1:]...)
+	limited := exec.Command(systemdRun, args...)
+	limited.Env = cmd.Env
+	limited.Dir = cmd.Dir
+	return limited, nil
+}
+
+// limitSidecarProcess does nothing on Linux, where the sidecar is limited by the scope it is started in.
+func limitSidecarProcess(process *os.Process, limits SidecarLimits) error {
+	return nil
+}
@@ -0,0 +1,38 @@
+//go:build !linux && !windows
+// +build !linux,!windows
+
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/dapr/cli/pkg/print"
+)
+
+// limitSidecarCommand returns cmd, as the resources of processes cannot be limited on this operating system.
+func limitSidecarCommand(cmd *exec.Cmd, limits SidecarLimits) (*exec.Cmd, error) {
+	if limits.IsSet() {
+		print.WarningStatusEvent(os.Stdout, "Limiting the resources of the sidecar is not supported on %s. Use --sidecar-in-container to limit its container instead", runtime.GOOS)
+	}
+	return cmd, nil
+}
+
+// limitSidecarProcess does nothing, as the resources of processes cannot be limited on this operating system.
+func limitSidecarProcess(process *os.Process, limits SidecarLimits) error {
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSidecarLimits(t *testing.T) {
+	limits, err := (&RunConfig{}).sidecarLimits()
+	require.NoError(t, err)
+	assert.False(t, limits.IsSet())
+
+	limits, err = (&RunConfig{DaprMaxMemory: "512Mi", DaprCPULimit: 0.5}).sidecarLimits()
+	require.NoError(t, err)
+	assert.Equal(t, SidecarLimits{MaxMemory: 512 * 1024 * 1024, CPUs: 0.5}, limits)
+
+	limits, err = (&RunConfig{DaprMaxMemory: "1G"}).sidecarLimits()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000*1000*1000), limits.MaxMemory)
+
+	_, err = (&RunConfig{DaprMaxMemory: "lots"}).sidecarLimits()
+	assert.ErrorContains(t, err, "invalid maximum memory")
+	_, err = (&RunConfig{DaprMaxMemory: "0"}).sidecarLimits()
+	assert.ErrorContains(t, err, "must be positive")
+	_, err = (&RunConfig{DaprCPULimit: -1}).sidecarLimits()
+	assert.ErrorContains(t, err, "must not be negative")
+}
+
+func TestSystemdRunArgs(t *testing.T) {
+	assert.Equal(t, []string{"--user", "--scope", "--quiet", "--collect", "-p", "MemoryMax=536870912", "-p", "CPUQuota=150%", "--"},
+		systemdRunArgs(SidecarLimits{MaxMemory: 512 * 1024 * 1024, CPUs: 1.5}, false))
+	assert.Equal(t, []string{"--scope", "--quiet", "--collect", "-p", "CPUQuota=25%", "--"},
+		systemdRunArgs(SidecarLimits{CPUs: 0.25}, true), "root uses the system service manager")
+}
+
+func TestContainerLimitArgs(t *testing.T) {
+	assert.Empty(t, containerLimitArgs(SidecarLimits{}))
+	assert.Equal(t, []string{"--memory", "268435456", "--cpus", "0.5"}, containerLimitArgs(SidecarLimits{MaxMemory: 256 * 1024 * 1024, CPUs: 0.5}))
+
+	args, err := sidecarContainerArgs(&RunConfig{AppID: "myapp", DaprMaxMemory: "256Mi"}, "daprio/dapr:1.9.0", "linux")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"run", "--rm", "--name", "dapr_sidecar_myapp", "--memory", "268435456"}, args[:6])
+}
+
+func TestCPURate(t *testing.T) {
+	assert.Equal(t, uint32(1250), cpuRate(1, 8), "one of eight CPUs is 12.5% of the cycles")
+	assert.Equal(t, uint32(5000), cpuRate(2, 4))
+	assert.Equal(t, uint32(10000), cpuRate(16, 4), "the rate is capped at all CPUs")
+	assert.Equal(t, uint32(1), cpuRate(0.00001, 64), "the rate is at least the minimum")
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	jobObjectCPURateControlEnable  = 0x1
+	jobObjectCPURateControlHardCap = 0x4
+)
+
+// jobObjectCPURateControlInformation is JOBOBJECT_CPU_RATE_CONTROL_INFORMATION, with the CpuRate of its union.
+type jobObjectCPURateControlInformation struct {
+	ControlFlags uint32
+	CPURate      uint32
+}
+
+// limitSidecarCommand returns cmd, as the sidecar is limited by a job object once it is started on Windows.
+func limitSidecarCommand(cmd *exec.Cmd, limits SidecarLimits) (*exec.Cmd, error) {
+	return cmd, nil
+}
+
+// limitSidecarProcess assigns process to a new job object that enforces limits.
+func limitSidecarProcess(process *os.Process, limits SidecarLimits) error {
+	if !limits.IsSet() || process == nil {
+		return nil
+	}
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create a job object: %w", err)
+	}
+	// The job object is kept by the system as long as the sidecar is assigned to it.
+	defer windows.CloseHandle(job)
+
+	if limits.MaxMemory > 0 {
+		info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{}
+		info.BasicLimitInformation.LimitFlags = windows.JOB_OBJECT_LIMIT_PROCESS_MEMORY
+		info.ProcessMemoryLimit = uintptr(limits.MaxMemory)
+		if _, err = windows.SetInformationJobObject(job, windows.JobObjectExtendedLimitInformation, uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+			return fmt.Errorf("failed to limit the memory of the job object: %w", err)
+		}
+	}
+	if limits.CPUs > 0 {
+		info := jobObjectCPURateControlInformation{
+			ControlFlags: jobObjectCPURateControlEnable | jobObjectCPURateControlHardCap,
+			CPURate:      cpuRate(limits.CPUs, runtime.NumCPU()),
+		}
+		if _, err = windows.SetInformationJobObject(job, windows.JobObjectCpuRateControlInformation, uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+			return fmt.Errorf("failed to limit the CPU rate of the job object: %w", err)
+		}
+	}
+
+	handle, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(process.Pid))
+	if err != nil {
+		return fmt.Errorf("failed to open the sidecar process: %w", err)
+	}
+	defer windows.CloseHandle(handle)
+	if err = windows.AssignProcessToJobObject(job, handle); err != nil {
+		return fmt.Errorf("failed to assign the sidecar to the job object: %w", err)
+	}
+	return nil
+}
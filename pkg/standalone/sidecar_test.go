@@ -0,0 +1,39 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSidecar(t *testing.T) {
+	list := []ListOutput{{AppID: "app1"}, {AppID: "app2"}}
+
+	lo, err := getSidecar(list, "app2")
+	require.NoError(t, err)
+	assert.Equal(t, "app2", lo.AppID)
+
+	lo, err = getSidecar(list[:1], "")
+	require.NoError(t, err)
+	assert.Equal(t, "app1", lo.AppID)
+
+	_, err = getSidecar(list, "")
+	assert.EqualError(t, err, "more than one Dapr instance is running: app1, app2. Use --app-id to choose one")
+
+	_, err = getSidecar(nil, "")
+	assert.EqualError(t, err, "couldn't find a running Dapr instance")
+}
@@ -21,7 +21,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"os"
 	"path"
@@ -49,14 +48,13 @@ const (
 	latestVersion   = "latest"
 	daprDefaultHost = "localhost"
 
-	pubSubYamlFileName     = "pubsub.yaml"
-	stateStoreYamlFileName = "statestore.yaml"
-
 	// used when DAPR_DEFAULT_IMAGE_REGISTRY is not set.
 	dockerContainerRegistryName = "dockerhub"
 	daprDockerImageName         = "daprio/dapr"
 	redisDockerImageName        = "redis:6"
 	zipkinDockerImageName       = "openzipkin/zipkin"
+	kafkaDockerImageName        = "bitnami/kafka:3.4"
+	postgresDockerImageName     = "postgres:15"
 
 	// used when DAPR_DEFAULT_IMAGE_REGISTRY is set as GHCR.
 	githubContainerRegistryName = "ghcr"
@@ -64,6 +62,8 @@ const (
 	daprGhcrImageName           = "dapr"
 	redisGhcrImageName          = "3rdparty/redis"
 	zipkinGhcrImageName         = "3rdparty/zipkin"
+	kafkaGhcrImageName          = "3rdparty/kafka"
+	postgresGhcrImageName       = "3rdparty/postgres"
 
 	// DaprPlacementContainerName is the container name of placement service.
 	DaprPlacementContainerName = "dapr_placement"
@@ -71,31 +71,58 @@ const (
 	DaprRedisContainerName = "dapr_redis"
 	// DaprZipkinContainerName is the container name of zipkin.
 	DaprZipkinContainerName = "dapr_zipkin"
+	// DaprKafkaContainerName is the container name of kafka.
+	DaprKafkaContainerName = "dapr_kafka"
+	// DaprPostgresContainerName is the container name of postgres.
+	DaprPostgresContainerName = "dapr_postgres"
 
 	errInstallTemplate = "please run `dapr uninstall` first before running `dapr init`"
+
+	// RedisInitComponent runs a Redis container and creates the Redis state store and pub/sub components.
+	RedisInitComponent = "redis"
+	// ZipkinInitComponent runs a Zipkin container and configures tracing to it.
+	ZipkinInitComponent = "zipkin"
+	// KafkaInitComponent runs a Kafka container and creates the Kafka pub/sub component.
+	KafkaInitComponent = "kafka"
+	// PostgresInitComponent runs a PostgreSQL container and creates the PostgreSQL state store component.
+	PostgresInitComponent = "postgres"
 )
 
 var (
 	defaultImageRegistryName string
 	privateRegTemplateString = "%s/dapr/%s"
 	isAirGapInit             bool
+
+	// DefaultInitComponents are the components set up by a non-slim installation.
+	DefaultInitComponents = []string{RedisInitComponent, ZipkinInitComponent}
+	// InitComponents are all components that can be set up by a non-slim installation.
+	InitComponents = []string{RedisInitComponent, ZipkinInitComponent, KafkaInitComponent, PostgresInitComponent}
 )
 
-type configuration struct {
-	APIVersion string `yaml:"apiVersion"`
-	Kind       string `yaml:"kind"`
-	Metadata   struct {
-		Name string `yaml:"name"`
-	} `yaml:"metadata"`
-	Spec struct {
-		Tracing struct {
-			SamplingRate string `yaml:"samplingRate,omitempty"`
-			Zipkin       struct {
-				EndpointAddress string `yaml:"endpointAddress,omitempty"`
-			} `yaml:"zipkin,omitempty"`
-		} `yaml:"tracing,omitempty"`
-	} `yaml:"spec"`
-}
+// defaultConfigurationHeader and defaultConfigurationFooter surround the tracing settings of the default
+// Dapr configuration written by dapr init.
+const (
+	defaultConfigurationHeader = `# The Dapr configuration of the apps run with dapr run.
+# See https://docs.dapr.io/reference/resource-specs/configuration-schema/ for all settings.
+apiVersion: dapr.io/v1alpha1
+kind: Configuration
+metadata:
+  name: daprConfig
+spec:
+`
+	defaultConfigurationFooter = `  metric:
+    enabled: true
+  # Uncomment to only allow the listed apps to invoke the apps run with this configuration.
+  # accessControl:
+  #   defaultAction: deny
+  #   trustDomain: public
+  #   policies:
+  #   - appId: caller-app
+  #     defaultAction: allow
+  #     trustDomain: public
+  #     namespace: default
+`
+)
 
 type component struct {
 	APIVersion string `yaml:"apiVersion"`
@@ -123,6 +150,35 @@ type initInfo struct {
 	dashboardVersion string
 	dockerNetwork    string
 	imageRegistryURL string
+	containerRuntime ContainerRuntime
+	skipVerify       bool
+	components       []string
+	// binDir is the directory the binaries are installed to. It defaults to the bin directory of the installation.
+	binDir string
+	// resourcesPath and configFile replace the default resources directory and Dapr configuration file.
+	resourcesPath string
+	configFile    string
+}
+
+// withComponent returns true if the component name is set up by the installation.
+func (i initInfo) withComponent(name string) bool {
+	return containsString(i.components, name)
+}
+
+// resourcesDir returns the directory the default components are written to.
+func (i initInfo) resourcesDir() string {
+	if i.resourcesPath != "" {
+		return i.resourcesPath
+	}
+	return DefaultComponentsDirPath()
+}
+
+// configFilePath returns the path the default Dapr configuration is written to.
+func (i initInfo) configFilePath() string {
+	if i.configFile != "" {
+		return i.configFile
+	}
+	return DefaultConfigFilePath()
 }
 
 type daprImageInfo struct {
@@ -144,22 +200,67 @@ func isBinaryInstallationRequired(binaryFilePrefix, installDir string) (bool, er
 	return true, nil
 }
 
-// Init installs Dapr on a local machine using the supplied runtimeVersion.
-func Init(runtimeVersion, dashboardVersion string, dockerNetwork string, slimMode bool, imageRegistryURL string, fromDir string) error {
-	var err error
+// InitConfig represents the options of a self-hosted init.
+type InitConfig struct {
+	// RuntimeVersion and DashboardVersion are the versions to install, or latest.
+	RuntimeVersion   string
+	DashboardVersion string
+	// DockerNetwork is the network the containers of a non-slim installation are run in.
+	DockerNetwork string
+	// SlimMode installs the binaries only, without containers.
+	SlimMode bool
+	// ImageRegistryURL is the private registry the container images are pulled from, if not empty.
+	ImageRegistryURL string
+	// FromDir is the directory of a bundle to install from instead of downloading, if not empty.
+	FromDir string
+	// ContainerRuntime is the container runtime the containers are run with, Docker or Podman.
+	ContainerRuntime string
+	// InsecureSkipVerify skips verifying the checksums of the downloaded binaries.
+	InsecureSkipVerify bool
+	// Components are the containers and component files set up by a non-slim installation, see DefaultInitComponents.
+	Components []string
+	// ResourcesPath and ConfigFile, if not empty, replace the default resources directory and Dapr configuration
+	// file, and are recorded as the defaults of the other commands.
+	ResourcesPath string
+	ConfigFile    string
+	// DryRun prints the downloads, files and containers of the installation instead of installing them.
+	DryRun bool
+}
+
+// Init installs Dapr on a local machine with the given configuration.
+func Init(config InitConfig) error {
 	var bundleDet bundleDetails
-	fromDir = strings.TrimSpace(fromDir)
+	runtimeVersion, dashboardVersion := config.RuntimeVersion, config.DashboardVersion
+	fromDir := strings.TrimSpace(config.FromDir)
+	resourcesPath, err := absPathOrEmpty(config.ResourcesPath)
+	if err != nil {
+		return err
+	}
+	configFile, err := absPathOrEmpty(config.ConfigFile)
+	if err != nil {
+		return err
+	}
 	// AirGap init flow is true when fromDir var is set i.e. --from-dir flag has value.
 	setAirGapInit(fromDir)
-	if !slimMode {
-		// If --slim installation is not requested, check if docker is installed.
-		dockerInstalled := utils.IsDockerInstalled()
-		if !dockerInstalled {
-			return errors.New("could not connect to Docker. Docker may not be installed or running")
+	containerRuntime, err := NewContainerRuntime(config.ContainerRuntime)
+	if err != nil {
+		return err
+	}
+	if !config.SlimMode {
+		if err = ValidateInitComponents(config.Components); err != nil {
+			return err
+		}
+		// If --slim installation is not requested, check if the container runtime is installed.
+		err = containerRuntime.CheckRunning()
+		if err != nil {
+			return err
+		}
+		if host := DockerHostName(); host != "" {
+			print.InfoStatusEvent(os.Stdout, "Running the Dapr containers on the Docker daemon at %s. Their ports must be reachable from this machine", host)
 		}
 
 		// Initialize default registry only if any of --slim or --image-registry or --from-dir are not given.
-		if len(strings.TrimSpace(imageRegistryURL)) == 0 && !isAirGapInit {
+		if len(strings.TrimSpace(config.ImageRegistryURL)) == 0 && !isAirGapInit {
 			defaultImageRegistryName, err = utils.GetDefaultRegistry(githubContainerRegistryName, dockerContainerRegistryName)
 			if err != nil {
 				return err
@@ -179,8 +280,7 @@ func Init(runtimeVersion, dashboardVersion string, dockerNetwork string, slimMod
 	if dashboardVersion == latestVersion && !isAirGapInit {
 		dashboardVersion, err = cli_ver.GetDashboardVersion()
 		if err != nil {
-			print.WarningStatusEvent(os.Stdout, "cannot get the latest dashboard version: '%s'. Try specifying --dashboard-version=<desired_version>", err)
-			print.WarningStatusEvent(os.Stdout, "continuing, but dashboard will be unavailable")
+			return fmt.Errorf("cannot get the latest dashboard version: '%w'. Try specifying --dashboard-version=<desired_version>", err)
 		}
 	}
 
@@ -205,20 +305,39 @@ func Init(runtimeVersion, dashboardVersion string, dockerNetwork string, slimMod
 	// After this point runtimeVersion will not be latest string but rather actual version.
 
 	print.InfoStatusEvent(os.Stdout, "Installing runtime version %s", runtimeVersion)
+	print.DebugStatusEvent(os.Stderr, "Dashboard version: %s, slim mode: %t, container runtime: %s, docker network: %q, image registry: %q, bundle directory: %q, components: %v",
+		dashboardVersion, config.SlimMode, containerRuntime.Name(), config.DockerNetwork, config.ImageRegistryURL, fromDir, config.Components)
 
 	daprBinDir := defaultDaprBinPath()
-	err = prepareDaprInstallDir(daprBinDir)
-	if err != nil {
-		return err
-	}
-
 	// confirm if installation is required.
 	if ok, er := isBinaryInstallationRequired(daprRuntimeFilePrefix, daprBinDir); !ok {
 		return er
 	}
 
-	var wg sync.WaitGroup
-	errorChan := make(chan error)
+	info := initInfo{
+		// values in bundleDet can be nil if fromDir is empty, so must be used in conjunction with fromDir.
+		bundleDet:        &bundleDet,
+		fromDir:          fromDir,
+		slimMode:         config.SlimMode,
+		runtimeVersion:   runtimeVersion,
+		dashboardVersion: dashboardVersion,
+		dockerNetwork:    config.DockerNetwork,
+		imageRegistryURL: config.ImageRegistryURL,
+		containerRuntime: containerRuntime,
+		skipVerify:       config.InsecureSkipVerify,
+		components:       config.Components,
+		resourcesPath:    resourcesPath,
+		configFile:       configFile,
+	}
+	if config.DryRun {
+		return printInitActions(info)
+	}
+
+	err = prepareDaprInstallDir(daprBinDir)
+	if err != nil {
+		return err
+	}
+
 	initSteps := []func(*sync.WaitGroup, chan<- error, initInfo){
 		createSlimConfiguration,
 		createComponentsAndConfiguration,
@@ -226,12 +345,13 @@ func Init(runtimeVersion, dashboardVersion string, dockerNetwork string, slimMod
 		installPlacement,
 		installDashboard,
 		runPlacementService,
-		runRedis,
-		runZipkin,
+	}
+	for _, c := range initContainers {
+		initSteps = append(initSteps, c.run)
 	}
 
-	// Init other configurations, containers.
-	wg.Add(len(initSteps))
+	installStep := print.BeginStep(os.Stdout, "Installing binaries and components")
+	defer installStep.End(print.Failure)
 
 	msg := "Downloading binaries and setting up components..."
 	if isAirGapInit {
@@ -241,33 +361,19 @@ func Init(runtimeVersion, dashboardVersion string, dockerNetwork string, slimMod
 	defer stopSpinning(print.Failure)
 
 	// Make default components directory.
-	err = makeDefaultComponentsDir()
+	err = makeDefaultComponentsDir(info.resourcesDir())
 	if err != nil {
 		return err
 	}
 
-	info := initInfo{
-		// values in bundleDet can be nil if fromDir is empty, so must be used in conjunction with fromDir.
-		bundleDet:        &bundleDet,
-		fromDir:          fromDir,
-		slimMode:         slimMode,
-		runtimeVersion:   runtimeVersion,
-		dashboardVersion: dashboardVersion,
-		dockerNetwork:    dockerNetwork,
-		imageRegistryURL: imageRegistryURL,
-	}
-	for _, step := range initSteps {
-		// Run init on the configurations and containers.
-		go step(&wg, errorChan, info)
+	// Init other configurations, containers.
+	err = runInitSteps(initSteps, info)
+	if err != nil {
+		return err
 	}
 
-	go func() {
-		wg.Wait()
-		close(errorChan)
-	}()
-
-	for err := range errorChan {
-		if err != nil {
+	if resourcesPath != "" || configFile != "" {
+		if err = saveInitLayout(resourcesPath, configFile); err != nil {
 			return err
 		}
 	}
@@ -279,19 +385,30 @@ func Init(runtimeVersion, dashboardVersion string, dockerNetwork string, slimMod
 		msg = "Extracted binaries and completed components set up."
 	}
 	print.SuccessStatusEvent(os.Stdout, msg)
+	installStep.End(print.Success)
+
+	verifyStep := print.BeginStep(os.Stdout, "Verifying the installation")
+	defer verifyStep.End(print.Failure)
 	print.InfoStatusEvent(os.Stdout, "%s binary has been installed to %s.", daprRuntimeFilePrefix, daprBinDir)
-	if slimMode {
+	if resourcesPath != "" || configFile != "" {
+		print.InfoStatusEvent(os.Stdout, "Resources directory %s and configuration file %s are the defaults of dapr run.", info.resourcesDir(), info.configFilePath())
+	}
+	if config.SlimMode {
 		// Print info on placement binary only on slim install.
 		print.InfoStatusEvent(os.Stdout, "%s binary has been installed to %s.", placementServiceFilePrefix, daprBinDir)
 	} else {
-		dockerContainerNames := []string{DaprPlacementContainerName, DaprRedisContainerName, DaprZipkinContainerName}
-		// Skip redis and zipkin in local installation mode.
-		if isAirGapInit {
-			dockerContainerNames = []string{DaprPlacementContainerName}
+		dockerContainerNames := []string{DaprPlacementContainerName}
+		// Skip the containers of the init components in local installation mode.
+		if !isAirGapInit {
+			for _, c := range initContainers {
+				if info.withComponent(c.component) {
+					dockerContainerNames = append(dockerContainerNames, c.name)
+				}
+			}
 		}
 		for _, container := range dockerContainerNames {
-			containerName := utils.CreateContainerName(container, dockerNetwork)
-			ok, err := confirmContainerIsRunningOrExists(containerName, true)
+			containerName := utils.CreateContainerName(container, config.DockerNetwork)
+			ok, err := confirmContainerIsRunningOrExists(containerRuntime, containerName, true)
 			if err != nil {
 				return err
 			}
@@ -299,141 +416,42 @@ func Init(runtimeVersion, dashboardVersion string, dockerNetwork string, slimMod
 				print.InfoStatusEvent(os.Stdout, "%s container is running.", containerName)
 			}
 		}
-		print.InfoStatusEvent(os.Stdout, "Use `docker ps` to check running containers.")
+		print.InfoStatusEvent(os.Stdout, "Use `%s ps` to check running containers.", containerRuntime.Name())
 	}
+	verifyStep.End(print.Success)
 	return nil
 }
 
-func runZipkin(wg *sync.WaitGroup, errorChan chan<- error, info initInfo) {
-	defer wg.Done()
-
-	if info.slimMode || isAirGapInit {
-		return
-	}
-
-	zipkinContainerName := utils.CreateContainerName(DaprZipkinContainerName, info.dockerNetwork)
-
-	exists, err := confirmContainerIsRunningOrExists(zipkinContainerName, false)
-	if err != nil {
-		errorChan <- err
-		return
-	}
-	args := []string{}
-
-	var imageName string
-
-	if exists {
-		// do not create container again if it exists.
-		args = append(args, "start", zipkinContainerName)
-	} else {
-		imageName, err = resolveImageURI(daprImageInfo{
-			ghcrImageName:      zipkinGhcrImageName,
-			dockerHubImageName: zipkinDockerImageName,
-			imageRegistryURL:   info.imageRegistryURL,
-			imageRegistryName:  defaultImageRegistryName,
-		})
-		if err != nil {
-			errorChan <- err
-			return
-		}
-
-		args = append(args,
-			"run",
-			"--name", zipkinContainerName,
-			"--restart", "always",
-			"-d",
-		)
-
-		if info.dockerNetwork != "" {
-			args = append(
-				args,
-				"--network", info.dockerNetwork,
-				"--network-alias", DaprZipkinContainerName)
-		} else {
-			args = append(
-				args,
-				"-p", "9411:9411")
-		}
-
-		args = append(args, imageName)
-	}
-	_, err = utils.RunCmdAndWait("docker", args...)
-
-	if err != nil {
-		runError := isContainerRunError(err)
-		if !runError {
-			errorChan <- parseDockerError("Zipkin tracing", err)
-		} else {
-			errorChan <- fmt.Errorf("docker %s failed with: %w", args, err)
+// ValidateInitComponents returns an error if any of components cannot be set up by dapr init.
+func ValidateInitComponents(components []string) error {
+	for _, c := range components {
+		if !containsString(InitComponents, c) {
+			return fmt.Errorf("invalid component %q. Valid values are: %s", c, strings.Join(InitComponents, ", "))
 		}
-		return
 	}
-	errorChan <- nil
+	return nil
 }
 
-func runRedis(wg *sync.WaitGroup, errorChan chan<- error, info initInfo) {
-	defer wg.Done()
-
-	if info.slimMode || isAirGapInit {
-		return
+// runInitSteps runs the given steps concurrently and returns the first error reported by any of them.
+func runInitSteps(steps []func(*sync.WaitGroup, chan<- error, initInfo), info initInfo) error {
+	var wg sync.WaitGroup
+	errorChan := make(chan error)
+	wg.Add(len(steps))
+	for _, step := range steps {
+		go step(&wg, errorChan, info)
 	}
 
-	redisContainerName := utils.CreateContainerName(DaprRedisContainerName, info.dockerNetwork)
-
-	exists, err := confirmContainerIsRunningOrExists(redisContainerName, false)
-	if err != nil {
-		errorChan <- err
-		return
-	}
-	args := []string{}
+	go func() {
+		wg.Wait()
+		close(errorChan)
+	}()
 
-	var imageName string
-	if exists {
-		// do not create container again if it exists.
-		args = append(args, "start", redisContainerName)
-	} else {
-		imageName, err = resolveImageURI(daprImageInfo{
-			ghcrImageName:      redisGhcrImageName,
-			dockerHubImageName: redisDockerImageName,
-			imageRegistryURL:   info.imageRegistryURL,
-			imageRegistryName:  defaultImageRegistryName,
-		})
+	for err := range errorChan {
 		if err != nil {
-			errorChan <- err
-			return
-		}
-
-		args = append(args,
-			"run",
-			"--name", redisContainerName,
-			"--restart", "always",
-			"-d",
-		)
-
-		if info.dockerNetwork != "" {
-			args = append(
-				args,
-				"--network", info.dockerNetwork,
-				"--network-alias", DaprRedisContainerName)
-		} else {
-			args = append(
-				args,
-				"-p", "6379:6379")
-		}
-		args = append(args, imageName)
-	}
-	_, err = utils.RunCmdAndWait("docker", args...)
-
-	if err != nil {
-		runError := isContainerRunError(err)
-		if !runError {
-			errorChan <- parseDockerError("Redis state store", err)
-		} else {
-			errorChan <- fmt.Errorf("docker %s failed with: %w", args, err)
+			return err
 		}
-		return
 	}
-	errorChan <- nil
+	return nil
 }
 
 func runPlacementService(wg *sync.WaitGroup, errorChan chan<- error, info initInfo) {
@@ -445,7 +463,7 @@ func runPlacementService(wg *sync.WaitGroup, errorChan chan<- error, info initIn
 
 	placementContainerName := utils.CreateContainerName(DaprPlacementContainerName, info.dockerNetwork)
 
-	exists, err := confirmContainerIsRunningOrExists(placementContainerName, false)
+	exists, err := confirmContainerIsRunningOrExists(info.containerRuntime, placementContainerName, false)
 
 	if err != nil {
 		errorChan <- err
@@ -467,7 +485,7 @@ func runPlacementService(wg *sync.WaitGroup, errorChan chan<- error, info initIn
 		// if --from-dir flag is given load the image details from the installer-bundle.
 		dir := path_filepath.Join(info.fromDir, *info.bundleDet.ImageSubDir)
 		image = info.bundleDet.getPlacementImageName()
-		err = loadDocker(dir, info.bundleDet.getPlacementImageFileName())
+		err = loadDocker(info.containerRuntime, dir, info.bundleDet.getPlacementImageFileName())
 		if err != nil {
 			errorChan <- err
 			return
@@ -481,6 +499,30 @@ func runPlacementService(wg *sync.WaitGroup, errorChan chan<- error, info initIn
 		}
 	}
 
+	image = info.containerRuntime.QualifyImage(image)
+	if !isAirGapInit {
+		// Pull a missing image before running it to report the download progress. Errors are reported by run.
+		pullImageIfMissing(info.containerRuntime, image)
+	}
+	args := placementContainerArgs(placementContainerName, info)
+	args = append(args, image)
+
+	_, err = info.containerRuntime.Run(args...)
+
+	if err != nil {
+		runError := isContainerRunError(err)
+		if !runError {
+			errorChan <- parseDockerError(info.containerRuntime, "placement service", err)
+		} else {
+			errorChan <- fmt.Errorf("%s %s failed with: %w", info.containerRuntime.Name(), args, err)
+		}
+		return
+	}
+	errorChan <- nil
+}
+
+// placementContainerArgs returns the arguments to run the placement container, except for its image.
+func placementContainerArgs(placementContainerName string, info initInfo) []string {
 	args := []string{
 		"run",
 		"--name", placementContainerName,
@@ -494,29 +536,10 @@ func runPlacementService(wg *sync.WaitGroup, errorChan chan<- error, info initIn
 			"--network", info.dockerNetwork,
 			"--network-alias", DaprPlacementContainerName)
 	} else {
-		osPort := 50005
-		if runtime.GOOS == daprWindowsOS {
-			osPort = 6050
-		}
-
 		args = append(args,
-			"-p", fmt.Sprintf("%v:50005", osPort))
-	}
-
-	args = append(args, image)
-
-	_, err = utils.RunCmdAndWait("docker", args...)
-
-	if err != nil {
-		runError := isContainerRunError(err)
-		if !runError {
-			errorChan <- parseDockerError("placement service", err)
-		} else {
-			errorChan <- fmt.Errorf("docker %s failed with: %w", args, err)
-		}
-		return
+			"-p", fmt.Sprintf("%v:50005", PlacementPort()))
 	}
-	errorChan <- nil
+	return args
 }
 
 func moveDashboardFiles(extractedFilePath string, dir string) (string, error) {
@@ -583,18 +606,21 @@ func installPlacement(wg *sync.WaitGroup, errorChan chan<- error, info initInfo)
 	}
 }
 
-// installBinary installs the daprd, placement or dashboard binaries and associated files inside the default dapr bin directory.
+// installBinary installs the daprd, placement or dashboard binaries and associated files inside the bin directory of info.
 func installBinary(version, binaryFilePrefix, githubRepo string, info initInfo) error {
 	var (
 		err      error
 		filepath string
 	)
 
-	dir := defaultDaprBinPath()
+	dir := info.binDir
+	if dir == "" {
+		dir = defaultDaprBinPath()
+	}
 	if isAirGapInit {
 		filepath = path_filepath.Join(info.fromDir, *info.bundleDet.BinarySubDir, binaryName(binaryFilePrefix))
 	} else {
-		filepath, err = downloadBinary(dir, version, binaryFilePrefix, githubRepo)
+		filepath, err = downloadBinary(dir, version, binaryFilePrefix, githubRepo, info.skipVerify)
 		if err != nil {
 			return fmt.Errorf("error downloading %s binary: %w", binaryFilePrefix, err)
 		}
@@ -640,29 +666,24 @@ func createComponentsAndConfiguration(wg *sync.WaitGroup, errorChan chan<- error
 		return
 	}
 
-	redisHost := daprDefaultHost
-	zipkinHost := daprDefaultHost
-	if info.dockerNetwork != "" {
-		// Default to network scoped alias of the container names when a dockerNetwork is specified.
-		redisHost = DaprRedisContainerName
-		zipkinHost = DaprZipkinContainerName
+	componentsDir := info.resourcesDir()
+	for _, file := range initComponentFiles(info.components, info.dockerNetwork) {
+		b, err := yaml.Marshal(&file.component)
+		if err == nil {
+			err = checkAndOverWriteFile(path_filepath.Join(componentsDir, file.name), b)
+		}
+		if err != nil {
+			errorChan <- fmt.Errorf("error creating component file %s: %w", file.name, err)
+			return
+		}
 	}
-	var err error
-
-	// Make default components directory.
-	componentsDir := DefaultComponentsDirPath()
 
-	err = createRedisPubSub(redisHost, componentsDir)
-	if err != nil {
-		errorChan <- fmt.Errorf("error creating redis pubsub component file: %w", err)
-		return
+	// An empty Zipkin host creates a configuration without tracing.
+	zipkinHost := ""
+	if info.withComponent(ZipkinInitComponent) {
+		zipkinHost = initContainerHost(DaprZipkinContainerName, info.dockerNetwork)
 	}
-	err = createRedisStateStore(redisHost, componentsDir)
-	if err != nil {
-		errorChan <- fmt.Errorf("error creating redis statestore component file: %w", err)
-		return
-	}
-	err = createDefaultConfiguration(zipkinHost, DefaultConfigFilePath())
+	err := createDefaultConfiguration(zipkinHost, info.configFilePath())
 	if err != nil {
 		errorChan <- fmt.Errorf("error creating default configuration file: %w", err)
 		return
@@ -677,16 +698,14 @@ func createSlimConfiguration(wg *sync.WaitGroup, errorChan chan<- error, info in
 	}
 
 	// For --slim we pass empty string so that we do not configure zipkin.
-	err := createDefaultConfiguration("", DefaultConfigFilePath())
+	err := createDefaultConfiguration("", info.configFilePath())
 	if err != nil {
 		errorChan <- fmt.Errorf("error creating default configuration file: %w", err)
 		return
 	}
 }
 
-func makeDefaultComponentsDir() error {
-	// Make default components directory.
-	componentsDir := DefaultComponentsDirPath()
+func makeDefaultComponentsDir(componentsDir string) error {
 	// nolint
 	_, err := os.Stat(componentsDir)
 	if os.IsNotExist(err) {
@@ -903,90 +922,28 @@ func moveFileToPath(filepath string, installLocation string) (string, error) {
 	return destFilePath, nil
 }
 
-func createRedisStateStore(redisHost string, componentsPath string) error {
-	redisStore := component{
-		APIVersion: "dapr.io/v1alpha1",
-		Kind:       "Component",
-	}
-
-	redisStore.Metadata.Name = "statestore"
-	redisStore.Spec.Type = "state.redis"
-	redisStore.Spec.Version = "v1"
-	redisStore.Spec.Metadata = []componentMetadataItem{
-		{
-			Name:  "redisHost",
-			Value: fmt.Sprintf("%s:6379", redisHost),
-		},
-		{
-			Name:  "redisPassword",
-			Value: "",
-		},
-		{
-			Name:  "actorStateStore",
-			Value: "true",
-		},
-	}
-
-	b, err := yaml.Marshal(&redisStore)
-	if err != nil {
-		return err
-	}
-
-	filePath := path_filepath.Join(componentsPath, stateStoreYamlFileName)
-	err = checkAndOverWriteFile(filePath, b)
-
-	return err
-}
-
-func createRedisPubSub(redisHost string, componentsPath string) error {
-	redisPubSub := component{
-		APIVersion: "dapr.io/v1alpha1",
-		Kind:       "Component",
-	}
-
-	redisPubSub.Metadata.Name = "pubsub"
-	redisPubSub.Spec.Type = "pubsub.redis"
-	redisPubSub.Spec.Version = "v1"
-	redisPubSub.Spec.Metadata = []componentMetadataItem{
-		{
-			Name:  "redisHost",
-			Value: fmt.Sprintf("%s:6379", redisHost),
-		},
-		{
-			Name:  "redisPassword",
-			Value: "",
-		},
-	}
-
-	b, err := yaml.Marshal(&redisPubSub)
-	if err != nil {
-		return err
-	}
-
-	filePath := path_filepath.Join(componentsPath, pubSubYamlFileName)
-	err = checkAndOverWriteFile(filePath, b)
-
-	return err
-}
-
+// createDefaultConfiguration writes the default Dapr configuration to filePath unless it exists. Tracing to
+// Zipkin is enabled if zipkinHost is not empty, otherwise it is left as a commented example, like access control.
 func createDefaultConfiguration(zipkinHost, filePath string) error {
-	defaultConfig := configuration{
-		APIVersion: "dapr.io/v1alpha1",
-		Kind:       "Configuration",
-	}
-	defaultConfig.Metadata.Name = "daprConfig"
+	tracing := `  # Uncomment to send traces to a Zipkin compatible collector.
+  # tracing:
+  #   samplingRate: "1"
+  #   zipkin:
+  #     endpointAddress: http://localhost:9411/api/v2/spans
+`
 	if zipkinHost != "" {
-		defaultConfig.Spec.Tracing.SamplingRate = "1"
-		defaultConfig.Spec.Tracing.Zipkin.EndpointAddress = fmt.Sprintf("http://%s:9411/api/v2/spans", zipkinHost) //nolint:nosprintfhostport
+		tracing = fmt.Sprintf(`  tracing:
+    samplingRate: "1"
+    zipkin:
+      endpointAddress: http://%s:9411/api/v2/spans
+`, zipkinHost)
 	}
-	b, err := yaml.Marshal(&defaultConfig)
-	if err != nil {
+	b := []byte(defaultConfigurationHeader + tracing + defaultConfigurationFooter)
+
+	if err := os.MkdirAll(path_filepath.Dir(filePath), 0o755); err != nil {
 		return err
 	}
-
-	err = checkAndOverWriteFile(filePath, b)
-
-	return err
+	return checkAndOverWriteFile(filePath, b)
 }
 
 func checkAndOverWriteFile(filePath string, b []byte) error {
@@ -1023,15 +980,19 @@ func archiveExt() string {
 	return ext
 }
 
-func downloadBinary(dir, version, binaryFilePrefix, githubRepo string) (string, error) {
-	fileURL := fmt.Sprintf(
-		"https://github.com/%s/%s/releases/download/v%s/%s",
-		cli_ver.DaprGitHubOrg,
-		githubRepo,
-		version,
-		binaryName(binaryFilePrefix))
+// downloadBinary downloads the release archive of a binary to dir and verifies its checksum, unless skipVerify is set.
+func downloadBinary(dir, version, binaryFilePrefix, githubRepo string, skipVerify bool) (string, error) {
+	fileURL := cli_ver.ReleaseURL(githubRepo, version, binaryName(binaryFilePrefix))
 
-	return downloadFile(dir, fileURL)
+	filepath, err := downloadFile(dir, fileURL)
+	if err != nil || skipVerify {
+		return filepath, err
+	}
+	if err = verifyChecksum(filepath, fileURL); err != nil {
+		os.Remove(filepath)
+		return "", err
+	}
+	return filepath, nil
 }
 
 func binaryName(binaryFilePrefix string) string {
@@ -1043,20 +1004,14 @@ func downloadFile(dir string, url string) (string, error) {
 	fileName := tokens[len(tokens)-1]
 
 	filepath := path.Join(dir, fileName)
+	print.DebugStatusEvent(os.Stderr, "Downloading %s to %s", url, filepath)
 	_, err := os.Stat(filepath)
 	if os.IsExist(err) {
 		return "", nil
 	}
-	client := http.Client{ //nolint:exhaustruct
-		Timeout: 0,
-		Transport: &http.Transport{ //nolint:exhaustruct
-			Dial: (&net.Dialer{ //nolint:exhaustruct
-				Timeout: 30 * time.Second,
-			}).Dial,
-			TLSHandshakeTimeout:   15 * time.Second,
-			ResponseHeaderTimeout: 15 * time.Second,
-		},
-	}
+	transport := utils.NewHTTPTransport()
+	transport.ResponseHeaderTimeout = 15 * time.Second
+	client := http.Client{Transport: transport} //nolint:exhaustruct
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -1082,7 +1037,9 @@ func downloadFile(dir string, url string) (string, error) {
 	}
 	defer out.Close()
 
-	_, err = copyWithTimeout(context.Background(), out, resp.Body)
+	bar := print.NewProgressBar(os.Stdout, fileName, resp.ContentLength)
+	_, err = copyWithTimeout(context.Background(), io.MultiWriter(out, bar), resp.Body)
+	bar.Finish()
 	if err != nil {
 		return "", err
 	}
@@ -1172,7 +1129,7 @@ func getPlacementImageName(imageInfo daprImageInfo, info initInfo) (string, erro
 
 	// if default registry is GHCR and the image is not available in or cannot be pulled from GHCR
 	// fallback to using dockerhub.
-	if useGHCR(imageInfo, info.fromDir) && !tryPullImage(image) {
+	if useGHCR(imageInfo, info.fromDir) && !pullImageIfMissing(info.containerRuntime, image) {
 		print.InfoStatusEvent(os.Stdout, "Placement image not found in Github container registry, pulling it from Docker Hub")
 		image = getPlacementImageWithTag(daprDockerImageName, info.runtimeVersion)
 	}
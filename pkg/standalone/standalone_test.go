@@ -18,13 +18,16 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
 )
 
 func TestStandaloneConfig(t *testing.T) {
 	testFile := "./test.yaml"
 
 	t.Run("Standalone config", func(t *testing.T) {
-		expectConfigZipkin := `apiVersion: dapr.io/v1alpha1
+		expectConfigZipkin := `# The Dapr configuration of the apps run with dapr run.
+# See https://docs.dapr.io/reference/resource-specs/configuration-schema/ for all settings.
+apiVersion: dapr.io/v1alpha1
 kind: Configuration
 metadata:
   name: daprConfig
@@ -33,6 +36,17 @@ spec:
     samplingRate: "1"
     zipkin:
       endpointAddress: http://test_zipkin_host:9411/api/v2/spans
+  metric:
+    enabled: true
+  # Uncomment to only allow the listed apps to invoke the apps run with this configuration.
+  # accessControl:
+  #   defaultAction: deny
+  #   trustDomain: public
+  #   policies:
+  #   - appId: caller-app
+  #     defaultAction: allow
+  #     trustDomain: public
+  #     namespace: default
 `
 		os.Remove(testFile)
 		createDefaultConfiguration("test_zipkin_host", testFile)
@@ -43,11 +57,29 @@ spec:
 	})
 
 	t.Run("Standalone config slim", func(t *testing.T) {
-		expectConfigSlim := `apiVersion: dapr.io/v1alpha1
+		expectConfigSlim := `# The Dapr configuration of the apps run with dapr run.
+# See https://docs.dapr.io/reference/resource-specs/configuration-schema/ for all settings.
+apiVersion: dapr.io/v1alpha1
 kind: Configuration
 metadata:
   name: daprConfig
-spec: {}
+spec:
+  # Uncomment to send traces to a Zipkin compatible collector.
+  # tracing:
+  #   samplingRate: "1"
+  #   zipkin:
+  #     endpointAddress: http://localhost:9411/api/v2/spans
+  metric:
+    enabled: true
+  # Uncomment to only allow the listed apps to invoke the apps run with this configuration.
+  # accessControl:
+  #   defaultAction: deny
+  #   trustDomain: public
+  #   policies:
+  #   - appId: caller-app
+  #     defaultAction: allow
+  #     trustDomain: public
+  #     namespace: default
 `
 		os.Remove(testFile)
 		createDefaultConfiguration("", testFile)
@@ -57,6 +89,16 @@ spec: {}
 		assert.Equal(t, expectConfigSlim, string(content))
 	})
 
+	t.Run("Standalone config is valid YAML", func(t *testing.T) {
+		os.Remove(testFile)
+		createDefaultConfiguration("", testFile)
+		content, err := os.ReadFile(testFile)
+		assert.NoError(t, err)
+		var config map[string]interface{}
+		assert.NoError(t, yaml.Unmarshal(content, &config))
+		assert.Equal(t, map[interface{}]interface{}{"metric": map[interface{}]interface{}{"enabled": true}}, config["spec"])
+	})
+
 	os.Remove(testFile)
 }
 
@@ -0,0 +1,319 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dapr/cli/pkg/api"
+	"github.com/dapr/cli/pkg/print"
+)
+
+// stateQueryAPIVersion is the version of the Dapr API that provides state queries.
+const stateQueryAPIVersion = "1.0-alpha1"
+
+// StateItemOutput is a key of a state store.
+type StateItemOutput struct {
+	Key   string     `csv:"KEY"   json:"key"            yaml:"key"`
+	Value StateValue `csv:"VALUE" json:"value"          yaml:"value"`
+	ETag  string     `csv:"ETAG"  json:"etag,omitempty" yaml:"etag,omitempty"`
+}
+
+// StateItem is a key to save in a state store, in the format of the state API.
+type StateItem struct {
+	Key      string            `json:"key"`
+	Value    json.RawMessage   `json:"value"`
+	ETag     string            `json:"etag,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// StateValue is a JSON value of a state store. It is printed as JSON in tables.
+type StateValue json.RawMessage
+
+// String returns the JSON value.
+func (v StateValue) String() string {
+	return string(v)
+}
+
+// MarshalJSON returns the value as is.
+func (v StateValue) MarshalJSON() ([]byte, error) {
+	if len(v) == 0 {
+		return []byte("null"), nil
+	}
+	return v, nil
+}
+
+// UnmarshalJSON keeps a copy of the value as is.
+func (v *StateValue) UnmarshalJSON(b []byte) error {
+	*v = append((*v)[:0], b...)
+	return nil
+}
+
+// MarshalYAML returns the decoded JSON value, so it is printed as YAML.
+func (v StateValue) MarshalYAML() (interface{}, error) {
+	var value interface{}
+	if len(v) == 0 {
+		return value, nil
+	}
+	if err := json.Unmarshal(v, &value); err != nil {
+		return string(v), nil //nolint:nilerr
+	}
+	return value, nil
+}
+
+// ParseStateValue returns value as JSON. A value that is not valid JSON is saved as a string, without a trailing newline.
+func ParseStateValue(value []byte) json.RawMessage {
+	if json.Valid(value) {
+		return value
+	}
+	// Marshalling a string cannot fail.
+	b, _ := json.Marshal(strings.TrimRight(string(value), "\r\n"))
+	return b
+}
+
+// stateQueryResult is an item of the response of the state query and bulk get APIs.
+type stateQueryResult struct {
+	Key   string          `json:"key"`
+	Data  json.RawMessage `json:"data"`
+	ETag  string          `json:"etag,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// stateQueryResponse is the response of the state query API.
+type stateQueryResponse struct {
+	Results []stateQueryResult `json:"results"`
+	Token   string             `json:"token,omitempty"`
+}
+
+// GetState returns the values of keys in the state store storeName, read through the sidecar of appID.
+// More than one key is read with the bulk API. appID can be empty if only one app is running.
+func (s *Standalone) GetState(appID, storeName string, keys []string, socket string) ([]StateItemOutput, error) {
+	if storeName == "" || len(keys) == 0 {
+		return nil, errors.New("the state store and at least one key are required")
+	}
+
+	if len(keys) > 1 {
+		return s.bulkGetState(appID, storeName, keys, socket)
+	}
+
+	endpoint, httpc, err := s.sidecarEndpoint(appID, socket, fmt.Sprintf("v%s/state/%s/%s", api.RuntimeAPIVersion, url.PathEscape(storeName), url.PathEscape(keys[0])))
+	if err != nil {
+		return nil, err
+	}
+	r, err := httpc.Get(endpoint) //nolint:noctx
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+	if r.StatusCode == http.StatusNoContent {
+		return nil, fmt.Errorf("key %s not found in state store %s", keys[0], storeName)
+	}
+	body, err := readStateResponse(r)
+	if err != nil {
+		return nil, err
+	}
+	return []StateItemOutput{{Key: keys[0], Value: StateValue(body), ETag: r.Header.Get("ETag")}}, nil
+}
+
+func (s *Standalone) bulkGetState(appID, storeName string, keys []string, socket string) ([]StateItemOutput, error) {
+	endpoint, httpc, err := s.sidecarEndpoint(appID, socket, fmt.Sprintf("v%s/state/%s/bulk", api.RuntimeAPIVersion, url.PathEscape(storeName)))
+	if err != nil {
+		return nil, err
+	}
+	req, err := json.Marshal(map[string]interface{}{"keys": keys})
+	if err != nil {
+		return nil, err
+	}
+	r, err := postWithHeaders(context.Background(), httpc, endpoint, "application/json", req, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+	body, err := readStateResponse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []stateQueryResult
+	if err = json.Unmarshal(body, &results); err != nil {
+		return nil, fmt.Errorf("error parsing the state response: %w", err)
+	}
+	items := make([]StateItemOutput, 0, len(results))
+	for _, result := range results {
+		if result.Error != "" {
+			return nil, fmt.Errorf("error getting key %s: %s", result.Key, result.Error)
+		}
+		items = append(items, StateItemOutput{Key: result.Key, Value: StateValue(result.Data), ETag: result.ETag})
+	}
+	return items, nil
+}
+
+// SaveState saves items in the state store storeName through the sidecar of appID.
+func (s *Standalone) SaveState(appID, storeName string, items []StateItem, socket string) error {
+	if storeName == "" || len(items) == 0 {
+		return errors.New("the state store and at least one item are required")
+	}
+	for _, item := range items {
+		if item.Key == "" {
+			return errors.New("every item must have a key")
+		}
+	}
+
+	endpoint, httpc, err := s.sidecarEndpoint(appID, socket, fmt.Sprintf("v%s/state/%s", api.RuntimeAPIVersion, url.PathEscape(storeName)))
+	if err != nil {
+		return err
+	}
+	req, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	r, err := postWithHeaders(context.Background(), httpc, endpoint, "application/json", req, nil)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	_, err = readStateResponse(r)
+	return err
+}
+
+// DeleteState deletes keys from the state store storeName through the sidecar of appID.
+func (s *Standalone) DeleteState(appID, storeName string, keys []string, socket string) error {
+	if storeName == "" || len(keys) == 0 {
+		return errors.New("the state store and at least one key are required")
+	}
+
+	for _, key := range keys {
+		endpoint, httpc, err := s.sidecarEndpoint(appID, socket, fmt.Sprintf("v%s/state/%s/%s", api.RuntimeAPIVersion, url.PathEscape(storeName), url.PathEscape(key)))
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequest(http.MethodDelete, endpoint, nil) //nolint:noctx
+		if err != nil {
+			return err
+		}
+		r, err := httpc.Do(req)
+		if err != nil {
+			return err
+		}
+		_, err = readStateResponse(r)
+		r.Body.Close()
+		if err != nil {
+			return fmt.Errorf("error deleting key %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// QueryState runs query against the state store storeName through the sidecar of appID, and returns all pages of
+// results. The state store must support the state query API.
+func (s *Standalone) QueryState(appID, storeName string, query []byte, socket string) ([]StateItemOutput, error) {
+	if storeName == "" {
+		return nil, errors.New("the state store is required")
+	}
+	results, err := s.queryState(appID, storeName, query, socket)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]StateItemOutput, 0, len(results))
+	for _, result := range results {
+		items = append(items, StateItemOutput{Key: result.Key, Value: StateValue(result.Data), ETag: result.ETag})
+	}
+	return items, nil
+}
+
+// queryState runs query against the state store storeName, following the page tokens of the responses.
+// Results with an error are skipped.
+func (s *Standalone) queryState(appID, storeName string, query []byte, socket string) ([]stateQueryResult, error) {
+	var q map[string]interface{}
+	if len(bytes.TrimSpace(query)) == 0 {
+		query = []byte("{}")
+	}
+	if err := json.Unmarshal(query, &q); err != nil {
+		return nil, fmt.Errorf("invalid state query: %w", err)
+	}
+	if q == nil {
+		q = map[string]interface{}{}
+	}
+
+	endpoint, httpc, err := s.sidecarEndpoint(appID, socket, fmt.Sprintf("v%s/state/%s/query", stateQueryAPIVersion, url.PathEscape(storeName)))
+	if err != nil {
+		return nil, err
+	}
+
+	results := []stateQueryResult{}
+	for {
+		req, err := json.Marshal(q)
+		if err != nil {
+			return nil, err
+		}
+		r, err := postWithHeaders(context.Background(), httpc, endpoint, "application/json", req, nil)
+		if err != nil {
+			return nil, err
+		}
+		body, err := readStateResponse(r)
+		r.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error querying the state store: %w", err)
+		}
+
+		var resp stateQueryResponse
+		if len(body) > 0 {
+			if err = json.Unmarshal(body, &resp); err != nil {
+				return nil, fmt.Errorf("error parsing the state query response: %w", err)
+			}
+		}
+		for _, result := range resp.Results {
+			if result.Error == "" {
+				results = append(results, result)
+			}
+		}
+		if resp.Token == "" || len(resp.Results) == 0 {
+			return results, nil
+		}
+		page, _ := q["page"].(map[string]interface{})
+		if page == nil {
+			page = map[string]interface{}{}
+		}
+		page["token"] = resp.Token
+		q["page"] = page
+	}
+}
+
+// readStateResponse returns the body of a response of the state API, or an error with the body if the request failed.
+func readStateResponse(r *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if r.StatusCode < 200 || r.StatusCode >= 300 {
+		err = fmt.Errorf("%s %s", r.Status, strings.TrimSpace(string(body)))
+		// The error code of the Dapr API, such as ERR_STATE_STORE_NOT_FOUND, is kept for structured output.
+		var apiErr struct {
+			ErrorCode string `json:"errorCode"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.ErrorCode != "" {
+			err = print.NewCodedError(apiErr.ErrorCode, err)
+		}
+		return nil, err
+	}
+	return body, nil
+}
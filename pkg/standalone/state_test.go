@@ -0,0 +1,157 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stateTestStore is an in-memory state store served with the state API of a sidecar.
+type stateTestStore struct {
+	lock  sync.Mutex
+	items map[string]json.RawMessage
+}
+
+func (s *stateTestStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1.0/state/statestore")
+	switch {
+	case r.URL.Path == "/v1.0-alpha1/state/statestore/query":
+		results := []stateQueryResult{}
+		for key, value := range s.items {
+			if strings.HasPrefix(key, "order") {
+				results = append(results, stateQueryResult{Key: key, Data: value})
+			}
+		}
+		json.NewEncoder(w).Encode(stateQueryResponse{Results: results})
+	case !strings.HasPrefix(r.URL.Path, "/v1.0/state/statestore"):
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errorCode":"ERR_STATE_STORE_NOT_FOUND"}`))
+	case path == "/bulk":
+		var req struct {
+			Keys []string `json:"keys"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		results := []stateQueryResult{}
+		for _, key := range req.Keys {
+			results = append(results, stateQueryResult{Key: key, Data: s.items[key], ETag: "1"})
+		}
+		json.NewEncoder(w).Encode(results)
+	case path == "" && r.Method == http.MethodPost:
+		var items []StateItem
+		json.NewDecoder(r.Body).Decode(&items)
+		for _, item := range items {
+			s.items[item.Key] = item.Value
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodDelete:
+		delete(s.items, strings.TrimPrefix(path, "/"))
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet:
+		value, ok := s.items[strings.TrimPrefix(path, "/")]
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("ETag", "1")
+		w.Write(value)
+	}
+}
+
+func TestState(t *testing.T) {
+	store := &stateTestStore{items: map[string]json.RawMessage{}}
+	ts, port := getTestServerFunc(store)
+	ts.Start()
+	defer ts.Close()
+
+	client := &Standalone{
+		process: &mockDaprProcess{
+			Lo: []ListOutput{{AppID: "testapp", HTTPPort: port}},
+		},
+	}
+
+	t.Run("save", func(t *testing.T) {
+		err := client.SaveState("", "statestore", []StateItem{
+			{Key: "order1", Value: ParseStateValue([]byte(`{"id":1}`))},
+			{Key: "order2", Value: ParseStateValue([]byte("pending\n"))},
+			{Key: "user", Value: ParseStateValue([]byte("1"))},
+		}, "")
+		require.NoError(t, err)
+		assert.Equal(t, json.RawMessage(`"pending"`), store.items["order2"])
+	})
+
+	t.Run("save without key", func(t *testing.T) {
+		err := client.SaveState("", "statestore", []StateItem{{Value: json.RawMessage("1")}}, "")
+		assert.EqualError(t, err, "every item must have a key")
+	})
+
+	t.Run("get", func(t *testing.T) {
+		items, err := client.GetState("testapp", "statestore", []string{"order1"}, "")
+		require.NoError(t, err)
+		assert.Equal(t, []StateItemOutput{{Key: "order1", Value: StateValue(`{"id":1}`), ETag: "1"}}, items)
+	})
+
+	t.Run("bulk get", func(t *testing.T) {
+		items, err := client.GetState("testapp", "statestore", []string{"order1", "user"}, "")
+		require.NoError(t, err)
+		assert.Equal(t, []StateItemOutput{
+			{Key: "order1", Value: StateValue(`{"id":1}`), ETag: "1"},
+			{Key: "user", Value: StateValue("1"), ETag: "1"},
+		}, items)
+	})
+
+	t.Run("query", func(t *testing.T) {
+		items, err := client.QueryState("testapp", "statestore", []byte(`{"filter":{}}`), "")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []StateItemOutput{
+			{Key: "order1", Value: StateValue(`{"id":1}`)},
+			{Key: "order2", Value: StateValue(`"pending"`)},
+		}, items)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, client.DeleteState("testapp", "statestore", []string{"order1", "order2"}, ""))
+		_, err := client.GetState("testapp", "statestore", []string{"order1"}, "")
+		assert.EqualError(t, err, "key order1 not found in state store statestore")
+	})
+
+	t.Run("store not found", func(t *testing.T) {
+		_, err := client.GetState("testapp", "missing", []string{"order1"}, "")
+		assert.EqualError(t, err, `400 Bad Request {"errorCode":"ERR_STATE_STORE_NOT_FOUND"}`)
+	})
+
+	t.Run("missing keys", func(t *testing.T) {
+		_, err := client.GetState("testapp", "statestore", nil, "")
+		assert.Error(t, err)
+	})
+}
+
+func TestStateValueMarshal(t *testing.T) {
+	b, err := json.Marshal(StateItemOutput{Key: "k", Value: StateValue(`{"a":1}`)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"key":"k","value":{"a":1}}`, string(b))
+
+	value, err := StateValue(`{"a":1}`).MarshalYAML()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": float64(1)}, value)
+}
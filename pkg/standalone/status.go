@@ -0,0 +1,177 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	ps "github.com/mitchellh/go-ps"
+
+	"github.com/dapr/cli/pkg/age"
+	"github.com/dapr/cli/pkg/metadata"
+	"github.com/dapr/cli/pkg/print"
+	"github.com/dapr/cli/utils"
+)
+
+const (
+	// DaprSchedulerContainerName is the container name of the scheduler service of newer Dapr versions.
+	// It is only reported when it exists, since `dapr init` does not create it.
+	DaprSchedulerContainerName = "dapr_scheduler"
+
+	containerInspectFormat = "{{.State.Status}}|{{.State.StartedAt}}|{{.Config.Image}}"
+	statusRunning          = "Running"
+	statusNotFound         = "Not found"
+)
+
+// startedAtLayouts are the formats of the start time of a container printed by Docker and Podman.
+var startedAtLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999 -0700 MST"}
+
+// StatusOutput represents the status of a service or sidecar of a self-hosted Dapr installation.
+type StatusOutput struct {
+	Name    string `csv:"NAME"`
+	Type    string `csv:"TYPE"`
+	Healthy string `csv:"HEALTHY"`
+	Status  string `csv:"STATUS"`
+	Version string `csv:"VERSION"`
+	Age     string `csv:"AGE"`
+	Created string `csv:"CREATED"`
+}
+
+// Status returns the status of the containers or binaries installed by `dapr init`
+// and of the sidecars started by `dapr run`.
+func Status(containerRuntimeName, dockerNetwork string) ([]StatusOutput, error) {
+	statuses := []StatusOutput{}
+	if isSlimInstallation() {
+		statuses = append(statuses, placementProcessStatus())
+	} else {
+		containerStatuses, err := containerStatuses(containerRuntimeName, dockerNetwork)
+		if err != nil {
+			// The sidecars can still be reported without the containers.
+			print.WarningStatusEvent(os.Stderr, "Could not get the status of the Dapr containers: %s", err)
+		}
+		statuses = append(statuses, containerStatuses...)
+	}
+
+	sidecars, err := List()
+	if err != nil {
+		return nil, err
+	}
+	for _, sidecar := range sidecars {
+		statuses = append(statuses, sidecarStatus(sidecar))
+	}
+	return statuses, nil
+}
+
+// isSlimInstallation returns true if Dapr was initialized with `dapr init --slim`,
+// which installs the placement service as a binary.
+func isSlimInstallation() bool {
+	_, err := os.Stat(binaryFilePath(defaultDaprBinPath(), placementServiceFilePrefix))
+	return err == nil
+}
+
+func containerStatuses(containerRuntimeName, dockerNetwork string) ([]StatusOutput, error) {
+	containerRuntime, err := NewContainerRuntime(containerRuntimeName)
+	if err != nil {
+		return nil, err
+	}
+	if err = containerRuntime.CheckRunning(); err != nil {
+		return nil, err
+	}
+
+	statuses := []StatusOutput{}
+	for _, name := range []string{DaprPlacementContainerName, DaprRedisContainerName, DaprZipkinContainerName, DaprKafkaContainerName, DaprPostgresContainerName, DaprSchedulerContainerName} {
+		containerName := utils.CreateContainerName(name, dockerNetwork)
+		out, inspectErr := containerRuntime.Run("inspect", "--type", "container", "--format", containerInspectFormat, containerName)
+		if inspectErr != nil {
+			print.DebugStatusEvent(os.Stderr, "Could not inspect container %s: %s", containerName, inspectErr)
+			if name == DaprSchedulerContainerName || name == DaprKafkaContainerName || name == DaprPostgresContainerName {
+				continue
+			}
+			statuses = append(statuses, StatusOutput{Name: containerName, Type: "container", Healthy: "False", Status: statusNotFound})
+			continue
+		}
+		statuses = append(statuses, parseContainerStatus(containerName, out))
+	}
+	return statuses, nil
+}
+
+// parseContainerStatus parses the output of inspecting a container with containerInspectFormat.
+func parseContainerStatus(containerName, out string) StatusOutput {
+	status := StatusOutput{Name: containerName, Type: "container", Healthy: "False"}
+	parts := strings.SplitN(strings.TrimSpace(out), "|", 3)
+	if len(parts) != 3 || parts[0] == "" {
+		status.Status = "Unknown"
+		return status
+	}
+
+	// Container states are lower case, such as "exited" or "paused".
+	status.Status = strings.ToUpper(parts[0][:1]) + parts[0][1:]
+	if parts[0] == "running" {
+		status.Status = statusRunning
+		status.Healthy = "True"
+		for _, layout := range startedAtLayouts {
+			if startedAt, err := time.Parse(layout, parts[1]); err == nil {
+				status.Age = age.GetAge(startedAt)
+				status.Created = startedAt.Local().Format("2006-01-02 15:04.05")
+				break
+			}
+		}
+	}
+
+	image := parts[2]
+	if i := strings.LastIndex(image, ":"); i >= 0 && !strings.Contains(image[i:], "/") {
+		status.Version = image[i+1:]
+	}
+	return status
+}
+
+// placementProcessStatus returns the status of the placement binary of a slim installation.
+func placementProcessStatus() StatusOutput {
+	status := StatusOutput{Name: placementServiceFilePrefix, Type: "process", Healthy: "False", Status: "Not running"}
+	processes, err := ps.Processes()
+	if err != nil {
+		status.Status = "Unknown"
+		return status
+	}
+	for _, proc := range processes {
+		executable := strings.ToLower(proc.Executable())
+		if executable == placementServiceFilePrefix || executable == placementServiceFilePrefix+".exe" {
+			status.Healthy = "True"
+			status.Status = statusRunning
+			status.Version = strings.TrimSpace(GetRuntimeVersion())
+			break
+		}
+	}
+	return status
+}
+
+// sidecarStatus returns the status of a sidecar started by `dapr run`. The sidecar is healthy if its metadata API responds.
+func sidecarStatus(sidecar ListOutput) StatusOutput {
+	status := StatusOutput{
+		Name:    "daprd (" + sidecar.AppID + ")",
+		Type:    "sidecar",
+		Healthy: "False",
+		Status:  statusRunning,
+		Age:     sidecar.Age,
+		Created: sidecar.Created,
+	}
+	appMetadata, err := metadata.Get(sidecar.HTTPPort, sidecar.AppID, "")
+	if err == nil {
+		status.Healthy = "True"
+		status.Version = appMetadata.Extended["daprRuntimeVersion"]
+	}
+	return status
+}
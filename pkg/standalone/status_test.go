@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseContainerStatus(t *testing.T) {
+	startedAt := time.Now().Add(-2 * time.Hour).UTC()
+
+	t.Run("running docker container", func(t *testing.T) {
+		status := parseContainerStatus("dapr_placement", "running|"+startedAt.Format(time.RFC3339Nano)+"|daprio/dapr:1.8.0\n")
+		assert.Equal(t, "dapr_placement", status.Name)
+		assert.Equal(t, "container", status.Type)
+		assert.Equal(t, "True", status.Healthy)
+		assert.Equal(t, "Running", status.Status)
+		assert.Equal(t, "1.8.0", status.Version)
+		assert.Equal(t, "2h", status.Age)
+		assert.NotEmpty(t, status.Created)
+	})
+
+	t.Run("running podman container", func(t *testing.T) {
+		status := parseContainerStatus("dapr_redis", "running|"+startedAt.Format("2006-01-02 15:04:05.999999999 -0700 MST")+"|docker.io/library/redis:6")
+		assert.Equal(t, "True", status.Healthy)
+		assert.Equal(t, "6", status.Version)
+		assert.Equal(t, "2h", status.Age)
+	})
+
+	t.Run("stopped container", func(t *testing.T) {
+		status := parseContainerStatus("dapr_zipkin", "exited|"+startedAt.Format(time.RFC3339Nano)+"|localhost:5000/openzipkin/zipkin")
+		assert.Equal(t, "False", status.Healthy)
+		assert.Equal(t, "Exited", status.Status)
+		assert.Empty(t, status.Version)
+		assert.Empty(t, status.Age)
+	})
+
+	t.Run("unexpected output", func(t *testing.T) {
+		status := parseContainerStatus("dapr_zipkin", "")
+		assert.Equal(t, "Unknown", status.Status)
+	})
+}
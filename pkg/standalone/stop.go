@@ -22,29 +22,9 @@ import (
 	"github.com/dapr/cli/utils"
 )
 
-// Stop terminates the application process.
-func Stop(appID string) error {
-	apps, err := List()
-	if err != nil {
-		return err
-	}
-
-	for _, a := range apps {
-		if a.AppID == appID {
-			var pid string
-			// Kill the Daprd process if Daprd was started without CLI, otherwise
-			// kill the CLI process which also kills the associated Daprd process.
-			if a.CliPID == 0 {
-				pid = fmt.Sprintf("%v", a.DaprdPID)
-			} else {
-				pid = fmt.Sprintf("%v", a.CliPID)
-			}
-
-			_, err := utils.RunCmdAndWait("kill", pid)
-
-			return err
-		}
-	}
-
-	return fmt.Errorf("couldn't find app id %s", appID)
+// signalStop asks the CLI process that started the app to stop the app and its sidecar.
+// If Daprd was started without the CLI, Daprd itself is asked to stop.
+func signalStop(a ListOutput) error {
+	_, err := utils.RunCmdAndWait("kill", fmt.Sprintf("%v", stopPID(a)))
+	return err
 }
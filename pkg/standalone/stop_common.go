@@ -0,0 +1,288 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	ps "github.com/mitchellh/go-ps"
+
+	"github.com/dapr/cli/pkg/print"
+)
+
+// stopPollInterval is the interval at which a stopped app is checked for exit.
+const stopPollInterval = 100 * time.Millisecond
+
+const (
+	stopStatusStopped  = "Stopped"
+	stopStatusFailed   = "Failed"
+	stopStatusNotFound = "Not found"
+)
+
+// StopOutput represents the result of stopping an app.
+type StopOutput struct {
+	AppID  string `csv:"APP ID" json:"appId"           yaml:"appId"`
+	Status string `csv:"STATUS" json:"status"          yaml:"status"`
+	Error  string `csv:"ERROR"  json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Stopped returns true if the app was stopped.
+func (o StopOutput) Stopped() bool {
+	return o.Status == stopStatusStopped
+}
+
+// Stop terminates the application process and its sidecar. If timeout is positive, Stop waits for them
+// to exit and kills them when they are still running after timeout.
+func Stop(appID string, timeout time.Duration) error {
+	apps, err := List()
+	if err != nil {
+		return err
+	}
+
+	for _, a := range apps {
+		if a.AppID == appID {
+			return stopApp(a, timeout)
+		}
+	}
+
+	return fmt.Errorf("couldn't find app id %s", appID)
+}
+
+// StopAll terminates every app started with `dapr run` in namespace, or in any namespace if namespace is empty,
+// and its sidecar, as Stop does. onStop is called with the app ID and the result as each app stops.
+func StopAll(namespace string, timeout time.Duration, onStop func(appID string, err error)) error {
+	apps, err := List()
+	if err != nil {
+		return err
+	}
+	apps, err = runApps(apps, namespace)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, a := range apps {
+		wg.Add(1)
+		go func(a ListOutput) {
+			defer wg.Done()
+			onStop(a.AppID, stopApp(a, timeout))
+		}(a)
+	}
+	wg.Wait()
+	return nil
+}
+
+// StopApps stops the apps whose IDs match any of patterns, which are app IDs or glob patterns such as order-*,
+// concurrently and as Stop does. A pattern that matches no app is reported as not found.
+func StopApps(patterns []string, timeout time.Duration) ([]StopOutput, error) {
+	apps, err := List()
+	if err != nil {
+		return nil, err
+	}
+	matched, unmatched, err := matchApps(apps, patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]StopOutput, len(matched))
+	var wg sync.WaitGroup
+	for i, a := range matched {
+		wg.Add(1)
+		go func(i int, a ListOutput) {
+			defer wg.Done()
+			results[i] = StopOutput{AppID: a.AppID, Status: stopStatusStopped}
+			if err := stopApp(a, timeout); err != nil {
+				results[i].Status = stopStatusFailed
+				results[i].Error = err.Error()
+			}
+		}(i, a)
+	}
+	wg.Wait()
+
+	for _, p := range unmatched {
+		results = append(results, StopOutput{AppID: p, Status: stopStatusNotFound})
+	}
+	return results, nil
+}
+
+// StopActions returns the actions that stopping the apps whose IDs match any of patterns would take, as printed by
+// `dapr stop --dry-run`, and the patterns that match no app. If patterns is empty, the actions of stopping all apps
+// started with `dapr run` in namespace, or in any namespace if namespace is empty, are returned.
+func StopActions(patterns []string, namespace string, timeout time.Duration) ([]string, []string, error) {
+	apps, err := List()
+	if err != nil {
+		return nil, nil, err
+	}
+	matched := []ListOutput{}
+	unmatched := []string{}
+	if len(patterns) == 0 {
+		matched, err = runApps(apps, namespace)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		matched, unmatched, err = matchApps(apps, patterns)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	actions := []string{}
+	for _, a := range matched {
+		actions = append(actions, stopActions(a, timeout)...)
+	}
+	return actions, unmatched, nil
+}
+
+// runApps returns the apps started with `dapr run` in namespace, or in any namespace if namespace is empty. Apps
+// are matched by their namespace rather than by their IDs, as apps in other namespaces can use the same IDs.
+func runApps(apps []ListOutput, namespace string) ([]ListOutput, error) {
+	matched := []ListOutput{}
+	for _, a := range FilterNamespace(apps, namespace) {
+		if a.CliPID != 0 {
+			matched = append(matched, a)
+		}
+	}
+	if namespace != "" && len(matched) == 0 {
+		return nil, fmt.Errorf("no apps are running in namespace %s", namespace)
+	}
+	return matched, nil
+}
+
+// stopActions returns the actions of stopApp.
+func stopActions(a ListOutput, timeout time.Duration) []string {
+	action := fmt.Sprintf("signal the CLI process %d of app %s to stop", a.CliPID, a.AppID)
+	if a.CliPID == 0 {
+		action = fmt.Sprintf("signal the daprd process %d of app %s to stop", a.DaprdPID, a.AppID)
+	}
+	actions := []string{action}
+	if timeout > 0 {
+		pids, err := appPIDs(a)
+		if err != nil {
+			pids = []int{stopPID(a)}
+		}
+		actions = append(actions, fmt.Sprintf("kill processes %v of app %s if it is still running after %s", pids, a.AppID, timeout))
+	}
+	return actions
+}
+
+// matchApps returns the apps whose IDs match any of patterns, in the order of apps, and the patterns that
+// match none of them.
+func matchApps(apps []ListOutput, patterns []string) ([]ListOutput, []string, error) {
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, nil, fmt.Errorf("invalid app id pattern %q: %w", p, err)
+		}
+	}
+
+	matched := []ListOutput{}
+	unmatched := []string{}
+	found := make([]bool, len(patterns))
+	for _, a := range apps {
+		match := false
+		for i, p := range patterns {
+			if ok, _ := path.Match(p, a.AppID); ok {
+				found[i] = true
+				match = true
+			}
+		}
+		if match {
+			matched = append(matched, a)
+		}
+	}
+	for i, p := range patterns {
+		if !found[i] {
+			unmatched = append(unmatched, p)
+		}
+	}
+	return matched, unmatched, nil
+}
+
+func stopApp(a ListOutput, timeout time.Duration) error {
+	if err := signalStop(a); err != nil {
+		return err
+	}
+	if timeout <= 0 {
+		return nil
+	}
+
+	pid := stopPID(a)
+	for deadline := time.Now().Add(timeout); time.Now().Before(deadline); time.Sleep(stopPollInterval) {
+		if !processRunning(pid) {
+			return nil
+		}
+	}
+
+	print.WarningStatusEvent(os.Stdout, "App %s did not exit within %s, killing it", a.AppID, timeout)
+	return killApp(a)
+}
+
+// stopPID returns the process to signal to stop an app: the CLI process, which also stops the associated
+// Daprd process, or Daprd if it was started without the CLI.
+func stopPID(a ListOutput) int {
+	if a.CliPID == 0 {
+		return a.DaprdPID
+	}
+	return a.CliPID
+}
+
+// appPIDs returns the Daprd process of an app, the other processes started by its CLI process, such as the app,
+// and the CLI process.
+func appPIDs(a ListOutput) ([]int, error) {
+	pids := []int{a.DaprdPID}
+	if a.CliPID != 0 {
+		processes, err := ps.Processes()
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range processes {
+			if p.PPid() == a.CliPID && p.Pid() != a.DaprdPID {
+				pids = append(pids, p.Pid())
+			}
+		}
+		pids = append(pids, a.CliPID)
+	}
+	return pids, nil
+}
+
+// killApp kills the CLI process of an app, its Daprd process and the other processes started by the CLI, such as the app.
+func killApp(a ListOutput) error {
+	pids, err := appPIDs(a)
+	if err != nil {
+		return err
+	}
+
+	for _, pid := range pids {
+		if !processRunning(pid) {
+			continue
+		}
+		p, err := os.FindProcess(pid)
+		if err == nil {
+			err = p.Kill()
+		}
+		if err != nil && processRunning(pid) {
+			return fmt.Errorf("failed to kill process %d: %w", pid, err)
+		}
+	}
+	return nil
+}
+
+func processRunning(pid int) bool {
+	p, err := ps.FindProcess(pid)
+	return err == nil && p != nil
+}
@@ -0,0 +1,114 @@
+//go:build !windows
+// +build !windows
+
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// startProcess starts a shell script and reaps it once it exits.
+func startProcess(t *testing.T, script string) int {
+	t.Helper()
+	cmd := exec.Command("sh", "-c", script)
+	assert.NoError(t, cmd.Start())
+	go cmd.Wait()
+	// Give the shell time to set up its traps.
+	time.Sleep(100 * time.Millisecond)
+	return cmd.Process.Pid
+}
+
+func TestStopApp(t *testing.T) {
+	t.Run("process exits on SIGTERM", func(t *testing.T) {
+		pid := startProcess(t, "exec sleep 30")
+		start := time.Now()
+		err := stopApp(ListOutput{AppID: "app", DaprdPID: pid}, 5*time.Second)
+		assert.NoError(t, err)
+		assert.False(t, processRunning(pid))
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("process ignoring SIGTERM is killed after the timeout", func(t *testing.T) {
+		pid := startProcess(t, `trap "" TERM; while true; do sleep 0.1; done`)
+		start := time.Now()
+		err := stopApp(ListOutput{AppID: "app", DaprdPID: pid}, 500*time.Millisecond)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 500*time.Millisecond)
+		assert.Eventually(t, func() bool { return !processRunning(pid) }, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("no timeout does not wait", func(t *testing.T) {
+		pid := startProcess(t, `trap "" TERM; while true; do sleep 0.1; done`)
+		defer killApp(ListOutput{DaprdPID: pid})
+		err := stopApp(ListOutput{AppID: "app", DaprdPID: pid}, 0)
+		assert.NoError(t, err)
+		assert.True(t, processRunning(pid))
+	})
+}
+
+func TestStopPID(t *testing.T) {
+	assert.Equal(t, 10, stopPID(ListOutput{CliPID: 10, DaprdPID: 20}))
+	assert.Equal(t, 20, stopPID(ListOutput{DaprdPID: 20}))
+}
+
+func TestMatchApps(t *testing.T) {
+	apps := []ListOutput{{AppID: "order-processor"}, {AppID: "checkout"}, {AppID: "order-web"}}
+
+	matched, unmatched, err := matchApps(apps, []string{"order-*", "checkout", "order-web", "payments"})
+	assert.NoError(t, err)
+	assert.Equal(t, apps, matched)
+	assert.Equal(t, []string{"payments"}, unmatched)
+
+	matched, unmatched, err = matchApps(apps, []string{"check?ut"})
+	assert.NoError(t, err)
+	assert.Equal(t, []ListOutput{{AppID: "checkout"}}, matched)
+	assert.Empty(t, unmatched)
+
+	_, _, err = matchApps(nil, []string{"order-["})
+	assert.ErrorContains(t, err, `invalid app id pattern "order-["`)
+}
+
+func TestStopActions(t *testing.T) {
+	assert.Equal(t, []string{"signal the CLI process 10 of app app to stop"}, stopActions(ListOutput{AppID: "app", CliPID: 10, DaprdPID: 20}, 0))
+	assert.Equal(t, []string{
+		"signal the daprd process 20 of app app to stop",
+		"kill processes [20] of app app if it is still running after 5s",
+	}, stopActions(ListOutput{AppID: "app", DaprdPID: 20}, 5*time.Second))
+}
+
+func TestRunApps(t *testing.T) {
+	apps := []ListOutput{
+		{AppID: "orders", Namespace: "shop", CliPID: 1},
+		{AppID: "orders", Namespace: "dev", CliPID: 2},
+		{AppID: "cart", Namespace: "shop"},
+		{AppID: "billing", CliPID: 3},
+	}
+
+	matched, err := runApps(apps, "shop")
+	assert.NoError(t, err)
+	assert.Equal(t, []ListOutput{apps[0]}, matched, "the app with the same ID in another namespace is not matched")
+
+	matched, err = runApps(apps, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []ListOutput{apps[0], apps[1], apps[3]}, matched)
+
+	_, err = runApps(apps, "blog")
+	assert.EqualError(t, err, "no apps are running in namespace blog")
+}
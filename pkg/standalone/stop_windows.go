@@ -20,25 +20,13 @@ import (
 	"golang.org/x/sys/windows"
 )
 
-// Stop terminates the application process.
-func Stop(appID string) error {
-	apps, err := List()
+// signalStop asks the CLI process that started the app to stop the app and its sidecar.
+func signalStop(a ListOutput) error {
+	eventName, _ := syscall.UTF16FromString(fmt.Sprintf("dapr_cli_%v", a.CliPID))
+	eventHandle, err := windows.OpenEvent(windows.EVENT_MODIFY_STATE, false, &eventName[0])
 	if err != nil {
 		return err
 	}
 
-	for _, a := range apps {
-		if a.AppID == appID {
-			eventName, _ := syscall.UTF16FromString(fmt.Sprintf("dapr_cli_%v", a.CliPID))
-			eventHandle, err := windows.OpenEvent(windows.EVENT_MODIFY_STATE, false, &eventName[0])
-			if err != nil {
-				return err
-			}
-
-			err = windows.SetEvent(eventHandle)
-			return err
-		}
-	}
-
-	return fmt.Errorf("couldn't find app id %s", appID)
+	return windows.SetEvent(eventHandle)
 }
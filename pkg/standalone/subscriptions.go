@@ -0,0 +1,43 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+
+	"github.com/dapr/cli/pkg/metadata"
+)
+
+// ListSubscriptions returns the programmatic and declarative pub/sub subscriptions registered by the sidecar of appID.
+// appID can be empty if only one app is running.
+func (s *Standalone) ListSubscriptions(appID, socket string) ([]metadata.SubscriptionOutput, error) {
+	list, err := s.process.List()
+	if err != nil {
+		return nil, err
+	}
+	instance, err := getSidecar(list, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	httpPort := instance.HTTPPort
+	if socket != "" {
+		httpPort = 0
+	}
+	appMetadata, err := metadata.Get(httpPort, instance.AppID, socket)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the metadata of app %s: %w", instance.AppID, err)
+	}
+	return metadata.Subscriptions(appMetadata), nil
+}
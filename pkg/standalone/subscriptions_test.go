@@ -0,0 +1,51 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/cli/pkg/metadata"
+)
+
+func TestListSubscriptions(t *testing.T) {
+	ts, port := getTestServerFunc(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1.0/metadata", r.URL.Path)
+		w.Write([]byte(`{"id":"orders","subscriptions":[
+			{"pubsubname":"pubsub","topic":"shipped","rules":[{"match":"event.type == \"express\"","path":"/express"},{"path":"/shipped"}],"type":"DECLARATIVE"},
+			{"pubsubname":"pubsub","topic":"created","deadLetterTopic":"poison","rules":[{"path":"/created"}]}
+		]}`))
+	}))
+	ts.Start()
+	defer ts.Close()
+
+	client := &Standalone{process: &mockDaprProcess{Lo: []ListOutput{{AppID: "orders", HTTPPort: port}}}}
+	subscriptions, err := client.ListSubscriptions("", "")
+	require.NoError(t, err)
+	assert.Equal(t, []metadata.SubscriptionOutput{
+		{PubsubName: "pubsub", Topic: "created", Routes: metadata.SubscriptionRoutes{{Path: "/created"}}, DeadLetterTopic: "poison"},
+		{PubsubName: "pubsub", Topic: "shipped", Routes: metadata.SubscriptionRoutes{{Match: `event.type == "express"`, Path: "/express"}, {Path: "/shipped"}}, Type: "declarative"},
+	}, subscriptions)
+
+	routes, err := subscriptions[1].Routes.MarshalCSV()
+	require.NoError(t, err)
+	assert.Equal(t, `/express (event.type == "express"), /shipped`, routes)
+
+	_, err = client.ListSubscriptions("payments", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// TraceparentHeader is the W3C Trace Context header that propagates a trace.
+	TraceparentHeader = "traceparent"
+	// DefaultTraceURL is the page of a trace in the Zipkin container started by dapr init.
+	DefaultTraceURL = "http://localhost:9411/zipkin/traces/{traceID}"
+
+	traceIDPlaceholder = "{traceID}"
+	traceparentVersion = "00"
+	// traceparentSampled is the trace flags of a trace that is recorded.
+	traceparentSampled = "01"
+)
+
+// NewTraceparent returns a traceparent header value of a new sampled trace with random trace and parent IDs.
+func NewTraceparent() (string, error) {
+	traceID, err := randomHex(16)
+	if err != nil {
+		return "", err
+	}
+	parentID, err := randomHex(8)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join([]string{traceparentVersion, traceID, parentID, traceparentSampled}, "-"), nil
+}
+
+// ParseTraceparent validates a traceparent header value as defined by https://www.w3.org/TR/trace-context/
+// and returns its trace ID.
+func ParseTraceparent(traceparent string) (string, error) {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 ||
+		!isLowerHex(parts[0], 2) || parts[0] == "ff" ||
+		!isLowerHex(parts[1], 32) || parts[1] == strings.Repeat("0", 32) ||
+		!isLowerHex(parts[2], 16) || parts[2] == strings.Repeat("0", 16) ||
+		!isLowerHex(parts[3], 2) ||
+		parts[0] == traceparentVersion && len(parts) != 4 {
+		return "", fmt.Errorf("invalid traceparent %q. Expected the format 00-<32 hex digit trace ID>-<16 hex digit parent ID>-<2 hex digit flags>", traceparent)
+	}
+	return parts[1], nil
+}
+
+// TraceURL returns the link to the trace with traceID, replacing {traceID} in urlTemplate.
+func TraceURL(urlTemplate, traceID string) string {
+	return strings.ReplaceAll(urlTemplate, traceIDPlaceholder, traceID)
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating trace ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func isLowerHex(s string, length int) bool {
+	if len(s) != length {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTraceparent(t *testing.T) {
+	traceparent, err := NewTraceparent()
+	assert.NoError(t, err)
+	assert.Regexp(t, `^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`, traceparent)
+
+	traceID, err := ParseTraceparent(traceparent)
+	assert.NoError(t, err)
+	assert.Equal(t, traceparent[3:35], traceID)
+
+	other, err := NewTraceparent()
+	assert.NoError(t, err)
+	assert.NotEqual(t, traceparent, other)
+}
+
+func TestParseTraceparent(t *testing.T) {
+	testCases := []struct {
+		traceparent string
+		traceID     string
+	}{
+		{traceparent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", traceID: "0af7651916cd43dd8448eb211c80319c"},
+		{traceparent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00", traceID: "0af7651916cd43dd8448eb211c80319c"},
+		{traceparent: "01-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-future", traceID: "0af7651916cd43dd8448eb211c80319c"},
+		{traceparent: "0af7651916cd43dd8448eb211c80319c"},
+		{traceparent: "00-0AF7651916CD43DD8448EB211C80319C-b7ad6b7169203331-01"},
+		{traceparent: "00-00000000000000000000000000000000-b7ad6b7169203331-01"},
+		{traceparent: "00-0af7651916cd43dd8448eb211c80319c-0000000000000000-01"},
+		{traceparent: "ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+		{traceparent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-extra"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.traceparent, func(t *testing.T) {
+			traceID, err := ParseTraceparent(tc.traceparent)
+			if tc.traceID == "" {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.traceID, traceID)
+		})
+	}
+}
+
+func TestTraceURL(t *testing.T) {
+	assert.Equal(t, "http://localhost:9411/zipkin/traces/abc", TraceURL(DefaultTraceURL, "abc"))
+	assert.Equal(t, "http://localhost:16686/trace/abc", TraceURL("http://localhost:16686/trace/{traceID}", "abc"))
+}
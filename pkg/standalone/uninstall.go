@@ -17,48 +17,132 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/dapr/cli/pkg/print"
 	"github.com/dapr/cli/utils"
 )
 
-func removeContainers(uninstallPlacementContainer, uninstallAll bool, dockerNetwork string) []error {
+// UninstallConfig represents the options of a self-hosted uninstall.
+type UninstallConfig struct {
+	// All also removes the Redis and Zipkin containers and the default Dapr directory.
+	All bool
+	// DockerNetwork is the network Dapr was initialized in.
+	DockerNetwork string
+	// ContainerRuntime is the container runtime Dapr was initialized with, Docker or Podman.
+	ContainerRuntime string
+	// DryRun prints what would be removed without removing it.
+	DryRun bool
+	// KeepRedis keeps the Redis container and its data.
+	KeepRedis bool
+	// KeepBin keeps the binaries in the bin and versions directories and the Dapr image.
+	KeepBin bool
+	// ContainersOnly removes the placement, Redis and Zipkin containers but no files or images.
+	ContainersOnly bool
+}
+
+// uninstallPlan lists what an uninstall removes.
+type uninstallPlan struct {
+	dirs       []string
+	containers []string
+	// optionalContainers are removed if they exist, as they are only created with dapr init --components.
+	optionalContainers []string
+	images             []string
+}
+
+// newUninstallPlan returns what an uninstall with config removes. placementContainer is true if
+// placement runs in a container, which is the case if the placement binary was not installed.
+func newUninstallPlan(config UninstallConfig, placementContainer bool) (uninstallPlan, error) {
+	plan := uninstallPlan{}
+	removeFiles := !config.ContainersOnly
+	removeBin := removeFiles && !config.KeepBin
+
+	if removeBin {
+		plan.dirs = append(plan.dirs, defaultDaprBinPath())
+		// The runtime versions installed with dapr use would otherwise bring the binaries back.
+		if _, err := os.Stat(defaultDaprVersionsPath()); err == nil {
+			plan.dirs = append(plan.dirs, defaultDaprVersionsPath())
+		}
+	}
+	if placementContainer {
+		plan.containers = append(plan.containers, DaprPlacementContainerName)
+		if removeBin {
+			plan.images = append(plan.images, daprDockerImageName)
+		}
+	}
+	if config.All || config.ContainersOnly {
+		if !config.KeepRedis {
+			plan.containers = append(plan.containers, DaprRedisContainerName)
+		}
+		plan.containers = append(plan.containers, DaprZipkinContainerName)
+		plan.optionalContainers = append(plan.optionalContainers, DaprKafkaContainerName, DaprPostgresContainerName)
+	}
+	if config.All && removeFiles {
+		if !config.KeepBin {
+			plan.dirs = append(plan.dirs, defaultDaprDirPath())
+			return plan, nil
+		}
+		// Remove everything in the default Dapr directory but the bin and versions directories.
+		entries, err := os.ReadDir(defaultDaprDirPath())
+		if err != nil && !os.IsNotExist(err) {
+			return plan, err
+		}
+		for _, entry := range entries {
+			path := filepath.Join(defaultDaprDirPath(), entry.Name())
+			if path != defaultDaprBinPath() && path != defaultDaprVersionsPath() {
+				plan.dirs = append(plan.dirs, path)
+			}
+		}
+	}
+	return plan, nil
+}
+
+func removeContainers(containerRuntime ContainerRuntime, plan uninstallPlan, dockerNetwork string, dryRun bool) []error {
 	var containerErrs []error
-	var err error
 
-	if uninstallPlacementContainer {
-		containerErrs = removeDockerContainer(containerErrs, DaprPlacementContainerName, dockerNetwork)
+	for _, container := range plan.containers {
+		containerErrs = removeDockerContainer(containerRuntime, containerErrs, container, dockerNetwork, dryRun)
+	}
+	for _, container := range plan.optionalContainers {
+		if exists, _ := confirmContainerIsRunningOrExists(containerRuntime, utils.CreateContainerName(container, dockerNetwork), false); exists {
+			containerErrs = removeDockerContainer(containerRuntime, containerErrs, container, dockerNetwork, dryRun)
+		}
+	}
 
-		_, err = utils.RunCmdAndWait(
-			"docker", "rmi",
+	for _, image := range plan.images {
+		if dryRun {
+			print.DryRunStatusEvent(os.Stdout, "Would remove image: %s", containerRuntime.QualifyImage(image))
+			continue
+		}
+		_, err := containerRuntime.Run(
+			"rmi",
 			"--force",
-			daprDockerImageName)
+			containerRuntime.QualifyImage(image))
 
 		if err != nil {
 			containerErrs = append(
 				containerErrs,
-				fmt.Errorf("could not remove %s image: %w", daprDockerImageName, err))
+				fmt.Errorf("could not remove %s image: %w", image, err))
 		}
 	}
 
-	if uninstallAll {
-		containerErrs = removeDockerContainer(containerErrs, DaprRedisContainerName, dockerNetwork)
-		containerErrs = removeDockerContainer(containerErrs, DaprZipkinContainerName, dockerNetwork)
-	}
-
 	return containerErrs
 }
 
-func removeDockerContainer(containerErrs []error, containerName, network string) []error {
+func removeDockerContainer(containerRuntime ContainerRuntime, containerErrs []error, containerName, network string, dryRun bool) []error {
 	container := utils.CreateContainerName(containerName, network)
-	exists, _ := confirmContainerIsRunningOrExists(container, false)
+	exists, _ := confirmContainerIsRunningOrExists(containerRuntime, container, false)
 	if !exists {
 		print.WarningStatusEvent(os.Stdout, "WARNING: %s container does not exist", container)
 		return containerErrs
 	}
+	if dryRun {
+		print.DryRunStatusEvent(os.Stdout, "Would remove container: %s", container)
+		return containerErrs
+	}
 	print.InfoStatusEvent(os.Stdout, "Removing container: %s", container)
-	_, err := utils.RunCmdAndWait(
-		"docker", "rm",
+	_, err := containerRuntime.Run(
+		"rm",
 		"--force",
 		container)
 	if err != nil {
@@ -69,51 +153,65 @@ func removeDockerContainer(containerErrs []error, containerName, network string)
 	return containerErrs
 }
 
-func removeDir(dirPath string) error {
+func removeDir(dirPath string, dryRun bool) error {
 	_, err := os.Stat(dirPath)
 	if os.IsNotExist(err) {
 		print.WarningStatusEvent(os.Stdout, "WARNING: %s does not exist", dirPath)
 		return nil
 	}
+	if dryRun {
+		print.DryRunStatusEvent(os.Stdout, "Would remove: %s", dirPath)
+		return nil
+	}
 	print.InfoStatusEvent(os.Stdout, "Removing directory: %s", dirPath)
 	err = os.RemoveAll(dirPath)
 	return err
 }
 
-// Uninstall reverts all changes made by init. Deletes all installed containers, removes default dapr folder,
-// removes the installed binary and unsets env variables.
-func Uninstall(uninstallAll bool, dockerNetwork string) error {
+// Uninstall reverts the changes made by init. By default it deletes the placement container and removes the
+// installed binaries, with config.All it also deletes the Redis, Zipkin, Kafka and PostgreSQL containers and removes the default dapr folder.
+// Containers are removed with the given container runtime, Docker or Podman.
+func Uninstall(config UninstallConfig) error {
 	var containerErrs []error
-	daprDefaultDir := defaultDaprDirPath()
+	containerRuntime, err := NewContainerRuntime(config.ContainerRuntime)
+	if err != nil {
+		return err
+	}
 	daprBinDir := defaultDaprBinPath()
 
 	placementFilePath := binaryFilePath(daprBinDir, placementServiceFilePrefix)
 	_, placementErr := os.Stat(placementFilePath) // check if the placement binary exists.
 	uninstallPlacementContainer := os.IsNotExist(placementErr)
 
-	// Remove .dapr/bin.
-	err := removeDir(daprBinDir)
+	plan, err := newUninstallPlan(config, uninstallPlacementContainer)
 	if err != nil {
-		print.WarningStatusEvent(os.Stdout, "WARNING: could not delete dapr bin dir: %s", daprBinDir)
+		return err
 	}
 
-	dockerInstalled := false
-	dockerInstalled = utils.IsDockerInstalled()
-	if dockerInstalled {
-		containerErrs = removeContainers(uninstallPlacementContainer, uninstallAll, dockerNetwork)
+	// The services of a slim installation would run binaries that are removed.
+	if !uninstallPlacementContainer && !config.ContainersOnly && !config.KeepBin && !config.DryRun {
+		if err = UninstallServices(); err != nil {
+			print.WarningStatusEvent(os.Stdout, "WARNING: could not remove the services of the slim installation: %s", err)
+		}
 	}
 
-	if uninstallAll {
-		err = removeDir(daprDefaultDir)
+	// Remove .dapr/bin, .dapr/versions and, with --all, the default dapr dir.
+	for _, dir := range plan.dirs {
+		err = removeDir(dir, config.DryRun)
 		if err != nil {
-			print.WarningStatusEvent(os.Stdout, "WARNING: could not delete default dapr dir: %s", daprDefaultDir)
+			print.WarningStatusEvent(os.Stdout, "WARNING: could not delete %s", dir)
 		}
 	}
 
+	containerRuntimeRunning := containerRuntime.CheckRunning() == nil
+	if containerRuntimeRunning {
+		containerErrs = removeContainers(containerRuntime, plan, config.DockerNetwork, config.DryRun)
+	}
+
 	err = errors.New("uninstall failed")
-	if uninstallPlacementContainer && !dockerInstalled {
-		// if placement binary did not exist before trying to delete it and not able to connect to docker.
-		return fmt.Errorf("%w \ncould not delete placement service. Either the placement binary is not found, or Docker may not be installed or running", err)
+	if uninstallPlacementContainer && !containerRuntimeRunning {
+		// if placement binary did not exist before trying to delete it and not able to connect to the container runtime.
+		return fmt.Errorf("%w \ncould not delete placement service. Either the placement binary is not found, or %s may not be installed or running", err, containerRuntime.Name())
 	}
 
 	if len(containerErrs) == 0 {
@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUninstallPlan(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for _, dir := range []string{defaultDaprBinPath(), defaultDaprVersionsPath(), DefaultComponentsDirPath()} {
+		assert.NoError(t, os.MkdirAll(dir, 0o755))
+	}
+	assert.NoError(t, os.WriteFile(DefaultConfigFilePath(), []byte{}, 0o600))
+	daprDir := defaultDaprDirPath()
+
+	testCases := []struct {
+		name               string
+		config             UninstallConfig
+		placementContainer bool
+		expected           uninstallPlan
+	}{
+		{
+			name:               "default",
+			placementContainer: true,
+			expected: uninstallPlan{
+				dirs:       []string{defaultDaprBinPath(), defaultDaprVersionsPath()},
+				containers: []string{DaprPlacementContainerName},
+				images:     []string{daprDockerImageName},
+			},
+		},
+		{
+			name:   "slim",
+			config: UninstallConfig{All: true},
+			expected: uninstallPlan{
+				dirs:               []string{defaultDaprBinPath(), defaultDaprVersionsPath(), daprDir},
+				containers:         []string{DaprRedisContainerName, DaprZipkinContainerName},
+				optionalContainers: []string{DaprKafkaContainerName, DaprPostgresContainerName},
+			},
+		},
+		{
+			name:               "all",
+			config:             UninstallConfig{All: true},
+			placementContainer: true,
+			expected: uninstallPlan{
+				dirs:               []string{defaultDaprBinPath(), defaultDaprVersionsPath(), daprDir},
+				containers:         []string{DaprPlacementContainerName, DaprRedisContainerName, DaprZipkinContainerName},
+				optionalContainers: []string{DaprKafkaContainerName, DaprPostgresContainerName},
+				images:             []string{daprDockerImageName},
+			},
+		},
+		{
+			name:               "all keeping redis and binaries",
+			config:             UninstallConfig{All: true, KeepRedis: true, KeepBin: true},
+			placementContainer: true,
+			expected: uninstallPlan{
+				dirs:               []string{filepath.Join(daprDir, "components"), DefaultConfigFilePath()},
+				containers:         []string{DaprPlacementContainerName, DaprZipkinContainerName},
+				optionalContainers: []string{DaprKafkaContainerName, DaprPostgresContainerName},
+			},
+		},
+		{
+			name:               "containers only",
+			config:             UninstallConfig{All: true, ContainersOnly: true},
+			placementContainer: true,
+			expected: uninstallPlan{
+				containers:         []string{DaprPlacementContainerName, DaprRedisContainerName, DaprZipkinContainerName},
+				optionalContainers: []string{DaprKafkaContainerName, DaprPostgresContainerName},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, err := newUninstallPlan(tc.config, tc.placementContainer)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, plan)
+		})
+	}
+}
+
+func TestRemoveDirDryRun(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, removeDir(dir, true))
+	assert.DirExists(t, dir)
+
+	assert.NoError(t, removeDir(dir, false))
+	assert.NoDirExists(t, dir)
+}
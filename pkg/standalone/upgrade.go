@@ -0,0 +1,226 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dapr/cli/pkg/print"
+	cli_ver "github.com/dapr/cli/pkg/version"
+	"github.com/dapr/cli/utils"
+)
+
+const binBackupDirSuffix = ".bak"
+
+// UpgradeConfig represents the options for upgrading a self-hosted Dapr installation.
+type UpgradeConfig struct {
+	RuntimeVersion   string
+	DashboardVersion string
+	DockerNetwork    string
+	ImageRegistryURL string
+	ContainerRuntime string
+	// InsecureSkipVerify disables the verification of the checksums of the downloaded binaries.
+	InsecureSkipVerify bool
+	// DryRun prints the downloads, files and containers of the upgrade without upgrading.
+	DryRun bool
+}
+
+// Upgrade replaces the installed daprd, dashboard and placement binaries and the placement container
+// with the given versions. Existing configuration and components are kept, and default files missing
+// from the Dapr directory are created. If any step fails, the previous installation is restored.
+func Upgrade(config UpgradeConfig) error {
+	containerRuntime, err := NewContainerRuntime(config.ContainerRuntime)
+	if err != nil {
+		return err
+	}
+
+	daprBinDir := defaultDaprBinPath()
+	if _, err = os.Stat(binaryFilePath(daprBinDir, daprRuntimeFilePrefix)); err != nil {
+		return errors.New("could not find an existing Dapr installation. Run `dapr init` to install Dapr")
+	}
+	// Only slim installations have a placement binary, others run placement in a container.
+	_, placementErr := os.Stat(binaryFilePath(daprBinDir, placementServiceFilePrefix))
+	slimMode := placementErr == nil
+	_, dashboardErr := os.Stat(binaryFilePath(daprBinDir, dashboardFilePrefix))
+	hasDashboard := dashboardErr == nil
+
+	setAirGapInit("")
+	if !slimMode {
+		err = containerRuntime.CheckRunning()
+		if err != nil {
+			return err
+		}
+		if len(strings.TrimSpace(config.ImageRegistryURL)) == 0 {
+			defaultImageRegistryName, err = utils.GetDefaultRegistry(githubContainerRegistryName, dockerContainerRegistryName)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	runtimeVersion := config.RuntimeVersion
+	if runtimeVersion == latestVersion {
+		runtimeVersion, err = cli_ver.GetDaprVersion()
+		if err != nil {
+			return fmt.Errorf("cannot get the latest release version: '%w'. Try specifying --runtime-version=<desired_version>", err)
+		}
+	}
+	dashboardVersion := config.DashboardVersion
+	if hasDashboard && dashboardVersion == latestVersion {
+		dashboardVersion, err = cli_ver.GetDashboardVersion()
+		if err != nil {
+			return fmt.Errorf("cannot get the latest dashboard version: '%w'. Try specifying --dashboard-version=<desired_version>", err)
+		}
+	}
+	if !hasDashboard {
+		dashboardVersion = ""
+	}
+
+	print.InfoStatusEvent(os.Stdout, "Upgrading Dapr from runtime version %s to %s", strings.TrimSpace(GetRuntimeVersion()), runtimeVersion)
+	print.DebugStatusEvent(os.Stderr, "Dashboard version: %s, slim mode: %t, container runtime: %s, docker network: %q, image registry: %q",
+		dashboardVersion, slimMode, containerRuntime.Name(), config.DockerNetwork, config.ImageRegistryURL)
+
+	info := initInfo{
+		bundleDet:        &bundleDetails{},
+		slimMode:         slimMode,
+		runtimeVersion:   runtimeVersion,
+		dashboardVersion: dashboardVersion,
+		dockerNetwork:    config.DockerNetwork,
+		imageRegistryURL: config.ImageRegistryURL,
+		containerRuntime: containerRuntime,
+		skipVerify:       config.InsecureSkipVerify,
+		components:       DefaultInitComponents,
+	}
+
+	if config.DryRun {
+		return printUpgradeActions(info, daprBinDir)
+	}
+
+	backupStep := print.BeginStep(os.Stdout, "Backing up the current installation")
+	defer backupStep.End(print.Failure)
+	backupDir := daprBinDir + binBackupDirSuffix
+	err = backupBinDir(daprBinDir, backupDir)
+	if err != nil {
+		return err
+	}
+	backupStep.End(print.Success)
+
+	installStep := print.BeginStep(os.Stdout, "Installing binaries and components")
+	defer installStep.End(print.Failure)
+	stopSpinning := print.Spinner(os.Stdout, "Downloading binaries and upgrading the installation...")
+	defer stopSpinning(print.Failure)
+
+	err = makeDefaultComponentsDir(info.resourcesDir())
+	if err == nil {
+		err = runInitSteps([]func(*sync.WaitGroup, chan<- error, initInfo){
+			createSlimConfiguration,
+			createComponentsAndConfiguration,
+			installDaprRuntime,
+			installPlacement,
+			installDashboard,
+		}, info)
+	}
+	if err != nil {
+		return rollbackUpgrade(daprBinDir, backupDir, nil, err)
+	}
+
+	stopSpinning(print.Success)
+	installStep.End(print.Success)
+
+	if !slimMode {
+		placementStep := print.BeginStep(os.Stdout, "Upgrading the placement container")
+		defer placementStep.End(print.Failure)
+		var restorePlacement func()
+		restorePlacement, err = upgradePlacementContainer(info)
+		if err != nil {
+			return rollbackUpgrade(daprBinDir, backupDir, restorePlacement, err)
+		}
+		placementStep.End(print.Success)
+	}
+
+	err = os.RemoveAll(backupDir)
+	if err != nil {
+		print.WarningStatusEvent(os.Stdout, "Could not remove the backup of the previous installation at %s: %s", backupDir, err)
+	}
+
+	print.SuccessStatusEvent(os.Stdout, "Upgraded Dapr to runtime version %s.", runtimeVersion)
+	return nil
+}
+
+// backupBinDir moves the binaries of the current installation to backupDir and creates an empty bin directory.
+func backupBinDir(daprBinDir, backupDir string) error {
+	err := os.RemoveAll(backupDir)
+	if err != nil {
+		return fmt.Errorf("error removing previous backup %s: %w", backupDir, err)
+	}
+	err = os.Rename(daprBinDir, backupDir)
+	if err != nil {
+		return fmt.Errorf("error backing up %s: %w", daprBinDir, err)
+	}
+	err = prepareDaprInstallDir(daprBinDir)
+	if err != nil {
+		os.Rename(backupDir, daprBinDir)
+		return err
+	}
+	return nil
+}
+
+// upgradePlacementContainer replaces the placement container with one running the upgraded image.
+// The returned function restores the previous container, and is nil if there was none.
+func upgradePlacementContainer(info initInfo) (func(), error) {
+	placementContainerName := utils.CreateContainerName(DaprPlacementContainerName, info.dockerNetwork)
+
+	var restore func()
+	previousImage, err := info.containerRuntime.Run("inspect", "--format", "{{.Config.Image}}", placementContainerName)
+	if err == nil {
+		previousImage = strings.TrimSpace(previousImage)
+		print.DebugStatusEvent(os.Stderr, "Replacing %s container running image %s", placementContainerName, previousImage)
+		_, err = info.containerRuntime.Run("rm", "--force", placementContainerName)
+		if err != nil {
+			return nil, fmt.Errorf("error removing %s container: %w", placementContainerName, err)
+		}
+		restore = func() {
+			info.containerRuntime.Run("rm", "--force", placementContainerName)
+			args := append(placementContainerArgs(placementContainerName, info), previousImage)
+			if _, restoreErr := info.containerRuntime.Run(args...); restoreErr != nil {
+				print.FailureStatusEvent(os.Stderr, "Could not restore %s container: %s", placementContainerName, restoreErr)
+			}
+		}
+	}
+
+	err = runInitSteps([]func(*sync.WaitGroup, chan<- error, initInfo){runPlacementService}, info)
+	return restore, err
+}
+
+// rollbackUpgrade restores the previous installation after a failed upgrade and returns the cause of the failure.
+func rollbackUpgrade(daprBinDir, backupDir string, restorePlacement func(), cause error) error {
+	print.WarningStatusEvent(os.Stdout, "Upgrade failed, restoring the previous installation")
+
+	if restorePlacement != nil {
+		restorePlacement()
+	}
+
+	err := os.RemoveAll(daprBinDir)
+	if err == nil {
+		err = os.Rename(backupDir, daprBinDir)
+	}
+	if err != nil {
+		return fmt.Errorf("%w. Could not restore the previous installation from %s: %s", cause, backupDir, err)
+	}
+	return fmt.Errorf("%w. The previous installation has been restored", cause)
+}
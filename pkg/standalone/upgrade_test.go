@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"errors"
+	"os"
+	path_filepath "path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpgradeRollback(t *testing.T) {
+	daprBinDir := path_filepath.Join(t.TempDir(), "bin")
+	backupDir := daprBinDir + binBackupDirSuffix
+	assert.NoError(t, os.Mkdir(daprBinDir, 0o755))
+	assert.NoError(t, os.WriteFile(path_filepath.Join(daprBinDir, "daprd"), []byte("old"), 0o600))
+
+	err := backupBinDir(daprBinDir, backupDir)
+	assert.NoError(t, err)
+	entries, err := os.ReadDir(daprBinDir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries, "bin directory should be empty after the backup")
+
+	assert.NoError(t, os.WriteFile(path_filepath.Join(daprBinDir, "daprd"), []byte("new"), 0o600))
+
+	restoredPlacement := false
+	cause := errors.New("download failed")
+	err = rollbackUpgrade(daprBinDir, backupDir, func() { restoredPlacement = true }, cause)
+	assert.ErrorIs(t, err, cause)
+	assert.True(t, restoredPlacement)
+
+	b, err := os.ReadFile(path_filepath.Join(daprBinDir, "daprd"))
+	assert.NoError(t, err)
+	assert.Equal(t, "old", string(b))
+	assert.NoDirExists(t, backupDir)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	process "github.com/shirou/gopsutil/process"
+
+	"github.com/dapr/cli/pkg/print"
+)
+
+// UsageSampleInterval is the interval over which the CPU usage of the processes of `dapr list` is measured.
+const UsageSampleInterval = 500 * time.Millisecond
+
+// ListSortKeys are the values of `dapr list --sort`.
+var ListSortKeys = []string{"cpu", "mem", "age"}
+
+// CPUUsage is the CPU usage of a process, in percent of one core.
+type CPUUsage float64
+
+// MarshalCSV prints the usage as a percentage in tables.
+func (c CPUUsage) MarshalCSV() (string, error) {
+	return fmt.Sprintf("%.1f%%", float64(c)), nil
+}
+
+// MemoryUsage is the resident memory of a process, in bytes.
+type MemoryUsage uint64
+
+// MarshalCSV prints the usage in a human readable unit in tables.
+func (m MemoryUsage) MarshalCSV() (string, error) {
+	return print.FormatBytes(int64(m)), nil
+}
+
+type cpuSample struct {
+	proc *process.Process
+	cpu  *CPUUsage
+	mem  *MemoryUsage
+	busy float64
+}
+
+// SampleResourceUsage sets the CPU and memory usage of the sidecar and app processes of list. The CPU usage is
+// measured over interval. The usage of processes that cannot be read, such as apps not started by `dapr run`, is left at 0.
+func SampleResourceUsage(list []ListOutput, interval time.Duration) {
+	samples := []*cpuSample{}
+	for i := range list {
+		row := &list[i]
+		for _, p := range []struct {
+			pid int
+			cpu *CPUUsage
+			mem *MemoryUsage
+		}{{row.DaprdPID, &row.DaprdCPU, &row.DaprdMemory}, {row.AppPID, &row.AppCPU, &row.AppMemory}} {
+			if p.pid <= 0 {
+				continue
+			}
+			proc, err := process.NewProcess(int32(p.pid))
+			if err != nil {
+				continue
+			}
+			times, err := proc.Times()
+			if err != nil {
+				continue
+			}
+			samples = append(samples, &cpuSample{proc: proc, cpu: p.cpu, mem: p.mem, busy: times.User + times.System})
+		}
+	}
+	if len(samples) == 0 {
+		return
+	}
+
+	start := time.Now()
+	time.Sleep(interval)
+	elapsed := time.Since(start).Seconds()
+	for _, s := range samples {
+		if times, err := s.proc.Times(); err == nil && elapsed > 0 {
+			*s.cpu = CPUUsage((times.User + times.System - s.busy) / elapsed * 100)
+		}
+		if mem, err := s.proc.MemoryInfo(); err == nil {
+			*s.mem = MemoryUsage(mem.RSS)
+		}
+	}
+}
+
+// SortList sorts list by key, one of ListSortKeys: by the CPU or memory usage of the sidecar and app together,
+// highest first, or by age, oldest first.
+func SortList(list []ListOutput, key string) error {
+	var less func(a, b ListOutput) bool
+	switch key {
+	case "cpu":
+		less = func(a, b ListOutput) bool {
+			return a.DaprdCPU+a.AppCPU > b.DaprdCPU+b.AppCPU
+		}
+	case "mem":
+		less = func(a, b ListOutput) bool {
+			return a.DaprdMemory+a.AppMemory > b.DaprdMemory+b.AppMemory
+		}
+	case "age":
+		less = func(a, b ListOutput) bool {
+			return a.Created < b.Created
+		}
+	default:
+		return fmt.Errorf("invalid sort key %q, valid values are: %s", key, strings.Join(ListSortKeys, ", "))
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return less(list[i], list[j])
+	})
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortList(t *testing.T) {
+	list := []ListOutput{
+		{AppID: "a", DaprdCPU: 1, AppCPU: 1, DaprdMemory: 300, Created: "2022-10-01 10:00.00"},
+		{AppID: "b", DaprdCPU: 5, AppCPU: 0, DaprdMemory: 100, AppMemory: 100, Created: "2022-09-01 10:00.00"},
+		{AppID: "c", DaprdCPU: 0, AppCPU: 3, DaprdMemory: 500, Created: "2022-10-02 10:00.00"},
+	}
+	ids := func() []string {
+		r := []string{}
+		for _, l := range list {
+			r = append(r, l.AppID)
+		}
+		return r
+	}
+
+	require.NoError(t, SortList(list, "cpu"))
+	assert.Equal(t, []string{"b", "c", "a"}, ids())
+	require.NoError(t, SortList(list, "mem"))
+	assert.Equal(t, []string{"c", "a", "b"}, ids())
+	require.NoError(t, SortList(list, "age"))
+	assert.Equal(t, []string{"b", "a", "c"}, ids())
+	assert.Error(t, SortList(list, "name"))
+}
+
+func TestSampleResourceUsage(t *testing.T) {
+	list := []ListOutput{{AppID: "self", DaprdPID: os.Getpid()}}
+	SampleResourceUsage(list, 50*time.Millisecond)
+	assert.Greater(t, list[0].DaprdMemory, MemoryUsage(0))
+	assert.Zero(t, list[0].AppMemory)
+
+	cpu, _ := CPUUsage(12.34).MarshalCSV()
+	assert.Equal(t, "12.3%", cpu)
+	mem, _ := MemoryUsage(3 * 1024 * 1024).MarshalCSV()
+	assert.Equal(t, "3.0 MB", mem)
+}
@@ -16,7 +16,10 @@ package standalone
 import (
 	"bufio"
 	"os/exec"
+	"runtime"
+	"sort"
 	"strings"
+	"unicode"
 )
 
 // Values for these are injected by the build.
@@ -24,6 +27,11 @@ var (
 	gitcommit, gitversion string
 )
 
+// GitCommit returns the git commit the CLI was built from.
+func GitCommit() string {
+	return gitcommit
+}
+
 // GetRuntimeVersion returns the version for the local Dapr runtime.
 func GetRuntimeVersion() string {
 	daprBinDir := defaultDaprBinPath()
@@ -48,31 +56,127 @@ func GetDashboardVersion() string {
 	return string(out)
 }
 
-// GetBuildInfo returns build info for the CLI and the local Dapr runtime.
-func GetBuildInfo(version string) string {
-	daprBinDir := defaultDaprBinPath()
-	daprCMD := binaryFilePath(daprBinDir, "daprd")
+// BuildInfo is the build info of the CLI and of the local Dapr runtime and dashboard.
+type BuildInfo struct {
+	CliVersion       string            `json:"cliVersion"`
+	GitCommit        string            `json:"gitCommit,omitempty"`
+	GitVersion       string            `json:"gitVersion,omitempty"`
+	GoVersion        string            `json:"goVersion"`
+	DashboardVersion string            `json:"dashboardVersion,omitempty"`
+	Runtime          *RuntimeBuildInfo `json:"runtime,omitempty"`
+}
 
-	strs := []string{
-		"CLI:",
-		"\tVersion: " + version,
-		"\tGit Commit: " + gitcommit,
-		"\tGit Version: " + gitversion,
-		"Runtime:",
+// RuntimeBuildInfo is the build info of the Dapr runtime, parsed from the output of daprd --build-info.
+type RuntimeBuildInfo struct {
+	Version    string   `json:"version"`
+	GitCommit  string   `json:"gitCommit,omitempty"`
+	GitVersion string   `json:"gitVersion,omitempty"`
+	Features   []string `json:"features,omitempty"`
+	// Other holds the fields of the build info that are not known to the CLI, such as those of newer runtimes.
+	Other map[string]string `json:"other,omitempty"`
+}
+
+// GetBuildInfo returns build info for the CLI and the local Dapr runtime and dashboard.
+func GetBuildInfo(version string) BuildInfo {
+	info := BuildInfo{
+		CliVersion: version,
+		GitCommit:  gitcommit,
+		GitVersion: gitversion,
+		GoVersion:  runtime.Version(),
+	}
+	if dashboardVersion := strings.TrimSpace(GetDashboardVersion()); dashboardVersion != "n/a" {
+		info.DashboardVersion = dashboardVersion
 	}
 
+	daprBinDir := defaultDaprBinPath()
+	daprCMD := binaryFilePath(daprBinDir, "daprd")
 	out, err := exec.Command(daprCMD, "--build-info").Output()
 	if err != nil {
 		// try '--version' for older runtime version.
 		out, err = exec.Command(daprCMD, "--version").Output()
 	}
-	if err != nil {
-		strs = append(strs, "\tN/A")
-	} else {
-		scanner := bufio.NewScanner(strings.NewReader(string(out)))
-		for scanner.Scan() {
-			strs = append(strs, "\t"+scanner.Text())
+	if err == nil {
+		info.Runtime = ParseRuntimeBuildInfo(string(out))
+	}
+	return info
+}
+
+// ParseRuntimeBuildInfo parses the "Key: value" lines printed by daprd --build-info. The single line printed by
+// daprd --version of older runtimes is parsed as the version. It returns nil if out has no version.
+func ParseRuntimeBuildInfo(out string) *RuntimeBuildInfo {
+	info := &RuntimeBuildInfo{}
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
 		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			if info.Version == "" {
+				info.Version = line
+			}
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch strings.ToLower(key) {
+		case "version":
+			info.Version = value
+		case "git commit":
+			info.GitCommit = value
+		case "git version":
+			info.GitVersion = value
+		case "features":
+			info.Features = strings.FieldsFunc(value, func(r rune) bool {
+				return r == ',' || unicode.IsSpace(r)
+			})
+		default:
+			if info.Other == nil {
+				info.Other = map[string]string{}
+			}
+			info.Other[key] = value
+		}
+	}
+	if info.Version == "" {
+		return nil
+	}
+	return info
+}
+
+// String formats the build info as printed by dapr build-info.
+func (info BuildInfo) String() string {
+	strs := []string{
+		"CLI:",
+		"\tVersion: " + info.CliVersion,
+		"\tGit Commit: " + info.GitCommit,
+		"\tGit Version: " + info.GitVersion,
+		"\tGo Version: " + info.GoVersion,
+	}
+	if info.DashboardVersion != "" {
+		strs = append(strs, "Dashboard:", "\tVersion: "+info.DashboardVersion)
+	}
+
+	strs = append(strs, "Runtime:")
+	if info.Runtime == nil {
+		return strings.Join(append(strs, "\tN/A"), "\n")
+	}
+	strs = append(strs, "\tVersion: "+info.Runtime.Version)
+	if info.Runtime.GitCommit != "" {
+		strs = append(strs, "\tGit Commit: "+info.Runtime.GitCommit)
+	}
+	if info.Runtime.GitVersion != "" {
+		strs = append(strs, "\tGit Version: "+info.Runtime.GitVersion)
+	}
+	if len(info.Runtime.Features) > 0 {
+		strs = append(strs, "\tFeatures: "+strings.Join(info.Runtime.Features, ", "))
+	}
+	keys := make([]string, 0, len(info.Runtime.Other))
+	for key := range info.Runtime.Other {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		strs = append(strs, "\t"+key+": "+info.Runtime.Other[key])
 	}
 	return strings.Join(strs, "\n")
 }
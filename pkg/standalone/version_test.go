@@ -0,0 +1,51 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRuntimeBuildInfo(t *testing.T) {
+	t.Run("build info", func(t *testing.T) {
+		info := ParseRuntimeBuildInfo("Version: 1.8.0\nGit Commit: 4b69c4902b1e\nGit Version: v1.8.0\n")
+		assert.Equal(t, &RuntimeBuildInfo{Version: "1.8.0", GitCommit: "4b69c4902b1e", GitVersion: "v1.8.0"}, info)
+	})
+
+	t.Run("features and unknown fields", func(t *testing.T) {
+		info := ParseRuntimeBuildInfo("Version: 1.9.0\nFeatures: allcomponents, wasm\nBuild Date: 2022-09-21\n")
+		assert.Equal(t, "1.9.0", info.Version)
+		assert.Equal(t, []string{"allcomponents", "wasm"}, info.Features)
+		assert.Equal(t, map[string]string{"Build Date": "2022-09-21"}, info.Other)
+	})
+
+	t.Run("version of older runtimes", func(t *testing.T) {
+		assert.Equal(t, &RuntimeBuildInfo{Version: "1.4.3"}, ParseRuntimeBuildInfo("1.4.3\n"))
+	})
+
+	t.Run("no version", func(t *testing.T) {
+		assert.Nil(t, ParseRuntimeBuildInfo(""))
+		assert.Nil(t, ParseRuntimeBuildInfo("Git Commit: 4b69c4902b1e\n"))
+	})
+}
+
+func TestBuildInfoString(t *testing.T) {
+	info := BuildInfo{CliVersion: "1.8.1", GitCommit: "abc", GitVersion: "v1.8.1", GoVersion: "go1.18"}
+	assert.Equal(t, "CLI:\n\tVersion: 1.8.1\n\tGit Commit: abc\n\tGit Version: v1.8.1\n\tGo Version: go1.18\nRuntime:\n\tN/A", info.String())
+
+	info.Runtime = &RuntimeBuildInfo{Version: "1.8.0", Features: []string{"wasm"}, Other: map[string]string{"Build Date": "today"}}
+	assert.Contains(t, info.String(), "Runtime:\n\tVersion: 1.8.0\n\tFeatures: wasm\n\tBuild Date: today")
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	path_filepath "path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-version"
+
+	"github.com/dapr/cli/pkg/print"
+	cli_ver "github.com/dapr/cli/pkg/version"
+)
+
+const defaultDaprVersionsDirName = "versions"
+
+// versionBinaries are the binaries of a runtime version. Only slim installations have a placement binary.
+var versionBinaries = []string{daprRuntimeFilePrefix, placementServiceFilePrefix}
+
+// RuntimeVersion is a version of the runtime installed side by side with the others.
+type RuntimeVersion struct {
+	Version string `csv:"VERSION" json:"version" yaml:"version"`
+	Active  bool   `csv:"ACTIVE" json:"active" yaml:"active"`
+	Path    string `csv:"PATH" json:"path" yaml:"path"`
+}
+
+func defaultDaprVersionsPath() string {
+	return path_filepath.Join(defaultDaprDirPath(), defaultDaprVersionsDirName)
+}
+
+// runtimeVersionDirPath returns the directory of the binaries of a runtime version, such as versions/1.10.0.
+func runtimeVersionDirPath(runtimeVersion string) string {
+	return path_filepath.Join(defaultDaprVersionsPath(), strings.TrimPrefix(runtimeVersion, "v"))
+}
+
+func validateRuntimeVersion(runtimeVersion string) error {
+	v := strings.TrimPrefix(runtimeVersion, "v")
+	if v == "" || v == "." || v == ".." || strings.ContainsAny(v, `/\`) {
+		return fmt.Errorf("invalid runtime version %q", runtimeVersion)
+	}
+	return nil
+}
+
+// activeRuntimeVersion returns the version of the daprd binary in the bin directory, or an empty string
+// if it cannot be run.
+func activeRuntimeVersion() string {
+	out, err := exec.Command(binaryFilePath(defaultDaprBinPath(), daprRuntimeFilePrefix), "--version").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(string(out)), "v")
+}
+
+// InstallRuntimeVersion installs the daprd binary of a runtime version, and the placement binary in slim
+// installations, next to the active version instead of replacing it. Switch to it with UseRuntimeVersion.
+func InstallRuntimeVersion(runtimeVersion string, insecureSkipVerify bool) error {
+	daprBinDir := defaultDaprBinPath()
+	if _, err := os.Stat(binaryFilePath(daprBinDir, daprRuntimeFilePrefix)); err != nil {
+		return errors.New("could not find an existing Dapr installation. Run `dapr init` to install Dapr before adding other runtime versions")
+	}
+
+	var err error
+	if runtimeVersion == latestVersion {
+		runtimeVersion, err = cli_ver.GetDaprVersion()
+		if err != nil {
+			return fmt.Errorf("cannot get the latest release version: '%w'. Try specifying --runtime-version=<desired_version>", err)
+		}
+	}
+	if err = validateRuntimeVersion(runtimeVersion); err != nil {
+		return err
+	}
+	runtimeVersion = strings.TrimPrefix(runtimeVersion, "v")
+
+	versionDir := runtimeVersionDirPath(runtimeVersion)
+	if _, err = os.Stat(binaryFilePath(versionDir, daprRuntimeFilePrefix)); err == nil {
+		return fmt.Errorf("runtime version %s is already installed to %s. Run `dapr use %s` to make it the default version", runtimeVersion, versionDir, runtimeVersion)
+	}
+	// Keep a copy of the active version, so that it can be switched back to.
+	if err = saveActiveRuntimeVersion(); err != nil {
+		return err
+	}
+
+	setAirGapInit("")
+	_, placementErr := os.Stat(binaryFilePath(daprBinDir, placementServiceFilePrefix))
+	info := initInfo{
+		bundleDet:      &bundleDetails{},
+		slimMode:       placementErr == nil,
+		runtimeVersion: runtimeVersion,
+		skipVerify:     insecureSkipVerify,
+		binDir:         versionDir,
+	}
+	if err = prepareDaprInstallDir(versionDir); err != nil {
+		return err
+	}
+
+	print.InfoStatusEvent(os.Stdout, "Installing runtime version %s side by side", runtimeVersion)
+	stopSpinning := print.Spinner(os.Stdout, "Downloading binaries...")
+	defer stopSpinning(print.Failure)
+	err = runInitSteps([]func(*sync.WaitGroup, chan<- error, initInfo){installDaprRuntime, installPlacement}, info)
+	if err != nil {
+		os.RemoveAll(versionDir)
+		return err
+	}
+	stopSpinning(print.Success)
+
+	print.SuccessStatusEvent(os.Stdout, "Runtime version %s has been installed to %s. Run `dapr use %s` to make it the default version", runtimeVersion, versionDir, runtimeVersion)
+	return nil
+}
+
+// UseRuntimeVersion makes a runtime version installed side by side the default version, by copying its binaries
+// to the bin directory. The active version is kept in the versions directory first.
+func UseRuntimeVersion(runtimeVersion string) error {
+	if err := validateRuntimeVersion(runtimeVersion); err != nil {
+		return err
+	}
+	runtimeVersion = strings.TrimPrefix(runtimeVersion, "v")
+
+	versionDir := runtimeVersionDirPath(runtimeVersion)
+	if _, err := os.Stat(binaryFilePath(versionDir, daprRuntimeFilePrefix)); err != nil {
+		return fmt.Errorf("runtime version %s is not installed. Install it with `dapr init --runtime-version %s --side-by-side`", runtimeVersion, runtimeVersion)
+	}
+	if activeRuntimeVersion() == runtimeVersion {
+		print.InfoStatusEvent(os.Stdout, "Runtime version %s is already the default version", runtimeVersion)
+		return nil
+	}
+	if err := saveActiveRuntimeVersion(); err != nil {
+		return err
+	}
+
+	if err := copyVersionBinaries(versionDir, defaultDaprBinPath()); err != nil {
+		return fmt.Errorf("error switching to runtime version %s: %w", runtimeVersion, err)
+	}
+	print.SuccessStatusEvent(os.Stdout, "Runtime version %s is now the default version", runtimeVersion)
+	return nil
+}
+
+// ListRuntimeVersions returns the runtime versions installed side by side, and the active version, sorted by version.
+func ListRuntimeVersions() ([]RuntimeVersion, error) {
+	active := activeRuntimeVersion()
+	versions := []RuntimeVersion{}
+
+	entries, err := os.ReadDir(defaultDaprVersionsPath())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading the installed runtime versions: %w", err)
+	}
+	for _, entry := range entries {
+		dir := path_filepath.Join(defaultDaprVersionsPath(), entry.Name())
+		if _, err = os.Stat(binaryFilePath(dir, daprRuntimeFilePrefix)); !entry.IsDir() || err != nil {
+			continue
+		}
+		versions = append(versions, RuntimeVersion{Version: entry.Name(), Active: entry.Name() == active, Path: dir})
+	}
+
+	found := false
+	for _, v := range versions {
+		found = found || v.Active
+	}
+	if active != "" && !found {
+		versions = append(versions, RuntimeVersion{Version: active, Active: true, Path: defaultDaprBinPath()})
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		vi, errI := version.NewVersion(versions[i].Version)
+		vj, errJ := version.NewVersion(versions[j].Version)
+		if errI != nil || errJ != nil {
+			return versions[i].Version < versions[j].Version
+		}
+		return vi.LessThan(vj)
+	})
+	return versions, nil
+}
+
+// saveActiveRuntimeVersion copies the binaries of the active version to its directory under versions,
+// unless they are already there.
+func saveActiveRuntimeVersion() error {
+	active := activeRuntimeVersion()
+	if active == "" || validateRuntimeVersion(active) != nil {
+		print.WarningStatusEvent(os.Stdout, "Could not get the version of the installed runtime, it will not be kept side by side")
+		return nil
+	}
+	versionDir := runtimeVersionDirPath(active)
+	if _, err := os.Stat(binaryFilePath(versionDir, daprRuntimeFilePrefix)); err == nil {
+		return nil
+	}
+	if err := prepareDaprInstallDir(versionDir); err != nil {
+		return err
+	}
+	if err := copyVersionBinaries(defaultDaprBinPath(), versionDir); err != nil {
+		os.RemoveAll(versionDir)
+		return fmt.Errorf("error keeping runtime version %s: %w", active, err)
+	}
+	print.DebugStatusEvent(os.Stderr, "Kept runtime version %s in %s", active, versionDir)
+	return nil
+}
+
+// copyVersionBinaries copies the binaries of a runtime version that exist in srcDir to dstDir.
+func copyVersionBinaries(srcDir, dstDir string) error {
+	for _, binary := range versionBinaries {
+		src := binaryFilePath(srcDir, binary)
+		if _, err := os.Stat(src); err != nil {
+			continue
+		}
+		if err := copyExecutable(src, binaryFilePath(dstDir, binary)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyExecutable copies src to dst through a temporary file, so that a binary in use by running apps
+// is replaced rather than written to.
+func copyExecutable(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(path_filepath.Dir(dst), path_filepath.Base(dst)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = io.Copy(tmp, in); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = makeExecutable(tmp.Name()); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"os"
+	path_filepath "path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFakeDaprd writes a daprd script to dir that prints version.
+func writeFakeDaprd(t *testing.T, dir, version string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	// #nosec G306
+	require.NoError(t, os.WriteFile(path_filepath.Join(dir, "daprd"), []byte("#!/bin/sh\necho "+version+"\n"), 0o755))
+}
+
+func setTestInstallPath(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, SetInstallPath(dir))
+	t.Cleanup(func() { installPath = "" })
+	return dir
+}
+
+func TestSetInstallPath(t *testing.T) {
+	dir := setTestInstallPath(t)
+
+	assert.Equal(t, dir, defaultDaprDirPath())
+	assert.Equal(t, path_filepath.Join(dir, "bin"), defaultDaprBinPath())
+	assert.Equal(t, path_filepath.Join(dir, "components"), DefaultComponentsDirPath())
+	assert.Equal(t, path_filepath.Join(dir, "versions", "1.10.0"), runtimeVersionDirPath("v1.10.0"))
+}
+
+func TestUseRuntimeVersion(t *testing.T) {
+	if runtime.GOOS == daprWindowsOS {
+		t.Skip("the fake daprd is a shell script")
+	}
+	dir := setTestInstallPath(t)
+	writeFakeDaprd(t, defaultDaprBinPath(), "1.10.0")
+	writeFakeDaprd(t, runtimeVersionDirPath("1.9.0"), "1.9.0")
+
+	versions, err := ListRuntimeVersions()
+	require.NoError(t, err)
+	assert.Equal(t, []RuntimeVersion{
+		{Version: "1.9.0", Path: path_filepath.Join(dir, "versions", "1.9.0")},
+		{Version: "1.10.0", Active: true, Path: path_filepath.Join(dir, "bin")},
+	}, versions)
+
+	require.NoError(t, UseRuntimeVersion("v1.9.0"))
+	assert.Equal(t, "1.9.0", activeRuntimeVersion())
+
+	// The previous version is kept, so that it can be switched back to.
+	versions, err = ListRuntimeVersions()
+	require.NoError(t, err)
+	assert.Equal(t, []RuntimeVersion{
+		{Version: "1.9.0", Active: true, Path: path_filepath.Join(dir, "versions", "1.9.0")},
+		{Version: "1.10.0", Path: path_filepath.Join(dir, "versions", "1.10.0")},
+	}, versions)
+
+	require.NoError(t, UseRuntimeVersion("1.10.0"))
+	assert.Equal(t, "1.10.0", activeRuntimeVersion())
+
+	assert.ErrorContains(t, UseRuntimeVersion("1.8.0"), "runtime version 1.8.0 is not installed")
+	assert.Error(t, UseRuntimeVersion("../bin"))
+}
+
+func TestInstallRuntimeVersionRequiresInstallation(t *testing.T) {
+	setTestInstallPath(t)
+
+	err := InstallRuntimeVersion("1.9.0", false)
+	assert.ErrorContains(t, err, "could not find an existing Dapr installation")
+}
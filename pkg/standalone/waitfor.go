@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dapr/cli/pkg/metadata"
+	"github.com/dapr/cli/pkg/print"
+)
+
+const (
+	// DefaultWaitForTimeout is the time to wait for the dependencies of an app to become reachable.
+	DefaultWaitForTimeout = 60 * time.Second
+
+	waitForPollInterval  = 500 * time.Millisecond
+	waitForCheckTimeout  = 2 * time.Second
+	waitForSchemeTCP     = "tcp"
+	waitForSchemeHTTP    = "http"
+	waitForSchemeHTTPS   = "https"
+	waitForSchemeDaprApp = "app"
+)
+
+// waitForTarget is a dependency that must be reachable before an app is started.
+type waitForTarget struct {
+	raw    string
+	scheme string
+	// address is host:port for TCP, the URL for HTTP and the app ID for apps.
+	address string
+}
+
+func parseWaitForTarget(target string) (waitForTarget, error) {
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme == "" {
+		return waitForTarget{}, fmt.Errorf("invalid wait-for target %q. Use tcp://host:port, http(s)://host:port/path or app://<app-id>", target)
+	}
+
+	parsed := waitForTarget{raw: target, scheme: strings.ToLower(u.Scheme)}
+	switch parsed.scheme {
+	case waitForSchemeTCP:
+		if u.Hostname() == "" || u.Port() == "" {
+			return waitForTarget{}, fmt.Errorf("invalid wait-for target %q. TCP targets must have a host and a port", target)
+		}
+		parsed.address = u.Host
+	case waitForSchemeHTTP, waitForSchemeHTTPS:
+		if u.Host == "" {
+			return waitForTarget{}, fmt.Errorf("invalid wait-for target %q. HTTP targets must have a host", target)
+		}
+		parsed.address = target
+	case waitForSchemeDaprApp:
+		if u.Host == "" {
+			return waitForTarget{}, fmt.Errorf("invalid wait-for target %q. App targets must have an app ID", target)
+		}
+		parsed.address = u.Host
+	default:
+		return waitForTarget{}, fmt.Errorf("unsupported scheme %q of wait-for target %q. Supported schemes are: tcp, http, https, app", u.Scheme, target)
+	}
+	return parsed, nil
+}
+
+// ValidateWaitFor returns an error if any of the wait-for targets is invalid.
+func ValidateWaitFor(targets []string) error {
+	for _, target := range targets {
+		if _, err := parseWaitForTarget(target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WaitFor waits until all targets are reachable, or returns an error naming the unreachable ones after timeout.
+// Targets are tcp://host:port, accepting TCP connections, http(s):// URLs, responding with a status below 400,
+// or app://<app-id>, an app started with dapr run whose sidecar responds.
+func WaitFor(targets []string, timeout time.Duration) error {
+	parsed := make([]waitForTarget, 0, len(targets))
+	for _, target := range targets {
+		t, err := parseWaitForTarget(target)
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, t)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	unreachable := []string{}
+	deadline := time.Now().Add(timeout)
+	for _, target := range parsed {
+		wg.Add(1)
+		go func(target waitForTarget) {
+			defer wg.Done()
+			print.InfoStatusEvent(os.Stdout, "Waiting for %s to be reachable", target.raw)
+			for {
+				err := target.check()
+				if err == nil {
+					print.SuccessStatusEvent(os.Stdout, "%s is reachable", target.raw)
+					return
+				}
+				if time.Now().Add(waitForPollInterval).After(deadline) {
+					print.DebugStatusEvent(os.Stderr, "Last error checking %s: %s", target.raw, err)
+					mu.Lock()
+					unreachable = append(unreachable, target.raw)
+					mu.Unlock()
+					return
+				}
+				time.Sleep(waitForPollInterval)
+			}
+		}(target)
+	}
+	wg.Wait()
+
+	if len(unreachable) > 0 {
+		return fmt.Errorf("timed out after %s waiting for %s", timeout, strings.Join(unreachable, ", "))
+	}
+	return nil
+}
+
+// check returns nil if the target is reachable.
+func (t waitForTarget) check() error {
+	switch t.scheme {
+	case waitForSchemeTCP:
+		conn, err := net.DialTimeout("tcp", t.address, waitForCheckTimeout)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	case waitForSchemeHTTP, waitForSchemeHTTPS:
+		client := http.Client{Timeout: waitForCheckTimeout}
+		resp, err := client.Get(t.address)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+		return nil
+	default:
+		return checkDaprApp(t.address)
+	}
+}
+
+func checkDaprApp(appID string) error {
+	apps, err := List()
+	if err != nil {
+		return err
+	}
+	for _, app := range apps {
+		if app.AppID == appID {
+			_, err = metadata.Get(app.HTTPPort, app.AppID, "")
+			return err
+		}
+	}
+	return errors.New("app is not running")
+}
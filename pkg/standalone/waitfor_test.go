@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWaitForTarget(t *testing.T) {
+	testCases := []struct {
+		target  string
+		scheme  string
+		address string
+		err     string
+	}{
+		{target: "tcp://localhost:5432", scheme: "tcp", address: "localhost:5432"},
+		{target: "http://localhost:8080/healthz", scheme: "http", address: "http://localhost:8080/healthz"},
+		{target: "HTTPS://example.com", scheme: "https", address: "HTTPS://example.com"},
+		{target: "app://orders", scheme: "app", address: "orders"},
+		{target: "localhost:5432", err: "unsupported scheme"},
+		{target: "tcp://localhost", err: "TCP targets must have a host and a port"},
+		{target: "app://", err: "App targets must have an app ID"},
+		{target: "redis://localhost:6379", err: "unsupported scheme"},
+		{target: "", err: "invalid wait-for target"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.target, func(t *testing.T) {
+			parsed, err := parseWaitForTarget(tc.target)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.scheme, parsed.scheme)
+			assert.Equal(t, tc.address, parsed.address)
+		})
+	}
+}
+
+func TestWaitFor(t *testing.T) {
+	t.Run("reachable targets", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		defer listener.Close()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		err = WaitFor([]string{"tcp://" + listener.Addr().String(), server.URL}, time.Second)
+		assert.NoError(t, err)
+	})
+
+	t.Run("target becomes reachable", func(t *testing.T) {
+		ready := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-ready:
+				w.WriteHeader(http.StatusOK)
+			default:
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+
+		time.AfterFunc(600*time.Millisecond, func() { close(ready) })
+		assert.NoError(t, WaitFor([]string{server.URL + "/healthz"}, 5*time.Second))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		target := "tcp://" + listener.Addr().String()
+		listener.Close()
+
+		err = WaitFor([]string{target}, time.Second)
+		assert.EqualError(t, err, "timed out after 1s waiting for "+target)
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		err := WaitFor([]string{"localhost:80"}, time.Second)
+		assert.True(t, strings.HasPrefix(err.Error(), "unsupported scheme"))
+	})
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"io/fs"
+	"os"
+	path_filepath "path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/dapr/cli/pkg/print"
+)
+
+// WatchDir calls onChange when files in dir or any of its subdirectories change.
+// A burst of changes within the debounce interval, such as saving many files at once, results in a single call.
+// Hidden files and directories are ignored. The returned function stops watching.
+func WatchDir(dir string, debounce time.Duration, onChange func()) (func() error, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	err = addWatchDirs(watcher, dir)
+	if err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	go func() {
+		var (
+			mu    sync.Mutex
+			timer *time.Timer
+		)
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if isHiddenPath(event.Name) || event.Op == fsnotify.Chmod {
+					continue
+				}
+				if event.Op&fsnotify.Create != 0 {
+					if info, statErr := os.Stat(event.Name); statErr == nil && info.IsDir() {
+						addWatchDirs(watcher, event.Name)
+					}
+				}
+
+				print.DebugStatusEvent(os.Stderr, "Detected change: %s", event)
+				mu.Lock()
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(debounce, onChange)
+				mu.Unlock()
+			case watchErr, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				print.WarningStatusEvent(os.Stdout, "Error watching %s: %s", dir, watchErr)
+			}
+		}
+	}()
+
+	return watcher.Close, nil
+}
+
+// addWatchDirs adds dir and all its subdirectories, except hidden ones, to the watcher.
+func addWatchDirs(watcher *fsnotify.Watcher, dir string) error {
+	return path_filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != dir && isHiddenPath(path) {
+			return path_filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
+
+// isHiddenPath returns true for hidden files and directories, and for backup files of editors.
+func isHiddenPath(path string) bool {
+	name := path_filepath.Base(path)
+	return strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~")
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"os"
+	path_filepath "path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(path_filepath.Join(dir, "src"), 0o755))
+	assert.NoError(t, os.Mkdir(path_filepath.Join(dir, ".git"), 0o755))
+
+	var changes int32
+	stop, err := WatchDir(dir, 200*time.Millisecond, func() {
+		atomic.AddInt32(&changes, 1)
+	})
+	assert.NoError(t, err)
+	defer stop()
+
+	t.Run("burst of changes is debounced", func(t *testing.T) {
+		for _, name := range []string{"a.go", "b.go", "src/c.go"} {
+			assert.NoError(t, os.WriteFile(path_filepath.Join(dir, name), []byte("package main"), 0o600))
+		}
+		assert.Eventually(t, func() bool { return atomic.LoadInt32(&changes) == 1 }, 2*time.Second, 50*time.Millisecond)
+		time.Sleep(400 * time.Millisecond)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&changes))
+	})
+
+	t.Run("hidden files are ignored", func(t *testing.T) {
+		atomic.StoreInt32(&changes, 0)
+		assert.NoError(t, os.WriteFile(path_filepath.Join(dir, ".git", "index"), []byte("x"), 0o600))
+		assert.NoError(t, os.WriteFile(path_filepath.Join(dir, ".a.go.swp"), []byte("x"), 0o600))
+		time.Sleep(400 * time.Millisecond)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&changes))
+	})
+}
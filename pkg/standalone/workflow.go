@@ -0,0 +1,397 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// workflowAPIVersion is the version of the Dapr API that manages workflows.
+	workflowAPIVersion = "1.0-beta1"
+	// DefaultWorkflowComponent is the name of the workflow engine built into the sidecar.
+	DefaultWorkflowComponent = "dapr"
+
+	workflowMetadataKey      = "metadata"
+	workflowHistoryKeyPrefix = "history-"
+)
+
+// WorkflowOutput is the status of a workflow instance, as returned by the workflow API.
+type WorkflowOutput struct {
+	InstanceID    string            `csv:"INSTANCE ID"  json:"instanceID"           yaml:"instanceID"`
+	WorkflowName  string            `csv:"NAME"         json:"workflowName"         yaml:"workflowName"`
+	RuntimeStatus string            `csv:"STATUS"       json:"runtimeStatus"        yaml:"runtimeStatus"`
+	CreatedAt     string            `csv:"CREATED"      json:"createdAt"            yaml:"createdAt"`
+	LastUpdatedAt string            `csv:"LAST UPDATED" json:"lastUpdatedAt"        yaml:"lastUpdatedAt"`
+	Properties    map[string]string `csv:"-"            json:"properties,omitempty" yaml:"properties,omitempty"`
+}
+
+// WorkflowHistoryOutput is an event of the history of a workflow instance.
+type WorkflowHistoryOutput struct {
+	EventID   int32  `csv:"EVENT ID"  json:"eventId"        yaml:"eventId"`
+	Timestamp string `csv:"TIMESTAMP" json:"timestamp"      yaml:"timestamp"`
+	Type      string `csv:"TYPE"      json:"type"           yaml:"type"`
+	Name      string `csv:"NAME"      json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// historyEventTypes are the names of the event types of a history event, by the number of their field in the
+// HistoryEvent message of the Durable Task protocol used by the workflow engine.
+var historyEventTypes = map[protowire.Number]string{
+	3:  "ExecutionStarted",
+	4:  "ExecutionCompleted",
+	5:  "ExecutionTerminated",
+	6:  "TaskScheduled",
+	7:  "TaskCompleted",
+	8:  "TaskFailed",
+	9:  "SubOrchestrationInstanceCreated",
+	10: "SubOrchestrationInstanceCompleted",
+	11: "SubOrchestrationInstanceFailed",
+	12: "TimerCreated",
+	13: "TimerFired",
+	14: "OrchestratorStarted",
+	15: "OrchestratorCompleted",
+	16: "EventSent",
+	17: "EventRaised",
+	18: "GenericEvent",
+	19: "HistoryState",
+	20: "ContinueAsNew",
+	21: "ExecutionSuspended",
+	22: "ExecutionResumed",
+}
+
+// historyEventNameFields are the numbers of the name field of the event types that have one.
+var historyEventNameFields = map[protowire.Number]protowire.Number{
+	3:  1,
+	6:  1,
+	9:  2,
+	16: 2,
+	17: 1,
+}
+
+// StartWorkflow starts an instance of the workflow workflowName with input through the sidecar of appID, and
+// returns its instance ID. A random instance ID is chosen by the sidecar if instanceID is empty.
+func (s *Standalone) StartWorkflow(appID, component, workflowName, instanceID string, input []byte, socket string) (string, error) {
+	if workflowName == "" {
+		return "", errors.New("the workflow name is required")
+	}
+	path := fmt.Sprintf("%s/%s/start", workflowPath(component), url.PathEscape(workflowName))
+	if instanceID != "" {
+		path += "?instanceID=" + url.QueryEscape(instanceID)
+	}
+	body, err := s.workflowRequest(appID, socket, path, input)
+	if err != nil {
+		return "", fmt.Errorf("error starting workflow %s: %w", workflowName, err)
+	}
+	var resp struct {
+		InstanceID string `json:"instanceID"`
+	}
+	if err = json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("error parsing the response of the workflow API: %w", err)
+	}
+	return resp.InstanceID, nil
+}
+
+// GetWorkflow returns the status of a workflow instance, read through the sidecar of appID.
+func (s *Standalone) GetWorkflow(appID, component, instanceID, socket string) (WorkflowOutput, error) {
+	if instanceID == "" {
+		return WorkflowOutput{}, errors.New("the instance ID is required")
+	}
+	endpoint, httpc, err := s.sidecarEndpoint(appID, socket, fmt.Sprintf("%s/%s", workflowPath(component), url.PathEscape(instanceID)))
+	if err != nil {
+		return WorkflowOutput{}, err
+	}
+	r, err := httpc.Get(endpoint) //nolint:noctx
+	if err != nil {
+		return WorkflowOutput{}, err
+	}
+	defer r.Body.Close()
+	body, err := readStateResponse(r)
+	if err != nil {
+		return WorkflowOutput{}, fmt.Errorf("error getting workflow instance %s: %w", instanceID, err)
+	}
+	var workflow WorkflowOutput
+	if err = json.Unmarshal(body, &workflow); err != nil {
+		return WorkflowOutput{}, fmt.Errorf("error parsing the response of the workflow API: %w", err)
+	}
+	return workflow, nil
+}
+
+// TerminateWorkflow terminates a running workflow instance through the sidecar of appID.
+func (s *Standalone) TerminateWorkflow(appID, component, instanceID, socket string) error {
+	return s.workflowAction(appID, component, instanceID, "terminate", socket)
+}
+
+// PauseWorkflow suspends a running workflow instance through the sidecar of appID.
+func (s *Standalone) PauseWorkflow(appID, component, instanceID, socket string) error {
+	return s.workflowAction(appID, component, instanceID, "pause", socket)
+}
+
+// ResumeWorkflow resumes a suspended workflow instance through the sidecar of appID.
+func (s *Standalone) ResumeWorkflow(appID, component, instanceID, socket string) error {
+	return s.workflowAction(appID, component, instanceID, "resume", socket)
+}
+
+// PurgeWorkflow removes the state and history of a completed, failed or terminated workflow instance through
+// the sidecar of appID.
+func (s *Standalone) PurgeWorkflow(appID, component, instanceID, socket string) error {
+	return s.workflowAction(appID, component, instanceID, "purge", socket)
+}
+
+// RaiseWorkflowEvent sends the event eventName with data to a workflow instance through the sidecar of appID.
+func (s *Standalone) RaiseWorkflowEvent(appID, component, instanceID, eventName string, data []byte, socket string) error {
+	if instanceID == "" || eventName == "" {
+		return errors.New("the instance ID and event name are required")
+	}
+	path := fmt.Sprintf("%s/%s/raiseEvent/%s", workflowPath(component), url.PathEscape(instanceID), url.PathEscape(eventName))
+	if _, err := s.workflowRequest(appID, socket, path, data); err != nil {
+		return fmt.Errorf("error raising event %s of workflow instance %s: %w", eventName, instanceID, err)
+	}
+	return nil
+}
+
+// ListWorkflows returns the status of the workflow instances of appID. The instances are read from the actor state
+// store storeName, which must support the state query API, and their status from the workflow API.
+func (s *Standalone) ListWorkflows(appID, component, storeName, socket string) ([]WorkflowOutput, error) {
+	sidecar, err := s.workflowSidecar(appID)
+	if err != nil {
+		return nil, err
+	}
+	instanceIDs, err := s.workflowState(sidecar, storeName, "", socket, func(key string) bool {
+		return key == workflowMetadataKey
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	workflows := []WorkflowOutput{}
+	for _, item := range instanceIDs {
+		workflow, err := s.GetWorkflow(sidecar.AppID, component, item.ActorID, socket)
+		if err != nil {
+			return nil, err
+		}
+		workflows = append(workflows, workflow)
+	}
+	sort.SliceStable(workflows, func(i, j int) bool {
+		return workflows[i].CreatedAt > workflows[j].CreatedAt
+	})
+	return workflows, nil
+}
+
+// WorkflowHistory returns the history events of a workflow instance of appID, read from the actor state store
+// storeName, which must support the state query API.
+func (s *Standalone) WorkflowHistory(appID, storeName, instanceID, socket string) ([]WorkflowHistoryOutput, error) {
+	if instanceID == "" {
+		return nil, errors.New("the instance ID is required")
+	}
+	sidecar, err := s.workflowSidecar(appID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.workflowState(sidecar, storeName, instanceID, socket, func(key string) bool {
+		return strings.HasPrefix(key, workflowHistoryKeyPrefix)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no history found for workflow instance %s", instanceID)
+	}
+
+	// The keys are numbered with leading zeros, so they are sorted in the order of the events.
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Key < items[j].Key
+	})
+	history := make([]WorkflowHistoryOutput, 0, len(items))
+	for _, item := range items {
+		event, err := decodeHistoryEvent(item.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding %s of workflow instance %s: %w", item.Key, instanceID, err)
+		}
+		history = append(history, event)
+	}
+	return history, nil
+}
+
+func workflowPath(component string) string {
+	if component == "" {
+		component = DefaultWorkflowComponent
+	}
+	return fmt.Sprintf("v%s/workflows/%s", workflowAPIVersion, url.PathEscape(component))
+}
+
+// workflowActorType returns the actor type the workflow engine of appID in namespace runs the workflow instances
+// as. Sidecars without a namespace run in the default namespace.
+func workflowActorType(namespace, appID string) string {
+	if namespace == "" {
+		namespace = "default"
+	}
+	return fmt.Sprintf("dapr.internal.%s.%s.workflow", namespace, appID)
+}
+
+// workflowSidecar returns the sidecar of appID, or the only running sidecar if appID is empty.
+func (s *Standalone) workflowSidecar(appID string) (ListOutput, error) {
+	list, err := s.process.List()
+	if err != nil {
+		return ListOutput{}, err
+	}
+	return getSidecar(list, appID)
+}
+
+// workflowState returns the keys of the state of the workflow instances of the app of sidecar that match
+// keyFilter, read from the actor state store. If instanceID is not empty, only the keys of that instance are returned.
+func (s *Standalone) workflowState(sidecar ListOutput, storeName, instanceID, socket string, keyFilter func(key string) bool) ([]ActorStateOutput, error) {
+	if storeName == "" {
+		return nil, errors.New("the actor state store is required")
+	}
+	appID := sidecar.AppID
+	results, err := s.queryState(appID, storeName, nil, socket)
+	if err != nil {
+		return nil, err
+	}
+	actorType := workflowActorType(sidecar.Namespace, appID)
+	state := []ActorStateOutput{}
+	for _, result := range results {
+		id, key, ok := parseActorStateKey(result.Key, appID, actorType)
+		if !ok || !keyFilter(key) || (instanceID != "" && id != instanceID) {
+			continue
+		}
+		state = append(state, ActorStateOutput{ActorID: id, Key: key, Value: StateValue(result.Data)})
+	}
+	return state, nil
+}
+
+func (s *Standalone) workflowAction(appID, component, instanceID, action, socket string) error {
+	if instanceID == "" {
+		return errors.New("the instance ID is required")
+	}
+	path := fmt.Sprintf("%s/%s/%s", workflowPath(component), url.PathEscape(instanceID), action)
+	if _, err := s.workflowRequest(appID, socket, path, nil); err != nil {
+		return fmt.Errorf("error running %s on workflow instance %s: %w", action, instanceID, err)
+	}
+	return nil
+}
+
+// workflowRequest posts body to the workflow API of the sidecar of appID and returns the body of the response.
+func (s *Standalone) workflowRequest(appID, socket, path string, body []byte) ([]byte, error) {
+	endpoint, httpc, err := s.sidecarEndpoint(appID, socket, path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := postWithHeaders(context.Background(), httpc, endpoint, "application/json", body, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+	return readStateResponse(r)
+}
+
+// decodeHistoryEvent decodes a history event stored by the workflow engine, a HistoryEvent protobuf message that
+// the state store returns either as is or as a base64 encoded JSON string.
+func decodeHistoryEvent(value StateValue) (WorkflowHistoryOutput, error) {
+	b := []byte(value)
+	var encoded string
+	if json.Unmarshal(value, &encoded) == nil {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return WorkflowHistoryOutput{}, fmt.Errorf("invalid history event: %w", err)
+		}
+		b = decoded
+	}
+
+	event := WorkflowHistoryOutput{}
+	err := walkProtoFields(b, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		switch {
+		case num == 1 && typ == protowire.VarintType:
+			event.EventID = int32(n)
+		case num == 2 && typ == protowire.BytesType:
+			var seconds, nanos uint64
+			err := walkProtoFields(v, func(num protowire.Number, typ protowire.Type, _ []byte, n uint64) error {
+				if typ == protowire.VarintType && num == 1 {
+					seconds = n
+				} else if typ == protowire.VarintType && num == 2 {
+					nanos = n
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			event.Timestamp = time.Unix(int64(seconds), int64(nanos)).UTC().Format(time.RFC3339Nano)
+		case historyEventTypes[num] != "" && typ == protowire.BytesType:
+			event.Type = historyEventTypes[num]
+			nameField, ok := historyEventNameFields[num]
+			if !ok {
+				return nil
+			}
+			return walkProtoFields(v, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) error {
+				if num == nameField && typ == protowire.BytesType {
+					event.Name = string(v)
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return WorkflowHistoryOutput{}, err
+	}
+	if event.Type == "" {
+		return WorkflowHistoryOutput{}, errors.New("invalid history event: unknown event type")
+	}
+	return event, nil
+}
+
+// walkProtoFields calls onField with the number, wire type and value of every field of a protobuf message.
+// The value of a length-delimited field is passed as bytes, the value of a varint field as a number.
+func walkProtoFields(b []byte, onField func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error) error {
+	for len(b) > 0 {
+		num, typ, length := protowire.ConsumeTag(b)
+		if length < 0 {
+			return fmt.Errorf("invalid history event: %w", protowire.ParseError(length))
+		}
+		b = b[length:]
+
+		var (
+			v []byte
+			n uint64
+		)
+		switch typ {
+		case protowire.VarintType:
+			n, length = protowire.ConsumeVarint(b)
+		case protowire.BytesType:
+			v, length = protowire.ConsumeBytes(b)
+		default:
+			length = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if length < 0 {
+			return fmt.Errorf("invalid history event: %w", protowire.ParseError(length))
+		}
+		b = b[length:]
+
+		if err := onField(num, typ, v, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
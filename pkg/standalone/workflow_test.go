@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package standalone
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// historyEvent encodes a HistoryEvent message with an event of the type of field eventField.
+func historyEvent(eventID int32, seconds int64, eventField protowire.Number, event []byte) []byte {
+	var timestamp []byte
+	timestamp = protowire.AppendTag(timestamp, 1, protowire.VarintType)
+	timestamp = protowire.AppendVarint(timestamp, uint64(seconds))
+
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(eventID))
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendBytes(b, timestamp)
+	b = protowire.AppendTag(b, eventField, protowire.BytesType)
+	return protowire.AppendBytes(b, event)
+}
+
+func historyEventName(field protowire.Number, name string) []byte {
+	b := protowire.AppendTag(nil, field, protowire.BytesType)
+	return protowire.AppendString(b, name)
+}
+
+func workflowTestHandler(t *testing.T, queryResponse string) http.Handler {
+	t.Helper()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1.0-beta1/workflows/dapr/OrderProcessing/start":
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, `{"id":1}`, string(body))
+			id := r.URL.Query().Get("instanceID")
+			if id == "" {
+				id = "random"
+			}
+			json.NewEncoder(w).Encode(map[string]string{"instanceID": id})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1.0-beta1/workflows/dapr/order-1":
+			w.Write([]byte(`{"instanceID":"order-1","workflowName":"OrderProcessing","runtimeStatus":"RUNNING","createdAt":"2023-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1.0-beta1/workflows/dapr/order-2":
+			w.Write([]byte(`{"instanceID":"order-2","workflowName":"OrderProcessing","runtimeStatus":"COMPLETED","createdAt":"2023-01-02T00:00:00Z"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1.0-beta1/workflows/dapr/order-1/terminate":
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1.0-beta1/workflows/dapr/order-1/raiseEvent/Approved":
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, `true`, string(body))
+			w.WriteHeader(http.StatusAccepted)
+		case r.URL.Path == "/v1.0-alpha1/state/statestore/query":
+			w.Write([]byte(queryResponse))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorCode":"ERR_INSTANCE_ID_NOT_FOUND"}`))
+		}
+	})
+}
+
+func TestWorkflowLifecycle(t *testing.T) {
+	ts, port := getTestServerFunc(workflowTestHandler(t, ""))
+	ts.Start()
+	defer ts.Close()
+	client := &Standalone{process: &mockDaprProcess{Lo: []ListOutput{{AppID: "orders", HTTPPort: port}}}}
+
+	t.Run("start", func(t *testing.T) {
+		id, err := client.StartWorkflow("orders", "", "OrderProcessing", "order-1", []byte(`{"id":1}`), "")
+		require.NoError(t, err)
+		assert.Equal(t, "order-1", id)
+
+		id, err = client.StartWorkflow("orders", "dapr", "OrderProcessing", "", []byte(`{"id":1}`), "")
+		require.NoError(t, err)
+		assert.Equal(t, "random", id)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		workflow, err := client.GetWorkflow("orders", "", "order-1", "")
+		require.NoError(t, err)
+		assert.Equal(t, "RUNNING", workflow.RuntimeStatus)
+		assert.Equal(t, "OrderProcessing", workflow.WorkflowName)
+	})
+
+	t.Run("terminate and raise event", func(t *testing.T) {
+		assert.NoError(t, client.TerminateWorkflow("orders", "", "order-1", ""))
+		assert.NoError(t, client.RaiseWorkflowEvent("orders", "", "order-1", "Approved", []byte("true"), ""))
+	})
+
+	t.Run("unknown instance", func(t *testing.T) {
+		err := client.PauseWorkflow("orders", "", "unknown", "")
+		assert.ErrorContains(t, err, "ERR_INSTANCE_ID_NOT_FOUND")
+	})
+
+	t.Run("missing instance ID", func(t *testing.T) {
+		assert.ErrorContains(t, client.ResumeWorkflow("orders", "", "", ""), "instance ID is required")
+	})
+}
+
+func TestListWorkflows(t *testing.T) {
+	response := `{"results":[` +
+		`{"key":"orders||dapr.internal.default.orders.workflow||order-1||metadata","data":{}},` +
+		`{"key":"orders||dapr.internal.default.orders.workflow||order-1||history-000000","data":""},` +
+		`{"key":"orders||dapr.internal.default.orders.workflow||order-2||metadata","data":{}},` +
+		`{"key":"orders||dapr.internal.default.orders.activity||order-1#0||metadata","data":{}}]}`
+	ts, port := getTestServerFunc(workflowTestHandler(t, response))
+	ts.Start()
+	defer ts.Close()
+	client := &Standalone{process: &mockDaprProcess{Lo: []ListOutput{{AppID: "orders", HTTPPort: port}}}}
+
+	workflows, err := client.ListWorkflows("", "", "statestore", "")
+	require.NoError(t, err)
+	require.Len(t, workflows, 2)
+	assert.Equal(t, "order-2", workflows[0].InstanceID)
+	assert.Equal(t, "order-1", workflows[1].InstanceID)
+
+	t.Run("app in a namespace", func(t *testing.T) {
+		client := &Standalone{process: &mockDaprProcess{Lo: []ListOutput{{AppID: "orders", HTTPPort: port, Namespace: "shop"}}}}
+		workflows, err := client.ListWorkflows("orders", "", "statestore", "")
+		require.NoError(t, err)
+		assert.Empty(t, workflows, "the instances of the default namespace are not listed")
+	})
+}
+
+func TestWorkflowActorType(t *testing.T) {
+	assert.Equal(t, "dapr.internal.default.orders.workflow", workflowActorType("", "orders"))
+	assert.Equal(t, "dapr.internal.shop.orders.workflow", workflowActorType("shop", "orders"))
+}
+
+func TestWorkflowHistory(t *testing.T) {
+	started := historyEvent(0, 1672531200, 3, historyEventName(1, "OrderProcessing"))
+	scheduled := historyEvent(1, 1672531201, 6, historyEventName(1, "ReserveInventory"))
+	raised := historyEvent(-1, 1672531202, 17, historyEventName(1, "Approved"))
+	key := func(n int) string {
+		return fmt.Sprintf("orders||dapr.internal.default.orders.workflow||order-1||history-%06d", n)
+	}
+	encode := func(b []byte) string {
+		return `"` + base64.StdEncoding.EncodeToString(b) + `"`
+	}
+	response := `{"results":[` +
+		`{"key":"` + key(2) + `","data":` + encode(raised) + `},` +
+		`{"key":"` + key(0) + `","data":` + encode(started) + `},` +
+		`{"key":"` + key(1) + `","data":` + encode(scheduled) + `},` +
+		`{"key":"orders||dapr.internal.default.orders.workflow||order-2||history-000000","data":` + encode(started) + `}]}`
+	ts, port := getTestServerFunc(workflowTestHandler(t, response))
+	ts.Start()
+	defer ts.Close()
+	client := &Standalone{process: &mockDaprProcess{Lo: []ListOutput{{AppID: "orders", HTTPPort: port}}}}
+
+	t.Run("history of an instance", func(t *testing.T) {
+		history, err := client.WorkflowHistory("orders", "statestore", "order-1", "")
+		require.NoError(t, err)
+		assert.Equal(t, []WorkflowHistoryOutput{
+			{EventID: 0, Timestamp: "2023-01-01T00:00:00Z", Type: "ExecutionStarted", Name: "OrderProcessing"},
+			{EventID: 1, Timestamp: "2023-01-01T00:00:01Z", Type: "TaskScheduled", Name: "ReserveInventory"},
+			{EventID: -1, Timestamp: "2023-01-01T00:00:02Z", Type: "EventRaised", Name: "Approved"},
+		}, history)
+	})
+
+	t.Run("unknown instance", func(t *testing.T) {
+		_, err := client.WorkflowHistory("orders", "statestore", "order-3", "")
+		assert.ErrorContains(t, err, "no history found")
+	})
+}
+
+func TestDecodeHistoryEvent(t *testing.T) {
+	t.Run("raw message", func(t *testing.T) {
+		event, err := decodeHistoryEvent(StateValue(historyEvent(4, 0, 4, nil)))
+		require.NoError(t, err)
+		assert.Equal(t, "ExecutionCompleted", event.Type)
+		assert.Equal(t, int32(4), event.EventID)
+	})
+
+	t.Run("unknown event type", func(t *testing.T) {
+		_, err := decodeHistoryEvent(StateValue(historyEvent(0, 0, 99, nil)))
+		assert.ErrorContains(t, err, "unknown event type")
+	})
+
+	t.Run("invalid message", func(t *testing.T) {
+		_, err := decodeHistoryEvent(StateValue([]byte{0x0a, 0xff}))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MaxHistoryEntries is the number of invocations kept in the history. Older ones are removed.
+const MaxHistoryEntries = 200
+
+// RedactedValue replaces the values of flags and the arguments of commands in the history.
+const RedactedValue = "<redacted>"
+
+// HistoryEntry is an invocation of the CLI.
+type HistoryEntry struct {
+	Time     string `csv:"TIME"     json:"time"            yaml:"time"`
+	Command  string `csv:"COMMAND"  json:"command"         yaml:"command"`
+	Result   string `csv:"RESULT"   json:"result"          yaml:"result"`
+	Duration string `csv:"DURATION" json:"duration"        yaml:"duration"`
+	Error    string `csv:"ERROR"    json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// AppendHistory adds entry to the history file at path, removing the oldest entries beyond MaxHistoryEntries.
+func AppendHistory(path string, entry HistoryEntry) error {
+	entries, err := ReadHistory(path, 0)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, entry)
+	if len(entries) > MaxHistoryEntries {
+		entries = entries[len(entries)-MaxHistoryEntries:]
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err = encoder.Encode(e); err != nil {
+			return err
+		}
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error writing history: %w", err)
+	}
+	if err = os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("error writing history: %w", err)
+	}
+	return nil
+}
+
+// ReadHistory returns the last limit entries of the history file at path, oldest first, or all entries if limit is 0.
+// Lines that cannot be parsed are skipped.
+func ReadHistory(path string, limit int) ([]HistoryEntry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []HistoryEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading history: %w", err)
+	}
+	defer f.Close()
+
+	entries := []HistoryEntry{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e HistoryEntry
+		if json.Unmarshal(scanner.Bytes(), &e) == nil {
+			entries = append(entries, e)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading history: %w", err)
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
+
+// ClearHistory removes the history file at path.
+func ClearHistory(path string) error {
+	err := os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error clearing history: %w", err)
+	}
+	return nil
+}
+
+// CommandLine returns the command line of an invocation of commandPath, such as "dapr state set", with args for
+// the history. Only the command path and the names of the flags are kept: the values of flags and the positional
+// arguments, such as --data, --env, --set, the key and value of dapr state set or the command of an app, are
+// replaced, as they can hold credentials.
+func CommandLine(commandPath string, args []string) string {
+	out := strings.Fields(commandPath)
+	words := []string{}
+	if len(out) > 0 {
+		words = out[1:]
+	}
+	for _, arg := range args {
+		switch {
+		case len(words) > 0 && arg == words[0]:
+			words = words[1:]
+		case strings.HasPrefix(arg, "-"):
+			if name, _, ok := strings.Cut(arg, "="); ok {
+				arg = name + "=" + RedactedValue
+			}
+			out = append(out, arg)
+		default:
+			out = append(out, RedactedValue)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// RedactCommandLine redacts a command line read from the history as CommandLine does, keeping the words before the
+// first flag as the command path, for lines recorded before the values were left out.
+func RedactCommandLine(line string) string {
+	fields := strings.Fields(line)
+	commandPath := []string{}
+	for _, field := range fields {
+		if strings.HasPrefix(field, "-") {
+			break
+		}
+		commandPath = append(commandPath, field)
+	}
+	return CommandLine(strings.Join(commandPath, " "), fields[len(commandPath):])
+}
+
+// FlagNames returns the names of the flags in args, without their values, for usage events.
+func FlagNames(args []string) []string {
+	names := []string{}
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if strings.HasPrefix(arg, "-") && arg != "-" {
+			name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package telemetry records the invocations of the CLI in a local history, and sends anonymous usage
+// events to a collector once the user opted in.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// sendTimeout limits the time a command waits for its usage event to be sent.
+const sendTimeout = 2 * time.Second
+
+// DefaultEndpoint is the URL of the collector that usage events are sent to if the user did not give one.
+// It is injected by the build. No events are sent if it is empty and no endpoint is set.
+var DefaultEndpoint string
+
+// Settings are the telemetry choices of the user.
+type Settings struct {
+	Enabled bool `json:"enabled"`
+	// InstallID identifies the events of an installation without identifying the user. It is random.
+	InstallID string `json:"installId,omitempty"`
+	// Endpoint overrides DefaultEndpoint.
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// EffectiveEndpoint returns the URL the events are sent to, or an empty string if they are not sent.
+func (s Settings) EffectiveEndpoint() string {
+	if s.Endpoint != "" {
+		return s.Endpoint
+	}
+	return DefaultEndpoint
+}
+
+// Event is an anonymous usage event. It holds the names of the command and of its flags, never their values.
+type Event struct {
+	InstallID  string   `json:"installId"`
+	Command    string   `json:"command"`
+	Flags      []string `json:"flags,omitempty"`
+	Result     string   `json:"result"`
+	DurationMs int64    `json:"durationMs"`
+	CLIVersion string   `json:"cliVersion"`
+	OS         string   `json:"os"`
+	Arch       string   `json:"arch"`
+}
+
+// LoadSettings reads the settings from path. Telemetry is disabled if the file does not exist.
+func LoadSettings(path string) (Settings, error) {
+	var s Settings
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("error reading telemetry settings: %w", err)
+	}
+	if err = json.Unmarshal(b, &s); err != nil {
+		return s, fmt.Errorf("error parsing telemetry settings in %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// SaveSettings writes s to path.
+func SaveSettings(path string, s Settings) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error saving telemetry settings: %w", err)
+	}
+	if err = os.WriteFile(path, b, 0o600); err != nil {
+		return fmt.Errorf("error saving telemetry settings: %w", err)
+	}
+	return nil
+}
+
+// Enable opts in to telemetry and returns the saved settings. A new install ID is generated the first time.
+// An empty endpoint keeps the current one.
+func Enable(path, endpoint string) (Settings, error) {
+	s, err := LoadSettings(path)
+	if err != nil {
+		return s, err
+	}
+	s.Enabled = true
+	if endpoint != "" {
+		s.Endpoint = endpoint
+	}
+	if s.InstallID == "" {
+		b := make([]byte, 16)
+		if _, err = rand.Read(b); err != nil {
+			return s, fmt.Errorf("error generating install ID: %w", err)
+		}
+		s.InstallID = hex.EncodeToString(b)
+	}
+	return s, SaveSettings(path, s)
+}
+
+// Disable opts out of telemetry. The install ID is removed, so that later events cannot be related to earlier ones.
+func Disable(path string) error {
+	s, err := LoadSettings(path)
+	if err != nil {
+		return err
+	}
+	return SaveSettings(path, Settings{Endpoint: s.Endpoint})
+}
+
+// NewEvent returns the usage event of a command that ended with result after duration.
+func NewEvent(s Settings, command string, flags []string, result string, duration time.Duration, cliVersion string) Event {
+	return Event{
+		InstallID:  s.InstallID,
+		Command:    command,
+		Flags:      flags,
+		Result:     result,
+		DurationMs: duration.Milliseconds(),
+		CLIVersion: cliVersion,
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+	}
+}
+
+// Send posts event to the endpoint of s if telemetry is enabled and an endpoint is set.
+// It gives up after a short timeout, so that commands are not slowed down by an unreachable collector.
+func Send(ctx context.Context, s Settings, event Event) error {
+	endpoint := s.EffectiveEndpoint()
+	if !s.Enabled || endpoint == "" {
+		return nil
+	}
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("invalid telemetry endpoint %q: %w", endpoint, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d sending usage event", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+
+	s, err := LoadSettings(path)
+	require.NoError(t, err)
+	assert.False(t, s.Enabled, "telemetry must be opt-in")
+
+	s, err = Enable(path, "")
+	require.NoError(t, err)
+	assert.True(t, s.Enabled)
+	assert.Len(t, s.InstallID, 32)
+	installID := s.InstallID
+
+	s, err = Enable(path, "https://collector.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, installID, s.InstallID, "enabling again keeps the install ID")
+
+	require.NoError(t, Disable(path))
+	s, err = LoadSettings(path)
+	require.NoError(t, err)
+	assert.Equal(t, Settings{Endpoint: "https://collector.example.com"}, s)
+}
+
+func TestSend(t *testing.T) {
+	events := make(chan Event, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e Event
+		json.NewDecoder(r.Body).Decode(&e)
+		events <- e
+	}))
+	defer ts.Close()
+
+	event := NewEvent(Settings{InstallID: "abc"}, "dapr run", []string{"app-id"}, "success", time.Second, "1.9.0")
+
+	require.NoError(t, Send(context.Background(), Settings{Endpoint: ts.URL}, event))
+	assert.Empty(t, events, "no event is sent if telemetry is disabled")
+
+	require.NoError(t, Send(context.Background(), Settings{Enabled: true, Endpoint: ts.URL}, event))
+	received := <-events
+	assert.Equal(t, "dapr run", received.Command)
+	assert.Equal(t, []string{"app-id"}, received.Flags)
+	assert.Equal(t, int64(1000), received.DurationMs)
+
+	assert.NoError(t, Send(context.Background(), Settings{Enabled: true}, event), "no event is sent without an endpoint")
+}
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+
+	entries, err := ReadHistory(path, 10)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	for i := 0; i < MaxHistoryEntries+5; i++ {
+		require.NoError(t, AppendHistory(path, HistoryEntry{Command: "dapr list", Result: "success"}))
+	}
+	require.NoError(t, AppendHistory(path, HistoryEntry{Command: "dapr stop", Result: "failure", Error: "app not found"}))
+
+	entries, err = ReadHistory(path, 0)
+	require.NoError(t, err)
+	assert.Len(t, entries, MaxHistoryEntries)
+
+	entries, err = ReadHistory(path, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []HistoryEntry{{Command: "dapr stop", Result: "failure", Error: "app not found"}}, entries)
+
+	require.NoError(t, ClearHistory(path))
+	require.NoError(t, ClearHistory(path))
+	entries, err = ReadHistory(path, 0)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestCommandLine(t *testing.T) {
+	args := []string{"init", "-k", "--set", "global.registry=x", "--password", "hunter2", "--api-token=abc", "--", "node", "app.js"}
+	assert.Equal(t, "dapr init -k --set <redacted> --password <redacted> --api-token=<redacted> -- <redacted> <redacted>", CommandLine("dapr init", args))
+	assert.Equal(t, []string{"k", "set", "password", "api-token"}, FlagNames(args))
+
+	args = []string{"--log-level", "debug", "state", "set", "orders", "secret", "--header", "Authorization: Bearer abc"}
+	assert.Equal(t, "dapr state set --log-level <redacted> <redacted> <redacted> --header <redacted>", CommandLine("dapr state set", args))
+	assert.Equal(t, "dapr", CommandLine("dapr", nil))
+}
+
+func TestRedactCommandLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected string
+	}{
+		{"dapr init --runtime-version 1.8.0 --slim", "dapr init --runtime-version <redacted> --slim"},
+		{"dapr invoke --app-id orders --method new --data {\"card\":\"4111\"}", "dapr invoke --app-id <redacted> --method <redacted> --data <redacted>"},
+		{"dapr init -k --set global.password=hunter2", "dapr init -k --set <redacted>"},
+		{"dapr run --env=API_KEY=abc -- node app.js", "dapr run --env=<redacted> -- <redacted> <redacted>"},
+		{"dapr list", "dapr list"},
+		{"dapr init --slim --runtime-version <redacted>", "dapr init --slim --runtime-version <redacted>"},
+		{"", ""},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, RedactCommandLine(tc.line))
+	}
+}
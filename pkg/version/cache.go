@@ -0,0 +1,90 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dapr/cli/utils"
+)
+
+// defaultCacheTTL is how long a latest version looked up online is used before it is looked up again.
+const defaultCacheTTL = time.Hour
+
+// cacheFile is the file the latest versions looked up online are cached in. Versions are not cached if it is empty.
+var cacheFile string
+
+// cachedVersion is a latest version looked up online.
+type cachedVersion struct {
+	Version   string    `json:"version"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// SetCacheFile sets the file the latest versions looked up online are cached in, so that they are not looked up
+// again until they are older than DAPR_VERSION_CACHE_TTL, one hour by default. An empty path disables the cache.
+func SetCacheFile(path string) {
+	cacheFile = path
+}
+
+// cacheTTL returns the duration set by DAPR_VERSION_CACHE_TTL, or defaultCacheTTL if it is not a valid duration.
+func cacheTTL() time.Duration {
+	ttl, err := time.ParseDuration(utils.GetEnv("DAPR_VERSION_CACHE_TTL", ""))
+	if err != nil || ttl < 0 {
+		return defaultCacheTTL
+	}
+	return ttl
+}
+
+func readCache() map[string]cachedVersion {
+	versions := map[string]cachedVersion{}
+	if cacheFile == "" {
+		return versions
+	}
+	b, err := os.ReadFile(cacheFile)
+	if err != nil {
+		return versions
+	}
+	// A corrupt cache is ignored and overwritten by the next lookup.
+	_ = json.Unmarshal(b, &versions)
+	return versions
+}
+
+// lookupCache returns the version cached for url and whether it is still fresh, or false if there is none.
+func lookupCache(url string, now time.Time) (cachedVersion, bool, bool) {
+	entry, ok := readCache()[url]
+	if !ok || entry.Version == "" {
+		return cachedVersion{}, false, false
+	}
+	return entry, now.Sub(entry.FetchedAt) < cacheTTL(), true
+}
+
+// storeCache caches version as the latest version at url. Failures are ignored, as the cache is only an optimization.
+func storeCache(url, version string, now time.Time) {
+	if cacheFile == "" {
+		return
+	}
+	versions := readCache()
+	versions[url] = cachedVersion{Version: version, FetchedAt: now}
+	b, err := json.MarshalIndent(versions, "", "  ")
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(cacheFile), 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(cacheFile, b, 0o644)
+}
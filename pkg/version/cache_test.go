@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVersionFromURLCache(t *testing.T) {
+	SetCacheFile(filepath.Join(t.TempDir(), "versions.json"))
+	t.Cleanup(func() { SetCacheFile("") })
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+
+	requests := 0
+	rateLimited := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if rateLimited {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`[{"tag_name": "v1.8.4"}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	version, err := GetLatestReleaseGithub(server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "1.8.4", version)
+
+	version, err = GetLatestReleaseGithub(server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "1.8.4", version)
+	assert.Equal(t, 1, requests, "a fresh cached version is not looked up again")
+
+	t.Setenv("DAPR_VERSION_CACHE_TTL", "0s")
+	rateLimited = true
+	version, err = GetLatestReleaseGithub(server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "1.8.4", version, "a stale cached version is used when the lookup fails")
+	assert.Equal(t, 2, requests)
+
+	SetCacheFile("")
+	_, err = GetLatestReleaseGithub(server.URL)
+	var rateLimitErr *RateLimitError
+	require.True(t, errors.As(err, &rateLimitErr))
+	assert.False(t, rateLimitErr.Reset.IsZero())
+	assert.Contains(t, err.Error(), "Set GITHUB_TOKEN")
+}
+
+func TestRateLimitError(t *testing.T) {
+	response := func(status int, remaining string) *http.Response {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}}
+		resp.Header.Set("X-RateLimit-Remaining", remaining)
+		return resp
+	}
+
+	assert.Error(t, rateLimitError("url", response(http.StatusForbidden, "0")))
+	assert.Error(t, rateLimitError("url", response(http.StatusTooManyRequests, "0")))
+	assert.NoError(t, rateLimitError("url", response(http.StatusForbidden, "12")), "other refusals are not rate limits")
+	assert.NoError(t, rateLimitError("url", response(http.StatusOK, "0")))
+
+	t.Setenv("GITHUB_TOKEN", "token")
+	assert.NotContains(t, rateLimitError("url", response(http.StatusForbidden, "0")).Error(), "GITHUB_TOKEN")
+}
+
+func TestCacheTTL(t *testing.T) {
+	t.Setenv("DAPR_VERSION_CACHE_TTL", "10m")
+	assert.Equal(t, 10*time.Minute, cacheTTL())
+	t.Setenv("DAPR_VERSION_CACHE_TTL", "soon")
+	assert.Equal(t, defaultCacheTTL, cacheTTL())
+}
@@ -19,7 +19,9 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-version"
 	yaml "gopkg.in/yaml.v2"
@@ -35,8 +37,31 @@ const (
 	DaprGitHubRepo = "dapr"
 	// DashboardGitHubRepo is the repo name of dapr dashboard on GitHub.
 	DashboardGitHubRepo = "dashboard"
+	// CLIGitHubRepo is the repo name of dapr CLI on GitHub.
+	CLIGitHubRepo = "cli"
+
+	gitHubURL    = "https://github.com"
+	gitHubAPIURL = "https://api.github.com"
 )
 
+// gitHubMirror replaces gitHubURL in the URLs of release archives.
+var gitHubMirror string
+
+// SetGitHubMirror sets the URL of a mirror of https://github.com that release archives are downloaded from,
+// as <mirror>/<org>/<repo>/releases/download/v<version>/<file>. An empty URL resets it to GitHub.
+func SetGitHubMirror(mirrorURL string) {
+	gitHubMirror = strings.TrimSuffix(mirrorURL, "/")
+}
+
+// ReleaseURL returns the URL of a file of a release of a Dapr repo on GitHub, or on the mirror set with SetGitHubMirror.
+func ReleaseURL(repo, version, fileName string) string {
+	baseURL := gitHubURL
+	if gitHubMirror != "" {
+		baseURL = gitHubMirror
+	}
+	return fmt.Sprintf("%s/%s/%s/releases/download/v%s/%s", baseURL, DaprGitHubOrg, repo, version, fileName)
+}
+
 type githubRepoReleaseItem struct {
 	URL     string `json:"url"`
 	TagName string `json:"tag_name"`
@@ -53,11 +78,15 @@ type helmChartItems struct {
 }
 
 func GetDashboardVersion() (string, error) {
-	return GetLatestReleaseGithub(fmt.Sprintf("https://api.github.com/repos/%s/%s/releases", DaprGitHubOrg, DashboardGitHubRepo))
+	return GetLatestReleaseGithub(fmt.Sprintf("%s/repos/%s/%s/releases", gitHubAPIURL, DaprGitHubOrg, DashboardGitHubRepo))
+}
+
+func GetCLIVersion() (string, error) {
+	return GetLatestReleaseGithub(fmt.Sprintf("%s/repos/%s/%s/releases", gitHubAPIURL, DaprGitHubOrg, CLIGitHubRepo))
 }
 
 func GetDaprVersion() (string, error) {
-	version, err := GetLatestReleaseGithub(fmt.Sprintf("https://api.github.com/repos/%s/%s/releases", DaprGitHubOrg, DaprGitHubRepo))
+	version, err := GetLatestReleaseGithub(fmt.Sprintf("%s/repos/%s/%s/releases", gitHubAPIURL, DaprGitHubOrg, DaprGitHubRepo))
 	if err != nil {
 		print.WarningStatusEvent(os.Stdout, "Failed to get runtime version: '%s'. Trying secondary source", err)
 
@@ -70,23 +99,47 @@ func GetDaprVersion() (string, error) {
 	return version, nil
 }
 
+// GetVersionFromURL returns the version parsed by parseVersion from the response to releaseURL. The version is
+// taken from the cache set with SetCacheFile while it is fresh, and a stale cached version is returned with a warning
+// if the lookup fails, for example because the GitHub API rate limit is exceeded.
 func GetVersionFromURL(releaseURL string, parseVersion func(body []byte) (string, error)) (string, error) {
+	now := time.Now()
+	cached, fresh, ok := lookupCache(releaseURL, now)
+	if fresh {
+		return cached.Version, nil
+	}
+
+	version, err := fetchVersion(releaseURL, parseVersion)
+	if err != nil {
+		if ok {
+			print.WarningStatusEvent(os.Stderr, "%s. Using version %s cached at %s", err, cached.Version, cached.FetchedAt.Format(time.RFC3339))
+			return cached.Version, nil
+		}
+		return "", err
+	}
+	storeCache(releaseURL, version, now)
+	return version, nil
+}
+
+func fetchVersion(releaseURL string, parseVersion func(body []byte) (string, error)) (string, error) {
 	req, err := http.NewRequest("GET", releaseURL, nil)
 	if err != nil {
 		return "", err
 	}
 
-	githubToken := utils.GetEnv("GITHUB_TOKEN", "")
-	if githubToken != "" {
-		req.Header.Add("Authorization", "token "+githubToken)
+	if token := gitHubToken(); token != "" && strings.HasPrefix(releaseURL, gitHubAPIURL+"/") {
+		req.Header.Add("Authorization", "token "+token)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := utils.NewHTTPClient(0).Do(req)
 	if err != nil {
 		return "", err
 	}
 	defer resp.Body.Close()
 
+	if err = rateLimitError(releaseURL, resp); err != nil {
+		return "", err
+	}
 	if resp.StatusCode != http.StatusOK {
 		return "", fmt.Errorf("%s - %s", releaseURL, resp.Status)
 	}
@@ -99,6 +152,48 @@ func GetVersionFromURL(releaseURL string, parseVersion func(body []byte) (string
 	return parseVersion(body)
 }
 
+// gitHubToken returns the token the GitHub API is called with, from GITHUB_TOKEN or else GH_TOKEN.
+func gitHubToken() string {
+	if token := utils.GetEnv("GITHUB_TOKEN", ""); token != "" {
+		return token
+	}
+	return utils.GetEnv("GH_TOKEN", "")
+}
+
+// RateLimitError is returned when the GitHub API refuses a request because its rate limit is exceeded.
+type RateLimitError struct {
+	URL string
+	// Reset is when the rate limit is reset, or the zero time if it is not known.
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	msg := "the GitHub API rate limit is exceeded for " + e.URL
+	if !e.Reset.IsZero() {
+		msg += " until " + e.Reset.Local().Format(time.RFC3339)
+	}
+	if gitHubToken() == "" {
+		msg += ". Set GITHUB_TOKEN to a GitHub token to raise the limit"
+	}
+	return msg
+}
+
+// rateLimitError returns a RateLimitError if resp is the refusal of a request to url by the GitHub API because
+// the rate limit is exceeded, or nil.
+func rateLimitError(url string, resp *http.Response) error {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return nil
+	}
+	err := &RateLimitError{URL: url}
+	if reset, parseErr := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); parseErr == nil {
+		err.Reset = time.Unix(reset, 0)
+	}
+	return err
+}
+
 // GetLatestReleaseGithub return the latest release version of dapr from GitHub API.
 func GetLatestReleaseGithub(githubURL string) (string, error) {
 	return GetVersionFromURL(githubURL, func(body []byte) (string, error) {
@@ -153,3 +248,17 @@ func GetLatestReleaseHelmChart(helmChartURL string) (string, error) {
 		return "", fmt.Errorf("no releases")
 	})
 }
+
+// IsOutdated returns true if the installed version is older than the latest release. Versions that cannot be
+// compared, such as edge builds or a runtime that is not installed, are never outdated.
+func IsOutdated(installed, latest string) bool {
+	installedVersion, err := version.NewVersion(strings.TrimPrefix(installed, "v"))
+	if err != nil {
+		return false
+	}
+	latestVersion, err := version.NewVersion(strings.TrimPrefix(latest, "v"))
+	if err != nil {
+		return false
+	}
+	return installedVersion.LessThan(latestVersion)
+}
@@ -297,3 +297,20 @@ entries:
 
 	s.Shutdown(context.Background())
 }
+
+func TestReleaseURL(t *testing.T) {
+	assert.Equal(t, "https://github.com/dapr/dapr/releases/download/v1.9.0/daprd_linux_amd64.tar.gz", ReleaseURL(DaprGitHubRepo, "1.9.0", "daprd_linux_amd64.tar.gz"))
+
+	SetGitHubMirror("https://mirror.example.com/github/")
+	defer SetGitHubMirror("")
+	assert.Equal(t, "https://mirror.example.com/github/dapr/dapr/releases/download/v1.9.0/daprd_linux_amd64.tar.gz", ReleaseURL(DaprGitHubRepo, "1.9.0", "daprd_linux_amd64.tar.gz"))
+}
+
+func TestIsOutdated(t *testing.T) {
+	assert.True(t, IsOutdated("1.8.3", "1.9.0"))
+	assert.True(t, IsOutdated("v1.9.0-rc.1", "1.9.0"))
+	assert.False(t, IsOutdated("1.9.0", "1.9.0"))
+	assert.False(t, IsOutdated("1.10.0", "1.9.0"))
+	assert.False(t, IsOutdated("edge", "1.9.0"))
+	assert.False(t, IsOutdated("n/a", "1.9.0"))
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+var (
+	caCertFile string
+	caCertPool *x509.CertPool
+)
+
+// SetCACertFile adds the certificates of a PEM bundle to the system roots trusted by the clients of
+// NewHTTPClient, for networks whose proxy intercepts TLS with a certificate of a corporate CA.
+func SetCACertFile(path string) error {
+	if path == "" {
+		caCertFile, caCertPool = "", nil
+		return nil
+	}
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading CA bundle: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return fmt.Errorf("no PEM encoded certificates found in CA bundle %s", path)
+	}
+	caCertFile, caCertPool = path, pool
+	return nil
+}
+
+// CACertFile returns the CA bundle given to SetCACertFile, or an empty string.
+func CACertFile() string {
+	return caCertFile
+}
+
+// NewHTTPTransport returns a transport that uses the proxy given by the HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+// environment variables, and trusts the CA bundle given to SetCACertFile.
+func NewHTTPTransport() *http.Transport {
+	transport := &http.Transport{ //nolint:exhaustruct
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{ //nolint:exhaustruct
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout: 15 * time.Second,
+	}
+	if caCertPool != nil {
+		transport.TLSClientConfig = &tls.Config{RootCAs: caCertPool, MinVersion: tls.VersionTLS12} //nolint:exhaustruct
+	}
+	return transport
+}
+
+// NewHTTPClient returns a client for downloads and lookups on the internet, see NewHTTPTransport.
+// A timeout of 0 means no timeout.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: NewHTTPTransport()} //nolint:exhaustruct
+}
@@ -30,8 +30,6 @@ import (
 	"github.com/dapr/cli/pkg/print"
 
 	"github.com/docker/docker/client"
-	"github.com/gocarina/gocsv"
-	"github.com/olekukonko/tablewriter"
 	"gopkg.in/yaml.v2"
 )
 
@@ -46,15 +44,7 @@ func PrintTable(csvContent string) {
 
 // WriteTable writes the csv table to writer.
 func WriteTable(writer io.Writer, csvContent string) {
-	table := tablewriter.NewWriter(writer)
-	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
-	table.SetBorder(false)
-	table.SetHeaderLine(false)
-	table.SetRowLine(false)
-	table.SetCenterSeparator("")
-	table.SetRowSeparator("")
-	table.SetColumnSeparator("")
-	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table := print.TableWriter(writer)
 	scanner := bufio.NewScanner(strings.NewReader(csvContent))
 	header := true
 
@@ -184,13 +174,7 @@ func IsDaprListeningOnSocket(socket string, timeout time.Duration) error {
 }
 
 func MarshalAndWriteTable(writer io.Writer, in interface{}) error {
-	table, err := gocsv.MarshalString(in)
-	if err != nil {
-		return err
-	}
-
-	WriteTable(writer, table)
-	return nil
+	return print.WriteTable(writer, in, false)
 }
 
 func PrintDetail(writer io.Writer, outputFormat string, list interface{}) error {